@@ -101,6 +101,12 @@ const (
 	// - "False" means the member agent is unhealthy.
 	// - "Unknown" means the member agent has an unknown health status.
 	AgentHealthy AgentConditionType = "Healthy"
+	// AgentMemberClusterConnectivity indicates whether the member agent can reach the API server of the member cluster.
+	// Its condition status can be one of the following:
+	// - "True" means the member cluster API server is reachable.
+	// - "False" means the member cluster API server is not reachable.
+	// - "Unknown" means the member agent has not probed the member cluster API server yet.
+	AgentMemberClusterConnectivity AgentConditionType = "MemberClusterConnectivity"
 )
 
 const (
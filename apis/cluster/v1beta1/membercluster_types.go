@@ -169,6 +169,20 @@ const (
 	// - "False" means the cluster property collection has failed.
 	// - "Unknown" means it is unknown whether the cluster property collection has succeeded or not.
 	ConditionTypeClusterPropertyCollectionSucceeded MemberClusterConditionType = "ClusterPropertyCollectionSucceeded"
+
+	// ConditionTypeMemberAgentReachable indicates whether the member agent keeps renewing its heartbeat in the
+	// AgentHeartbeatAnnotation of the member cluster.
+	// Its condition status can be one of the following:
+	// - "True" means the latest heartbeat is recent.
+	// - "False" means the latest heartbeat is too old, i.e., the member agent is down or cannot reach the hub cluster.
+	// - "Unknown" means the heartbeat cannot be read.
+	ConditionTypeMemberAgentReachable MemberClusterConditionType = "MemberAgentReachable"
+)
+
+const (
+	// AgentHeartbeatAnnotation is the annotation on a member cluster which the member agent periodically sets to the
+	// time of its latest heartbeat in RFC 3339 format.
+	AgentHeartbeatAnnotation = "fleet.azure.com/agent-heartbeat"
 )
 
 //+kubebuilder:object:root=true
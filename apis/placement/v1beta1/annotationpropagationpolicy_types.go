@@ -0,0 +1,78 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AnnotationValueTransformPrefix is the prefix of the value transforms which prepend a string to the value.
+	AnnotationValueTransformPrefix = "prefix:"
+	// AnnotationValueTransformSuffix is the prefix of the value transforms which append a string to the value.
+	AnnotationValueTransformSuffix = "suffix:"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,categories={fleet,fleet-placement}
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name="Age",type=date
+
+// AnnotationPropagationPolicy routes the annotations of all the works in its namespace to the resources they apply,
+// without configuring every work. The annotations listed in the propagateAnnotations of a work take precedence over
+// the policies. When more than one policy in the namespace routes the same annotation to a resource, the policy whose
+// name sorts last wins.
+type AnnotationPropagationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the desired state of the policy.
+	// +required
+	Spec AnnotationPropagationPolicySpec `json:"spec"`
+}
+
+// AnnotationPropagationPolicySpec defines the desired state of AnnotationPropagationPolicy.
+type AnnotationPropagationPolicySpec struct {
+	// Rules are the annotation routing rules of the policy.
+	// +kubebuilder:validation:MaxItems=100
+	// +optional
+	Rules []AnnotationRule `json:"rules,omitempty"`
+}
+
+// AnnotationRule routes an annotation of the works to the resources of the given kinds.
+type AnnotationRule struct {
+	// AnnotationKey is the key of the annotation of the works to propagate. The rule does nothing for the works
+	// without the annotation.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	AnnotationKey string `json:"annotationKey"`
+
+	// PropagateToGVKs are the kinds of the resources the annotation is added to, formatted as group/version/kind,
+	// e.g. `apps/v1/Deployment`, or version/kind for the core group, e.g. `v1/ConfigMap`. The annotation is added to
+	// the resources of every kind if empty.
+	// +optional
+	PropagateToGVKs []string `json:"propagateToGVKs,omitempty"`
+
+	// ValueTransform transforms the value of the annotation before it is added to the resources: `prefix:{string}`
+	// prepends the string to the value and `suffix:{string}` appends it. The value is kept as is if empty.
+	// +kubebuilder:validation:Pattern=`^((prefix|suffix):.*)?$`
+	// +optional
+	ValueTransform string `json:"valueTransform,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AnnotationPropagationPolicyList contains a list of AnnotationPropagationPolicy.
+type AnnotationPropagationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AnnotationPropagationPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AnnotationPropagationPolicy{}, &AnnotationPropagationPolicyList{})
+}
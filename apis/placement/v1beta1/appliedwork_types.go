@@ -64,6 +64,16 @@ type AppliedResourceMeta struct {
 	// It is not directly settable by a client.
 	// +optional
 	UID types.UID `json:"uid,omitempty"`
+
+	// RotationGeneration is the value of the secret rotation generation annotation of the applied Secret manifest.
+	// It is only set for Secret resources that carry the annotation.
+	// +optional
+	RotationGeneration int `json:"rotationGeneration,omitempty"`
+
+	// AppliedNamespace is the namespace the resource is applied to when its manifest is routed to a namespace other
+	// than its own; the resource is in the namespace of the identifier if it is empty.
+	// +optional
+	AppliedNamespace string `json:"appliedNamespace,omitempty"`
 }
 
 // +genclient
@@ -66,6 +66,11 @@ type ResourceBindingSpec struct {
 	// and is owned by other appliers.
 	// +optional
 	ApplyStrategy *ApplyStrategy `json:"applyStrategy,omitempty"`
+
+	// CrossClusterDependencies are the works for other member clusters which must reach the required condition before
+	// the works of the binding are created.
+	// +optional
+	CrossClusterDependencies []CrossClusterDependency `json:"crossClusterDependencies,omitempty"`
 }
 
 // BindingState is the state of the binding.
@@ -144,6 +149,13 @@ const (
 	// - "False" means not all the resources are available in the target cluster yet.
 	// - "Unknown" means we haven't finished the apply yet so that we cannot check the resource availability.
 	ResourceBindingAvailable ResourceBindingConditionType = "Available"
+
+	// ResourceBindingWaitingForDependency indicates whether the works of the binding wait for their cross cluster
+	// dependencies before they are created.
+	// Its condition status can be one of the following:
+	// - "True" means some dependencies are not met yet so that the works are not created.
+	// - "False" means all the dependencies are met.
+	ResourceBindingWaitingForDependency ResourceBindingConditionType = "WaitingForDependency"
 )
 
 // ClusterResourceBindingList is a collection of ClusterResourceBinding.
@@ -0,0 +1,108 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,categories={fleet,fleet-placement},shortName=bw
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="WorkSynchronized")].status`,name="WorkSynchronized",type=string
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name="Age",type=date
+
+// BroadcastWork places the same workload on all the member clusters matching its cluster selector.
+// The broadcast work controller creates a Work object named `{broadcastWorkName}-{clusterName}` in the reserved
+// namespace of every selected cluster and aggregates the Work statuses back into the BroadcastWork status.
+type BroadcastWork struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// The desired state of BroadcastWork.
+	// +required
+	Spec BroadcastWorkSpec `json:"spec"`
+
+	// The observed status of BroadcastWork.
+	// +optional
+	Status BroadcastWorkStatus `json:"status,omitempty"`
+}
+
+// BroadcastWorkSpec defines the desired state of BroadcastWork.
+type BroadcastWorkSpec struct {
+	// ClusterSelector selects the member clusters to place the workload on.
+	// An empty list of cluster selector terms selects all the member clusters.
+	// +required
+	ClusterSelector *ClusterSelector `json:"clusterSelector"`
+
+	// Workload represents the manifest workload to be deployed on the selected clusters.
+	// +optional
+	Workload WorkloadTemplate `json:"workload,omitempty"`
+
+	// ApplyStrategy describes how to resolve the conflict if the resource to be placed already exists in the target cluster
+	// and is owned by other appliers.
+	// +optional
+	ApplyStrategy *ApplyStrategy `json:"applyStrategy,omitempty"`
+}
+
+// BroadcastWorkStatus defines the observed state of BroadcastWork.
+type BroadcastWorkStatus struct {
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+
+	// Conditions is an array of current observed conditions for BroadcastWork.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ClusterStatuses contains the status of the Work object created for each selected cluster, keyed by the
+	// cluster name.
+	// +optional
+	ClusterStatuses map[string]WorkStatus `json:"clusterStatuses,omitempty"`
+}
+
+// BroadcastWorkConditionType identifies a specific condition of the BroadcastWork.
+type BroadcastWorkConditionType string
+
+const (
+	// BroadcastWorkConditionTypeWorkSynchronized indicates whether the Work objects of all the selected clusters are
+	// synchronized with the BroadcastWork.
+	// Its condition status can be one of the following:
+	// - "True" means the Work objects are created or updated for all the selected clusters and the Work objects of
+	// the clusters that are no longer selected are deleted.
+	// - "False" means the Work objects are not fully synchronized yet.
+	BroadcastWorkConditionTypeWorkSynchronized BroadcastWorkConditionType = "WorkSynchronized"
+)
+
+// +kubebuilder:object:root=true
+
+// BroadcastWorkList contains a list of BroadcastWork.
+type BroadcastWorkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BroadcastWork `json:"items"`
+}
+
+// SetConditions sets the conditions of the BroadcastWork.
+func (b *BroadcastWork) SetConditions(conditions ...metav1.Condition) {
+	for _, c := range conditions {
+		meta.SetStatusCondition(&b.Status.Conditions, c)
+	}
+}
+
+// GetCondition returns the condition of the BroadcastWork.
+func (b *BroadcastWork) GetCondition(conditionType string) *metav1.Condition {
+	return meta.FindStatusCondition(b.Status.Conditions, conditionType)
+}
+
+func init() {
+	SchemeBuilder.Register(&BroadcastWork{}, &BroadcastWorkList{})
+}
@@ -410,13 +410,14 @@ const (
 
 // RolloutStrategy describes how to roll out a new change in selected resources to target clusters.
 type RolloutStrategy struct {
-	// Type of rollout. The only supported type is "RollingUpdate". Default is "RollingUpdate".
+	// Type of rollout. The supported types are "RollingUpdate" and "AllAtOnce". Default is "RollingUpdate".
 	// +optional
-	// +kubebuilder:validation:Enum=RollingUpdate
+	// +kubebuilder:validation:Enum=RollingUpdate;AllAtOnce
 	// +kubebuilder:default=RollingUpdate
 	Type RolloutStrategyType `json:"type,omitempty"`
 
 	// Rolling update config params. Present only if RolloutStrategyType = RollingUpdate.
+	// The MaxUnavailable and MaxSurge limits are ignored if RolloutStrategyType = AllAtOnce.
 	// +optional
 	RollingUpdate *RollingUpdateConfig `json:"rollingUpdate,omitempty"`
 
@@ -424,6 +425,12 @@ type RolloutStrategy struct {
 	// and is owned by other appliers.
 	// +optional
 	ApplyStrategy *ApplyStrategy `json:"applyStrategy,omitempty"`
+
+	// CrossClusterDependencies are the works for other member clusters which must reach the required condition before
+	// the works of the placement are created, e.g. the database of an application placed on another cluster must be
+	// applied before the application itself is placed.
+	// +optional
+	CrossClusterDependencies []CrossClusterDependency `json:"crossClusterDependencies,omitempty"`
 }
 
 // ApplyStrategy describes how to resolve the conflict if the resource to be placed already exists in the target cluster
@@ -448,6 +455,96 @@ type ApplyStrategy struct {
 	// ServerSideApplyConfig defines the configuration for server side apply. It is honored only when type is ServerSideApply.
 	// +optional
 	ServerSideApplyConfig *ServerSideApplyConfig `json:"serverSideApplyConfig,omitempty"`
+
+	// ShadowApply defines whether to apply the resources to a shadow namespace named `{namespace}-shadow` in the target
+	// cluster instead of their own namespace, so that the changes can be validated without affecting the real resources.
+	// Namespace resources are renamed to their shadow namespace and other cluster scoped resources fail to apply.
+	// +optional
+	ShadowApply bool `json:"shadowApply,omitempty"`
+
+	// RequireApproval defines whether the changes to the resources must be approved before they are applied.
+	// If true, the work applier only performs a server-side dry-run apply of the manifests and reports the changes in
+	// the pendingApprovalDiff of the work status. The changes are applied once the work is annotated with
+	// `fleet.azure.com/approved: "true"`, after which the annotation is removed.
+	// +optional
+	RequireApproval bool `json:"requireApproval,omitempty"`
+
+	// ReportAdditionalResources defines whether to report the resources which are owned by the applied work but are
+	// not in its manifests. If true, the work applier lists the resources of the same kinds in the namespaces of the
+	// manifests and reports the ones with the owner reference of the applied work in the additionalResources of the
+	// work status.
+	// +optional
+	ReportAdditionalResources bool `json:"reportAdditionalResources,omitempty"`
+
+	// IgnoreAnnotationKeys are the keys of the annotations whose changes are excluded when the work applier compares
+	// the resources in the member cluster with the manifests, e.g. for the changes pending approval or the dry-run
+	// results. They only match the annotations, not the labels or any other field.
+	// Defaults to `kubectl.kubernetes.io/last-applied-configuration`, which changes on every `kubectl apply`, if not set.
+	// +optional
+	IgnoreAnnotationKeys []string `json:"ignoreAnnotationKeys,omitempty"`
+
+	// IgnorePaths are the JSON pointers (RFC 6901), e.g. `/spec/replicas`, of the fields which are excluded when the
+	// work applier compares the resources in the member cluster with the manifests, both for detecting the drifts of
+	// the resources and for the changes pending approval or the dry-run results. A resource whose only drifts are in
+	// the ignored fields, e.g. the replicas managed by a HorizontalPodAutoscaler, is not applied again to correct them.
+	// +kubebuilder:validation:items:Pattern=`^(/([^/~]|~[01])*)+$`
+	// +optional
+	IgnorePaths []string `json:"ignorePaths,omitempty"`
+
+	// ComparisonOption defines how the work applier compares the resources in the target cluster with their manifests,
+	// i.e. to tell whether a resource drifted from its manifest and which of its fields differ. Default to
+	// PartialComparison.
+	// +kubebuilder:default=PartialComparison
+	// +kubebuilder:validation:Enum=PartialComparison;SemanticComparison
+	// +optional
+	ComparisonOption ComparisonOptionType `json:"comparisonOption,omitempty"`
+
+	// TriggerRollingRestartOnAnnotationUpdate defines whether to restart the pods of a Deployment when only the
+	// annotations of the Deployment change, which does not trigger a rollout on its own.
+	// If true, the work applier sets the `kubectl.kubernetes.io/restartedAt` annotation of the pod template to the
+	// current time after applying a Deployment manifest whose annotations change but whose spec does not.
+	// +optional
+	TriggerRollingRestartOnAnnotationUpdate bool `json:"triggerRollingRestartOnAnnotationUpdate,omitempty"`
+
+	// BatchSize defines how many manifests of a work are applied at a time. If set, the work applier splits the
+	// manifests, in the order they are applied in, into batches of this size and applies the next batch only after
+	// all the manifests of the previous batches are applied; the progress is reported in the rolloutProgress of the
+	// work status. All the manifests are applied at once if not set.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	BatchSize int `json:"batchSize,omitempty"`
+
+	// AllowRollback defines whether to apply a manifest of a work whose content goes back to an earlier version, e.g.
+	// when the hub cluster restores an older spec of the work. If false, the work applier keeps the resource at the
+	// version it is pinned to and reports the rollback in the ManifestVersionRollbackDetected condition of the work.
+	// +optional
+	AllowRollback bool `json:"allowRollback,omitempty"`
+
+	// WhenToApply defines when the work applier applies the manifests to the resources in the target cluster.
+	// Default to Always.
+	// +kubebuilder:default=Always
+	// +kubebuilder:validation:Enum=Always;Once
+	// +optional
+	WhenToApply WhenToApplyType `json:"whenToApply,omitempty"`
+
+	// StuckThreshold defines how long the Available condition of a manifest can stay false before the manifest is
+	// reported as stuck in its Stuck condition, which the Stuck condition of the work rolls up. The manifests are not
+	// checked for being stuck if not set.
+	// +optional
+	StuckThreshold *metav1.Duration `json:"stuckThreshold,omitempty"`
+
+	// DryRunBeforeApply defines whether to perform a server-side dry-run of every apply before the real one, so that
+	// a manifest which the API server would reject fails without touching the resource in the target cluster. The
+	// dry-run is skipped for a manifest which is unchanged since its last successful apply. It is honored only when
+	// type is ServerSideApply.
+	// +optional
+	DryRunBeforeApply bool `json:"dryRunBeforeApply,omitempty"`
+
+	// PausedUntil pauses applying the manifests until the given time, e.g. during a maintenance window of the target
+	// cluster, without deleting the work. The drifts of the resources in the target cluster are not corrected while
+	// the work is paused. The work is applied as usual once the time passes.
+	// +optional
+	PausedUntil *metav1.Time `json:"pausedUntil,omitempty"`
 }
 
 // ApplyStrategyType describes the type of the strategy used to resolve the conflict if the resource to be placed already
@@ -467,6 +564,38 @@ const (
 	ApplyStrategyTypeServerSideApply ApplyStrategyType = "ServerSideApply"
 )
 
+// WhenToApplyType describes when the work applier applies the manifests to the resources in the target cluster.
+// +enum
+type WhenToApplyType string
+
+const (
+	// WhenToApplyTypeAlways will apply the manifests on every reconcile, so that the changes made to the resources in
+	// the target cluster outside of fleet are corrected.
+	WhenToApplyTypeAlways WhenToApplyType = "Always"
+
+	// WhenToApplyTypeOnce will apply the manifests only to create the resources which do not exist in the target
+	// cluster yet. The resources created by fleet are never updated afterward, e.g. the seed data or the bootstrap
+	// secrets whose later changes in the target cluster must be kept, and the drift from their manifests is never
+	// corrected. The resources are still tracked for their availability and deleted with the work.
+	WhenToApplyTypeOnce WhenToApplyType = "Once"
+)
+
+// ComparisonOptionType describes how the work applier compares the resources in the target cluster with their
+// manifests.
+// +enum
+type ComparisonOptionType string
+
+const (
+	// ComparisonOptionTypePartialComparison will compare the fields set by the manifests as they are, so that the
+	// items of a list in a different order are a difference.
+	ComparisonOptionTypePartialComparison ComparisonOptionType = "PartialComparison"
+
+	// ComparisonOptionTypeSemanticComparison will compare the fields set by the manifests regardless of the order of
+	// the items in the list fields whose items can be identified, e.g. the environment variables of the containers
+	// which Kubernetes may reorder, so that reordering the items is not a difference.
+	ComparisonOptionTypeSemanticComparison ComparisonOptionType = "SemanticComparison"
+)
+
 // ServerSideApplyConfig defines the configuration for server side apply.
 // Details: https://kubernetes.io/docs/reference/using-api/server-side-apply/#conflicts
 type ServerSideApplyConfig struct {
@@ -488,6 +617,10 @@ const (
 	// RollingUpdateRolloutStrategyType replaces the old placed resource using rolling update
 	// i.e. gradually create the new one while replace the old ones.
 	RollingUpdateRolloutStrategyType RolloutStrategyType = "RollingUpdate"
+
+	// AllAtOnceRolloutStrategyType replaces the old placed resource on all the target clusters at the same time
+	// without waiting for the clusters to become available.
+	AllAtOnceRolloutStrategyType RolloutStrategyType = "AllAtOnce"
 )
 
 // RollingUpdateConfig contains the config to control the desired behavior of rolling update.
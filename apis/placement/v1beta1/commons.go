@@ -13,6 +13,10 @@ const (
 	ClusterSchedulingPolicySnapshotKind = "ClusterSchedulingPolicySnapshot"
 	WorkKind                            = "Work"
 	AppliedWorkKind                     = "AppliedWork"
+	BroadcastWorkKind                   = "BroadcastWork"
+	WorkReplicatorKind                  = "WorkReplicator"
+	WorkDRReplicationKind               = "WorkDRReplication"
+	ManifestStoreKind                   = "ManifestStore"
 )
 
 const (
@@ -74,6 +78,54 @@ const (
 	// EnvelopeNameLabel is the label that contains the name of the envelope object that the work is generated from.
 	EnvelopeNameLabel = fleetPrefix + "envelope-name"
 
+	// BroadcastWorkTrackingLabel is the label applied to work that contains the name of the broadcast work that generates the work.
+	BroadcastWorkTrackingLabel = fleetPrefix + "parent-broadcast-work"
+
+	// BroadcastWorkNameFmt is the format of the name of the work generated by a broadcast work.
+	// The format is {broadcastWorkName}-{clusterName}.
+	BroadcastWorkNameFmt = "%s-%s"
+
+	// WorkReplicatorTrackingLabel is the label applied to the work replicas that contains the name of the work
+	// replicator that replicates the work.
+	WorkReplicatorTrackingLabel = fleetPrefix + "parent-work-replicator"
+
+	// ReplicaSourceHubLabel is the label applied to the work replicas that contains the name of the hub cluster the
+	// work is replicated from. The works with this label are never replicated again.
+	ReplicaSourceHubLabel = fleetPrefix + "replica-source-hub"
+
+	// WorkReplicatorFinalizer is added to the work replicators to delete their work replicas on the target hubs
+	// before the replicators are deleted.
+	WorkReplicatorFinalizer = fleetPrefix + "work-replicator-cleanup"
+
+	// WorkDRReplicationTrackingLabel is the label applied to the work replicas on a disaster recovery hub that
+	// contains the name of the WorkDRReplication that replicates the work.
+	WorkDRReplicationTrackingLabel = fleetPrefix + "parent-work-dr-replication"
+
+	// WorkGroupLabel is the label applied to the parts of a split work that contains the name of the WorkGroup which
+	// tracks them.
+	WorkGroupLabel = fleetPrefix + "parent-work-group"
+
+	// WorkPartNameFmt is the format of the name of a part of a split work, which is `{workName}-part-{index}`.
+	// The indexes start from 0.
+	WorkPartNameFmt = "%s-part-%d"
+
+	// ConsolidatedWorkLabel is the label applied to the consolidated works which the work compactor creates to apply
+	// the manifests of many small works at once.
+	ConsolidatedWorkLabel = fleetPrefix + "consolidated-work"
+
+	// ConsolidatedWorkNameFmt is the format of the name of a consolidated work, which is
+	// `compacted-{hash of the shared spec}-{index}`. The indexes start from 0.
+	ConsolidatedWorkNameFmt = "compacted-%s-%d"
+
+	// CompactedIntoAnnotation is the annotation on a work whose manifests are applied by the consolidated work it
+	// names instead of itself. The work applier hands the resources of the work over to the consolidated work once
+	// the consolidated work applies them, and no longer applies the work.
+	CompactedIntoAnnotation = fleetPrefix + "compacted-into"
+
+	// CompactedWorksAnnotation is the annotation on a consolidated work which records the works compacted into it
+	// and the manifests of each of them, as JSON.
+	CompactedWorksAnnotation = fleetPrefix + "compacted-works"
+
 	// PreviousBindingStateAnnotation is the annotation that records the previous state of a binding.
 	// This is used to remember if an "unscheduled" binding was moved from a "bound" state or a "scheduled" state.
 	PreviousBindingStateAnnotation = fleetPrefix + "previous-binding-state"
@@ -0,0 +1,50 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,categories={fleet,fleet-placement}
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name="Age",type=date
+
+// ManifestStore is a content-addressed blob holding a manifest which many works share, e.g. the same RBAC policy
+// placed on every member cluster. Its name is the hex-encoded SHA-256 checksum of the canonical JSON of the manifest,
+// which the works refer to in their manifestBlobRefs. The work applier rejects a blob whose content does not match
+// its name. A blob cannot be deleted while a work refers to it.
+type ManifestStore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds the content of the blob. It is immutable as the blob is addressed by its content.
+	// +required
+	Spec ManifestStoreSpec `json:"spec"`
+}
+
+// ManifestStoreSpec defines the content of a ManifestStore.
+type ManifestStoreSpec struct {
+	// Manifest is the manifest the blob holds.
+	// +required
+	Manifest Manifest `json:"manifest"`
+}
+
+// +kubebuilder:object:root=true
+
+// ManifestStoreList contains a list of ManifestStore.
+type ManifestStoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ManifestStore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ManifestStore{}, &ManifestStoreList{})
+}
@@ -0,0 +1,69 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// MultiClusterWorkSummaryName is the name of the singleton MultiClusterWorkSummary.
+	MultiClusterWorkSummaryName = "fleet-work-summary"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,categories={fleet,fleet-placement}
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name="Age",type=date
+
+// MultiClusterWorkSummary summarizes the statuses of the works of all the member clusters in the fleet.
+// It is a singleton named fleet-work-summary in the fleet system namespace, maintained by the hub agent.
+type MultiClusterWorkSummary struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Clusters are the work stats of the member clusters, keyed by their reserved namespaces.
+	// +optional
+	Clusters map[string]ClusterWorkStats `json:"clusters,omitempty"`
+}
+
+// ClusterWorkStats are the stats of the works of a member cluster.
+type ClusterWorkStats struct {
+	// TotalWorks is the number of the works of the cluster.
+	// +required
+	TotalWorks int `json:"totalWorks"`
+
+	// AppliedWorks is the number of the works whose current spec is applied.
+	// +required
+	AppliedWorks int `json:"appliedWorks"`
+
+	// DriftedWorks is the number of the applied works whose resources drifted in the member cluster since their
+	// current spec was applied.
+	// +required
+	DriftedWorks int `json:"driftedWorks"`
+
+	// FailedWorks is the number of the works whose current spec fails to apply.
+	// +required
+	FailedWorks int `json:"failedWorks"`
+
+	// LastUpdated is when the stats of the cluster last changed.
+	// +required
+	LastUpdated metav1.Time `json:"lastUpdated"`
+}
+
+// +kubebuilder:object:root=true
+
+// MultiClusterWorkSummaryList contains a list of MultiClusterWorkSummary.
+type MultiClusterWorkSummaryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MultiClusterWorkSummary `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MultiClusterWorkSummary{}, &MultiClusterWorkSummaryList{})
+}
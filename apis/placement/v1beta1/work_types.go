@@ -22,6 +22,7 @@ Licensed under the MIT license.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -34,11 +35,79 @@ const (
 	// LastAppliedConfigAnnotation is to record the last applied configuration on the object.
 	LastAppliedConfigAnnotation = fleetPrefix + "last-applied-configuration"
 
+	// SecretRotationGenerationAnnotation is the annotation on a Secret manifest that records how many times its content
+	// has been rotated. Incrementing the value forces the secret to be re-applied even if its spec hash is unchanged.
+	SecretRotationGenerationAnnotation = fleetPrefix + "secret-rotation-generation"
+
+	// ForceResyncAnnotation is the annotation on a Work that records when a forced resync of the Work was last
+	// requested. The work applier applies every manifest of the Work again once per new value, even if its spec hash is
+	// unchanged.
+	ForceResyncAnnotation = fleetPrefix + "force-resync"
+
 	// WorkConditionTypeApplied represents workload in Work is applied successfully on the spoke cluster.
 	WorkConditionTypeApplied = "Applied"
 
 	// WorkConditionTypeAvailable represents workload in Work is available on the spoke cluster.
 	WorkConditionTypeAvailable = "Available"
+
+	// WorkConditionTypeGCDryRunCompleted represents the resources which the deletion of the Work would garbage collect
+	// on the spoke cluster are listed in the status instead of being deleted.
+	WorkConditionTypeGCDryRunCompleted = "GCDryRunCompleted"
+
+	// WorkConditionTypeDryRunCompleted represents the manifests in Work are dry-run applied on the spoke cluster
+	// instead of being applied and the results are in the status.
+	WorkConditionTypeDryRunCompleted = "DryRunCompleted"
+
+	// WorkConditionTypeSpecGrowthWarning represents that the Work spec grew by more than the allowed ratio in a single
+	// update, which usually means some large data is embedded in the manifests by accident.
+	WorkConditionTypeSpecGrowthWarning = "SpecGrowthWarning"
+
+	// WorkConditionTypeDuplicateManifestsRemoved represents that some manifests in Work describe the same resource as
+	// a manifest before them and are not applied.
+	WorkConditionTypeDuplicateManifestsRemoved = "DuplicateManifestsRemoved"
+
+	// WorkConditionTypeManifestVersionRollbackDetected represents that some manifests in Work went back to the content
+	// of an earlier version, e.g. when the hub cluster restores an older spec of the work.
+	WorkConditionTypeManifestVersionRollbackDetected = "ManifestVersionRollbackDetected"
+
+	// WorkConditionTypeStuck represents that some manifests in Work have not been available for longer than the
+	// stuck threshold of the apply strategy. The manifest conditions have the condition of the same type.
+	WorkConditionTypeStuck = "Stuck"
+
+	// WorkConditionTypeCompacted represents that the manifests in Work are applied by the consolidated Work it is
+	// compacted into, whose status is reflected in the status of the Work.
+	WorkConditionTypeCompacted = "Compacted"
+
+	// WorkConditionTypePaused represents that the manifests in Work are not applied, nor are the drifts of their
+	// resources corrected, until the PausedUntil time of the apply strategy.
+	WorkConditionTypePaused = "Paused"
+
+	// MaxWorkRecentEvents is the maximum number of the recent events kept in the work status.
+	MaxWorkRecentEvents = 20
+
+	// MaxManifestApplyHistory is the maximum number of the apply history entries kept for a manifest.
+	MaxManifestApplyHistory = 5
+
+	// MaxManifestVersionHistory is the maximum number of the content versions kept for a manifest.
+	MaxManifestVersionHistory = 5
+
+	// MaxConditionHistory is the maximum number of the transitions kept for each condition type of a work.
+	MaxConditionHistory = 10
+
+	// WorkEventTypeSpecChanged is the event of the work applier observing a new generation of the work spec.
+	WorkEventTypeSpecChanged = "SpecChanged"
+
+	// WorkEventTypeManifestApplied is the event of a manifest being applied to its resource.
+	WorkEventTypeManifestApplied = "ManifestApplied"
+
+	// WorkEventTypeDriftFound is the event of a resource found changed in the member cluster since it was last applied.
+	WorkEventTypeDriftFound = "DriftFound"
+
+	// WorkEventTypeGarbageCollected is the event of the resources no longer in the work being deleted.
+	WorkEventTypeGarbageCollected = "GarbageCollected"
+
+	// WorkEventTypeError is the event of a manifest failing to be applied.
+	WorkEventTypeError = "Error"
 )
 
 // This api is copied from https://github.com/kubernetes-sigs/work-api/blob/master/pkg/apis/v1alpha1/work_types.go.
@@ -53,6 +122,138 @@ type WorkSpec struct {
 	// and is owned by other appliers.
 	// +optional
 	ApplyStrategy *ApplyStrategy `json:"applyStrategy,omitempty"`
+
+	// PropagateAnnotations is a list of annotation keys on the Work object whose key-value pairs are added to
+	// the metadata of every resource applied by the Work.
+	// +optional
+	PropagateAnnotations []string `json:"propagateAnnotations,omitempty"`
+
+	// Compressed indicates the manifests are stored gzip-compressed in workload.compressedManifests instead of
+	// workload.manifests. It is set by the mutating webhook for the works whose manifests exceed the size threshold.
+	// +optional
+	Compressed bool `json:"compressed,omitempty"`
+
+	// MemberAPITimeoutSeconds is the time limit, in seconds, of applying the manifests to the member cluster in one
+	// reconcile. The manifests which are not processed before the time limit are left pending until the next reconcile.
+	// Defaults to 30 seconds.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=300
+	// +kubebuilder:default=30
+	// +optional
+	MemberAPITimeoutSeconds *int64 `json:"memberAPITimeoutSeconds,omitempty"`
+
+	// DefaultPriorityClassName is the PriorityClass set on the pod templates of the Deployment, StatefulSet,
+	// DaemonSet and Job manifests which do not set one themselves. The PriorityClass must exist in the member cluster.
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	DefaultPriorityClassName string `json:"defaultPriorityClassName,omitempty"`
+
+	// HealthPolicyRef refers to the WorkHealthPolicy in the namespace of the work whose health criteria the work
+	// inherits. A missing policy is treated as an empty one.
+	// +optional
+	HealthPolicyRef *corev1.LocalObjectReference `json:"healthPolicyRef,omitempty"`
+
+	// HealthCriteria overrides the health criteria inherited from the referenced WorkHealthPolicy.
+	// +optional
+	HealthCriteria *WorkHealthCriteria `json:"healthCriteria,omitempty"`
+
+	// ReadinessGates are the conditions which must be set on the work before its manifests are applied, so that
+	// external systems can control when the work is rolled out by setting the conditions in the work status.
+	// +optional
+	ReadinessGates []WorkReadinessGate `json:"readinessGates,omitempty"`
+
+	// NotBeforeTime is the start of the maintenance window of the work; the manifests are not applied before it.
+	// +optional
+	NotBeforeTime *metav1.Time `json:"notBeforeTime,omitempty"`
+
+	// NotAfterTime is the end of the maintenance window of the work; the manifests are no longer applied after it.
+	// +optional
+	NotAfterTime *metav1.Time `json:"notAfterTime,omitempty"`
+
+	// ValidationSchemas are the JSON schemas which the manifests of the given kinds are validated against before they
+	// are applied. A manifest which does not match its schema is not applied.
+	// +optional
+	ValidationSchemas []ValidationSchemaRef `json:"validationSchemas,omitempty"`
+
+	// CrossClusterDependencies are the works for other member clusters which must reach the required condition
+	// before the work is created. They are checked by the hub agent which generates the work and are kept in the spec
+	// for reference only.
+	// +optional
+	CrossClusterDependencies []CrossClusterDependency `json:"crossClusterDependencies,omitempty"`
+
+	// TerminationPolicy defines what happens to the resources applied by the work when the work is deleted.
+	// Defaults to Delete.
+	// +kubebuilder:default=Delete
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	// +optional
+	TerminationPolicy TerminationPolicyType `json:"terminationPolicy,omitempty"`
+}
+
+// TerminationPolicyType describes what happens to the resources applied by a work when the work is deleted.
+// +enum
+type TerminationPolicyType string
+
+const (
+	// TerminationPolicyTypeDelete deletes the resources applied by the work along with the work.
+	TerminationPolicyTypeDelete TerminationPolicyType = "Delete"
+
+	// TerminationPolicyTypeOrphan leaves the resources applied by the work in the member cluster when the work is
+	// deleted; the owner references to the AppliedWork of the work are removed from them so that they are not
+	// garbage collected.
+	TerminationPolicyTypeOrphan TerminationPolicyType = "Orphan"
+)
+
+// CrossClusterDependency refers to a work for another member cluster which a work depends on.
+type CrossClusterDependency struct {
+	// ClusterNamespace is the namespace of the member cluster of the work on the hub cluster, e.g. `fleet-member-cluster-a`.
+	// +required
+	ClusterNamespace string `json:"clusterNamespace"`
+
+	// WorkName is the name of the work.
+	// +required
+	WorkName string `json:"workName"`
+
+	// RequiredCondition is the type of the condition which must be true in the work status for the observed generation
+	// of the work. Defaults to Applied.
+	// +kubebuilder:default=Applied
+	// +optional
+	RequiredCondition string `json:"requiredCondition,omitempty"`
+}
+
+// ValidationSchemaRef refers to the JSON schema of the manifests of a kind, which is stored in a ConfigMap in the
+// namespace of the work.
+type ValidationSchemaRef struct {
+	// Group is the API group of the manifests; it is empty for the core group.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Version is the API version of the manifests.
+	// +required
+	Version string `json:"version"`
+
+	// Kind is the kind of the manifests.
+	// +required
+	Kind string `json:"kind"`
+
+	// ConfigMapName is the name of the ConfigMap which holds the schema.
+	// +required
+	ConfigMapName string `json:"configMapName"`
+
+	// Key is the key of the ConfigMap data whose value is the schema in JSON.
+	// +required
+	Key string `json:"key"`
+}
+
+// WorkReadinessGate is satisfied when the work status carries a condition of the given type and status.
+type WorkReadinessGate struct {
+	// ConditionType is the type of the condition in the work status.
+	// +kubebuilder:validation:Required
+	ConditionType string `json:"conditionType"`
+
+	// ConditionStatus is the status the condition must have.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=True;False;Unknown
+	ConditionStatus metav1.ConditionStatus `json:"conditionStatus"`
 }
 
 // WorkloadTemplate represents the manifest workload to be deployed on spoke cluster
@@ -60,6 +261,40 @@ type WorkloadTemplate struct {
 	// Manifests represents a list of kuberenetes resources to be deployed on the spoke cluster.
 	// +optional
 	Manifests []Manifest `json:"manifests,omitempty"`
+
+	// ManifestRetryPolicies configures how the apply errors of individual manifests are retried.
+	// The manifests without a retry policy are retried until they are applied.
+	// +optional
+	ManifestRetryPolicies []ManifestRetryPolicy `json:"manifestRetryPolicies,omitempty"`
+
+	// ManifestTargetNamespaces routes the manifests with the given ordinals to a namespace other than their own
+	// metadata.namespace, so that the same manifest can be placed in different namespaces on different clusters.
+	// +optional
+	ManifestTargetNamespaces []ManifestTargetNamespace `json:"manifestTargetNamespaces,omitempty"`
+
+	// ManifestBinaryData carries the binary contents of the manifests with the given ordinals, which are merged into
+	// the binary fields of the decoded manifests before they are applied, e.g. the data of a Secret.
+	// +optional
+	ManifestBinaryData []ManifestBinaryData `json:"manifestBinaryData,omitempty"`
+
+	// ManifestHooks are the webhooks called before and after the manifests with the given ordinals are applied.
+	// +optional
+	ManifestHooks []ManifestHooks `json:"manifestHooks,omitempty"`
+
+	// ManifestBlobRefs replace the manifests with the given ordinals with the content of the ManifestStore blobs they
+	// refer to, so that the identical manifests of many works are stored on the hub cluster only once.
+	// +optional
+	ManifestBlobRefs []ManifestBlobRef `json:"manifestBlobRefs,omitempty"`
+
+	// CompressedManifests is the gzip-compressed JSON of the manifests list; it is honored only when the work spec is
+	// compressed.
+	// +optional
+	CompressedManifests []byte `json:"compressedManifests,omitempty"`
+
+	// ManifestChecksums are the hex-encoded SHA-256 checksums of the canonical JSON of the manifests, in the order of
+	// the manifests. They are used to detect the manifests corrupted in storage; a work without checksums is not checked.
+	// +optional
+	ManifestChecksums []string `json:"manifestChecksums,omitempty"`
 }
 
 // Manifest represents a resource to be deployed on spoke cluster.
@@ -69,6 +304,132 @@ type Manifest struct {
 	runtime.RawExtension `json:",inline"`
 }
 
+// ManifestRetryPolicy is the retry policy of the manifest with the given ordinal.
+// The retry policy, like the other per-manifest settings of the work, is kept outside the Manifest as the manifest
+// is serialized as the raw resource.
+type ManifestRetryPolicy struct {
+	// Ordinal is the index of the manifest in the manifests list.
+	// +kubebuilder:validation:Minimum=0
+	// +required
+	Ordinal int `json:"ordinal"`
+
+	// RetryPolicy is the retry policy of the manifest.
+	// +required
+	RetryPolicy RetryPolicy `json:"retryPolicy"`
+}
+
+// ManifestTargetNamespace is the namespace the manifest with the given ordinal is applied to.
+// The target namespace replaces the metadata.namespace of the manifest when the manifest is applied.
+type ManifestTargetNamespace struct {
+	// Ordinal is the index of the manifest in the manifests list.
+	// +kubebuilder:validation:Minimum=0
+	// +required
+	Ordinal int `json:"ordinal"`
+
+	// TargetNamespace is the namespace the manifest is applied to instead of its own metadata.namespace.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +required
+	TargetNamespace string `json:"targetNamespace"`
+}
+
+// ManifestBinaryData is the binary content of the manifest with the given ordinal, which may not be valid UTF-8.
+// The binary data is merged into the binary fields of the decoded manifest, e.g. the data of a Secret.
+type ManifestBinaryData struct {
+	// Ordinal is the index of the manifest in the manifests list.
+	// +kubebuilder:validation:Minimum=0
+	// +required
+	Ordinal int `json:"ordinal"`
+
+	// BinaryData are the binary values keyed by their field names. They are merged into the data of a Secret and the
+	// binaryData of a ConfigMap; the manifests of the other kinds have no binary fields.
+	// +required
+	BinaryData map[string][]byte `json:"binaryData"`
+}
+
+// ManifestBlobRef refers the manifest with the given ordinal to a ManifestStore blob which holds its content. The
+// manifest in the manifests list is a stub which should carry the apiVersion, kind and metadata of the resource; the
+// work applier replaces it with the content of the blob before applying it.
+type ManifestBlobRef struct {
+	// Ordinal is the index of the manifest in the manifests list.
+	// +kubebuilder:validation:Minimum=0
+	// +required
+	Ordinal int `json:"ordinal"`
+
+	// BlobRef is the hex-encoded SHA-256 checksum of the canonical JSON of the manifest content, which is the name of
+	// the ManifestStore holding the content.
+	// +kubebuilder:validation:Pattern=`^[a-f0-9]{64}$`
+	// +required
+	BlobRef string `json:"blobRef"`
+}
+
+// ManifestHooks are the webhooks called before and after the manifest with the given ordinal is applied, e.g. to
+// notify a service mesh control plane. The hooks are called only when the manifest changes since it was last applied,
+// or a forced resync is requested, so that a steady manifest does not trigger their side effects on every apply.
+type ManifestHooks struct {
+	// Ordinal is the index of the manifest in the manifests list.
+	// +kubebuilder:validation:Minimum=0
+	// +required
+	Ordinal int `json:"ordinal"`
+
+	// PreApply is called with the manifest JSON before the manifest is applied; the manifest is applied only if the
+	// hook responds with 200.
+	// +optional
+	PreApply *HookRef `json:"preApply,omitempty"`
+
+	// PostApply is called with the applied resource JSON after the manifest is applied successfully.
+	// +optional
+	PostApply *HookRef `json:"postApply,omitempty"`
+}
+
+// HookRef refers to a webhook which is called with a POST request of a JSON payload.
+type HookRef struct {
+	// WebhookURL is the URL of the webhook.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +required
+	WebhookURL string `json:"webhookURL"`
+
+	// TimeoutSeconds is how long to wait for the webhook to respond. Defaults to 10 seconds.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=30
+	// +kubebuilder:default=10
+	// +optional
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+}
+
+// RetryPolicy describes how the apply errors of a manifest are retried.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries of the manifest for the same generation of the work.
+	// +kubebuilder:validation:Minimum=0
+	// +required
+	MaxRetries int `json:"maxRetries"`
+
+	// RetryOn is the list of errors to retry; the other errors are not retried.
+	// +optional
+	RetryOn []ErrorCode `json:"retryOn,omitempty"`
+
+	// BackoffSeconds is the time to wait before the next retry.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=5
+	// +optional
+	BackoffSeconds int32 `json:"backoffSeconds,omitempty"`
+}
+
+// ErrorCode is the category of an error returned by the member cluster API server when applying a manifest.
+// +kubebuilder:validation:Enum=Conflict;Forbidden;Timeout
+type ErrorCode string
+
+const (
+	// ErrorCodeConflict is the error returned when the resource is modified concurrently.
+	ErrorCodeConflict ErrorCode = "Conflict"
+
+	// ErrorCodeForbidden is the error returned when the applier is not allowed to modify the resource.
+	ErrorCodeForbidden ErrorCode = "Forbidden"
+
+	// ErrorCodeTimeout is the error returned when the request times out.
+	ErrorCodeTimeout ErrorCode = "Timeout"
+)
+
 // WorkStatus defines the observed state of Work.
 type WorkStatus struct {
 	// Conditions contains the different condition statuses for this work.
@@ -84,6 +445,283 @@ type WorkStatus struct {
 	// spoke cluster.
 	// +optional
 	ManifestConditions []ManifestCondition `json:"manifestConditions,omitempty"`
+
+	// LastGoodStatus is a snapshot of the work status taken the last time the work was both applied and available.
+	// It is not overwritten when a later spec change fails to apply or become available, so that the last known
+	// good state stays visible next to the current failure.
+	// +optional
+	LastGoodStatus *WorkStatusSnapshot `json:"lastGoodStatus,omitempty"`
+
+	// DesiredStatePercentage is the percentage of the manifests in the work that are both applied and available.
+	// A work without any manifest is considered to be 100% in the desired state.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	DesiredStatePercentage float32 `json:"desiredStatePercentage"`
+
+	// RolloutProgress is the progress of applying the manifests of the work in batches. It is only reported if the
+	// apply strategy of the work sets a batch size smaller than the number of manifests.
+	// +optional
+	RolloutProgress *RolloutProgress `json:"rolloutProgress,omitempty"`
+
+	// SpecSizeBytes is the size of the serialized work spec in bytes.
+	// +optional
+	SpecSizeBytes int64 `json:"specSizeBytes,omitempty"`
+
+	// StatusSizeBytes is the size of the serialized work status in bytes.
+	// +optional
+	StatusSizeBytes int64 `json:"statusSizeBytes,omitempty"`
+
+	// StatusHash is the SHA-256 hash of the rest of the work status, which the work applier uses to skip the status
+	// updates that would not change anything.
+	// +optional
+	StatusHash string `json:"statusHash,omitempty"`
+
+	// ProcessedResourceVersion is the resource version of the work which the work applier computed the status from.
+	// It is refreshed together with the next status change so that recording it never causes a status update alone.
+	// +optional
+	ProcessedResourceVersion string `json:"processedResourceVersion,omitempty"`
+
+	// PendingApprovalDiff lists the changes a dry-run apply of the manifests would make to the resources in the member
+	// cluster, which wait for approval when the apply strategy requires it.
+	// +optional
+	PendingApprovalDiff []PendingManifestChange `json:"pendingApprovalDiff,omitempty"`
+
+	// PendingGCResources lists the resources which would be garbage collected on the spoke cluster when the work is
+	// deleted with the garbage collection dry-run annotation.
+	// +optional
+	PendingGCResources []WorkResourceIdentifier `json:"pendingGCResources,omitempty"`
+
+	// StatusPageCount is the number of the WorkStatusPages which hold the manifest conditions overflowing
+	// manifestConditions when the status is paginated.
+	// +optional
+	StatusPageCount int `json:"statusPageCount,omitempty"`
+
+	// WorkStatusPageRef lists the names of the WorkStatusPages of the work in the order of the pages.
+	// +optional
+	WorkStatusPageRef []string `json:"workStatusPageRef,omitempty"`
+
+	// DryRunResults are the results of the server-side dry-run applies of the manifests performed instead of applying
+	// them when the work has the pre-apply dry-run annotation.
+	// +optional
+	DryRunResults []ManifestDryRunResult `json:"dryRunResults,omitempty"`
+
+	// AdditionalResources are the resources in the member cluster which are owned by the appliedWork of the work but
+	// are neither in its manifests nor applied by it, e.g. the resources created by the controllers in the member
+	// cluster. They are reported only if the apply strategy asks for them; their ordinals are not set.
+	// +optional
+	AdditionalResources []WorkResourceIdentifier `json:"additionalResources,omitempty"`
+
+	// RecentEvents are the most recent events of applying the work, oldest first. Once the list is full, a new event
+	// displaces the oldest one.
+	// +kubebuilder:validation:MaxItems=20
+	// +optional
+	RecentEvents []WorkEvent `json:"recentEvents,omitempty"`
+
+	// LastComplianceReport is the report of the last compliance scan of the resources applied for the work against
+	// the policy rules. It is written by the scanner, not by the work applier.
+	// +optional
+	LastComplianceReport *ComplianceReport `json:"lastComplianceReport,omitempty"`
+
+	// HubConnectivityLost is true when the member agent has lost its connectivity to the hub cluster, so that the
+	// rest of the status may be stale. It is set by the member agent on the works in its local cache only, as the hub
+	// cluster cannot be reached, and is cleared once the connectivity recovers.
+	// +optional
+	HubConnectivityLost bool `json:"hubConnectivityLost,omitempty"`
+
+	// CurrentManifestVersion is the latest version assigned to the manifest content of the work. It is incremented
+	// whenever the content of a manifest changes to one not seen before, and never decreases.
+	// +optional
+	CurrentManifestVersion int64 `json:"currentManifestVersion,omitempty"`
+
+	// ConditionHistory is the most recent transitions of the conditions of the work, keyed by the condition type,
+	// oldest first. Once a list holds MaxConditionHistory transitions, a new one displaces the oldest.
+	// +optional
+	ConditionHistory map[string][]ConditionSnapshot `json:"conditionHistory,omitempty"`
+}
+
+// ConditionSnapshot is a transition of a condition of a work.
+type ConditionSnapshot struct {
+	// Status is the status of the condition after the transition.
+	// +required
+	Status metav1.ConditionStatus `json:"status"`
+
+	// Reason is the reason of the condition after the transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// ObservedAt is when the work applier observed the transition.
+	// +required
+	ObservedAt metav1.Time `json:"observedAt"`
+}
+
+// RolloutProgress is the progress of applying the manifests of a work in batches.
+type RolloutProgress struct {
+	// ObservedGeneration is the generation of the work the progress is for; the rollout starts over from the first
+	// batch when the work spec changes.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// TotalBatches is the number of batches the manifests are split into.
+	// +required
+	TotalBatches int `json:"totalBatches"`
+
+	// CompletedBatches is the number of batches whose manifests are all applied.
+	// +required
+	CompletedBatches int `json:"completedBatches"`
+
+	// CurrentBatchOrdinals are the ordinals of the manifests in the batch being applied, in the order they are applied
+	// in. It is empty once all the batches are completed.
+	// +optional
+	CurrentBatchOrdinals []int `json:"currentBatchOrdinals,omitempty"`
+
+	// ProgressPercentage is the percentage of the batches which are completed.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +required
+	ProgressPercentage float32 `json:"progressPercentage"`
+}
+
+// WorkEvent is an event of applying a work, kept in the work status as a lightweight audit trail.
+type WorkEvent struct {
+	// Timestamp is when the event happened.
+	// +required
+	Timestamp metav1.Time `json:"timestamp"`
+
+	// Type is the type of the event.
+	// +kubebuilder:validation:Enum=SpecChanged;ManifestApplied;DriftFound;GarbageCollected;Error
+	// +required
+	Type string `json:"type"`
+
+	// ManifestOrdinal is the ordinal of the manifest the event is about; it is not set for the events of the whole work.
+	// +optional
+	ManifestOrdinal *int `json:"manifestOrdinal,omitempty"`
+
+	// Message is the human-readable details of the event.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ComplianceReport is the result of scanning the resources applied for a work against the policy rules.
+type ComplianceReport struct {
+	// ScanTime is when the resources were scanned.
+	// +required
+	ScanTime metav1.Time `json:"scanTime"`
+
+	// PassedCount is the number of the rule evaluations which passed.
+	// +required
+	PassedCount int `json:"passedCount"`
+
+	// FailedCount is the number of the rule evaluations which failed.
+	// +required
+	FailedCount int `json:"failedCount"`
+
+	// Results are the results of evaluating each rule against each resource it matches.
+	// +optional
+	Results []ComplianceResult `json:"results,omitempty"`
+}
+
+// ComplianceResult is the result of evaluating a policy rule against a resource.
+type ComplianceResult struct {
+	// Identifier is the identity of the resource linking to the manifest in spec.
+	// +required
+	Identifier WorkResourceIdentifier `json:"identifier"`
+
+	// Rule is the name of the policy rule.
+	// +required
+	Rule string `json:"rule"`
+
+	// Passed is whether the resource complies with the rule.
+	// +required
+	Passed bool `json:"passed"`
+
+	// Message is the human-readable details of why the resource does not comply with the rule.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// PendingManifestChange is the change a dry-run apply of a manifest would make to its resource, as reported in the
+// pending approval diff of the work.
+type PendingManifestChange struct {
+	// Identifier is the identity of the resource linking to the manifest in spec.
+	// +required
+	Identifier WorkResourceIdentifier `json:"identifier"`
+
+	// Operation is the operation the apply would perform on the resource.
+	// +kubebuilder:validation:Enum=Create;Update
+	// +required
+	Operation PendingOperation `json:"operation"`
+
+	// ChangedFields are the paths of the fields the apply would change in the existing resource, e.g. `spec.replicas`.
+	// When the resource would be created, they are the paths of all the fields the created resource would have.
+	// +optional
+	ChangedFields []string `json:"changedFields,omitempty"`
+
+	// ResourceExistsInMember is true if the resource exists in the member cluster, i.e. the changes are compared
+	// against the existing resource instead of an empty one.
+	// +optional
+	ResourceExistsInMember bool `json:"resourceExistsInMember,omitempty"`
+}
+
+// PendingOperation is the operation a dry-run apply would perform on a resource.
+// +enum
+type PendingOperation string
+
+const (
+	// PendingOperationCreate means the resource does not exist and would be created.
+	PendingOperationCreate PendingOperation = "Create"
+
+	// PendingOperationUpdate means the existing resource would be updated.
+	PendingOperationUpdate PendingOperation = "Update"
+)
+
+// ManifestDryRunResult is the result of a server-side dry-run apply of a manifest.
+type ManifestDryRunResult struct {
+	// Ordinal is the index of the manifest in the manifests list.
+	// +required
+	Ordinal int `json:"ordinal"`
+
+	// ResultJSON is the JSON of the resource as it would be in the member cluster after the apply.
+	// +optional
+	ResultJSON []byte `json:"resultJSON,omitempty"`
+
+	// Changes are the changes the apply would make to the resource in the member cluster. When the resource does not
+	// exist, they are all the fields the created resource would have.
+	// +optional
+	Changes []PatchDetail `json:"changes,omitempty"`
+
+	// ResourceExistsInMember is true if the resource exists in the member cluster, i.e. the changes are compared
+	// against the existing resource instead of an empty one.
+	// +optional
+	ResourceExistsInMember bool `json:"resourceExistsInMember,omitempty"`
+}
+
+// PatchDetail is the change of a field of a resource.
+type PatchDetail struct {
+	// Path is the path of the field, e.g. `spec.replicas`.
+	// +required
+	Path string `json:"path"`
+
+	// ValueInMember is the JSON of the value of the field in the member cluster; it is empty if the field is absent.
+	// +optional
+	ValueInMember string `json:"valueInMember,omitempty"`
+
+	// ValueInHub is the JSON of the value of the field after the apply; it is empty if the field is removed.
+	// +optional
+	ValueInHub string `json:"valueInHub,omitempty"`
+}
+
+// WorkStatusSnapshot is a snapshot of the conditions of a work and its manifests.
+type WorkStatusSnapshot struct {
+	// ObservedGeneration is the generation of the work when the snapshot was taken.
+	ObservedGeneration int64 `json:"observedGeneration"`
+
+	// Conditions contains the condition statuses of the work when the snapshot was taken.
+	Conditions []metav1.Condition `json:"conditions"`
+
+	// ManifestConditions contains the conditions of each resource in the work when the snapshot was taken.
+	// +optional
+	ManifestConditions []ManifestCondition `json:"manifestConditions,omitempty"`
 }
 
 // WorkResourceIdentifier provides the identifiers needed to interact with any arbitrary object.
@@ -123,6 +761,122 @@ type ManifestCondition struct {
 	// Conditions represents the conditions of this resource on spoke cluster
 	// +required
 	Conditions []metav1.Condition `json:"conditions"`
+
+	// RetryCount is the number of times the apply of the resource has been retried according to its retry policy
+	// for the current generation of the work.
+	// +optional
+	RetryCount int `json:"retryCount,omitempty"`
+
+	// ManifestCreatedAt is the first time the manifest with this ordinal was reconciled as part of the work.
+	// It is not reset when the content of the manifest changes.
+	// +optional
+	ManifestCreatedAt *metav1.Time `json:"manifestCreatedAt,omitempty"`
+
+	// ApplyStartedAt is the time the work applier started the last apply call of the manifest.
+	// +optional
+	ApplyStartedAt *metav1.Time `json:"applyStartedAt,omitempty"`
+
+	// ApplyCompletedAt is the time the last apply call of the manifest returned.
+	// +optional
+	ApplyCompletedAt *metav1.Time `json:"applyCompletedAt,omitempty"`
+
+	// ApplyDurationMs is the duration of the last apply call of the manifest in milliseconds.
+	// +optional
+	ApplyDurationMs int64 `json:"applyDurationMs,omitempty"`
+
+	// ApplyHistory is the results of the most recent apply calls of the manifest, oldest first. Once the list is full,
+	// a new entry displaces the oldest one.
+	// +kubebuilder:validation:MaxItems=5
+	// +optional
+	ApplyHistory []ApplyHistoryEntry `json:"applyHistory,omitempty"`
+
+	// LastAppliedHash is the SHA-256 hash of the manifest content which was last applied successfully.
+	// The work applier skips applying the manifest again when neither the manifest nor the fields it sets on the
+	// resource have changed since.
+	// +optional
+	LastAppliedHash string `json:"lastAppliedHash,omitempty"`
+
+	// ManifestVersion is the version of the manifest content the work applier pins the manifest to. It goes back to
+	// an earlier version only if a rollback of the manifest is allowed by the apply strategy.
+	// +optional
+	ManifestVersion int64 `json:"manifestVersion,omitempty"`
+
+	// ManifestVersionHistory are the most recent versions of the manifest content, oldest first; the last one is the
+	// version the manifest is pinned to. Once the list is full, a new version displaces the oldest one.
+	// +kubebuilder:validation:MaxItems=5
+	// +optional
+	ManifestVersionHistory []ManifestVersionEntry `json:"manifestVersionHistory,omitempty"`
+
+	// DriftHistory are the most recent drifts of the resource in the member cluster from the manifest, newest first,
+	// so that a drift stays visible after the work applier corrects it. The number of the entries kept is configured
+	// on the work applier; the oldest entries are displaced once the list is full.
+	// +optional
+	DriftHistory []DriftHistoryEntry `json:"driftHistory,omitempty"`
+}
+
+// ManifestVersionEntry is a version of the content of a manifest.
+type ManifestVersionEntry struct {
+	// Version is the version assigned to the manifest content.
+	// +required
+	Version int64 `json:"version"`
+
+	// Hash is the SHA-256 hash of the manifest content.
+	// +required
+	Hash string `json:"hash"`
+}
+
+// ManifestProcessingApplyResultType is the result of applying a manifest, the same as the reason of the Applied
+// condition of the manifest, e.g. ManifestCreated or ManifestApplyFailed.
+type ManifestProcessingApplyResultType string
+
+const (
+	// ManifestProcessingApplyResultTypeSchemaValidationFailed is the result of a manifest which does not match the
+	// validation schema of its kind, so it is not applied.
+	ManifestProcessingApplyResultTypeSchemaValidationFailed ManifestProcessingApplyResultType = "SchemaValidationFailed"
+
+	// ManifestProcessingApplyResultTypeSkippedAsAlreadyExists is the result of a manifest which is not applied as
+	// the apply strategy applies the manifests only once and the resource created by the work already exists.
+	ManifestProcessingApplyResultTypeSkippedAsAlreadyExists ManifestProcessingApplyResultType = "SkippedAsAlreadyExists"
+
+	// ManifestProcessingApplyResultTypeDryRunFailed is the result of a manifest which is not applied as the
+	// server-side dry-run of its apply failed.
+	ManifestProcessingApplyResultTypeDryRunFailed ManifestProcessingApplyResultType = "DryRunFailed"
+
+	// ManifestProcessingApplyResultTypeTooLarge is the result of a manifest which is not applied as its raw JSON is
+	// larger than the limit of the work applier.
+	ManifestProcessingApplyResultTypeTooLarge ManifestProcessingApplyResultType = "TooLarge"
+)
+
+// ApplyHistoryEntry is the result of an apply call of a manifest.
+type ApplyHistoryEntry struct {
+	// ApplyTime is when the apply call of the manifest returned.
+	// +required
+	ApplyTime metav1.Time `json:"applyTime"`
+
+	// Result is the result of the apply call.
+	// +required
+	Result ManifestProcessingApplyResultType `json:"result"`
+
+	// ManifestGeneration is the generation of the resource in the member cluster after the apply call; it is not set
+	// if the apply call failed.
+	// +optional
+	ManifestGeneration int64 `json:"manifestGeneration,omitempty"`
+}
+
+// DriftHistoryEntry is a drift of the resource of a manifest observed by the work applier, i.e. a change made to the
+// resource in the member cluster since the manifest was last applied.
+type DriftHistoryEntry struct {
+	// ObservedTime is when the work applier observed the drift.
+	// +required
+	ObservedTime metav1.Time `json:"observedTime"`
+
+	// AppliedGeneration is the generation of the resource when the manifest was last applied before the drift.
+	// +required
+	AppliedGeneration int64 `json:"appliedGeneration"`
+
+	// ObservedGeneration is the generation of the resource the work applier observed with the drift.
+	// +required
+	ObservedGeneration int64 `json:"observedGeneration"`
 }
 
 // +genclient
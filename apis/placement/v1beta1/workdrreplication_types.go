@@ -0,0 +1,134 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DRReplicaAnnotation is the annotation on the work replicas on a disaster recovery hub which tells them apart
+	// from the original works. Its value is always "true".
+	DRReplicaAnnotation = "fleet.azure.com/dr-replica"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,categories={fleet,fleet-placement},shortName=wdr
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=`.spec.drHubClient.secretName`,name="DR-Hub",type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="WorkDRReplicated")].status`,name="Replicated",type=string
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name="Age",type=date
+
+// WorkDRReplication mirrors the approved Work objects of a hub cluster to a disaster recovery hub cluster, so that
+// the works can be restored from the disaster recovery hub if the source hub is lost.
+// Only the specs of the works are copied, every sync interval; the works whose changes are pending approval are
+// left out until they are approved. A replica keeps the name, the namespace, the labels and the spec of its source
+// work, carries the `fleet.azure.com/dr-replica: "true"` annotation, and is deleted when the source work is deleted.
+// The replicas are left on the disaster recovery hub when the WorkDRReplication itself is deleted.
+type WorkDRReplication struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// The desired state of WorkDRReplication.
+	// +required
+	Spec WorkDRReplicationSpec `json:"spec"`
+
+	// The observed status of WorkDRReplication.
+	// +optional
+	Status WorkDRReplicationStatus `json:"status,omitempty"`
+}
+
+// WorkDRReplicationSpec defines the desired state of WorkDRReplication.
+type WorkDRReplicationSpec struct {
+	// SourceHubClient refers to the kubeconfig of the hub cluster to copy the works from.
+	// The hub cluster the WorkDRReplication is in is the source hub if not set.
+	// +optional
+	SourceHubClient *KubeconfigRef `json:"sourceHubClient,omitempty"`
+
+	// DRHubClient refers to the kubeconfig of the disaster recovery hub cluster to copy the works to.
+	// +required
+	DRHubClient *KubeconfigRef `json:"drHubClient"`
+
+	// SyncInterval is how often the works are copied to the disaster recovery hub. Defaults to 1 minute.
+	// +kubebuilder:default="1m"
+	// +optional
+	SyncInterval *metav1.Duration `json:"syncInterval,omitempty"`
+}
+
+// KubeconfigRef refers to a kubeconfig stored in a Secret in the fleet system namespace.
+type KubeconfigRef struct {
+	// SecretName is the name of the Secret in the fleet system namespace which holds the kubeconfig.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	SecretName string `json:"secretName"`
+
+	// Key is the key of the kubeconfig in the Secret. Defaults to `kubeconfig`.
+	// +kubebuilder:default=kubeconfig
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// WorkDRReplicationStatus defines the observed state of WorkDRReplication.
+type WorkDRReplicationStatus struct {
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+
+	// Conditions is an array of current observed conditions for WorkDRReplication.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ReplicatedWorks is the number of the works copied to the disaster recovery hub in the last sync.
+	// +optional
+	ReplicatedWorks int `json:"replicatedWorks,omitempty"`
+
+	// LastSyncTime is when the works were last copied to the disaster recovery hub successfully.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+}
+
+// WorkDRReplicationConditionType identifies a specific condition of the WorkDRReplication.
+type WorkDRReplicationConditionType string
+
+const (
+	// WorkDRReplicationConditionTypeReplicated indicates whether the approved works are copied to the disaster
+	// recovery hub.
+	// Its condition status can be one of the following:
+	// - "True" means the replicas of the approved works are created or updated on the disaster recovery hub and the
+	// replicas of the deleted works are deleted.
+	// - "False" means the works are not fully copied yet.
+	WorkDRReplicationConditionTypeReplicated WorkDRReplicationConditionType = "WorkDRReplicated"
+)
+
+// +kubebuilder:object:root=true
+
+// WorkDRReplicationList contains a list of WorkDRReplication.
+type WorkDRReplicationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkDRReplication `json:"items"`
+}
+
+// SetConditions sets the conditions of the WorkDRReplication.
+func (w *WorkDRReplication) SetConditions(conditions ...metav1.Condition) {
+	for _, c := range conditions {
+		meta.SetStatusCondition(&w.Status.Conditions, c)
+	}
+}
+
+// GetCondition returns the condition of the WorkDRReplication.
+func (w *WorkDRReplication) GetCondition(conditionType string) *metav1.Condition {
+	return meta.FindStatusCondition(w.Status.Conditions, conditionType)
+}
+
+func init() {
+	SchemeBuilder.Register(&WorkDRReplication{}, &WorkDRReplicationList{})
+}
@@ -0,0 +1,81 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// WorkGroupConditionTypeApplied represents that all the parts of the split work are applied.
+	WorkGroupConditionTypeApplied = "Applied"
+
+	// WorkGroupConditionTypeAvailable represents that all the parts of the split work are available.
+	WorkGroupConditionTypeAvailable = "Available"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,categories={fleet,fleet-placement}
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Applied")].status`,name="Applied",type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Available")].status`,name="Available",type=string
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name="Age",type=date
+
+// WorkGroup tracks the parts a work with too many manifests is split into, and aggregates their statuses.
+// The WorkGroup has the name of the split work, and its parts are the works labeled with its name.
+// The parts are an atomic unit: the group is applied only when all of them are applied.
+type WorkGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec defines the parts of the split work.
+	// +required
+	Spec WorkGroupSpec `json:"spec"`
+
+	// status represents the aggregated status of the parts.
+	// +optional
+	Status WorkGroupStatus `json:"status,omitempty"`
+}
+
+// WorkGroupSpec defines the parts of a split work.
+type WorkGroupSpec struct {
+	// Parts are the names of the works the manifests are split into, in the order of the manifests.
+	// +required
+	Parts []string `json:"parts"`
+}
+
+// WorkGroupStatus is the aggregated status of the parts of a split work.
+type WorkGroupStatus struct {
+	// Conditions are the aggregated conditions of the parts.
+	// Valid condition types are:
+	// 1. Applied represents that all the parts are applied.
+	// 2. Available represents that all the parts are available.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// AppliedParts is the number of the parts which are applied.
+	// +optional
+	AppliedParts int `json:"appliedParts,omitempty"`
+
+	// AvailableParts is the number of the parts which are available.
+	// +optional
+	AvailableParts int `json:"availableParts,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WorkGroupList contains a list of WorkGroup.
+type WorkGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WorkGroup{}, &WorkGroupList{})
+}
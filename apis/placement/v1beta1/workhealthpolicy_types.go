@@ -0,0 +1,71 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultWorkHealthPolicyName is the name of the fleet-wide default WorkHealthPolicy. The policy of this name in
+	// the fleet system namespace is copied to the namespace of every member cluster and referenced by the new works.
+	DefaultWorkHealthPolicyName = "default"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,categories={fleet,fleet-placement}
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name="Age",type=date
+
+// WorkHealthPolicy defines the health criteria which can be shared by the works in the same namespace through
+// their healthPolicyRef. The criteria set in the health criteria of a work take precedence over the policy.
+type WorkHealthPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the health criteria of the policy.
+	// +required
+	Spec WorkHealthCriteria `json:"spec"`
+}
+
+// WorkHealthCriteria describes how the work applier assesses and maintains the health of the applied resources.
+// The criteria which are not set fall back to the referenced WorkHealthPolicy and then to the built-in defaults.
+type WorkHealthCriteria struct {
+	// AvailabilityProbePeriodSeconds is how often, in seconds, the availability of the applied resources is checked
+	// again while the work is not available. Defaults to 3 seconds.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	AvailabilityProbePeriodSeconds *int32 `json:"availabilityProbePeriodSeconds,omitempty"`
+
+	// DriftToleranceSeconds is how long, in seconds, a change made to the applied resources in the member cluster
+	// may persist before the work is applied again to correct it. Defaults to 300 seconds.
+	// +kubebuilder:validation:Minimum=10
+	// +kubebuilder:validation:Maximum=86400
+	// +optional
+	DriftToleranceSeconds *int32 `json:"driftToleranceSeconds,omitempty"`
+
+	// TimeoutSeconds is the time limit, in seconds, of applying the manifests to the member cluster in one reconcile.
+	// It takes precedence over the memberAPITimeoutSeconds of the work when set.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=300
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WorkHealthPolicyList contains a list of WorkHealthPolicy.
+type WorkHealthPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkHealthPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WorkHealthPolicy{}, &WorkHealthPolicyList{})
+}
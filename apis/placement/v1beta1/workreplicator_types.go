@@ -0,0 +1,111 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,categories={fleet,fleet-placement},shortName=wrep
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=`.spec.sourceHub`,name="Source",type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="WorkReplicated")].status`,name="WorkReplicated",type=string
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name="Age",type=date
+
+// WorkReplicator mirrors the Work objects selected on a source hub cluster to the target hub clusters, so that the
+// same works exist on every hub of an active-active fleet.
+// The hub clusters are named after the Secrets in the fleet system namespace which hold their kubeconfig under the
+// `kubeconfig` key. A replica keeps the name, the namespace, the labels and the spec of its source work and is
+// deleted when the source work is deleted or is no longer selected.
+type WorkReplicator struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// The desired state of WorkReplicator.
+	// +required
+	Spec WorkReplicatorSpec `json:"spec"`
+
+	// The observed status of WorkReplicator.
+	// +optional
+	Status WorkReplicatorStatus `json:"status,omitempty"`
+}
+
+// WorkReplicatorSpec defines the desired state of WorkReplicator.
+type WorkReplicatorSpec struct {
+	// SourceHub is the name of the hub cluster to replicate the works from.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	SourceHub string `json:"sourceHub"`
+
+	// TargetHubs are the names of the hub clusters to replicate the works to.
+	// +kubebuilder:validation:MinItems=1
+	// +required
+	TargetHubs []string `json:"targetHubs"`
+
+	// WorkSelector selects the works on the source hub to replicate. A nil selector selects all the works.
+	// The work replicas on the source hub are never selected so that the hubs can replicate to each other.
+	// +optional
+	WorkSelector *metav1.LabelSelector `json:"workSelector,omitempty"`
+}
+
+// WorkReplicatorStatus defines the observed state of WorkReplicator.
+type WorkReplicatorStatus struct {
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+
+	// Conditions is an array of current observed conditions for WorkReplicator.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ReplicatedWorks is the number of the works replicated to every target hub in the last replication.
+	// +optional
+	ReplicatedWorks int `json:"replicatedWorks,omitempty"`
+}
+
+// WorkReplicatorConditionType identifies a specific condition of the WorkReplicator.
+type WorkReplicatorConditionType string
+
+const (
+	// WorkReplicatorConditionTypeWorkReplicated indicates whether the selected works are replicated to all the target
+	// hubs.
+	// Its condition status can be one of the following:
+	// - "True" means the replicas of the selected works are created or updated on all the target hubs and the replicas
+	// of the works that are deleted or no longer selected are deleted.
+	// - "False" means the works are not fully replicated yet.
+	WorkReplicatorConditionTypeWorkReplicated WorkReplicatorConditionType = "WorkReplicated"
+)
+
+// +kubebuilder:object:root=true
+
+// WorkReplicatorList contains a list of WorkReplicator.
+type WorkReplicatorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkReplicator `json:"items"`
+}
+
+// SetConditions sets the conditions of the WorkReplicator.
+func (w *WorkReplicator) SetConditions(conditions ...metav1.Condition) {
+	for _, c := range conditions {
+		meta.SetStatusCondition(&w.Status.Conditions, c)
+	}
+}
+
+// GetCondition returns the condition of the WorkReplicator.
+func (w *WorkReplicator) GetCondition(conditionType string) *metav1.Condition {
+	return meta.FindStatusCondition(w.Status.Conditions, conditionType)
+}
+
+func init() {
+	SchemeBuilder.Register(&WorkReplicator{}, &WorkReplicatorList{})
+}
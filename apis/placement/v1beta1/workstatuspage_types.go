@@ -0,0 +1,52 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// WorkStatusPageNameFmt is the format of the name of a WorkStatusPage, which is `{workName}-page-{pageNumber}`.
+	// The page numbers start from 1.
+	WorkStatusPageNameFmt = "%s-page-%d"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,categories={fleet,fleet-placement}
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=`.workName`,name="Work",type=string
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name="Age",type=date
+
+// WorkStatusPage holds the manifest conditions of a Work which overflow the manifest conditions kept in the Work
+// status when the status is paginated.
+// The pages of a Work are listed in the workStatusPageRef of its status and are owned by the Work.
+type WorkStatusPage struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// WorkName is the name of the work the page belongs to.
+	// +required
+	WorkName string `json:"workName"`
+
+	// ManifestConditions are the conditions of the manifests on this page, in the order of their ordinals.
+	// +optional
+	ManifestConditions []ManifestCondition `json:"manifestConditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WorkStatusPageList contains a list of WorkStatusPage.
+type WorkStatusPageList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkStatusPage `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WorkStatusPage{}, &WorkStatusPageList{})
+}
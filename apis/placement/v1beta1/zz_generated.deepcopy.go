@@ -10,6 +10,7 @@ Licensed under the MIT license.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -35,6 +36,106 @@ func (in *Affinity) DeepCopy() *Affinity {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnnotationPropagationPolicy) DeepCopyInto(out *AnnotationPropagationPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnnotationPropagationPolicy.
+func (in *AnnotationPropagationPolicy) DeepCopy() *AnnotationPropagationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AnnotationPropagationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AnnotationPropagationPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnnotationPropagationPolicyList) DeepCopyInto(out *AnnotationPropagationPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AnnotationPropagationPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnnotationPropagationPolicyList.
+func (in *AnnotationPropagationPolicyList) DeepCopy() *AnnotationPropagationPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(AnnotationPropagationPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AnnotationPropagationPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnnotationPropagationPolicySpec) DeepCopyInto(out *AnnotationPropagationPolicySpec) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]AnnotationRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnnotationPropagationPolicySpec.
+func (in *AnnotationPropagationPolicySpec) DeepCopy() *AnnotationPropagationPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AnnotationPropagationPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnnotationRule) DeepCopyInto(out *AnnotationRule) {
+	*out = *in
+	if in.PropagateToGVKs != nil {
+		in, out := &in.PropagateToGVKs, &out.PropagateToGVKs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnnotationRule.
+func (in *AnnotationRule) DeepCopy() *AnnotationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(AnnotationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppliedResourceMeta) DeepCopyInto(out *AppliedResourceMeta) {
 	*out = *in
@@ -145,6 +246,22 @@ func (in *AppliedWorkStatus) DeepCopy() *AppliedWorkStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplyHistoryEntry) DeepCopyInto(out *ApplyHistoryEntry) {
+	*out = *in
+	in.ApplyTime.DeepCopyInto(&out.ApplyTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplyHistoryEntry.
+func (in *ApplyHistoryEntry) DeepCopy() *ApplyHistoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplyHistoryEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplyStrategy) DeepCopyInto(out *ApplyStrategy) {
 	*out = *in
@@ -153,6 +270,25 @@ func (in *ApplyStrategy) DeepCopyInto(out *ApplyStrategy) {
 		*out = new(ServerSideApplyConfig)
 		**out = **in
 	}
+	if in.IgnoreAnnotationKeys != nil {
+		in, out := &in.IgnoreAnnotationKeys, &out.IgnoreAnnotationKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnorePaths != nil {
+		in, out := &in.IgnorePaths, &out.IgnorePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StuckThreshold != nil {
+		in, out := &in.StuckThreshold, &out.StuckThreshold
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PausedUntil != nil {
+		in, out := &in.PausedUntil, &out.PausedUntil
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplyStrategy.
@@ -165,6 +301,120 @@ func (in *ApplyStrategy) DeepCopy() *ApplyStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BroadcastWork) DeepCopyInto(out *BroadcastWork) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BroadcastWork.
+func (in *BroadcastWork) DeepCopy() *BroadcastWork {
+	if in == nil {
+		return nil
+	}
+	out := new(BroadcastWork)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BroadcastWork) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BroadcastWorkList) DeepCopyInto(out *BroadcastWorkList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BroadcastWork, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BroadcastWorkList.
+func (in *BroadcastWorkList) DeepCopy() *BroadcastWorkList {
+	if in == nil {
+		return nil
+	}
+	out := new(BroadcastWorkList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BroadcastWorkList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BroadcastWorkSpec) DeepCopyInto(out *BroadcastWorkSpec) {
+	*out = *in
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(ClusterSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Workload.DeepCopyInto(&out.Workload)
+	if in.ApplyStrategy != nil {
+		in, out := &in.ApplyStrategy, &out.ApplyStrategy
+		*out = new(ApplyStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BroadcastWorkSpec.
+func (in *BroadcastWorkSpec) DeepCopy() *BroadcastWorkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BroadcastWorkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BroadcastWorkStatus) DeepCopyInto(out *BroadcastWorkStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClusterStatuses != nil {
+		in, out := &in.ClusterStatuses, &out.ClusterStatuses
+		*out = make(map[string]WorkStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BroadcastWorkStatus.
+func (in *BroadcastWorkStatus) DeepCopy() *BroadcastWorkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BroadcastWorkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterAffinity) DeepCopyInto(out *ClusterAffinity) {
 	*out = *in
@@ -615,540 +865,1082 @@ func (in *ClusterSelectorTerm) DeepCopy() *ClusterSelectorTerm {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EnvelopeIdentifier) DeepCopyInto(out *EnvelopeIdentifier) {
+func (in *ClusterWorkStats) DeepCopyInto(out *ClusterWorkStats) {
 	*out = *in
+	in.LastUpdated.DeepCopyInto(&out.LastUpdated)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvelopeIdentifier.
-func (in *EnvelopeIdentifier) DeepCopy() *EnvelopeIdentifier {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterWorkStats.
+func (in *ClusterWorkStats) DeepCopy() *ClusterWorkStats {
 	if in == nil {
 		return nil
 	}
-	out := new(EnvelopeIdentifier)
+	out := new(ClusterWorkStats)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FailedResourcePlacement) DeepCopyInto(out *FailedResourcePlacement) {
+func (in *ComplianceReport) DeepCopyInto(out *ComplianceReport) {
 	*out = *in
-	in.ResourceIdentifier.DeepCopyInto(&out.ResourceIdentifier)
-	in.Condition.DeepCopyInto(&out.Condition)
+	in.ScanTime.DeepCopyInto(&out.ScanTime)
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]ComplianceResult, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedResourcePlacement.
-func (in *FailedResourcePlacement) DeepCopy() *FailedResourcePlacement {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceReport.
+func (in *ComplianceReport) DeepCopy() *ComplianceReport {
 	if in == nil {
 		return nil
 	}
-	out := new(FailedResourcePlacement)
+	out := new(ComplianceReport)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Manifest) DeepCopyInto(out *Manifest) {
+func (in *ComplianceResult) DeepCopyInto(out *ComplianceResult) {
 	*out = *in
-	in.RawExtension.DeepCopyInto(&out.RawExtension)
+	out.Identifier = in.Identifier
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Manifest.
-func (in *Manifest) DeepCopy() *Manifest {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceResult.
+func (in *ComplianceResult) DeepCopy() *ComplianceResult {
 	if in == nil {
 		return nil
 	}
-	out := new(Manifest)
+	out := new(ComplianceResult)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManifestCondition) DeepCopyInto(out *ManifestCondition) {
+func (in *ConditionSnapshot) DeepCopyInto(out *ConditionSnapshot) {
 	*out = *in
-	out.Identifier = in.Identifier
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.ObservedAt.DeepCopyInto(&out.ObservedAt)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestCondition.
-func (in *ManifestCondition) DeepCopy() *ManifestCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionSnapshot.
+func (in *ConditionSnapshot) DeepCopy() *ConditionSnapshot {
 	if in == nil {
 		return nil
 	}
-	out := new(ManifestCondition)
+	out := new(ConditionSnapshot)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NamespacedName) DeepCopyInto(out *NamespacedName) {
+func (in *CrossClusterDependency) DeepCopyInto(out *CrossClusterDependency) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedName.
-func (in *NamespacedName) DeepCopy() *NamespacedName {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrossClusterDependency.
+func (in *CrossClusterDependency) DeepCopy() *CrossClusterDependency {
 	if in == nil {
 		return nil
 	}
-	out := new(NamespacedName)
+	out := new(CrossClusterDependency)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PlacementPolicy) DeepCopyInto(out *PlacementPolicy) {
+func (in *DriftHistoryEntry) DeepCopyInto(out *DriftHistoryEntry) {
 	*out = *in
-	if in.ClusterNames != nil {
-		in, out := &in.ClusterNames, &out.ClusterNames
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.NumberOfClusters != nil {
-		in, out := &in.NumberOfClusters, &out.NumberOfClusters
-		*out = new(int32)
-		**out = **in
-	}
-	if in.Affinity != nil {
-		in, out := &in.Affinity, &out.Affinity
-		*out = new(Affinity)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.TopologySpreadConstraints != nil {
-		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
-		*out = make([]TopologySpreadConstraint, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]Toleration, len(*in))
-		copy(*out, *in)
-	}
+	in.ObservedTime.DeepCopyInto(&out.ObservedTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlacementPolicy.
-func (in *PlacementPolicy) DeepCopy() *PlacementPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftHistoryEntry.
+func (in *DriftHistoryEntry) DeepCopy() *DriftHistoryEntry {
 	if in == nil {
 		return nil
 	}
-	out := new(PlacementPolicy)
+	out := new(DriftHistoryEntry)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PreferredClusterSelector) DeepCopyInto(out *PreferredClusterSelector) {
+func (in *EnvelopeIdentifier) DeepCopyInto(out *EnvelopeIdentifier) {
 	*out = *in
-	in.Preference.DeepCopyInto(&out.Preference)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreferredClusterSelector.
-func (in *PreferredClusterSelector) DeepCopy() *PreferredClusterSelector {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvelopeIdentifier.
+func (in *EnvelopeIdentifier) DeepCopy() *EnvelopeIdentifier {
 	if in == nil {
 		return nil
 	}
-	out := new(PreferredClusterSelector)
+	out := new(EnvelopeIdentifier)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PropertySelector) DeepCopyInto(out *PropertySelector) {
+func (in *FailedResourcePlacement) DeepCopyInto(out *FailedResourcePlacement) {
 	*out = *in
-	if in.MatchExpressions != nil {
-		in, out := &in.MatchExpressions, &out.MatchExpressions
-		*out = make([]PropertySelectorRequirement, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.ResourceIdentifier.DeepCopyInto(&out.ResourceIdentifier)
+	in.Condition.DeepCopyInto(&out.Condition)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PropertySelector.
-func (in *PropertySelector) DeepCopy() *PropertySelector {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedResourcePlacement.
+func (in *FailedResourcePlacement) DeepCopy() *FailedResourcePlacement {
 	if in == nil {
 		return nil
 	}
-	out := new(PropertySelector)
+	out := new(FailedResourcePlacement)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PropertySelectorRequirement) DeepCopyInto(out *PropertySelectorRequirement) {
+func (in *HookRef) DeepCopyInto(out *HookRef) {
 	*out = *in
-	if in.Values != nil {
-		in, out := &in.Values, &out.Values
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PropertySelectorRequirement.
-func (in *PropertySelectorRequirement) DeepCopy() *PropertySelectorRequirement {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookRef.
+func (in *HookRef) DeepCopy() *HookRef {
 	if in == nil {
 		return nil
 	}
-	out := new(PropertySelectorRequirement)
+	out := new(HookRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PropertySorter) DeepCopyInto(out *PropertySorter) {
+func (in *KubeconfigRef) DeepCopyInto(out *KubeconfigRef) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PropertySorter.
-func (in *PropertySorter) DeepCopy() *PropertySorter {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigRef.
+func (in *KubeconfigRef) DeepCopy() *KubeconfigRef {
 	if in == nil {
 		return nil
 	}
-	out := new(PropertySorter)
+	out := new(KubeconfigRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceBindingSpec) DeepCopyInto(out *ResourceBindingSpec) {
+func (in *Manifest) DeepCopyInto(out *Manifest) {
 	*out = *in
-	if in.ResourceOverrideSnapshots != nil {
-		in, out := &in.ResourceOverrideSnapshots, &out.ResourceOverrideSnapshots
-		*out = make([]NamespacedName, len(*in))
-		copy(*out, *in)
-	}
-	if in.ClusterResourceOverrideSnapshots != nil {
-		in, out := &in.ClusterResourceOverrideSnapshots, &out.ClusterResourceOverrideSnapshots
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	in.ClusterDecision.DeepCopyInto(&out.ClusterDecision)
-	if in.ApplyStrategy != nil {
-		in, out := &in.ApplyStrategy, &out.ApplyStrategy
-		*out = new(ApplyStrategy)
-		(*in).DeepCopyInto(*out)
-	}
+	in.RawExtension.DeepCopyInto(&out.RawExtension)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceBindingSpec.
-func (in *ResourceBindingSpec) DeepCopy() *ResourceBindingSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Manifest.
+func (in *Manifest) DeepCopy() *Manifest {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceBindingSpec)
+	out := new(Manifest)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceBindingStatus) DeepCopyInto(out *ResourceBindingStatus) {
+func (in *ManifestBinaryData) DeepCopyInto(out *ManifestBinaryData) {
 	*out = *in
-	if in.FailedPlacements != nil {
-		in, out := &in.FailedPlacements, &out.FailedPlacements
-		*out = make([]FailedResourcePlacement, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.BinaryData != nil {
+		in, out := &in.BinaryData, &out.BinaryData
+		*out = make(map[string][]byte, len(*in))
+		for key, val := range *in {
+			var outVal []byte
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]byte, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceBindingStatus.
-func (in *ResourceBindingStatus) DeepCopy() *ResourceBindingStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(ResourceBindingStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceContent) DeepCopyInto(out *ResourceContent) {
-	*out = *in
-	in.RawExtension.DeepCopyInto(&out.RawExtension)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceContent.
-func (in *ResourceContent) DeepCopy() *ResourceContent {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestBinaryData.
+func (in *ManifestBinaryData) DeepCopy() *ManifestBinaryData {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceContent)
+	out := new(ManifestBinaryData)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceIdentifier) DeepCopyInto(out *ResourceIdentifier) {
+func (in *ManifestBlobRef) DeepCopyInto(out *ManifestBlobRef) {
 	*out = *in
-	if in.Envelope != nil {
-		in, out := &in.Envelope, &out.Envelope
-		*out = new(EnvelopeIdentifier)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceIdentifier.
-func (in *ResourceIdentifier) DeepCopy() *ResourceIdentifier {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestBlobRef.
+func (in *ManifestBlobRef) DeepCopy() *ManifestBlobRef {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceIdentifier)
+	out := new(ManifestBlobRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourcePlacementStatus) DeepCopyInto(out *ResourcePlacementStatus) {
+func (in *ManifestCondition) DeepCopyInto(out *ManifestCondition) {
 	*out = *in
-	if in.ApplicableResourceOverrides != nil {
-		in, out := &in.ApplicableResourceOverrides, &out.ApplicableResourceOverrides
-		*out = make([]NamespacedName, len(*in))
-		copy(*out, *in)
-	}
-	if in.ApplicableClusterResourceOverrides != nil {
-		in, out := &in.ApplicableClusterResourceOverrides, &out.ApplicableClusterResourceOverrides
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.FailedPlacements != nil {
-		in, out := &in.FailedPlacements, &out.FailedPlacements
-		*out = make([]FailedResourcePlacement, len(*in))
+	out.Identifier = in.Identifier
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+	if in.ManifestCreatedAt != nil {
+		in, out := &in.ManifestCreatedAt, &out.ManifestCreatedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ApplyStartedAt != nil {
+		in, out := &in.ApplyStartedAt, &out.ApplyStartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ApplyCompletedAt != nil {
+		in, out := &in.ApplyCompletedAt, &out.ApplyCompletedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ApplyHistory != nil {
+		in, out := &in.ApplyHistory, &out.ApplyHistory
+		*out = make([]ApplyHistoryEntry, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePlacementStatus.
-func (in *ResourcePlacementStatus) DeepCopy() *ResourcePlacementStatus {
-	if in == nil {
-		return nil
+	if in.ManifestVersionHistory != nil {
+		in, out := &in.ManifestVersionHistory, &out.ManifestVersionHistory
+		*out = make([]ManifestVersionEntry, len(*in))
+		copy(*out, *in)
 	}
-	out := new(ResourcePlacementStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceSnapshotSpec) DeepCopyInto(out *ResourceSnapshotSpec) {
-	*out = *in
-	if in.SelectedResources != nil {
-		in, out := &in.SelectedResources, &out.SelectedResources
-		*out = make([]ResourceContent, len(*in))
+	if in.DriftHistory != nil {
+		in, out := &in.DriftHistory, &out.DriftHistory
+		*out = make([]DriftHistoryEntry, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSnapshotSpec.
-func (in *ResourceSnapshotSpec) DeepCopy() *ResourceSnapshotSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestCondition.
+func (in *ManifestCondition) DeepCopy() *ManifestCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceSnapshotSpec)
+	out := new(ManifestCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceSnapshotStatus) DeepCopyInto(out *ResourceSnapshotStatus) {
+func (in *ManifestDryRunResult) DeepCopyInto(out *ManifestDryRunResult) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.ResultJSON != nil {
+		in, out := &in.ResultJSON, &out.ResultJSON
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]PatchDetail, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSnapshotStatus.
-func (in *ResourceSnapshotStatus) DeepCopy() *ResourceSnapshotStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestDryRunResult.
+func (in *ManifestDryRunResult) DeepCopy() *ManifestDryRunResult {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceSnapshotStatus)
+	out := new(ManifestDryRunResult)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RollingUpdateConfig) DeepCopyInto(out *RollingUpdateConfig) {
+func (in *ManifestHooks) DeepCopyInto(out *ManifestHooks) {
 	*out = *in
-	if in.MaxUnavailable != nil {
-		in, out := &in.MaxUnavailable, &out.MaxUnavailable
-		*out = new(intstr.IntOrString)
-		**out = **in
-	}
-	if in.MaxSurge != nil {
-		in, out := &in.MaxSurge, &out.MaxSurge
-		*out = new(intstr.IntOrString)
+	if in.PreApply != nil {
+		in, out := &in.PreApply, &out.PreApply
+		*out = new(HookRef)
 		**out = **in
 	}
-	if in.UnavailablePeriodSeconds != nil {
-		in, out := &in.UnavailablePeriodSeconds, &out.UnavailablePeriodSeconds
-		*out = new(int)
+	if in.PostApply != nil {
+		in, out := &in.PostApply, &out.PostApply
+		*out = new(HookRef)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateConfig.
-func (in *RollingUpdateConfig) DeepCopy() *RollingUpdateConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestHooks.
+func (in *ManifestHooks) DeepCopy() *ManifestHooks {
 	if in == nil {
 		return nil
 	}
-	out := new(RollingUpdateConfig)
+	out := new(ManifestHooks)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RolloutStrategy) DeepCopyInto(out *RolloutStrategy) {
+func (in *ManifestRetryPolicy) DeepCopyInto(out *ManifestRetryPolicy) {
 	*out = *in
-	if in.RollingUpdate != nil {
-		in, out := &in.RollingUpdate, &out.RollingUpdate
-		*out = new(RollingUpdateConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ApplyStrategy != nil {
-		in, out := &in.ApplyStrategy, &out.ApplyStrategy
-		*out = new(ApplyStrategy)
-		(*in).DeepCopyInto(*out)
-	}
+	in.RetryPolicy.DeepCopyInto(&out.RetryPolicy)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStrategy.
-func (in *RolloutStrategy) DeepCopy() *RolloutStrategy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestRetryPolicy.
+func (in *ManifestRetryPolicy) DeepCopy() *ManifestRetryPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(RolloutStrategy)
+	out := new(ManifestRetryPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SchedulingPolicySnapshotSpec) DeepCopyInto(out *SchedulingPolicySnapshotSpec) {
+func (in *ManifestStore) DeepCopyInto(out *ManifestStore) {
 	*out = *in
-	if in.Policy != nil {
-		in, out := &in.Policy, &out.Policy
-		*out = new(PlacementPolicy)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.PolicyHash != nil {
-		in, out := &in.PolicyHash, &out.PolicyHash
-		*out = make([]byte, len(*in))
-		copy(*out, *in)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingPolicySnapshotSpec.
-func (in *SchedulingPolicySnapshotSpec) DeepCopy() *SchedulingPolicySnapshotSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestStore.
+func (in *ManifestStore) DeepCopy() *ManifestStore {
 	if in == nil {
 		return nil
 	}
-	out := new(SchedulingPolicySnapshotSpec)
+	out := new(ManifestStore)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ManifestStore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SchedulingPolicySnapshotStatus) DeepCopyInto(out *SchedulingPolicySnapshotStatus) {
+func (in *ManifestStoreList) DeepCopyInto(out *ManifestStoreList) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.ClusterDecisions != nil {
-		in, out := &in.ClusterDecisions, &out.ClusterDecisions
-		*out = make([]ClusterDecision, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ManifestStore, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingPolicySnapshotStatus.
-func (in *SchedulingPolicySnapshotStatus) DeepCopy() *SchedulingPolicySnapshotStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestStoreList.
+func (in *ManifestStoreList) DeepCopy() *ManifestStoreList {
 	if in == nil {
 		return nil
 	}
-	out := new(SchedulingPolicySnapshotStatus)
+	out := new(ManifestStoreList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ManifestStoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServerSideApplyConfig) DeepCopyInto(out *ServerSideApplyConfig) {
+func (in *ManifestStoreSpec) DeepCopyInto(out *ManifestStoreSpec) {
 	*out = *in
+	in.Manifest.DeepCopyInto(&out.Manifest)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerSideApplyConfig.
-func (in *ServerSideApplyConfig) DeepCopy() *ServerSideApplyConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestStoreSpec.
+func (in *ManifestStoreSpec) DeepCopy() *ManifestStoreSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ServerSideApplyConfig)
+	out := new(ManifestStoreSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Toleration) DeepCopyInto(out *Toleration) {
+func (in *ManifestTargetNamespace) DeepCopyInto(out *ManifestTargetNamespace) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Toleration.
-func (in *Toleration) DeepCopy() *Toleration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestTargetNamespace.
+func (in *ManifestTargetNamespace) DeepCopy() *ManifestTargetNamespace {
 	if in == nil {
 		return nil
 	}
-	out := new(Toleration)
+	out := new(ManifestTargetNamespace)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TopologySpreadConstraint) DeepCopyInto(out *TopologySpreadConstraint) {
+func (in *ManifestVersionEntry) DeepCopyInto(out *ManifestVersionEntry) {
 	*out = *in
-	if in.MaxSkew != nil {
-		in, out := &in.MaxSkew, &out.MaxSkew
-		*out = new(int32)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologySpreadConstraint.
-func (in *TopologySpreadConstraint) DeepCopy() *TopologySpreadConstraint {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestVersionEntry.
+func (in *ManifestVersionEntry) DeepCopy() *ManifestVersionEntry {
 	if in == nil {
 		return nil
 	}
-	out := new(TopologySpreadConstraint)
+	out := new(ManifestVersionEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiClusterWorkSummary) DeepCopyInto(out *MultiClusterWorkSummary) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make(map[string]ClusterWorkStats, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterWorkSummary.
+func (in *MultiClusterWorkSummary) DeepCopy() *MultiClusterWorkSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiClusterWorkSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MultiClusterWorkSummary) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiClusterWorkSummaryList) DeepCopyInto(out *MultiClusterWorkSummaryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MultiClusterWorkSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterWorkSummaryList.
+func (in *MultiClusterWorkSummaryList) DeepCopy() *MultiClusterWorkSummaryList {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiClusterWorkSummaryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MultiClusterWorkSummaryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedName) DeepCopyInto(out *NamespacedName) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedName.
+func (in *NamespacedName) DeepCopy() *NamespacedName {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedName)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PatchDetail) DeepCopyInto(out *PatchDetail) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatchDetail.
+func (in *PatchDetail) DeepCopy() *PatchDetail {
+	if in == nil {
+		return nil
+	}
+	out := new(PatchDetail)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingManifestChange) DeepCopyInto(out *PendingManifestChange) {
+	*out = *in
+	out.Identifier = in.Identifier
+	if in.ChangedFields != nil {
+		in, out := &in.ChangedFields, &out.ChangedFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingManifestChange.
+func (in *PendingManifestChange) DeepCopy() *PendingManifestChange {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingManifestChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlacementPolicy) DeepCopyInto(out *PlacementPolicy) {
+	*out = *in
+	if in.ClusterNames != nil {
+		in, out := &in.ClusterNames, &out.ClusterNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NumberOfClusters != nil {
+		in, out := &in.NumberOfClusters, &out.NumberOfClusters
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]Toleration, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlacementPolicy.
+func (in *PlacementPolicy) DeepCopy() *PlacementPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PlacementPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreferredClusterSelector) DeepCopyInto(out *PreferredClusterSelector) {
+	*out = *in
+	in.Preference.DeepCopyInto(&out.Preference)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreferredClusterSelector.
+func (in *PreferredClusterSelector) DeepCopy() *PreferredClusterSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(PreferredClusterSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PropertySelector) DeepCopyInto(out *PropertySelector) {
+	*out = *in
+	if in.MatchExpressions != nil {
+		in, out := &in.MatchExpressions, &out.MatchExpressions
+		*out = make([]PropertySelectorRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PropertySelector.
+func (in *PropertySelector) DeepCopy() *PropertySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(PropertySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PropertySelectorRequirement) DeepCopyInto(out *PropertySelectorRequirement) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PropertySelectorRequirement.
+func (in *PropertySelectorRequirement) DeepCopy() *PropertySelectorRequirement {
+	if in == nil {
+		return nil
+	}
+	out := new(PropertySelectorRequirement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PropertySorter) DeepCopyInto(out *PropertySorter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PropertySorter.
+func (in *PropertySorter) DeepCopy() *PropertySorter {
+	if in == nil {
+		return nil
+	}
+	out := new(PropertySorter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceBindingSpec) DeepCopyInto(out *ResourceBindingSpec) {
+	*out = *in
+	if in.ResourceOverrideSnapshots != nil {
+		in, out := &in.ResourceOverrideSnapshots, &out.ResourceOverrideSnapshots
+		*out = make([]NamespacedName, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterResourceOverrideSnapshots != nil {
+		in, out := &in.ClusterResourceOverrideSnapshots, &out.ClusterResourceOverrideSnapshots
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.ClusterDecision.DeepCopyInto(&out.ClusterDecision)
+	if in.ApplyStrategy != nil {
+		in, out := &in.ApplyStrategy, &out.ApplyStrategy
+		*out = new(ApplyStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CrossClusterDependencies != nil {
+		in, out := &in.CrossClusterDependencies, &out.CrossClusterDependencies
+		*out = make([]CrossClusterDependency, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceBindingSpec.
+func (in *ResourceBindingSpec) DeepCopy() *ResourceBindingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceBindingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceBindingStatus) DeepCopyInto(out *ResourceBindingStatus) {
+	*out = *in
+	if in.FailedPlacements != nil {
+		in, out := &in.FailedPlacements, &out.FailedPlacements
+		*out = make([]FailedResourcePlacement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceBindingStatus.
+func (in *ResourceBindingStatus) DeepCopy() *ResourceBindingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceBindingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceContent) DeepCopyInto(out *ResourceContent) {
+	*out = *in
+	in.RawExtension.DeepCopyInto(&out.RawExtension)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceContent.
+func (in *ResourceContent) DeepCopy() *ResourceContent {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceContent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceIdentifier) DeepCopyInto(out *ResourceIdentifier) {
+	*out = *in
+	if in.Envelope != nil {
+		in, out := &in.Envelope, &out.Envelope
+		*out = new(EnvelopeIdentifier)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceIdentifier.
+func (in *ResourceIdentifier) DeepCopy() *ResourceIdentifier {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceIdentifier)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePlacementStatus) DeepCopyInto(out *ResourcePlacementStatus) {
+	*out = *in
+	if in.ApplicableResourceOverrides != nil {
+		in, out := &in.ApplicableResourceOverrides, &out.ApplicableResourceOverrides
+		*out = make([]NamespacedName, len(*in))
+		copy(*out, *in)
+	}
+	if in.ApplicableClusterResourceOverrides != nil {
+		in, out := &in.ApplicableClusterResourceOverrides, &out.ApplicableClusterResourceOverrides
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailedPlacements != nil {
+		in, out := &in.FailedPlacements, &out.FailedPlacements
+		*out = make([]FailedResourcePlacement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePlacementStatus.
+func (in *ResourcePlacementStatus) DeepCopy() *ResourcePlacementStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePlacementStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSnapshotSpec) DeepCopyInto(out *ResourceSnapshotSpec) {
+	*out = *in
+	if in.SelectedResources != nil {
+		in, out := &in.SelectedResources, &out.SelectedResources
+		*out = make([]ResourceContent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSnapshotSpec.
+func (in *ResourceSnapshotSpec) DeepCopy() *ResourceSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSnapshotStatus) DeepCopyInto(out *ResourceSnapshotStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSnapshotStatus.
+func (in *ResourceSnapshotStatus) DeepCopy() *ResourceSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+	*out = *in
+	if in.RetryOn != nil {
+		in, out := &in.RetryOn, &out.RetryOn
+		*out = make([]ErrorCode, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollingUpdateConfig) DeepCopyInto(out *RollingUpdateConfig) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.UnavailablePeriodSeconds != nil {
+		in, out := &in.UnavailablePeriodSeconds, &out.UnavailablePeriodSeconds
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateConfig.
+func (in *RollingUpdateConfig) DeepCopy() *RollingUpdateConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RollingUpdateConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutProgress) DeepCopyInto(out *RolloutProgress) {
+	*out = *in
+	if in.CurrentBatchOrdinals != nil {
+		in, out := &in.CurrentBatchOrdinals, &out.CurrentBatchOrdinals
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutProgress.
+func (in *RolloutProgress) DeepCopy() *RolloutProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStrategy) DeepCopyInto(out *RolloutStrategy) {
+	*out = *in
+	if in.RollingUpdate != nil {
+		in, out := &in.RollingUpdate, &out.RollingUpdate
+		*out = new(RollingUpdateConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ApplyStrategy != nil {
+		in, out := &in.ApplyStrategy, &out.ApplyStrategy
+		*out = new(ApplyStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CrossClusterDependencies != nil {
+		in, out := &in.CrossClusterDependencies, &out.CrossClusterDependencies
+		*out = make([]CrossClusterDependency, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStrategy.
+func (in *RolloutStrategy) DeepCopy() *RolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulingPolicySnapshotSpec) DeepCopyInto(out *SchedulingPolicySnapshotSpec) {
+	*out = *in
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(PlacementPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PolicyHash != nil {
+		in, out := &in.PolicyHash, &out.PolicyHash
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingPolicySnapshotSpec.
+func (in *SchedulingPolicySnapshotSpec) DeepCopy() *SchedulingPolicySnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulingPolicySnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulingPolicySnapshotStatus) DeepCopyInto(out *SchedulingPolicySnapshotStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClusterDecisions != nil {
+		in, out := &in.ClusterDecisions, &out.ClusterDecisions
+		*out = make([]ClusterDecision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingPolicySnapshotStatus.
+func (in *SchedulingPolicySnapshotStatus) DeepCopy() *SchedulingPolicySnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulingPolicySnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerSideApplyConfig) DeepCopyInto(out *ServerSideApplyConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerSideApplyConfig.
+func (in *ServerSideApplyConfig) DeepCopy() *ServerSideApplyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerSideApplyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Toleration) DeepCopyInto(out *Toleration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Toleration.
+func (in *Toleration) DeepCopy() *Toleration {
+	if in == nil {
+		return nil
+	}
+	out := new(Toleration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologySpreadConstraint) DeepCopyInto(out *TopologySpreadConstraint) {
+	*out = *in
+	if in.MaxSkew != nil {
+		in, out := &in.MaxSkew, &out.MaxSkew
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologySpreadConstraint.
+func (in *TopologySpreadConstraint) DeepCopy() *TopologySpreadConstraint {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologySpreadConstraint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationSchemaRef) DeepCopyInto(out *ValidationSchemaRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationSchemaRef.
+func (in *ValidationSchemaRef) DeepCopy() *ValidationSchemaRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidationSchemaRef)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1157,23 +1949,348 @@ func (in *TopologySpreadConstraint) DeepCopy() *TopologySpreadConstraint {
 func (in *Work) DeepCopyInto(out *Work) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Work.
+func (in *Work) DeepCopy() *Work {
+	if in == nil {
+		return nil
+	}
+	out := new(Work)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Work) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkDRReplication) DeepCopyInto(out *WorkDRReplication) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkDRReplication.
+func (in *WorkDRReplication) DeepCopy() *WorkDRReplication {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkDRReplication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkDRReplication) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkDRReplicationList) DeepCopyInto(out *WorkDRReplicationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WorkDRReplication, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkDRReplicationList.
+func (in *WorkDRReplicationList) DeepCopy() *WorkDRReplicationList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkDRReplicationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkDRReplicationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkDRReplicationSpec) DeepCopyInto(out *WorkDRReplicationSpec) {
+	*out = *in
+	if in.SourceHubClient != nil {
+		in, out := &in.SourceHubClient, &out.SourceHubClient
+		*out = new(KubeconfigRef)
+		**out = **in
+	}
+	if in.DRHubClient != nil {
+		in, out := &in.DRHubClient, &out.DRHubClient
+		*out = new(KubeconfigRef)
+		**out = **in
+	}
+	if in.SyncInterval != nil {
+		in, out := &in.SyncInterval, &out.SyncInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkDRReplicationSpec.
+func (in *WorkDRReplicationSpec) DeepCopy() *WorkDRReplicationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkDRReplicationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkDRReplicationStatus) DeepCopyInto(out *WorkDRReplicationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkDRReplicationStatus.
+func (in *WorkDRReplicationStatus) DeepCopy() *WorkDRReplicationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkDRReplicationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkEvent) DeepCopyInto(out *WorkEvent) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+	if in.ManifestOrdinal != nil {
+		in, out := &in.ManifestOrdinal, &out.ManifestOrdinal
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkEvent.
+func (in *WorkEvent) DeepCopy() *WorkEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkGroup) DeepCopyInto(out *WorkGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkGroup.
+func (in *WorkGroup) DeepCopy() *WorkGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkGroupList) DeepCopyInto(out *WorkGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WorkGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkGroupList.
+func (in *WorkGroupList) DeepCopy() *WorkGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkGroupSpec) DeepCopyInto(out *WorkGroupSpec) {
+	*out = *in
+	if in.Parts != nil {
+		in, out := &in.Parts, &out.Parts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkGroupSpec.
+func (in *WorkGroupSpec) DeepCopy() *WorkGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkGroupStatus) DeepCopyInto(out *WorkGroupStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkGroupStatus.
+func (in *WorkGroupStatus) DeepCopy() *WorkGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkHealthCriteria) DeepCopyInto(out *WorkHealthCriteria) {
+	*out = *in
+	if in.AvailabilityProbePeriodSeconds != nil {
+		in, out := &in.AvailabilityProbePeriodSeconds, &out.AvailabilityProbePeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DriftToleranceSeconds != nil {
+		in, out := &in.DriftToleranceSeconds, &out.DriftToleranceSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkHealthCriteria.
+func (in *WorkHealthCriteria) DeepCopy() *WorkHealthCriteria {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkHealthCriteria)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkHealthPolicy) DeepCopyInto(out *WorkHealthPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkHealthPolicy.
+func (in *WorkHealthPolicy) DeepCopy() *WorkHealthPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkHealthPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkHealthPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkHealthPolicyList) DeepCopyInto(out *WorkHealthPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WorkHealthPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Work.
-func (in *Work) DeepCopy() *Work {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkHealthPolicyList.
+func (in *WorkHealthPolicyList) DeepCopy() *WorkHealthPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(Work)
+	out := new(WorkHealthPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Work) DeepCopyObject() runtime.Object {
+func (in *WorkHealthPolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1212,6 +2329,127 @@ func (in *WorkList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkReadinessGate) DeepCopyInto(out *WorkReadinessGate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkReadinessGate.
+func (in *WorkReadinessGate) DeepCopy() *WorkReadinessGate {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkReadinessGate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkReplicator) DeepCopyInto(out *WorkReplicator) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkReplicator.
+func (in *WorkReplicator) DeepCopy() *WorkReplicator {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkReplicator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkReplicator) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkReplicatorList) DeepCopyInto(out *WorkReplicatorList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WorkReplicator, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkReplicatorList.
+func (in *WorkReplicatorList) DeepCopy() *WorkReplicatorList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkReplicatorList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkReplicatorList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkReplicatorSpec) DeepCopyInto(out *WorkReplicatorSpec) {
+	*out = *in
+	if in.TargetHubs != nil {
+		in, out := &in.TargetHubs, &out.TargetHubs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WorkSelector != nil {
+		in, out := &in.WorkSelector, &out.WorkSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkReplicatorSpec.
+func (in *WorkReplicatorSpec) DeepCopy() *WorkReplicatorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkReplicatorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkReplicatorStatus) DeepCopyInto(out *WorkReplicatorStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkReplicatorStatus.
+func (in *WorkReplicatorStatus) DeepCopy() *WorkReplicatorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkReplicatorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkResourceIdentifier) DeepCopyInto(out *WorkResourceIdentifier) {
 	*out = *in
@@ -1236,6 +2474,49 @@ func (in *WorkSpec) DeepCopyInto(out *WorkSpec) {
 		*out = new(ApplyStrategy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PropagateAnnotations != nil {
+		in, out := &in.PropagateAnnotations, &out.PropagateAnnotations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MemberAPITimeoutSeconds != nil {
+		in, out := &in.MemberAPITimeoutSeconds, &out.MemberAPITimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.HealthPolicyRef != nil {
+		in, out := &in.HealthPolicyRef, &out.HealthPolicyRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.HealthCriteria != nil {
+		in, out := &in.HealthCriteria, &out.HealthCriteria
+		*out = new(WorkHealthCriteria)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessGates != nil {
+		in, out := &in.ReadinessGates, &out.ReadinessGates
+		*out = make([]WorkReadinessGate, len(*in))
+		copy(*out, *in)
+	}
+	if in.NotBeforeTime != nil {
+		in, out := &in.NotBeforeTime, &out.NotBeforeTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NotAfterTime != nil {
+		in, out := &in.NotAfterTime, &out.NotAfterTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ValidationSchemas != nil {
+		in, out := &in.ValidationSchemas, &out.ValidationSchemas
+		*out = make([]ValidationSchemaRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.CrossClusterDependencies != nil {
+		in, out := &in.CrossClusterDependencies, &out.CrossClusterDependencies
+		*out = make([]CrossClusterDependency, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkSpec.
@@ -1265,6 +2546,75 @@ func (in *WorkStatus) DeepCopyInto(out *WorkStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastGoodStatus != nil {
+		in, out := &in.LastGoodStatus, &out.LastGoodStatus
+		*out = new(WorkStatusSnapshot)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RolloutProgress != nil {
+		in, out := &in.RolloutProgress, &out.RolloutProgress
+		*out = new(RolloutProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PendingApprovalDiff != nil {
+		in, out := &in.PendingApprovalDiff, &out.PendingApprovalDiff
+		*out = make([]PendingManifestChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PendingGCResources != nil {
+		in, out := &in.PendingGCResources, &out.PendingGCResources
+		*out = make([]WorkResourceIdentifier, len(*in))
+		copy(*out, *in)
+	}
+	if in.WorkStatusPageRef != nil {
+		in, out := &in.WorkStatusPageRef, &out.WorkStatusPageRef
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DryRunResults != nil {
+		in, out := &in.DryRunResults, &out.DryRunResults
+		*out = make([]ManifestDryRunResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AdditionalResources != nil {
+		in, out := &in.AdditionalResources, &out.AdditionalResources
+		*out = make([]WorkResourceIdentifier, len(*in))
+		copy(*out, *in)
+	}
+	if in.RecentEvents != nil {
+		in, out := &in.RecentEvents, &out.RecentEvents
+		*out = make([]WorkEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastComplianceReport != nil {
+		in, out := &in.LastComplianceReport, &out.LastComplianceReport
+		*out = new(ComplianceReport)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConditionHistory != nil {
+		in, out := &in.ConditionHistory, &out.ConditionHistory
+		*out = make(map[string][]ConditionSnapshot, len(*in))
+		for key, val := range *in {
+			var outVal []ConditionSnapshot
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]ConditionSnapshot, len(*in))
+				for i := range *in {
+					(*in)[i].DeepCopyInto(&(*out)[i])
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkStatus.
@@ -1277,6 +2627,99 @@ func (in *WorkStatus) DeepCopy() *WorkStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkStatusPage) DeepCopyInto(out *WorkStatusPage) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.ManifestConditions != nil {
+		in, out := &in.ManifestConditions, &out.ManifestConditions
+		*out = make([]ManifestCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkStatusPage.
+func (in *WorkStatusPage) DeepCopy() *WorkStatusPage {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkStatusPage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkStatusPage) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkStatusPageList) DeepCopyInto(out *WorkStatusPageList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WorkStatusPage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkStatusPageList.
+func (in *WorkStatusPageList) DeepCopy() *WorkStatusPageList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkStatusPageList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkStatusPageList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkStatusSnapshot) DeepCopyInto(out *WorkStatusSnapshot) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ManifestConditions != nil {
+		in, out := &in.ManifestConditions, &out.ManifestConditions
+		*out = make([]ManifestCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkStatusSnapshot.
+func (in *WorkStatusSnapshot) DeepCopy() *WorkStatusSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkStatusSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadTemplate) DeepCopyInto(out *WorkloadTemplate) {
 	*out = *in
@@ -1287,6 +2730,47 @@ func (in *WorkloadTemplate) DeepCopyInto(out *WorkloadTemplate) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ManifestRetryPolicies != nil {
+		in, out := &in.ManifestRetryPolicies, &out.ManifestRetryPolicies
+		*out = make([]ManifestRetryPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ManifestTargetNamespaces != nil {
+		in, out := &in.ManifestTargetNamespaces, &out.ManifestTargetNamespaces
+		*out = make([]ManifestTargetNamespace, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManifestBinaryData != nil {
+		in, out := &in.ManifestBinaryData, &out.ManifestBinaryData
+		*out = make([]ManifestBinaryData, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ManifestHooks != nil {
+		in, out := &in.ManifestHooks, &out.ManifestHooks
+		*out = make([]ManifestHooks, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ManifestBlobRefs != nil {
+		in, out := &in.ManifestBlobRefs, &out.ManifestBlobRefs
+		*out = make([]ManifestBlobRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompressedManifests != nil {
+		in, out := &in.CompressedManifests, &out.CompressedManifests
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManifestChecksums != nil {
+		in, out := &in.ManifestChecksums, &out.ManifestChecksums
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadTemplate.
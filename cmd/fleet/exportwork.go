@@ -0,0 +1,207 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/controllers/work"
+)
+
+const (
+	// kubectlLastAppliedConfigAnnotation is the annotation kubectl records the last applied configuration in.
+	kubectlLastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+// exportedMetadataFields are the metadata fields set at runtime which are stripped from the exported manifests on top
+// of the fields the work applier sanitizes by default.
+var exportedMetadataFields = [][]string{
+	{"metadata", "managedFields"},
+	{"metadata", "selfLink"},
+	{"metadata", "deletionTimestamp"},
+	{"metadata", "deletionGracePeriodSeconds"},
+}
+
+// workExporter reconstructs a Work object from the resources applied for it on a member cluster.
+type workExporter struct {
+	memberClient     dynamic.Interface
+	clusterNamespace string
+	workName         string
+	out              io.Writer
+}
+
+func newExportWorkCmd() *cobra.Command {
+	var clusterNamespace, workName, output, kubeconfig string
+	cmd := &cobra.Command{
+		Use:   "export-work",
+		Short: "Reconstruct a Work object from the resources applied for it on a member cluster",
+		Long: `Reconstruct a Work object from the resources applied for it on a member cluster.
+
+The resources listed in the AppliedWork of the Work are read from the member cluster and turned back into manifests.
+The configuration last applied by the work applier is used when the resource carries it; otherwise the fields set at
+runtime are stripped from the live resource. The apply strategy and the other settings of the Work are not recorded
+on the member cluster, so they are left to their defaults.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			memberClient, err := newMemberDynamicClient(kubeconfig)
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			if output != "" && output != "-" {
+				f, err := os.Create(output)
+				if err != nil {
+					return fmt.Errorf("failed to create the output file: %w", err)
+				}
+				defer f.Close()
+				out = f
+			}
+			e := &workExporter{
+				memberClient:     memberClient,
+				clusterNamespace: clusterNamespace,
+				workName:         workName,
+				out:              out,
+			}
+			return e.export(cmd.Context())
+		},
+	}
+	cmd.Flags().StringVar(&clusterNamespace, "cluster-namespace", "", "Reserved namespace of the member cluster on the hub cluster, which the Work is exported to (required)")
+	_ = cmd.MarkFlagRequired("cluster-namespace")
+	cmd.Flags().StringVar(&workName, "work-name", "", "Name of the Work object (required)")
+	_ = cmd.MarkFlagRequired("work-name")
+	cmd.Flags().StringVar(&output, "output", "", "Path of the file the Work YAML is written to; the standard output if empty or -")
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to the kubeconfig of the member cluster (optional)")
+	return cmd
+}
+
+func newMemberDynamicClient(kubeconfig string) (dynamic.Interface, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfig
+	cfg, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the member cluster config: %w", err)
+	}
+	return dynamic.NewForConfig(cfg)
+}
+
+// export writes the YAML of the work reconstructed from its appliedWork.
+func (e *workExporter) export(ctx context.Context) error {
+	appliedWork, err := getAppliedWork(ctx, e.memberClient, e.workName)
+	if err != nil {
+		return err
+	}
+	resources := appliedWork.Status.AppliedResources
+	sort.SliceStable(resources, func(i, j int) bool { return resources[i].Ordinal < resources[j].Ordinal })
+
+	exported := &placementv1beta1.Work{
+		TypeMeta:   metav1.TypeMeta{APIVersion: placementv1beta1.GroupVersion.String(), Kind: "Work"},
+		ObjectMeta: metav1.ObjectMeta{Name: e.workName, Namespace: e.clusterNamespace},
+	}
+	for _, res := range resources {
+		manifest, err := e.exportManifest(ctx, res)
+		if err != nil {
+			return err
+		}
+		exported.Spec.Workload.Manifests = append(exported.Spec.Workload.Manifests, manifest)
+	}
+	data, err := yaml.Marshal(exported)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the work: %w", err)
+	}
+	_, err = e.out.Write(data)
+	return err
+}
+
+// exportManifest reads the resource from the member cluster and turns it back into its manifest.
+func (e *workExporter) exportManifest(ctx context.Context, res placementv1beta1.AppliedResourceMeta) (placementv1beta1.Manifest, error) {
+	live, err := getAppliedResource(ctx, e.memberClient, res)
+	if err != nil {
+		return placementv1beta1.Manifest{}, err
+	}
+	manifestObj := live
+	if lastApplied := live.GetAnnotations()[placementv1beta1.LastAppliedConfigAnnotation]; lastApplied != "" {
+		manifestObj = &unstructured.Unstructured{}
+		if err := manifestObj.UnmarshalJSON([]byte(lastApplied)); err != nil {
+			return placementv1beta1.Manifest{}, fmt.Errorf("failed to decode the last applied configuration of %s %s/%s: %w",
+				res.Resource, live.GetNamespace(), res.Name, err)
+		}
+	}
+	sanitizeExportedManifest(manifestObj)
+	// the manifest is in its own namespace, the work routes it to the namespace it is applied to.
+	manifestObj.SetNamespace(res.Namespace)
+	raw, err := json.Marshal(manifestObj)
+	if err != nil {
+		return placementv1beta1.Manifest{}, fmt.Errorf("failed to marshal the manifest with ordinal %d: %w", res.Ordinal, err)
+	}
+	return placementv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: raw}}, nil
+}
+
+// getAppliedWork gets the appliedWork of the work from the member cluster.
+func getAppliedWork(ctx context.Context, memberClient dynamic.Interface, workName string) (*placementv1beta1.AppliedWork, error) {
+	rawAppliedWork, err := memberClient.Resource(placementv1beta1.GroupVersion.WithResource("appliedworks")).
+		Get(ctx, workName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the appliedWork %s: %w", workName, err)
+	}
+	var appliedWork placementv1beta1.AppliedWork
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(rawAppliedWork.Object, &appliedWork); err != nil {
+		return nil, fmt.Errorf("failed to convert the appliedWork %s: %w", workName, err)
+	}
+	return &appliedWork, nil
+}
+
+// getAppliedResource gets the live resource applied for a manifest from the member cluster, in the namespace the
+// manifest is routed to if any.
+func getAppliedResource(ctx context.Context, memberClient dynamic.Interface, res placementv1beta1.AppliedResourceMeta) (*unstructured.Unstructured, error) {
+	gvr := schema.GroupVersionResource{Group: res.Group, Version: res.Version, Resource: res.Resource}
+	namespace := res.Namespace
+	if res.AppliedNamespace != "" {
+		namespace = res.AppliedNamespace
+	}
+	live, err := memberClient.Resource(gvr).Namespace(namespace).Get(ctx, res.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the %s %s/%s of the manifest with ordinal %d: %w",
+			gvr.Resource, namespace, res.Name, res.Ordinal, err)
+	}
+	return live, nil
+}
+
+// sanitizeExportedManifest strips the fields set at runtime and by the work applier from the manifest.
+func sanitizeExportedManifest(manifestObj *unstructured.Unstructured) {
+	work.NewManifestSanitizer(work.DefaultSanitizedManifestFields).Sanitize(manifestObj)
+	for _, field := range exportedMetadataFields {
+		unstructured.RemoveNestedField(manifestObj.Object, field...)
+	}
+	var owners []metav1.OwnerReference
+	for _, owner := range manifestObj.GetOwnerReferences() {
+		if owner.APIVersion != placementv1beta1.GroupVersion.String() || owner.Kind != placementv1beta1.AppliedWorkKind {
+			owners = append(owners, owner)
+		}
+	}
+	manifestObj.SetOwnerReferences(owners)
+	annotations := manifestObj.GetAnnotations()
+	delete(annotations, placementv1beta1.ManifestHashAnnotation)
+	delete(annotations, placementv1beta1.LastAppliedConfigAnnotation)
+	delete(annotations, kubectlLastAppliedConfigAnnotation)
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	manifestObj.SetAnnotations(annotations)
+}
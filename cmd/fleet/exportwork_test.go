@@ -0,0 +1,146 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+var (
+	configMapGVR  = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	deploymentGVR = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+)
+
+// unstructuredObj returns the unstructured object of the JSON.
+func unstructuredObj(t *testing.T, raw string) *unstructured.Unstructured {
+	t.Helper()
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON([]byte(raw)); err != nil {
+		t.Fatalf("failed to decode %s: %v", raw, err)
+	}
+	return obj
+}
+
+func TestExportWork(t *testing.T) {
+	const (
+		configMapManifest = `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"config","namespace":"app","labels":{"team":"blue"}},"data":{"key":"value"}}`
+		// the deployment is routed from namespace app to namespace app-prod.
+		deploymentManifest = `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"app"},"spec":{"replicas":2}}`
+	)
+	appliedWorkOwner := `{"apiVersion":"placement.kubernetes-fleet.io/v1beta1","kind":"AppliedWork","name":"my-work","uid":"applied-uid"}`
+	// the config map is applied by the client side applier, which records the applied configuration.
+	lastApplied := `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"config","namespace":"app","labels":{"team":"blue"},` +
+		`"annotations":{"kubernetes-fleet.io/spec-hash":"abc"},"ownerReferences":[` + appliedWorkOwner + `]},"data":{"key":"value"}}`
+	liveConfigMap := unstructuredObj(t, `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"config","namespace":"app",`+
+		`"uid":"cm-uid","resourceVersion":"7","creationTimestamp":"2024-01-01T00:00:00Z","labels":{"team":"blue","added-by":"controller"},`+
+		`"ownerReferences":[`+appliedWorkOwner+`],"managedFields":[{"manager":"work-api-agent","operation":"Update"}]},"data":{"key":"value"}}`)
+	liveConfigMap.SetAnnotations(map[string]string{
+		placementv1beta1.ManifestHashAnnotation:      "abc",
+		placementv1beta1.LastAppliedConfigAnnotation: lastApplied,
+	})
+	// the deployment is applied by the server side applier, so only the live resource is available.
+	liveDeployment := unstructuredObj(t, `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"app-prod",`+
+		`"uid":"deploy-uid","resourceVersion":"9","generation":3,"creationTimestamp":"2024-01-01T00:00:00Z",`+
+		`"ownerReferences":[`+appliedWorkOwner+`]},"spec":{"replicas":2},"status":{"availableReplicas":2}}`)
+	appliedWork := &placementv1beta1.AppliedWork{
+		TypeMeta:   metav1.TypeMeta{APIVersion: placementv1beta1.GroupVersion.String(), Kind: placementv1beta1.AppliedWorkKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "my-work"},
+		Status: placementv1beta1.AppliedWorkStatus{
+			AppliedResources: []placementv1beta1.AppliedResourceMeta{
+				{
+					WorkResourceIdentifier: placementv1beta1.WorkResourceIdentifier{
+						Ordinal: 1, Group: "apps", Version: "v1", Kind: "Deployment", Resource: "deployments", Namespace: "app", Name: "web",
+					},
+					AppliedNamespace: "app-prod",
+				},
+				{
+					WorkResourceIdentifier: placementv1beta1.WorkResourceIdentifier{
+						Ordinal: 0, Version: "v1", Kind: "ConfigMap", Resource: "configmaps", Namespace: "app", Name: "config",
+					},
+				},
+			},
+		},
+	}
+	rawAppliedWork, err := runtime.DefaultUnstructuredConverter.ToUnstructured(appliedWork)
+	if err != nil {
+		t.Fatalf("failed to convert the appliedWork: %v", err)
+	}
+	memberClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(),
+		&unstructured.Unstructured{Object: rawAppliedWork}, liveConfigMap, liveDeployment)
+
+	var out bytes.Buffer
+	e := &workExporter{memberClient: memberClient, clusterNamespace: sourceNamespace, workName: "my-work", out: &out}
+	if err := e.export(context.Background()); err != nil {
+		t.Fatalf("export() = %v, want no error", err)
+	}
+
+	var got placementv1beta1.Work
+	if err := yaml.UnmarshalStrict(out.Bytes(), &got); err != nil {
+		t.Fatalf("failed to unmarshal the exported work %s: %v", out.String(), err)
+	}
+	if got.APIVersion != placementv1beta1.GroupVersion.String() || got.Kind != "Work" || got.Name != "my-work" || got.Namespace != sourceNamespace {
+		t.Errorf("exported work = %s %s %s/%s, want the Work my-work in namespace %s", got.APIVersion, got.Kind, got.Namespace, got.Name, sourceNamespace)
+	}
+	wantManifests := []string{configMapManifest, deploymentManifest}
+	if len(got.Spec.Workload.Manifests) != len(wantManifests) {
+		t.Fatalf("exported manifests = %d, want %d", len(got.Spec.Workload.Manifests), len(wantManifests))
+	}
+	for i, want := range wantManifests {
+		var gotObj, wantObj map[string]interface{}
+		if err := json.Unmarshal(got.Spec.Workload.Manifests[i].Raw, &gotObj); err != nil {
+			t.Fatalf("failed to decode the exported manifest %d: %v", i, err)
+		}
+		if err := json.Unmarshal([]byte(want), &wantObj); err != nil {
+			t.Fatalf("failed to decode the expected manifest %d: %v", i, err)
+		}
+		if diff := cmp.Diff(wantObj, gotObj); diff != "" {
+			t.Errorf("exported manifest %d mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+
+	// the exported work is accepted by the hub cluster and its manifests create their resources anew.
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	if err := hubClient.Create(context.Background(), &got); err != nil {
+		t.Fatalf("failed to create the exported work: %v", err)
+	}
+	newMemberClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	for i, manifest := range got.Spec.Workload.Manifests {
+		obj := unstructuredObj(t, string(manifest.Raw))
+		gvr := map[string]schema.GroupVersionResource{"ConfigMap": configMapGVR, "Deployment": deploymentGVR}[obj.GetKind()]
+		if _, err := newMemberClient.Resource(gvr).Namespace(obj.GetNamespace()).Create(context.Background(), obj, metav1.CreateOptions{}); err != nil {
+			t.Errorf("failed to apply the exported manifest %d: %v", i, err)
+		}
+	}
+}
+
+func TestExportWorkWithoutAppliedWork(t *testing.T) {
+	e := &workExporter{
+		memberClient:     dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()),
+		clusterNamespace: sourceNamespace,
+		workName:         "missing-work",
+		out:              &bytes.Buffer{},
+	}
+	if err := e.export(context.Background()); err == nil {
+		t.Errorf("export() = nil, want the error of the missing appliedWork")
+	}
+}
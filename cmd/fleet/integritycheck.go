@@ -0,0 +1,86 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/workintegrity"
+)
+
+// integrityChecker verifies the manifests of the Work objects against their checksums.
+type integrityChecker struct {
+	hubClient client.Client
+	// namespace limits the check to the works in the namespace; all the works are checked if it is empty.
+	namespace string
+	out       io.Writer
+}
+
+func newIntegrityCheckCmd() *cobra.Command {
+	var namespace, kubeconfig string
+	cmd := &cobra.Command{
+		Use:   "integrity-check",
+		Short: "Verify the manifests of the Work objects against their checksums",
+		Long: `Verify the manifests of the Work objects against their checksums.
+
+Every manifest whose checksum does not match is reported as a discrepancy. The Works without checksums, e.g. those
+created before the checksums were recorded, are skipped. The command fails if any discrepancy is found.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			hubClient, err := newHubClient(kubeconfig)
+			if err != nil {
+				return err
+			}
+			c := &integrityChecker{
+				hubClient: hubClient,
+				namespace: namespace,
+				out:       cmd.OutOrStdout(),
+			}
+			return c.check(cmd.Context())
+		},
+	}
+	cmd.Flags().StringVar(&namespace, "namespace", "", "Namespace of the Work objects to check; all namespaces if empty")
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to the kubeconfig of the hub cluster (optional)")
+	return cmd
+}
+
+// check verifies all the works and reports the discrepancies. It returns an error if any work is corrupted.
+func (c *integrityChecker) check(ctx context.Context) error {
+	var works placementv1beta1.WorkList
+	if err := c.hubClient.List(ctx, &works, client.InNamespace(c.namespace)); err != nil {
+		return fmt.Errorf("failed to list the works: %w", err)
+	}
+	var checked, skipped, violations int
+	for i := range works.Items {
+		work := &works.Items[i]
+		if len(work.Spec.Workload.ManifestChecksums) == 0 {
+			skipped++
+			continue
+		}
+		checked++
+		corrupted, err := workintegrity.CorruptedManifests(work)
+		if err != nil {
+			fmt.Fprintf(c.out, "work %s: failed to read the manifests: %v\n", klog.KObj(work), err)
+			violations++
+			continue
+		}
+		for _, ordinal := range corrupted {
+			fmt.Fprintf(c.out, "work %s: the manifest with ordinal %d does not match its checksum\n", klog.KObj(work), ordinal)
+		}
+		violations += len(corrupted)
+	}
+	fmt.Fprintf(c.out, "checked %d work(s), skipped %d work(s) without checksums, found %d violation(s)\n", checked, skipped, violations)
+	if violations > 0 {
+		return fmt.Errorf("found %d integrity violation(s)", violations)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/workintegrity"
+)
+
+// checksummedWork returns a work whose checksums are computed from the given manifests.
+func checksummedWork(t *testing.T, name string, manifests ...string) *placementv1beta1.Work {
+	t.Helper()
+	work := &placementv1beta1.Work{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: sourceNamespace}}
+	for _, raw := range manifests {
+		work.Spec.Workload.Manifests = append(work.Spec.Workload.Manifests,
+			placementv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: []byte(raw)}})
+	}
+	checksums, err := workintegrity.ManifestChecksums(work.Spec.Workload.Manifests)
+	if err != nil {
+		t.Fatalf("ManifestChecksums() = %v, want no error", err)
+	}
+	work.Spec.Workload.ManifestChecksums = checksums
+	return work
+}
+
+func TestIntegrityCheck(t *testing.T) {
+	const (
+		configMap      = `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm","namespace":"default"}}`
+		otherConfigMap = `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"other-cm","namespace":"default"}}`
+	)
+	// simulate a manifest corrupted in storage after its checksum was recorded.
+	corrupted := checksummedWork(t, "corrupted", configMap, otherConfigMap)
+	corrupted.Spec.Workload.Manifests[1].Raw = []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"other-cm","namespace":"kube-system"}}`)
+	withoutChecksums := checksummedWork(t, "without-checksums", configMap)
+	withoutChecksums.Spec.Workload.ManifestChecksums = nil
+
+	tests := map[string]struct {
+		works      []*placementv1beta1.Work
+		wantErr    bool
+		wantOutput []string
+	}{
+		"intact works": {
+			works:      []*placementv1beta1.Work{checksummedWork(t, "intact", configMap, otherConfigMap), withoutChecksums},
+			wantOutput: []string{"checked 1 work(s), skipped 1 work(s) without checksums, found 0 violation(s)"},
+		},
+		"checksum mismatch": {
+			works:   []*placementv1beta1.Work{checksummedWork(t, "intact", configMap), corrupted},
+			wantErr: true,
+			wantOutput: []string{
+				"work " + sourceNamespace + "/corrupted: the manifest with ordinal 1 does not match its checksum",
+				"checked 2 work(s), skipped 0 work(s) without checksums, found 1 violation(s)",
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := placementv1beta1.AddToScheme(scheme); err != nil {
+				t.Fatalf("failed to add the placement scheme: %v", err)
+			}
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			for _, work := range tt.works {
+				builder = builder.WithObjects(work)
+			}
+			var out bytes.Buffer
+			c := &integrityChecker{hubClient: builder.Build(), out: &out}
+
+			err := c.check(context.Background())
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("check() = %v, want error %t", err, tt.wantErr)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("check() output = %q, want it to contain %q", out.String(), want)
+				}
+			}
+		})
+	}
+}
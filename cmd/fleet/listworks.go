@@ -0,0 +1,258 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/compression"
+)
+
+const (
+	// listWorksPageSize is the number of the Work objects fetched in one list request.
+	listWorksPageSize = 100
+
+	outputFormatJSON  = "json"
+	outputFormatTable = "table"
+	outputFormatCSV   = "csv"
+)
+
+// workListHeaders are the columns of the table and CSV outputs of the works.
+var workListHeaders = []string{"NAMESPACE", "NAME", "MANIFESTS", "APPLIED", "AVAILABLE"}
+
+// workLister lists the Work objects page by page and writes each page as soon as it is fetched.
+type workLister struct {
+	hubClient client.Client
+	// namespace limits the list to the works in the namespace; all the works are listed if it is empty.
+	namespace string
+	// maxItems is the maximum number of the works listed; there is no limit if it is 0.
+	maxItems int
+	pageSize int
+	printer  workPrinter
+}
+
+// workPrinter writes the works in an output format.
+type workPrinter interface {
+	// printPage writes a page of the works.
+	printPage(works []placementv1beta1.Work) error
+	// finish completes the output once all the pages are written.
+	finish() error
+}
+
+func newListWorksCmd() *cobra.Command {
+	var namespace, kubeconfig, output string
+	var maxItems int
+	cmd := &cobra.Command{
+		Use:   "list-works",
+		Short: "List the Work objects page by page",
+		Long: `List the Work objects page by page.
+
+The Works are fetched in pages of 100 and each page is written as soon as it is fetched, so that listing thousands of
+Works neither waits for all of them nor holds them all in memory.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if maxItems < 0 {
+				return fmt.Errorf("--max-items must not be negative, got %d", maxItems)
+			}
+			printer, err := newWorkPrinter(output, cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			hubClient, err := newHubClient(kubeconfig)
+			if err != nil {
+				return err
+			}
+			l := &workLister{
+				hubClient: hubClient,
+				namespace: namespace,
+				maxItems:  maxItems,
+				pageSize:  listWorksPageSize,
+				printer:   printer,
+			}
+			return l.list(cmd.Context())
+		},
+	}
+	cmd.Flags().StringVar(&namespace, "namespace", "", "Namespace of the Work objects to list; all namespaces if empty")
+	cmd.Flags().IntVar(&maxItems, "max-items", 0, "Maximum number of the Work objects to list; no limit if 0")
+	cmd.Flags().StringVarP(&output, "output", "o", outputFormatTable, "Output format, one of json, table or csv")
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to the kubeconfig of the hub cluster (optional)")
+	return cmd
+}
+
+// list fetches the works page by page until there are no more pages or the maximum number of works is reached.
+func (l *workLister) list(ctx context.Context) error {
+	listed := 0
+	continueToken := ""
+	for {
+		limit := l.pageSize
+		if l.maxItems > 0 && l.maxItems-listed < limit {
+			limit = l.maxItems - listed
+		}
+		var works placementv1beta1.WorkList
+		opts := []client.ListOption{client.InNamespace(l.namespace), client.Limit(int64(limit))}
+		if continueToken != "" {
+			opts = append(opts, client.Continue(continueToken))
+		}
+		if err := l.hubClient.List(ctx, &works, opts...); err != nil {
+			return fmt.Errorf("failed to list the works: %w", err)
+		}
+		// the API server may return more items than asked for if it does not support pagination.
+		if len(works.Items) > limit {
+			works.Items = works.Items[:limit]
+		}
+		if err := l.printer.printPage(works.Items); err != nil {
+			return err
+		}
+		listed += len(works.Items)
+		continueToken = works.Continue
+		if continueToken == "" || (l.maxItems > 0 && listed >= l.maxItems) {
+			return l.printer.finish()
+		}
+	}
+}
+
+func newWorkPrinter(format string, out io.Writer) (workPrinter, error) {
+	switch format {
+	case outputFormatJSON:
+		return &jsonWorkPrinter{out: out}, nil
+	case outputFormatTable:
+		return &tableWorkPrinter{out: tabwriter.NewWriter(out, 0, 8, 3, ' ', 0)}, nil
+	case outputFormatCSV:
+		return &csvWorkPrinter{out: csv.NewWriter(out)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported output format %q, must be one of json, table or csv", format)
+	}
+}
+
+// workListRow returns the columns of the work in the table and CSV outputs.
+func workListRow(work *placementv1beta1.Work) ([]string, error) {
+	if err := compression.DecompressWork(work); err != nil {
+		return nil, fmt.Errorf("failed to decompress the manifests of work %s/%s: %w", work.Namespace, work.Name, err)
+	}
+	conditionStatus := func(conditionType string) string {
+		if cond := meta.FindStatusCondition(work.Status.Conditions, conditionType); cond != nil {
+			return string(cond.Status)
+		}
+		return "Unknown"
+	}
+	return []string{
+		work.Namespace,
+		work.Name,
+		strconv.Itoa(len(work.Spec.Workload.Manifests)),
+		conditionStatus(placementv1beta1.WorkConditionTypeApplied),
+		conditionStatus(placementv1beta1.WorkConditionTypeAvailable),
+	}, nil
+}
+
+// jsonWorkPrinter writes the works as a JSON array, one work per line.
+type jsonWorkPrinter struct {
+	out     io.Writer
+	printed int
+}
+
+func (p *jsonWorkPrinter) printPage(works []placementv1beta1.Work) error {
+	for i := range works {
+		raw, err := json.Marshal(&works[i])
+		if err != nil {
+			return fmt.Errorf("failed to encode work %s/%s: %w", works[i].Namespace, works[i].Name, err)
+		}
+		separator := ",\n"
+		if p.printed == 0 {
+			separator = "[\n"
+		}
+		if _, err := fmt.Fprintf(p.out, "%s%s", separator, raw); err != nil {
+			return err
+		}
+		p.printed++
+	}
+	return nil
+}
+
+func (p *jsonWorkPrinter) finish() error {
+	if p.printed == 0 {
+		_, err := io.WriteString(p.out, "[]\n")
+		return err
+	}
+	_, err := io.WriteString(p.out, "\n]\n")
+	return err
+}
+
+// tableWorkPrinter writes the works as a table; the columns are aligned within each page as the page is written
+// before the next one is fetched.
+type tableWorkPrinter struct {
+	out           *tabwriter.Writer
+	headerPrinted bool
+}
+
+func (p *tableWorkPrinter) printPage(works []placementv1beta1.Work) error {
+	if !p.headerPrinted {
+		p.writeRow(workListHeaders)
+		p.headerPrinted = true
+	}
+	for i := range works {
+		row, err := workListRow(&works[i])
+		if err != nil {
+			return err
+		}
+		p.writeRow(row)
+	}
+	return p.out.Flush()
+}
+
+func (p *tableWorkPrinter) writeRow(row []string) {
+	for i, cell := range row {
+		if i > 0 {
+			fmt.Fprint(p.out, "\t")
+		}
+		fmt.Fprint(p.out, cell)
+	}
+	fmt.Fprintln(p.out)
+}
+
+func (p *tableWorkPrinter) finish() error {
+	return p.out.Flush()
+}
+
+// csvWorkPrinter writes the works as CSV records.
+type csvWorkPrinter struct {
+	out           *csv.Writer
+	headerPrinted bool
+}
+
+func (p *csvWorkPrinter) printPage(works []placementv1beta1.Work) error {
+	if !p.headerPrinted {
+		if err := p.out.Write(workListHeaders); err != nil {
+			return err
+		}
+		p.headerPrinted = true
+	}
+	for i := range works {
+		row, err := workListRow(&works[i])
+		if err != nil {
+			return err
+		}
+		if err := p.out.Write(row); err != nil {
+			return err
+		}
+	}
+	p.out.Flush()
+	return p.out.Error()
+}
+
+func (p *csvWorkPrinter) finish() error {
+	p.out.Flush()
+	return p.out.Error()
+}
@@ -0,0 +1,266 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// pagedWorkClient serves the works page by page like the API server, recording the limit and the continue token
+// of every list request.
+type pagedWorkClient struct {
+	works    []placementv1beta1.Work
+	requests []client.ListOptions
+}
+
+func (p *pagedWorkClient) build(t *testing.T) client.Client {
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		List: func(_ context.Context, _ client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			listOpts := client.ListOptions{}
+			listOpts.ApplyOptions(opts)
+			p.requests = append(p.requests, listOpts)
+			start := 0
+			if listOpts.Continue != "" {
+				var err error
+				if start, err = strconv.Atoi(listOpts.Continue); err != nil {
+					return fmt.Errorf("invalid continue token %q", listOpts.Continue)
+				}
+			}
+			end := start + int(listOpts.Limit)
+			if listOpts.Limit == 0 || end > len(p.works) {
+				end = len(p.works)
+			}
+			workList := list.(*placementv1beta1.WorkList)
+			workList.Items = append([]placementv1beta1.Work(nil), p.works[start:end]...)
+			workList.Continue = ""
+			if end < len(p.works) {
+				workList.Continue = strconv.Itoa(end)
+			}
+			return nil
+		},
+	}).Build()
+}
+
+func listTestWork(name string, manifests int, applied, available metav1.ConditionStatus) placementv1beta1.Work {
+	work := placementv1beta1.Work{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: sourceNamespace}}
+	for i := 0; i < manifests; i++ {
+		work.Spec.Workload.Manifests = append(work.Spec.Workload.Manifests,
+			placementv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap"}`)}})
+	}
+	// the conditions are encoded to JSON in the precision of seconds.
+	transitionTime := metav1.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	if applied != "" {
+		meta.SetStatusCondition(&work.Status.Conditions, metav1.Condition{Type: placementv1beta1.WorkConditionTypeApplied, Status: applied, Reason: "Test", LastTransitionTime: transitionTime})
+	}
+	if available != "" {
+		meta.SetStatusCondition(&work.Status.Conditions, metav1.Condition{Type: placementv1beta1.WorkConditionTypeAvailable, Status: available, Reason: "Test", LastTransitionTime: transitionTime})
+	}
+	return work
+}
+
+// namesPrinter records the names of the works in each page.
+type namesPrinter struct {
+	pages    [][]string
+	finished bool
+}
+
+func (p *namesPrinter) printPage(works []placementv1beta1.Work) error {
+	names := []string{}
+	for _, work := range works {
+		names = append(names, work.Name)
+	}
+	p.pages = append(p.pages, names)
+	return nil
+}
+
+func (p *namesPrinter) finish() error {
+	p.finished = true
+	return nil
+}
+
+func TestListWorksPagination(t *testing.T) {
+	works := make([]placementv1beta1.Work, 250)
+	for i := range works {
+		works[i] = listTestWork(fmt.Sprintf("work-%03d", i), 1, "", "")
+	}
+	tests := map[string]struct {
+		maxItems      int
+		wantPageSizes []int
+		wantRequests  []client.ListOptions
+	}{
+		"all the pages are fetched": {
+			wantPageSizes: []int{100, 100, 50},
+			wantRequests: []client.ListOptions{
+				{Namespace: sourceNamespace, Limit: 100},
+				{Namespace: sourceNamespace, Limit: 100, Continue: "100"},
+				{Namespace: sourceNamespace, Limit: 100, Continue: "200"},
+			},
+		},
+		"max items within the first page": {
+			maxItems:      30,
+			wantPageSizes: []int{30},
+			wantRequests:  []client.ListOptions{{Namespace: sourceNamespace, Limit: 30}},
+		},
+		"max items across the pages": {
+			maxItems:      150,
+			wantPageSizes: []int{100, 50},
+			wantRequests: []client.ListOptions{
+				{Namespace: sourceNamespace, Limit: 100},
+				{Namespace: sourceNamespace, Limit: 50, Continue: "100"},
+			},
+		},
+		"max items more than the works": {
+			maxItems:      1000,
+			wantPageSizes: []int{100, 100, 50},
+			wantRequests: []client.ListOptions{
+				{Namespace: sourceNamespace, Limit: 100},
+				{Namespace: sourceNamespace, Limit: 100, Continue: "100"},
+				{Namespace: sourceNamespace, Limit: 100, Continue: "200"},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			paged := &pagedWorkClient{works: works}
+			printer := &namesPrinter{}
+			l := &workLister{
+				hubClient: paged.build(t),
+				namespace: sourceNamespace,
+				maxItems:  tt.maxItems,
+				pageSize:  listWorksPageSize,
+				printer:   printer,
+			}
+			if err := l.list(context.Background()); err != nil {
+				t.Fatalf("list() = %v, want no error", err)
+			}
+			if diff := cmp.Diff(tt.wantRequests, paged.requests); diff != "" {
+				t.Errorf("list() requests mismatch (-want +got):\n%s", diff)
+			}
+			var pageSizes []int
+			var names []string
+			for _, page := range printer.pages {
+				pageSizes = append(pageSizes, len(page))
+				names = append(names, page...)
+			}
+			if diff := cmp.Diff(tt.wantPageSizes, pageSizes); diff != "" {
+				t.Errorf("list() page sizes mismatch (-want +got):\n%s", diff)
+			}
+			// the works are listed in order without any duplicate.
+			for i, name := range names {
+				if want := works[i].Name; name != want {
+					t.Fatalf("list() work %d = %s, want %s", i, name, want)
+				}
+			}
+			if !printer.finished {
+				t.Errorf("list() did not finish the output")
+			}
+		})
+	}
+}
+
+func TestListWorksOutput(t *testing.T) {
+	works := []placementv1beta1.Work{
+		listTestWork("app", 2, metav1.ConditionTrue, metav1.ConditionTrue),
+		listTestWork("app-with-a-longer-name", 1, metav1.ConditionFalse, ""),
+		listTestWork("new", 3, "", ""),
+	}
+	tests := map[string]struct {
+		works []placementv1beta1.Work
+		want  string
+	}{
+		"table": {
+			works: works,
+			// the columns are aligned within each page of 2 works.
+			want: "NAMESPACE               NAME                     MANIFESTS   APPLIED   AVAILABLE\n" +
+				"fleet-member-cluster1   app                      2           True      True\n" +
+				"fleet-member-cluster1   app-with-a-longer-name   1           False     Unknown\n" +
+				"fleet-member-cluster1   new   3   Unknown   Unknown\n",
+		},
+		"csv": {
+			works: works,
+			want: "NAMESPACE,NAME,MANIFESTS,APPLIED,AVAILABLE\n" +
+				"fleet-member-cluster1,app,2,True,True\n" +
+				"fleet-member-cluster1,app-with-a-longer-name,1,False,Unknown\n" +
+				"fleet-member-cluster1,new,3,Unknown,Unknown\n",
+		},
+		"empty table": {
+			want: "NAMESPACE   NAME   MANIFESTS   APPLIED   AVAILABLE\n",
+		},
+		"empty json": {
+			want: "[]\n",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			format := name
+			if len(tt.works) == 0 {
+				format = format[len("empty "):]
+			}
+			var out bytes.Buffer
+			printer, err := newWorkPrinter(format, &out)
+			if err != nil {
+				t.Fatalf("newWorkPrinter() = %v, want no error", err)
+			}
+			l := &workLister{hubClient: (&pagedWorkClient{works: tt.works}).build(t), pageSize: 2, printer: printer}
+			if err := l.list(context.Background()); err != nil {
+				t.Fatalf("list() = %v, want no error", err)
+			}
+			if diff := cmp.Diff(tt.want, out.String()); diff != "" {
+				t.Errorf("list() output mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestListWorksJSONOutput(t *testing.T) {
+	works := []placementv1beta1.Work{
+		listTestWork("app", 2, metav1.ConditionTrue, metav1.ConditionTrue),
+		listTestWork("other", 1, "", ""),
+		listTestWork("new", 3, "", ""),
+	}
+	var out bytes.Buffer
+	printer, err := newWorkPrinter(outputFormatJSON, &out)
+	if err != nil {
+		t.Fatalf("newWorkPrinter() = %v, want no error", err)
+	}
+	l := &workLister{hubClient: (&pagedWorkClient{works: works}).build(t), pageSize: 2, printer: printer}
+	if err := l.list(context.Background()); err != nil {
+		t.Fatalf("list() = %v, want no error", err)
+	}
+	// the works written across the pages make up a single JSON array.
+	var got []placementv1beta1.Work
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("failed to decode the output %q: %v", out.String(), err)
+	}
+	if diff := cmp.Diff(works, got); diff != "" {
+		t.Errorf("list() works mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewWorkPrinterUnsupportedFormat(t *testing.T) {
+	if _, err := newWorkPrinter("yaml", &bytes.Buffer{}); err == nil {
+		t.Errorf("newWorkPrinter(yaml) = nil, want an error")
+	}
+}
@@ -0,0 +1,41 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/klog/v2"
+)
+
+func newRootCmd() *cobra.Command {
+	rootCmd := &cobra.Command{Use: "fleet", Args: cobra.NoArgs, SilenceUsage: true}
+	rootCmd.AddCommand(newWorkDepsCmd())
+	rootCmd.AddCommand(newMigrateWorkCmd())
+	rootCmd.AddCommand(newIntegrityCheckCmd())
+	rootCmd.AddCommand(newWorkCmd())
+	rootCmd.AddCommand(newListWorksCmd())
+	rootCmd.AddCommand(newExportWorkCmd())
+	rootCmd.AddCommand(newScanCmd())
+	return rootCmd
+}
+
+func main() {
+	klog.InitFlags(nil)
+
+	// Add go flags (e.g., --v) to pflag.
+	// Reference: https://github.com/spf13/pflag#supporting-go-flags-when-using-pflag
+	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
+
+	defer klog.Flush()
+	if err := newRootCmd().Execute(); err != nil {
+		klog.ErrorS(err, "error has occurred while running the fleet command")
+		klog.Flush()
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,190 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/condition"
+)
+
+const (
+	// workAppliedPollInterval is how often the migrated works are checked for the applied condition.
+	workAppliedPollInterval = 2 * time.Second
+)
+
+// workMigrator moves the Work objects from a source namespace to a target namespace.
+type workMigrator struct {
+	hubClient client.Client
+	from, to  string
+	// timeout is how long to wait for a migrated work to be applied in the target namespace.
+	timeout      time.Duration
+	pollInterval time.Duration
+	out          io.Writer
+}
+
+func newMigrateWorkCmd() *cobra.Command {
+	var from, to, kubeconfig string
+	var timeout time.Duration
+	cmd := &cobra.Command{
+		Use:   "migrate-work",
+		Short: "Move the Work objects of a member cluster from one reserved namespace to another",
+		Long: `Move the Work objects of a member cluster from one reserved namespace to another.
+
+Each Work is re-created in the target namespace and its source is deleted only after the copy is applied.
+A copy which fails to be applied is deleted again so that the source stays in charge, and the command can be
+re-run to retry the remaining Works.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if from == to {
+				return fmt.Errorf("the source and the target namespaces are both %q", from)
+			}
+			hubClient, err := newHubClient(kubeconfig)
+			if err != nil {
+				return err
+			}
+			m := &workMigrator{
+				hubClient:    hubClient,
+				from:         from,
+				to:           to,
+				timeout:      timeout,
+				pollInterval: workAppliedPollInterval,
+				out:          cmd.OutOrStdout(),
+			}
+			return m.migrate(cmd.Context())
+		},
+	}
+	cmd.Flags().StringVar(&from, "from", "", "Source namespace of the Work objects (required)")
+	_ = cmd.MarkFlagRequired("from")
+	cmd.Flags().StringVar(&to, "to", "", "Target namespace of the Work objects (required)")
+	_ = cmd.MarkFlagRequired("to")
+	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Minute, "How long to wait for each migrated Work to be applied")
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to the kubeconfig of the hub cluster (optional)")
+	return cmd
+}
+
+// migrate moves all the works in the source namespace to the target namespace.
+// A failure to migrate one work does not stop the others; the errors are aggregated.
+func (m *workMigrator) migrate(ctx context.Context) error {
+	var works placementv1beta1.WorkList
+	if err := m.hubClient.List(ctx, &works, client.InNamespace(m.from)); err != nil {
+		return fmt.Errorf("failed to list the works in namespace %s: %w", m.from, err)
+	}
+	var errs []error
+	for i := range works.Items {
+		work := &works.Items[i]
+		if err := m.migrateWork(ctx, work); err != nil {
+			fmt.Fprintf(m.out, "work %s: migration failed: %v\n", work.Name, err)
+			errs = append(errs, fmt.Errorf("work %s: %w", work.Name, err))
+			continue
+		}
+		fmt.Fprintf(m.out, "work %s: migrated from %s to %s\n", work.Name, m.from, m.to)
+	}
+	return errors.Join(errs...)
+}
+
+// migrateWork moves a single work. On failure, either the source work is kept and no copy that was created by this
+// call is left behind, or the copy is applied and only the deletion of the source has to be retried.
+func (m *workMigrator) migrateWork(ctx context.Context, source *placementv1beta1.Work) error {
+	if !source.DeletionTimestamp.IsZero() {
+		return fmt.Errorf("the source work is being deleted")
+	}
+	target, created, err := m.ensureTargetWork(ctx, source)
+	if err != nil {
+		return err
+	}
+	if err := m.waitForApplied(ctx, target); err != nil {
+		if created {
+			if rollbackErr := m.deleteWork(ctx, target); rollbackErr != nil {
+				return fmt.Errorf("%w; failed to delete the target work: %v", err, rollbackErr)
+			}
+		}
+		return err
+	}
+	if err := m.deleteWork(ctx, source); err != nil {
+		return fmt.Errorf("failed to delete the source work: %w", err)
+	}
+	return nil
+}
+
+// ensureTargetWork creates the copy of the source work in the target namespace.
+// An existing copy with the same spec, e.g. left by a previous run, is reused.
+func (m *workMigrator) ensureTargetWork(ctx context.Context, source *placementv1beta1.Work) (*placementv1beta1.Work, bool, error) {
+	var existing placementv1beta1.Work
+	err := m.hubClient.Get(ctx, types.NamespacedName{Name: source.Name, Namespace: m.to}, &existing)
+	switch {
+	case err == nil:
+		if !equality.Semantic.DeepEqual(existing.Spec, source.Spec) {
+			return nil, false, fmt.Errorf("a work with a different spec already exists in namespace %s", m.to)
+		}
+		return &existing, false, nil
+	case !apierrors.IsNotFound(err):
+		return nil, false, fmt.Errorf("failed to get the target work: %w", err)
+	}
+
+	target := &placementv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            source.Name,
+			Namespace:       m.to,
+			Labels:          source.Labels,
+			Annotations:     source.Annotations,
+			OwnerReferences: source.OwnerReferences,
+		},
+		Spec: *source.Spec.DeepCopy(),
+	}
+	if err := m.hubClient.Create(ctx, target); err != nil {
+		return nil, false, fmt.Errorf("failed to create the target work: %w", err)
+	}
+	return target, true, nil
+}
+
+// waitForApplied waits until the work is applied with its latest generation.
+func (m *workMigrator) waitForApplied(ctx context.Context, work *placementv1beta1.Work) error {
+	key := client.ObjectKeyFromObject(work)
+	err := wait.PollUntilContextTimeout(ctx, m.pollInterval, m.timeout, true, func(ctx context.Context) (bool, error) {
+		var current placementv1beta1.Work
+		if err := m.hubClient.Get(ctx, key, &current); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		appliedCond := meta.FindStatusCondition(current.Status.Conditions, placementv1beta1.WorkConditionTypeApplied)
+		return condition.IsConditionStatusTrue(appliedCond, current.Generation), nil
+	})
+	if err != nil {
+		return fmt.Errorf("the target work is not applied: %w", err)
+	}
+	return nil
+}
+
+// deleteWork deletes a work without garbage collecting its applied resources.
+// The work finalizer is removed first as the applied work is shared by the works with the same name in the source and
+// the target namespaces, so the member agent must not garbage collect it on behalf of the deleted one.
+func (m *workMigrator) deleteWork(ctx context.Context, work *placementv1beta1.Work) error {
+	var current placementv1beta1.Work
+	if err := m.hubClient.Get(ctx, client.ObjectKeyFromObject(work), &current); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if controllerutil.ContainsFinalizer(&current, placementv1beta1.WorkFinalizer) {
+		controllerutil.RemoveFinalizer(&current, placementv1beta1.WorkFinalizer)
+		if err := m.hubClient.Update(ctx, &current); err != nil {
+			return fmt.Errorf("failed to remove the work finalizer: %w", err)
+		}
+	}
+	return client.IgnoreNotFound(m.hubClient.Delete(ctx, &current))
+}
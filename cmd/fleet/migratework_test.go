@@ -0,0 +1,131 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+package main
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	sourceNamespace = "fleet-member-cluster1"
+	targetNamespace = "fleet-member-prod-cluster1"
+)
+
+func migrationTestWork(name, namespace string) *placementv1beta1.Work {
+	return &placementv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Namespace:  namespace,
+			Labels:     map[string]string{"app": name},
+			Finalizers: []string{placementv1beta1.WorkFinalizer},
+		},
+		Spec: placementv1beta1.WorkSpec{
+			Workload: placementv1beta1.WorkloadTemplate{
+				Manifests: []placementv1beta1.Manifest{{RawExtension: runtime.RawExtension{
+					Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"` + name + `","namespace":"default"}}`),
+				}}},
+			},
+		},
+	}
+}
+
+func TestMigrateWorks(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	conflicting := migrationTestWork("conflicting", targetNamespace)
+	conflicting.Spec.Workload.Manifests = nil
+	hubClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			migrationTestWork("applied", sourceNamespace),
+			migrationTestWork("not-applied", sourceNamespace),
+			migrationTestWork("conflicting", sourceNamespace),
+			conflicting,
+		).
+		WithInterceptorFuncs(interceptor.Funcs{
+			// the member agent applies the works in the target namespace except for the one named "not-applied".
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if work, ok := obj.(*placementv1beta1.Work); ok && work.Name != "not-applied" {
+					work.Finalizers = []string{placementv1beta1.WorkFinalizer}
+					meta.SetStatusCondition(&work.Status.Conditions, metav1.Condition{
+						Type:   placementv1beta1.WorkConditionTypeApplied,
+						Status: metav1.ConditionTrue,
+						Reason: "Applied",
+					})
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	m := &workMigrator{
+		hubClient:    hubClient,
+		from:         sourceNamespace,
+		to:           targetNamespace,
+		timeout:      50 * time.Millisecond,
+		pollInterval: 10 * time.Millisecond,
+		out:          io.Discard,
+	}
+	if err := m.migrate(context.Background()); err == nil {
+		t.Fatalf("migrate() = nil, want an error for the works which cannot be migrated")
+	}
+
+	workNames := func(namespace string) []string {
+		var works placementv1beta1.WorkList
+		if err := hubClient.List(context.Background(), &works, client.InNamespace(namespace)); err != nil {
+			t.Fatalf("failed to list the works in namespace %s: %v", namespace, err)
+		}
+		names := make([]string, 0, len(works.Items))
+		for _, work := range works.Items {
+			names = append(names, work.Name)
+		}
+		return names
+	}
+	sortStrings := cmpopts.SortSlices(func(a, b string) bool { return a < b })
+	// the applied work is moved, the work failing to apply is rolled back and the conflicting work is left untouched.
+	if diff := cmp.Diff([]string{"not-applied", "conflicting"}, workNames(sourceNamespace), sortStrings); diff != "" {
+		t.Errorf("source works mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"applied", "conflicting"}, workNames(targetNamespace), sortStrings); diff != "" {
+		t.Errorf("target works mismatch (-want +got):\n%s", diff)
+	}
+
+	var migrated placementv1beta1.Work
+	if err := hubClient.Get(context.Background(), types.NamespacedName{Name: "applied", Namespace: targetNamespace}, &migrated); err != nil {
+		t.Fatalf("failed to get the migrated work: %v", err)
+	}
+	want := migrationTestWork("applied", targetNamespace)
+	if diff := cmp.Diff(want.Spec, migrated.Spec); diff != "" {
+		t.Errorf("migrated work spec mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(want.Labels, migrated.Labels); diff != "" {
+		t.Errorf("migrated work labels mismatch (-want +got):\n%s", diff)
+	}
+
+	// the copy of the work failing to apply is deleted from the target namespace.
+	var notApplied placementv1beta1.Work
+	err := hubClient.Get(context.Background(), types.NamespacedName{Name: "not-applied", Namespace: targetNamespace}, &notApplied)
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("the rolled back target work still exists: %v", err)
+	}
+}
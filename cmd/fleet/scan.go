@@ -0,0 +1,284 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/jsonpath"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// compliancePolicy is the set of rules the resources applied for the works are checked against.
+type compliancePolicy struct {
+	Rules []complianceRule `json:"rules"`
+}
+
+// complianceRule checks the value found at a JSON path in the resources of a kind.
+type complianceRule struct {
+	// Name identifies the rule in the reports.
+	Name string `json:"name"`
+	// Group and Kind select the resources the rule is evaluated against; the rule applies to all the resources if
+	// Kind is empty. An empty Group is the core group.
+	Group string `json:"group,omitempty"`
+	Kind  string `json:"kind,omitempty"`
+	// Path is the JSON path of the checked field, e.g. `{.spec.template.spec.containers[*].resources.limits}`.
+	Path string `json:"path"`
+	// Expected is the value every field found at the path must equal; the fields only need to be set and not empty
+	// if it is nil.
+	Expected *string `json:"expected,omitempty"`
+
+	parsed *jsonpath.JSONPath
+}
+
+// complianceScanner evaluates the policy rules against the resources applied for the works of a member cluster.
+type complianceScanner struct {
+	hubClient    client.Client
+	memberClient dynamic.Interface
+	// namespace is the reserved namespace of the member cluster on the hub cluster.
+	namespace string
+	policy    *compliancePolicy
+	out       io.Writer
+	now       func() metav1.Time
+}
+
+func newScanCmd() *cobra.Command {
+	var policyFile, namespace, kubeconfig, memberKubeconfig string
+	cmd := &cobra.Command{
+		Use:   "scan",
+		Short: "Check the resources applied for the Work objects of a member cluster against policy rules",
+		Long: `Check the resources applied for the Work objects of a member cluster against policy rules.
+
+The policy file lists the rules, each of which checks the value found at a JSON path in the resources of a kind:
+
+  rules:
+  - name: deployment-resource-limits
+    group: apps
+    kind: Deployment
+    path: "{.spec.template.spec.containers[*].resources.limits}"
+  - name: service-type
+    kind: Service
+    path: "{.spec.type}"
+    expected: ClusterIP
+
+A rule without an expected value only requires the fields at its path to be set and not empty. The live resources
+are read from the member cluster through the AppliedWork of every Work in the namespace, and the report of each Work
+is stored in its status. The command fails if any resource does not comply with a rule.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			policy, err := loadCompliancePolicy(policyFile)
+			if err != nil {
+				return err
+			}
+			hubClient, err := newHubClient(kubeconfig)
+			if err != nil {
+				return err
+			}
+			memberClient, err := newMemberDynamicClient(memberKubeconfig)
+			if err != nil {
+				return err
+			}
+			s := &complianceScanner{
+				hubClient:    hubClient,
+				memberClient: memberClient,
+				namespace:    namespace,
+				policy:       policy,
+				out:          cmd.OutOrStdout(),
+				now:          metav1.Now,
+			}
+			return s.scan(cmd.Context())
+		},
+	}
+	cmd.Flags().StringVar(&policyFile, "policy-file", "", "Path to the YAML file of the policy rules (required)")
+	_ = cmd.MarkFlagRequired("policy-file")
+	cmd.Flags().StringVar(&namespace, "namespace", "", "Reserved namespace of the member cluster on the hub cluster (required)")
+	_ = cmd.MarkFlagRequired("namespace")
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to the kubeconfig of the hub cluster (optional)")
+	cmd.Flags().StringVar(&memberKubeconfig, "member-kubeconfig", "", "Path to the kubeconfig of the member cluster (optional)")
+	return cmd
+}
+
+// loadCompliancePolicy reads the policy file and parses the JSON paths of its rules.
+func loadCompliancePolicy(path string) (*compliancePolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the policy file: %w", err)
+	}
+	return parseCompliancePolicy(data)
+}
+
+func parseCompliancePolicy(data []byte) (*compliancePolicy, error) {
+	var policy compliancePolicy
+	if err := yaml.UnmarshalStrict(data, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse the policy: %w", err)
+	}
+	names := make(map[string]bool, len(policy.Rules))
+	for i := range policy.Rules {
+		rule := &policy.Rules[i]
+		if rule.Name == "" || rule.Path == "" {
+			return nil, fmt.Errorf("the rule %d must have a name and a path", i)
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("the rule name %s is duplicated", rule.Name)
+		}
+		names[rule.Name] = true
+		rule.parsed = jsonpath.New(rule.Name).AllowMissingKeys(true)
+		if err := rule.parsed.Parse(rule.Path); err != nil {
+			return nil, fmt.Errorf("failed to parse the path of the rule %s: %w", rule.Name, err)
+		}
+	}
+	return &policy, nil
+}
+
+// scan evaluates the rules for every work in the namespace and stores the reports in the work status. It returns an
+// error if any resource does not comply with a rule.
+func (s *complianceScanner) scan(ctx context.Context) error {
+	var works placementv1beta1.WorkList
+	if err := s.hubClient.List(ctx, &works, client.InNamespace(s.namespace)); err != nil {
+		return fmt.Errorf("failed to list the works: %w", err)
+	}
+	var scanned, failed int
+	for i := range works.Items {
+		work := &works.Items[i]
+		appliedWork, err := getAppliedWork(ctx, s.memberClient, work.Name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				fmt.Fprintf(s.out, "work %s: skipped as it is not applied to the member cluster\n", klog.KObj(work))
+				continue
+			}
+			return err
+		}
+		report := s.evaluate(ctx, appliedWork.Status.AppliedResources)
+		for _, result := range report.Results {
+			if !result.Passed {
+				fmt.Fprintf(s.out, "work %s: the %s %s/%s of the manifest with ordinal %d violates the rule %s: %s\n",
+					klog.KObj(work), result.Identifier.Kind, result.Identifier.Namespace, result.Identifier.Name,
+					result.Identifier.Ordinal, result.Rule, result.Message)
+			}
+		}
+		if err := s.storeReport(ctx, work, report); err != nil {
+			return err
+		}
+		scanned++
+		failed += report.FailedCount
+	}
+	fmt.Fprintf(s.out, "scanned %d work(s), found %d violation(s)\n", scanned, failed)
+	if failed > 0 {
+		return fmt.Errorf("found %d compliance violation(s)", failed)
+	}
+	return nil
+}
+
+// evaluate evaluates every rule against the applied resources it matches.
+func (s *complianceScanner) evaluate(ctx context.Context, resources []placementv1beta1.AppliedResourceMeta) *placementv1beta1.ComplianceReport {
+	report := &placementv1beta1.ComplianceReport{ScanTime: s.now()}
+	for _, res := range resources {
+		var live *unstructured.Unstructured
+		var getErr error
+		for i := range s.policy.Rules {
+			rule := &s.policy.Rules[i]
+			if !rule.matches(res.WorkResourceIdentifier) {
+				continue
+			}
+			// the resource is only read if any rule applies to it.
+			if live == nil && getErr == nil {
+				live, getErr = getAppliedResource(ctx, s.memberClient, res)
+			}
+			result := placementv1beta1.ComplianceResult{Identifier: res.WorkResourceIdentifier, Rule: rule.Name}
+			if getErr != nil {
+				result.Message = getErr.Error()
+			} else {
+				result.Passed, result.Message = rule.evaluate(live)
+			}
+			if result.Passed {
+				report.PassedCount++
+			} else {
+				report.FailedCount++
+			}
+			report.Results = append(report.Results, result)
+		}
+	}
+	return report
+}
+
+// storeReport stores the report in the status of the work.
+func (s *complianceScanner) storeReport(ctx context.Context, work *placementv1beta1.Work, report *placementv1beta1.ComplianceReport) error {
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if err := s.hubClient.Get(ctx, client.ObjectKeyFromObject(work), work); err != nil {
+			return err
+		}
+		work.Status.LastComplianceReport = report
+		return s.hubClient.Status().Update(ctx, work)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store the compliance report of the work %s: %w", klog.KObj(work), err)
+	}
+	return nil
+}
+
+// matches returns whether the rule applies to the resource.
+func (r *complianceRule) matches(id placementv1beta1.WorkResourceIdentifier) bool {
+	if r.Kind == "" {
+		return true
+	}
+	return r.Kind == id.Kind && r.Group == id.Group
+}
+
+// evaluate returns whether the resource complies with the rule, and the reason if it does not.
+func (r *complianceRule) evaluate(obj *unstructured.Unstructured) (bool, string) {
+	results, err := r.parsed.FindResults(obj.Object)
+	if err != nil {
+		return false, fmt.Sprintf("failed to evaluate the path %s: %v", r.Path, err)
+	}
+	found := 0
+	for _, values := range results {
+		for _, value := range values {
+			found++
+			if r.Expected == nil {
+				if isEmptyValue(value) {
+					return false, fmt.Sprintf("the field at %s is empty", r.Path)
+				}
+				continue
+			}
+			if got := fmt.Sprint(value.Interface()); got != *r.Expected {
+				return false, fmt.Sprintf("the field at %s is %q, want %q", r.Path, got, *r.Expected)
+			}
+		}
+	}
+	if found == 0 {
+		return false, fmt.Sprintf("no field is set at %s", r.Path)
+	}
+	return true, ""
+}
+
+// isEmptyValue returns whether the value found by a JSON path is nil or an empty string, list or map.
+func isEmptyValue(value reflect.Value) bool {
+	for value.Kind() == reflect.Interface || value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return true
+		}
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	default:
+		return !value.IsValid()
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const testPolicy = `
+rules:
+- name: deployment-resource-limits
+  group: apps
+  kind: Deployment
+  path: "{.spec.template.spec.containers[*].resources.limits}"
+- name: deployment-replicas
+  group: apps
+  kind: Deployment
+  path: "{.spec.replicas}"
+  expected: "2"
+`
+
+// appliedWorkObj returns the unstructured appliedWork which applied the resources.
+func appliedWorkObj(t *testing.T, name string, resources ...placementv1beta1.WorkResourceIdentifier) *unstructured.Unstructured {
+	t.Helper()
+	appliedWork := &placementv1beta1.AppliedWork{
+		TypeMeta:   metav1.TypeMeta{APIVersion: placementv1beta1.GroupVersion.String(), Kind: placementv1beta1.AppliedWorkKind},
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}
+	for _, res := range resources {
+		appliedWork.Status.AppliedResources = append(appliedWork.Status.AppliedResources, placementv1beta1.AppliedResourceMeta{WorkResourceIdentifier: res})
+	}
+	raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(appliedWork)
+	if err != nil {
+		t.Fatalf("failed to convert the appliedWork: %v", err)
+	}
+	return &unstructured.Unstructured{Object: raw}
+}
+
+func TestParseCompliancePolicy(t *testing.T) {
+	tests := map[string]string{
+		"rule without a path":    "rules:\n- name: no-path\n",
+		"duplicated rule names":  "rules:\n- name: r\n  path: \"{.spec}\"\n- name: r\n  path: \"{.metadata}\"\n",
+		"invalid JSON path":      "rules:\n- name: r\n  path: \"{.spec\"\n",
+		"unknown rule attribute": "rules:\n- name: r\n  path: \"{.spec}\"\n  operator: equals\n",
+	}
+	for name, policy := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseCompliancePolicy([]byte(policy)); err == nil {
+				t.Errorf("parseCompliancePolicy() = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestScan(t *testing.T) {
+	policy, err := parseCompliancePolicy([]byte(testPolicy))
+	if err != nil {
+		t.Fatalf("parseCompliancePolicy() = %v, want no error", err)
+	}
+	compliant := unstructuredObj(t, `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"compliant","namespace":"app"},`+
+		`"spec":{"replicas":2,"template":{"spec":{"containers":[{"name":"web","resources":{"limits":{"cpu":"1"}}}]}}}}`)
+	nonCompliant := unstructuredObj(t, `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"non-compliant","namespace":"app"},`+
+		`"spec":{"replicas":3,"template":{"spec":{"containers":[{"name":"web","resources":{}}]}}}}`)
+	configMap := unstructuredObj(t, `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"config","namespace":"app"}}`)
+	compliantID := placementv1beta1.WorkResourceIdentifier{Ordinal: 0, Group: "apps", Version: "v1", Kind: "Deployment", Resource: "deployments", Namespace: "app", Name: "compliant"}
+	nonCompliantID := placementv1beta1.WorkResourceIdentifier{Ordinal: 1, Group: "apps", Version: "v1", Kind: "Deployment", Resource: "deployments", Namespace: "app", Name: "non-compliant"}
+	configMapID := placementv1beta1.WorkResourceIdentifier{Ordinal: 2, Version: "v1", Kind: "ConfigMap", Resource: "configmaps", Namespace: "app", Name: "config"}
+	memberClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(),
+		appliedWorkObj(t, "app-work", compliantID, nonCompliantID, configMapID), compliant, nonCompliant, configMap)
+
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	appWork := &placementv1beta1.Work{ObjectMeta: metav1.ObjectMeta{Name: "app-work", Namespace: sourceNamespace}}
+	// the work which is not applied yet is skipped.
+	pendingWork := &placementv1beta1.Work{ObjectMeta: metav1.ObjectMeta{Name: "pending-work", Namespace: sourceNamespace}}
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(appWork, pendingWork).WithStatusSubresource(appWork, pendingWork).Build()
+
+	scanTime := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	var out bytes.Buffer
+	s := &complianceScanner{
+		hubClient:    hubClient,
+		memberClient: memberClient,
+		namespace:    sourceNamespace,
+		policy:       policy,
+		out:          &out,
+		now:          func() metav1.Time { return scanTime },
+	}
+	if err := s.scan(context.Background()); err == nil {
+		t.Errorf("scan() = nil, want the error of the violations")
+	}
+
+	want := &placementv1beta1.ComplianceReport{
+		ScanTime:    scanTime,
+		PassedCount: 2,
+		FailedCount: 2,
+		Results: []placementv1beta1.ComplianceResult{
+			{Identifier: compliantID, Rule: "deployment-resource-limits", Passed: true},
+			{Identifier: compliantID, Rule: "deployment-replicas", Passed: true},
+			{
+				Identifier: nonCompliantID,
+				Rule:       "deployment-resource-limits",
+				Message:    "no field is set at {.spec.template.spec.containers[*].resources.limits}",
+			},
+			{
+				Identifier: nonCompliantID,
+				Rule:       "deployment-replicas",
+				Message:    `the field at {.spec.replicas} is "3", want "2"`,
+			},
+		},
+	}
+	var got placementv1beta1.Work
+	if err := hubClient.Get(context.Background(), types.NamespacedName{Name: appWork.Name, Namespace: sourceNamespace}, &got); err != nil {
+		t.Fatalf("failed to get the work: %v", err)
+	}
+	if diff := cmp.Diff(want, got.Status.LastComplianceReport); diff != "" {
+		t.Errorf("compliance report mismatch (-want +got):\n%s", diff)
+	}
+	if err := hubClient.Get(context.Background(), types.NamespacedName{Name: pendingWork.Name, Namespace: sourceNamespace}, &got); err != nil {
+		t.Fatalf("failed to get the work: %v", err)
+	}
+	if got.Status.LastComplianceReport != nil {
+		t.Errorf("compliance report of the pending work = %+v, want nil", got.Status.LastComplianceReport)
+	}
+	for _, want := range []string{
+		"work fleet-member-cluster1/pending-work: skipped as it is not applied to the member cluster",
+		"the Deployment app/non-compliant of the manifest with ordinal 1 violates the rule deployment-replicas",
+		"scanned 1 work(s), found 2 violation(s)",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("scan() output = %q, want it to contain %q", out.String(), want)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/compression"
+)
+
+const (
+	// applyOrderAnnotation is the annotation on a manifest which orders its application relative to the other
+	// annotated manifests in the same Work; manifests with a lower value must be applied first.
+	applyOrderAnnotation = "kubernetes-fleet.io/apply-order"
+)
+
+// kindPrerequisites lists, for a given kind, the kinds that must be applied before it when they live in the
+// same namespace (or are cluster scoped).
+var kindPrerequisites = map[string][]string{
+	"RoleBinding":           {"Role", "ClusterRole", "ServiceAccount"},
+	"ClusterRoleBinding":    {"ClusterRole", "ServiceAccount"},
+	"PersistentVolumeClaim": {"StorageClass", "PersistentVolume"},
+	"Pod":                   {"ServiceAccount", "ConfigMap", "Secret", "PersistentVolumeClaim"},
+	"ReplicaSet":            {"ServiceAccount", "ConfigMap", "Secret", "PersistentVolumeClaim"},
+	"Deployment":            {"ServiceAccount", "ConfigMap", "Secret", "PersistentVolumeClaim"},
+	"StatefulSet":           {"ServiceAccount", "ConfigMap", "Secret", "PersistentVolumeClaim", "Service"},
+	"DaemonSet":             {"ServiceAccount", "ConfigMap", "Secret", "PersistentVolumeClaim"},
+	"Job":                   {"ServiceAccount", "ConfigMap", "Secret", "PersistentVolumeClaim"},
+	"CronJob":               {"ServiceAccount", "ConfigMap", "Secret", "PersistentVolumeClaim"},
+	"Ingress":               {"Service", "IngressClass", "Secret"},
+}
+
+// manifestNode is a node in the manifest dependency graph.
+type manifestNode struct {
+	ordinal    int
+	obj        *unstructured.Unstructured
+	applyOrder *int
+}
+
+// label returns the label of the node in the form of `ordinal:gvk:name`.
+func (n *manifestNode) label() string {
+	gvk := n.obj.GroupVersionKind()
+	return fmt.Sprintf("%d:%s/%s:%s", n.ordinal, gvk.GroupVersion().String(), gvk.Kind, n.obj.GetName())
+}
+
+// manifestEdge represents a "must apply before" relationship between two manifests, identified by their ordinals.
+type manifestEdge struct {
+	from, to int
+}
+
+// manifestGraph is the dependency graph of the manifests in a Work object.
+type manifestGraph struct {
+	name  string
+	nodes []*manifestNode
+	edges []manifestEdge
+}
+
+func newWorkDepsCmd() *cobra.Command {
+	var name, namespace, kubeconfig string
+	cmd := &cobra.Command{
+		Use:   "workdeps",
+		Short: "Print the manifest dependency graph of a Work object in the DOT format",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			hubClient, err := newHubClient(kubeconfig)
+			if err != nil {
+				return err
+			}
+			var work placementv1beta1.Work
+			if err := hubClient.Get(cmd.Context(), types.NamespacedName{Name: name, Namespace: namespace}, &work); err != nil {
+				return fmt.Errorf("failed to get work %s/%s: %w", namespace, name, err)
+			}
+			graph, err := buildManifestGraph(&work)
+			if err != nil {
+				return err
+			}
+			return graph.writeDOT(cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().StringVar(&name, "name", "", "Work name (required)")
+	_ = cmd.MarkFlagRequired("name")
+	cmd.Flags().StringVar(&namespace, "namespace", "", "Work namespace (required)")
+	_ = cmd.MarkFlagRequired("namespace")
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to the kubeconfig of the hub cluster (optional)")
+	return cmd
+}
+
+func newHubClient(kubeconfig string) (client.Client, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfig
+	cfg, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the hub cluster config: %w", err)
+	}
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	return client.New(cfg, client.Options{Scheme: scheme})
+}
+
+// buildManifestGraph builds the dependency graph of the manifests in the given Work object.
+func buildManifestGraph(work *placementv1beta1.Work) (*manifestGraph, error) {
+	if err := compression.DecompressWork(work); err != nil {
+		return nil, err
+	}
+	graph := &manifestGraph{name: work.GetName()}
+	for i, manifest := range work.Spec.Workload.Manifests {
+		var obj unstructured.Unstructured
+		if err := obj.UnmarshalJSON(manifest.Raw); err != nil {
+			return nil, fmt.Errorf("failed to decode the manifest with ordinal %d: %w", i, err)
+		}
+		node := &manifestNode{ordinal: i, obj: &obj}
+		if v, ok := obj.GetAnnotations()[applyOrderAnnotation]; ok {
+			order, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s annotation %q on the manifest with ordinal %d: %w", applyOrderAnnotation, v, i, err)
+			}
+			node.applyOrder = &order
+		}
+		graph.nodes = append(graph.nodes, node)
+	}
+
+	edges := make(map[manifestEdge]bool)
+	for _, from := range graph.nodes {
+		for _, to := range graph.nodes {
+			if from != to && mustApplyBefore(from, to) {
+				edges[manifestEdge{from: from.ordinal, to: to.ordinal}] = true
+			}
+		}
+	}
+	for _, next := range graph.nodes {
+		if next.applyOrder == nil {
+			continue
+		}
+		// only link to the closest lower apply order to keep the graph readable.
+		prevOrder := -1
+		hasPrev := false
+		for _, n := range graph.nodes {
+			if n.applyOrder != nil && *n.applyOrder < *next.applyOrder && (!hasPrev || *n.applyOrder > prevOrder) {
+				prevOrder = *n.applyOrder
+				hasPrev = true
+			}
+		}
+		for _, n := range graph.nodes {
+			if hasPrev && n.applyOrder != nil && *n.applyOrder == prevOrder {
+				edges[manifestEdge{from: n.ordinal, to: next.ordinal}] = true
+			}
+		}
+	}
+	for edge := range edges {
+		graph.edges = append(graph.edges, edge)
+	}
+	sort.Slice(graph.edges, func(i, j int) bool {
+		if graph.edges[i].from != graph.edges[j].from {
+			return graph.edges[i].from < graph.edges[j].from
+		}
+		return graph.edges[i].to < graph.edges[j].to
+	})
+	return graph, nil
+}
+
+// mustApplyBefore returns true if the manifest from must be applied before the manifest to based on the GVK
+// dependency rules.
+func mustApplyBefore(from, to *manifestNode) bool {
+	fromGVK, toGVK := from.obj.GroupVersionKind(), to.obj.GroupVersionKind()
+	// a namespace must exist before the objects in it.
+	if fromGVK.Group == "" && fromGVK.Kind == "Namespace" {
+		return to.obj.GetNamespace() == from.obj.GetName()
+	}
+	// a CRD must exist before its custom resources.
+	if fromGVK.Group == "apiextensions.k8s.io" && fromGVK.Kind == "CustomResourceDefinition" {
+		group, _, _ := unstructured.NestedString(from.obj.Object, "spec", "group")
+		kind, _, _ := unstructured.NestedString(from.obj.Object, "spec", "names", "kind")
+		return toGVK.Group == group && toGVK.Kind == kind
+	}
+	if from.obj.GetNamespace() != "" && from.obj.GetNamespace() != to.obj.GetNamespace() {
+		return false
+	}
+	for _, kind := range kindPrerequisites[toGVK.Kind] {
+		if fromGVK.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// writeDOT writes the graph in the DOT format.
+func (g *manifestGraph) writeDOT(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %q {\n", g.name)
+	for _, n := range g.nodes {
+		fmt.Fprintf(&b, "  \"%d\" [label=%q];\n", n.ordinal, n.label())
+	}
+	for _, e := range g.edges {
+		fmt.Fprintf(&b, "  \"%d\" -> \"%d\";\n", e.from, e.to)
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
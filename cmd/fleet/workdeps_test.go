@@ -0,0 +1,75 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func TestBuildManifestGraph(t *testing.T) {
+	manifests := []string{
+		`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"app"}}`,
+		`{"apiVersion":"v1","kind":"ServiceAccount","metadata":{"name":"sa","namespace":"app"}}`,
+		`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm","namespace":"app"}}`,
+		`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"deploy","namespace":"app","annotations":{"kubernetes-fleet.io/apply-order":"1"}}}`,
+		`{"apiVersion":"v1","kind":"Service","metadata":{"name":"svc","namespace":"app","annotations":{"kubernetes-fleet.io/apply-order":"2"}}}`,
+	}
+	work := &placementv1beta1.Work{}
+	work.SetName("test-work")
+	for _, m := range manifests {
+		work.Spec.Workload.Manifests = append(work.Spec.Workload.Manifests, placementv1beta1.Manifest{
+			RawExtension: runtime.RawExtension{Raw: []byte(m)},
+		})
+	}
+
+	graph, err := buildManifestGraph(work)
+	if err != nil {
+		t.Fatalf("buildManifestGraph() = %v, want no error", err)
+	}
+	wantEdges := []manifestEdge{
+		{from: 0, to: 1},
+		{from: 0, to: 2},
+		{from: 0, to: 3},
+		{from: 0, to: 4},
+		{from: 1, to: 3},
+		{from: 2, to: 3},
+		{from: 3, to: 4},
+	}
+	assert.Equal(t, wantEdges, graph.edges, "buildManifestGraph() edges mismatch")
+
+	var out bytes.Buffer
+	if err := graph.writeDOT(&out); err != nil {
+		t.Fatalf("writeDOT() = %v, want no error", err)
+	}
+	dot := out.String()
+	wantLabels := []string{
+		`label="0:v1/Namespace:app"`,
+		`label="1:v1/ServiceAccount:sa"`,
+		`label="2:v1/ConfigMap:cm"`,
+		`label="3:apps/v1/Deployment:deploy"`,
+		`label="4:v1/Service:svc"`,
+	}
+	for _, label := range wantLabels {
+		assert.Contains(t, dot, label, "writeDOT() output is missing a node label")
+	}
+	assert.Equal(t, len(wantEdges), strings.Count(dot, "->"), "writeDOT() edge count mismatch")
+	assert.True(t, strings.HasPrefix(dot, `digraph "test-work" {`), "writeDOT() output should start with the digraph header")
+}
+
+func TestBuildManifestGraphInvalidApplyOrder(t *testing.T) {
+	work := &placementv1beta1.Work{}
+	work.Spec.Workload.Manifests = []placementv1beta1.Manifest{
+		{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm","annotations":{"kubernetes-fleet.io/apply-order":"first"}}}`)}},
+	}
+	_, err := buildManifestGraph(work)
+	assert.Error(t, err, "buildManifestGraph() should fail on an invalid apply order")
+}
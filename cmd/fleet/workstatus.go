@@ -0,0 +1,63 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/cli"
+)
+
+func newWorkCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "work",
+		Short: "Inspect the Work objects",
+		Args:  cobra.NoArgs,
+	}
+	cmd.AddCommand(newWorkStatusCmd())
+	return cmd
+}
+
+func newWorkStatusCmd() *cobra.Command {
+	var namespace, kubeconfig string
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Print a summary of the status of the Work objects in a namespace",
+		Long: `Print a summary of the status of the Work objects in a namespace, the most recently changed first.
+
+When the output is a terminal, the Works with manifests not applied yet are highlighted in red and the Works with
+drifted manifests are highlighted in yellow.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			hubClient, err := newHubClient(kubeconfig)
+			if err != nil {
+				return err
+			}
+			var works placementv1beta1.WorkList
+			if err := hubClient.List(cmd.Context(), &works, client.InNamespace(namespace)); err != nil {
+				return fmt.Errorf("failed to list the works in namespace %s: %w", namespace, err)
+			}
+			rows := make([]cli.WorkStatusRow, 0, len(works.Items))
+			for i := range works.Items {
+				row, err := cli.NewWorkStatusRow(&works.Items[i])
+				if err != nil {
+					return err
+				}
+				rows = append(rows, row)
+			}
+			cli.SortWorkStatusRows(rows)
+			out := cmd.OutOrStdout()
+			return cli.WriteWorkStatusTable(out, rows, cli.IsTerminal(out))
+		},
+	}
+	cmd.Flags().StringVar(&namespace, "namespace", "", "Namespace of the Work objects (required)")
+	_ = cmd.MarkFlagRequired("namespace")
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to the kubeconfig of the hub cluster (optional)")
+	return cmd
+}
@@ -13,6 +13,7 @@ import (
 	"sync"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -38,6 +39,12 @@ import (
 	mcv1beta1 "go.goms.io/fleet/pkg/controllers/membercluster/v1beta1"
 	fleetmetrics "go.goms.io/fleet/pkg/metrics"
 	"go.goms.io/fleet/pkg/webhook"
+	"go.goms.io/fleet/pkg/workdelta"
+	"go.goms.io/fleet/pkg/workdiff"
+	"go.goms.io/fleet/pkg/workmerge"
+	"go.goms.io/fleet/pkg/workresync"
+	"go.goms.io/fleet/pkg/workstatusstream"
+	"go.goms.io/fleet/pkg/workstatussummary"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -72,7 +79,8 @@ func init() {
 
 	metrics.Registry.MustRegister(fleetmetrics.JoinResultMetrics, fleetmetrics.LeaveResultMetrics,
 		fleetmetrics.PlacementApplyFailedCount, fleetmetrics.PlacementApplySucceedCount,
-		fleetmetrics.SchedulingCycleDurationMilliseconds, fleetmetrics.SchedulerActiveWorkers)
+		fleetmetrics.SchedulingCycleDurationMilliseconds, fleetmetrics.SchedulerActiveWorkers,
+		fleetmetrics.WorkApplicationLatencySeconds)
 }
 
 func main() {
@@ -152,12 +160,77 @@ func main() {
 
 	if opts.EnableWebhook {
 		whiteListedUsers := strings.Split(opts.WhiteListedUsers, ",")
-		if err := SetupWebhook(mgr, options.WebhookClientConnectionType(opts.WebhookClientConnectionType), opts.WebhookServiceName, whiteListedUsers, opts.EnableGuardRail, opts.EnableV1Beta1APIs); err != nil {
+		if err := SetupWebhook(mgr, options.WebhookClientConnectionType(opts.WebhookClientConnectionType), opts.WebhookServiceName, whiteListedUsers, opts.EnableGuardRail, opts.EnableV1Beta1APIs, opts.WorkNamespacePattern); err != nil {
 			klog.ErrorS(err, "unable to set up webhook")
 			exitWithErrorFunc()
 		}
 	}
 
+	if opts.EnableV1Beta1APIs && opts.WorkStatusStreamAddress != "" {
+		if err := mgr.Add(&workstatusstream.Server{
+			Addr:   opts.WorkStatusStreamAddress,
+			Cache:  mgr.GetCache(),
+			Reader: mgr.GetClient(),
+		}); err != nil {
+			klog.ErrorS(err, "unable to set up the work status stream server")
+			exitWithErrorFunc()
+		}
+	}
+
+	if opts.EnableV1Beta1APIs && opts.WorkMergeAddress != "" {
+		if err := mgr.Add(&workmerge.Server{
+			Addr:   opts.WorkMergeAddress,
+			Client: mgr.GetClient(),
+		}); err != nil {
+			klog.ErrorS(err, "unable to set up the work merge server")
+			exitWithErrorFunc()
+		}
+	}
+
+	if opts.EnableV1Beta1APIs && opts.WorkResyncAddress != "" {
+		if err := mgr.Add(&workresync.Server{
+			Addr:   opts.WorkResyncAddress,
+			Client: mgr.GetClient(),
+		}); err != nil {
+			klog.ErrorS(err, "unable to set up the work resync server")
+			exitWithErrorFunc()
+		}
+	}
+
+	if opts.EnableV1Beta1APIs && opts.WorkDiffAddress != "" {
+		if err := mgr.Add(&workdiff.Server{
+			Addr:   opts.WorkDiffAddress,
+			Reader: mgr.GetClient(),
+		}); err != nil {
+			klog.ErrorS(err, "unable to set up the work diff server")
+			exitWithErrorFunc()
+		}
+	}
+
+	if opts.EnableV1Beta1APIs && opts.WorkDeltaAddress != "" {
+		if err := mgr.Add(&workdelta.Server{
+			Addr:   opts.WorkDeltaAddress,
+			Cache:  mgr.GetCache(),
+			Reader: mgr.GetClient(),
+		}); err != nil {
+			klog.ErrorS(err, "unable to set up the work delta server")
+			exitWithErrorFunc()
+		}
+	}
+
+	if opts.EnableV1Beta1APIs && opts.WorkStatusSummaryAddress != "" {
+		// the selector is checked when the options are validated.
+		namespaceSelector, _ := labels.Parse(opts.WorkStatusSummaryNamespaceSelector)
+		if err := mgr.Add(&workstatussummary.Server{
+			Addr:              opts.WorkStatusSummaryAddress,
+			Reader:            mgr.GetClient(),
+			NamespaceSelector: namespaceSelector,
+		}); err != nil {
+			klog.ErrorS(err, "unable to set up the work status summary server")
+			exitWithErrorFunc()
+		}
+	}
+
 	ctx := ctrl.SetupSignalHandler()
 	if err := workload.SetupControllers(ctx, &wg, mgr, config, opts); err != nil {
 		klog.ErrorS(err, "unable to set up ready check")
@@ -184,7 +257,7 @@ func main() {
 }
 
 // SetupWebhook generates the webhook cert and then set up the webhook configurator.
-func SetupWebhook(mgr manager.Manager, webhookClientConnectionType options.WebhookClientConnectionType, webhookServiceName string, whiteListedUsers []string, enableGuardRail, isFleetV1Beta1API bool) error {
+func SetupWebhook(mgr manager.Manager, webhookClientConnectionType options.WebhookClientConnectionType, webhookServiceName string, whiteListedUsers []string, enableGuardRail, isFleetV1Beta1API bool, workNamespacePattern string) error {
 	// Generate self-signed key and crt files in FleetWebhookCertDir for the webhook server to start.
 	w, err := webhook.NewWebhookConfig(mgr, webhookServiceName, FleetWebhookPort, &webhookClientConnectionType, FleetWebhookCertDir, enableGuardRail)
 	if err != nil {
@@ -195,7 +268,7 @@ func SetupWebhook(mgr manager.Manager, webhookClientConnectionType options.Webho
 		klog.ErrorS(err, "unable to add WebhookConfig")
 		return err
 	}
-	if err = webhook.AddToManager(mgr, whiteListedUsers, isFleetV1Beta1API); err != nil {
+	if err = webhook.AddToManager(mgr, whiteListedUsers, isFleetV1Beta1API, workNamespacePattern); err != nil {
 		klog.ErrorS(err, "unable to register webhooks to the manager")
 		return err
 	}
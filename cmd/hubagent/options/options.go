@@ -41,6 +41,9 @@ type Options struct {
 	EnableGuardRail bool
 	// WhiteListedUsers indicates the list of user who are allowed to modify fleet resources
 	WhiteListedUsers string
+	// WorkNamespacePattern is the regular expression the namespaces the works are created in must match; it defaults
+	// to the member cluster reserved namespaces if empty.
+	WorkNamespacePattern string
 	// Sets the connection type for the webhook.
 	WebhookClientConnectionType string
 	// NetworkingAgentsEnabled indicates if we enable network agents
@@ -79,6 +82,36 @@ type Options struct {
 	EnableV1Alpha1APIs bool
 	// EnableV1Beta1APIs enables the agents to watch the v1beta1 CRs.
 	EnableV1Beta1APIs bool
+	// WorkStatusStreamAddress is the TCP address the work status streams are served on.
+	// The streams are not served if it is empty.
+	WorkStatusStreamAddress string
+	// WorkMergeAddress is the TCP address the partial updates of the work specs are served on.
+	// The partial updates are not served if it is empty.
+	WorkMergeAddress string
+	// WorkResyncAddress is the TCP address the forced resyncs of the works are requested on.
+	// The forced resyncs are not served if it is empty.
+	WorkResyncAddress string
+	// WorkDiffAddress is the TCP address the diff reports of the works are served on.
+	// The diff reports are not served if it is empty.
+	WorkDiffAddress string
+	// WorkDeltaAddress is the TCP address the deltas of the works are served on for the delta sync of the members.
+	// The deltas are not served if it is empty.
+	WorkDeltaAddress string
+	// WorkStatusSummaryAddress is the TCP address the summaries of the work statuses are served on.
+	// The summaries are not served if it is empty.
+	WorkStatusSummaryAddress string
+	// WorkStatusSummaryNamespaceSelector is the label selector of the namespaces whose works are summarized.
+	// The works of all the namespaces are summarized if it is empty.
+	WorkStatusSummaryNamespaceSelector string
+	// MaxManifestsPerWork is the max number of manifests in a work; the works with more manifests are split into
+	// parts tracked by a WorkGroup. The works are never split if it is 0.
+	MaxManifestsPerWork int
+	// EnableWorkCompaction enables compacting the small works which share the same spec except for their manifests
+	// into consolidated works of up to MaxManifestsPerWork manifests.
+	EnableWorkCompaction bool
+	// ManifestBlobMinSize is the min size in bytes of the work manifests stored as ManifestStore blobs shared by the
+	// works. No manifest is stored as a blob if it is 0.
+	ManifestBlobMinSize int
 }
 
 // NewOptions builds an empty options.
@@ -110,6 +143,7 @@ func (o *Options) AddFlags(flags *flag.FlagSet) {
 	flag.StringVar(&o.WebhookServiceName, "webhook-service-name", "fleetwebhook", "Fleet webhook service name.")
 	flag.BoolVar(&o.EnableGuardRail, "enable-guard-rail", false, "If set, the fleet guard rail webhook configurations are enabled.")
 	flag.StringVar(&o.WhiteListedUsers, "whitelisted-users", "", "If set, white listed users can modify fleet related resources.")
+	flag.StringVar(&o.WorkNamespacePattern, "work-namespace-pattern", "", "The regular expression the namespaces the works are created in must match. It defaults to the member cluster reserved namespaces (fleet-member-<cluster>) if empty.")
 	flag.StringVar(&o.WebhookClientConnectionType, "webhook-client-connection-type", "url", "Sets the connection type used by the webhook client. Only URL or Service is valid.")
 	flag.BoolVar(&o.NetworkingAgentsEnabled, "networking-agents-enabled", false, "Whether the networking agents are enabled or not.")
 	flags.DurationVar(&o.ClusterUnhealthyThreshold.Duration, "cluster-unhealthy-threshold", 60*time.Second, "The duration for a member cluster to be in a degraded state before considered unhealthy.")
@@ -133,6 +167,16 @@ func (o *Options) AddFlags(flags *flag.FlagSet) {
 	flags.IntVar(&o.MaxFleetSizeSupported, "max-fleet-size", 100, "The max number of member clusters supported in this fleet")
 	flags.BoolVar(&o.EnableV1Alpha1APIs, "enable-v1alpha1-apis", false, "If set, the agents will watch for the v1alpha1 APIs.")
 	flags.BoolVar(&o.EnableV1Beta1APIs, "enable-v1beta1-apis", true, "If set, the agents will watch for the v1beta1 APIs.")
+	flags.StringVar(&o.WorkStatusStreamAddress, "work-status-stream-bind-address", "", "The TCP address the work status changes are streamed on as Server-Sent Events (e.g. :8090). The streams are not served if empty.")
+	flags.StringVar(&o.WorkMergeAddress, "work-merge-bind-address", "", "The TCP address the JSON merge patches of the work specs are served on (e.g. :8091). The partial updates are not served if empty.")
+	flags.StringVar(&o.WorkResyncAddress, "work-resync-bind-address", "", "The TCP address the forced resyncs of the works are requested on (e.g. :8092). The forced resyncs are not served if empty.")
+	flags.StringVar(&o.WorkDiffAddress, "work-diff-bind-address", "", "The TCP address the diffs of the work manifests against their resources in the member clusters are served on as unified diffs (e.g. :8095). The diffs are not served if empty.")
+	flags.StringVar(&o.WorkDeltaAddress, "work-delta-bind-address", "", "The TCP address the deltas of the works since their earlier versions are served on for the delta sync of the members (e.g. :8094). The deltas are not served if empty.")
+	flags.StringVar(&o.WorkStatusSummaryAddress, "work-status-summary-bind-address", "", "The TCP address the applied, available and drifted work counts per namespace are served on (e.g. :8093). The summaries are not served if empty.")
+	flags.StringVar(&o.WorkStatusSummaryNamespaceSelector, "work-status-summary-namespace-selector", "", "The label selector of the namespaces whose works are summarized (e.g. kubernetes-fleet.io/is-fleet-resource=true). The works of all the namespaces are summarized if empty.")
+	flags.IntVar(&o.MaxManifestsPerWork, "max-manifests-per-work", 0, "The max number of manifests in a work; the works with more manifests are split into parts tracked by a WorkGroup. The works are never split if 0.")
+	flags.BoolVar(&o.EnableWorkCompaction, "enable-work-compaction", false, "If set, the small works in the same namespace which share the same apply strategy are compacted into consolidated works of up to max-manifests-per-work manifests.")
+	flags.IntVar(&o.ManifestBlobMinSize, "manifest-blob-min-size", 0, "The min size in bytes of the work manifests stored once as content-addressed ManifestStore blobs shared by the works instead of inline. No manifest is stored as a blob if 0.")
 
 	o.RateLimiterOpts.AddFlags(flags)
 }
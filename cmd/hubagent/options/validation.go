@@ -6,6 +6,9 @@ Licensed under the MIT license.
 package options
 
 import (
+	"regexp"
+
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"go.goms.io/fleet/pkg/utils"
@@ -41,11 +44,27 @@ func (o *Options) Validate() field.ErrorList {
 		errs = append(errs, field.Invalid(newPath.Child("WebhookServiceName"), o.WebhookServiceName, "Webhook service name is required when webhook is enabled"))
 	}
 
+	if _, err := regexp.Compile(o.WorkNamespacePattern); err != nil {
+		errs = append(errs, field.Invalid(newPath.Child("WorkNamespacePattern"), o.WorkNamespacePattern, err.Error()))
+	}
+
 	connectionType := o.WebhookClientConnectionType
 	if _, err := parseWebhookClientConnectionString(connectionType); err != nil {
 		errs = append(errs, field.Invalid(newPath.Child("WebhookClientConnectionType"), o.WebhookClientConnectionType, err.Error()))
 	}
 
+	if _, err := labels.Parse(o.WorkStatusSummaryNamespaceSelector); err != nil {
+		errs = append(errs, field.Invalid(newPath.Child("WorkStatusSummaryNamespaceSelector"), o.WorkStatusSummaryNamespaceSelector, err.Error()))
+	}
+
+	if o.MaxManifestsPerWork < 0 {
+		errs = append(errs, field.Invalid(newPath.Child("MaxManifestsPerWork"), o.MaxManifestsPerWork, "Must be greater than or equal to 0"))
+	}
+
+	if o.EnableWorkCompaction && o.MaxManifestsPerWork == 0 {
+		errs = append(errs, field.Invalid(newPath.Child("MaxManifestsPerWork"), o.MaxManifestsPerWork, "Must be greater than 0 when the work compaction is enabled"))
+	}
+
 	if !o.EnableV1Alpha1APIs && !o.EnableV1Beta1APIs {
 		errs = append(errs, field.Required(newPath.Child("EnableV1Alpha1APIs"), "Either EnableV1Alpha1APIs or EnableV1Beta1APIs is required"))
 	}
@@ -6,11 +6,13 @@ Licensed under the MIT license.
 package options
 
 import (
+	"regexp"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -33,6 +35,26 @@ func newTestOptions(modifyOptions ModifyOptions) Options {
 	return option
 }
 
+// selectorParseError returns the message of the error parsing the invalid label selector.
+func selectorParseError(t *testing.T, selector string) string {
+	t.Helper()
+	_, err := labels.Parse(selector)
+	if err == nil {
+		t.Fatalf("labels.Parse(%q) = nil, want an error", selector)
+	}
+	return err.Error()
+}
+
+// patternCompileError returns the message of the error compiling the invalid regular expression.
+func patternCompileError(t *testing.T, pattern string) string {
+	t.Helper()
+	_, err := regexp.Compile(pattern)
+	if err == nil {
+		t.Fatalf("regexp.Compile(%q) = nil, want an error", pattern)
+	}
+	return err.Error()
+}
+
 func TestValidateControllerManagerConfiguration(t *testing.T) {
 	newPath := field.NewPath("Options")
 	testCases := map[string]struct {
@@ -61,6 +83,18 @@ func TestValidateControllerManagerConfiguration(t *testing.T) {
 			}),
 			want: field.ErrorList{field.Invalid(newPath.Child("WorkPendingGracePeriod"), metav1.Duration{Duration: -40 * time.Second}, "Must be greater than 0")},
 		},
+		"invalid MaxManifestsPerWork": {
+			opt: newTestOptions(func(options *Options) {
+				options.MaxManifestsPerWork = -1
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("MaxManifestsPerWork"), -1, "Must be greater than or equal to 0")},
+		},
+		"work compaction without MaxManifestsPerWork": {
+			opt: newTestOptions(func(options *Options) {
+				options.EnableWorkCompaction = true
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("MaxManifestsPerWork"), 0, "Must be greater than 0 when the work compaction is enabled")},
+		},
 		"invalid EnableV1Alpha1APIs": {
 			opt: newTestOptions(func(option *Options) {
 				option.EnableV1Alpha1APIs = false
@@ -80,6 +114,18 @@ func TestValidateControllerManagerConfiguration(t *testing.T) {
 			}),
 			want: field.ErrorList{field.Invalid(newPath.Child("WebhookServiceName"), "", "Webhook service name is required when webhook is enabled")},
 		},
+		"invalid WorkStatusSummaryNamespaceSelector": {
+			opt: newTestOptions(func(option *Options) {
+				option.WorkStatusSummaryNamespaceSelector = "env in (prod"
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("WorkStatusSummaryNamespaceSelector"), "env in (prod", selectorParseError(t, "env in (prod"))},
+		},
+		"invalid WorkNamespacePattern": {
+			opt: newTestOptions(func(option *Options) {
+				option.WorkNamespacePattern = "fleet-member-(.+"
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("WorkNamespacePattern"), "fleet-member-(.+", patternCompileError(t, "fleet-member-(.+"))},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -24,15 +24,22 @@ import (
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	fleetv1alpha1 "go.goms.io/fleet/apis/v1alpha1"
 	"go.goms.io/fleet/cmd/hubagent/options"
+	"go.goms.io/fleet/pkg/controllers/broadcastwork"
 	"go.goms.io/fleet/pkg/controllers/clusterresourcebindingwatcher"
 	"go.goms.io/fleet/pkg/controllers/clusterresourceplacement"
 	"go.goms.io/fleet/pkg/controllers/clusterresourceplacementwatcher"
 	"go.goms.io/fleet/pkg/controllers/clusterschedulingpolicysnapshot"
+	"go.goms.io/fleet/pkg/controllers/fleetstatusaggregator"
 	"go.goms.io/fleet/pkg/controllers/memberclusterplacement"
 	"go.goms.io/fleet/pkg/controllers/overrider"
 	"go.goms.io/fleet/pkg/controllers/resourcechange"
 	"go.goms.io/fleet/pkg/controllers/rollout"
+	"go.goms.io/fleet/pkg/controllers/workcompactor"
+	"go.goms.io/fleet/pkg/controllers/workdrreplication"
 	"go.goms.io/fleet/pkg/controllers/workgenerator"
+	"go.goms.io/fleet/pkg/controllers/worklatency"
+	"go.goms.io/fleet/pkg/controllers/workreplicator"
+	"go.goms.io/fleet/pkg/controllers/workspecgrowth"
 	"go.goms.io/fleet/pkg/resourcewatcher"
 	"go.goms.io/fleet/pkg/scheduler"
 	"go.goms.io/fleet/pkg/scheduler/clustereligibilitychecker"
@@ -75,6 +82,7 @@ var (
 		placementv1beta1.GroupVersion.WithKind(placementv1beta1.ClusterResourceSnapshotKind),
 		placementv1beta1.GroupVersion.WithKind(placementv1beta1.ClusterSchedulingPolicySnapshotKind),
 		placementv1beta1.GroupVersion.WithKind(placementv1beta1.WorkKind),
+		placementv1beta1.GroupVersion.WithKind(placementv1beta1.BroadcastWorkKind),
 		placementv1alpha1.GroupVersion.WithKind(placementv1alpha1.ClusterResourceOverrideKind),
 		placementv1alpha1.GroupVersion.WithKind(placementv1alpha1.ClusterResourceOverrideSnapshotKind),
 		placementv1alpha1.GroupVersion.WithKind(placementv1alpha1.ResourceOverrideKind),
@@ -232,11 +240,82 @@ func SetupControllers(ctx context.Context, wg *sync.WaitGroup, mgr ctrl.Manager,
 			Client:                  mgr.GetClient(),
 			MaxConcurrentReconciles: int(math.Ceil(float64(opts.MaxFleetSizeSupported)/10) * math.Ceil(float64(opts.MaxConcurrentClusterPlacement)/10)),
 			InformerManager:         dynamicInformerManager,
+			WorkSplitter:            workgenerator.WorkSplitter{MaxManifestsPerWork: opts.MaxManifestsPerWork},
+			ManifestBlobMinSize:     opts.ManifestBlobMinSize,
 		}).SetupWithManager(mgr); err != nil {
 			klog.ErrorS(err, "Unable to set up work generator")
 			return err
 		}
 
+		if opts.EnableWorkCompaction {
+			klog.Info("Setting up work compactor")
+			if err := (&workcompactor.Reconciler{
+				Client:              mgr.GetClient(),
+				MaxManifestsPerWork: opts.MaxManifestsPerWork,
+			}).SetupWithManager(mgr); err != nil {
+				klog.ErrorS(err, "Unable to set up work compactor")
+				return err
+			}
+		}
+
+		// Set up the work latency observer
+		klog.Info("Setting up work latency observer")
+		if err := worklatency.NewWorkLatencyObserver(mgr.GetClient()).SetupWithManager(mgr); err != nil {
+			klog.ErrorS(err, "Unable to set up work latency observer")
+			return err
+		}
+
+		// Set up the work spec growth detector
+		klog.Info("Setting up work spec growth detector")
+		if err := workspecgrowth.NewWorkSpecGrowthDetector(mgr.GetClient(), mgr.GetEventRecorderFor("work-spec-growth-detector"),
+			workspecgrowth.DefaultMaxGrowthRatioPerUpdate).SetupWithManager(mgr); err != nil {
+			klog.ErrorS(err, "Unable to set up work spec growth detector")
+			return err
+		}
+
+		// Set up the fleet status aggregator
+		klog.Info("Setting up fleet status aggregator")
+		if err := (&fleetstatusaggregator.Reconciler{
+			Client:    mgr.GetClient(),
+			Namespace: utils.FleetSystemNamespace,
+		}).SetupWithManager(mgr); err != nil {
+			klog.ErrorS(err, "Unable to set up fleet status aggregator")
+			return err
+		}
+
+		// Set up the broadcast work controller
+		klog.Info("Setting up broadcast work controller")
+		if err := (&broadcastwork.Reconciler{
+			Client: mgr.GetClient(),
+		}).SetupWithManager(mgr); err != nil {
+			klog.ErrorS(err, "Unable to set up broadcast work controller")
+			return err
+		}
+
+		// Set up the work replicator controller
+		klog.Info("Setting up work replicator controller")
+		hubClientGetter := &workreplicator.SecretHubClientGetter{
+			Reader: mgr.GetAPIReader(),
+			Scheme: mgr.GetScheme(),
+		}
+		if err := (&workreplicator.Reconciler{
+			Client:          mgr.GetClient(),
+			HubClientGetter: hubClientGetter,
+		}).SetupWithManager(mgr); err != nil {
+			klog.ErrorS(err, "Unable to set up work replicator controller")
+			return err
+		}
+
+		// Set up the work DR replication controller
+		klog.Info("Setting up work DR replication controller")
+		if err := (&workdrreplication.Reconciler{
+			Client:          mgr.GetClient(),
+			HubClientGetter: hubClientGetter,
+		}).SetupWithManager(mgr); err != nil {
+			klog.ErrorS(err, "Unable to set up work DR replication controller")
+			return err
+		}
+
 		// Set up the scheduler
 		klog.Info("Setting up scheduler")
 		defaultProfile := profile.NewDefaultProfile()
@@ -20,17 +20,20 @@ import (
 	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilrand "k8s.io/apimachinery/pkg/util/rand"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
@@ -41,13 +44,18 @@ import (
 	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	fleetv1alpha1 "go.goms.io/fleet/apis/v1alpha1"
+	"go.goms.io/fleet/pkg/agentheartbeat"
+	"go.goms.io/fleet/pkg/connectivityprobe"
 	imcv1alpha1 "go.goms.io/fleet/pkg/controllers/internalmembercluster/v1alpha1"
 	imcv1beta1 "go.goms.io/fleet/pkg/controllers/internalmembercluster/v1beta1"
 	"go.goms.io/fleet/pkg/controllers/work"
+	"go.goms.io/fleet/pkg/controllers/workchangenotifier"
+	"go.goms.io/fleet/pkg/controllers/workemailnotifier"
 	workv1alpha1controller "go.goms.io/fleet/pkg/controllers/workv1alpha1"
 	fleetmetrics "go.goms.io/fleet/pkg/metrics"
 	"go.goms.io/fleet/pkg/propertyprovider"
 	"go.goms.io/fleet/pkg/propertyprovider/azure"
+	"go.goms.io/fleet/pkg/tracing"
 	"go.goms.io/fleet/pkg/utils"
 	"go.goms.io/fleet/pkg/utils/httpclient"
 	//+kubebuilder:scaffold:imports
@@ -73,6 +81,29 @@ var (
 	enableV1Beta1APIs       = flag.Bool("enable-v1beta1-apis", false, "If set, the agents will watch for the v1beta1 APIs.")
 	propertyProvider        = flag.String("property-provider", "none", "The property provider to use for the agent.")
 	region                  = flag.String("region", "", "The region where the member cluster resides.")
+	maxAPICallsPerWork      = flag.Int("max-api-calls-per-work", 0, "The estimated number of member cluster API server calls above which applying a work is deferred behind the other works. 0 disables the deferral.")
+	workStatusPageSize      = flag.Int("work-status-page-size", 0, "The number of manifest conditions kept in the status of a work, the rest overflow into the WorkStatusPages of the work. 0 disables the status pagination.")
+	otelEndpoint            = flag.String("otel-endpoint", "", "The OTLP/HTTP endpoint URL the traces are exported to. Tracing is disabled if empty.")
+	otelServiceName         = flag.String("otel-service-name", "fleet-member-agent", "The service name the traces are reported with.")
+	changeNotifierURL       = flag.String("change-notifier-url", "", "The HTTP endpoint the Work change events are posted to. The notification is disabled if empty.")
+	changeNotifierSecret    = flag.String("change-notifier-secret", "", "The secret the Work change events are signed with using HMAC-SHA256. The events are not signed if empty.")
+	emailGatewayURL         = flag.String("email-gateway-url", "", "The HTTP endpoint the Work transition emails are posted to. It takes precedence over the SMTP server.")
+	emailSMTPAddress        = flag.String("email-smtp-address", "", "The host:port of the SMTP server the Work transition emails are sent through. The emails are disabled if neither the gateway URL nor the SMTP server is set.")
+	emailSMTPFrom           = flag.String("email-smtp-from", "", "The sender address of the Work transition emails sent through the SMTP server.")
+	emailSMTPUsername       = flag.String("email-smtp-username", "", "The username to authenticate with the SMTP server. The emails are sent without authentication if empty.")
+	emailSMTPPassword       = flag.String("email-smtp-password", "", "The password to authenticate with the SMTP server.")
+	emailCooldown           = flag.Duration("email-cooldown", workemailnotifier.DefaultCooldown, "The minimum interval between two Work transition emails of the same Work.")
+	sanitizedManifestFields = flag.String("sanitized-manifest-fields", strings.Join(work.DefaultSanitizedManifestFields, ","), "The comma-separated paths of the fields which are stripped from the manifests before they are applied, such as the fields set at runtime by the Kubernetes controllers.")
+	ssaFieldManager         = flag.String("ssa-field-manager", work.DefaultFieldManagerName, "The name of the field manager the work applier changes the resources in the member cluster as. The member agents of the fleets which manage the same member cluster must use different names.")
+	slowReconcileThreshold  = flag.Duration("slow-reconcile-threshold", work.DefaultSlowReconcileThreshold, "How long a Work reconcile takes before its CPU profile is captured.")
+	profileStorageURL       = flag.String("profile-storage-url", "", "Where the CPU profiles of the slow Work reconciles are stored: a local directory, e.g. a mounted PVC, as a path or a file:// URL, or an object storage endpoint as an http:// or https:// URL the profiles are PUT under. The profiling is disabled if empty.")
+	maxProfileFiles         = flag.Int("max-profile-files", work.DefaultMaxProfileFiles, "The number of the CPU profiles kept in the local profile directory; the oldest ones are removed beyond it.")
+	manifestRetryAttempts   = flag.Int("manifest-retry-max-attempts", work.DefaultRetryBudgetMaxAttempts, "The maximum number of attempts to apply a manifest which fails with a transient API server error within a Work reconcile, including the first one. 1 or less disables the retries.")
+	manifestRetryDelay      = flag.Duration("manifest-retry-initial-delay", work.DefaultRetryBudgetInitialDelay, "The delay before the first retry of a manifest within a Work reconcile; every further retry waits twice as long as the previous one.")
+	manifestRetryMaxDelay   = flag.Duration("manifest-retry-max-delay", work.DefaultRetryBudgetMaxDelay, "The maximum delay between two retries of a manifest within a Work reconcile.")
+	maxDriftHistoryDepth    = flag.Int("max-drift-history-depth", work.DefaultMaxDriftHistoryDepth, "The number of the most recent drifts of a resource in the member cluster kept in the status of its Work manifest. 0 disables the drift history.")
+	maxManifestSizeBytes    = flag.Int64("max-manifest-size-bytes", work.DefaultMaxManifestSizeBytes, "The maximum size in bytes of the raw JSON of a Work manifest; the larger manifests are not applied and reported as TooLarge. 0 disables the limit.")
+	manifestApplyWorkers    = flag.Int("manifest-apply-workers", work.DefaultManifestWorkerPoolSize, "The number of the manifests of a Work applied concurrently; the manifests which depend on each other are still applied in order. 1 applies the manifests one at a time.")
 )
 
 func init() {
@@ -85,7 +116,10 @@ func init() {
 	utilruntime.Must(placementv1beta1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 
-	metrics.Registry.MustRegister(fleetmetrics.JoinResultMetrics, fleetmetrics.LeaveResultMetrics, fleetmetrics.WorkApplyTime)
+	metrics.Registry.MustRegister(fleetmetrics.JoinResultMetrics, fleetmetrics.LeaveResultMetrics, fleetmetrics.WorkApplyTime,
+		fleetmetrics.WorkEstimatedAPICalls, fleetmetrics.WorkDesiredStatePercentage, fleetmetrics.WorkRolloutProgressPercentage, fleetmetrics.WorkSpecSizeBytes,
+		fleetmetrics.WorkStatusSizeBytes, fleetmetrics.ManifestApplyDurationMilliseconds, fleetmetrics.WorkIntegrityViolationsTotal,
+		fleetmetrics.WorkReconcileDurationSeconds, fleetmetrics.ManifestApplyOutcomesTotal, fleetmetrics.WorkGarbageCollectedResourcesTotal)
 }
 
 func main() {
@@ -124,6 +158,8 @@ func main() {
 	mcNamespace := fmt.Sprintf(utils.NamespaceNameFormat, mcName)
 
 	memberConfig := ctrl.GetConfigOrDie()
+	// propagate the trace context of the work applier into the member cluster API server calls.
+	memberConfig.Wrap(tracing.WrapTransport)
 	// we place the leader election lease on the member cluster to avoid adding load to the hub
 	hubOpts := ctrl.Options{
 		Scheme: scheme,
@@ -143,6 +179,13 @@ func main() {
 				mcNamespace: {},
 			},
 		},
+		Client: client.Options{
+			Cache: &client.CacheOptions{
+				// the work namespace is read only when a work is deleted, which does not warrant a namespace informer;
+				// the manifest store blobs are cluster scoped and only the ones the works refer to are read.
+				DisableFor: []client.Object{&corev1.Namespace{}, &placementv1beta1.ManifestStore{}},
+			},
+		},
 	}
 
 	memberOpts := ctrl.Options{
@@ -160,7 +203,43 @@ func main() {
 	}
 	//+kubebuilder:scaffold:builder
 
-	if err := Start(ctrl.SetupSignalHandler(), hubConfig, memberConfig, hubOpts, memberOpts); err != nil {
+	ctx := ctrl.SetupSignalHandler()
+	// fail fast with the missing permissions instead of failing every work later.
+	hubClientSet, err := kubernetes.NewForConfig(hubConfig)
+	if err != nil {
+		klog.ErrorS(err, "Failed to create the clientset for the hub cluster")
+		klog.FlushAndExit(klog.ExitFlushTimeout, 1)
+	}
+	memberClientSet, err := kubernetes.NewForConfig(memberConfig)
+	if err != nil {
+		klog.ErrorS(err, "Failed to create the clientset for the member cluster")
+		klog.FlushAndExit(klog.ExitFlushTimeout, 1)
+	}
+	var workGroups []string
+	if *enableV1Alpha1APIs {
+		workGroups = append(workGroups, workv1alpha1.GroupName)
+	}
+	if *enableV1Beta1APIs {
+		workGroups = append(workGroups, placementv1beta1.GroupVersion.Group)
+	}
+	if err := preflightCheck(ctx, hubClientSet.AuthorizationV1().SelfSubjectAccessReviews(),
+		memberClientSet.AuthorizationV1().SelfSubjectAccessReviews(), mcNamespace, workGroups); err != nil {
+		klog.ErrorS(err, "The member agent does not have the permissions to manage the works")
+		klog.FlushAndExit(klog.ExitFlushTimeout, 1)
+	}
+
+	shutdownTracing, err := tracing.Setup(ctx, *otelEndpoint, *otelServiceName)
+	if err != nil {
+		klog.ErrorS(err, "Failed to set up tracing")
+		klog.FlushAndExit(klog.ExitFlushTimeout, 1)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			klog.ErrorS(err, "Failed to shut down tracing")
+		}
+	}()
+
+	if err := Start(ctx, mcName, hubConfig, memberConfig, hubOpts, memberOpts); err != nil {
 		klog.ErrorS(err, "Failed to start the controllers for the member agent")
 		klog.FlushAndExit(klog.ExitFlushTimeout, 1)
 	}
@@ -257,7 +336,7 @@ func buildHubConfig(hubURL string, useCertificateAuth bool, tlsClientInsecure bo
 }
 
 // Start the member controllers with the supplied config
-func Start(ctx context.Context, hubCfg, memberConfig *rest.Config, hubOpts, memberOpts ctrl.Options) error {
+func Start(ctx context.Context, mcName string, hubCfg, memberConfig *rest.Config, hubOpts, memberOpts ctrl.Options) error {
 	hubMgr, err := ctrl.NewManager(hubCfg, hubOpts)
 	if err != nil {
 		return fmt.Errorf("unable to start hub manager: %w", err)
@@ -322,6 +401,7 @@ func Start(ctx context.Context, hubCfg, memberConfig *rest.Config, hubOpts, memb
 			klog.ErrorS(err, "unable to find the required CRD", "GVK", gvk)
 			return err
 		}
+
 		// create the work controller, so we can pass it to the internal member cluster reconciler
 		workController := workv1alpha1controller.NewApplyWorkReconciler(
 			hubMgr.GetClient(),
@@ -347,18 +427,79 @@ func Start(ctx context.Context, hubCfg, memberConfig *rest.Config, hubOpts, memb
 			klog.ErrorS(err, "unable to find the required CRD", "GVK", gvk)
 			return err
 		}
+		// set up the connectivity prober, so we can pass it to both the work controller and the internal member cluster reconciler
+		connectivityProber := connectivityprobe.New(discoverClient.RESTClient(), connectivityprobe.DefaultProbeInterval)
+		if err = hubMgr.Add(connectivityProber); err != nil {
+			klog.ErrorS(err, "Failed to set up the member cluster connectivity prober")
+			return err
+		}
+		// renew the heartbeat of the member agent on its member cluster, so the hub cluster can tell that it is reachable
+		if err = hubMgr.Add(agentheartbeat.New(hubMgr.GetClient(), mcName)); err != nil {
+			klog.ErrorS(err, "Failed to set up the member agent heartbeater")
+			return err
+		}
+
+		var profiler *work.SlowReconcileProfiler
+		if *profileStorageURL != "" {
+			if profiler, err = work.NewSlowReconcileProfiler(*slowReconcileThreshold, *profileStorageURL, *maxProfileFiles); err != nil {
+				klog.ErrorS(err, "Failed to set up the slow reconcile profiler")
+				return err
+			}
+		}
+
 		// create the work controller, so we can pass it to the internal member cluster reconciler
 		workController := work.NewApplyWorkReconciler(
 			hubMgr.GetClient(),
 			spokeDynamicClient,
 			memberMgr.GetClient(),
-			restMapper, hubMgr.GetEventRecorderFor("work_controller"), 5, targetNS)
+			restMapper, hubMgr.GetEventRecorderFor("work_controller"),
+			work.ApplyWorkReconcilerOptions{
+				Concurrency:             5,
+				WorkNamespace:           targetNS,
+				ConnectivityProber:      connectivityProber,
+				MaxAPICallsPerWork:      *maxAPICallsPerWork,
+				StatusPageSize:          *workStatusPageSize,
+				SanitizedManifestFields: strings.Split(*sanitizedManifestFields, ","),
+				FieldManager:            *ssaFieldManager,
+				Profiler:                profiler,
+				RetryBudget: work.RetryBudgetConfig{
+					MaxAttempts:  *manifestRetryAttempts,
+					InitialDelay: *manifestRetryDelay,
+					MaxDelay:     *manifestRetryMaxDelay,
+				},
+				MaxDriftHistoryDepth: *maxDriftHistoryDepth,
+				MaxManifestSizeBytes: *maxManifestSizeBytes,
+				WorkerPoolSize:       *manifestApplyWorkers,
+			})
 
 		if err = workController.SetupWithManager(hubMgr); err != nil {
 			klog.ErrorS(err, "Failed to create v1beta1 controller", "controller", "work")
 			return err
 		}
 
+		if *changeNotifierURL != "" {
+			klog.Info("Setting up the work change notifier")
+			if err = workchangenotifier.NewWorkChangeNotifier(hubMgr.GetClient(), *changeNotifierURL, *changeNotifierSecret).SetupWithManager(hubMgr); err != nil {
+				klog.ErrorS(err, "Failed to create v1beta1 controller", "controller", "workChangeNotifier")
+				return err
+			}
+		}
+
+		var emailGateway workemailnotifier.Gateway
+		switch {
+		case *emailGatewayURL != "":
+			emailGateway = workemailnotifier.NewWebhookGateway(*emailGatewayURL)
+		case *emailSMTPAddress != "":
+			emailGateway = workemailnotifier.NewSMTPGateway(*emailSMTPAddress, *emailSMTPFrom, *emailSMTPUsername, *emailSMTPPassword)
+		}
+		if emailGateway != nil {
+			klog.Info("Setting up the work email notifier")
+			if err = workemailnotifier.NewWorkEmailNotifier(hubMgr.GetClient(), emailGateway, *emailCooldown).SetupWithManager(hubMgr); err != nil {
+				klog.ErrorS(err, "Failed to create v1beta1 controller", "controller", "workEmailNotifier")
+				return err
+			}
+		}
+
 		klog.Info("Setting up the internalMemberCluster v1beta1 controller")
 		// Set up a provider provider (if applicable).
 		var pp propertyprovider.PropertyProvider
@@ -381,7 +522,8 @@ func Start(ctx context.Context, hubCfg, memberConfig *rest.Config, hubOpts, memb
 			hubMgr.GetClient(),
 			memberMgr.GetConfig(), memberMgr.GetClient(),
 			workController,
-			pp)
+			pp,
+			connectivityProber)
 		if err != nil {
 			klog.ErrorS(err, "Failed to create InternalMemberCluster v1beta1 reconciler")
 			return fmt.Errorf("failed to create InternalMemberCluster v1beta1 reconciler: %w", err)
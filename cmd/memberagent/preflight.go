@@ -0,0 +1,63 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+// workVerbs are the verbs the member agent needs on the works and the appliedWorks.
+var workVerbs = []string{"get", "list", "watch", "update", "patch", "delete"}
+
+// preflightCheck verifies that the member agent has all the permissions it needs to manage the works in the member
+// cluster namespace on the hub cluster and the appliedWorks on the member cluster, for each of the given API groups.
+// The returned error lists every missing permission.
+func preflightCheck(ctx context.Context, hubSARs, memberSARs authorizationv1client.SelfSubjectAccessReviewInterface,
+	mcNamespace string, workGroups []string) error {
+	var errs []error
+	for _, group := range workGroups {
+		for _, verb := range workVerbs {
+			works := authorizationv1.ResourceAttributes{Namespace: mcNamespace, Verb: verb, Group: group, Resource: "works"}
+			if err := checkPermission(ctx, hubSARs, "hub", works); err != nil {
+				errs = append(errs, err)
+			}
+			appliedWorks := authorizationv1.ResourceAttributes{Verb: verb, Group: group, Resource: "appliedworks"}
+			if err := checkPermission(ctx, memberSARs, "member", appliedWorks); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// checkPermission issues a SelfSubjectAccessReview for the resource attributes and returns an error if the access is
+// not allowed.
+func checkPermission(ctx context.Context, sars authorizationv1client.SelfSubjectAccessReviewInterface, cluster string,
+	attrs authorizationv1.ResourceAttributes) error {
+	scope := "cluster scope"
+	if attrs.Namespace != "" {
+		scope = fmt.Sprintf("namespace %s", attrs.Namespace)
+	}
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: &attrs},
+	}
+	result, err := sars.Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to check the permission to %s %s.%s in %s on the %s cluster: %w",
+			attrs.Verb, attrs.Resource, attrs.Group, scope, cluster, err)
+	}
+	if !result.Status.Allowed {
+		return fmt.Errorf("missing the permission to %s %s.%s in %s on the %s cluster",
+			attrs.Verb, attrs.Resource, attrs.Group, scope, cluster)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// fakeSARClientSet returns a clientset whose SelfSubjectAccessReviews deny the given verb/resource combinations.
+func fakeSARClientSet(denied map[string]bool, reviewErr error) *kubefake.Clientset {
+	clientSet := kubefake.NewSimpleClientset()
+	clientSet.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if reviewErr != nil {
+			return true, nil, reviewErr
+		}
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		attrs := review.Spec.ResourceAttributes
+		review.Status.Allowed = !denied[attrs.Verb+" "+attrs.Resource]
+		return true, review, nil
+	})
+	return clientSet
+}
+
+func Test_preflightCheck(t *testing.T) {
+	tests := map[string]struct {
+		hubDenied    map[string]bool
+		memberDenied map[string]bool
+		reviewErr    error
+		wantErrs     []string
+	}{
+		"all the permissions are granted": {},
+		"missing permission to delete works": {
+			hubDenied: map[string]bool{"delete works": true},
+			wantErrs: []string{
+				"missing the permission to delete works.placement.kubernetes-fleet.io in namespace fleet-member-test on the hub cluster",
+			},
+		},
+		"missing permission to watch appliedWorks": {
+			memberDenied: map[string]bool{"watch appliedworks": true},
+			wantErrs: []string{
+				"missing the permission to watch appliedworks.placement.kubernetes-fleet.io in cluster scope on the member cluster",
+			},
+		},
+		"every missing permission is reported": {
+			hubDenied:    map[string]bool{"get works": true, "patch works": true},
+			memberDenied: map[string]bool{"update appliedworks": true},
+			wantErrs: []string{
+				"missing the permission to get works.placement.kubernetes-fleet.io in namespace fleet-member-test on the hub cluster",
+				"missing the permission to patch works.placement.kubernetes-fleet.io in namespace fleet-member-test on the hub cluster",
+				"missing the permission to update appliedworks.placement.kubernetes-fleet.io in cluster scope on the member cluster",
+			},
+		},
+		"failed access review": {
+			reviewErr: errors.New("connection refused"),
+			wantErrs: []string{
+				"failed to check the permission to get works.placement.kubernetes-fleet.io in namespace fleet-member-test on the hub cluster: connection refused",
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			hubClientSet := fakeSARClientSet(tt.hubDenied, tt.reviewErr)
+			memberClientSet := fakeSARClientSet(tt.memberDenied, nil)
+			err := preflightCheck(context.Background(), hubClientSet.AuthorizationV1().SelfSubjectAccessReviews(),
+				memberClientSet.AuthorizationV1().SelfSubjectAccessReviews(), "fleet-member-test",
+				[]string{"placement.kubernetes-fleet.io"})
+			if len(tt.wantErrs) == 0 {
+				assert.Nil(t, err)
+				return
+			}
+			if assert.NotNil(t, err) {
+				for _, want := range tt.wantErrs {
+					assert.Contains(t, err.Error(), want)
+				}
+			}
+		})
+	}
+}
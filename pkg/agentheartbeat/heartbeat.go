@@ -0,0 +1,102 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package agentheartbeat features the heartbeat which the member agent renews on its MemberCluster in the hub cluster,
+// so that the hub cluster can tell quickly whether the member agent is still reachable.
+package agentheartbeat
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+)
+
+const (
+	// Interval is how often the member agent renews its heartbeat.
+	Interval = 30 * time.Second
+
+	// StaleAfter is how old the latest heartbeat can be before the member agent is considered unreachable.
+	StaleAfter = 3 * Interval
+)
+
+// make sure that our Heartbeater implements controller runtime interfaces
+var (
+	_ manager.Runnable               = &Heartbeater{}
+	_ manager.LeaderElectionRunnable = &Heartbeater{}
+)
+
+// Heartbeater periodically sets the heartbeat annotation of the member cluster in the hub cluster.
+type Heartbeater struct {
+	hubClient         client.Client
+	memberClusterName string
+	interval          time.Duration
+}
+
+// New returns a heartbeater which renews the heartbeat of the given member cluster every Interval.
+func New(hubClient client.Client, memberClusterName string) *Heartbeater {
+	return &Heartbeater{
+		hubClient:         hubClient,
+		memberClusterName: memberClusterName,
+		interval:          Interval,
+	}
+}
+
+// Start implements the Runnable interface; it keeps renewing the heartbeat until the context is done.
+func (h *Heartbeater) Start(ctx context.Context) error {
+	klog.V(2).InfoS("Starting the member agent heartbeater", "memberCluster", h.memberClusterName, "interval", h.interval)
+	defer klog.V(2).InfoS("Stopping the member agent heartbeater", "memberCluster", h.memberClusterName)
+	wait.UntilWithContext(ctx, h.beat, h.interval)
+	return nil
+}
+
+// NeedLeaderElection implements the LeaderElectionRunnable interface.
+// Only the member agent which is applying the works reports that it is alive.
+func (h *Heartbeater) NeedLeaderElection() bool {
+	return true
+}
+
+// beat sets the heartbeat annotation to the current time. A failed heartbeat is not retried until the next one as the
+// hub cluster only considers the member agent unreachable after several heartbeats are missed.
+func (h *Heartbeater) beat(ctx context.Context) {
+	now := time.Now()
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{clusterv1beta1.AgentHeartbeatAnnotation: now.UTC().Format(time.RFC3339)},
+		},
+	})
+	if err != nil {
+		klog.ErrorS(err, "Failed to build the heartbeat patch", "memberCluster", h.memberClusterName)
+		return
+	}
+	mc := &clusterv1beta1.MemberCluster{}
+	mc.SetName(h.memberClusterName)
+	if err := h.hubClient.Patch(ctx, mc, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		klog.ErrorS(err, "Failed to renew the member agent heartbeat", "memberCluster", h.memberClusterName)
+		return
+	}
+	klog.V(4).InfoS("Renewed the member agent heartbeat", "memberCluster", h.memberClusterName, "heartbeat", now)
+}
+
+// LastHeartbeat returns the time of the latest heartbeat of the member agent; it returns false if the member agent
+// has not reported any heartbeat.
+func LastHeartbeat(mc *clusterv1beta1.MemberCluster) (time.Time, bool, error) {
+	value, ok := mc.GetAnnotations()[clusterv1beta1.AgentHeartbeatAnnotation]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	heartbeat, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, true, err
+	}
+	return heartbeat, true, nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package agentheartbeat
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+)
+
+func TestBeat(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clusterv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the cluster scheme: %v", err)
+	}
+	mc := &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "member-1",
+			Annotations: map[string]string{"other": "kept"},
+		},
+	}
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(mc).Build()
+	h := New(hubClient, mc.Name)
+
+	lastHeartbeat := func() time.Time {
+		t.Helper()
+		var got clusterv1beta1.MemberCluster
+		if err := hubClient.Get(context.Background(), client.ObjectKeyFromObject(mc), &got); err != nil {
+			t.Fatalf("failed to get the member cluster: %v", err)
+		}
+		if got.GetAnnotations()["other"] != "kept" {
+			t.Errorf("member cluster annotations = %v, want the other annotations kept", got.GetAnnotations())
+		}
+		heartbeat, found, err := LastHeartbeat(&got)
+		if !found || err != nil {
+			t.Fatalf("LastHeartbeat() = %v, %t, %v, want a heartbeat", heartbeat, found, err)
+		}
+		return heartbeat
+	}
+
+	before := time.Now().Add(-time.Second)
+	h.beat(context.Background())
+	first := lastHeartbeat()
+	if first.Before(before) {
+		t.Errorf("heartbeat = %v, want it after %v", first, before)
+	}
+
+	// the heartbeat is recorded in seconds.
+	time.Sleep(time.Second)
+	h.beat(context.Background())
+	if second := lastHeartbeat(); !second.After(first) {
+		t.Errorf("renewed heartbeat = %v, want it after the previous heartbeat %v", second, first)
+	}
+}
+
+func TestLastHeartbeat(t *testing.T) {
+	heartbeat := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		annotations map[string]string
+		want        time.Time
+		wantFound   bool
+		wantErr     bool
+	}{
+		"no heartbeat": {},
+		"valid heartbeat": {
+			annotations: map[string]string{clusterv1beta1.AgentHeartbeatAnnotation: heartbeat.Format(time.RFC3339)},
+			want:        heartbeat,
+			wantFound:   true,
+		},
+		"invalid heartbeat": {
+			annotations: map[string]string{clusterv1beta1.AgentHeartbeatAnnotation: "yesterday"},
+			wantFound:   true,
+			wantErr:     true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mc := &clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: "member-1", Annotations: tt.annotations}}
+			got, found, err := LastHeartbeat(mc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LastHeartbeat() error = %v, want error %t", err, tt.wantErr)
+			}
+			if found != tt.wantFound || !got.Equal(tt.want) {
+				t.Errorf("LastHeartbeat() = %v, %t, want %v, %t", got, found, tt.want, tt.wantFound)
+			}
+		})
+	}
+}
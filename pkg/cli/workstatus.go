@@ -0,0 +1,169 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package cli features the helpers shared by the fleet command line tools.
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/compression"
+)
+
+const (
+	colorRed    = "\x1b[31m"
+	colorYellow = "\x1b[33m"
+	colorReset  = "\x1b[0m"
+
+	// columnGap is the number of spaces between two columns of the table.
+	columnGap = 3
+)
+
+// workStatusHeaders are the column headers of the work status table.
+var workStatusHeaders = []string{"NAME", "MANIFESTS", "APPLIED", "AVAILABLE", "DRIFTED", "LAST RECONCILE"}
+
+// WorkStatusRow is the summary of the status of a work.
+type WorkStatusRow struct {
+	Name           string
+	ManifestsTotal int
+	Applied        int
+	Available      int
+	// Drifted is the number of the manifests whose latest recent event is a drift found in the member cluster.
+	Drifted int
+	// LastReconcile is the last time the status of the work changed; it is zero if the work has never been applied.
+	LastReconcile time.Time
+}
+
+// NewWorkStatusRow summarizes the status of the work.
+func NewWorkStatusRow(work *fleetv1beta1.Work) (WorkStatusRow, error) {
+	if err := compression.DecompressWork(work); err != nil {
+		return WorkStatusRow{}, fmt.Errorf("failed to decompress the manifests of work %s: %w", work.Name, err)
+	}
+	row := WorkStatusRow{
+		Name:           work.Name,
+		ManifestsTotal: len(work.Spec.Workload.Manifests),
+	}
+	for _, manifestCond := range work.Status.ManifestConditions {
+		if meta.IsStatusConditionTrue(manifestCond.Conditions, fleetv1beta1.WorkConditionTypeApplied) {
+			row.Applied++
+		}
+		if meta.IsStatusConditionTrue(manifestCond.Conditions, fleetv1beta1.WorkConditionTypeAvailable) {
+			row.Available++
+		}
+	}
+
+	// the recent events are kept oldest first, so the last event of a manifest tells whether it is still drifted.
+	lastEvents := make(map[int]string)
+	for _, event := range work.Status.RecentEvents {
+		if event.ManifestOrdinal != nil {
+			lastEvents[*event.ManifestOrdinal] = event.Type
+		}
+		row.LastReconcile = latest(row.LastReconcile, event.Timestamp)
+	}
+	for _, eventType := range lastEvents {
+		if eventType == fleetv1beta1.WorkEventTypeDriftFound {
+			row.Drifted++
+		}
+	}
+	for _, cond := range work.Status.Conditions {
+		row.LastReconcile = latest(row.LastReconcile, cond.LastTransitionTime)
+	}
+	return row, nil
+}
+
+// latest returns the later one of the two times.
+func latest(t time.Time, mt metav1.Time) time.Time {
+	if mt.Time.After(t) {
+		return mt.Time
+	}
+	return t
+}
+
+// SortWorkStatusRows sorts the rows by the last reconcile time, the most recently changed work first. The works
+// reconciled at the same time are sorted by their names.
+func SortWorkStatusRows(rows []WorkStatusRow) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		if !rows[i].LastReconcile.Equal(rows[j].LastReconcile) {
+			return rows[i].LastReconcile.After(rows[j].LastReconcile)
+		}
+		return rows[i].Name < rows[j].Name
+	})
+}
+
+// WriteWorkStatusTable writes the rows as a table. If color is true, the rows of the works with drifted manifests are
+// highlighted in yellow and the rows of the works with manifests not applied yet are highlighted in red, which takes
+// precedence.
+func WriteWorkStatusTable(w io.Writer, rows []WorkStatusRow, color bool) error {
+	cells := make([][]string, 0, len(rows)+1)
+	cells = append(cells, workStatusHeaders)
+	for _, row := range rows {
+		lastReconcile := "<unknown>"
+		if !row.LastReconcile.IsZero() {
+			lastReconcile = row.LastReconcile.UTC().Format(time.RFC3339)
+		}
+		cells = append(cells, []string{
+			row.Name,
+			strconv.Itoa(row.ManifestsTotal),
+			strconv.Itoa(row.Applied),
+			strconv.Itoa(row.Available),
+			strconv.Itoa(row.Drifted),
+			lastReconcile,
+		})
+	}
+	widths := make([]int, len(workStatusHeaders))
+	for _, line := range cells {
+		for i, cell := range line {
+			if len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+
+	var b strings.Builder
+	for i, line := range cells {
+		// the escape codes wrap the padded line so that they do not break the alignment of the columns.
+		highlight := ""
+		if color && i > 0 {
+			switch row := rows[i-1]; {
+			case row.Applied < row.ManifestsTotal:
+				highlight = colorRed
+			case row.Drifted > 0:
+				highlight = colorYellow
+			}
+		}
+		b.WriteString(highlight)
+		for j, cell := range line {
+			if j == len(line)-1 {
+				b.WriteString(cell)
+				break
+			}
+			b.WriteString(cell)
+			b.WriteString(strings.Repeat(" ", widths[j]-len(cell)+columnGap))
+		}
+		if highlight != "" {
+			b.WriteString(colorReset)
+		}
+		b.WriteString("\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// IsTerminal returns true if the writer is a terminal.
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
@@ -0,0 +1,158 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+var baseTime = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// statusWork returns a work with the given number of manifests and the given manifest conditions.
+func statusWork(name string, manifests int, lastTransition time.Time, applied, available []bool, events ...fleetv1beta1.WorkEvent) *fleetv1beta1.Work {
+	work := &fleetv1beta1.Work{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	for i := 0; i < manifests; i++ {
+		work.Spec.Workload.Manifests = append(work.Spec.Workload.Manifests,
+			fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: []byte(`{}`)}})
+	}
+	status := func(b bool) metav1.ConditionStatus {
+		if b {
+			return metav1.ConditionTrue
+		}
+		return metav1.ConditionFalse
+	}
+	for i := range applied {
+		work.Status.ManifestConditions = append(work.Status.ManifestConditions, fleetv1beta1.ManifestCondition{
+			Identifier: fleetv1beta1.WorkResourceIdentifier{Ordinal: i},
+			Conditions: []metav1.Condition{
+				{Type: fleetv1beta1.WorkConditionTypeApplied, Status: status(applied[i])},
+				{Type: fleetv1beta1.WorkConditionTypeAvailable, Status: status(available[i])},
+			},
+		})
+	}
+	if !lastTransition.IsZero() {
+		work.Status.Conditions = []metav1.Condition{
+			{Type: fleetv1beta1.WorkConditionTypeApplied, Status: metav1.ConditionTrue, LastTransitionTime: metav1.NewTime(lastTransition)},
+		}
+	}
+	work.Status.RecentEvents = events
+	return work
+}
+
+func workEvent(at time.Time, eventType string, ordinal int) fleetv1beta1.WorkEvent {
+	return fleetv1beta1.WorkEvent{Timestamp: metav1.NewTime(at), Type: eventType, ManifestOrdinal: ptr.To(ordinal)}
+}
+
+// mixedStatusWorks returns the works of a fleet in which the works are in different states.
+func mixedStatusWorks() []*fleetv1beta1.Work {
+	return []*fleetv1beta1.Work{
+		statusWork("healthy", 2, baseTime.Add(-time.Hour), []bool{true, true}, []bool{true, true}),
+		statusWork("failing", 3, baseTime.Add(-2*time.Hour), []bool{true, false, false}, []bool{true, false, false},
+			workEvent(baseTime.Add(-30*time.Minute), fleetv1beta1.WorkEventTypeError, 1)),
+		statusWork("drifted", 2, baseTime.Add(-3*time.Hour), []bool{true, true}, []bool{true, true},
+			workEvent(baseTime.Add(-20*time.Minute), fleetv1beta1.WorkEventTypeDriftFound, 0),
+			// the drift of the 2nd manifest has been corrected since.
+			workEvent(baseTime.Add(-15*time.Minute), fleetv1beta1.WorkEventTypeDriftFound, 1),
+			workEvent(baseTime.Add(-10*time.Minute), fleetv1beta1.WorkEventTypeManifestApplied, 1)),
+		statusWork("new", 1, time.Time{}, nil, nil),
+	}
+}
+
+func TestNewWorkStatusRow(t *testing.T) {
+	var rows []WorkStatusRow
+	for _, work := range mixedStatusWorks() {
+		row, err := NewWorkStatusRow(work)
+		if err != nil {
+			t.Fatalf("NewWorkStatusRow(%s) = %v, want no error", work.Name, err)
+		}
+		rows = append(rows, row)
+	}
+	SortWorkStatusRows(rows)
+	want := []WorkStatusRow{
+		{Name: "drifted", ManifestsTotal: 2, Applied: 2, Available: 2, Drifted: 1, LastReconcile: baseTime.Add(-10 * time.Minute)},
+		{Name: "failing", ManifestsTotal: 3, Applied: 1, Available: 1, LastReconcile: baseTime.Add(-30 * time.Minute)},
+		{Name: "healthy", ManifestsTotal: 2, Applied: 2, Available: 2, LastReconcile: baseTime.Add(-time.Hour)},
+		{Name: "new", ManifestsTotal: 1},
+	}
+	if diff := cmp.Diff(want, rows); diff != "" {
+		t.Errorf("NewWorkStatusRow() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteWorkStatusTable(t *testing.T) {
+	rows := []WorkStatusRow{
+		{Name: "drifted", ManifestsTotal: 2, Applied: 2, Available: 2, Drifted: 1, LastReconcile: baseTime.Add(-10 * time.Minute)},
+		{Name: "failing", ManifestsTotal: 3, Applied: 1, Available: 1, LastReconcile: baseTime.Add(-30 * time.Minute)},
+		{Name: "failing-and-drifted", ManifestsTotal: 2, Applied: 1, Available: 1, Drifted: 1, LastReconcile: baseTime.Add(-45 * time.Minute)},
+		{Name: "healthy", ManifestsTotal: 2, Applied: 2, Available: 2, LastReconcile: baseTime.Add(-time.Hour)},
+		{Name: "new", ManifestsTotal: 1},
+	}
+	lines := []string{
+		"NAME                  MANIFESTS   APPLIED   AVAILABLE   DRIFTED   LAST RECONCILE",
+		"drifted               2           2         2           1         2024-06-01T11:50:00Z",
+		"failing               3           1         1           0         2024-06-01T11:30:00Z",
+		"failing-and-drifted   2           1         1           1         2024-06-01T11:15:00Z",
+		"healthy               2           2         2           0         2024-06-01T11:00:00Z",
+		"new                   1           0         0           0         <unknown>",
+	}
+	tests := map[string]struct {
+		color bool
+		want  []string
+	}{
+		"without color": {
+			want: lines,
+		},
+		"with color": {
+			color: true,
+			want: []string{
+				lines[0],
+				colorYellow + lines[1] + colorReset,
+				colorRed + lines[2] + colorReset,
+				colorRed + lines[3] + colorReset,
+				lines[4],
+				colorRed + lines[5] + colorReset,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := WriteWorkStatusTable(&out, rows, tt.color); err != nil {
+				t.Fatalf("WriteWorkStatusTable() = %v, want no error", err)
+			}
+			if diff := cmp.Diff(strings.Join(tt.want, "\n")+"\n", out.String()); diff != "" {
+				t.Errorf("WriteWorkStatusTable() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsTerminal(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create a pipe: %v", err)
+	}
+	defer r.Close()
+	defer w.Close()
+	// neither an in-memory buffer nor a pipe, e.g. the output redirected to another command, gets the color codes.
+	for name, out := range map[string]io.Writer{"buffer": &bytes.Buffer{}, "pipe": w} {
+		if IsTerminal(out) {
+			t.Errorf("IsTerminal(%s) = true, want false", name)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package connectivityprobe features a prober that periodically checks whether the member cluster
+// API server is reachable from the Fleet member agent.
+package connectivityprobe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// probePath is the path to the member cluster API server which the prober performs
+	// connectivity checks against.
+	//
+	// The `/healthz` endpoint has been deprecated since Kubernetes v1.16; here Fleet will
+	// probe the readiness check endpoint instead.
+	probePath = "/readyz"
+
+	// probeTimeout is the timeout of a single connectivity check.
+	probeTimeout = time.Second * 5
+
+	// DefaultProbeInterval is the default interval between two connectivity checks.
+	DefaultProbeInterval = time.Second * 15
+)
+
+// make sure that our Prober implements controller runtime interfaces
+var (
+	_ manager.Runnable               = &Prober{}
+	_ manager.LeaderElectionRunnable = &Prober{}
+)
+
+// Prober periodically probes the member cluster API server and keeps the latest connectivity status.
+type Prober struct {
+	restClient rest.Interface
+	interval   time.Duration
+
+	mu      sync.RWMutex
+	status  metav1.ConditionStatus
+	message string
+}
+
+// New returns a prober which uses the given REST client to probe the member cluster API server every interval.
+func New(restClient rest.Interface, interval time.Duration) *Prober {
+	return &Prober{
+		restClient: restClient,
+		interval:   interval,
+		status:     metav1.ConditionUnknown,
+		message:    "the member cluster API server has not been probed yet",
+	}
+}
+
+// Start implements the Runnable interface; it keeps probing the member cluster API server until the context is done.
+func (p *Prober) Start(ctx context.Context) error {
+	klog.V(2).InfoS("Starting the member cluster connectivity prober", "interval", p.interval)
+	defer klog.V(2).InfoS("Stopping the member cluster connectivity prober")
+	wait.UntilWithContext(ctx, p.probe, p.interval)
+	return nil
+}
+
+// NeedLeaderElection implements the LeaderElectionRunnable interface.
+// The connectivity status is local to each agent so every agent probes on its own.
+func (p *Prober) NeedLeaderElection() bool {
+	return false
+}
+
+// Status returns the latest connectivity status of the member cluster API server and a human-readable message.
+func (p *Prober) Status() (metav1.ConditionStatus, string) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.status, p.message
+}
+
+// IsDisconnected returns true only if the latest probe has failed; an unknown status is not treated as disconnected.
+func (p *Prober) IsDisconnected() bool {
+	status, _ := p.Status()
+	return status == metav1.ConditionFalse
+}
+
+func (p *Prober) probe(ctx context.Context) {
+	probeCtx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	var statusCode int
+	err := p.restClient.Get().AbsPath(probePath).Do(probeCtx).StatusCode(&statusCode).Error()
+	if err == nil && statusCode != http.StatusOK {
+		err = fmt.Errorf("connectivity probe failed with status code %d", statusCode)
+	}
+	if err != nil {
+		klog.ErrorS(err, "Failed to probe the member cluster API server")
+		p.setStatus(metav1.ConditionFalse, err.Error())
+		return
+	}
+	klog.V(4).InfoS("Connectivity probe succeeded")
+	p.setStatus(metav1.ConditionTrue, "the member cluster API server is reachable")
+}
+
+func (p *Prober) setStatus(status metav1.ConditionStatus, message string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.status != status {
+		klog.V(2).InfoS("Member cluster connectivity status changed", "oldStatus", p.status, "newStatus", status)
+	}
+	p.status = status
+	p.message = message
+}
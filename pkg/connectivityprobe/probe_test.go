@@ -0,0 +1,84 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package connectivityprobe
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+func TestProbe(t *testing.T) {
+	tests := map[string]struct {
+		statusCode       int
+		wantStatus       metav1.ConditionStatus
+		wantDisconnected bool
+	}{
+		"api server is ready": {
+			statusCode:       http.StatusOK,
+			wantStatus:       metav1.ConditionTrue,
+			wantDisconnected: false,
+		},
+		"api server is not ready": {
+			statusCode:       http.StatusInternalServerError,
+			wantStatus:       metav1.ConditionFalse,
+			wantDisconnected: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != probePath {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+			clientSet, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+			if err != nil {
+				t.Fatalf("failed to create the client set: %v", err)
+			}
+			p := New(clientSet.Discovery().RESTClient(), time.Second)
+			if status, _ := p.Status(); status != metav1.ConditionUnknown {
+				t.Errorf("Status() before probing = %v, want %v", status, metav1.ConditionUnknown)
+			}
+			if p.IsDisconnected() {
+				t.Errorf("IsDisconnected() before probing = true, want false")
+			}
+
+			p.probe(context.Background())
+			if status, _ := p.Status(); status != tt.wantStatus {
+				t.Errorf("Status() = %v, want %v", status, tt.wantStatus)
+			}
+			if got := p.IsDisconnected(); got != tt.wantDisconnected {
+				t.Errorf("IsDisconnected() = %v, want %v", got, tt.wantDisconnected)
+			}
+		})
+	}
+}
+
+func TestProbeUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	host := server.URL
+	server.Close()
+
+	clientSet, err := kubernetes.NewForConfig(&rest.Config{Host: host})
+	if err != nil {
+		t.Fatalf("failed to create the client set: %v", err)
+	}
+	p := New(clientSet.Discovery().RESTClient(), time.Second)
+	p.probe(context.Background())
+	if !p.IsDisconnected() {
+		t.Errorf("IsDisconnected() = false, want true")
+	}
+}
@@ -0,0 +1,318 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package broadcastwork features a controller to place the workload of a BroadcastWork on all the selected member
+// clusters.
+package broadcastwork
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// workSynchronizedReason is the reason of the WorkSynchronized condition when all the works are synchronized.
+	workSynchronizedReason = "AllWorkSynced"
+	// workNotSynchronizedReason is the reason of the WorkSynchronized condition when some works fail to synchronize.
+	workNotSynchronizedReason = "SyncWorkFailed"
+	// invalidClusterSelectorReason is the reason of the WorkSynchronized condition when the cluster selector is invalid.
+	invalidClusterSelectorReason = "InvalidClusterSelector"
+)
+
+// Reconciler reconciles a BroadcastWork object.
+type Reconciler struct {
+	// Client is the client the controller uses to access the hub cluster.
+	client.Client
+}
+
+// Reconcile creates a work for every member cluster selected by the broadcast work, deletes the works of the clusters
+// which are no longer selected and aggregates the work statuses.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	bwRef := klog.KRef("", req.Name)
+	startTime := time.Now()
+	klog.V(2).InfoS("BroadcastWork reconciliation starts", "broadcastWork", bwRef)
+	defer func() {
+		latency := time.Since(startTime).Milliseconds()
+		klog.V(2).InfoS("BroadcastWork reconciliation ends", "broadcastWork", bwRef, "latency", latency)
+	}()
+
+	var bw fleetv1beta1.BroadcastWork
+	if err := r.Client.Get(ctx, req.NamespacedName, &bw); err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.V(2).InfoS("Ignoring NotFound broadcastWork", "broadcastWork", bwRef)
+			return ctrl.Result{}, nil
+		}
+		klog.ErrorS(err, "Failed to get broadcastWork", "broadcastWork", bwRef)
+		return ctrl.Result{}, controller.NewAPIServerError(true, err)
+	}
+	if bw.DeletionTimestamp != nil {
+		// the works are garbage collected via the owner reference.
+		klog.V(2).InfoS("BroadcastWork is being deleted", "broadcastWork", bwRef)
+		return ctrl.Result{}, nil
+	}
+
+	selectedClusters, err := r.selectClusters(ctx, &bw)
+	if err != nil {
+		if errors.Is(err, controller.ErrUserError) {
+			bw.SetConditions(metav1.Condition{
+				Type:               string(fleetv1beta1.BroadcastWorkConditionTypeWorkSynchronized),
+				Status:             metav1.ConditionFalse,
+				Reason:             invalidClusterSelectorReason,
+				Message:            err.Error(),
+				ObservedGeneration: bw.Generation,
+			})
+			return ctrl.Result{}, r.updateStatus(ctx, &bw)
+		}
+		return ctrl.Result{}, err
+	}
+
+	existingWorks, err := r.listWorks(ctx, &bw)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var syncErr error
+	for cluster := range selectedClusters {
+		if err := r.syncWork(ctx, &bw, cluster, existingWorks[cluster]); err != nil {
+			syncErr = err
+		}
+	}
+	for cluster, work := range existingWorks {
+		if selectedClusters[cluster] {
+			continue
+		}
+		klog.V(2).InfoS("Deleting the work of the unselected cluster", "broadcastWork", bwRef, "work", klog.KObj(work))
+		if err := r.Client.Delete(ctx, work); err != nil && !apierrors.IsNotFound(err) {
+			klog.ErrorS(err, "Failed to delete the work of the unselected cluster", "broadcastWork", bwRef, "work", klog.KObj(work))
+			syncErr = controller.NewAPIServerError(false, err)
+		}
+		delete(existingWorks, cluster)
+	}
+
+	bw.Status.ClusterStatuses = make(map[string]fleetv1beta1.WorkStatus, len(existingWorks))
+	for cluster, work := range existingWorks {
+		bw.Status.ClusterStatuses[cluster] = work.Status
+	}
+	syncCond := metav1.Condition{
+		Type:               string(fleetv1beta1.BroadcastWorkConditionTypeWorkSynchronized),
+		Status:             metav1.ConditionTrue,
+		Reason:             workSynchronizedReason,
+		Message:            fmt.Sprintf("Works are synchronized to %d selected clusters", len(selectedClusters)),
+		ObservedGeneration: bw.Generation,
+	}
+	if syncErr != nil {
+		syncCond.Status = metav1.ConditionFalse
+		syncCond.Reason = workNotSynchronizedReason
+		syncCond.Message = syncErr.Error()
+	}
+	bw.SetConditions(syncCond)
+	if err := r.updateStatus(ctx, &bw); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, syncErr
+}
+
+// selectClusters returns the names of the member clusters selected by the broadcast work.
+// The member clusters which are being deleted are skipped.
+func (r *Reconciler) selectClusters(ctx context.Context, bw *fleetv1beta1.BroadcastWork) (map[string]bool, error) {
+	var clusterList clusterv1beta1.MemberClusterList
+	if err := r.Client.List(ctx, &clusterList); err != nil {
+		klog.ErrorS(err, "Failed to list member clusters", "broadcastWork", klog.KObj(bw))
+		return nil, controller.NewAPIServerError(true, err)
+	}
+	selected := make(map[string]bool)
+	for i := range clusterList.Items {
+		cluster := &clusterList.Items[i]
+		if cluster.DeletionTimestamp != nil {
+			continue
+		}
+		matched, err := isClusterSelected(cluster, bw.Spec.ClusterSelector)
+		if err != nil {
+			klog.ErrorS(err, "Invalid cluster selector", "broadcastWork", klog.KObj(bw))
+			return nil, controller.NewUserError(err)
+		}
+		if matched {
+			selected[cluster.Name] = true
+		}
+	}
+	return selected, nil
+}
+
+// isClusterSelected checks if the cluster is matched by the cluster selector.
+// A nil cluster selector matches no member clusters while an empty list of terms matches all member clusters.
+func isClusterSelected(cluster *clusterv1beta1.MemberCluster, clusterSelector *fleetv1beta1.ClusterSelector) (bool, error) {
+	if clusterSelector == nil {
+		return false, nil
+	}
+	if len(clusterSelector.ClusterSelectorTerms) == 0 {
+		return true, nil
+	}
+	for _, term := range clusterSelector.ClusterSelectorTerms {
+		selector, err := metav1.LabelSelectorAsSelector(term.LabelSelector)
+		if err != nil {
+			return false, fmt.Errorf("invalid cluster label selector %v: %w", term.LabelSelector, err)
+		}
+		if selector.Matches(labels.Set(cluster.Labels)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// listWorks returns the existing works generated by the broadcast work keyed by the cluster name.
+func (r *Reconciler) listWorks(ctx context.Context, bw *fleetv1beta1.BroadcastWork) (map[string]*fleetv1beta1.Work, error) {
+	var workList fleetv1beta1.WorkList
+	if err := r.Client.List(ctx, &workList, client.MatchingLabels{fleetv1beta1.BroadcastWorkTrackingLabel: bw.Name}); err != nil {
+		klog.ErrorS(err, "Failed to list the works of the broadcastWork", "broadcastWork", klog.KObj(bw))
+		return nil, controller.NewAPIServerError(true, err)
+	}
+	works := make(map[string]*fleetv1beta1.Work, len(workList.Items))
+	for i := range workList.Items {
+		work := &workList.Items[i]
+		if work.DeletionTimestamp != nil {
+			continue
+		}
+		var cluster string
+		if _, err := fmt.Sscanf(work.Namespace, utils.NamespaceNameFormat, &cluster); err != nil {
+			klog.ErrorS(controller.NewUnexpectedBehaviorError(err), "Found a broadcast work in an unexpected namespace", "broadcastWork", klog.KObj(bw), "work", klog.KObj(work))
+			continue
+		}
+		works[cluster] = work
+	}
+	return works, nil
+}
+
+// syncWork creates or updates the work of the given cluster so that it matches the broadcast work spec.
+func (r *Reconciler) syncWork(ctx context.Context, bw *fleetv1beta1.BroadcastWork, cluster string, existing *fleetv1beta1.Work) error {
+	desired := buildWork(bw, cluster)
+	if existing == nil {
+		klog.V(2).InfoS("Creating the work for the selected cluster", "broadcastWork", klog.KObj(bw), "work", klog.KObj(desired))
+		if err := r.Client.Create(ctx, desired); err != nil {
+			klog.ErrorS(err, "Failed to create the work for the selected cluster", "broadcastWork", klog.KObj(bw), "work", klog.KObj(desired))
+			return controller.NewAPIServerError(false, err)
+		}
+		return nil
+	}
+	if equality.Semantic.DeepEqual(existing.Spec, desired.Spec) {
+		return nil
+	}
+	existing.Spec = desired.Spec
+	klog.V(2).InfoS("Updating the work for the selected cluster", "broadcastWork", klog.KObj(bw), "work", klog.KObj(existing))
+	if err := r.Client.Update(ctx, existing); err != nil {
+		klog.ErrorS(err, "Failed to update the work for the selected cluster", "broadcastWork", klog.KObj(bw), "work", klog.KObj(existing))
+		return controller.NewAPIServerError(false, err)
+	}
+	return nil
+}
+
+// buildWork builds the work of the broadcast work for the given cluster.
+func buildWork(bw *fleetv1beta1.BroadcastWork, cluster string) *fleetv1beta1.Work {
+	return &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf(fleetv1beta1.BroadcastWorkNameFmt, bw.Name, cluster),
+			Namespace: fmt.Sprintf(utils.NamespaceNameFormat, cluster),
+			Labels: map[string]string{
+				fleetv1beta1.BroadcastWorkTrackingLabel: bw.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         fleetv1beta1.GroupVersion.String(),
+					Kind:               fleetv1beta1.BroadcastWorkKind,
+					Name:               bw.Name,
+					UID:                bw.UID,
+					BlockOwnerDeletion: ptr.To(true), // make sure that the k8s will call work delete when the broadcast work is deleted
+				},
+			},
+		},
+		Spec: fleetv1beta1.WorkSpec{
+			Workload:      *bw.Spec.Workload.DeepCopy(),
+			ApplyStrategy: bw.Spec.ApplyStrategy.DeepCopy(),
+		},
+	}
+}
+
+func (r *Reconciler) updateStatus(ctx context.Context, bw *fleetv1beta1.BroadcastWork) error {
+	if err := r.Client.Status().Update(ctx, bw); err != nil {
+		klog.ErrorS(err, "Failed to update the broadcastWork status", "broadcastWork", klog.KObj(bw))
+		return controller.NewUpdateIgnoreConflictError(err)
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).Named("broadcast-work-controller").
+		For(&fleetv1beta1.BroadcastWork{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&fleetv1beta1.Work{}, handler.EnqueueRequestsFromMapFunc(
+			func(_ context.Context, o client.Object) []reconcile.Request {
+				bwName, ok := o.GetLabels()[fleetv1beta1.BroadcastWorkTrackingLabel]
+				if !ok || len(bwName) == 0 {
+					return nil
+				}
+				return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: bwName}}}
+			})).
+		Watches(&clusterv1beta1.MemberCluster{}, handler.EnqueueRequestsFromMapFunc(r.enqueueAllBroadcastWorks),
+			builder.WithPredicates(memberClusterPredicate())).
+		Complete(r)
+}
+
+// enqueueAllBroadcastWorks enqueues all the broadcast works as any of them may select the changed member cluster.
+func (r *Reconciler) enqueueAllBroadcastWorks(ctx context.Context, o client.Object) []reconcile.Request {
+	var bwList fleetv1beta1.BroadcastWorkList
+	if err := r.Client.List(ctx, &bwList); err != nil {
+		klog.ErrorS(err, "Failed to list broadcastWorks", "memberCluster", klog.KObj(o))
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(bwList.Items))
+	for _, bw := range bwList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: bw.Name}})
+	}
+	return requests
+}
+
+// memberClusterPredicate filters the member cluster events which could change the cluster selection.
+func memberClusterPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(_ event.CreateEvent) bool {
+			return true
+		},
+		DeleteFunc: func(_ event.DeleteEvent) bool {
+			return true
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				klog.ErrorS(controller.NewUnexpectedBehaviorError(fmt.Errorf("update event is invalid")), "Failed to process update event")
+				return false
+			}
+			return !equality.Semantic.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels()) ||
+				(e.ObjectOld.GetDeletionTimestamp() == nil) != (e.ObjectNew.GetDeletionTimestamp() == nil)
+		},
+		GenericFunc: func(_ event.GenericEvent) bool {
+			return false
+		},
+	}
+}
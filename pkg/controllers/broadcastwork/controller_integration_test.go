@@ -0,0 +1,171 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+package broadcastwork
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+)
+
+const (
+	testBroadcastWorkName = "test-broadcast-work"
+	testLabelKey          = "env"
+	testLabelValue        = "prod"
+
+	eventuallyTimeout = time.Second * 10
+	interval          = time.Millisecond * 250
+)
+
+var _ = Describe("Test BroadcastWork controller", Serial, func() {
+	var bw *fleetv1beta1.BroadcastWork
+	clusterNames := []string{"cluster-1", "cluster-2"}
+
+	BeforeEach(func() {
+		By("Creating the member clusters and their reserved namespaces")
+		createMemberCluster(clusterNames[0], map[string]string{testLabelKey: testLabelValue})
+		createMemberCluster(clusterNames[1], map[string]string{testLabelKey: "dev"})
+
+		By("Creating the broadcast work")
+		bw = &fleetv1beta1.BroadcastWork{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: testBroadcastWorkName,
+			},
+			Spec: fleetv1beta1.BroadcastWorkSpec{
+				ClusterSelector: &fleetv1beta1.ClusterSelector{
+					ClusterSelectorTerms: []fleetv1beta1.ClusterSelectorTerm{
+						{
+							LabelSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{testLabelKey: testLabelValue},
+							},
+						},
+					},
+				},
+				Workload: fleetv1beta1.WorkloadTemplate{
+					Manifests: []fleetv1beta1.Manifest{
+						{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"test-cm","namespace":"default"}}`)}},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, bw)).Should(Succeed(), "failed to create broadcast work")
+	})
+
+	AfterEach(func() {
+		By("Deleting the broadcast work")
+		Expect(k8sClient.Delete(ctx, bw)).Should(Succeed(), "failed to delete broadcast work")
+		for _, name := range append(clusterNames, "cluster-3") {
+			deleteMemberCluster(name)
+		}
+	})
+
+	It("Should create the work only for the selected clusters", func() {
+		checkWorkCreated(clusterNames[0], bw)
+		checkWorkNotCreated(clusterNames[1])
+	})
+
+	It("Should create the work for a new cluster matching the selector", func() {
+		checkWorkCreated(clusterNames[0], bw)
+
+		By("Joining a new member cluster matching the selector")
+		createMemberCluster("cluster-3", map[string]string{testLabelKey: testLabelValue})
+		checkWorkCreated("cluster-3", bw)
+	})
+
+	It("Should add and remove the works when the selector changes", func() {
+		checkWorkCreated(clusterNames[0], bw)
+
+		By("Updating the selector to select the other cluster")
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testBroadcastWorkName}, bw)).Should(Succeed())
+		bw.Spec.ClusterSelector.ClusterSelectorTerms[0].LabelSelector.MatchLabels = map[string]string{testLabelKey: "dev"}
+		Expect(k8sClient.Update(ctx, bw)).Should(Succeed(), "failed to update broadcast work")
+
+		checkWorkCreated(clusterNames[1], bw)
+		checkWorkDeleted(clusterNames[0])
+	})
+})
+
+func createMemberCluster(name string, labels map[string]string) {
+	mc := &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+		Spec: clusterv1beta1.MemberClusterSpec{
+			Identity: rbacv1.Subject{
+				Name:      "fleet-member-agent",
+				Kind:      "ServiceAccount",
+				Namespace: utils.FleetSystemNamespace,
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, mc)).Should(Succeed(), "failed to create member cluster %s", name)
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf(utils.NamespaceNameFormat, name),
+		},
+	}
+	Expect(k8sClient.Create(ctx, ns)).Should(Or(Succeed(), Satisfy(apierrors.IsAlreadyExists)), "failed to create namespace %s", ns.Name)
+}
+
+func deleteMemberCluster(name string) {
+	mc := &clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	Expect(k8sClient.Delete(ctx, mc)).Should(Or(Succeed(), Satisfy(apierrors.IsNotFound)), "failed to delete member cluster %s", name)
+	// envtest does not run the garbage collector, so the works are cleaned up explicitly.
+	Expect(k8sClient.DeleteAllOf(ctx, &fleetv1beta1.Work{}, client.InNamespace(fmt.Sprintf(utils.NamespaceNameFormat, name)))).Should(Succeed())
+}
+
+func checkWorkCreated(cluster string, bw *fleetv1beta1.BroadcastWork) {
+	By(fmt.Sprintf("Checking the work is created for cluster %s", cluster))
+	workKey := types.NamespacedName{
+		Name:      fmt.Sprintf(fleetv1beta1.BroadcastWorkNameFmt, bw.Name, cluster),
+		Namespace: fmt.Sprintf(utils.NamespaceNameFormat, cluster),
+	}
+	Eventually(func() error {
+		var work fleetv1beta1.Work
+		if err := k8sClient.Get(ctx, workKey, &work); err != nil {
+			return err
+		}
+		if diff := cmp.Diff(bw.Spec.Workload, work.Spec.Workload); diff != "" {
+			return fmt.Errorf("work workload mismatch (-want +got):\n%s", diff)
+		}
+		if got := work.Labels[fleetv1beta1.BroadcastWorkTrackingLabel]; got != bw.Name {
+			return fmt.Errorf("work tracking label = %q, want %q", got, bw.Name)
+		}
+		return nil
+	}, eventuallyTimeout, interval).Should(Succeed(), "failed to find the work for cluster %s", cluster)
+}
+
+func checkWorkNotCreated(cluster string) {
+	By(fmt.Sprintf("Checking the work is not created for cluster %s", cluster))
+	Consistently(func() (int, error) {
+		var workList fleetv1beta1.WorkList
+		err := k8sClient.List(ctx, &workList, client.InNamespace(fmt.Sprintf(utils.NamespaceNameFormat, cluster)))
+		return len(workList.Items), err
+	}, time.Second*3, interval).Should(BeZero(), "work should not be created for cluster %s", cluster)
+}
+
+func checkWorkDeleted(cluster string) {
+	By(fmt.Sprintf("Checking the work is deleted for cluster %s", cluster))
+	Eventually(func() (int, error) {
+		var workList fleetv1beta1.WorkList
+		err := k8sClient.List(ctx, &workList, client.InNamespace(fmt.Sprintf(utils.NamespaceNameFormat, cluster)))
+		return len(workList.Items), err
+	}, eventuallyTimeout, interval).Should(BeZero(), "work should be deleted for cluster %s", cluster)
+}
@@ -0,0 +1,171 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package broadcastwork
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+)
+
+func TestIsClusterSelected(t *testing.T) {
+	cluster := &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "cluster-1",
+			Labels: map[string]string{"env": "prod"},
+		},
+	}
+	tests := map[string]struct {
+		clusterSelector *fleetv1beta1.ClusterSelector
+		want            bool
+		wantErr         bool
+	}{
+		"nil cluster selector selects no cluster": {
+			clusterSelector: nil,
+			want:            false,
+		},
+		"empty cluster selector terms select all clusters": {
+			clusterSelector: &fleetv1beta1.ClusterSelector{},
+			want:            true,
+		},
+		"matched label selector": {
+			clusterSelector: &fleetv1beta1.ClusterSelector{
+				ClusterSelectorTerms: []fleetv1beta1.ClusterSelectorTerm{
+					{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}}},
+					{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}},
+				},
+			},
+			want: true,
+		},
+		"unmatched label selector": {
+			clusterSelector: &fleetv1beta1.ClusterSelector{
+				ClusterSelectorTerms: []fleetv1beta1.ClusterSelectorTerm{
+					{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}}},
+				},
+			},
+			want: false,
+		},
+		"invalid label selector": {
+			clusterSelector: &fleetv1beta1.ClusterSelector{
+				ClusterSelectorTerms: []fleetv1beta1.ClusterSelectorTerm{
+					{LabelSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "env", Operator: "invalid"}}}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := isClusterSelected(cluster, tt.clusterSelector)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("isClusterSelected() got error %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("isClusterSelected() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add placement scheme: %v", err)
+	}
+	if err := clusterv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add cluster scheme: %v", err)
+	}
+	bw := &fleetv1beta1.BroadcastWork{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-bw",
+			Generation: 2,
+		},
+		Spec: fleetv1beta1.BroadcastWorkSpec{
+			ClusterSelector: &fleetv1beta1.ClusterSelector{
+				ClusterSelectorTerms: []fleetv1beta1.ClusterSelectorTerm{
+					{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}},
+				},
+			},
+			Workload: fleetv1beta1.WorkloadTemplate{
+				Manifests: []fleetv1beta1.Manifest{
+					{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm","namespace":"default"}}`)}},
+				},
+			},
+		},
+	}
+	selectedCluster := &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-1", Labels: map[string]string{"env": "prod"}},
+	}
+	unselectedCluster := &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-2", Labels: map[string]string{"env": "dev"}},
+	}
+	staleWork := buildWork(bw, unselectedCluster.Name)
+	staleWork.Status = fleetv1beta1.WorkStatus{
+		Conditions: []metav1.Condition{{Type: fleetv1beta1.WorkConditionTypeApplied, Status: metav1.ConditionTrue, Reason: "applied"}},
+	}
+	outdatedWork := buildWork(bw, selectedCluster.Name)
+	outdatedWork.Spec.Workload.Manifests = nil
+	outdatedWork.Status = fleetv1beta1.WorkStatus{
+		Conditions: []metav1.Condition{{Type: fleetv1beta1.WorkConditionTypeApplied, Status: metav1.ConditionFalse, Reason: "failed"}},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(bw, selectedCluster, unselectedCluster, staleWork, outdatedWork).
+		WithStatusSubresource(&fleetv1beta1.BroadcastWork{}, &fleetv1beta1.Work{}).
+		Build()
+	r := &Reconciler{Client: fakeClient}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: bw.Name}}); err != nil {
+		t.Fatalf("Reconcile() = %v, want no error", err)
+	}
+
+	var gotWork fleetv1beta1.Work
+	workKey := types.NamespacedName{
+		Name:      fmt.Sprintf(fleetv1beta1.BroadcastWorkNameFmt, bw.Name, selectedCluster.Name),
+		Namespace: fmt.Sprintf(utils.NamespaceNameFormat, selectedCluster.Name),
+	}
+	if err := fakeClient.Get(context.Background(), workKey, &gotWork); err != nil {
+		t.Fatalf("failed to get the work of the selected cluster: %v", err)
+	}
+	if diff := cmp.Diff(bw.Spec.Workload, gotWork.Spec.Workload); diff != "" {
+		t.Errorf("work workload mismatch (-want +got):\n%s", diff)
+	}
+
+	var workList fleetv1beta1.WorkList
+	if err := fakeClient.List(context.Background(), &workList, client.InNamespace(fmt.Sprintf(utils.NamespaceNameFormat, unselectedCluster.Name))); err != nil {
+		t.Fatalf("failed to list the works of the unselected cluster: %v", err)
+	}
+	if len(workList.Items) != 0 {
+		t.Errorf("got %d works for the unselected cluster, want 0", len(workList.Items))
+	}
+
+	var gotBW fleetv1beta1.BroadcastWork
+	if err := fakeClient.Get(context.Background(), types.NamespacedName{Name: bw.Name}, &gotBW); err != nil {
+		t.Fatalf("failed to get the broadcast work: %v", err)
+	}
+	wantClusterStatuses := map[string]fleetv1beta1.WorkStatus{
+		selectedCluster.Name: outdatedWork.Status,
+	}
+	if diff := cmp.Diff(wantClusterStatuses, gotBW.Status.ClusterStatuses); diff != "" {
+		t.Errorf("broadcast work cluster statuses mismatch (-want +got):\n%s", diff)
+	}
+	cond := gotBW.GetCondition(string(fleetv1beta1.BroadcastWorkConditionTypeWorkSynchronized))
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.ObservedGeneration != bw.Generation {
+		t.Errorf("broadcast work synchronized condition = %+v, want true with the observed generation %d", cond, bw.Generation)
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package fleetstatusaggregator features a controller to summarize the statuses of the works of all the member
+// clusters in a MultiClusterWorkSummary.
+package fleetstatusaggregator
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/condition"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// DefaultBatchPeriod is how long the work status changes are batched before the summary is updated.
+	// It leaves the summary enough time to be updated within 5 seconds of a change.
+	DefaultBatchPeriod = 3 * time.Second
+)
+
+// Reconciler maintains the singleton MultiClusterWorkSummary in the namespace from the works of all the member
+// clusters. The work changes are debounced: every change within a batch period is summarized by a single reconcile.
+type Reconciler struct {
+	Client client.Client
+	// Namespace is the hub namespace the summary is stored in.
+	Namespace string
+	// BatchPeriod is how long the work changes are batched before the summary is updated.
+	BatchPeriod time.Duration
+
+	now func() time.Time
+}
+
+// Reconcile summarizes the works of all the member clusters into the summary.
+func (r *Reconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+	now := time.Now
+	if r.now != nil {
+		now = r.now
+	}
+	var works fleetv1beta1.WorkList
+	if err := r.Client.List(ctx, &works); err != nil {
+		klog.ErrorS(err, "Failed to list the works")
+		return ctrl.Result{}, controller.NewAPIServerError(true, err)
+	}
+
+	summary := fleetv1beta1.MultiClusterWorkSummary{}
+	key := types.NamespacedName{Name: fleetv1beta1.MultiClusterWorkSummaryName, Namespace: r.Namespace}
+	if err := r.Client.Get(ctx, key, &summary); err != nil {
+		if !apierrors.IsNotFound(err) {
+			klog.ErrorS(err, "Failed to get the work summary", "summary", key)
+			return ctrl.Result{}, controller.NewAPIServerError(true, err)
+		}
+		summary = fleetv1beta1.MultiClusterWorkSummary{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}}
+		summary.Clusters = summarizeWorks(works.Items, nil, metav1.NewTime(now()))
+		if err := r.Client.Create(ctx, &summary); err != nil {
+			klog.ErrorS(err, "Failed to create the work summary", "summary", key)
+			return ctrl.Result{}, controller.NewCreateIgnoreAlreadyExistError(err)
+		}
+		klog.V(2).InfoS("Created the work summary", "summary", key, "clusters", len(summary.Clusters))
+		return ctrl.Result{}, nil
+	}
+
+	clusters := summarizeWorks(works.Items, summary.Clusters, metav1.NewTime(now()))
+	if equality.Semantic.DeepEqual(clusters, summary.Clusters) {
+		return ctrl.Result{}, nil
+	}
+	summary.Clusters = clusters
+	if err := r.Client.Update(ctx, &summary); err != nil {
+		klog.ErrorS(err, "Failed to update the work summary", "summary", key)
+		return ctrl.Result{}, controller.NewUpdateIgnoreConflictError(err)
+	}
+	klog.V(2).InfoS("Updated the work summary", "summary", key, "clusters", len(summary.Clusters))
+	return ctrl.Result{}, nil
+}
+
+// summarizeWorks returns the work stats of the member clusters, keyed by their reserved namespaces. The stats of a
+// cluster keep their last updated time from the previous summary unless they change.
+func summarizeWorks(works []fleetv1beta1.Work, previous map[string]fleetv1beta1.ClusterWorkStats, now metav1.Time) map[string]fleetv1beta1.ClusterWorkStats {
+	if len(works) == 0 {
+		return nil
+	}
+	clusters := make(map[string]fleetv1beta1.ClusterWorkStats)
+	for i := range works {
+		work := &works[i]
+		stats := clusters[work.Namespace]
+		stats.TotalWorks++
+		applied := meta.FindStatusCondition(work.Status.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+		switch {
+		case condition.IsConditionStatusTrue(applied, work.Generation):
+			stats.AppliedWorks++
+			if isDrifted(work) {
+				stats.DriftedWorks++
+			}
+		case condition.IsConditionStatusFalse(applied, work.Generation):
+			stats.FailedWorks++
+		}
+		clusters[work.Namespace] = stats
+	}
+	for namespace, stats := range clusters {
+		stats.LastUpdated = now
+		if last, found := previous[namespace]; found {
+			last.LastUpdated = now
+			if last == stats {
+				stats.LastUpdated = previous[namespace].LastUpdated
+			}
+		}
+		clusters[namespace] = stats
+	}
+	return clusters
+}
+
+// isDrifted returns whether the resources of the work drifted in the member cluster since its current spec was
+// applied, i.e. a drift is found after the latest spec change in the recent events of the work.
+func isDrifted(work *fleetv1beta1.Work) bool {
+	for i := len(work.Status.RecentEvents) - 1; i >= 0; i-- {
+		switch work.Status.RecentEvents[i].Type {
+		case fleetv1beta1.WorkEventTypeDriftFound:
+			return true
+		case fleetv1beta1.WorkEventTypeSpecChanged:
+			return false
+		}
+	}
+	return false
+}
+
+// batchHandler enqueues the summary after the batch period on any work change, so that all the changes within the
+// period are summarized by a single reconcile.
+type batchHandler struct {
+	request reconcile.Request
+	period  time.Duration
+}
+
+var _ handler.EventHandler = &batchHandler{}
+
+func (h *batchHandler) Create(_ context.Context, _ event.CreateEvent, q workqueue.RateLimitingInterface) {
+	q.AddAfter(h.request, h.period)
+}
+
+func (h *batchHandler) Update(_ context.Context, _ event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	q.AddAfter(h.request, h.period)
+}
+
+func (h *batchHandler) Delete(_ context.Context, _ event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	q.AddAfter(h.request, h.period)
+}
+
+func (h *batchHandler) Generic(_ context.Context, _ event.GenericEvent, q workqueue.RateLimitingInterface) {
+	q.AddAfter(h.request, h.period)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	period := r.BatchPeriod
+	if period <= 0 {
+		period = DefaultBatchPeriod
+	}
+	return ctrl.NewControllerManagedBy(mgr).Named("fleet-status-aggregator").
+		Watches(&fleetv1beta1.Work{}, &batchHandler{
+			request: reconcile.Request{NamespacedName: types.NamespacedName{Name: fleetv1beta1.MultiClusterWorkSummaryName, Namespace: r.Namespace}},
+			period:  period,
+		}).
+		Complete(r)
+}
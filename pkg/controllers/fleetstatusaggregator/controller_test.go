@@ -0,0 +1,146 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package fleetstatusaggregator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const testSummaryNamespace = "fleet-system"
+
+// testWork returns a work at generation 1 with the given applied condition status; the work has no applied
+// condition if the status is empty.
+func testWork(namespace, name string, applied metav1.ConditionStatus, events ...string) *fleetv1beta1.Work {
+	work := &fleetv1beta1.Work{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Generation: 1}}
+	if applied != "" {
+		work.Status.Conditions = []metav1.Condition{{
+			Type:               fleetv1beta1.WorkConditionTypeApplied,
+			Status:             applied,
+			ObservedGeneration: 1,
+			Reason:             "test",
+			LastTransitionTime: metav1.Now(),
+		}}
+	}
+	for _, eventType := range events {
+		work.Status.RecentEvents = append(work.Status.RecentEvents, fleetv1beta1.WorkEvent{Type: eventType})
+	}
+	return work
+}
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := &Reconciler{Client: hubClient, Namespace: testSummaryNamespace, now: func() time.Time { return now }}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: fleetv1beta1.MultiClusterWorkSummaryName, Namespace: testSummaryNamespace}
+
+	reconcileAndGet := func() map[string]fleetv1beta1.ClusterWorkStats {
+		t.Helper()
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("Reconcile() = %v, want no error", err)
+		}
+		var summary fleetv1beta1.MultiClusterWorkSummary
+		if err := hubClient.Get(ctx, key, &summary); err != nil {
+			t.Fatalf("failed to get the summary: %v", err)
+		}
+		return summary.Clusters
+	}
+	created := metav1.NewTime(now)
+
+	// the works are created across the member clusters.
+	works := []*fleetv1beta1.Work{
+		testWork("fleet-member-a", "applied", metav1.ConditionTrue, fleetv1beta1.WorkEventTypeSpecChanged, fleetv1beta1.WorkEventTypeManifestApplied),
+		testWork("fleet-member-a", "drifted", metav1.ConditionTrue, fleetv1beta1.WorkEventTypeSpecChanged, fleetv1beta1.WorkEventTypeDriftFound),
+		testWork("fleet-member-a", "pending", ""),
+		testWork("fleet-member-b", "failed", metav1.ConditionFalse),
+		testWork("fleet-member-b", "drift-before-spec-change", metav1.ConditionTrue, fleetv1beta1.WorkEventTypeDriftFound, fleetv1beta1.WorkEventTypeSpecChanged),
+	}
+	for _, work := range works {
+		if err := hubClient.Create(ctx, work); err != nil {
+			t.Fatalf("failed to create the work: %v", err)
+		}
+	}
+	want := map[string]fleetv1beta1.ClusterWorkStats{
+		"fleet-member-a": {TotalWorks: 3, AppliedWorks: 2, DriftedWorks: 1, LastUpdated: created},
+		"fleet-member-b": {TotalWorks: 2, AppliedWorks: 1, FailedWorks: 1, LastUpdated: created},
+	}
+	if diff := cmp.Diff(want, reconcileAndGet()); diff != "" {
+		t.Errorf("summary after creating the works mismatch (-want +got):\n%s", diff)
+	}
+
+	// the failed work is applied; only the stats of its cluster are updated.
+	now = now.Add(time.Minute)
+	updated := metav1.NewTime(now)
+	fixed := testWork("fleet-member-b", "failed", metav1.ConditionTrue)
+	var current fleetv1beta1.Work
+	if err := hubClient.Get(ctx, client.ObjectKeyFromObject(fixed), &current); err != nil {
+		t.Fatalf("failed to get the work: %v", err)
+	}
+	current.Status = fixed.Status
+	if err := hubClient.Update(ctx, &current); err != nil {
+		t.Fatalf("failed to update the work: %v", err)
+	}
+	want["fleet-member-b"] = fleetv1beta1.ClusterWorkStats{TotalWorks: 2, AppliedWorks: 2, LastUpdated: updated}
+	if diff := cmp.Diff(want, reconcileAndGet()); diff != "" {
+		t.Errorf("summary after updating a work mismatch (-want +got):\n%s", diff)
+	}
+
+	// the works of a cluster are all deleted, the cluster is removed from the summary.
+	now = now.Add(time.Minute)
+	for _, name := range []string{"failed", "drift-before-spec-change"} {
+		if err := hubClient.Delete(ctx, &fleetv1beta1.Work{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "fleet-member-b"}}); err != nil {
+			t.Fatalf("failed to delete the work: %v", err)
+		}
+	}
+	if err := hubClient.Delete(ctx, works[1]); err != nil {
+		t.Fatalf("failed to delete the work: %v", err)
+	}
+	want = map[string]fleetv1beta1.ClusterWorkStats{
+		"fleet-member-a": {TotalWorks: 2, AppliedWorks: 1, LastUpdated: metav1.NewTime(now)},
+	}
+	if diff := cmp.Diff(want, reconcileAndGet()); diff != "" {
+		t.Errorf("summary after deleting the works mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBatchHandler(t *testing.T) {
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: fleetv1beta1.MultiClusterWorkSummaryName, Namespace: testSummaryNamespace}}
+	h := &batchHandler{request: request, period: 100 * time.Millisecond}
+	q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer q.ShutDown()
+
+	ctx := context.Background()
+	work := testWork("fleet-member-a", "work", "")
+	h.Create(ctx, event.CreateEvent{Object: work}, q)
+	h.Update(ctx, event.UpdateEvent{ObjectOld: work, ObjectNew: work}, q)
+	h.Delete(ctx, event.DeleteEvent{Object: work}, q)
+	if q.Len() != 0 {
+		t.Fatalf("queue length before the batch period = %d, want 0", q.Len())
+	}
+	time.Sleep(300 * time.Millisecond)
+	if q.Len() != 1 {
+		t.Errorf("queue length after the batch period = %d, want 1", q.Len())
+	}
+}
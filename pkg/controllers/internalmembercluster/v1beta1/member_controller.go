@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	"go.goms.io/fleet/pkg/connectivityprobe"
 	"go.goms.io/fleet/pkg/controllers/work"
 	"go.goms.io/fleet/pkg/metrics"
 	"go.goms.io/fleet/pkg/propertyprovider"
@@ -97,6 +98,12 @@ type Reconciler struct {
 	// The property provider configuration.
 	propertyProviderCfg *propertyProviderConfig
 
+	// connectivityProber keeps the latest connectivity status of the member cluster API server.
+	//
+	// Note that this can be set to nil; in that case, the controller will not report the
+	// member cluster connectivity condition.
+	connectivityProber *connectivityprobe.Prober
+
 	recorder record.EventRecorder
 }
 
@@ -117,6 +124,11 @@ const (
 	ClusterPropertyCollectionSucceededReason           = "PropertiesCollected"
 	ClusterPropertyCollectionSucceededMessage          = "The property provider has returned the latest cluster properties"
 
+	// The condition information for reporting the connectivity to the member cluster API server.
+	MemberClusterConnectivityUnknownReason = "MemberClusterConnectivityUnknown"
+	MemberClusterReachableReason           = "MemberClusterReachable"
+	MemberClusterUnreachableReason         = "MemberClusterUnreachable"
+
 	// EventReasonInternalMemberClusterHealthy is the event type and reason string when the agent is healthy.
 	EventReasonInternalMemberClusterHealthy = "InternalMemberClusterHealthy"
 	// EventReasonInternalMemberClusterUnhealthy is the event type and reason string when the agent is unhealthy.
@@ -167,6 +179,7 @@ func NewReconciler(globalCtx context.Context,
 	memberClient client.Client,
 	workController *work.ApplyWorkReconciler,
 	propertyProvider propertyprovider.PropertyProvider,
+	connectivityProber *connectivityprobe.Prober,
 ) (*Reconciler, error) {
 	rawMemberClientSet, err := kubernetes.NewForConfig(memberCfg)
 	if err != nil {
@@ -183,6 +196,7 @@ func NewReconciler(globalCtx context.Context,
 			memberConfig:     memberCfg,
 			propertyProvider: propertyProvider,
 		},
+		connectivityProber: connectivityProber,
 	}, nil
 }
 
@@ -207,6 +221,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		}
 		updateMemberAgentHeartBeat(&imc)
 		updateHealthErr := r.updateHealth(ctx, &imc)
+		r.reportMemberClusterConnectivity(&imc)
 		clusterPropertyCollectionErr := r.connectToPropertyProvider(ctx, &imc)
 		r.markInternalMemberClusterJoined(&imc)
 		if err := r.updateInternalMemberClusterWithRetry(ctx, &imc); err != nil {
@@ -293,6 +308,30 @@ func (r *Reconciler) updateHealth(ctx context.Context, imc *clusterv1beta1.Inter
 	return nil
 }
 
+// reportMemberClusterConnectivity reports the latest connectivity status observed by the connectivity prober.
+func (r *Reconciler) reportMemberClusterConnectivity(imc *clusterv1beta1.InternalMemberCluster) {
+	if r.connectivityProber == nil {
+		return
+	}
+	status, message := r.connectivityProber.Status()
+	var reason string
+	switch status {
+	case metav1.ConditionTrue:
+		reason = MemberClusterReachableReason
+	case metav1.ConditionFalse:
+		reason = MemberClusterUnreachableReason
+	default:
+		reason = MemberClusterConnectivityUnknownReason
+	}
+	imc.SetConditionsWithType(clusterv1beta1.MemberAgent, metav1.Condition{
+		Type:               string(clusterv1beta1.AgentMemberClusterConnectivity),
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: imc.GetGeneration(),
+	})
+}
+
 // connectToPropertyProvider connects to the property provider to collect the latest cluster properties.
 func (r *Reconciler) connectToPropertyProvider(ctx context.Context, imc *clusterv1beta1.InternalMemberCluster) error {
 	r.propertyProviderCfg.startPropertyProviderOnce.Do(func() {
@@ -367,10 +367,10 @@ var _ = BeforeSuite(func() {
 
 	// This controller is created for testing purposes only; no reconciliation loop is actually
 	// run.
-	workApplier1 = work.NewApplyWorkReconciler(hubClient, nil, nil, nil, nil, 0, "")
+	workApplier1 = work.NewApplyWorkReconciler(hubClient, nil, nil, nil, nil, work.ApplyWorkReconcilerOptions{})
 
 	propertyProvider1 = &manuallyUpdatedProvider{}
-	member1Reconciler, err := NewReconciler(ctx, hubClient, member1Cfg, member1Client, workApplier1, propertyProvider1)
+	member1Reconciler, err := NewReconciler(ctx, hubClient, member1Cfg, member1Client, workApplier1, propertyProvider1, nil)
 	Expect(err).NotTo(HaveOccurred())
 	Expect(member1Reconciler.SetupWithManager(member1Mgr)).To(Succeed())
 
@@ -390,9 +390,9 @@ var _ = BeforeSuite(func() {
 
 	// This controller is created for testing purposes only; no reconciliation loop is actually
 	// run.
-	workApplier2 = work.NewApplyWorkReconciler(hubClient, nil, nil, nil, nil, 0, "")
+	workApplier2 = work.NewApplyWorkReconciler(hubClient, nil, nil, nil, nil, work.ApplyWorkReconcilerOptions{})
 
-	member2Reconciler, err := NewReconciler(ctx, hubClient, member2Cfg, member2Client, workApplier2, nil)
+	member2Reconciler, err := NewReconciler(ctx, hubClient, member2Cfg, member2Client, workApplier2, nil, nil)
 	Expect(err).NotTo(HaveOccurred())
 	Expect(member2Reconciler.SetupWithManager(member2Mgr)).To(Succeed())
 
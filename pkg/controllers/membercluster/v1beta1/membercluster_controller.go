@@ -31,6 +31,7 @@ import (
 	"go.goms.io/fleet/apis"
 	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/agentheartbeat"
 	"go.goms.io/fleet/pkg/metrics"
 	"go.goms.io/fleet/pkg/utils"
 	"go.goms.io/fleet/pkg/utils/controller"
@@ -43,6 +44,10 @@ const (
 	eventReasonRoleUpdated            = "RoleUpdated"
 	eventReasonRoleBindingCreated     = "RoleBindingCreated"
 	eventReasonRoleBindingUpdated     = "RoleBindingUpdated"
+	eventReasonClusterRoleCreated     = "ClusterRoleCreated"
+	eventReasonClusterRoleUpdated     = "ClusterRoleUpdated"
+	eventReasonCRBCreated             = "ClusterRoleBindingCreated"
+	eventReasonCRBUpdated             = "ClusterRoleBindingUpdated"
 	eventReasonIMCCreated             = "InternalMemberClusterCreated"
 	eventReasonIMCSpecUpdated         = "InternalMemberClusterSpecUpdated"
 	reasonMemberClusterReadyToJoin    = "MemberClusterReadyToJoin"
@@ -50,6 +55,9 @@ const (
 	reasonMemberClusterJoined         = "MemberClusterJoined"
 	reasonMemberClusterLeft           = "MemberClusterLeft"
 	reasonMemberClusterUnknown        = "MemberClusterJoinStateUnknown"
+	reasonMemberAgentHeartbeatRecent  = "MemberAgentHeartbeatRecent"
+	reasonMemberAgentHeartbeatStale   = "MemberAgentHeartbeatStale"
+	reasonMemberAgentHeartbeatInvalid = "MemberAgentHeartbeatInvalid"
 )
 
 // Reconciler reconciles a MemberCluster object
@@ -101,6 +109,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req runtime.Request) (runtim
 
 	// Copy status from InternalMemberCluster to MemberCluster.
 	r.syncInternalMemberClusterStatus(currentIMC, &mc)
+	requeueAfter := syncMemberAgentReachableCondition(r.recorder, &mc, time.Now())
 	if err := r.updateMemberClusterStatus(ctx, &mc); err != nil {
 		if apierrors.IsConflict(err) {
 			klog.V(2).InfoS("failed to update status due to conflicts", "memberCluster", mcObjRef)
@@ -110,7 +119,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req runtime.Request) (runtim
 		return runtime.Result{}, client.IgnoreNotFound(err)
 	}
 
-	return runtime.Result{}, nil
+	return runtime.Result{RequeueAfter: requeueAfter}, nil
 }
 
 // handleDelete handles the delete event of the member cluster, makes sure the agent has finished leaving the fleet first and
@@ -208,6 +217,7 @@ func (r *Reconciler) ensureFinalizer(ctx context.Context, mc *clusterv1beta1.Mem
 // join takes the actions to make hub cluster ready for member cluster to join, including:
 // - Create namespace for member cluster
 // - Create role & role bindings for member cluster to access hub cluster
+// - Create cluster role & cluster role bindings for member cluster to read its namespace in hub cluster
 // - Create InternalMemberCluster with state=Join for member cluster
 // - Set ReadyToJoin to true
 //
@@ -231,6 +241,16 @@ func (r *Reconciler) join(ctx context.Context, mc *clusterv1beta1.MemberCluster,
 		return fmt.Errorf("failed to sync role binding: %w", err)
 	}
 
+	clusterRoleName, err := r.syncClusterRole(ctx, mc, namespaceName)
+	if err != nil {
+		return fmt.Errorf("failed to sync cluster role: %w", err)
+	}
+
+	err = r.syncClusterRoleBinding(ctx, mc, namespaceName, clusterRoleName)
+	if err != nil {
+		return fmt.Errorf("failed to sync cluster role binding: %w", err)
+	}
+
 	if _, err := r.syncInternalMemberCluster(ctx, mc, namespaceName, imc); err != nil {
 		return fmt.Errorf("failed to sync internal member cluster spec: %w", err)
 	}
@@ -308,7 +328,7 @@ func (r *Reconciler) syncRole(ctx context.Context, mc *clusterv1beta1.MemberClus
 			Namespace:       namespaceName,
 			OwnerReferences: []metav1.OwnerReference{*toOwnerReference(mc)},
 		},
-		Rules: []rbacv1.PolicyRule{utils.FleetClusterRule, utils.FleetPlacementRule, utils.FleetNetworkRule, utils.EventRule},
+		Rules: []rbacv1.PolicyRule{utils.FleetClusterRule, utils.FleetPlacementRule, utils.FleetNetworkRule, utils.EventRule, utils.ConfigMapReadRule},
 	}
 
 	// Creates role if not found.
@@ -389,6 +409,134 @@ func (r *Reconciler) syncRoleBinding(ctx context.Context, mc *clusterv1beta1.Mem
 	return nil
 }
 
+// syncClusterRole creates or updates the cluster role for member cluster to access its cluster scoped resources in hub
+// cluster, i.e. to read its namespace so that the member agent can tell whether the namespace is being deleted, to
+// patch its member cluster so that the member agent can renew its heartbeat, and to read the manifest store blobs its
+// works refer to.
+func (r *Reconciler) syncClusterRole(ctx context.Context, mc *clusterv1beta1.MemberCluster, namespaceName string) (string, error) {
+	klog.V(2).InfoS("Sync the cluster role for the member cluster", "memberCluster", klog.KObj(mc))
+	// Cluster role name is created using member cluster name.
+	clusterRoleName := fmt.Sprintf(utils.ClusterRoleNameFormat, mc.Name)
+	expectedClusterRole := rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            clusterRoleName,
+			OwnerReferences: []metav1.OwnerReference{*toOwnerReference(mc)},
+		},
+		Rules: []rbacv1.PolicyRule{namespaceReadRule(namespaceName), memberClusterPatchRule(mc.Name), manifestStoreReadRule},
+	}
+
+	// Creates cluster role if not found.
+	var currentClusterRole rbacv1.ClusterRole
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: clusterRoleName}, &currentClusterRole); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to get cluster role %s: %w", clusterRoleName, err)
+		}
+		klog.V(2).InfoS("creating cluster role", "memberCluster", klog.KObj(mc), "clusterRole", clusterRoleName)
+		if err = r.Client.Create(ctx, &expectedClusterRole, client.FieldOwner(utils.MCControllerFieldManagerName)); err != nil {
+			return "", fmt.Errorf("failed to create cluster role %s with rules %+v: %w", clusterRoleName, expectedClusterRole.Rules, err)
+		}
+		r.recorder.Event(mc, corev1.EventTypeNormal, eventReasonClusterRoleCreated, "cluster role was created")
+		klog.V(2).InfoS("created cluster role", "memberCluster", klog.KObj(mc), "clusterRole", clusterRoleName)
+		return clusterRoleName, nil
+	}
+
+	// Updates cluster role if currentClusterRole != expectedClusterRole.
+	if reflect.DeepEqual(currentClusterRole.Rules, expectedClusterRole.Rules) {
+		return clusterRoleName, nil
+	}
+	currentClusterRole.Rules = expectedClusterRole.Rules
+	klog.V(2).InfoS("updating cluster role", "memberCluster", klog.KObj(mc), "clusterRole", clusterRoleName)
+	if err := r.Client.Update(ctx, &currentClusterRole, client.FieldOwner(utils.MCControllerFieldManagerName)); err != nil {
+		return "", fmt.Errorf("failed to update cluster role %s with rules %+v: %w", clusterRoleName, currentClusterRole.Rules, err)
+	}
+	r.recorder.Event(mc, corev1.EventTypeNormal, eventReasonClusterRoleUpdated, "cluster role was updated")
+	klog.V(2).InfoS("updated cluster role", "memberCluster", klog.KObj(mc), "clusterRole", clusterRoleName)
+	return clusterRoleName, nil
+}
+
+// syncClusterRoleBinding creates or updates the cluster role binding for member cluster to access its cluster scoped
+// resources in hub cluster.
+func (r *Reconciler) syncClusterRoleBinding(ctx context.Context, mc *clusterv1beta1.MemberCluster, namespaceName string, clusterRoleName string) error {
+	klog.V(2).InfoS("Sync the clusterRoleBinding for the member cluster", "memberCluster", klog.KObj(mc))
+	// Cluster role binding name is created using member cluster name
+	clusterRoleBindingName := fmt.Sprintf(utils.ClusterRoleBindingNameFormat, mc.Name)
+	subject := mc.Spec.Identity
+	// a service account subject without a namespace refers to the namespace of the role binding, which a cluster role
+	// binding does not have.
+	if subject.Kind == rbacv1.ServiceAccountKind && subject.Namespace == "" {
+		subject.Namespace = namespaceName
+	}
+	expectedClusterRoleBinding := rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            clusterRoleBindingName,
+			OwnerReferences: []metav1.OwnerReference{*toOwnerReference(mc)},
+		},
+		Subjects: []rbacv1.Subject{subject},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     clusterRoleName,
+		},
+	}
+
+	// Creates cluster role binding if not found.
+	var currentClusterRoleBinding rbacv1.ClusterRoleBinding
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: clusterRoleBindingName}, &currentClusterRoleBinding); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get cluster role binding %s: %w", clusterRoleBindingName, err)
+		}
+		klog.V(2).InfoS("creating cluster role binding", "memberCluster", klog.KObj(mc), "subject", subject)
+		if err = r.Client.Create(ctx, &expectedClusterRoleBinding, client.FieldOwner(utils.MCControllerFieldManagerName)); err != nil {
+			return fmt.Errorf("failed to create cluster role binding %s: %w", clusterRoleBindingName, err)
+		}
+		r.recorder.Event(mc, corev1.EventTypeNormal, eventReasonCRBCreated, "cluster role binding was created")
+		klog.V(2).InfoS("created cluster role binding", "memberCluster", klog.KObj(mc), "subject", subject)
+		return nil
+	}
+
+	// Updates cluster role binding if currentClusterRoleBinding != expectedClusterRoleBinding.
+	if reflect.DeepEqual(currentClusterRoleBinding.Subjects, expectedClusterRoleBinding.Subjects) && reflect.DeepEqual(currentClusterRoleBinding.RoleRef, expectedClusterRoleBinding.RoleRef) {
+		return nil
+	}
+	currentClusterRoleBinding.Subjects = expectedClusterRoleBinding.Subjects
+	currentClusterRoleBinding.RoleRef = expectedClusterRoleBinding.RoleRef
+	klog.V(2).InfoS("updating cluster role binding", "memberCluster", klog.KObj(mc), "subject", subject)
+	if err := r.Client.Update(ctx, &currentClusterRoleBinding, client.FieldOwner(utils.MCControllerFieldManagerName)); err != nil {
+		return fmt.Errorf("failed to update cluster role binding %s: %w", clusterRoleBindingName, err)
+	}
+	r.recorder.Event(mc, corev1.EventTypeNormal, eventReasonCRBUpdated, "cluster role binding was updated")
+	klog.V(2).InfoS("updated cluster role binding", "memberCluster", klog.KObj(mc), "subject", subject)
+	return nil
+}
+
+// manifestStoreReadRule is the rule to read the manifest store blobs. The blobs are shared by the works of all the
+// member clusters and named by their content, so they cannot be narrowed down by name.
+var manifestStoreReadRule = rbacv1.PolicyRule{
+	Verbs:     []string{"get"},
+	APIGroups: []string{placementv1beta1.GroupVersion.Group},
+	Resources: []string{"manifeststores"},
+}
+
+// memberClusterPatchRule returns the rule to patch only the given member cluster, e.g. to renew the member agent heartbeat.
+func memberClusterPatchRule(memberClusterName string) rbacv1.PolicyRule {
+	return rbacv1.PolicyRule{
+		Verbs:         []string{"patch"},
+		APIGroups:     []string{clusterv1beta1.GroupVersion.Group},
+		Resources:     []string{"memberclusters"},
+		ResourceNames: []string{memberClusterName},
+	}
+}
+
+// namespaceReadRule returns the rule to read only the given namespace.
+func namespaceReadRule(namespaceName string) rbacv1.PolicyRule {
+	return rbacv1.PolicyRule{
+		Verbs:         []string{"get"},
+		APIGroups:     []string{""},
+		Resources:     []string{"namespaces"},
+		ResourceNames: []string{namespaceName},
+	}
+}
+
 // syncInternalMemberCluster is used to sync spec from MemberCluster to InternalMemberCluster.
 func (r *Reconciler) syncInternalMemberCluster(ctx context.Context, mc *clusterv1beta1.MemberCluster,
 	namespaceName string, currentImc *clusterv1beta1.InternalMemberCluster) (*clusterv1beta1.InternalMemberCluster, error) {
@@ -605,6 +753,53 @@ func markMemberClusterUnknown(recorder record.EventRecorder, mc apis.Conditioned
 	mc.SetConditions(newCondition)
 }
 
+// syncMemberAgentReachableCondition sets the MemberAgentReachable condition of the member cluster based on the
+// heartbeat of the member agent and returns when the condition must be checked again. The heartbeats do not trigger
+// the reconciliation, so the member cluster is checked again when its latest heartbeat becomes stale. The condition is
+// not set if the member agent does not report any heartbeat.
+func syncMemberAgentReachableCondition(recorder record.EventRecorder, mc *clusterv1beta1.MemberCluster, now time.Time) time.Duration {
+	heartbeat, found, err := agentheartbeat.LastHeartbeat(mc)
+	if !found {
+		return 0
+	}
+	newCondition := metav1.Condition{
+		Type:               string(clusterv1beta1.ConditionTypeMemberAgentReachable),
+		ObservedGeneration: mc.GetGeneration(),
+	}
+	// check again after the next heartbeat by default to find out when the member agent comes back.
+	requeueAfter := agentheartbeat.Interval
+	switch age := now.Sub(heartbeat); {
+	case err != nil:
+		newCondition.Status = metav1.ConditionUnknown
+		newCondition.Reason = reasonMemberAgentHeartbeatInvalid
+		newCondition.Message = fmt.Sprintf("Failed to read the heartbeat of the member agent: %v", err)
+	case age >= agentheartbeat.StaleAfter:
+		newCondition.Status = metav1.ConditionFalse
+		newCondition.Reason = reasonMemberAgentHeartbeatStale
+		newCondition.Message = fmt.Sprintf("The latest heartbeat of the member agent was at %s, more than %s ago",
+			heartbeat.Format(time.RFC3339), agentheartbeat.StaleAfter)
+	default:
+		newCondition.Status = metav1.ConditionTrue
+		newCondition.Reason = reasonMemberAgentHeartbeatRecent
+		newCondition.Message = fmt.Sprintf("The latest heartbeat of the member agent was at %s", heartbeat.Format(time.RFC3339))
+		requeueAfter = agentheartbeat.StaleAfter - age
+	}
+
+	// Reachable status changed.
+	existingCondition := mc.GetCondition(newCondition.Type)
+	if existingCondition == nil || existingCondition.Status != newCondition.Status {
+		eventType := corev1.EventTypeNormal
+		if newCondition.Status != metav1.ConditionTrue {
+			eventType = corev1.EventTypeWarning
+		}
+		recorder.Event(mc, eventType, newCondition.Reason, newCondition.Message)
+		klog.V(2).InfoS("member agent reachable status changed", "memberCluster", klog.KObj(mc), "status", newCondition.Status)
+	}
+
+	mc.SetConditions(newCondition)
+	return requeueAfter
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *Reconciler) SetupWithManager(mgr runtime.Manager) error {
 	r.recorder = mgr.GetEventRecorderFor("mcv1beta1")
@@ -14,16 +14,21 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/agentheartbeat"
 	"go.goms.io/fleet/pkg/utils"
 )
 
@@ -86,11 +91,15 @@ var _ = Describe("Test MemberCluster Controller", func() {
 			var ns corev1.Namespace
 			var role rbacv1.Role
 			var roleBinding rbacv1.RoleBinding
+			var clusterRole rbacv1.ClusterRole
+			var clusterRoleBinding rbacv1.ClusterRoleBinding
 			var imc clusterv1beta1.InternalMemberCluster
 			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: namespaceName}, &ns)).Should(Succeed())
 			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: memberClusterName, Namespace: namespaceName}, &imc)).Should(Succeed())
 			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: fmt.Sprintf(utils.RoleNameFormat, memberClusterName), Namespace: namespaceName}, &role)).Should(Succeed())
 			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: fmt.Sprintf(utils.RoleBindingNameFormat, memberClusterName), Namespace: namespaceName}, &roleBinding)).Should(Succeed())
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: fmt.Sprintf(utils.ClusterRoleNameFormat, memberClusterName)}, &clusterRole)).Should(Succeed())
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: fmt.Sprintf(utils.ClusterRoleBindingNameFormat, memberClusterName)}, &clusterRoleBinding)).Should(Succeed())
 			Expect(k8sClient.Get(ctx, memberClusterNamespacedName, mc)).Should(Succeed())
 
 			wantMC := clusterv1beta1.MemberClusterStatus{
@@ -279,6 +288,54 @@ var _ = Describe("Test MemberCluster Controller", func() {
 			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: namespaceName}, &mcNamespace)).Should(Succeed())
 			Expect(mcNamespace.Labels[placementv1beta1.FleetResourceLabelKey]).Should(Equal("true"))
 		})
+
+		It("should only allow the member cluster identity to read its own namespace", func() {
+			canGetNamespace := func(name string) bool {
+				review := &authorizationv1.SubjectAccessReview{
+					Spec: authorizationv1.SubjectAccessReviewSpec{
+						// the service account identity without a namespace is bound in the member cluster namespace.
+						User: fmt.Sprintf("system:serviceaccount:%s:hub-access", namespaceName),
+						ResourceAttributes: &authorizationv1.ResourceAttributes{
+							Verb:     "get",
+							Resource: "namespaces",
+							Name:     name,
+						},
+					},
+				}
+				Expect(k8sClient.Create(ctx, review)).Should(Succeed())
+				return review.Status.Allowed
+			}
+			Expect(canGetNamespace(namespaceName)).Should(BeTrue(), "the member cluster should be able to read its own namespace")
+			Expect(canGetNamespace("default")).Should(BeFalse(), "the member cluster should not be able to read other namespaces")
+		})
+
+		It("should allow the member agent to renew its heartbeat with the member cluster identity", func() {
+			memberCfg := rest.CopyConfig(cfg)
+			memberCfg.Impersonate = rest.ImpersonationConfig{UserName: fmt.Sprintf("system:serviceaccount:%s:hub-access", namespaceName)}
+			memberClient, err := client.New(memberCfg, client.Options{Scheme: scheme.Scheme})
+			Expect(err).Should(Succeed())
+
+			By("run the heartbeater as the member cluster identity")
+			heartbeatCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			go func() {
+				defer GinkgoRecover()
+				Expect(agentheartbeat.New(memberClient, memberClusterName).Start(heartbeatCtx)).Should(Succeed())
+			}()
+			Eventually(func() bool {
+				var mc clusterv1beta1.MemberCluster
+				if err := k8sClient.Get(ctx, memberClusterNamespacedName, &mc); err != nil {
+					return false
+				}
+				_, found, err := agentheartbeat.LastHeartbeat(&mc)
+				return found && err == nil
+			}, timeout, interval).Should(BeTrue(), "the member agent should be able to renew its heartbeat")
+
+			By("patch another member cluster as the member cluster identity")
+			other := &clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: utils.RandStr()}}
+			patch := client.RawPatch(types.MergePatchType, []byte(`{"metadata":{"annotations":{"foo":"bar"}}}`))
+			Expect(apierrors.IsForbidden(memberClient.Patch(ctx, other, patch))).Should(BeTrue(), "the member cluster should not be able to patch other member clusters")
+		})
 	})
 
 	Context("Test membercluster controller with enabling networking agents", func() {
@@ -26,6 +26,7 @@ import (
 
 	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/agentheartbeat"
 	"go.goms.io/fleet/pkg/utils"
 )
 
@@ -214,7 +215,7 @@ func TestSyncRole(t *testing.T) {
 								Name:      "fleet-role-mc1",
 								Namespace: namespace1,
 							},
-							Rules: []rbacv1.PolicyRule{utils.FleetClusterRule, utils.FleetPlacementRule, utils.FleetNetworkRule, utils.EventRule},
+							Rules: []rbacv1.PolicyRule{utils.FleetClusterRule, utils.FleetPlacementRule, utils.FleetNetworkRule, utils.EventRule, utils.ConfigMapReadRule},
 						}
 						return nil
 					},
@@ -529,6 +530,193 @@ func TestSyncRoleBinding(t *testing.T) {
 	}
 }
 
+func TestSyncClusterRole(t *testing.T) {
+	memberCluster := clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: "mc1"}}
+	wantRules := []rbacv1.PolicyRule{
+		{
+			Verbs:         []string{"get"},
+			APIGroups:     []string{""},
+			Resources:     []string{"namespaces"},
+			ResourceNames: []string{namespace1},
+		},
+		{
+			Verbs:         []string{"patch"},
+			APIGroups:     []string{clusterv1beta1.GroupVersion.Group},
+			Resources:     []string{"memberclusters"},
+			ResourceNames: []string{"mc1"},
+		},
+		{
+			Verbs:     []string{"get"},
+			APIGroups: []string{placementv1beta1.GroupVersion.Group},
+			Resources: []string{"manifeststores"},
+		},
+	}
+
+	tests := map[string]struct {
+		currentRules []rbacv1.PolicyRule
+		getErr       error
+		writeErr     error
+		wantedRules  []rbacv1.PolicyRule
+		wantedEvent  string
+		wantedError  string
+	}{
+		"cluster role exists but no diff": {
+			currentRules: wantRules,
+		},
+		"cluster role exists but with diff": {
+			currentRules: []rbacv1.PolicyRule{utils.ConfigMapReadRule},
+			wantedRules:  wantRules,
+			wantedEvent:  utils.GetEventString(&memberCluster, corev1.EventTypeNormal, eventReasonClusterRoleUpdated, "cluster role was updated"),
+		},
+		"cluster role doesn't exist": {
+			getErr:      apierrors.NewNotFound(schema.GroupResource{Group: rbacv1.GroupName, Resource: "clusterroles"}, "fleet-clusterrole-mc1"),
+			wantedRules: wantRules,
+			wantedEvent: utils.GetEventString(&memberCluster, corev1.EventTypeNormal, eventReasonClusterRoleCreated, "cluster role was created"),
+		},
+		"cluster role get error": {
+			getErr:      errors.New("cluster role cannot be retrieved"),
+			wantedError: "cluster role cannot be retrieved",
+		},
+		"cluster role create error": {
+			getErr:      apierrors.NewNotFound(schema.GroupResource{Group: rbacv1.GroupName, Resource: "clusterroles"}, "fleet-clusterrole-mc1"),
+			writeErr:    errors.New("cluster role cannot be created"),
+			wantedError: "cluster role cannot be created",
+		},
+		"cluster role update error": {
+			writeErr:    errors.New("cluster role cannot be updated"),
+			wantedError: "cluster role cannot be updated",
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			var gotRules []rbacv1.PolicyRule
+			write := func(obj client.Object) error {
+				o := obj.(*rbacv1.ClusterRole)
+				assert.Equal(t, "fleet-clusterrole-mc1", o.Name)
+				gotRules = o.Rules
+				return tt.writeErr
+			}
+			r := &Reconciler{
+				Client: &test.MockClient{
+					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+						if tt.getErr != nil {
+							return tt.getErr
+						}
+						o := obj.(*rbacv1.ClusterRole)
+						o.Name = key.Name
+						o.Rules = tt.currentRules
+						return nil
+					},
+					MockCreate: func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+						return write(obj)
+					},
+					MockUpdate: func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+						return write(obj)
+					},
+				},
+				recorder: utils.NewFakeRecorder(1),
+			}
+			got, err := r.syncClusterRole(context.Background(), &memberCluster, namespace1)
+			if tt.wantedError != "" {
+				assert.ErrorContains(t, err, tt.wantedError, utils.TestCaseMsg, testName)
+				return
+			}
+			assert.NoError(t, err, utils.TestCaseMsg, testName)
+			assert.Equal(t, "fleet-clusterrole-mc1", got, utils.TestCaseMsg, testName)
+			assert.Equal(t, tt.wantedRules, gotRules, utils.TestCaseMsg, testName)
+			if tt.wantedEvent != "" {
+				assert.Equal(t, tt.wantedEvent, <-r.recorder.(*record.FakeRecorder).Events)
+			}
+		})
+	}
+}
+
+func TestSyncClusterRoleBinding(t *testing.T) {
+	user := rbacv1.Subject{Kind: rbacv1.UserKind, Name: "MemberClusterIdentity"}
+	roleRef := rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "fleet-clusterrole-mc1"}
+
+	tests := map[string]struct {
+		identity        rbacv1.Subject
+		currentSubjects []rbacv1.Subject
+		getErr          error
+		wantedSubjects  []rbacv1.Subject
+		wantedReason    string
+		wantedMessage   string
+	}{
+		"cluster role binding exists but no diff": {
+			identity:        user,
+			currentSubjects: []rbacv1.Subject{user},
+		},
+		"cluster role binding exists but with diff": {
+			identity:        user,
+			currentSubjects: []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "MemberClusterIdentity1"}},
+			wantedSubjects:  []rbacv1.Subject{user},
+			wantedReason:    eventReasonCRBUpdated,
+			wantedMessage:   "cluster role binding was updated",
+		},
+		"cluster role binding doesn't exist": {
+			identity:       user,
+			getErr:         apierrors.NewNotFound(schema.GroupResource{Group: rbacv1.GroupName, Resource: "clusterrolebindings"}, "fleet-clusterrolebinding-mc1"),
+			wantedSubjects: []rbacv1.Subject{user},
+			wantedReason:   eventReasonCRBCreated,
+			wantedMessage:  "cluster role binding was created",
+		},
+		"service account without a namespace is bound in the member cluster namespace": {
+			identity:       rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: "hub-access"},
+			getErr:         apierrors.NewNotFound(schema.GroupResource{Group: rbacv1.GroupName, Resource: "clusterrolebindings"}, "fleet-clusterrolebinding-mc1"),
+			wantedSubjects: []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "hub-access", Namespace: namespace1}},
+			wantedReason:   eventReasonCRBCreated,
+			wantedMessage:  "cluster role binding was created",
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			memberCluster := clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "mc1"},
+				Spec:       clusterv1beta1.MemberClusterSpec{Identity: tt.identity},
+			}
+			var gotSubjects []rbacv1.Subject
+			write := func(obj client.Object) error {
+				o := obj.(*rbacv1.ClusterRoleBinding)
+				assert.Equal(t, "fleet-clusterrolebinding-mc1", o.Name)
+				assert.Equal(t, roleRef, o.RoleRef)
+				gotSubjects = o.Subjects
+				return nil
+			}
+			r := &Reconciler{
+				Client: &test.MockClient{
+					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+						if tt.getErr != nil {
+							return tt.getErr
+						}
+						o := obj.(*rbacv1.ClusterRoleBinding)
+						o.Name = key.Name
+						o.Subjects = tt.currentSubjects
+						o.RoleRef = roleRef
+						return nil
+					},
+					MockCreate: func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+						return write(obj)
+					},
+					MockUpdate: func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+						return write(obj)
+					},
+				},
+				recorder: utils.NewFakeRecorder(1),
+			}
+			err := r.syncClusterRoleBinding(context.Background(), &memberCluster, namespace1, "fleet-clusterrole-mc1")
+			assert.NoError(t, err, utils.TestCaseMsg, testName)
+			assert.Equal(t, tt.wantedSubjects, gotSubjects, utils.TestCaseMsg, testName)
+			if tt.wantedReason != "" {
+				wantedEvent := utils.GetEventString(&memberCluster, corev1.EventTypeNormal, tt.wantedReason, tt.wantedMessage)
+				assert.Equal(t, wantedEvent, <-r.recorder.(*record.FakeRecorder).Events)
+			}
+		})
+	}
+}
+
 func TestSyncInternalMemberCluster(t *testing.T) {
 	deleteTime := metav1.Now()
 	updateMock := func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
@@ -1540,3 +1728,69 @@ func TestUpdateMemberClusterStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestSyncMemberAgentReachableCondition(t *testing.T) {
+	heartbeat := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	memberCluster := &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "mc1",
+			Generation:  2,
+			Annotations: map[string]string{clusterv1beta1.AgentHeartbeatAnnotation: heartbeat.Format(time.RFC3339)},
+		},
+	}
+	reachableCondition := func() *metav1.Condition {
+		return memberCluster.GetCondition(string(clusterv1beta1.ConditionTypeMemberAgentReachable))
+	}
+	recorder := utils.NewFakeRecorder(10)
+
+	// the heartbeat is recent.
+	requeueAfter := syncMemberAgentReachableCondition(recorder, memberCluster, heartbeat.Add(agentheartbeat.Interval))
+	want := &metav1.Condition{
+		Type:               string(clusterv1beta1.ConditionTypeMemberAgentReachable),
+		Status:             metav1.ConditionTrue,
+		Reason:             reasonMemberAgentHeartbeatRecent,
+		ObservedGeneration: 2,
+	}
+	if diff := cmp.Diff(want, reachableCondition(), cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime", "Message")); diff != "" {
+		t.Errorf("MemberAgentReachable condition with a recent heartbeat mismatch (-want +got):\n%s", diff)
+	}
+	if wantRequeue := agentheartbeat.StaleAfter - agentheartbeat.Interval; requeueAfter != wantRequeue {
+		t.Errorf("syncMemberAgentReachableCondition() = %v, want it checked again when the heartbeat becomes stale in %v", requeueAfter, wantRequeue)
+	}
+
+	// the heartbeats stop.
+	requeueAfter = syncMemberAgentReachableCondition(recorder, memberCluster, heartbeat.Add(agentheartbeat.StaleAfter))
+	want.Status = metav1.ConditionFalse
+	want.Reason = reasonMemberAgentHeartbeatStale
+	if diff := cmp.Diff(want, reachableCondition(), cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime", "Message")); diff != "" {
+		t.Errorf("MemberAgentReachable condition with a stale heartbeat mismatch (-want +got):\n%s", diff)
+	}
+	if requeueAfter != agentheartbeat.Interval {
+		t.Errorf("syncMemberAgentReachableCondition() = %v, want it checked again after the next heartbeat in %v", requeueAfter, agentheartbeat.Interval)
+	}
+
+	// the heartbeats resume.
+	resumed := heartbeat.Add(time.Hour)
+	memberCluster.Annotations[clusterv1beta1.AgentHeartbeatAnnotation] = resumed.Format(time.RFC3339)
+	syncMemberAgentReachableCondition(recorder, memberCluster, resumed.Add(time.Second))
+	want.Status = metav1.ConditionTrue
+	want.Reason = reasonMemberAgentHeartbeatRecent
+	if diff := cmp.Diff(want, reachableCondition(), cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime", "Message")); diff != "" {
+		t.Errorf("MemberAgentReachable condition with a resumed heartbeat mismatch (-want +got):\n%s", diff)
+	}
+
+	// one event is emitted per transition.
+	if got := len(recorder.Events); got != 3 {
+		t.Errorf("emitted events = %d, want 3", got)
+	}
+}
+
+func TestSyncMemberAgentReachableConditionWithoutHeartbeat(t *testing.T) {
+	memberCluster := &clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: "mc1"}}
+	if requeueAfter := syncMemberAgentReachableCondition(utils.NewFakeRecorder(1), memberCluster, time.Now()); requeueAfter != 0 {
+		t.Errorf("syncMemberAgentReachableCondition() = %v, want no requeue", requeueAfter)
+	}
+	if cond := memberCluster.GetCondition(string(clusterv1beta1.ConditionTypeMemberAgentReachable)); cond != nil {
+		t.Errorf("MemberAgentReachable condition = %+v, want no condition", cond)
+	}
+}
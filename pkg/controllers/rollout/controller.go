@@ -78,10 +78,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req runtime.Request) (runtim
 		return runtime.Result{}, nil
 	}
 
-	// check that it's actually rollingUpdate strategy
-	// TODO: support the rollout all at once type of RolloutStrategy
-	if crp.Spec.Strategy.Type != fleetv1beta1.RollingUpdateRolloutStrategyType {
-		klog.V(2).InfoS("Ignoring clusterResourcePlacement with non-rolling-update strategy", "clusterResourcePlacement", crpName)
+	// check that it's actually a supported rollout strategy
+	if crp.Spec.Strategy.Type != fleetv1beta1.RollingUpdateRolloutStrategyType && crp.Spec.Strategy.Type != fleetv1beta1.AllAtOnceRolloutStrategyType {
+		klog.V(2).InfoS("Ignoring clusterResourcePlacement with unsupported rollout strategy", "clusterResourcePlacement", crpName, "strategyType", crp.Spec.Strategy.Type)
 		return runtime.Result{}, nil
 	}
 
@@ -283,6 +282,7 @@ func createUpdateInfo(binding *fleetv1beta1.ClusterResourceBinding, crp *fleetv1
 	desiredBinding.Spec.ResourceSnapshotName = latestResourceSnapshot.Name
 	// update the resource apply strategy when controller rolls out the new changes
 	desiredBinding.Spec.ApplyStrategy = crp.Spec.Strategy.ApplyStrategy
+	desiredBinding.Spec.CrossClusterDependencies = crp.Spec.Strategy.CrossClusterDependencies
 	desiredBinding.Spec.ClusterResourceOverrideSnapshots = cro
 	desiredBinding.Spec.ResourceOverrideSnapshots = ro
 	return toBeUpdatedBinding{
@@ -428,6 +428,10 @@ func (r *Reconciler) pickBindingsToRoll(ctx context.Context, allBindings []*flee
 	// Since we can't predict the number of bindings that can be unavailable after they are applied, we don't take them into account
 	lowerBoundAvailableNumber := len(readyBindings) - len(canBeUnavailableBindings)
 	maxNumberToRemove := lowerBoundAvailableNumber - minAvailableNumber
+	if crp.Spec.Strategy.Type == fleetv1beta1.AllAtOnceRolloutStrategyType {
+		// we roll out to all the clusters at once regardless of how many of them are available
+		maxNumberToRemove = len(removeCandidates) + len(updateCandidates)
+	}
 	klog.V(2).InfoS("Calculated the max number of bindings to remove", "clusterResourcePlacement", crpKObj,
 		"maxUnavailableNumber", maxUnavailableNumber, "minAvailableNumber", minAvailableNumber,
 		"lowerBoundAvailableBindings", lowerBoundAvailableNumber, "maxNumberOfBindingsToRemove", maxNumberToRemove)
@@ -458,6 +462,10 @@ func (r *Reconciler) pickBindingsToRoll(ctx context.Context, allBindings []*flee
 	// We count anything that still has work object on the hub cluster as can be ready since the member agent may have connection issue with the hub cluster
 	upperBoundReadyNumber := len(canBeReadyBindings)
 	maxNumberToAdd := maxReadyNumber - upperBoundReadyNumber
+	if crp.Spec.Strategy.Type == fleetv1beta1.AllAtOnceRolloutStrategyType {
+		// we bind all the newly scheduled clusters at once regardless of how many of them can be ready
+		maxNumberToAdd = len(boundingCandidates)
+	}
 
 	klog.V(2).InfoS("Calculated the max number of bindings to add", "clusterResourcePlacement", crpKObj,
 		"maxSurgeNumber", maxSurgeNumber, "maxReadyNumber", maxReadyNumber, "upperBoundReadyBindings",
@@ -8,6 +8,7 @@ package rollout
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -1420,6 +1421,117 @@ func TestPickBindingsToRoll(t *testing.T) {
 	}
 }
 
+// TestPickBindingsToRollInWaves simulates a rollout of a new resource snapshot to 10 clusters.
+func TestPickBindingsToRollInWaves(t *testing.T) {
+	const clusterCount = 10
+	rollingCRP := clusterResourcePlacementForTest("test",
+		createPlacementPolicyForTest(fleetv1beta1.PickNPlacementType, clusterCount))
+	rollingCRP.Spec.Strategy.RollingUpdate.MaxUnavailable = ptr.To(intstr.FromInt32(2))
+	rollingCRP.Spec.Strategy.RollingUpdate.MaxSurge = ptr.To(intstr.FromInt32(0))
+	allAtOnceCRP := rollingCRP.DeepCopy()
+	allAtOnceCRP.Spec.Strategy.Type = fleetv1beta1.AllAtOnceRolloutStrategyType
+
+	// readyBindings returns bindings that are bound to snapshot-1 and available on all the clusters.
+	readyBindings := func() []*fleetv1beta1.ClusterResourceBinding {
+		bindings := make([]*fleetv1beta1.ClusterResourceBinding, clusterCount)
+		for i := range bindings {
+			bindings[i] = generateClusterResourceBinding(fleetv1beta1.BindingStateBound, "snapshot-1", fmt.Sprintf("cluster-%d", i))
+			bindings[i].Generation = 1
+			bindings[i].Status.Conditions = []metav1.Condition{
+				{
+					Type:               string(fleetv1beta1.ResourceBindingAvailable),
+					Status:             metav1.ConditionTrue,
+					ObservedGeneration: 1,
+				},
+			}
+		}
+		return bindings
+	}
+	// firstWaveFailed returns bindings where the first wave has been rolled out to snapshot-2 but failed to apply.
+	firstWaveFailed := func() []*fleetv1beta1.ClusterResourceBinding {
+		bindings := readyBindings()
+		for i := 0; i < 2; i++ {
+			bindings[i].Spec.ResourceSnapshotName = "snapshot-2"
+			bindings[i].Generation = 2
+			bindings[i].Status.Conditions = []metav1.Condition{
+				{
+					Type:               string(fleetv1beta1.ResourceBindingApplied),
+					Status:             metav1.ConditionFalse,
+					ObservedGeneration: 2,
+				},
+			}
+		}
+		return bindings
+	}
+
+	tests := map[string]struct {
+		allBindings      []*fleetv1beta1.ClusterResourceBinding
+		crp              *fleetv1beta1.ClusterResourcePlacement
+		wantUpdatedCount int
+		wantStaleCount   int
+		wantNeedRoll     bool
+	}{
+		"first wave only updates up to max unavailable clusters": {
+			allBindings:      readyBindings(),
+			crp:              rollingCRP,
+			wantUpdatedCount: 2,
+			wantStaleCount:   8,
+			wantNeedRoll:     true,
+		},
+		"wave progression halts when the first wave fails": {
+			allBindings:      firstWaveFailed(),
+			crp:              rollingCRP,
+			wantUpdatedCount: 0,
+			wantStaleCount:   8,
+			wantNeedRoll:     true,
+		},
+		"all at once updates all the clusters": {
+			allBindings:      readyBindings(),
+			crp:              allAtOnceCRP,
+			wantUpdatedCount: clusterCount,
+			wantStaleCount:   0,
+			wantNeedRoll:     true,
+		},
+		"all at once does not halt when some clusters fail": {
+			allBindings:      firstWaveFailed(),
+			crp:              allAtOnceCRP,
+			wantUpdatedCount: 8,
+			wantStaleCount:   0,
+			wantNeedRoll:     true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := Reconciler{
+				Client: fake.NewClientBuilder().WithScheme(serviceScheme(t)).Build(),
+			}
+			resourceSnapshot := &fleetv1beta1.ClusterResourceSnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "snapshot-2",
+				},
+			}
+			gotUpdatedBindings, gotStaleUnselectedBindings, gotNeedRoll, err := r.pickBindingsToRoll(context.Background(), tt.allBindings, resourceSnapshot, tt.crp, nil, nil)
+			if err != nil {
+				t.Fatalf("pickBindingsToRoll() error = %v, want no error", err)
+			}
+			if len(gotUpdatedBindings) != tt.wantUpdatedCount {
+				t.Errorf("pickBindingsToRoll() got %d toBeUpdatedBindings, want %d", len(gotUpdatedBindings), tt.wantUpdatedCount)
+			}
+			for _, binding := range gotUpdatedBindings {
+				if binding.desiredBinding.Spec.ResourceSnapshotName != "snapshot-2" {
+					t.Errorf("pickBindingsToRoll() desired binding %s points to %s, want snapshot-2", binding.desiredBinding.Name, binding.desiredBinding.Spec.ResourceSnapshotName)
+				}
+			}
+			if len(gotStaleUnselectedBindings) != tt.wantStaleCount {
+				t.Errorf("pickBindingsToRoll() got %d staleUnselectedBindings, want %d", len(gotStaleUnselectedBindings), tt.wantStaleCount)
+			}
+			if gotNeedRoll != tt.wantNeedRoll {
+				t.Errorf("pickBindingsToRoll() = needRoll %v, want %v", gotNeedRoll, tt.wantNeedRoll)
+			}
+		})
+	}
+}
+
 func createPlacementPolicyForTest(placementType fleetv1beta1.PlacementType, numberOfClusters int32) *fleetv1beta1.PlacementPolicy {
 	return &fleetv1beta1.PlacementPolicy{
 		PlacementType:    placementType,
@@ -0,0 +1,104 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// resourceScope is a kind of resources in a namespace; the namespace is empty for the cluster scoped resources.
+type resourceScope struct {
+	gvr       schema.GroupVersionResource
+	namespace string
+}
+
+// resourceKey identifies a resource in the member cluster.
+type resourceKey struct {
+	resourceScope
+	name string
+}
+
+// additionalResources returns the resources which have the owner reference of the appliedWork but are neither in the
+// manifests of the work nor tracked by the appliedWork, looking in the namespaces and the kinds of the manifests.
+// The listing is a best effort: the kinds which cannot be listed are skipped.
+func (r *ApplyWorkReconciler) additionalResources(ctx context.Context, work *fleetv1beta1.Work, appliedWork *fleetv1beta1.AppliedWork,
+	owner metav1.OwnerReference, results []applyResult) []fleetv1beta1.WorkResourceIdentifier {
+	targetNamespaces := manifestTargetNamespaces(work)
+	known := make(map[resourceKey]bool, len(results)+len(appliedWork.Status.AppliedResources))
+	var scopes []resourceScope
+	seenScopes := make(map[resourceScope]bool)
+	for _, result := range results {
+		identifier := result.identifier
+		if identifier.Resource == "" {
+			// the manifest cannot be decoded so there is nothing to look for.
+			continue
+		}
+		scope := resourceScope{
+			gvr:       schema.GroupVersionResource{Group: identifier.Group, Version: identifier.Version, Resource: identifier.Resource},
+			namespace: routedNamespace(identifier, targetNamespaces),
+		}
+		known[resourceKey{resourceScope: scope, name: identifier.Name}] = true
+		if !seenScopes[scope] {
+			seenScopes[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	// the resources applied by the work before, e.g. the stale ones waiting to be deleted, are not additional.
+	for _, resourceMeta := range appliedWork.Status.AppliedResources {
+		known[resourceKey{
+			resourceScope: resourceScope{
+				gvr:       schema.GroupVersionResource{Group: resourceMeta.Group, Version: resourceMeta.Version, Resource: resourceMeta.Resource},
+				namespace: appliedNamespace(resourceMeta),
+			},
+			name: resourceMeta.Name,
+		}] = true
+	}
+
+	var additional []fleetv1beta1.WorkResourceIdentifier
+	for _, scope := range scopes {
+		list, err := r.spokeDynamicClient.Resource(scope.gvr).Namespace(scope.namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			klog.ErrorS(err, "Failed to list the resources to find the additional ones", "work", klog.KObj(work), "gvr", scope.gvr, "namespace", scope.namespace)
+			continue
+		}
+		for i := range list.Items {
+			item := &list.Items[i]
+			if known[resourceKey{resourceScope: scope, name: item.GetName()}] || indexOwnerRef(item.GetOwnerReferences(), owner) == -1 {
+				continue
+			}
+			additional = append(additional, fleetv1beta1.WorkResourceIdentifier{
+				Group:     scope.gvr.Group,
+				Version:   scope.gvr.Version,
+				Kind:      item.GetKind(),
+				Resource:  scope.gvr.Resource,
+				Namespace: item.GetNamespace(),
+				Name:      item.GetName(),
+			})
+		}
+	}
+	// keep the order stable so that the work status does not change between reconciles.
+	sort.Slice(additional, func(i, j int) bool {
+		a, b := additional[i], additional[j]
+		if a.Group != b.Group {
+			return a.Group < b.Group
+		}
+		if a.Resource != b.Resource {
+			return a.Resource < b.Resource
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return additional
+}
@@ -0,0 +1,69 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+)
+
+// ownedDeployment returns the live deployment in the namespace which has the given owner references.
+func ownedDeployment(namespace, name string, ownerRefs ...metav1.OwnerReference) *unstructured.Unstructured {
+	obj := liveDeployment(name, "")
+	obj.SetNamespace(namespace)
+	obj.SetOwnerReferences(ownerRefs)
+	return obj
+}
+
+func TestAdditionalResources(t *testing.T) {
+	otherOwner := metav1.OwnerReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "other", UID: "other-uid"}
+	dynamicClient := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{utils.DeploymentGVR: "DeploymentList"},
+		// the resource of the manifest.
+		ownedDeployment("default", "deploy", ownerRef),
+		// the resources created by the controllers in the member cluster with the owner reference of the appliedWork.
+		ownedDeployment("default", "controller-created", otherOwner, ownerRef),
+		ownedDeployment("default", "another-controller-created", ownerRef),
+		// the resource applied by the work before which waits to be garbage-collected.
+		ownedDeployment("default", "stale", ownerRef),
+		// the resource owned by something else.
+		ownedDeployment("default", "unowned", otherOwner),
+		// the resource in a namespace without manifests.
+		ownedDeployment("kube-system", "elsewhere", ownerRef),
+	)
+	r := &ApplyWorkReconciler{spokeDynamicClient: dynamicClient}
+	work := &fleetv1beta1.Work{ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: "fleet-member-test"}}
+	appliedWork := &fleetv1beta1.AppliedWork{
+		ObjectMeta: metav1.ObjectMeta{Name: ownerRef.Name, UID: ownerRef.UID},
+		Status: fleetv1beta1.AppliedWorkStatus{
+			AppliedResources: []fleetv1beta1.AppliedResourceMeta{appliedDeploymentMeta("stale", "stale-uid")},
+		},
+	}
+	results := []applyResult{
+		{identifier: appliedDeploymentMeta("deploy", "").WorkResourceIdentifier},
+		// the manifest which cannot be decoded is ignored.
+		{identifier: fleetv1beta1.WorkResourceIdentifier{Ordinal: 1}},
+	}
+
+	got := r.additionalResources(context.Background(), work, appliedWork, ownerRef, results)
+	want := []fleetv1beta1.WorkResourceIdentifier{
+		appliedDeploymentMeta("another-controller-created", "").WorkResourceIdentifier,
+		appliedDeploymentMeta("controller-created", "").WorkResourceIdentifier,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("additionalResources() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+// policyAnnotation is an annotation of the work which an annotation propagation policy routes to the resources.
+type policyAnnotation struct {
+	key   string
+	value string
+	// gvks are the kinds of the resources the annotation is added to; it is added to all of them if empty.
+	gvks map[string]bool
+}
+
+// policyAnnotationsKey is the context key of the annotations of the work routed by the annotation propagation policies.
+type policyAnnotationsKey struct{}
+
+// withPolicyAnnotations returns a context which carries the annotations of the work routed by the annotation
+// propagation policies in its namespace.
+func (r *ApplyWorkReconciler) withPolicyAnnotations(ctx context.Context, work *fleetv1beta1.Work) (context.Context, error) {
+	var policies fleetv1beta1.AnnotationPropagationPolicyList
+	if err := r.client.List(ctx, &policies, client.InNamespace(work.Namespace)); err != nil {
+		klog.ErrorS(err, "Failed to list the annotation propagation policies", "work", klog.KObj(work))
+		return ctx, controller.NewAPIServerError(true, err)
+	}
+	annotations := resolvePolicyAnnotations(work, policies.Items)
+	if len(annotations) == 0 {
+		return ctx, nil
+	}
+	return context.WithValue(ctx, policyAnnotationsKey{}, annotations), nil
+}
+
+// resolvePolicyAnnotations returns the annotations of the work routed by the rules of the policies, with their values
+// transformed. The policies are applied in the order of their names so that the later ones win.
+func resolvePolicyAnnotations(work *fleetv1beta1.Work, policies []fleetv1beta1.AnnotationPropagationPolicy) []policyAnnotation {
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Name < policies[j].Name
+	})
+	workAnnotations := work.GetAnnotations()
+	var annotations []policyAnnotation
+	for _, policy := range policies {
+		for _, rule := range policy.Spec.Rules {
+			value, ok := workAnnotations[rule.AnnotationKey]
+			if !ok {
+				continue
+			}
+			annotation := policyAnnotation{key: rule.AnnotationKey, value: transformAnnotationValue(value, rule.ValueTransform)}
+			if len(rule.PropagateToGVKs) > 0 {
+				annotation.gvks = make(map[string]bool, len(rule.PropagateToGVKs))
+				for _, gvk := range rule.PropagateToGVKs {
+					annotation.gvks[gvk] = true
+				}
+			}
+			annotations = append(annotations, annotation)
+		}
+	}
+	return annotations
+}
+
+// transformAnnotationValue returns the value transformed by the value transform of an annotation rule.
+func transformAnnotationValue(value, transform string) string {
+	switch {
+	case strings.HasPrefix(transform, fleetv1beta1.AnnotationValueTransformPrefix):
+		return strings.TrimPrefix(transform, fleetv1beta1.AnnotationValueTransformPrefix) + value
+	case strings.HasPrefix(transform, fleetv1beta1.AnnotationValueTransformSuffix):
+		return value + strings.TrimPrefix(transform, fleetv1beta1.AnnotationValueTransformSuffix)
+	default:
+		return value
+	}
+}
+
+// formatRuleGVK formats the kind as in the propagateToGVKs of the annotation rules: group/version/kind, or
+// version/kind for the core group.
+func formatRuleGVK(gvk schema.GroupVersionKind) string {
+	if gvk.Group == "" {
+		return gvk.Version + "/" + gvk.Kind
+	}
+	return gvk.Group + "/" + gvk.Version + "/" + gvk.Kind
+}
+
+// addPolicyAnnotations adds the annotations routed by the annotation propagation policies to the manifest object if
+// it is of a kind they are routed to, overriding the values set in the manifest itself.
+func addPolicyAnnotations(ctx context.Context, obj *unstructured.Unstructured) {
+	annotations, _ := ctx.Value(policyAnnotationsKey{}).([]policyAnnotation)
+	if len(annotations) == 0 {
+		return
+	}
+	gvk := formatRuleGVK(obj.GroupVersionKind())
+	objAnnotations := obj.GetAnnotations()
+	for _, annotation := range annotations {
+		if annotation.gvks != nil && !annotation.gvks[gvk] {
+			continue
+		}
+		if objAnnotations == nil {
+			objAnnotations = make(map[string]string, len(annotations))
+		}
+		objAnnotations[annotation.key] = annotation.value
+	}
+	obj.SetAnnotations(objAnnotations)
+}
@@ -0,0 +1,105 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func TestAddPolicyAnnotations(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the fleet scheme: %v", err)
+	}
+	namespace := "fleet-member-test"
+	policies := []runtime.Object{
+		&fleetv1beta1.AnnotationPropagationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-policy", Namespace: namespace},
+			Spec: fleetv1beta1.AnnotationPropagationPolicySpec{Rules: []fleetv1beta1.AnnotationRule{
+				{AnnotationKey: "team", PropagateToGVKs: []string{"apps/v1/Deployment"}, ValueTransform: "prefix:fleet-"},
+				{AnnotationKey: "owner", ValueTransform: "suffix:@example.com"},
+				{AnnotationKey: "missing"},
+			}},
+		},
+		// the later policy wins for the same annotation.
+		&fleetv1beta1.AnnotationPropagationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "b-policy", Namespace: namespace},
+			Spec: fleetv1beta1.AnnotationPropagationPolicySpec{Rules: []fleetv1beta1.AnnotationRule{
+				{AnnotationKey: "owner", PropagateToGVKs: []string{"v1/ConfigMap"}},
+			}},
+		},
+		// the policies in the other namespaces do not apply.
+		&fleetv1beta1.AnnotationPropagationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-policy", Namespace: "other"},
+			Spec: fleetv1beta1.AnnotationPropagationPolicySpec{Rules: []fleetv1beta1.AnnotationRule{
+				{AnnotationKey: "team", PropagateToGVKs: []string{"v1/ConfigMap"}},
+			}},
+		},
+	}
+	r := &ApplyWorkReconciler{client: fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(policies...).Build()}
+	work := &fleetv1beta1.Work{ObjectMeta: metav1.ObjectMeta{
+		Name:        "test-work",
+		Namespace:   namespace,
+		Annotations: map[string]string{"team": "payments", "owner": "alice"},
+	}}
+	ctx, err := r.withPolicyAnnotations(context.Background(), work)
+	if err != nil {
+		t.Fatalf("withPolicyAnnotations() = %v, want no error", err)
+	}
+
+	tests := map[string]struct {
+		apiVersion string
+		kind       string
+		want       map[string]string
+	}{
+		"deployment": {
+			apiVersion: "apps/v1",
+			kind:       "Deployment",
+			want:       map[string]string{"existing": "kept", "team": "fleet-payments", "owner": "alice@example.com"},
+		},
+		"config map": {
+			apiVersion: "v1",
+			kind:       "ConfigMap",
+			want:       map[string]string{"existing": "kept", "owner": "alice"},
+		},
+		"service": {
+			apiVersion: "v1",
+			kind:       "Service",
+			want:       map[string]string{"existing": "kept", "owner": "alice@example.com"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			obj := &unstructured.Unstructured{}
+			obj.SetAPIVersion(tt.apiVersion)
+			obj.SetKind(tt.kind)
+			obj.SetName("test")
+			obj.SetAnnotations(map[string]string{"existing": "kept"})
+			addPolicyAnnotations(ctx, obj)
+			if diff := cmp.Diff(tt.want, obj.GetAnnotations()); diff != "" {
+				t.Errorf("addPolicyAnnotations() annotations mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+
+	// nothing is added without a policy.
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("apps/v1")
+	obj.SetKind("Deployment")
+	addPolicyAnnotations(context.Background(), obj)
+	if got := obj.GetAnnotations(); len(got) != 0 {
+		t.Errorf("addPolicyAnnotations() annotations = %v, want none without a policy", got)
+	}
+}
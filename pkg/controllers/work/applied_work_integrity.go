@@ -0,0 +1,82 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/metrics"
+)
+
+const (
+	// integrityViolationResourceMissing is the reason of the violation when an applied resource no longer exists.
+	integrityViolationResourceMissing = "ResourceMissing"
+	// integrityViolationUIDMismatch is the reason of the violation when an applied resource is replaced by another
+	// resource of the same name.
+	integrityViolationUIDMismatch = "UIDMismatch"
+)
+
+// appliedWorkGVR is the resource of the appliedWorks; they are listed with the dynamic client since the integrity
+// check runs before the cache of the member cluster is synced.
+var appliedWorkGVR = fleetv1beta1.GroupVersion.WithResource("appliedworks")
+
+// checkAppliedWorkIntegrity compares the applied resources recorded in the appliedWorks against the live resources
+// in the member cluster and reports the resources which are missing or replaced. It returns the number of violations.
+// The check is a best effort: the resources which cannot be read are skipped.
+func (r *ApplyWorkReconciler) checkAppliedWorkIntegrity(ctx context.Context) (int, error) {
+	list, err := r.spokeDynamicClient.Resource(appliedWorkGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		klog.ErrorS(err, "Failed to list the appliedWorks for the integrity check")
+		return 0, err
+	}
+	violations := 0
+	for i := range list.Items {
+		var appliedWork fleetv1beta1.AppliedWork
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(list.Items[i].Object, &appliedWork); err != nil {
+			klog.ErrorS(err, "Failed to convert the appliedWork", "appliedWork", list.Items[i].GetName())
+			continue
+		}
+		for _, resourceMeta := range appliedWork.Status.AppliedResources {
+			// the resources whose UID is not recorded cannot be told apart from their replacements.
+			if resourceMeta.UID == "" {
+				continue
+			}
+			gvr := schema.GroupVersionResource{
+				Group:    resourceMeta.Group,
+				Version:  resourceMeta.Version,
+				Resource: resourceMeta.Resource,
+			}
+			namespace := appliedNamespace(resourceMeta)
+			logObjRef := klog.KRef(namespace, resourceMeta.Name)
+			obj, err := r.spokeDynamicClient.Resource(gvr).Namespace(namespace).Get(ctx, resourceMeta.Name, metav1.GetOptions{})
+			reason := ""
+			switch {
+			case apierrors.IsNotFound(err):
+				reason = integrityViolationResourceMissing
+			case err != nil:
+				klog.ErrorS(err, "Failed to get the applied resource for the integrity check", "appliedWork", appliedWork.Name, "gvr", gvr, "resource", logObjRef)
+				continue
+			case obj.GetUID() != resourceMeta.UID:
+				reason = integrityViolationUIDMismatch
+			default:
+				continue
+			}
+			violations++
+			metrics.WorkIntegrityViolationsTotal.WithLabelValues(reason).Inc()
+			klog.InfoS("Found an applied resource which does not match the appliedWork", "appliedWork", appliedWork.Name, "gvr", gvr,
+				"resource", logObjRef, "reason", reason)
+		}
+	}
+	klog.InfoS("Completed the integrity check of the appliedWorks", "appliedWorks", len(list.Items), "violations", violations)
+	return violations, nil
+}
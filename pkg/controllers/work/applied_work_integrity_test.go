@@ -0,0 +1,95 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/metrics"
+	"go.goms.io/fleet/pkg/utils"
+)
+
+// appliedDeploymentMeta returns the applied resource meta of the deployment with the given name and UID.
+func appliedDeploymentMeta(name string, uid types.UID) fleetv1beta1.AppliedResourceMeta {
+	return fleetv1beta1.AppliedResourceMeta{
+		WorkResourceIdentifier: fleetv1beta1.WorkResourceIdentifier{
+			Group:     utils.DeploymentGVR.Group,
+			Version:   utils.DeploymentGVR.Version,
+			Kind:      "Deployment",
+			Resource:  utils.DeploymentGVR.Resource,
+			Namespace: "default",
+			Name:      name,
+		},
+		UID: uid,
+	}
+}
+
+// liveDeployment returns the deployment with the given name and UID in the member cluster.
+func liveDeployment(name string, uid types.UID) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("apps/v1")
+	obj.SetKind("Deployment")
+	obj.SetName(name)
+	obj.SetNamespace("default")
+	obj.SetUID(uid)
+	return obj
+}
+
+func TestCheckAppliedWorkIntegrity(t *testing.T) {
+	appliedWork := &fleetv1beta1.AppliedWork{
+		TypeMeta:   metav1.TypeMeta{APIVersion: fleetv1beta1.GroupVersion.String(), Kind: fleetv1beta1.AppliedWorkKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "test-work"},
+		Status: fleetv1beta1.AppliedWorkStatus{
+			AppliedResources: []fleetv1beta1.AppliedResourceMeta{
+				appliedDeploymentMeta("intact", "intact-uid"),
+				appliedDeploymentMeta("missing", "missing-uid"),
+				appliedDeploymentMeta("replaced", "replaced-uid"),
+				// a resource whose UID is not recorded is not checked.
+				appliedDeploymentMeta("untracked", ""),
+			},
+		},
+	}
+	rawAppliedWork, err := runtime.DefaultUnstructuredConverter.ToUnstructured(appliedWork)
+	if err != nil {
+		t.Fatalf("failed to convert the appliedWork: %v", err)
+	}
+	dynamicClient := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			appliedWorkGVR:      "AppliedWorkList",
+			utils.DeploymentGVR: "DeploymentList",
+		},
+		&unstructured.Unstructured{Object: rawAppliedWork},
+		liveDeployment("intact", "intact-uid"),
+		liveDeployment("replaced", "recreated-uid"),
+	)
+	r := &ApplyWorkReconciler{spokeDynamicClient: dynamicClient}
+
+	missingBefore := testutil.ToFloat64(metrics.WorkIntegrityViolationsTotal.WithLabelValues(integrityViolationResourceMissing))
+	mismatchBefore := testutil.ToFloat64(metrics.WorkIntegrityViolationsTotal.WithLabelValues(integrityViolationUIDMismatch))
+	violations, err := r.checkAppliedWorkIntegrity(context.Background())
+	if err != nil {
+		t.Fatalf("checkAppliedWorkIntegrity() = %v, want no error", err)
+	}
+	if violations != 2 {
+		t.Errorf("checkAppliedWorkIntegrity() violations = %d, want 2", violations)
+	}
+	if got := testutil.ToFloat64(metrics.WorkIntegrityViolationsTotal.WithLabelValues(integrityViolationResourceMissing)) - missingBefore; got != 1 {
+		t.Errorf("checkAppliedWorkIntegrity() reported %v missing resource violation(s), want 1", got)
+	}
+	if got := testutil.ToFloat64(metrics.WorkIntegrityViolationsTotal.WithLabelValues(integrityViolationUIDMismatch)) - mismatchBefore; got != 1 {
+		t.Errorf("checkAppliedWorkIntegrity() reported %v UID mismatch violation(s), want 1", got)
+	}
+}
@@ -28,11 +28,14 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/klog/v2"
 
 	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/metrics"
+	"go.goms.io/fleet/pkg/utils"
 )
 
 // generateDiff check the difference between what is supposed to be applied  (tracked by the work CR status)
@@ -41,13 +44,16 @@ import (
 // What is in the `work` but not in the `appliedWork` should be added to the appliedWork status
 func (r *ApplyWorkReconciler) generateDiff(ctx context.Context, work *fleetv1beta1.Work, appliedWork *fleetv1beta1.AppliedWork) ([]fleetv1beta1.AppliedResourceMeta, []fleetv1beta1.AppliedResourceMeta, error) {
 	var staleRes, newRes []fleetv1beta1.AppliedResourceMeta
+	// the resource applied to a namespace the manifest is no longer routed to is stale as well.
+	targetNamespaces := manifestTargetNamespaces(work)
 	// for every resource applied in cluster, check if it's still in the work's manifest condition
 	// we keep the applied resource in the appliedWork status even if it is not applied successfully
 	// to make sure that it is safe to delete the resource from the member cluster.
 	for _, resourceMeta := range appliedWork.Status.AppliedResources {
 		resStillExist := false
 		for _, manifestCond := range work.Status.ManifestConditions {
-			if isSameResourceIdentifier(resourceMeta.WorkResourceIdentifier, manifestCond.Identifier) {
+			if isSameResourceIdentifier(resourceMeta.WorkResourceIdentifier, manifestCond.Identifier) &&
+				appliedNamespace(resourceMeta) == routedNamespace(manifestCond.Identifier, targetNamespaces) {
 				resStillExist = true
 				break
 			}
@@ -66,29 +72,40 @@ func (r *ApplyWorkReconciler) generateDiff(ctx context.Context, work *fleetv1bet
 			klog.ErrorS(fmt.Errorf("resource is missing  applied condition"), "applied condition missing", "resource", manifestCond.Identifier)
 			continue
 		}
-		// we only add the applied one to the appliedWork status
-		if ac.Status == metav1.ConditionTrue {
+		// we only add the applied one to the appliedWork status, and keep the skipped, batch pending or rollback blocked
+		// one that was applied before so that it is still tracked.
+		skipped := ac.Reason == ManifestSkippedByAnnotationReason || ac.Reason == ManifestBatchPendingReason ||
+			ac.Reason == ManifestVersionRollbackBlockedReason
+		if ac.Status == metav1.ConditionTrue || skipped {
 			resRecorded := false
+			namespace := routedNamespace(manifestCond.Identifier, targetNamespaces)
+			var routedTo string
+			if namespace != manifestCond.Identifier.Namespace {
+				routedTo = namespace
+			}
 			// we update the identifier
 			// TODO: this UID may not be the current one if the resource is deleted and recreated
 			for _, resourceMeta := range appliedWork.Status.AppliedResources {
-				if isSameResourceIdentifier(resourceMeta.WorkResourceIdentifier, manifestCond.Identifier) {
+				if isSameResourceIdentifier(resourceMeta.WorkResourceIdentifier, manifestCond.Identifier) &&
+					appliedNamespace(resourceMeta) == namespace {
 					resRecorded = true
 					newRes = append(newRes, fleetv1beta1.AppliedResourceMeta{
 						WorkResourceIdentifier: manifestCond.Identifier,
 						UID:                    resourceMeta.UID,
+						RotationGeneration:     manifestRotationGeneration(work, manifestCond.Identifier),
+						AppliedNamespace:       routedTo,
 					})
 					break
 				}
 			}
-			if !resRecorded {
+			if !resRecorded && !skipped {
 				klog.V(2).InfoS("discovered a new manifest resource",
 					"parent Work", work.GetName(), "manifest", manifestCond.Identifier)
 				obj, err := r.spokeDynamicClient.Resource(schema.GroupVersionResource{
 					Group:    manifestCond.Identifier.Group,
 					Version:  manifestCond.Identifier.Version,
 					Resource: manifestCond.Identifier.Resource,
-				}).Namespace(manifestCond.Identifier.Namespace).Get(ctx, manifestCond.Identifier.Name, metav1.GetOptions{})
+				}).Namespace(namespace).Get(ctx, manifestCond.Identifier.Name, metav1.GetOptions{})
 				switch {
 				case apierrors.IsNotFound(err):
 					klog.V(2).InfoS("the new manifest resource is already deleted", "parent Work", work.GetName(), "manifest", manifestCond.Identifier)
@@ -100,6 +117,8 @@ func (r *ApplyWorkReconciler) generateDiff(ctx context.Context, work *fleetv1bet
 				newRes = append(newRes, fleetv1beta1.AppliedResourceMeta{
 					WorkResourceIdentifier: manifestCond.Identifier,
 					UID:                    obj.GetUID(),
+					RotationGeneration:     manifestRotationGeneration(work, manifestCond.Identifier),
+					AppliedNamespace:       routedTo,
 				})
 			}
 		}
@@ -116,7 +135,7 @@ func (r *ApplyWorkReconciler) deleteStaleManifest(ctx context.Context, staleMani
 			Version:  staleManifest.Version,
 			Resource: staleManifest.Resource,
 		}
-		uObj, err := r.spokeDynamicClient.Resource(gvr).Namespace(staleManifest.Namespace).
+		uObj, err := r.spokeDynamicClient.Resource(gvr).Namespace(appliedNamespace(staleManifest)).
 			Get(ctx, staleManifest.Name, metav1.GetOptions{})
 		if err != nil {
 			// It is possible that the staled manifest was already deleted but the status wasn't updated to reflect that yet.
@@ -144,16 +163,19 @@ func (r *ApplyWorkReconciler) deleteStaleManifest(ctx context.Context, staleMani
 		}
 		if len(newOwners) == 0 {
 			klog.V(2).InfoS("delete the staled manifest", "manifest", staleManifest, "owner", owner)
-			err = r.spokeDynamicClient.Resource(gvr).Namespace(staleManifest.Namespace).
+			err = r.spokeDynamicClient.Resource(gvr).Namespace(appliedNamespace(staleManifest)).
 				Delete(ctx, staleManifest.Name, metav1.DeleteOptions{})
-			if err != nil && !apierrors.IsNotFound(err) {
+			switch {
+			case err == nil:
+				metrics.WorkGarbageCollectedResourcesTotal.WithLabelValues(staleManifest.Group, staleManifest.Version, staleManifest.Kind).Inc()
+			case !apierrors.IsNotFound(err):
 				klog.ErrorS(err, "failed to delete the staled manifest", "manifest", staleManifest, "owner", owner)
 				errs = append(errs, err)
 			}
 		} else {
 			klog.V(2).InfoS("remove the owner reference from the staled manifest", "manifest", staleManifest, "owner", owner)
 			uObj.SetOwnerReferences(newOwners)
-			_, err = r.spokeDynamicClient.Resource(gvr).Namespace(staleManifest.Namespace).Update(ctx, uObj, metav1.UpdateOptions{FieldManager: workFieldManagerName})
+			_, err = r.spokeDynamicClient.Resource(gvr).Namespace(appliedNamespace(staleManifest)).Update(ctx, uObj, metav1.UpdateOptions{FieldManager: r.fieldManager})
 			if err != nil {
 				klog.ErrorS(err, "failed to remove the owner reference from manifest", "manifest", staleManifest, "owner", owner)
 				errs = append(errs, err)
@@ -163,6 +185,24 @@ func (r *ApplyWorkReconciler) deleteStaleManifest(ctx context.Context, staleMani
 	return utilerrors.NewAggregate(errs)
 }
 
+// manifestRotationGeneration returns the secret rotation generation of the manifest identified by the identifier.
+// It returns 0 if the manifest is not a secret or cannot be decoded.
+func manifestRotationGeneration(work *fleetv1beta1.Work, identifier fleetv1beta1.WorkResourceIdentifier) int {
+	if identifier.Group != utils.SecretGVR.Group || identifier.Kind != "Secret" {
+		return 0
+	}
+	manifests := work.Spec.Workload.Manifests
+	if identifier.Ordinal < 0 || identifier.Ordinal >= len(manifests) {
+		return 0
+	}
+	var manifestObj unstructured.Unstructured
+	if err := manifestObj.UnmarshalJSON(manifests[identifier.Ordinal].Raw); err != nil {
+		klog.V(2).InfoS("Failed to decode the manifest to read its rotation generation", "work", klog.KObj(work), "manifest", identifier)
+		return 0
+	}
+	return secretRotationGeneration(&manifestObj)
+}
+
 // isSameResourceIdentifier returns true if a and b identifies the same object.
 func isSameResourceIdentifier(a, b fleetv1beta1.WorkResourceIdentifier) bool {
 	// compare GVKNN but ignore the Ordinal and Resource
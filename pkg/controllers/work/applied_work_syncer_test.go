@@ -27,6 +27,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -40,6 +41,7 @@ import (
 	testingclient "k8s.io/client-go/testing"
 
 	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/metrics"
 )
 
 // TestCalculateNewAppliedWork validates the calculation logic between the Work & AppliedWork resources.
@@ -49,6 +51,14 @@ func TestCalculateNewAppliedWork(t *testing.T) {
 	workIdentifier := generateResourceIdentifier()
 	diffOrdinalIdentifier := workIdentifier
 	diffOrdinalIdentifier.Ordinal = rand.Int()
+	secretIdentifier := fleetv1beta1.WorkResourceIdentifier{
+		Ordinal:   0,
+		Version:   "v1",
+		Kind:      "Secret",
+		Resource:  "secrets",
+		Namespace: "default",
+		Name:      "secret",
+	}
 	tests := map[string]struct {
 		spokeDynamicClient dynamic.Interface
 		inputWork          fleetv1beta1.Work
@@ -163,6 +173,28 @@ func TestCalculateNewAppliedWork(t *testing.T) {
 			expectedStaleRes: []fleetv1beta1.AppliedResourceMeta(nil),
 			hasErr:           false,
 		},
+		"Test work and appliedWork in sync with a rotated secret": {
+			spokeDynamicClient: nil,
+			inputWork: func() fleetv1beta1.Work {
+				work := generateWorkObj(&secretIdentifier)
+				work.Spec.Workload.Manifests = []fleetv1beta1.Manifest{
+					{
+						RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"Secret","metadata":{"name":"secret","namespace":"default","annotations":{"` +
+							fleetv1beta1.SecretRotationGenerationAnnotation + `":"3"}}}`)},
+					},
+				}
+				return work
+			}(),
+			inputAppliedWork: generateAppliedWorkObj(&secretIdentifier),
+			expectedNewRes: []fleetv1beta1.AppliedResourceMeta{
+				{
+					WorkResourceIdentifier: secretIdentifier,
+					RotationGeneration:     3,
+				},
+			},
+			expectedStaleRes: []fleetv1beta1.AppliedResourceMeta(nil),
+			hasErr:           false,
+		},
 		"Test work is adding one manifest but failed to get it on the member cluster": {
 			spokeDynamicClient: func() *fake.FakeDynamicClient {
 				dynamicClient := fake.NewSimpleDynamicClient(runtime.NewScheme())
@@ -192,6 +224,9 @@ func TestCalculateNewAppliedWork(t *testing.T) {
 				if len(diff) != 0 {
 					t.Errorf("Testcase %s: get newRes is different from the want newRes, diff = %s", testName, diff)
 				}
+				if tt.expectedNewRes[i].RotationGeneration != newRes[i].RotationGeneration {
+					t.Errorf("Testcase %s: get newRes rotation generation %d, want %d", testName, newRes[i].RotationGeneration, tt.expectedNewRes[i].RotationGeneration)
+				}
 			}
 			if len(tt.expectedStaleRes) != len(staleRes) {
 				t.Errorf("Testcase %s: get staleRes contains different number of elements than the want staleRes.", testName)
@@ -363,3 +398,39 @@ func generateResourceIdentifier() fleetv1beta1.WorkResourceIdentifier {
 		Name:      rand.String(10),
 	}
 }
+
+func TestDeleteStaleManifestGarbageCollectedMetric(t *testing.T) {
+	dynamicClient := fake.NewSimpleDynamicClient(runtime.NewScheme(), routedTestDeployment("default"))
+	r := &ApplyWorkReconciler{spokeDynamicClient: dynamicClient}
+	staleManifests := []fleetv1beta1.AppliedResourceMeta{
+		{
+			WorkResourceIdentifier: fleetv1beta1.WorkResourceIdentifier{
+				Group:     "apps",
+				Version:   "v1",
+				Kind:      "Deployment",
+				Resource:  "deployments",
+				Namespace: "default",
+				Name:      "deploy",
+			},
+		},
+		{
+			// the stale resource already deleted is not counted.
+			WorkResourceIdentifier: fleetv1beta1.WorkResourceIdentifier{
+				Group:     "apps",
+				Version:   "v1",
+				Kind:      "Deployment",
+				Resource:  "deployments",
+				Namespace: "default",
+				Name:      "gone",
+			},
+		},
+	}
+	before := testutil.ToFloat64(metrics.WorkGarbageCollectedResourcesTotal.WithLabelValues("apps", "v1", "Deployment"))
+
+	if err := r.deleteStaleManifest(context.Background(), staleManifests, ownerRef); err != nil {
+		t.Fatalf("deleteStaleManifest() = %v, want no error", err)
+	}
+	if got := testutil.ToFloat64(metrics.WorkGarbageCollectedResourcesTotal.WithLabelValues("apps", "v1", "Deployment")) - before; got != 1 {
+		t.Errorf("deleteStaleManifest() counted %v garbage collected deployments, want 1", got)
+	}
+}
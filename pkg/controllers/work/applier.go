@@ -30,11 +30,11 @@ type Applier interface {
 }
 
 // serverSideApply uses server side apply to apply the manifest.
-func serverSideApply(ctx context.Context, client dynamic.Interface, force bool, gvr schema.GroupVersionResource,
+func serverSideApply(ctx context.Context, client dynamic.Interface, fieldManager string, force bool, gvr schema.GroupVersionResource,
 	manifestObj *unstructured.Unstructured) (*unstructured.Unstructured, ApplyAction, error) {
 	manifestRef := klog.KObj(manifestObj)
 	options := metav1.ApplyOptions{
-		FieldManager: workFieldManagerName,
+		FieldManager: fieldManager,
 		Force:        force,
 	}
 	manifestRes, err := client.Resource(gvr).Namespace(manifestObj.GetNamespace()).Apply(ctx, manifestObj.GetName(), manifestObj, options)
@@ -82,6 +82,19 @@ func findConflictedWork(ctx context.Context, hubClient client.Client, namespace
 	return nil, nil
 }
 
+// validateFieldManagers fails the apply of the manifest if the fields it would change are owned by other field
+// managers through server side apply, so that the apply does not take them over.
+func validateFieldManagers(manifestObj, curObj *unstructured.Unstructured, fieldManager string) (ApplyAction, error) {
+	managers := conflictingFieldManagers(manifestObj, curObj, fieldManager)
+	if len(managers) == 0 {
+		return "", nil
+	}
+	err := fmt.Errorf("the fields of the manifest are managed by the other field managers %v through server side apply", managers)
+	klog.ErrorS(err, "Skip applying a manifest whose fields are managed by other field managers",
+		"manifest", klog.KObj(manifestObj), "fieldManager", fieldManager)
+	return applyConflictWithOtherFieldManagers, controller.NewUserError(err)
+}
+
 func validateOwnerReference(ctx context.Context, hubClient client.Client, namespace string, strategy *fleetv1beta1.ApplyStrategy, ownerRefs []metav1.OwnerReference) (ApplyAction, error) {
 	// If no owner reference is found, the resource could be managed by the work.
 	// There is a corner case that the resource is already managed by the work but the owner reference could be removed
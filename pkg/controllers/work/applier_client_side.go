@@ -25,6 +25,8 @@ type ClientSideApplier struct {
 	HubClient          client.Client
 	WorkNamespace      string
 	SpokeDynamicClient dynamic.Interface
+	// FieldManager is the name of the field manager the manifests are applied as.
+	FieldManager string
 }
 
 // ApplyUnstructured determines if an unstructured manifest object can & should be applied. It first validates
@@ -45,7 +47,7 @@ func (applier *ClientSideApplier) ApplyUnstructured(ctx context.Context, applySt
 			return nil, errorApplyAction, controller.NewUnexpectedBehaviorError(err)
 		}
 		actual, err := applier.SpokeDynamicClient.Resource(gvr).Namespace(manifestObj.GetNamespace()).Create(
-			ctx, manifestObj, metav1.CreateOptions{FieldManager: workFieldManagerName})
+			ctx, manifestObj, metav1.CreateOptions{FieldManager: applier.FieldManager})
 		if err == nil {
 			klog.V(2).InfoS("successfully created the manifest", "gvr", gvr, "manifest", manifestRef)
 			return actual, manifestCreatedAction, nil
@@ -75,8 +77,10 @@ func (applier *ClientSideApplier) ApplyUnstructured(ctx context.Context, applySt
 		return nil, result, err
 	}
 
-	// We only try to update the object if its spec hash value has changed.
-	if manifestObj.GetAnnotations()[fleetv1beta1.ManifestHashAnnotation] != curObj.GetAnnotations()[fleetv1beta1.ManifestHashAnnotation] {
+	// We only try to update the object if its spec hash value has changed, the secret has been rotated or a forced
+	// resync of the work is requested.
+	if manifestObj.GetAnnotations()[fleetv1beta1.ManifestHashAnnotation] != curObj.GetAnnotations()[fleetv1beta1.ManifestHashAnnotation] ||
+		isSecretRotated(manifestObj, curObj) || isForcedApply(ctx) {
 		// we need to merge the owner reference between the current and the manifest since we support one manifest
 		// belong to multiple work, so it contains the union of all the appliedWork.
 		manifestObj.SetOwnerReferences(mergeOwnerReference(curObj.GetOwnerReferences(), manifestObj.GetOwnerReferences()))
@@ -86,8 +90,13 @@ func (applier *ClientSideApplier) ApplyUnstructured(ctx context.Context, applySt
 			return nil, errorApplyAction, err
 		}
 		if !isModifiedConfigAnnotationNotEmpty {
+			// the server side apply is forced, it must not take over the fields other field managers apply, e.g. the work
+			// appliers of the other fleets.
+			if result, err := validateFieldManagers(manifestObj, curObj, applier.FieldManager); err != nil {
+				return nil, result, err
+			}
 			klog.V(2).InfoS("Using server side apply for manifest", "gvr", gvr, "manifest", manifestRef)
-			return serverSideApply(ctx, applier.SpokeDynamicClient, true, gvr, manifestObj)
+			return serverSideApply(ctx, applier.SpokeDynamicClient, applier.FieldManager, true, gvr, manifestObj)
 		}
 		klog.V(2).InfoS("Using three way merge for manifest", "gvr", gvr, "manifest", manifestRef)
 		return applier.patchCurrentResource(ctx, gvr, manifestObj, curObj)
@@ -116,7 +125,7 @@ func (applier *ClientSideApplier) patchCurrentResource(ctx context.Context, gvr
 	}
 	// Use three-way merge (similar to kubectl client side apply) to the patch to the member cluster
 	manifestObj, patchErr := applier.SpokeDynamicClient.Resource(gvr).Namespace(manifestObj.GetNamespace()).
-		Patch(ctx, manifestObj.GetName(), patch.Type(), data, metav1.PatchOptions{FieldManager: workFieldManagerName})
+		Patch(ctx, manifestObj.GetName(), patch.Type(), data, metav1.PatchOptions{FieldManager: applier.FieldManager})
 	if patchErr != nil {
 		klog.ErrorS(patchErr, "Failed to patch the manifest", "gvr", gvr, "manifest", manifestRef)
 		return nil, errorApplyAction, controller.NewAPIServerError(false, patchErr)
@@ -25,6 +25,8 @@ type ServerSideApplier struct {
 	HubClient          client.Client
 	WorkNamespace      string
 	SpokeDynamicClient dynamic.Interface
+	// FieldManager is the name of the field manager the manifests are applied as.
+	FieldManager string
 }
 
 // ApplyUnstructured applies the manifest to the cluster using server side apply according to the given apply strategy.
@@ -35,13 +37,13 @@ func (applier *ServerSideApplier) ApplyUnstructured(ctx context.Context, applySt
 	// support resources with generated name
 	if manifestObj.GetName() == "" && manifestObj.GetGenerateName() != "" {
 		klog.V(2).InfoS("Create the resource with generated name regardless", "gvr", gvr, "manifest", manifestRef)
-		return serverSideApply(ctx, applier.SpokeDynamicClient, force, gvr, manifestObj)
+		return applier.serverSideApply(ctx, applyStrategy, gvr, manifestObj)
 	}
 
 	curObj, err := applier.SpokeDynamicClient.Resource(gvr).Namespace(manifestObj.GetNamespace()).Get(ctx, manifestObj.GetName(), metav1.GetOptions{})
 	switch {
 	case errors.IsNotFound(err):
-		return serverSideApply(ctx, applier.SpokeDynamicClient, force, gvr, manifestObj)
+		return applier.serverSideApply(ctx, applyStrategy, gvr, manifestObj)
 	case err != nil:
 		return nil, errorApplyAction, controller.NewAPIServerError(false, err)
 	}
@@ -52,5 +54,16 @@ func (applier *ServerSideApplier) ApplyUnstructured(ctx context.Context, applySt
 			"gvr", gvr, "manifest", manifestRef, "applyStrategy", applyStrategy, "ownerReferences", curObj.GetOwnerReferences())
 		return nil, result, err
 	}
-	return serverSideApply(ctx, applier.SpokeDynamicClient, force, gvr, manifestObj)
+	if isResourceUpToDate(ctx, manifestObj, curObj, applyStrategy) {
+		klog.V(2).InfoS("Skip applying the manifest which is unchanged and has no drift", "gvr", gvr, "manifest", manifestRef)
+		return curObj, manifestServerSideAppliedAction, nil
+	}
+	// the fields owned by the other field managers, e.g. the work appliers of the other fleets, are only taken over
+	// if the apply strategy forces the conflicts.
+	if !force {
+		if result, err := validateFieldManagers(manifestObj, curObj, applier.FieldManager); err != nil {
+			return nil, result, err
+		}
+	}
+	return applier.serverSideApply(ctx, applyStrategy, gvr, manifestObj)
 }
@@ -23,11 +23,15 @@ package work
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"go.uber.org/atomic"
+	"golang.org/x/sync/errgroup"
 	appv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -37,6 +41,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
@@ -46,19 +51,35 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrloption "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/connectivityprobe"
 	"go.goms.io/fleet/pkg/metrics"
 	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/binarydata"
+	"go.goms.io/fleet/pkg/utils/compression"
 	"go.goms.io/fleet/pkg/utils/condition"
 	"go.goms.io/fleet/pkg/utils/controller"
 	"go.goms.io/fleet/pkg/utils/defaulter"
+	"go.goms.io/fleet/pkg/utils/manifestorder"
+	"go.goms.io/fleet/pkg/utils/manifeststore"
 	"go.goms.io/fleet/pkg/utils/resource"
+	"go.goms.io/fleet/pkg/utils/resourcelock"
+	"go.goms.io/fleet/pkg/utils/workdedup"
+	"go.goms.io/fleet/pkg/utils/workstatuspage"
 )
 
 const (
-	workFieldManagerName = "work-api-agent"
+	// DefaultFieldManagerName is the default name of the field manager the work applier changes the resources in the
+	// member cluster as.
+	DefaultFieldManagerName = "work-api-agent"
+	// DefaultManifestWorkerPoolSize is the default number of the manifests of a work the work applier applies
+	// concurrently.
+	DefaultManifestWorkerPoolSize = 5
 )
 
 // WorkCondition condition reasons
@@ -80,12 +101,22 @@ const (
 	// ManifestsAlreadyOwnedByOthersReason is the reason string of condition when the manifest is already owned by other
 	// non-fleet appliers.
 	ManifestsAlreadyOwnedByOthersReason = "ManifestsAlreadyOwnedByOthers"
+	// ApplyConflictWithOtherFieldManagersReason is the reason string of condition when the fields of the manifest are
+	// owned by other field managers through server side apply, e.g. by the work applier of another fleet.
+	ApplyConflictWithOtherFieldManagersReason = "ApplyConflictWithOtherFieldManagers"
+	// PreApplyHookRejectedReason is the reason string of condition when the pre-apply hook of the manifest rejected it.
+	PreApplyHookRejectedReason = "PreApplyHookRejected"
+	// PostApplyHookFailedReason is the reason string of condition when the post-apply hook of the manifest failed.
+	PostApplyHookFailedReason = "PostApplyHookFailed"
 	// ManifestAlreadyUpToDateReason is the reason string of condition when the manifest is already up to date.
 	ManifestAlreadyUpToDateReason  = "ManifestAlreadyUpToDate"
 	manifestAlreadyUpToDateMessage = "Manifest is already up to date"
 	// ManifestNeedsUpdateReason is the reason string of condition when the manifest needs to be updated.
-	ManifestNeedsUpdateReason  = "ManifestNeedsUpdate"
-	manifestNeedsUpdateMessage = "Manifest has just been updated and in the processing of checking its availability"
+	ManifestNeedsUpdateReason = "ManifestNeedsUpdate"
+	// MemberClusterUnhealthyReason is the reason string of condition when the member cluster API server is not reachable
+	// and the work is not applied at all.
+	MemberClusterUnhealthyReason = "MemberClusterUnhealthy"
+	manifestNeedsUpdateMessage   = "Manifest has just been updated and in the processing of checking its availability"
 )
 
 // ApplyWorkReconciler reconciles a Work object
@@ -99,19 +130,111 @@ type ApplyWorkReconciler struct {
 	workNameSpace      string
 	joined             *atomic.Bool
 	appliers           map[fleetv1beta1.ApplyStrategyType]Applier
+	// connectivityProber keeps the latest connectivity status of the member cluster API server; it can be nil.
+	connectivityProber *connectivityprobe.Prober
+	// costLimiter defers the works which are expensive to apply.
+	costLimiter *costLimiter
+	// statusPageSize is the number of manifest conditions kept in the work status; the rest overflow into the
+	// WorkStatusPages of the work. 0 disables the pagination.
+	statusPageSize int
+	// processedVersions keeps the versions of the works processed last so that the stale requests are skipped; it can be nil.
+	processedVersions *processedVersionTracker
+	// sanitizer strips the fields set at runtime from the manifests before they are applied; it can be nil.
+	sanitizer *ManifestSanitizer
+	// resourceLocks serializes the applies of the resources shared by more than one work; it can be nil.
+	resourceLocks *resourcelock.Registry
+	// fieldManager is the name of the field manager the resources are changed as. The work appliers of the fleets
+	// which manage the same member cluster must use different names so that they do not take over the fields of
+	// each other.
+	fieldManager string
+	// hubConnectivity monitors the connectivity to the hub cluster; it can be nil.
+	hubConnectivity *hubConnectivityMonitor
+	// profiler captures the CPU profiles of the slow reconciles; it can be nil.
+	profiler *SlowReconcileProfiler
+	// retryBudgets keeps the budgets of the manifests for retrying their transient apply errors within a reconcile;
+	// it can be nil.
+	retryBudgets *retryBudgetTracker
+	// maxDriftHistoryDepth is the number of the drifts kept in the drift history of a manifest; 0 disables the history.
+	maxDriftHistoryDepth int
+	// maxManifestSizeBytes is the maximum size of the raw JSON of a manifest; the larger manifests are not applied.
+	// 0 disables the limit.
+	maxManifestSizeBytes int64
+	// workerPoolSize is the number of the manifests of a work applied concurrently; they are applied one at a time if
+	// it is 1 or less.
+	workerPoolSize int
 }
 
+// ApplyWorkReconcilerOptions are the settings of the work applier; the unset ones are defaulted.
+type ApplyWorkReconcilerOptions struct {
+	// Concurrency is the number of the works reconciled concurrently; it defaults to 1.
+	Concurrency int
+	// WorkNamespace is the namespace of the member cluster on the hub cluster which the works are in.
+	WorkNamespace string
+	// ConnectivityProber keeps the latest connectivity status of the member cluster API server; it can be nil.
+	ConnectivityProber *connectivityprobe.Prober
+	// MaxAPICallsPerWork is the estimated number of API calls above which a work is deferred; 0 disables the limit.
+	MaxAPICallsPerWork int
+	// StatusPageSize is the number of manifest conditions kept in the work status; 0 disables the pagination.
+	StatusPageSize int
+	// SanitizedManifestFields are the paths of the fields stripped from the manifests before they are applied; they
+	// default to DefaultSanitizedManifestFields if nil.
+	SanitizedManifestFields []string
+	// FieldManager is the name of the field manager the resources are changed as; it defaults to
+	// DefaultFieldManagerName.
+	FieldManager string
+	// Profiler captures the CPU profiles of the slow reconciles; it can be nil.
+	Profiler *SlowReconcileProfiler
+	// RetryBudget is the budget for retrying the transient apply errors of the manifests within a reconcile.
+	RetryBudget RetryBudgetConfig
+	// MaxDriftHistoryDepth is the number of the drifts kept in the drift history of a manifest; 0 disables the history.
+	MaxDriftHistoryDepth int
+	// MaxManifestSizeBytes is the maximum size of the raw JSON of a manifest; 0 disables the limit.
+	MaxManifestSizeBytes int64
+	// WorkerPoolSize is the number of the manifests of a work applied concurrently; it defaults to 1.
+	WorkerPoolSize int
+}
+
+// setDefaults sets the default values of the unset options.
+func (o *ApplyWorkReconcilerOptions) setDefaults() {
+	if o.Concurrency < 1 {
+		o.Concurrency = 1
+	}
+	if o.SanitizedManifestFields == nil {
+		o.SanitizedManifestFields = DefaultSanitizedManifestFields
+	}
+	if o.FieldManager == "" {
+		o.FieldManager = DefaultFieldManagerName
+	}
+	if o.WorkerPoolSize < 1 {
+		o.WorkerPoolSize = 1
+	}
+}
+
+// NewApplyWorkReconciler returns a work applier which applies the works in the hub cluster to the member cluster.
 func NewApplyWorkReconciler(hubClient client.Client, spokeDynamicClient dynamic.Interface, spokeClient client.Client,
-	restMapper meta.RESTMapper, recorder record.EventRecorder, concurrency int, workNameSpace string) *ApplyWorkReconciler {
+	restMapper meta.RESTMapper, recorder record.EventRecorder, opts ApplyWorkReconcilerOptions) *ApplyWorkReconciler {
+	opts.setDefaults()
 	return &ApplyWorkReconciler{
-		client:             hubClient,
-		spokeDynamicClient: spokeDynamicClient,
-		spokeClient:        spokeClient,
-		restMapper:         restMapper,
-		recorder:           recorder,
-		concurrency:        concurrency,
-		workNameSpace:      workNameSpace,
-		joined:             atomic.NewBool(false),
+		client:               hubClient,
+		spokeDynamicClient:   spokeDynamicClient,
+		spokeClient:          spokeClient,
+		restMapper:           restMapper,
+		recorder:             recorder,
+		concurrency:          opts.Concurrency,
+		workNameSpace:        opts.WorkNamespace,
+		joined:               atomic.NewBool(false),
+		connectivityProber:   opts.ConnectivityProber,
+		costLimiter:          newCostLimiter(opts.MaxAPICallsPerWork),
+		statusPageSize:       opts.StatusPageSize,
+		processedVersions:    newProcessedVersionTracker(),
+		sanitizer:            NewManifestSanitizer(opts.SanitizedManifestFields),
+		resourceLocks:        resourcelock.NewRegistry(),
+		fieldManager:         opts.FieldManager,
+		profiler:             opts.Profiler,
+		retryBudgets:         newRetryBudgetTracker(opts.RetryBudget),
+		maxDriftHistoryDepth: opts.MaxDriftHistoryDepth,
+		maxManifestSizeBytes: opts.MaxManifestSizeBytes,
+		workerPoolSize:       opts.WorkerPoolSize,
 	}
 }
 
@@ -140,14 +263,45 @@ const (
 	// manifestAlreadyOwnedByOthers indicates that the manifest is already owned by other non-fleet applier.
 	manifestAlreadyOwnedByOthers ApplyAction = "ManifestAlreadyOwnedByOthers"
 
+	// applyConflictWithOtherFieldManagers indicates that it fails to apply the manifest as its fields are owned by
+	// other field managers through server side apply, e.g. by the work applier of another fleet.
+	applyConflictWithOtherFieldManagers ApplyAction = "ApplyConflictWithOtherFieldManagers"
+
 	// manifestNotAvailableYetAction indicates that we still need to wait for the manifest to be available.
 	manifestNotAvailableYetAction ApplyAction = "ManifestNotAvailableYet"
 
+	// manifestProgressingAction indicates that the manifest is not available yet but its controller has observed it and
+	// is making progress, e.g. a daemonSet whose pods are becoming available on the nodes.
+	manifestProgressingAction ApplyAction = "ManifestProgressing"
+
+	// manifestFailedAction indicates that the manifest has failed and will not become available, e.g. a failed job.
+	manifestFailedAction ApplyAction = "ManifestFailed"
+
 	// manifestNotTrackableAction indicates that the manifest is already up to date but we don't have a way to track its availabilities.
 	manifestNotTrackableAction ApplyAction = "ManifestNotTrackable"
 
 	// manifestAvailableAction indicates that the manifest is available.
 	manifestAvailableAction ApplyAction = "ManifestAvailable"
+
+	// manifestApplyPendingAction indicates that the manifest is not processed before the member cluster API timeout.
+	manifestApplyPendingAction ApplyAction = "ManifestApplyPending"
+
+	// manifestSkippedAction indicates that the manifest is skipped per the skip-manifest-ordinals annotation of the work.
+	manifestSkippedAction ApplyAction = "ManifestSkipped"
+
+	// manifestBatchPendingAction indicates that the manifest waits for the manifests of the previous batches to be
+	// applied per the batch size of the apply strategy.
+	manifestBatchPendingAction ApplyAction = "ManifestBatchPending"
+
+	// manifestSchemaValidationFailedAction indicates that the manifest is not applied as it does not match the
+	// validation schema of its kind.
+	manifestSchemaValidationFailedAction ApplyAction = ApplyAction(fleetv1beta1.ManifestProcessingApplyResultTypeSchemaValidationFailed)
+
+	// preApplyHookRejectedAction indicates that the manifest is not applied as its pre-apply hook rejected it.
+	preApplyHookRejectedAction ApplyAction = "PreApplyHookRejected"
+
+	// postApplyHookFailedAction indicates that the manifest is applied but its post-apply hook failed.
+	postApplyHookFailedAction ApplyAction = "PostApplyHookFailed"
 )
 
 // applyResult contains the result of a manifest being applied.
@@ -156,6 +310,21 @@ type applyResult struct {
 	generation int64
 	action     ApplyAction
 	applyErr   error
+	// retryHandled is true if the manifest has a retry policy so the apply error does not requeue the work.
+	retryHandled bool
+	// retryCount is the number of times the manifest has been retried per its retry policy.
+	retryCount int
+	// retriesExceeded is true if the manifest has been retried for the maximum number of times.
+	retriesExceeded bool
+	// applyStartedAt and applyCompletedAt are the times the apply call of the manifest started and returned;
+	// they are zero if the manifest is not applied at all.
+	applyStartedAt   time.Time
+	applyCompletedAt time.Time
+	// lastAppliedHash is the hash of the manifest content if it is applied successfully.
+	lastAppliedHash string
+	// skippedAsAlreadyExists is true if the manifest is not applied as it is applied only once and the resource
+	// already exists.
+	skippedAsAlreadyExists bool
 }
 
 // Reconcile implement the control loop logic for Work object.
@@ -164,12 +333,26 @@ func (r *ApplyWorkReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		klog.V(2).InfoS("Work controller is not started yet, requeue the request", "work", req.NamespacedName)
 		return ctrl.Result{RequeueAfter: time.Second * 5}, nil
 	}
+	// the works cannot be reconciled without the hub cluster; they are requeued as soon as the connectivity recovers.
+	if r.hubConnectivity != nil && r.hubConnectivity.isLost() {
+		klog.V(2).InfoS("The hub connectivity is lost, skip reconciling the work", "work", req.NamespacedName)
+		return ctrl.Result{RequeueAfter: hubConnectivityCheckInterval}, nil
+	}
 	startTime := time.Now()
 	klog.V(2).InfoS("ApplyWork reconciliation starts", "work", req.NamespacedName)
+	// workDeleted is set once the work is found deleted so that its series is not recreated after it is dropped.
+	workDeleted := false
 	defer func() {
-		latency := time.Since(startTime).Milliseconds()
-		klog.V(2).InfoS("ApplyWork reconciliation ends", "work", req.NamespacedName, "latency", latency)
+		latency := time.Since(startTime)
+		klog.V(2).InfoS("ApplyWork reconciliation ends", "work", req.NamespacedName, "latency", latency.Milliseconds())
+		if !workDeleted {
+			metrics.WorkReconcileDurationSeconds.WithLabelValues(req.Namespace, req.Name).Observe(latency.Seconds())
+		}
 	}()
+	defer r.profiler.watch(ctx, req.Name)()
+
+	ctx, span := startWorkSpan(ctx, req.NamespacedName)
+	defer span.End()
 
 	// Fetch the work resource
 	work := &fleetv1beta1.Work{}
@@ -177,12 +360,41 @@ func (r *ApplyWorkReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	switch {
 	case apierrors.IsNotFound(err):
 		klog.V(2).InfoS("The work resource is deleted", "work", req.NamespacedName)
+		if r.processedVersions != nil {
+			r.processedVersions.forget(req.NamespacedName)
+		}
+		r.retryBudgets.forget(req.NamespacedName)
+		workDeleted = true
+		deleteWorkMetrics(req.NamespacedName)
 		return ctrl.Result{}, nil
 	case err != nil:
 		klog.ErrorS(err, "Failed to retrieve the work", "work", req.NamespacedName)
 		return ctrl.Result{}, controller.NewAPIServerError(true, err)
 	}
 	logObjRef := klog.KObj(work)
+	// do not process the same version of the work again before it is due, nor an older one.
+	if r.processedVersions != nil {
+		if skip, requeueAfter := r.processedVersions.shouldSkip(work); skip {
+			klog.V(2).InfoS("Skip the work version which is processed already", "work", logObjRef,
+				"resourceVersion", work.ResourceVersion, "generation", work.Generation, "requeueAfter", requeueAfter)
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+		// apply the manifests even if they are unchanged when a forced resync is requested.
+		if r.processedVersions.isForcedResync(work) {
+			klog.V(2).InfoS("A forced resync of the work is requested", "work", logObjRef,
+				"requestedAt", work.GetAnnotations()[fleetv1beta1.ForceResyncAnnotation])
+			ctx = withForcedApply(ctx)
+		}
+	}
+	// the manifests of a compacted work are applied by the consolidated work it is compacted into.
+	if consolidatedName, compacted := compactedInto(work); compacted && work.DeletionTimestamp.IsZero() {
+		return r.handOverCompactedWork(ctx, work, consolidatedName)
+	}
+	// bring back the manifest conditions overflowing into the status pages.
+	if err := workstatuspage.Merge(ctx, r.client, work); err != nil {
+		klog.ErrorS(err, "Failed to merge the status pages of the work", "work", logObjRef)
+		return ctrl.Result{}, controller.NewAPIServerError(true, err)
+	}
 
 	// Handle deleting work, garbage collect the resources
 	if !work.DeletionTimestamp.IsZero() {
@@ -190,6 +402,16 @@ func (r *ApplyWorkReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return r.garbageCollectAppliedWork(ctx, work)
 	}
 
+	// merge the apply strategy defaults configured for the namespace before falling back to the built-in defaults.
+	if err := r.setApplyStrategyDefaultsFromConfigMap(ctx, work); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// inherit the health criteria of the health policy which the work does not set itself.
+	if err := r.setHealthCriteriaFromPolicy(ctx, work); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// set default value so that the following call can skip checking nil
 	// TODO, could be removed once we have the defaulting webhook with fail policy.
 	// Make sure these conditions are met before moving
@@ -197,6 +419,27 @@ func (r *ApplyWorkReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	// * user cannot update/delete the webhook.
 	defaulter.SetDefaultsWork(work)
 
+	// leave the resources and the appliedWork as they are while the work is paused.
+	pending, requeueAfter, err := r.gateOnPause(ctx, work, time.Now())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if pending {
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	// report a single condition on the work instead of the individual apply errors when the member cluster is down.
+	if r.connectivityProber != nil && r.connectivityProber.IsDisconnected() {
+		_, message := r.connectivityProber.Status()
+		klog.V(2).InfoS("The member cluster API server is not reachable, skip applying the work", "work", logObjRef)
+		setMemberClusterUnhealthyCondition(work, message)
+		if err := r.updateWorkStatusIfChanged(ctx, work); err != nil {
+			klog.ErrorS(err, "Failed to update work status", "work", logObjRef)
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: connectivityprobe.DefaultProbeInterval}, nil
+	}
+
 	// ensure that the appliedWork and the finalizer exist
 	appliedWork, err := r.ensureAppliedWork(ctx, work)
 	if err != nil {
@@ -210,8 +453,98 @@ func (r *ApplyWorkReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		BlockOwnerDeletion: ptr.To(false),
 	}
 
-	// apply the manifests to the member cluster
-	results := r.applyManifests(ctx, work.Spec.Workload.Manifests, owner, work.Spec.ApplyStrategy)
+	// process the compressed manifests the same way as the uncompressed ones.
+	if err := r.decompressWork(work); err != nil {
+		return ctrl.Result{}, err
+	}
+	// drop the conditions of the manifests removed from the work before anything relies on them.
+	if err := r.pruneStaleManifestConditions(ctx, work); err != nil {
+		return ctrl.Result{}, err
+	}
+	// apply the manifests stored as manifest store blobs the same way as the inline ones.
+	if err := r.resolveManifestBlobs(ctx, work); err != nil {
+		return ctrl.Result{}, err
+	}
+	ctx = withManifestHooks(withManifestBinaryData(ctx, work), work)
+	ctx, err = r.withPolicyAnnotations(ctx, work)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// give way to the other works if applying this one would put too much load on the member cluster API server.
+	if r.costLimiter != nil && r.costLimiter.shouldDefer(work, appliedWork) {
+		return ctrl.Result{RequeueAfter: deferredWorkRequeueDelay}, nil
+	}
+
+	// only apply the work within its maintenance window.
+	pending, requeueAfter, err = r.gateOnMaintenanceWindow(ctx, work, time.Now())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if pending {
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	// do not apply the workloads whose pods would be rejected for a missing priority class.
+	pending, err = r.gateOnPriorityClass(ctx, work)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if pending {
+		return ctrl.Result{RequeueAfter: time.Minute * 5}, nil
+	}
+
+	// wait for the external systems to set the conditions which the readiness gates of the work require.
+	pending, err = r.gateOnReadinessGates(ctx, work)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if pending {
+		return ctrl.Result{RequeueAfter: readinessGatesRequeueDelay}, nil
+	}
+
+	// only report what the apply would do if the work asks for a dry-run.
+	if isPreApplyDryRun(work) {
+		if err := r.preApplyDryRun(ctx, work, owner); err != nil {
+			return ctrl.Result{}, err
+		}
+		// check the diffs again later as the resources may be corrected in the member cluster.
+		if hasDryRunChanges(work) {
+			return ctrl.Result{RequeueAfter: dryRunDiffRecheckInterval}, nil
+		}
+		return ctrl.Result{}, nil
+	}
+	clearDryRunResults(work)
+
+	// hold the changes back until they are approved if the apply strategy requires approval.
+	if work.Spec.ApplyStrategy.RequireApproval {
+		pending, err := r.gateOnApproval(ctx, work, owner)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if pending {
+			return ctrl.Result{RequeueAfter: time.Minute * 5}, nil
+		}
+	} else {
+		work.Status.PendingApprovalDiff = nil
+	}
+
+	schemas, err := r.loadValidationSchemas(ctx, work)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	r.reportDuplicateManifests(work, manifestTargetNamespaces(work))
+	versions := r.pinManifestVersions(work)
+
+	// apply the manifests to the member cluster within the time limit of the work, up to the current batch if the
+	// work is applied in batches.
+	plan := planRollout(work)
+	applyCtx := r.retryBudgets.withRetryBudget(ctx, work)
+	applyCtx, cancel := context.WithTimeout(withManifestVersions(withLastAppliedHashes(applyCtx, work), versions), memberAPITimeout(work))
+	results := r.applyManifests(applyCtx, work.Spec.Workload.Manifests, owner, work.Spec.ApplyStrategy, propagatedAnnotations(work),
+		manifestTargetNamespaces(work), work.Spec.DefaultPriorityClassName, skippedManifestOrdinals(work), plan.pendingOrdinals(), schemas)
+	cancel()
 
 	// collect the latency from the work update time to now.
 	lastUpdateTime, ok := work.GetAnnotations()[utils.LastWorkUpdateTimeAnnotationKey]
@@ -228,14 +561,41 @@ func (r *ApplyWorkReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		klog.V(2).InfoS("Work has no last update time", "work", work.GetName())
 	}
 
+	// handle the apply errors of the manifests with a retry policy before their previous retry counts are overwritten
+	retryAfter := evaluateRetryPolicies(results, work)
+
+	// keep the audit trail of what changed since the last apply before the status is overwritten
+	recordApplyEvents(work, results)
+	drifts := observeDrifts(work, results, metav1.Now())
+	recordApplyOutcomes(results, drifts)
 	// generate the work condition based on the manifest apply result
 	errs := constructWorkCondition(results, work)
+	updateDriftHistory(work, drifts, r.maxDriftHistoryDepth)
+	reportStuckManifests(work, time.Now())
+	setManifestVersions(work, versions)
+	rolloutInProgress := updateRolloutProgress(work, plan, results)
+	if work.Spec.ApplyStrategy.ReportAdditionalResources {
+		work.Status.AdditionalResources = r.additionalResources(ctx, work, appliedWork, owner, results)
+	} else {
+		work.Status.AdditionalResources = nil
+	}
+	r.reportWorkSize(work)
+	work.Status.ProcessedResourceVersion = work.ResourceVersion
 
 	// update the work status
-	if err = r.client.Status().Update(ctx, work, &client.SubResourceUpdateOptions{}); err != nil {
+	if err = r.updateWorkStatusIfChanged(ctx, work); err != nil {
 		klog.ErrorS(err, "Failed to update work status", "work", logObjRef)
 		return ctrl.Result{}, err
 	}
+	// the status update overwrites the work with the stored one.
+	if err := r.decompressWork(work); err != nil {
+		return ctrl.Result{}, err
+	}
+	// the parts of a split work are applied as an atomic unit tracked by their work group.
+	if err := r.syncWorkGroupStatus(ctx, work); err != nil {
+		return ctrl.Result{}, err
+	}
+	metrics.WorkDesiredStatePercentage.WithLabelValues(work.Namespace, work.Name).Set(float64(work.Status.DesiredStatePercentage))
 	if len(errs) == 0 {
 		klog.InfoS("Successfully applied the work to the cluster", "work", logObjRef)
 		r.recorder.Event(work, v1.EventTypeNormal, "ApplyWorkSucceed", "apply the work successfully")
@@ -257,6 +617,12 @@ func (r *ApplyWorkReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		for _, res := range staleRes {
 			klog.V(2).InfoS("Successfully garbage-collected a stale manifest", work.Kind, logObjRef, "res", res)
 		}
+		recordWorkEvent(work, fleetv1beta1.WorkEventTypeGarbageCollected, nil,
+			fmt.Sprintf("%d resources which are no longer in the work are garbage collected", len(staleRes)))
+		if err = r.updateWorkStatusIfChanged(ctx, work); err != nil {
+			klog.ErrorS(err, "Failed to update work status", "work", logObjRef)
+			return ctrl.Result{}, err
+		}
 	}
 	// update the appliedWork with the new work after the stales are deleted
 	appliedWork.Status.AppliedResources = newRes
@@ -270,25 +636,88 @@ func (r *ApplyWorkReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			"work", logObjRef)
 		return ctrl.Result{}, err
 	}
+	if retryAfter > 0 {
+		klog.V(2).InfoS("Retrying the failed manifests per their retry policies", "work", logObjRef, "retryAfter", retryAfter)
+		return r.requeueProcessedWork(work, retryAfter), nil
+	}
+	if rolloutInProgress {
+		klog.V(2).InfoS("Applying the next batch of the work", "work", logObjRef, "rolloutProgress", work.Status.RolloutProgress)
+		return r.requeueProcessedWork(work, rolloutBatchRequeueDelay), nil
+	}
 	// check if the work is available, if not, we will requeue the work for reconciliation
 	availableCond := meta.FindStatusCondition(work.Status.Conditions, fleetv1beta1.WorkConditionTypeAvailable)
 	if !condition.IsConditionStatusTrue(availableCond, work.Generation) {
 		klog.V(2).InfoS("Work is not available yet, check again", "work", logObjRef, "availableCond", availableCond)
-		return ctrl.Result{RequeueAfter: time.Second * 3}, nil
+		return r.requeueProcessedWork(work, availabilityProbePeriod(work)), nil
 	}
 	// the work is available (might due to not trackable) but we still periodically reconcile to make sure the
 	// member cluster state is in sync with the work in case the resources on the member cluster is removed/changed.
-	return ctrl.Result{RequeueAfter: time.Minute * 5}, nil
+	return r.requeueProcessedWork(work, driftTolerance(work)), nil
+}
+
+// decompressWork decompresses the manifests of the work if its spec is compressed.
+func (r *ApplyWorkReconciler) decompressWork(work *fleetv1beta1.Work) error {
+	if err := compression.DecompressWork(work); err != nil {
+		klog.ErrorS(err, "Failed to decompress the manifests of the work", "work", klog.KObj(work))
+		return controller.NewUserError(err)
+	}
+	return nil
+}
+
+// resolveManifestBlobs replaces the manifests of the work which refer to a manifest store blob with its content.
+func (r *ApplyWorkReconciler) resolveManifestBlobs(ctx context.Context, work *fleetv1beta1.Work) error {
+	if len(work.Spec.Workload.ManifestBlobRefs) == 0 {
+		return nil
+	}
+	if err := manifeststore.Resolve(ctx, r.client, work); err != nil {
+		klog.ErrorS(err, "Failed to resolve the manifest blobs of the work", "work", klog.KObj(work))
+		var statusErr apierrors.APIStatus
+		if errors.As(err, &statusErr) {
+			return controller.NewAPIServerError(false, err)
+		}
+		return controller.NewUserError(err)
+	}
+	return nil
+}
+
+// deleteWorkMetrics deletes the series of the per-work metrics of a work which no longer exists.
+func deleteWorkMetrics(workKey types.NamespacedName) {
+	metrics.WorkReconcileDurationSeconds.DeleteLabelValues(workKey.Namespace, workKey.Name)
+	metrics.WorkDesiredStatePercentage.DeleteLabelValues(workKey.Namespace, workKey.Name)
+	metrics.WorkRolloutProgressPercentage.DeleteLabelValues(workKey.Namespace, workKey.Name)
+	metrics.WorkSpecSizeBytes.DeleteLabelValues(workKey.Namespace, workKey.Name)
+	metrics.WorkStatusSizeBytes.DeleteLabelValues(workKey.Namespace, workKey.Name)
 }
 
 // garbageCollectAppliedWork deletes the appliedWork and all the manifests associated with it from the cluster.
 func (r *ApplyWorkReconciler) garbageCollectAppliedWork(ctx context.Context, work *fleetv1beta1.Work) (ctrl.Result, error) {
 	deletePolicy := metav1.DeletePropagationBackground
+	if r.costLimiter != nil {
+		r.costLimiter.forget(work)
+	}
 	if !controllerutil.ContainsFinalizer(work, fleetv1beta1.WorkFinalizer) {
 		return ctrl.Result{}, nil
 	}
+	// only list the resources to delete until the dry-run annotation is removed.
+	if isGCDryRun(work) {
+		return ctrl.Result{}, r.dryRunGarbageCollection(ctx, work)
+	}
+	// the work is deleted along with its namespace, e.g., when the member cluster is decommissioned, and the member
+	// cluster may no longer be accessible; release the work right away instead of blocking the namespace deletion.
+	if r.isWorkNamespaceTerminating(ctx, work) {
+		klog.InfoS("Skip garbage collecting the appliedWork as the work namespace is terminating", "work", klog.KObj(work))
+		controllerutil.RemoveFinalizer(work, fleetv1beta1.WorkFinalizer)
+		return ctrl.Result{}, r.client.Update(ctx, work, &client.UpdateOptions{})
+	}
+	// leave the resources applied by the work in the member cluster per its termination policy.
+	if isOrphanOnDelete(work) {
+		if err := r.orphanAppliedResources(ctx, work); err != nil {
+			return ctrl.Result{}, err
+		}
+		controllerutil.RemoveFinalizer(work, fleetv1beta1.WorkFinalizer)
+		return ctrl.Result{}, r.client.Update(ctx, work, &client.UpdateOptions{})
+	}
 	// delete the appliedWork which will remove all the manifests associated with it
-	// TODO: allow orphaned manifest
 	appliedWork := fleetv1beta1.AppliedWork{
 		ObjectMeta: metav1.ObjectMeta{Name: work.Name},
 	}
@@ -301,11 +730,26 @@ func (r *ApplyWorkReconciler) garbageCollectAppliedWork(ctx context.Context, wor
 		return ctrl.Result{}, err
 	default:
 		klog.InfoS("Successfully deleted the appliedWork", "appliedWork", work.Name)
+		// the resources applied by the work are deleted along with the appliedWork which owns them.
+		metrics.WorkGarbageCollectedResourcesTotal.WithLabelValues(fleetv1beta1.GroupVersion.Group, fleetv1beta1.GroupVersion.Version,
+			fleetv1beta1.AppliedWorkKind).Inc()
 	}
 	controllerutil.RemoveFinalizer(work, fleetv1beta1.WorkFinalizer)
 	return ctrl.Result{}, r.client.Update(ctx, work, &client.UpdateOptions{})
 }
 
+// isWorkNamespaceTerminating returns true if the namespace of the work on the hub cluster is being deleted.
+// The member cluster is allowed to read its namespace by the cluster role the hub creates when the cluster joins.
+// It returns false if the namespace cannot be read so that the appliedWork is garbage collected as usual.
+func (r *ApplyWorkReconciler) isWorkNamespaceTerminating(ctx context.Context, work *fleetv1beta1.Work) bool {
+	var namespace v1.Namespace
+	if err := r.client.Get(ctx, types.NamespacedName{Name: work.Namespace}, &namespace); err != nil {
+		klog.V(2).InfoS("Failed to get the work namespace, assume it is not terminating", "work", klog.KObj(work), "error", err)
+		return false
+	}
+	return !namespace.DeletionTimestamp.IsZero()
+}
+
 // ensureAppliedWork makes sure that an associated appliedWork and a finalizer on the work resource exsits on the cluster.
 func (r *ApplyWorkReconciler) ensureAppliedWork(ctx context.Context, work *fleetv1beta1.Work) (*fleetv1beta1.AppliedWork, error) {
 	workRef := klog.KObj(work)
@@ -349,16 +793,64 @@ func (r *ApplyWorkReconciler) ensureAppliedWork(ctx context.Context, work *fleet
 }
 
 // applyManifests processes a given set of Manifests by: setting ownership, validating the manifest, and passing it on for application to the cluster.
-func (r *ApplyWorkReconciler) applyManifests(ctx context.Context, manifests []fleetv1beta1.Manifest, owner metav1.OwnerReference, applyStrategy *fleetv1beta1.ApplyStrategy) []applyResult {
-	var appliedObj *unstructured.Unstructured
-
+// The propagated annotations are added to every manifest before it is applied, and the manifests with a target
+// namespace are applied to that namespace instead of their own. The manifests with the skipped or batch pending
+// ordinals are not applied, neither are the manifests which duplicate a manifest before them.
+func (r *ApplyWorkReconciler) applyManifests(ctx context.Context, manifests []fleetv1beta1.Manifest, owner metav1.OwnerReference,
+	applyStrategy *fleetv1beta1.ApplyStrategy, annotations map[string]string, targetNamespaces map[int]string, priorityClassName string,
+	skipped, batchPending map[int]bool, schemas manifestSchemas) []applyResult {
 	results := make([]applyResult, len(manifests))
-	for index, manifest := range manifests {
+	duplicates := workdedup.Duplicates(manifests, targetNamespaces)
+	applyManifest := func(index int) applyResult {
+		manifest := manifests[index]
+		// leave the rest of the manifests to the next reconcile once the time limit is reached.
+		if ctx.Err() != nil {
+			return r.pendingApplyResult(index, manifest)
+		}
+		if skipped[index] {
+			klog.V(2).InfoS("Skip applying the manifest per the work annotation", "ordinal", index)
+			return r.skippedApplyResult(index, manifest)
+		}
+		if batchPending[index] {
+			return r.batchPendingApplyResult(index, manifest)
+		}
+		if version := blockedRollback(ctx, index); version != nil {
+			klog.V(2).InfoS("Skip applying the manifest which goes back to an earlier version", "ordinal", index,
+				"version", version.pinned, "rollbackTo", version.rollbackTo)
+			return r.rollbackBlockedApplyResult(index, manifest, version)
+		}
 		var result applyResult
-		gvr, rawObj, err := r.decodeManifest(manifest)
+		manifestCtx, span := startManifestSpan(ctx, index)
+		var gvr schema.GroupVersionResource
+		var rawObj, appliedObj *unstructured.Unstructured
+		err := checkManifestSize(index, manifest, r.maxManifestSizeBytes)
+		if err == nil {
+			gvr, rawObj, err = r.decodeManifest(manifest)
+		}
+		if err == nil {
+			err = binarydata.Merge(rawObj, manifestBinaryData(ctx, index))
+		}
+		if err == nil {
+			r.sanitizer.Sanitize(rawObj)
+			err = schemas.validate(rawObj)
+		}
+		if err == nil {
+			err = injectPriorityClassName(rawObj, priorityClassName)
+		}
+		if err == nil && applyStrategy.ShadowApply {
+			err = r.redirectToShadowNamespace(manifestCtx, rawObj, owner)
+		}
 		switch {
 		case err != nil:
 			result.applyErr = err
+			var invalidErr *schemaValidationError
+			if errors.As(err, &invalidErr) {
+				result.action = manifestSchemaValidationFailedAction
+			}
+			var tooLargeErr *manifestTooLargeError
+			if errors.As(err, &tooLargeErr) {
+				result.action = manifestTooLargeAction
+			}
 			result.identifier = fleetv1beta1.WorkResourceIdentifier{
 				Ordinal: index,
 			}
@@ -372,25 +864,124 @@ func (r *ApplyWorkReconciler) applyManifests(ctx context.Context, manifests []fl
 
 		default:
 			addOwnerRef(owner, rawObj)
-			appliedObj, result.action, result.applyErr = r.applyUnstructuredAndTrackAvailability(ctx, gvr, rawObj, applyStrategy)
+			addPolicyAnnotations(ctx, rawObj)
+			addPropagatedAnnotations(rawObj, annotations)
+			manifestNamespace := rawObj.GetNamespace()
+			if targetNamespace, ok := targetNamespaces[index]; ok {
+				rawObj.SetNamespace(targetNamespace)
+			}
+			contentHash := manifestContentHash(manifest)
+			if contentHash == lastAppliedHash(ctx, index) {
+				manifestCtx = withUnchangedManifest(manifestCtx)
+			}
+			unlock := r.lockResource(rawObj)
+			appliedObj, result.action, result.skippedAsAlreadyExists, result.applyErr = r.skipExistingResource(manifestCtx, gvr, rawObj, applyStrategy)
+			if !result.skippedAsAlreadyExists && result.applyErr == nil {
+				result.applyStartedAt = time.Now()
+				appliedObj, result.action, result.applyErr = r.applyWithRetryBudget(manifestCtx, index, gvr, rawObj, applyStrategy)
+				result.applyCompletedAt = time.Now()
+				gvk := rawObj.GroupVersionKind()
+				metrics.ManifestApplyDurationMilliseconds.WithLabelValues(gvk.Group, gvk.Version, gvk.Kind).
+					Observe(float64(result.applyCompletedAt.Sub(result.applyStartedAt).Milliseconds()))
+			}
+			unlock()
 			result.identifier = buildResourceIdentifier(index, rawObj, gvr)
+			// the manifest is identified by its own namespace; the appliedWork tracks the namespace it is routed to.
+			result.identifier.Namespace = manifestNamespace
+			if result.applyErr != nil && ctx.Err() != nil {
+				// the apply call is cut off by the time limit so the manifest is left pending.
+				result = applyResult{identifier: result.identifier, action: manifestApplyPendingAction}
+			}
 			logObjRef := klog.ObjectRef{
 				Name:      result.identifier.Name,
 				Namespace: result.identifier.Namespace,
 			}
-			if result.applyErr == nil {
+			switch {
+			case result.action == manifestApplyPendingAction:
+				klog.V(2).InfoS("Apply manifest timed out, leave it pending", "gvr", gvr, "manifest", logObjRef)
+			case result.skippedAsAlreadyExists:
+				// the resource is not written so the manifest is not recorded as applied.
+				result.generation = appliedObj.GetGeneration()
+				klog.V(2).InfoS("Apply manifest skipped as the resource already exists", "gvr", gvr, "manifest", logObjRef,
+					"action", result.action, "applyStrategy", applyStrategy)
+			case result.applyErr == nil:
 				result.generation = appliedObj.GetGeneration()
+				result.lastAppliedHash = contentHash
 				klog.V(2).InfoS("Apply manifest succeeded", "gvr", gvr, "manifest", logObjRef,
 					"action", result.action, "applyStrategy", applyStrategy, "new ObservedGeneration", result.generation)
-			} else {
+			default:
 				klog.ErrorS(result.applyErr, "manifest upsert failed", "gvr", gvr, "manifest", logObjRef)
 			}
 		}
-		results[index] = result
+		endManifestSpan(span, rawObj, result)
+		return result
+	}
+
+	// the manifests of a wave do not depend on each other so they are applied concurrently, while the waves are
+	// applied one after another; the results are kept by ordinal so their order does not depend on the timing.
+	for _, wave := range applyWaves(manifests) {
+		var g errgroup.Group
+		g.SetLimit(max(r.workerPoolSize, 1))
+		for _, index := range wave {
+			if _, ok := duplicates[index]; ok {
+				continue
+			}
+			g.Go(func() error {
+				results[index] = applyManifest(index)
+				return nil
+			})
+		}
+		_ = g.Wait()
+	}
+	// the duplicates report the result of the manifest they duplicate as they describe the same resource.
+	for index, kept := range duplicates {
+		results[index] = results[kept]
+		results[index].identifier.Ordinal = index
 	}
 	return results
 }
 
+// applyOrder returns the ordinals of the manifests in the order they are applied in, so that every resource is applied
+// after the resources it depends on. The manifests which cannot be decoded are left after the others as they fail
+// to apply anyway.
+func applyOrder(manifests []fleetv1beta1.Manifest) []int {
+	order := make([]int, 0, len(manifests))
+	for _, wave := range applyWaves(manifests) {
+		order = append(order, wave...)
+	}
+	return order
+}
+
+// applyWaves returns the ordinals of the manifests, in the order they are applied in, split into consecutive waves
+// whose manifests do not depend on each other, so that the manifests of a wave can be applied concurrently once the
+// waves before it are applied. The manifests which cannot be decoded form the last wave.
+func applyWaves(manifests []fleetv1beta1.Manifest) [][]int {
+	ordinals := make(map[*unstructured.Unstructured]int, len(manifests))
+	objs := make([]*unstructured.Unstructured, 0, len(manifests))
+	var undecodable []int
+	for index, manifest := range manifests {
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(manifest.Raw); err != nil {
+			undecodable = append(undecodable, index)
+			continue
+		}
+		ordinals[obj] = index
+		objs = append(objs, obj)
+	}
+	var waves [][]int
+	for _, objWave := range manifestorder.SplitIntoWaves(manifestorder.TopoSortManifests(objs)) {
+		wave := make([]int, len(objWave))
+		for i, obj := range objWave {
+			wave[i] = ordinals[obj]
+		}
+		waves = append(waves, wave)
+	}
+	if len(undecodable) > 0 {
+		waves = append(waves, undecodable)
+	}
+	return waves
+}
+
 // Decodes the manifest into usable structs.
 func (r *ApplyWorkReconciler) decodeManifest(manifest fleetv1beta1.Manifest) (schema.GroupVersionResource, *unstructured.Unstructured, error) {
 	unstructuredObj := &unstructured.Unstructured{}
@@ -407,6 +998,20 @@ func (r *ApplyWorkReconciler) decodeManifest(manifest fleetv1beta1.Manifest) (sc
 	return mapping.Resource, unstructuredObj, nil
 }
 
+// lockResource acquires the lock of the resource which the manifest is applied to, so that the works sharing the
+// resource do not apply it at the same time. It returns the function which releases the lock.
+func (r *ApplyWorkReconciler) lockResource(manifestObj *unstructured.Unstructured) func() {
+	// the resources with generated names are created anew on every apply so they are never shared.
+	if r.resourceLocks == nil || manifestObj.GetName() == "" {
+		return func() {}
+	}
+	return r.resourceLocks.Lock(resourcelock.Key{
+		GVK:       manifestObj.GroupVersionKind(),
+		Namespace: manifestObj.GetNamespace(),
+		Name:      manifestObj.GetName(),
+	})
+}
+
 // applyUnstructuredAndTrackAvailability determines if an unstructured manifest object can & should be applied. It first validates
 // the size of the last modified annotation of the manifest, it removes the annotation if the size crosses the annotation size threshold
 // and then creates/updates the resource on the cluster using server side apply instead of three-way merge patch.
@@ -420,12 +1025,20 @@ func (r *ApplyWorkReconciler) applyUnstructuredAndTrackAvailability(ctx context.
 		return nil, errorApplyAction, controller.NewUserError(err)
 	}
 
+	prevObj, err := r.getRollingRestartBaseline(ctx, gvr, manifestObj, applyStrategy)
+	if err != nil {
+		return nil, errorApplyAction, err
+	}
 	curObj, applyActionRes, err := applier.ApplyUnstructured(ctx, applyStrategy, gvr, manifestObj)
 	if err != nil {
 		klog.ErrorS(err, "Failed to apply the manifest", "gvr", gvr, "manifest", objManifest, "applyStrategyType", applyStrategy.Type)
 		return nil, applyActionRes, err // do not overwrite the applyActionRes
 	}
 	klog.V(2).InfoS("Applied the manifest", "gvr", gvr, "manifest", objManifest, "applyStrategyType", applyStrategy.Type)
+	curObj = r.cleanUpStaleFieldManagement(ctx, gvr, curObj, applyStrategy.Type, applyActionRes)
+	if curObj, err = r.triggerRollingRestart(ctx, gvr, prevObj, curObj); err != nil {
+		return nil, errorApplyAction, err
+	}
 
 	// the manifest is already up to date, we just need to track its availability
 	applyActionRes, err = trackResourceAvailability(gvr, curObj)
@@ -446,6 +1059,12 @@ func trackResourceAvailability(gvr schema.GroupVersionResource, curObj *unstruct
 	case utils.ServiceGVR:
 		return trackServiceAvailability(curObj)
 
+	case utils.JobGVR:
+		return trackJobAvailability(curObj)
+
+	case utils.CronJobGVR:
+		return trackCronJobAvailability(curObj)
+
 	default:
 		if isDataResource(gvr) {
 			klog.V(2).InfoS("Data resources are available immediately", "gvr", gvr, "resource", klog.KObj(curObj))
@@ -481,12 +1100,14 @@ func trackStatefulSetAvailability(curObj *unstructured.Unstructured) (ApplyActio
 		return errorApplyAction, controller.NewUnexpectedBehaviorError(err)
 	}
 	// a statefulSet is available if all the replicas are available and the currentReplicas is equal to the updatedReplicas
-	// which means there is no more update in progress.
+	// which means there is no more update in progress. The replicas must be the required ones as well, so that the
+	// statefulSet which is still scaling down is not available yet.
 	requiredReplicas := int32(1)
 	if statefulSet.Spec.Replicas != nil {
 		requiredReplicas = *statefulSet.Spec.Replicas
 	}
 	if statefulSet.Status.ObservedGeneration == statefulSet.Generation &&
+		statefulSet.Status.Replicas == requiredReplicas &&
 		statefulSet.Status.AvailableReplicas == requiredReplicas &&
 		statefulSet.Status.CurrentReplicas == statefulSet.Status.UpdatedReplicas &&
 		statefulSet.Status.CurrentRevision == statefulSet.Status.UpdateRevision {
@@ -502,15 +1123,67 @@ func trackDaemonSetAvailability(curObj *unstructured.Unstructured) (ApplyAction,
 	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(curObj.Object, &daemonSet); err != nil {
 		return errorApplyAction, controller.NewUnexpectedBehaviorError(err)
 	}
+	if daemonSet.Status.ObservedGeneration != daemonSet.Generation {
+		klog.V(2).InfoS("Still need to wait for daemonSet to be observed", "daemonSet", klog.KObj(curObj))
+		return manifestNotAvailableYetAction, nil
+	}
 	// a daemonSet is available if all the desired replicas (equal to all node suit for this Daemonset)
 	// are available and the currentReplicas is equal to the updatedReplicas which means there is no more update in progress.
-	if daemonSet.Status.ObservedGeneration == daemonSet.Generation &&
-		daemonSet.Status.NumberAvailable == daemonSet.Status.DesiredNumberScheduled &&
+	if daemonSet.Status.NumberAvailable >= daemonSet.Status.DesiredNumberScheduled &&
 		daemonSet.Status.CurrentNumberScheduled == daemonSet.Status.UpdatedNumberScheduled {
 		klog.V(2).InfoS("DaemonSet is available", "daemonSet", klog.KObj(curObj))
 		return manifestAvailableAction, nil
 	}
-	klog.V(2).InfoS("Still need to wait for daemonSet to be available", "daemonSet", klog.KObj(curObj))
+	klog.V(2).InfoS("DaemonSet is progressing", "daemonSet", klog.KObj(curObj),
+		"numberAvailable", daemonSet.Status.NumberAvailable, "desiredNumberScheduled", daemonSet.Status.DesiredNumberScheduled)
+	return manifestProgressingAction, nil
+}
+
+func trackJobAvailability(curObj *unstructured.Unstructured) (ApplyAction, error) {
+	var job batchv1.Job
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(curObj.Object, &job); err != nil {
+		return errorApplyAction, controller.NewUnexpectedBehaviorError(err)
+	}
+	// a job is available once it completes, and will never be if it fails.
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == batchv1.JobFailed && cond.Status == v1.ConditionTrue {
+			klog.V(2).InfoS("Job has failed", "job", klog.KObj(curObj), "reason", cond.Reason)
+			return manifestFailedAction, nil
+		}
+	}
+	if job.Status.CompletionTime != nil {
+		klog.V(2).InfoS("Job is available", "job", klog.KObj(curObj))
+		return manifestAvailableAction, nil
+	}
+	if job.Status.Active > 0 {
+		klog.V(2).InfoS("Job is progressing", "job", klog.KObj(curObj), "active", job.Status.Active)
+		return manifestProgressingAction, nil
+	}
+	klog.V(2).InfoS("Still need to wait for job to be available", "job", klog.KObj(curObj))
+	return manifestNotAvailableYetAction, nil
+}
+
+func trackCronJobAvailability(curObj *unstructured.Unstructured) (ApplyAction, error) {
+	var cronJob batchv1.CronJob
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(curObj.Object, &cronJob); err != nil {
+		return errorApplyAction, controller.NewUnexpectedBehaviorError(err)
+	}
+	// a cronJob is available if a job has succeeded within its last schedule window, i.e. the job of the last schedule
+	// has succeeded, or it is still running and the job of an earlier schedule has succeeded.
+	status := cronJob.Status
+	switch {
+	case status.LastSuccessfulTime == nil && len(status.Active) > 0:
+		klog.V(2).InfoS("CronJob is progressing", "cronJob", klog.KObj(curObj), "active", len(status.Active))
+		return manifestProgressingAction, nil
+	case status.LastSuccessfulTime == nil:
+		klog.V(2).InfoS("Still need to wait for cronJob to succeed", "cronJob", klog.KObj(curObj))
+		return manifestNotAvailableYetAction, nil
+	case status.LastScheduleTime == nil || !status.LastSuccessfulTime.Before(status.LastScheduleTime) || len(status.Active) > 0:
+		klog.V(2).InfoS("CronJob is available", "cronJob", klog.KObj(curObj))
+		return manifestAvailableAction, nil
+	}
+	klog.V(2).InfoS("The job of the last schedule of the cronJob has not succeeded", "cronJob", klog.KObj(curObj),
+		"lastScheduleTime", status.LastScheduleTime, "lastSuccessfulTime", status.LastSuccessfulTime)
 	return manifestNotAvailableYetAction, nil
 }
 
@@ -574,24 +1247,50 @@ func isDataResource(gvr schema.GroupVersionResource) bool {
 // TODO: special handle no results
 func constructWorkCondition(results []applyResult, work *fleetv1beta1.Work) []error {
 	var errs []error
+	now := metav1.Now()
+	// keep the time each ordinal first appeared in the work.
+	createdAt := make(map[int]*metav1.Time, len(work.Status.ManifestConditions))
+	for _, manifestCond := range work.Status.ManifestConditions {
+		if manifestCond.ManifestCreatedAt != nil {
+			createdAt[manifestCond.Identifier.Ordinal] = manifestCond.ManifestCreatedAt
+		}
+	}
 	// Update manifestCondition based on the results.
 	manifestConditions := make([]fleetv1beta1.ManifestCondition, len(results))
 	for index, result := range results {
-		if result.applyErr != nil {
+		if result.applyErr != nil && !result.retryHandled {
 			errs = append(errs, result.applyErr)
 		}
 		newConditions := buildManifestCondition(result.applyErr, result.action, result.generation)
 		manifestCondition := fleetv1beta1.ManifestCondition{
-			Identifier: result.identifier,
+			Identifier:        result.identifier,
+			RetryCount:        result.retryCount,
+			ManifestCreatedAt: createdAt[result.identifier.Ordinal],
 		}
+		if manifestCondition.ManifestCreatedAt == nil {
+			manifestCondition.ManifestCreatedAt = &now
+		}
+		setApplyTiming(&manifestCondition, result)
 		existingManifestCondition := findManifestConditionByIdentifier(result.identifier, work.Status.ManifestConditions)
 		if existingManifestCondition != nil {
 			manifestCondition.Conditions = existingManifestCondition.Conditions
+			manifestCondition.DriftHistory = existingManifestCondition.DriftHistory
 		}
+		setLastAppliedHash(&manifestCondition, existingManifestCondition, result)
 		// merge the status of the manifest condition
 		for _, condition := range newConditions {
 			meta.SetStatusCondition(&manifestCondition.Conditions, condition)
 		}
+		if result.retriesExceeded {
+			applyCond := meta.FindStatusCondition(manifestCondition.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+			applyCond.Reason = MaxRetriesExceededReason
+		}
+		if result.skippedAsAlreadyExists {
+			applyCond := meta.FindStatusCondition(manifestCondition.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+			applyCond.Reason = string(fleetv1beta1.ManifestProcessingApplyResultTypeSkippedAsAlreadyExists)
+			applyCond.Message = manifestSkippedAsAlreadyExistsMessage
+		}
+		appendApplyHistory(&manifestCondition, existingManifestCondition, result)
 		manifestConditions[index] = manifestCondition
 	}
 
@@ -601,9 +1300,104 @@ func constructWorkCondition(results []applyResult, work *fleetv1beta1.Work) []er
 	for _, condition := range newWorkConditions {
 		meta.SetStatusCondition(&work.Status.Conditions, condition)
 	}
+	updateLastGoodStatus(work)
+	work.Status.DesiredStatePercentage = desiredStatePercentage(manifestConditions)
 	return errs
 }
 
+// setApplyTiming records the start and completion times and the duration of the apply call of the manifest.
+func setApplyTiming(manifestCondition *fleetv1beta1.ManifestCondition, result applyResult) {
+	if result.applyStartedAt.IsZero() {
+		return
+	}
+	startedAt := metav1.NewTime(result.applyStartedAt)
+	completedAt := metav1.NewTime(result.applyCompletedAt)
+	manifestCondition.ApplyStartedAt = &startedAt
+	manifestCondition.ApplyCompletedAt = &completedAt
+	manifestCondition.ApplyDurationMs = result.applyCompletedAt.Sub(result.applyStartedAt).Milliseconds()
+}
+
+// appendApplyHistory carries over the apply history of the manifest and, if the manifest was applied, appends the
+// result of the apply call, displacing the oldest entries once there are more than MaxManifestApplyHistory of them.
+func appendApplyHistory(manifestCondition, existing *fleetv1beta1.ManifestCondition, result applyResult) {
+	var history []fleetv1beta1.ApplyHistoryEntry
+	if existing != nil {
+		history = existing.ApplyHistory
+	}
+	if !result.applyCompletedAt.IsZero() {
+		entry := fleetv1beta1.ApplyHistoryEntry{ApplyTime: metav1.NewTime(result.applyCompletedAt)}
+		if applyCond := meta.FindStatusCondition(manifestCondition.Conditions, fleetv1beta1.WorkConditionTypeApplied); applyCond != nil {
+			entry.Result = fleetv1beta1.ManifestProcessingApplyResultType(applyCond.Reason)
+		}
+		if result.applyErr == nil {
+			entry.ManifestGeneration = result.generation
+		}
+		history = append(history, entry)
+		if overflow := len(history) - fleetv1beta1.MaxManifestApplyHistory; overflow > 0 {
+			// copy the kept entries so that the displaced ones are not retained by the backing array.
+			history = append([]fleetv1beta1.ApplyHistoryEntry(nil), history[overflow:]...)
+		}
+	}
+	manifestCondition.ApplyHistory = history
+}
+
+// updateLastGoodStatus takes a snapshot of the work status if the work is both applied and available; otherwise the
+// last good status is kept as is.
+func updateLastGoodStatus(work *fleetv1beta1.Work) {
+	appliedCond := meta.FindStatusCondition(work.Status.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+	availableCond := meta.FindStatusCondition(work.Status.Conditions, fleetv1beta1.WorkConditionTypeAvailable)
+	if !condition.IsConditionStatusTrue(appliedCond, work.Generation) || !condition.IsConditionStatusTrue(availableCond, work.Generation) {
+		return
+	}
+	snapshot := &fleetv1beta1.WorkStatusSnapshot{
+		ObservedGeneration: work.Generation,
+		Conditions:         make([]metav1.Condition, len(work.Status.Conditions)),
+		ManifestConditions: make([]fleetv1beta1.ManifestCondition, len(work.Status.ManifestConditions)),
+	}
+	for i := range work.Status.Conditions {
+		work.Status.Conditions[i].DeepCopyInto(&snapshot.Conditions[i])
+	}
+	for i := range work.Status.ManifestConditions {
+		work.Status.ManifestConditions[i].DeepCopyInto(&snapshot.ManifestConditions[i])
+	}
+	work.Status.LastGoodStatus = snapshot
+}
+
+// desiredStatePercentage returns the percentage of the manifests that are both applied and available.
+// A work without any manifest is always in its desired state.
+func desiredStatePercentage(manifestConditions []fleetv1beta1.ManifestCondition) float32 {
+	if len(manifestConditions) == 0 {
+		return 100
+	}
+	inDesiredState := 0
+	for _, manifestCond := range manifestConditions {
+		if meta.IsStatusConditionTrue(manifestCond.Conditions, fleetv1beta1.WorkConditionTypeApplied) &&
+			meta.IsStatusConditionTrue(manifestCond.Conditions, fleetv1beta1.WorkConditionTypeAvailable) {
+			inDesiredState++
+		}
+	}
+	return float32(inDesiredState) * 100 / float32(len(manifestConditions))
+}
+
+// setMemberClusterUnhealthyCondition sets the work conditions when the member cluster API server is not reachable.
+// The manifest conditions are left untouched as none of the manifests is applied.
+func setMemberClusterUnhealthyCondition(work *fleetv1beta1.Work, message string) {
+	meta.SetStatusCondition(&work.Status.Conditions, metav1.Condition{
+		Type:               fleetv1beta1.WorkConditionTypeApplied,
+		Status:             metav1.ConditionFalse,
+		Reason:             MemberClusterUnhealthyReason,
+		Message:            fmt.Sprintf("The member cluster API server is not reachable: %s", message),
+		ObservedGeneration: work.Generation,
+	})
+	meta.SetStatusCondition(&work.Status.Conditions, metav1.Condition{
+		Type:               fleetv1beta1.WorkConditionTypeAvailable,
+		Status:             metav1.ConditionUnknown,
+		Reason:             MemberClusterUnhealthyReason,
+		Message:            "Work is not applied as the member cluster API server is not reachable",
+		ObservedGeneration: work.Generation,
+	})
+}
+
 // Join starts to reconcile
 func (r *ApplyWorkReconciler) Join(_ context.Context) error {
 	if !r.joined.Load() {
@@ -652,18 +1446,39 @@ func (r *ApplyWorkReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			HubClient:          r.client,
 			WorkNamespace:      r.workNameSpace,
 			SpokeDynamicClient: r.spokeDynamicClient,
+			FieldManager:       r.fieldManager,
 		},
 		fleetv1beta1.ApplyStrategyTypeClientSideApply: &ClientSideApplier{
 			HubClient:          r.client,
 			WorkNamespace:      r.workNameSpace,
 			SpokeDynamicClient: r.spokeDynamicClient,
+			FieldManager:       r.fieldManager,
 		},
 	}
+	// check once on startup that the resources applied before the restart are still in place.
+	if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		// a failed check must not stop the agent.
+		_, _ = r.checkAppliedWorkIntegrity(ctx)
+		return nil
+	})); err != nil {
+		return err
+	}
+	hubDiscoveryClient, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		return err
+	}
+	r.hubConnectivity = newHubConnectivityMonitor(hubDiscoveryClient.RESTClient(), mgr.GetCache())
+	if err := mgr.Add(r.hubConnectivity); err != nil {
+		return err
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		WithOptions(ctrloption.Options{
 			MaxConcurrentReconciles: r.concurrency,
 		}).
-		For(&fleetv1beta1.Work{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// the annotation changes are watched as well so that the propagated annotations are updated promptly.
+		For(&fleetv1beta1.Work{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
+		// the works are requeued as soon as the hub connectivity recovers.
+		WatchesRawSource(source.Channel(r.hubConnectivity.requeue, &handler.EnqueueRequestForObject{})).
 		Complete(r)
 }
 
@@ -676,6 +1491,8 @@ func computeManifestHash(obj *unstructured.Unstructured) (string, error) {
 	if annotation != nil {
 		delete(annotation, fleetv1beta1.ManifestHashAnnotation)
 		delete(annotation, fleetv1beta1.LastAppliedConfigAnnotation)
+		// the secret rotation is tracked separately so that it can force a re-apply on its own
+		delete(annotation, fleetv1beta1.SecretRotationGenerationAnnotation)
 		if len(annotation) == 0 {
 			manifest.SetAnnotations(nil)
 		} else {
@@ -695,6 +1512,30 @@ func computeManifestHash(obj *unstructured.Unstructured) (string, error) {
 	return resource.HashOf(manifest.Object)
 }
 
+// secretRotationGeneration returns the rotation generation recorded on a secret object.
+// It returns 0 if the object is not a secret or does not carry a valid rotation generation annotation.
+func secretRotationGeneration(obj *unstructured.Unstructured) int {
+	gvk := obj.GroupVersionKind()
+	if gvk.Group != utils.SecretGVR.Group || gvk.Kind != "Secret" {
+		return 0
+	}
+	value, ok := obj.GetAnnotations()[fleetv1beta1.SecretRotationGenerationAnnotation]
+	if !ok {
+		return 0
+	}
+	generation, err := strconv.Atoi(value)
+	if err != nil || generation < 0 {
+		klog.V(2).InfoS("Ignore the invalid secret rotation generation", "secret", klog.KObj(obj), "rotationGeneration", value)
+		return 0
+	}
+	return generation
+}
+
+// isSecretRotated returns true if the manifest carries a newer secret rotation generation than the current object.
+func isSecretRotated(manifestObj, curObj *unstructured.Unstructured) bool {
+	return secretRotationGeneration(manifestObj) > secretRotationGeneration(curObj)
+}
+
 // isManifestManagedByWork determines if an object is managed by the work controller.
 func isManifestManagedByWork(ownerRefs []metav1.OwnerReference) bool {
 	if len(ownerRefs) == 0 {
@@ -780,6 +1621,36 @@ func buildManifestCondition(err error, action ApplyAction, observedGeneration in
 		ObservedGeneration: observedGeneration,
 	}
 
+	if action == manifestApplyPendingAction {
+		applyCondition.Status = metav1.ConditionUnknown
+		applyCondition.Reason = ManifestApplyPendingReason
+		applyCondition.Message = "Manifest is not processed before the member cluster API timeout"
+		availableCondition.Status = metav1.ConditionUnknown
+		availableCondition.Reason = ManifestApplyPendingReason
+		availableCondition.Message = "Manifest is not applied yet"
+		return []metav1.Condition{applyCondition, availableCondition}
+	}
+
+	if action == manifestSkippedAction {
+		applyCondition.Status = metav1.ConditionUnknown
+		applyCondition.Reason = ManifestSkippedByAnnotationReason
+		applyCondition.Message = fmt.Sprintf("Manifest is skipped per the %s annotation of the work", WorkSkipManifestOrdinalsAnnotation)
+		availableCondition.Status = metav1.ConditionUnknown
+		availableCondition.Reason = ManifestSkippedByAnnotationReason
+		availableCondition.Message = "Manifest is not applied"
+		return []metav1.Condition{applyCondition, availableCondition}
+	}
+
+	if action == manifestBatchPendingAction {
+		applyCondition.Status = metav1.ConditionUnknown
+		applyCondition.Reason = ManifestBatchPendingReason
+		applyCondition.Message = "Manifest waits for the manifests of the previous batches to be applied"
+		availableCondition.Status = metav1.ConditionUnknown
+		availableCondition.Reason = ManifestBatchPendingReason
+		availableCondition.Message = "Manifest is not applied yet"
+		return []metav1.Condition{applyCondition, availableCondition}
+	}
+
 	if err != nil {
 		applyCondition.Status = metav1.ConditionFalse
 		switch action {
@@ -787,6 +1658,20 @@ func buildManifestCondition(err error, action ApplyAction, observedGeneration in
 			applyCondition.Reason = ApplyConflictBetweenPlacementsReason
 		case manifestAlreadyOwnedByOthers:
 			applyCondition.Reason = ManifestsAlreadyOwnedByOthersReason
+		case applyConflictWithOtherFieldManagers:
+			applyCondition.Reason = ApplyConflictWithOtherFieldManagersReason
+		case manifestSchemaValidationFailedAction:
+			applyCondition.Reason = string(fleetv1beta1.ManifestProcessingApplyResultTypeSchemaValidationFailed)
+		case manifestTooLargeAction:
+			applyCondition.Reason = string(fleetv1beta1.ManifestProcessingApplyResultTypeTooLarge)
+		case preApplyHookRejectedAction:
+			applyCondition.Reason = PreApplyHookRejectedReason
+		case postApplyHookFailedAction:
+			applyCondition.Reason = PostApplyHookFailedReason
+		case manifestVersionRollbackBlockedAction:
+			applyCondition.Reason = ManifestVersionRollbackBlockedReason
+		case manifestDryRunFailedAction:
+			applyCondition.Reason = string(fleetv1beta1.ManifestProcessingApplyResultTypeDryRunFailed)
 		default:
 			applyCondition.Reason = ManifestApplyFailedReason
 		}
@@ -834,6 +1719,20 @@ func buildManifestCondition(err error, action ApplyAction, observedGeneration in
 			availableCondition.Reason = string(manifestNotAvailableYetAction)
 			availableCondition.Message = "Manifest is trackable but not available yet"
 
+		case manifestProgressingAction:
+			applyCondition.Reason = ManifestAlreadyUpToDateReason
+			applyCondition.Message = manifestAlreadyUpToDateMessage
+			availableCondition.Status = metav1.ConditionFalse
+			availableCondition.Reason = string(manifestProgressingAction)
+			availableCondition.Message = "Manifest is trackable and progressing but not available yet"
+
+		case manifestFailedAction:
+			applyCondition.Reason = ManifestAlreadyUpToDateReason
+			applyCondition.Message = manifestAlreadyUpToDateMessage
+			availableCondition.Status = metav1.ConditionFalse
+			availableCondition.Reason = string(manifestFailedAction)
+			availableCondition.Message = "Manifest is trackable but has failed and will not become available"
+
 		// we cannot stuck at unknown so we have to mark it as true
 		case manifestNotTrackableAction:
 			applyCondition.Reason = ManifestAlreadyUpToDateReason
@@ -866,6 +1765,15 @@ func buildWorkCondition(manifestConditions []fleetv1beta1.ManifestCondition, obs
 		LastTransitionTime: metav1.Now(),
 		ObservedGeneration: observedGeneration,
 	}
+	// the work is not applied if the member cluster API timeout left any manifest pending
+	if processed, timedOut := processedOrdinals(manifestConditions); timedOut {
+		applyCondition.Status = metav1.ConditionFalse
+		applyCondition.Reason = ReconcileTimedOutReason
+		applyCondition.Message = fmt.Sprintf("Timed out applying the manifests, processed the manifests with ordinals %v", processed)
+		availableCondition.Status = metav1.ConditionUnknown
+		availableCondition.Reason = ReconcileTimedOutReason
+		return []metav1.Condition{applyCondition, availableCondition}
+	}
 	// the manifest condition should not be an empty list
 	for _, manifestCond := range manifestConditions {
 		if meta.IsStatusConditionFalse(manifestCond.Conditions, fleetv1beta1.WorkConditionTypeApplied) {
@@ -878,6 +1786,24 @@ func buildWorkCondition(manifestConditions []fleetv1beta1.ManifestCondition, obs
 			return []metav1.Condition{applyCondition, availableCondition}
 		}
 	}
+	// the work is not fully applied until the manifests of all the batches are applied
+	if pending := batchPendingOrdinals(manifestConditions); len(pending) > 0 {
+		applyCondition.Status = metav1.ConditionFalse
+		applyCondition.Reason = workRolloutInProgressReason
+		applyCondition.Message = fmt.Sprintf("The manifests with ordinals %v wait for the previous batches to be applied", pending)
+		availableCondition.Status = metav1.ConditionUnknown
+		availableCondition.Reason = workRolloutInProgressReason
+		return []metav1.Condition{applyCondition, availableCondition}
+	}
+	// the work is not fully applied if any manifest is skipped per the annotation of the work
+	if skipped := skippedOrdinals(manifestConditions); len(skipped) > 0 {
+		applyCondition.Status = metav1.ConditionFalse
+		applyCondition.Reason = workManifestsSkippedReason
+		applyCondition.Message = fmt.Sprintf("The manifests with ordinals %v are skipped per the %s annotation", skipped, WorkSkipManifestOrdinalsAnnotation)
+		availableCondition.Status = metav1.ConditionUnknown
+		availableCondition.Reason = workManifestsSkippedReason
+		return []metav1.Condition{applyCondition, availableCondition}
+	}
 	applyCondition.Status = metav1.ConditionTrue
 	applyCondition.Reason = workAppliedCompletedReason
 	applyCondition.Message = "Work is applied successfully"
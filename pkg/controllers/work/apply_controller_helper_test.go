@@ -22,6 +22,7 @@ import (
 
 	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	"go.goms.io/fleet/pkg/utils/condition"
+	"go.goms.io/fleet/pkg/utils/workstatuspage"
 )
 
 // createWorkWithManifest creates a work given a manifest
@@ -66,12 +67,20 @@ func verifyAppliedConfigMap(cm *corev1.ConfigMap) *corev1.ConfigMap {
 	return &appliedCM
 }
 
+// getWorkWithStatusPages gets a work with the manifest conditions of its status pages merged back.
+func getWorkWithStatusPages(workName, workNS string, work *fleetv1beta1.Work) error {
+	*work = fleetv1beta1.Work{}
+	if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: workName, Namespace: workNS}, work); err != nil {
+		return err
+	}
+	return workstatuspage.Merge(context.Background(), k8sClient, work)
+}
+
 // waitForWorkToApply waits for a work to be applied
 func waitForWorkToApply(workName, workNS string) *fleetv1beta1.Work {
 	var resultWork fleetv1beta1.Work
 	Eventually(func() bool {
-		err := k8sClient.Get(context.Background(), types.NamespacedName{Name: workName, Namespace: workNS}, &resultWork)
-		if err != nil {
+		if err := getWorkWithStatusPages(workName, workNS, &resultWork); err != nil {
 			return false
 		}
 		applyCond := meta.FindStatusCondition(resultWork.Status.Conditions, fleetv1beta1.WorkConditionTypeApplied)
@@ -94,8 +103,7 @@ func waitForWorkToApply(workName, workNS string) *fleetv1beta1.Work {
 func waitForWorkToBeAvailable(workName, workNS string) *fleetv1beta1.Work {
 	var resultWork fleetv1beta1.Work
 	Eventually(func() bool {
-		err := k8sClient.Get(context.Background(), types.NamespacedName{Name: workName, Namespace: workNS}, &resultWork)
-		if err != nil {
+		if err := getWorkWithStatusPages(workName, workNS, &resultWork); err != nil {
 			return false
 		}
 		availCond := meta.FindStatusCondition(resultWork.Status.Conditions, fleetv1beta1.WorkConditionTypeAvailable)
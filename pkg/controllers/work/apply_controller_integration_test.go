@@ -25,17 +25,24 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
@@ -248,6 +255,78 @@ var _ = Describe("Work Controller", func() {
 			Expect(k8sClient.Delete(ctx, work)).Should(Succeed(), "Failed to deleted the work")
 		})
 
+		It("Should re-apply a secret when its rotation generation is incremented", func() {
+			secretName := "test-rotated-secret"
+			secret := &corev1.Secret{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "v1",
+					Kind:       "Secret",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      secretName,
+					Namespace: defaultNS,
+					Annotations: map[string]string{
+						fleetv1beta1.SecretRotationGenerationAnnotation: "1",
+					},
+				},
+				Data: map[string][]byte{
+					"token": []byte("original"),
+				},
+			}
+
+			By("create the work")
+			work = createWorkWithManifest(testWorkNamespace, secret)
+			Expect(k8sClient.Create(context.Background(), work)).ToNot(HaveOccurred())
+
+			By("wait for the work to be available")
+			waitForWorkToBeAvailable(work.GetName(), work.GetNamespace())
+
+			By("modify the secret content on the member cluster")
+			var appliedSecret corev1.Secret
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: secretName, Namespace: defaultNS}, &appliedSecret)).Should(Succeed())
+			appliedSecret.Data["token"] = []byte("tampered")
+			Expect(k8sClient.Update(ctx, &appliedSecret)).Should(Succeed())
+
+			By("increment the rotation generation without changing the secret content")
+			secret.Annotations[fleetv1beta1.SecretRotationGenerationAnnotation] = "2"
+			resultWork := waitForWorkToApply(work.GetName(), work.GetNamespace())
+			rawSecret, err := json.Marshal(secret)
+			Expect(err).Should(Succeed())
+			resultWork.Spec.Workload.Manifests[0].Raw = rawSecret
+			Expect(k8sClient.Update(ctx, resultWork)).Should(Succeed())
+
+			By("verify that the secret is re-applied")
+			Eventually(func() error {
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: secretName, Namespace: defaultNS}, &appliedSecret); err != nil {
+					return err
+				}
+				if got := appliedSecret.Annotations[fleetv1beta1.SecretRotationGenerationAnnotation]; got != "2" {
+					return fmt.Errorf("rotation generation = %s, want 2", got)
+				}
+				if got := string(appliedSecret.Data["token"]); got != "original" {
+					return fmt.Errorf("secret token = %s, want original", got)
+				}
+				return nil
+			}, timeout, interval).Should(Succeed())
+
+			By("verify that the rotation generation is tracked in the appliedWork")
+			Eventually(func() error {
+				var appliedWork fleetv1beta1.AppliedWork
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: work.GetName()}, &appliedWork); err != nil {
+					return err
+				}
+				if len(appliedWork.Status.AppliedResources) != 1 {
+					return fmt.Errorf("got %d applied resources, want 1", len(appliedWork.Status.AppliedResources))
+				}
+				if got := appliedWork.Status.AppliedResources[0].RotationGeneration; got != 2 {
+					return fmt.Errorf("tracked rotation generation = %d, want 2", got)
+				}
+				return nil
+			}, timeout, interval).Should(Succeed())
+
+			Expect(k8sClient.Delete(ctx, work)).Should(Succeed(), "Failed to deleted the work")
+		})
+
 		It("Should merge the third party change correctly", func() {
 			cmName := "test-merge"
 			cmNamespace := defaultNS
@@ -558,6 +637,939 @@ var _ = Describe("Work Controller", func() {
 			Expect(k8sClient.Delete(ctx, work)).Should(Succeed(), "Failed to deleted the work")
 		})
 
+		It("Should shadow apply the manifests to the shadow namespace", func() {
+			cmName := "test-shadow-cm"
+			cm = &corev1.ConfigMap{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "v1",
+					Kind:       "ConfigMap",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cmName,
+					Namespace: defaultNS,
+				},
+				Data: map[string]string{
+					"test": "shadow",
+				},
+			}
+
+			By("create the work with the shadow apply enabled")
+			work = createWorkWithManifest(testWorkNamespace, cm)
+			work.Spec.ApplyStrategy = &fleetv1beta1.ApplyStrategy{ShadowApply: true}
+			Expect(k8sClient.Create(context.Background(), work)).Should(Succeed())
+
+			resultWork := waitForWorkToBeAvailable(work.GetName(), work.GetNamespace())
+			Expect(len(resultWork.Status.ManifestConditions)).Should(Equal(1))
+			shadowNS := fmt.Sprintf(shadowNamespaceFmt, defaultNS)
+			Expect(resultWork.Status.ManifestConditions[0].Identifier.Namespace).Should(Equal(shadowNS))
+
+			By("Check the config map is applied to the shadow namespace")
+			var shadowNamespace corev1.Namespace
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: shadowNS}, &shadowNamespace)).Should(Succeed())
+			var configMap corev1.ConfigMap
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: cmName, Namespace: shadowNS}, &configMap)).Should(Succeed())
+			Expect(cmp.Diff(configMap.Data, cm.Data)).Should(BeEmpty())
+
+			By("Check the real namespace is untouched")
+			err := k8sClient.Get(context.Background(), types.NamespacedName{Name: cmName, Namespace: defaultNS}, &configMap)
+			Expect(apierrors.IsNotFound(err)).Should(BeTrue(), "config map should not be applied to the real namespace")
+
+			By("Disable the shadow apply and check the shadow resources are garbage collected")
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, resultWork)).Should(Succeed())
+			resultWork.Spec.ApplyStrategy.ShadowApply = false
+			Expect(k8sClient.Update(context.Background(), resultWork)).Should(Succeed())
+			waitForWorkToBeAvailable(work.GetName(), work.GetNamespace())
+			Eventually(func() bool {
+				err := k8sClient.Get(context.Background(), types.NamespacedName{Name: cmName, Namespace: shadowNS}, &configMap)
+				return apierrors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue(), "shadow config map should be garbage collected")
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: cmName, Namespace: defaultNS}, &configMap)).Should(Succeed())
+
+			Expect(k8sClient.Delete(ctx, work)).Should(Succeed(), "Failed to deleted the work")
+		})
+
+		It("Should propagate the listed work annotations to the applied resources", func() {
+			cmName := "test-propagate-annotations-cm"
+			annotationKey := "example.com/cost-center"
+			cm = &corev1.ConfigMap{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "v1",
+					Kind:       "ConfigMap",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cmName,
+					Namespace: defaultNS,
+				},
+				Data: map[string]string{
+					"test": "test",
+				},
+			}
+
+			By("create the work with the annotation to propagate")
+			work = createWorkWithManifest(testWorkNamespace, cm)
+			work.SetAnnotations(map[string]string{annotationKey: "cc-1", "not-propagated": "true"})
+			work.Spec.PropagateAnnotations = []string{annotationKey}
+			Expect(k8sClient.Create(context.Background(), work)).Should(Succeed())
+			waitForWorkToBeAvailable(work.GetName(), work.GetNamespace())
+
+			var configMap corev1.ConfigMap
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: cmName, Namespace: defaultNS}, &configMap)).Should(Succeed())
+			Expect(configMap.Annotations[annotationKey]).Should(Equal("cc-1"))
+			Expect(configMap.Annotations).ShouldNot(HaveKey("not-propagated"))
+
+			By("update the work annotation")
+			var resultWork fleetv1beta1.Work
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, &resultWork)).Should(Succeed())
+			resultWork.Annotations[annotationKey] = "cc-2"
+			Expect(k8sClient.Update(context.Background(), &resultWork)).Should(Succeed())
+			Eventually(func() string {
+				if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: cmName, Namespace: defaultNS}, &configMap); err != nil {
+					return err.Error()
+				}
+				return configMap.Annotations[annotationKey]
+			}, timeout, interval).Should(Equal("cc-2"), "propagated annotation should be updated")
+
+			By("remove the annotation key from the propagated list")
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, &resultWork)).Should(Succeed())
+			resultWork.Spec.PropagateAnnotations = nil
+			Expect(k8sClient.Update(context.Background(), &resultWork)).Should(Succeed())
+			Eventually(func() bool {
+				if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: cmName, Namespace: defaultNS}, &configMap); err != nil {
+					return false
+				}
+				_, found := configMap.Annotations[annotationKey]
+				return !found
+			}, timeout, interval).Should(BeTrue(), "propagated annotation should be removed")
+
+			Expect(k8sClient.Delete(ctx, work)).Should(Succeed(), "Failed to deleted the work")
+		})
+
+		It("Should re-apply a manifest exported from the member cluster after sanitizing it", func() {
+			cmName := "test-exported-cm"
+			By("export a configmap from the member cluster")
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cmName,
+					Namespace: defaultNS,
+				},
+				Data: map[string]string{
+					"test": "test",
+				},
+			}
+			Expect(k8sClient.Create(context.Background(), cm)).Should(Succeed())
+			var exported corev1.ConfigMap
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: cmName, Namespace: defaultNS}, &exported)).Should(Succeed())
+			Expect(exported.ResourceVersion).ShouldNot(BeEmpty())
+			Expect(exported.UID).ShouldNot(BeEmpty())
+			exported.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"}
+			Expect(k8sClient.Delete(context.Background(), cm)).Should(Succeed())
+			Eventually(func() bool {
+				return apierrors.IsNotFound(k8sClient.Get(context.Background(), types.NamespacedName{Name: cmName, Namespace: defaultNS}, &corev1.ConfigMap{}))
+			}, timeout, interval).Should(BeTrue(), "exported configmap should be deleted")
+
+			By("create the work with the exported manifest")
+			work = createWorkWithManifest(testWorkNamespace, &exported)
+			Expect(k8sClient.Create(context.Background(), work)).Should(Succeed())
+			waitForWorkToApply(work.GetName(), work.GetNamespace())
+
+			var configMap corev1.ConfigMap
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: cmName, Namespace: defaultNS}, &configMap)).Should(Succeed())
+			Expect(configMap.UID).ShouldNot(Equal(exported.UID))
+			Expect(cmp.Diff(configMap.Data, exported.Data)).Should(BeEmpty())
+
+			Expect(k8sClient.Delete(ctx, work)).Should(Succeed(), "Failed to deleted the work")
+		})
+
+		It("Should hold the changes back until the work is approved", func() {
+			cmName := "test-require-approval-cm"
+			cm = &corev1.ConfigMap{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "v1",
+					Kind:       "ConfigMap",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cmName,
+					Namespace: defaultNS,
+				},
+				Data: map[string]string{
+					"test": "test",
+				},
+			}
+
+			By("create the work requiring approval")
+			work = createWorkWithManifest(testWorkNamespace, cm)
+			work.Spec.ApplyStrategy = &fleetv1beta1.ApplyStrategy{RequireApproval: true}
+			Expect(k8sClient.Create(context.Background(), work)).Should(Succeed())
+
+			var resultWork fleetv1beta1.Work
+			Eventually(func() []fleetv1beta1.PendingManifestChange {
+				if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, &resultWork); err != nil {
+					return nil
+				}
+				return resultWork.Status.PendingApprovalDiff
+			}, timeout, interval).Should(HaveLen(1), "the creation of the config map should wait for approval")
+			Expect(resultWork.Status.PendingApprovalDiff[0].Operation).Should(Equal(fleetv1beta1.PendingOperationCreate))
+			appliedCond := meta.FindStatusCondition(resultWork.Status.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+			Expect(appliedCond).ShouldNot(BeNil())
+			Expect(appliedCond.Reason).Should(Equal(WorkPendingApprovalReason))
+
+			By("Check the config map is not applied before the approval")
+			var configMap corev1.ConfigMap
+			Consistently(func() bool {
+				err := k8sClient.Get(context.Background(), types.NamespacedName{Name: cmName, Namespace: defaultNS}, &configMap)
+				return apierrors.IsNotFound(err)
+			}, time.Second*3, interval).Should(BeTrue(), "config map should not be applied before the approval")
+
+			By("approve the work")
+			resultWork.SetAnnotations(map[string]string{WorkApprovedAnnotation: "true"})
+			Expect(k8sClient.Update(context.Background(), &resultWork)).Should(Succeed())
+			waitForWorkToBeAvailable(work.GetName(), work.GetNamespace())
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: cmName, Namespace: defaultNS}, &configMap)).Should(Succeed())
+			Expect(cmp.Diff(configMap.Data, cm.Data)).Should(BeEmpty())
+
+			By("Check the approval annotation is removed and nothing is pending")
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, &resultWork)).Should(Succeed())
+			Expect(resultWork.Annotations).ShouldNot(HaveKey(WorkApprovedAnnotation))
+			Expect(resultWork.Status.PendingApprovalDiff).Should(BeEmpty())
+
+			Expect(k8sClient.Delete(ctx, work)).Should(Succeed(), "Failed to deleted the work")
+		})
+
+		It("Should list the fields of a missing deployment pending approval one by one", func() {
+			deploymentName := "test-require-approval-deployment"
+			deployment := &appsv1.Deployment{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      deploymentName,
+					Namespace: defaultNS,
+				},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: ptr.To[int32](2),
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": deploymentName}},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": deploymentName}},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}},
+						},
+					},
+				},
+			}
+
+			By("create the work requiring approval")
+			work = createWorkWithManifest(testWorkNamespace, deployment)
+			work.Spec.ApplyStrategy = &fleetv1beta1.ApplyStrategy{RequireApproval: true}
+			Expect(k8sClient.Create(context.Background(), work)).Should(Succeed())
+
+			var resultWork fleetv1beta1.Work
+			Eventually(func() []fleetv1beta1.PendingManifestChange {
+				if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, &resultWork); err != nil {
+					return nil
+				}
+				return resultWork.Status.PendingApprovalDiff
+			}, timeout, interval).Should(HaveLen(1), "the creation of the deployment should wait for approval")
+
+			By("Check the fields the deployment would be created with are listed")
+			change := resultWork.Status.PendingApprovalDiff[0]
+			Expect(change.Operation).Should(Equal(fleetv1beta1.PendingOperationCreate))
+			Expect(change.ResourceExistsInMember).Should(BeFalse())
+			Expect(change.ChangedFields).Should(ContainElements(
+				"apiVersion", "kind", "metadata.name", "metadata.namespace",
+				"spec.replicas", "spec.selector.matchLabels.app", "spec.template.spec.containers"))
+			for _, field := range []string{"metadata", "spec", "metadata.uid", "status"} {
+				Expect(change.ChangedFields).ShouldNot(ContainElement(field))
+			}
+
+			By("Check the deployment is not created before the approval")
+			var appliedDeployment appsv1.Deployment
+			err := k8sClient.Get(context.Background(), types.NamespacedName{Name: deploymentName, Namespace: defaultNS}, &appliedDeployment)
+			Expect(apierrors.IsNotFound(err)).Should(BeTrue(), "deployment should not be created before the approval")
+
+			Expect(k8sClient.Delete(ctx, work)).Should(Succeed(), "Failed to deleted the work")
+		})
+
+		It("Should record the spans of applying the work", func() {
+			cmName := "test-tracing-cm"
+			cm = &corev1.ConfigMap{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "v1",
+					Kind:       "ConfigMap",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cmName,
+					Namespace: defaultNS,
+				},
+				Data: map[string]string{
+					"test": "tracing",
+				},
+			}
+			work = createWorkWithManifest(testWorkNamespace, cm)
+			Expect(k8sClient.Create(context.Background(), work)).Should(Succeed())
+			waitForWorkToBeAvailable(work.GetName(), work.GetNamespace())
+
+			By("Check the work span and its manifest child span are recorded")
+			Eventually(func() error {
+				var workSpan, manifestSpan *tracetest.SpanStub
+				spans := spanExporter.GetSpans()
+				for i := range spans {
+					attrs := attribute.NewSet(spans[i].Attributes...)
+					if name, _ := attrs.Value(workNameAttributeKey); spans[i].Name == "ApplyWork" && name.AsString() == work.Name {
+						workSpan = &spans[i]
+					}
+				}
+				if workSpan == nil {
+					return fmt.Errorf("no span is recorded for work %s", work.Name)
+				}
+				for i := range spans {
+					if spans[i].Name == "ApplyManifest" && spans[i].Parent.SpanID() == workSpan.SpanContext.SpanID() {
+						manifestSpan = &spans[i]
+					}
+				}
+				if manifestSpan == nil {
+					return fmt.Errorf("no manifest span is recorded for work %s", work.Name)
+				}
+				workAttrs := attribute.NewSet(workSpan.Attributes...)
+				if ns, _ := workAttrs.Value(workNamespaceAttributeKey); ns.AsString() != work.Namespace {
+					return fmt.Errorf("work span namespace = %q, want %q", ns.AsString(), work.Namespace)
+				}
+				attrs := attribute.NewSet(manifestSpan.Attributes...)
+				if ordinal, _ := attrs.Value(manifestOrdinalAttributeKey); ordinal.AsInt64() != 0 {
+					return fmt.Errorf("manifest span ordinal = %d, want 0", ordinal.AsInt64())
+				}
+				if gvk, _ := attrs.Value(manifestGVKAttributeKey); gvk.AsString() != cm.GroupVersionKind().String() {
+					return fmt.Errorf("manifest span gvk = %q, want %q", gvk.AsString(), cm.GroupVersionKind().String())
+				}
+				if result, _ := attrs.Value(applyResultAttributeKey); result.AsString() == "" || result.AsString() == applyResultFailed {
+					return fmt.Errorf("manifest span apply result = %q, want a successful apply action", result.AsString())
+				}
+				return nil
+			}, timeout, interval).Should(Succeed())
+
+			Expect(k8sClient.Delete(ctx, work)).Should(Succeed(), "Failed to deleted the work")
+		})
+
+		It("Should report the manifests which stay unavailable beyond the stuck threshold as stuck", func() {
+			deploymentName := "test-stuck-deployment"
+			deployment := &appsv1.Deployment{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      deploymentName,
+					Namespace: defaultNS,
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": deploymentName}},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": deploymentName}},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}},
+						},
+					},
+				},
+			}
+
+			By("create the work with a stuck threshold")
+			work = createWorkWithManifest(testWorkNamespace, deployment)
+			work.Spec.ApplyStrategy = &fleetv1beta1.ApplyStrategy{StuckThreshold: &metav1.Duration{Duration: time.Second}}
+			Expect(k8sClient.Create(context.Background(), work)).Should(Succeed())
+
+			By("check the deployment, which no controller makes available, is reported as stuck")
+			var resultWork fleetv1beta1.Work
+			Eventually(func() bool {
+				if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, &resultWork); err != nil {
+					return false
+				}
+				if !meta.IsStatusConditionTrue(resultWork.Status.Conditions, fleetv1beta1.WorkConditionTypeStuck) {
+					return false
+				}
+				return len(resultWork.Status.ManifestConditions) == 1 &&
+					meta.IsStatusConditionTrue(resultWork.Status.ManifestConditions[0].Conditions, fleetv1beta1.WorkConditionTypeStuck)
+			}, timeout*2, interval).Should(BeTrue(), "work should be stuck")
+
+			By("make the deployment available")
+			var appliedDeployment appsv1.Deployment
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: deploymentName, Namespace: defaultNS}, &appliedDeployment)).Should(Succeed())
+			appliedDeployment.Status = appsv1.DeploymentStatus{
+				ObservedGeneration: appliedDeployment.Generation,
+				Replicas:           1,
+				UpdatedReplicas:    1,
+				ReadyReplicas:      1,
+				AvailableReplicas:  1,
+			}
+			Expect(k8sClient.Status().Update(context.Background(), &appliedDeployment)).Should(Succeed())
+
+			By("check the stuck condition flips back to false")
+			Eventually(func() bool {
+				if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, &resultWork); err != nil {
+					return false
+				}
+				if !meta.IsStatusConditionFalse(resultWork.Status.Conditions, fleetv1beta1.WorkConditionTypeStuck) {
+					return false
+				}
+				return len(resultWork.Status.ManifestConditions) == 1 &&
+					meta.IsStatusConditionFalse(resultWork.Status.ManifestConditions[0].Conditions, fleetv1beta1.WorkConditionTypeStuck)
+			}, timeout*2, interval).Should(BeTrue(), "work should not be stuck once the deployment is available")
+
+			Expect(k8sClient.Delete(ctx, work)).Should(Succeed(), "Failed to deleted the work")
+		})
+
+		It("Should report the statefulSet as available once all its replicas are available", func() {
+			statefulSetName := "test-available-statefulset"
+			statefulSet := &appsv1.StatefulSet{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "apps/v1",
+					Kind:       "StatefulSet",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      statefulSetName,
+					Namespace: defaultNS,
+				},
+				Spec: appsv1.StatefulSetSpec{
+					Replicas: ptr.To(int32(2)),
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": statefulSetName}},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": statefulSetName}},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}},
+						},
+					},
+				},
+			}
+
+			By("create the work")
+			work = createWorkWithManifest(testWorkNamespace, statefulSet)
+			Expect(k8sClient.Create(context.Background(), work)).Should(Succeed())
+			waitForWorkToApply(work.GetName(), work.GetNamespace())
+
+			By("check the statefulSet, which no controller makes available, is not available yet")
+			var resultWork fleetv1beta1.Work
+			Eventually(func() bool {
+				if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, &resultWork); err != nil {
+					return false
+				}
+				return len(resultWork.Status.ManifestConditions) == 1 &&
+					meta.IsStatusConditionFalse(resultWork.Status.ManifestConditions[0].Conditions, fleetv1beta1.WorkConditionTypeAvailable)
+			}, timeout, interval).Should(BeTrue(), "the statefulSet should not be available yet")
+
+			By("make the statefulSet available")
+			var appliedStatefulSet appsv1.StatefulSet
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: statefulSetName, Namespace: defaultNS}, &appliedStatefulSet)).Should(Succeed())
+			appliedStatefulSet.Status = appsv1.StatefulSetStatus{
+				ObservedGeneration: appliedStatefulSet.Generation,
+				Replicas:           2,
+				ReadyReplicas:      2,
+				AvailableReplicas:  2,
+				CurrentReplicas:    2,
+				UpdatedReplicas:    2,
+			}
+			Expect(k8sClient.Status().Update(context.Background(), &appliedStatefulSet)).Should(Succeed())
+
+			By("check the work becomes available")
+			waitForWorkToBeAvailable(work.GetName(), work.GetNamespace())
+
+			Expect(k8sClient.Delete(ctx, work)).Should(Succeed(), "Failed to deleted the work")
+		})
+
+		It("Should report the daemonSet as progressing until all its pods are available", func() {
+			daemonSetName := "test-progressing-daemonset"
+			daemonSet := &appsv1.DaemonSet{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "apps/v1",
+					Kind:       "DaemonSet",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      daemonSetName,
+					Namespace: defaultNS,
+				},
+				Spec: appsv1.DaemonSetSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": daemonSetName}},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": daemonSetName}},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}},
+						},
+					},
+				},
+			}
+
+			By("create the work")
+			work = createWorkWithManifest(testWorkNamespace, daemonSet)
+			Expect(k8sClient.Create(context.Background(), work)).Should(Succeed())
+			waitForWorkToApply(work.GetName(), work.GetNamespace())
+
+			availableReason := func() string {
+				var resultWork fleetv1beta1.Work
+				if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, &resultWork); err != nil {
+					return ""
+				}
+				if len(resultWork.Status.ManifestConditions) != 1 {
+					return ""
+				}
+				availableCond := meta.FindStatusCondition(resultWork.Status.ManifestConditions[0].Conditions, fleetv1beta1.WorkConditionTypeAvailable)
+				if availableCond == nil || availableCond.Status == metav1.ConditionTrue {
+					return ""
+				}
+				return availableCond.Reason
+			}
+			By("check the daemonSet, which no controller observes, is not available yet")
+			Eventually(availableReason, timeout, interval).Should(Equal(string(manifestNotAvailableYetAction)))
+
+			var appliedDaemonSet appsv1.DaemonSet
+			for numberAvailable := int32(0); numberAvailable < 3; numberAvailable++ {
+				By(fmt.Sprintf("make %d out of 3 pods of the daemonSet available", numberAvailable))
+				Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: daemonSetName, Namespace: defaultNS}, &appliedDaemonSet)).Should(Succeed())
+				appliedDaemonSet.Status = appsv1.DaemonSetStatus{
+					ObservedGeneration:     appliedDaemonSet.Generation,
+					DesiredNumberScheduled: 3,
+					CurrentNumberScheduled: 3,
+					UpdatedNumberScheduled: 3,
+					NumberReady:            numberAvailable,
+					NumberAvailable:        numberAvailable,
+					NumberUnavailable:      3 - numberAvailable,
+				}
+				Expect(k8sClient.Status().Update(context.Background(), &appliedDaemonSet)).Should(Succeed())
+
+				By("check the daemonSet is progressing")
+				Eventually(availableReason, timeout, interval).Should(Equal(string(manifestProgressingAction)))
+			}
+
+			By("make all the pods of the daemonSet available")
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: daemonSetName, Namespace: defaultNS}, &appliedDaemonSet)).Should(Succeed())
+			appliedDaemonSet.Status.NumberReady = 3
+			appliedDaemonSet.Status.NumberAvailable = 3
+			appliedDaemonSet.Status.NumberUnavailable = 0
+			Expect(k8sClient.Status().Update(context.Background(), &appliedDaemonSet)).Should(Succeed())
+
+			By("check the work becomes available")
+			waitForWorkToBeAvailable(work.GetName(), work.GetNamespace())
+
+			Expect(k8sClient.Delete(ctx, work)).Should(Succeed(), "Failed to deleted the work")
+		})
+
+		It("Should report the job as available once it completes", func() {
+			jobName := "test-available-job"
+			job := &batchv1.Job{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "batch/v1",
+					Kind:       "Job",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      jobName,
+					Namespace: defaultNS,
+				},
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							RestartPolicy: corev1.RestartPolicyNever,
+							Containers:    []corev1.Container{{Name: "busybox", Image: "busybox"}},
+						},
+					},
+				},
+			}
+
+			By("create the work")
+			work = createWorkWithManifest(testWorkNamespace, job)
+			Expect(k8sClient.Create(context.Background(), work)).Should(Succeed())
+			waitForWorkToApply(work.GetName(), work.GetNamespace())
+
+			By("check the job, which no controller runs, is not available yet")
+			var resultWork fleetv1beta1.Work
+			Eventually(func() bool {
+				if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, &resultWork); err != nil {
+					return false
+				}
+				return len(resultWork.Status.ManifestConditions) == 1 &&
+					meta.IsStatusConditionFalse(resultWork.Status.ManifestConditions[0].Conditions, fleetv1beta1.WorkConditionTypeAvailable)
+			}, timeout, interval).Should(BeTrue(), "the job should not be available yet")
+
+			By("complete the job")
+			var appliedJob batchv1.Job
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: jobName, Namespace: defaultNS}, &appliedJob)).Should(Succeed())
+			now := metav1.Now()
+			appliedJob.Status = batchv1.JobStatus{
+				StartTime:      &now,
+				CompletionTime: &now,
+				Succeeded:      1,
+				Conditions: []batchv1.JobCondition{{
+					Type:               batchv1.JobComplete,
+					Status:             corev1.ConditionTrue,
+					LastProbeTime:      now,
+					LastTransitionTime: now,
+				}},
+			}
+			Expect(k8sClient.Status().Update(context.Background(), &appliedJob)).Should(Succeed())
+
+			By("check the work becomes available")
+			waitForWorkToBeAvailable(work.GetName(), work.GetNamespace())
+
+			Expect(k8sClient.Delete(ctx, work)).Should(Succeed(), "Failed to deleted the work")
+		})
+
+		It("Should pause applying the work until the pause ends", func() {
+			cm = &corev1.ConfigMap{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "v1",
+					Kind:       "ConfigMap",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-paused-cm",
+					Namespace: defaultNS,
+				},
+				Data: map[string]string{
+					"test": "test",
+				},
+			}
+
+			By("create the work")
+			work = createWorkWithManifest(testWorkNamespace, cm)
+			Expect(k8sClient.Create(context.Background(), work)).Should(Succeed())
+			resultWork := waitForWorkToApply(work.GetName(), work.GetNamespace())
+			var appliedWork fleetv1beta1.AppliedWork
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: work.GetName()}, &appliedWork)).Should(Succeed())
+
+			By("pause the work and change its manifest")
+			cm.Data["test"] = "paused"
+			rawCM, err := json.Marshal(cm)
+			Expect(err).Should(Succeed())
+			resultWork.Spec.Workload.Manifests[0].Raw = rawCM
+			resultWork.Spec.ApplyStrategy.PausedUntil = &metav1.Time{Time: time.Now().Add(timeout / 2)}
+			Expect(k8sClient.Update(ctx, resultWork)).Should(Succeed())
+
+			By("check the work is paused")
+			Eventually(func() bool {
+				if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, resultWork); err != nil {
+					return false
+				}
+				pausedCond := meta.FindStatusCondition(resultWork.Status.Conditions, fleetv1beta1.WorkConditionTypePaused)
+				return pausedCond != nil && pausedCond.Status == metav1.ConditionTrue && pausedCond.Reason == ApplyPausedReason
+			}, timeout, interval).Should(BeTrue(), "the work should be paused")
+
+			By("check neither the config map nor the appliedWork changes while the work is paused")
+			Consistently(func() error {
+				var configMap corev1.ConfigMap
+				if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: cm.Name, Namespace: defaultNS}, &configMap); err != nil {
+					return err
+				}
+				if got := configMap.Data["test"]; got != "test" {
+					return fmt.Errorf("config map data = %s, want test", got)
+				}
+				var currentAppliedWork fleetv1beta1.AppliedWork
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: work.GetName()}, &currentAppliedWork); err != nil {
+					return err
+				}
+				if currentAppliedWork.ResourceVersion != appliedWork.ResourceVersion {
+					return fmt.Errorf("appliedWork resource version = %s, want %s", currentAppliedWork.ResourceVersion, appliedWork.ResourceVersion)
+				}
+				return nil
+			}, timeout/4, interval).Should(Succeed())
+
+			By("check the change is applied and the paused condition is cleared once the pause ends")
+			Eventually(func() error {
+				if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, resultWork); err != nil {
+					return err
+				}
+				if pausedCond := meta.FindStatusCondition(resultWork.Status.Conditions, fleetv1beta1.WorkConditionTypePaused); pausedCond != nil {
+					return fmt.Errorf("the work still has the paused condition %+v", pausedCond)
+				}
+				var configMap corev1.ConfigMap
+				if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: cm.Name, Namespace: defaultNS}, &configMap); err != nil {
+					return err
+				}
+				if got := configMap.Data["test"]; got != "paused" {
+					return fmt.Errorf("config map data = %s, want paused", got)
+				}
+				return nil
+			}, timeout, interval).Should(Succeed())
+
+			Expect(k8sClient.Delete(ctx, work)).Should(Succeed(), "Failed to deleted the work")
+		})
+
+		It("Should not apply the manifest which is larger than the limit", func() {
+			largeCM := &corev1.ConfigMap{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "v1",
+					Kind:       "ConfigMap",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-too-large-cm",
+					Namespace: defaultNS,
+				},
+				Data: map[string]string{
+					"test": strings.Repeat("x", testMaxManifestSizeBytes),
+				},
+			}
+			smallCM := &corev1.ConfigMap{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "v1",
+					Kind:       "ConfigMap",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-small-cm",
+					Namespace: defaultNS,
+				},
+				Data: map[string]string{
+					"test": "test",
+				},
+			}
+
+			By("create the work with a manifest larger than the limit")
+			work = createWorkWithManifest(testWorkNamespace, largeCM)
+			work.Spec.Workload.Manifests = append(work.Spec.Workload.Manifests, fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{Object: smallCM}})
+			Expect(k8sClient.Create(context.Background(), work)).Should(Succeed())
+
+			By("check the large manifest is reported as too large")
+			var resultWork fleetv1beta1.Work
+			Eventually(func() bool {
+				if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, &resultWork); err != nil {
+					return false
+				}
+				if len(resultWork.Status.ManifestConditions) != 2 {
+					return false
+				}
+				applyCond := meta.FindStatusCondition(resultWork.Status.ManifestConditions[0].Conditions, fleetv1beta1.WorkConditionTypeApplied)
+				return applyCond != nil && applyCond.Status == metav1.ConditionFalse &&
+					applyCond.Reason == string(fleetv1beta1.ManifestProcessingApplyResultTypeTooLarge) &&
+					meta.IsStatusConditionTrue(resultWork.Status.ManifestConditions[1].Conditions, fleetv1beta1.WorkConditionTypeApplied)
+			}, timeout, interval).Should(BeTrue(), "the large manifest should be reported as too large")
+			Expect(meta.IsStatusConditionFalse(resultWork.Status.Conditions, fleetv1beta1.WorkConditionTypeApplied)).Should(BeTrue())
+
+			By("check only the small config map is applied")
+			var configMap corev1.ConfigMap
+			err := k8sClient.Get(context.Background(), types.NamespacedName{Name: largeCM.Name, Namespace: defaultNS}, &configMap)
+			Expect(apierrors.IsNotFound(err)).Should(BeTrue(), "the large config map should not be applied")
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: smallCM.Name, Namespace: defaultNS}, &configMap)).Should(Succeed())
+
+			Expect(k8sClient.Delete(ctx, work)).Should(Succeed(), "Failed to deleted the work")
+		})
+
+		It("Should keep the most recent drifts of the resource in the drift history of the manifest", func() {
+			deploymentName := "test-drift-history-deployment"
+			deployment := &appsv1.Deployment{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      deploymentName,
+					Namespace: defaultNS,
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": deploymentName}},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": deploymentName}},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}},
+						},
+					},
+				},
+			}
+
+			By("create the work")
+			work = createWorkWithManifest(testWorkNamespace, deployment)
+			Expect(k8sClient.Create(context.Background(), work)).Should(Succeed())
+			waitForWorkToApply(work.GetName(), work.GetNamespace())
+
+			var resultWork fleetv1beta1.Work
+			var appliedDeployment appsv1.Deployment
+			var generations []int64
+			for i := 1; i <= testMaxDriftHistoryDepth+1; i++ {
+				By(fmt.Sprintf("change the deployment in the member cluster for the drift %d", i))
+				Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: deploymentName, Namespace: defaultNS}, &appliedDeployment)).Should(Succeed())
+				appliedDeployment.Spec.MinReadySeconds = int32(i)
+				Expect(k8sClient.Update(context.Background(), &appliedDeployment)).Should(Succeed())
+				generations = append(generations, appliedDeployment.Generation)
+
+				By(fmt.Sprintf("check the drift %d is the newest entry of the drift history", i))
+				Eventually(func() bool {
+					if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, &resultWork); err != nil {
+						return false
+					}
+					if len(resultWork.Status.ManifestConditions) != 1 {
+						return false
+					}
+					history := resultWork.Status.ManifestConditions[0].DriftHistory
+					return len(history) > 0 && history[0].ObservedGeneration == appliedDeployment.Generation
+				}, timeout*2, interval).Should(BeTrue(), "the drift should be recorded in the drift history")
+			}
+
+			By("check the oldest drift is evicted from the drift history")
+			history := resultWork.Status.ManifestConditions[0].DriftHistory
+			Expect(history).Should(HaveLen(testMaxDriftHistoryDepth))
+			for i, entry := range history {
+				Expect(entry.ObservedGeneration).Should(Equal(generations[len(generations)-1-i]))
+			}
+
+			Expect(k8sClient.Delete(ctx, work)).Should(Succeed(), "Failed to deleted the work")
+		})
+
+		It("Should not revert the changes to the ignored paths of the resource", func() {
+			deploymentName := "test-ignore-paths-deployment"
+			deployment := &appsv1.Deployment{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      deploymentName,
+					Namespace: defaultNS,
+				},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: ptr.To(int32(1)),
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": deploymentName}},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": deploymentName}},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}},
+						},
+					},
+				},
+			}
+
+			By("create the work which ignores the replicas of the deployment")
+			work = createWorkWithManifest(testWorkNamespace, deployment)
+			work.Spec.ApplyStrategy = &fleetv1beta1.ApplyStrategy{
+				Type:        fleetv1beta1.ApplyStrategyTypeServerSideApply,
+				IgnorePaths: []string{"/spec/replicas"},
+			}
+			Expect(k8sClient.Create(context.Background(), work)).Should(Succeed())
+			waitForWorkToApply(work.GetName(), work.GetNamespace())
+
+			By("scale the deployment in the member cluster")
+			var appliedDeployment appsv1.Deployment
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: deploymentName, Namespace: defaultNS}, &appliedDeployment)).Should(Succeed())
+			appliedDeployment.Spec.Replicas = ptr.To(int32(3))
+			Expect(k8sClient.Update(context.Background(), &appliedDeployment)).Should(Succeed())
+
+			By("check the replicas are not reverted while the manifest stays applied")
+			Consistently(func() bool {
+				if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: deploymentName, Namespace: defaultNS}, &appliedDeployment); err != nil {
+					return false
+				}
+				var resultWork fleetv1beta1.Work
+				if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, &resultWork); err != nil {
+					return false
+				}
+				if len(resultWork.Status.ManifestConditions) != 1 {
+					return false
+				}
+				applied := meta.IsStatusConditionTrue(resultWork.Status.ManifestConditions[0].Conditions, fleetv1beta1.WorkConditionTypeApplied)
+				return applied && *appliedDeployment.Spec.Replicas == 3
+			}, timeout, interval).Should(BeTrue(), "the replicas of the deployment should not be reverted")
+
+			Expect(k8sClient.Delete(ctx, work)).Should(Succeed(), "Failed to deleted the work")
+		})
+
+		It("Should not report the reordered env of the containers as a drift in the semantic comparison", func() {
+			deploymentName := "test-semantic-comparison-deployment"
+			deployment := &appsv1.Deployment{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      deploymentName,
+					Namespace: defaultNS,
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": deploymentName}},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": deploymentName}},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{
+								Name:  "nginx",
+								Image: "nginx",
+								Env:   []corev1.EnvVar{{Name: "A", Value: "a"}, {Name: "B", Value: "b"}, {Name: "C", Value: "c"}},
+							}},
+						},
+					},
+				},
+			}
+
+			By("create the work which compares the resources semantically")
+			work = createWorkWithManifest(testWorkNamespace, deployment)
+			work.Spec.ApplyStrategy = &fleetv1beta1.ApplyStrategy{
+				Type:             fleetv1beta1.ApplyStrategyTypeServerSideApply,
+				ComparisonOption: fleetv1beta1.ComparisonOptionTypeSemanticComparison,
+			}
+			Expect(k8sClient.Create(context.Background(), work)).Should(Succeed())
+			waitForWorkToApply(work.GetName(), work.GetNamespace())
+
+			By("reorder the env of the container in the member cluster")
+			var appliedDeployment appsv1.Deployment
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: deploymentName, Namespace: defaultNS}, &appliedDeployment)).Should(Succeed())
+			reordered := []corev1.EnvVar{{Name: "C", Value: "c"}, {Name: "A", Value: "a"}, {Name: "B", Value: "b"}}
+			appliedDeployment.Spec.Template.Spec.Containers[0].Env = reordered
+			Expect(k8sClient.Update(context.Background(), &appliedDeployment)).Should(Succeed())
+
+			By("check the env is not applied again while the manifest stays applied")
+			Consistently(func() bool {
+				if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: deploymentName, Namespace: defaultNS}, &appliedDeployment); err != nil {
+					return false
+				}
+				var resultWork fleetv1beta1.Work
+				if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, &resultWork); err != nil {
+					return false
+				}
+				if len(resultWork.Status.ManifestConditions) != 1 {
+					return false
+				}
+				applied := meta.IsStatusConditionTrue(resultWork.Status.ManifestConditions[0].Conditions, fleetv1beta1.WorkConditionTypeApplied)
+				return applied && cmp.Equal(appliedDeployment.Spec.Template.Spec.Containers[0].Env, reordered)
+			}, timeout, interval).Should(BeTrue(), "the reordered env should not be reported as a drift")
+
+			Expect(k8sClient.Delete(ctx, work)).Should(Succeed(), "Failed to deleted the work")
+		})
+
+		It("Should leave the applied resources in the member cluster when the work orphans them on delete", func() {
+			cmName := "test-orphan-cm"
+			cm = &corev1.ConfigMap{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "v1",
+					Kind:       "ConfigMap",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cmName,
+					Namespace: defaultNS,
+				},
+				Data: map[string]string{
+					"test": "test",
+				},
+			}
+
+			By("create the work which orphans its resources on delete")
+			work = createWorkWithManifest(testWorkNamespace, cm)
+			work.Spec.TerminationPolicy = fleetv1beta1.TerminationPolicyTypeOrphan
+			Expect(k8sClient.Create(context.Background(), work)).Should(Succeed())
+			waitForWorkToApply(work.GetName(), work.GetNamespace())
+			var configMap corev1.ConfigMap
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: cmName, Namespace: defaultNS}, &configMap)).Should(Succeed())
+			Expect(configMap.OwnerReferences).Should(HaveLen(1))
+
+			By("delete the work")
+			Expect(k8sClient.Delete(ctx, work)).Should(Succeed(), "Failed to deleted the work")
+			Eventually(func() bool {
+				err := k8sClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, &fleetv1beta1.Work{})
+				return apierrors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue(), "the work should be deleted")
+			Eventually(func() bool {
+				err := k8sClient.Get(context.Background(), types.NamespacedName{Name: work.Name}, &fleetv1beta1.AppliedWork{})
+				return apierrors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue(), "the appliedWork should be deleted")
+
+			By("check the config map is left without the owner reference to the appliedWork")
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: cmName, Namespace: defaultNS}, &configMap)).Should(Succeed())
+			for _, owner := range configMap.OwnerReferences {
+				Expect(owner.Kind).ShouldNot(Equal(fleetv1beta1.AppliedWorkKind))
+			}
+			Expect(cmp.Diff(configMap.Data, cm.Data)).Should(BeEmpty())
+
+			Expect(k8sClient.Delete(ctx, &configMap)).Should(Succeed(), "Failed to delete the orphaned config map")
+		})
+
 		It("Check that failed to apply manifest has the proper identification", func() {
 			testResourceName := "test-resource-name-failed"
 			// to ensure apply fails.
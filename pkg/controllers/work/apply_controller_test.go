@@ -28,10 +28,15 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/atomic"
 	appsv1 "k8s.io/api/apps/v1"
@@ -50,10 +55,13 @@ import (
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/metrics"
 	"go.goms.io/fleet/pkg/utils"
 	"go.goms.io/fleet/pkg/utils/controller"
+	"go.goms.io/fleet/pkg/utils/resourcelock"
 	testcontroller "go.goms.io/fleet/test/utils/controller"
 )
 
@@ -101,6 +109,46 @@ func (m testMapper) RESTMapping(gk schema.GroupKind, _ ...string) (*meta.RESTMap
 	return nil, errors.New("test error: mapping does not exist")
 }
 
+func TestApplyWorkReconcilerOptionsSetDefaults(t *testing.T) {
+	tests := map[string]struct {
+		opts ApplyWorkReconcilerOptions
+		want ApplyWorkReconcilerOptions
+	}{
+		"unset options are defaulted": {
+			opts: ApplyWorkReconcilerOptions{WorkNamespace: "fleet-member-test"},
+			want: ApplyWorkReconcilerOptions{
+				Concurrency:             1,
+				WorkNamespace:           "fleet-member-test",
+				SanitizedManifestFields: DefaultSanitizedManifestFields,
+				FieldManager:            DefaultFieldManagerName,
+				WorkerPoolSize:          1,
+			},
+		},
+		"set options are kept": {
+			opts: ApplyWorkReconcilerOptions{
+				Concurrency:             5,
+				SanitizedManifestFields: []string{},
+				FieldManager:            "other-fleet",
+				WorkerPoolSize:          4,
+			},
+			want: ApplyWorkReconcilerOptions{
+				Concurrency:             5,
+				SanitizedManifestFields: []string{},
+				FieldManager:            "other-fleet",
+				WorkerPoolSize:          4,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tt.opts.setDefaults()
+			if diff := cmp.Diff(tt.want, tt.opts); diff != "" {
+				t.Errorf("setDefaults() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestSetManifestHashAnnotation(t *testing.T) {
 	// basic setup
 	manifestObj := appsv1.Deployment{
@@ -302,6 +350,300 @@ func TestIsManifestManagedByWork(t *testing.T) {
 	}
 }
 
+func TestIsSecretRotated(t *testing.T) {
+	secretWithGeneration := func(generation string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("Secret")
+		obj.SetName("secret")
+		if generation != "" {
+			obj.SetAnnotations(map[string]string{fleetv1beta1.SecretRotationGenerationAnnotation: generation})
+		}
+		return obj
+	}
+	configMap := &unstructured.Unstructured{}
+	configMap.SetAPIVersion("v1")
+	configMap.SetKind("ConfigMap")
+	configMap.SetAnnotations(map[string]string{fleetv1beta1.SecretRotationGenerationAnnotation: "2"})
+
+	tests := map[string]struct {
+		manifestObj *unstructured.Unstructured
+		curObj      *unstructured.Unstructured
+		want        bool
+	}{
+		"neither object has the annotation": {
+			manifestObj: secretWithGeneration(""),
+			curObj:      secretWithGeneration(""),
+			want:        false,
+		},
+		"rotation generation is added": {
+			manifestObj: secretWithGeneration("1"),
+			curObj:      secretWithGeneration(""),
+			want:        true,
+		},
+		"rotation generation is incremented": {
+			manifestObj: secretWithGeneration("2"),
+			curObj:      secretWithGeneration("1"),
+			want:        true,
+		},
+		"rotation generation is unchanged": {
+			manifestObj: secretWithGeneration("2"),
+			curObj:      secretWithGeneration("2"),
+			want:        false,
+		},
+		"rotation generation is decremented": {
+			manifestObj: secretWithGeneration("1"),
+			curObj:      secretWithGeneration("2"),
+			want:        false,
+		},
+		"rotation generation is invalid": {
+			manifestObj: secretWithGeneration("abc"),
+			curObj:      secretWithGeneration("1"),
+			want:        false,
+		},
+		"non secret object is ignored": {
+			manifestObj: configMap,
+			curObj:      &unstructured.Unstructured{},
+			want:        false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equalf(t, tt.want, isSecretRotated(tt.manifestObj, tt.curObj), "isSecretRotated()")
+		})
+	}
+}
+
+func TestComputeManifestHashIgnoresSecretRotationGeneration(t *testing.T) {
+	secret := &unstructured.Unstructured{}
+	secret.SetAPIVersion("v1")
+	secret.SetKind("Secret")
+	secret.SetName("secret")
+	secret.SetAnnotations(map[string]string{fleetv1beta1.SecretRotationGenerationAnnotation: "1"})
+	rotated := secret.DeepCopy()
+	rotated.SetAnnotations(map[string]string{fleetv1beta1.SecretRotationGenerationAnnotation: "2"})
+
+	hash, err := computeManifestHash(secret)
+	if err != nil {
+		t.Fatalf("computeManifestHash() = %v, want no error", err)
+	}
+	rotatedHash, err := computeManifestHash(rotated)
+	if err != nil {
+		t.Fatalf("computeManifestHash() = %v, want no error", err)
+	}
+	assert.Equal(t, hash, rotatedHash, "rotating a secret should not change its spec hash")
+}
+
+func TestUpdateLastGoodStatus(t *testing.T) {
+	identifier := fleetv1beta1.WorkResourceIdentifier{Ordinal: 0, Version: "v1", Kind: "ConfigMap", Name: "cm"}
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{
+			Generation: 1,
+		},
+	}
+
+	// the work is applied and available
+	constructWorkCondition([]applyResult{{identifier: identifier, generation: 1, action: manifestAvailableAction}}, work)
+	if work.Status.LastGoodStatus == nil {
+		t.Fatalf("updateLastGoodStatus() last good status = nil, want a snapshot of generation 1")
+	}
+	wantLastGoodStatus := work.Status.LastGoodStatus.DeepCopy()
+	if wantLastGoodStatus.ObservedGeneration != 1 {
+		t.Errorf("updateLastGoodStatus() last good status observed generation = %d, want 1", wantLastGoodStatus.ObservedGeneration)
+	}
+	if len(wantLastGoodStatus.ManifestConditions) != 1 {
+		t.Errorf("updateLastGoodStatus() last good status has %d manifest conditions, want 1", len(wantLastGoodStatus.ManifestConditions))
+	}
+
+	// the spec is updated but the new manifest fails to apply
+	work.Generation = 2
+	constructWorkCondition([]applyResult{{identifier: identifier, generation: 2, action: errorApplyAction, applyErr: errors.New("apply failed")}}, work)
+	appliedCond := meta.FindStatusCondition(work.Status.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+	if appliedCond == nil || appliedCond.Status != metav1.ConditionFalse {
+		t.Fatalf("constructWorkCondition() applied condition = %v, want false", appliedCond)
+	}
+	if diff := cmp.Diff(wantLastGoodStatus, work.Status.LastGoodStatus); diff != "" {
+		t.Errorf("updateLastGoodStatus() overwrote the last good status on apply failure (-want +got):\n%s", diff)
+	}
+
+	// the manifest is applied but not available yet
+	constructWorkCondition([]applyResult{{identifier: identifier, generation: 2, action: manifestNotAvailableYetAction}}, work)
+	if diff := cmp.Diff(wantLastGoodStatus, work.Status.LastGoodStatus); diff != "" {
+		t.Errorf("updateLastGoodStatus() overwrote the last good status while not available (-want +got):\n%s", diff)
+	}
+
+	// the manifest becomes available again
+	constructWorkCondition([]applyResult{{identifier: identifier, generation: 2, action: manifestAvailableAction}}, work)
+	if work.Status.LastGoodStatus == nil || work.Status.LastGoodStatus.ObservedGeneration != 2 {
+		t.Errorf("updateLastGoodStatus() last good status = %+v, want a snapshot of generation 2", work.Status.LastGoodStatus)
+	}
+}
+
+func TestDesiredStatePercentage(t *testing.T) {
+	identifier := func(ordinal int) fleetv1beta1.WorkResourceIdentifier {
+		return fleetv1beta1.WorkResourceIdentifier{Ordinal: ordinal, Version: "v1", Kind: "ConfigMap", Name: fmt.Sprintf("cm-%d", ordinal)}
+	}
+	tests := map[string]struct {
+		results []applyResult
+		want    float32
+	}{
+		"work without manifests": {
+			want: 100,
+		},
+		"all manifests are applied and available": {
+			results: []applyResult{
+				{identifier: identifier(0), action: manifestAvailableAction},
+				{identifier: identifier(1), action: manifestNotTrackableAction},
+			},
+			want: 100,
+		},
+		"half of the manifests are applied and available": {
+			results: []applyResult{
+				{identifier: identifier(0), action: manifestAvailableAction},
+				{identifier: identifier(1), action: manifestNotAvailableYetAction},
+				{identifier: identifier(2), action: errorApplyAction, applyErr: errors.New("apply failed")},
+				{identifier: identifier(3), action: manifestAvailableAction},
+			},
+			want: 50,
+		},
+		"all manifests fail to apply": {
+			results: []applyResult{
+				{identifier: identifier(0), action: errorApplyAction, applyErr: errors.New("apply failed")},
+				{identifier: identifier(1), action: errorApplyAction, applyErr: errors.New("apply failed")},
+			},
+			want: 0,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			work := &fleetv1beta1.Work{ObjectMeta: metav1.ObjectMeta{Generation: 1}}
+			constructWorkCondition(tt.results, work)
+			assert.Equal(t, tt.want, work.Status.DesiredStatePercentage, "desiredStatePercentage() mismatch")
+		})
+	}
+}
+
+func TestManifestCreatedAt(t *testing.T) {
+	identifier := fleetv1beta1.WorkResourceIdentifier{Ordinal: 0, Version: "v1", Kind: "ConfigMap", Name: "cm"}
+	work := &fleetv1beta1.Work{ObjectMeta: metav1.ObjectMeta{Generation: 1}}
+
+	constructWorkCondition([]applyResult{{identifier: identifier, action: manifestAvailableAction}}, work)
+	createdAt := work.Status.ManifestConditions[0].ManifestCreatedAt
+	if createdAt == nil {
+		t.Fatalf("constructWorkCondition() manifest created at = nil, want the first reconcile time")
+	}
+	wantCreatedAt := createdAt.DeepCopy()
+
+	// the following reconcile of the same generation.
+	constructWorkCondition([]applyResult{{identifier: identifier, action: manifestAvailableAction}}, work)
+	if diff := cmp.Diff(wantCreatedAt, work.Status.ManifestConditions[0].ManifestCreatedAt); diff != "" {
+		t.Errorf("constructWorkCondition() manifest created at changed across reconciles (-want +got):\n%s", diff)
+	}
+
+	// the manifest content is changed to a different resource and a new manifest is added.
+	work.Generation = 2
+	changed := fleetv1beta1.WorkResourceIdentifier{Ordinal: 0, Version: "v1", Kind: "Secret", Name: "secret"}
+	added := fleetv1beta1.WorkResourceIdentifier{Ordinal: 1, Version: "v1", Kind: "ConfigMap", Name: "cm-2"}
+	constructWorkCondition([]applyResult{
+		{identifier: changed, action: errorApplyAction, applyErr: errors.New("apply failed")},
+		{identifier: added, action: manifestAvailableAction},
+	}, work)
+	if diff := cmp.Diff(wantCreatedAt, work.Status.ManifestConditions[0].ManifestCreatedAt); diff != "" {
+		t.Errorf("constructWorkCondition() manifest created at changed across spec updates (-want +got):\n%s", diff)
+	}
+	if work.Status.ManifestConditions[1].ManifestCreatedAt == nil {
+		t.Errorf("constructWorkCondition() manifest created at of the added manifest = nil, want the reconcile time")
+	}
+}
+
+func TestManifestApplyTiming(t *testing.T) {
+	identifier := fleetv1beta1.WorkResourceIdentifier{Ordinal: 0, Version: "v1", Kind: "ConfigMap", Name: "cm"}
+	notApplied := fleetv1beta1.WorkResourceIdentifier{Ordinal: 1}
+	work := &fleetv1beta1.Work{ObjectMeta: metav1.ObjectMeta{Generation: 1}}
+	startedAt := time.Now().Truncate(time.Second)
+
+	constructWorkCondition([]applyResult{
+		{
+			identifier:       identifier,
+			action:           manifestAvailableAction,
+			applyStartedAt:   startedAt,
+			applyCompletedAt: startedAt.Add(1500 * time.Millisecond),
+		},
+		{identifier: notApplied, action: errorApplyAction, applyErr: errors.New("failed to decode object")},
+	}, work)
+	got := work.Status.ManifestConditions[0]
+	if got.ApplyStartedAt == nil || got.ApplyCompletedAt == nil {
+		t.Fatalf("constructWorkCondition() apply timestamps = (%v, %v), want both set", got.ApplyStartedAt, got.ApplyCompletedAt)
+	}
+	if got.ApplyCompletedAt.Before(got.ApplyStartedAt) {
+		t.Errorf("constructWorkCondition() apply completed at %v before it started at %v", got.ApplyCompletedAt, got.ApplyStartedAt)
+	}
+	if got.ApplyDurationMs != 1500 {
+		t.Errorf("constructWorkCondition() apply duration = %dms, want 1500ms", got.ApplyDurationMs)
+	}
+	if failed := work.Status.ManifestConditions[1]; failed.ApplyStartedAt != nil || failed.ApplyCompletedAt != nil || failed.ApplyDurationMs != 0 {
+		t.Errorf("constructWorkCondition() apply timing of the manifest not applied = %+v, want none", failed)
+	}
+
+	// the next apply starts after the previous one completed.
+	nextStartedAt := startedAt.Add(5 * time.Second)
+	constructWorkCondition([]applyResult{{
+		identifier:       identifier,
+		action:           manifestAvailableAction,
+		applyStartedAt:   nextStartedAt,
+		applyCompletedAt: nextStartedAt.Add(20 * time.Millisecond),
+	}}, work)
+	next := work.Status.ManifestConditions[0]
+	if !got.ApplyCompletedAt.Before(next.ApplyStartedAt) {
+		t.Errorf("constructWorkCondition() next apply started at %v, want after %v", next.ApplyStartedAt, got.ApplyCompletedAt)
+	}
+	if next.ApplyDurationMs != 20 {
+		t.Errorf("constructWorkCondition() next apply duration = %dms, want 20ms", next.ApplyDurationMs)
+	}
+}
+
+func TestSetMemberClusterUnhealthyCondition(t *testing.T) {
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{
+			Generation: 2,
+		},
+		Status: fleetv1beta1.WorkStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:               fleetv1beta1.WorkConditionTypeApplied,
+					Status:             metav1.ConditionTrue,
+					Reason:             workAppliedCompletedReason,
+					ObservedGeneration: 1,
+				},
+			},
+			ManifestConditions: []fleetv1beta1.ManifestCondition{
+				{
+					Identifier: fleetv1beta1.WorkResourceIdentifier{Ordinal: 0, Kind: "ConfigMap"},
+				},
+			},
+		},
+	}
+	want := []metav1.Condition{
+		{
+			Type:               fleetv1beta1.WorkConditionTypeApplied,
+			Status:             metav1.ConditionFalse,
+			Reason:             MemberClusterUnhealthyReason,
+			ObservedGeneration: 2,
+		},
+		{
+			Type:               fleetv1beta1.WorkConditionTypeAvailable,
+			Status:             metav1.ConditionUnknown,
+			Reason:             MemberClusterUnhealthyReason,
+			ObservedGeneration: 2,
+		},
+	}
+
+	setMemberClusterUnhealthyCondition(work, "connection refused")
+	diff := testcontroller.CompareConditions(want, work.Status.Conditions)
+	assert.Empty(t, diff, "setMemberClusterUnhealthyCondition() conditions mismatch (-want +got):\n%s", diff)
+	assert.Len(t, work.Status.ManifestConditions, 1, "setMemberClusterUnhealthyCondition() should not touch the manifest conditions")
+}
+
 func TestBuildManifestCondition(t *testing.T) {
 	tests := map[string]struct {
 		err    error
@@ -340,6 +682,38 @@ func TestBuildManifestCondition(t *testing.T) {
 				},
 			},
 		},
+		"TestNoErrorManifestProgressing": {
+			err:    nil,
+			action: manifestProgressingAction,
+			want: []metav1.Condition{
+				{
+					Type:   fleetv1beta1.WorkConditionTypeApplied,
+					Status: metav1.ConditionTrue,
+					Reason: ManifestAlreadyUpToDateReason,
+				},
+				{
+					Type:   fleetv1beta1.WorkConditionTypeAvailable,
+					Status: metav1.ConditionFalse,
+					Reason: string(manifestProgressingAction),
+				},
+			},
+		},
+		"TestNoErrorManifestFailed": {
+			err:    nil,
+			action: manifestFailedAction,
+			want: []metav1.Condition{
+				{
+					Type:   fleetv1beta1.WorkConditionTypeApplied,
+					Status: metav1.ConditionTrue,
+					Reason: ManifestAlreadyUpToDateReason,
+				},
+				{
+					Type:   fleetv1beta1.WorkConditionTypeAvailable,
+					Status: metav1.ConditionFalse,
+					Reason: string(manifestFailedAction),
+				},
+			},
+		},
 		"TestNoErrorManifestThreeWayMergePatch": {
 			err:    nil,
 			action: manifestThreeWayMergePatchAction,
@@ -1056,6 +1430,7 @@ func TestTrackResourceAvailability(t *testing.T) {
 					},
 					"status": map[string]interface{}{
 						"observedGeneration": 5,
+						"replicas":           3,
 						"availableReplicas":  3,
 						"currentReplicas":    3,
 						"updatedReplicas":    3,
@@ -1065,6 +1440,80 @@ func TestTrackResourceAvailability(t *testing.T) {
 			expected: manifestAvailableAction,
 			err:      nil,
 		},
+		"Test StatefulSet available with the default replicas": {
+			gvr: utils.StatefulSettGVR,
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "apps/v1",
+					"kind":       "StatefulSet",
+					"metadata": map[string]interface{}{
+						"generation": 1,
+						"name":       "test-statefulset",
+					},
+					"status": map[string]interface{}{
+						"observedGeneration": 1,
+						"replicas":           1,
+						"availableReplicas":  1,
+						"currentReplicas":    1,
+						"updatedReplicas":    1,
+					},
+				},
+			},
+			expected: manifestAvailableAction,
+			err:      nil,
+		},
+		"Test StatefulSet still scaling down": {
+			gvr: utils.StatefulSettGVR,
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "apps/v1",
+					"kind":       "StatefulSet",
+					"metadata": map[string]interface{}{
+						"generation": 4,
+						"name":       "test-statefulset",
+					},
+					"spec": map[string]interface{}{
+						"replicas": 2,
+					},
+					"status": map[string]interface{}{
+						"observedGeneration": 4,
+						"replicas":           3,
+						"availableReplicas":  2,
+						"currentReplicas":    3,
+						"updatedReplicas":    3,
+					},
+				},
+			},
+			expected: manifestNotAvailableYetAction,
+			err:      nil,
+		},
+		"Test StatefulSet rolling out a new revision": {
+			gvr: utils.StatefulSettGVR,
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "apps/v1",
+					"kind":       "StatefulSet",
+					"metadata": map[string]interface{}{
+						"generation": 6,
+						"name":       "test-statefulset",
+					},
+					"spec": map[string]interface{}{
+						"replicas": 3,
+					},
+					"status": map[string]interface{}{
+						"observedGeneration": 6,
+						"replicas":           3,
+						"availableReplicas":  3,
+						"currentReplicas":    2,
+						"updatedReplicas":    1,
+						"currentRevision":    "web-1",
+						"updateRevision":     "web-2",
+					},
+				},
+			},
+			expected: manifestNotAvailableYetAction,
+			err:      nil,
+		},
 		"Test StatefulSet not available": {
 			gvr: utils.StatefulSettGVR,
 			obj: &unstructured.Unstructured{
@@ -1134,7 +1583,28 @@ func TestTrackResourceAvailability(t *testing.T) {
 			expected: manifestAvailableAction,
 			err:      nil,
 		},
-		"Test DaemonSet not available": {
+		"Test DaemonSet available with more pods than desired": {
+			gvr: utils.DaemonSettGVR,
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "apps/v1",
+					"kind":       "DaemonSet",
+					"metadata": map[string]interface{}{
+						"generation": 1,
+					},
+					"status": map[string]interface{}{
+						"observedGeneration":     1,
+						"numberAvailable":        3,
+						"desiredNumberScheduled": 2,
+						"currentNumberScheduled": 2,
+						"updatedNumberScheduled": 2,
+					},
+				},
+			},
+			expected: manifestAvailableAction,
+			err:      nil,
+		},
+		"Test DaemonSet progressing": {
 			gvr: utils.DaemonSettGVR,
 			obj: &unstructured.Unstructured{
 				Object: map[string]interface{}{
@@ -1152,7 +1622,28 @@ func TestTrackResourceAvailability(t *testing.T) {
 					},
 				},
 			},
-			expected: manifestNotAvailableYetAction,
+			expected: manifestProgressingAction,
+			err:      nil,
+		},
+		"Test DaemonSet rolling out an update": {
+			gvr: utils.DaemonSettGVR,
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "apps/v1",
+					"kind":       "DaemonSet",
+					"metadata": map[string]interface{}{
+						"generation": 2,
+					},
+					"status": map[string]interface{}{
+						"observedGeneration":     2,
+						"numberAvailable":        2,
+						"desiredNumberScheduled": 2,
+						"currentNumberScheduled": 2,
+						"updatedNumberScheduled": 1,
+					},
+				},
+			},
+			expected: manifestProgressingAction,
 			err:      nil,
 		},
 		"Test DaemonSet not observe current generation": {
@@ -1176,19 +1667,162 @@ func TestTrackResourceAvailability(t *testing.T) {
 			expected: manifestNotAvailableYetAction,
 			err:      nil,
 		},
-		"Test Job not trackable": {
+		"Test Job pending": {
 			gvr: utils.JobGVR,
 			obj: &unstructured.Unstructured{
 				Object: map[string]interface{}{
 					"apiVersion": "batch/v1",
 					"kind":       "Job",
 					"status": map[string]interface{}{
-						"succeeded": 2,
-						"ready":     1,
+						"active": 0,
 					},
 				},
 			},
-			expected: manifestNotTrackableAction,
+			expected: manifestNotAvailableYetAction,
+			err:      nil,
+		},
+		"Test Job running": {
+			gvr: utils.JobGVR,
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "batch/v1",
+					"kind":       "Job",
+					"status": map[string]interface{}{
+						"active":    1,
+						"startTime": "2024-05-01T10:00:00Z",
+					},
+				},
+			},
+			expected: manifestProgressingAction,
+			err:      nil,
+		},
+		"Test Job completed": {
+			gvr: utils.JobGVR,
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "batch/v1",
+					"kind":       "Job",
+					"status": map[string]interface{}{
+						"succeeded":      1,
+						"startTime":      "2024-05-01T10:00:00Z",
+						"completionTime": "2024-05-01T10:05:00Z",
+						"conditions": []interface{}{
+							map[string]interface{}{"type": "Complete", "status": "True"},
+						},
+					},
+				},
+			},
+			expected: manifestAvailableAction,
+			err:      nil,
+		},
+		"Test Job retrying after a failed pod": {
+			gvr: utils.JobGVR,
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "batch/v1",
+					"kind":       "Job",
+					"status": map[string]interface{}{
+						"active":    1,
+						"failed":    1,
+						"startTime": "2024-05-01T10:00:00Z",
+						"conditions": []interface{}{
+							map[string]interface{}{"type": "Failed", "status": "False"},
+						},
+					},
+				},
+			},
+			expected: manifestProgressingAction,
+			err:      nil,
+		},
+		"Test Job failed": {
+			gvr: utils.JobGVR,
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "batch/v1",
+					"kind":       "Job",
+					"status": map[string]interface{}{
+						"failed":    6,
+						"startTime": "2024-05-01T10:00:00Z",
+						"conditions": []interface{}{
+							map[string]interface{}{"type": "Failed", "status": "True", "reason": "BackoffLimitExceeded"},
+						},
+					},
+				},
+			},
+			expected: manifestFailedAction,
+			err:      nil,
+		},
+		"Test CronJob never scheduled": {
+			gvr: utils.CronJobGVR,
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "batch/v1",
+					"kind":       "CronJob",
+					"status":     map[string]interface{}{},
+				},
+			},
+			expected: manifestNotAvailableYetAction,
+			err:      nil,
+		},
+		"Test CronJob running its first job": {
+			gvr: utils.CronJobGVR,
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "batch/v1",
+					"kind":       "CronJob",
+					"status": map[string]interface{}{
+						"active":           []interface{}{map[string]interface{}{"name": "backup-1"}},
+						"lastScheduleTime": "2024-05-01T10:00:00Z",
+					},
+				},
+			},
+			expected: manifestProgressingAction,
+			err:      nil,
+		},
+		"Test CronJob succeeded in the last schedule": {
+			gvr: utils.CronJobGVR,
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "batch/v1",
+					"kind":       "CronJob",
+					"status": map[string]interface{}{
+						"lastScheduleTime":   "2024-05-01T10:00:00Z",
+						"lastSuccessfulTime": "2024-05-01T10:05:00Z",
+					},
+				},
+			},
+			expected: manifestAvailableAction,
+			err:      nil,
+		},
+		"Test CronJob running after an earlier success": {
+			gvr: utils.CronJobGVR,
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "batch/v1",
+					"kind":       "CronJob",
+					"status": map[string]interface{}{
+						"active":             []interface{}{map[string]interface{}{"name": "backup-2"}},
+						"lastScheduleTime":   "2024-05-01T11:00:00Z",
+						"lastSuccessfulTime": "2024-05-01T10:05:00Z",
+					},
+				},
+			},
+			expected: manifestAvailableAction,
+			err:      nil,
+		},
+		"Test CronJob not succeeded in the last schedule": {
+			gvr: utils.CronJobGVR,
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "batch/v1",
+					"kind":       "CronJob",
+					"status": map[string]interface{}{
+						"lastScheduleTime":   "2024-05-01T11:00:00Z",
+						"lastSuccessfulTime": "2024-05-01T10:05:00Z",
+					},
+				},
+			},
+			expected: manifestNotAvailableYetAction,
 			err:      nil,
 		},
 		"Test configMap is considered ready after it is applied": {
@@ -1970,8 +2604,9 @@ func TestApplyManifest(t *testing.T) {
 				},
 			}
 			applyStrategy := &fleetv1beta1.ApplyStrategy{Type: fleetv1beta1.ApplyStrategyTypeClientSideApply}
-			resultList := r.applyManifests(context.Background(), testCase.manifestList, ownerRef, applyStrategy)
+			resultList := r.applyManifests(context.Background(), testCase.manifestList, ownerRef, applyStrategy, nil, nil, "", nil, nil, nil)
 			for _, result := range resultList {
+				assert.Falsef(t, result.applyCompletedAt.Before(result.applyStartedAt), "Testcase %s: apply completed before it started", testName)
 				if testCase.wantErr != nil {
 					assert.Containsf(t, result.applyErr.Error(), testCase.wantErr.Error(), "Incorrect error for Testcase %s", testName)
 				} else {
@@ -2015,7 +2650,11 @@ func TestReconcile(t *testing.T) {
 					Reason: metav1.StatusReasonNotFound,
 				}}
 		}
-		o, _ := obj.(*fleetv1beta1.Work)
+		o, ok := obj.(*fleetv1beta1.Work)
+		if !ok {
+			// there is no apply strategy defaults config map
+			return apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, key.Name)
+		}
 		*o = fleetv1beta1.Work{
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace:  key.Namespace,
@@ -2137,7 +2776,10 @@ func TestReconcile(t *testing.T) {
 			reconciler: ApplyWorkReconciler{
 				client: &test.MockClient{
 					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
-						o, _ := obj.(*fleetv1beta1.Work)
+						o, ok := obj.(*fleetv1beta1.Work)
+						if !ok {
+							return apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, key.Name)
+						}
 						*o = fleetv1beta1.Work{
 							ObjectMeta: metav1.ObjectMeta{
 								Namespace: workNamespace,
@@ -2294,6 +2936,10 @@ func TestReconcile(t *testing.T) {
 		t.Run(testName, func(t *testing.T) {
 			r := testCase.reconciler
 			r.workNameSpace = workNamespace
+			// there are no annotation propagation policies in the work namespace.
+			if mockClient, ok := r.client.(*test.MockClient); ok && mockClient.MockList == nil {
+				mockClient.MockList = test.NewMockListFn(nil)
+			}
 			r.appliers = map[fleetv1beta1.ApplyStrategyType]Applier{
 				fleetv1beta1.ApplyStrategyTypeClientSideApply: &ClientSideApplier{
 					HubClient:          r.client,
@@ -2363,3 +3009,294 @@ func createLargeObj() (*unstructured.Unstructured, error) {
 	}
 	return &largeObj, nil
 }
+
+// concurrencyTrackingApplier applies the manifests slowly and tracks how many applies of the same resource overlap.
+type concurrencyTrackingApplier struct {
+	mu        sync.Mutex
+	active    map[string]int
+	maxActive int
+}
+
+func (a *concurrencyTrackingApplier) ApplyUnstructured(_ context.Context, _ *fleetv1beta1.ApplyStrategy, _ schema.GroupVersionResource,
+	manifestObj *unstructured.Unstructured) (*unstructured.Unstructured, ApplyAction, error) {
+	key := manifestObj.GetNamespace() + "/" + manifestObj.GetName()
+	a.mu.Lock()
+	a.active[key]++
+	if a.active[key] > a.maxActive {
+		a.maxActive = a.active[key]
+	}
+	a.mu.Unlock()
+	time.Sleep(10 * time.Millisecond)
+	a.mu.Lock()
+	a.active[key]--
+	a.mu.Unlock()
+	return manifestObj, manifestServerSideAppliedAction, nil
+}
+
+func TestApplyManifestsSharedResource(t *testing.T) {
+	raw, err := json.Marshal(liveDeployment("shared", ""))
+	if err != nil {
+		t.Fatalf("failed to marshal the deployment: %v", err)
+	}
+	manifests := []fleetv1beta1.Manifest{{RawExtension: runtime.RawExtension{Raw: raw}}}
+	applyStrategy := &fleetv1beta1.ApplyStrategy{Type: fleetv1beta1.ApplyStrategyTypeServerSideApply}
+	applier := &concurrencyTrackingApplier{active: map[string]int{}}
+	resourceLocks := resourcelock.NewRegistry()
+	r := &ApplyWorkReconciler{
+		restMapper:    testMapper{},
+		appliers:      map[fleetv1beta1.ApplyStrategyType]Applier{fleetv1beta1.ApplyStrategyTypeServerSideApply: applier},
+		resourceLocks: resourceLocks,
+	}
+
+	// the works reconcile at the same time, all of them applying the same deployment.
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := 0; i < 5; i++ {
+		owner := ownerRef
+		owner.Name = fmt.Sprintf("work-%d", i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			results := r.applyManifests(context.Background(), manifests, owner, applyStrategy, nil, nil, "", nil, nil, nil)
+			if results[0].applyErr != nil {
+				t.Errorf("applyManifests() = %v, want no error", results[0].applyErr)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if applier.maxActive != 1 {
+		t.Errorf("applyManifests() applied the shared resource %d times at once, want 1", applier.maxActive)
+	}
+	if resourceLocks.Len() != 0 {
+		t.Errorf("applyManifests() left %d resource locks held, want 0", resourceLocks.Len())
+	}
+}
+
+func TestApplyOrder(t *testing.T) {
+	manifests := []fleetv1beta1.Manifest{
+		{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"app"}}`)}},
+		{RawExtension: runtime.RawExtension{Raw: []byte(`not a manifest`)}},
+		{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"settings","namespace":"app"}}`)}},
+		{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"app"}}`)}},
+	}
+	// the namespace is applied before the resources in it and the undecodable manifest last.
+	if diff := cmp.Diff([]int{3, 0, 2, 1}, applyOrder(manifests)); diff != "" {
+		t.Errorf("applyOrder() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestApplyWaves(t *testing.T) {
+	manifests := []fleetv1beta1.Manifest{
+		{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"app"}}`)}},
+		{RawExtension: runtime.RawExtension{Raw: []byte(`not a manifest`)}},
+		{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"settings","namespace":"app"}}`)}},
+		{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"app"}}`)}},
+	}
+	// the resources in the namespace do not depend on each other so they are applied together.
+	if diff := cmp.Diff([][]int{{3}, {0, 2}, {1}}, applyWaves(manifests)); diff != "" {
+		t.Errorf("applyWaves() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// poolTrackingApplier applies the manifests slowly and tracks how many applies overlap and whether a resource is
+// applied before the namespace it is in.
+type poolTrackingApplier struct {
+	delay                  time.Duration
+	mu                     sync.Mutex
+	active                 int
+	maxActive              int
+	namespaceApplied       bool
+	appliedBeforeNamespace int
+}
+
+func (a *poolTrackingApplier) ApplyUnstructured(_ context.Context, _ *fleetv1beta1.ApplyStrategy, _ schema.GroupVersionResource,
+	manifestObj *unstructured.Unstructured) (*unstructured.Unstructured, ApplyAction, error) {
+	a.mu.Lock()
+	a.active++
+	if a.active > a.maxActive {
+		a.maxActive = a.active
+	}
+	if manifestObj.GetNamespace() != "" && !a.namespaceApplied {
+		a.appliedBeforeNamespace++
+	}
+	a.mu.Unlock()
+	time.Sleep(a.delay)
+	a.mu.Lock()
+	a.active--
+	if manifestObj.GetKind() == "Namespace" {
+		a.namespaceApplied = true
+	}
+	a.mu.Unlock()
+	return manifestObj, manifestServerSideAppliedAction, nil
+}
+
+// newPoolTrackingReconciler returns a reconciler which applies the manifests of a work with the given worker pool
+// size through the applier.
+func newPoolTrackingReconciler(applier Applier, workerPoolSize int) *ApplyWorkReconciler {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(v1.SchemeGroupVersion.WithKind("Namespace"), meta.RESTScopeRoot)
+	mapper.Add(v1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+	return &ApplyWorkReconciler{
+		restMapper:     mapper,
+		appliers:       map[fleetv1beta1.ApplyStrategyType]Applier{fleetv1beta1.ApplyStrategyTypeServerSideApply: applier},
+		workerPoolSize: workerPoolSize,
+	}
+}
+
+// configMapManifests returns the manifests of the given number of config maps in the app namespace, following the
+// manifest of the namespace.
+func configMapManifests(count int) []fleetv1beta1.Manifest {
+	manifests := []fleetv1beta1.Manifest{
+		{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"app"}}`)}},
+	}
+	for i := 0; i < count; i++ {
+		manifests = append(manifests, fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{
+			Raw: []byte(fmt.Sprintf(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"config-%d","namespace":"app"}}`, i)),
+		}})
+	}
+	return manifests
+}
+
+func TestApplyManifestsConcurrently(t *testing.T) {
+	applyStrategy := &fleetv1beta1.ApplyStrategy{Type: fleetv1beta1.ApplyStrategyTypeServerSideApply}
+	// the config maps come before the namespace in the work.
+	manifests := configMapManifests(8)
+	manifests = append(manifests[1:], manifests[0])
+	tests := map[string]struct {
+		workerPoolSize int
+		wantMaxActive  int
+	}{
+		"pool of one": {
+			workerPoolSize: 1,
+			wantMaxActive:  1,
+		},
+		"pool unset": {
+			workerPoolSize: 0,
+			wantMaxActive:  1,
+		},
+		"pool of four": {
+			workerPoolSize: 4,
+			wantMaxActive:  4,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			applier := &poolTrackingApplier{delay: 10 * time.Millisecond}
+			r := newPoolTrackingReconciler(applier, tt.workerPoolSize)
+			results := r.applyManifests(context.Background(), manifests, ownerRef, applyStrategy, nil, nil, "", nil, nil, nil)
+			for i, result := range results {
+				if result.applyErr != nil {
+					t.Errorf("applyManifests() result %d = %v, want no error", i, result.applyErr)
+				}
+				if result.identifier.Ordinal != i {
+					t.Errorf("applyManifests() result %d is of the manifest %d, want the results in the order of the manifests", i, result.identifier.Ordinal)
+				}
+			}
+			if applier.maxActive != tt.wantMaxActive {
+				t.Errorf("applyManifests() applied %d manifests at once, want %d", applier.maxActive, tt.wantMaxActive)
+			}
+			if applier.appliedBeforeNamespace != 0 {
+				t.Errorf("applyManifests() applied %d config maps before their namespace, want 0", applier.appliedBeforeNamespace)
+			}
+		})
+	}
+}
+
+// BenchmarkApplyManifestsConcurrently measures applying a work of 20 config maps, each of which takes 5ms to apply,
+// one at a time and with a worker pool.
+func BenchmarkApplyManifestsConcurrently(b *testing.B) {
+	applyStrategy := &fleetv1beta1.ApplyStrategy{Type: fleetv1beta1.ApplyStrategyTypeServerSideApply}
+	manifests := configMapManifests(20)
+	for _, workerPoolSize := range []int{1, DefaultManifestWorkerPoolSize, 10} {
+		b.Run(fmt.Sprintf("workers=%d", workerPoolSize), func(b *testing.B) {
+			r := newPoolTrackingReconciler(&poolTrackingApplier{delay: 5 * time.Millisecond}, workerPoolSize)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				r.applyManifests(context.Background(), manifests, ownerRef, applyStrategy, nil, nil, "", nil, nil, nil)
+			}
+		})
+	}
+}
+
+func TestReconcileMetrics(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the fleet scheme: %v", err)
+	}
+	if err := v1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the core scheme: %v", err)
+	}
+	workKey := types.NamespacedName{Name: "test-metrics-work", Namespace: "fleet-member-test"}
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: workKey.Name, Namespace: workKey.Namespace, Generation: 1},
+		Spec:       fleetv1beta1.WorkSpec{Workload: versionedWorkload(t, "v1")},
+	}
+	hubClient := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(work).WithStatusSubresource(&fleetv1beta1.Work{}).Build()
+	applier := &forceRecordingApplier{}
+	r := &ApplyWorkReconciler{
+		client:             hubClient,
+		spokeDynamicClient: fake.NewSimpleDynamicClient(runtime.NewScheme(), liveDeployment("deploy", "deploy-uid")),
+		spokeClient:        clientfake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&fleetv1beta1.AppliedWork{}).Build(),
+		restMapper:         testMapper{},
+		recorder:           utils.NewFakeRecorder(100),
+		joined:             atomic.NewBool(true),
+		appliers: map[fleetv1beta1.ApplyStrategyType]Applier{
+			fleetv1beta1.ApplyStrategyTypeClientSideApply: applier,
+		},
+	}
+	reconcile := func() {
+		t.Helper()
+		if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: workKey}); err != nil {
+			t.Fatalf("Reconcile() = %v, want no error", err)
+		}
+	}
+	metrics.WorkReconcileDurationSeconds.Reset()
+	gcBefore := testutil.ToFloat64(metrics.WorkGarbageCollectedResourcesTotal.WithLabelValues(fleetv1beta1.GroupVersion.Group,
+		fleetv1beta1.GroupVersion.Version, fleetv1beta1.AppliedWorkKind))
+
+	reconcile()
+	if got := testutil.CollectAndCount(metrics.WorkReconcileDurationSeconds); got != 1 {
+		t.Errorf("Reconcile() observed the reconcile durations in %d series, want 1", got)
+	}
+
+	// the work is garbage collected along with its appliedWork once it is deleted.
+	if err := hubClient.Delete(context.Background(), work); err != nil {
+		t.Fatalf("failed to delete the work: %v", err)
+	}
+	reconcile()
+	gcAfter := testutil.ToFloat64(metrics.WorkGarbageCollectedResourcesTotal.WithLabelValues(fleetv1beta1.GroupVersion.Group,
+		fleetv1beta1.GroupVersion.Version, fleetv1beta1.AppliedWorkKind))
+	if got := gcAfter - gcBefore; got != 1 {
+		t.Errorf("Reconcile() counted %v garbage collected appliedWorks, want 1", got)
+	}
+
+	var m dto.Metric
+	if err := metrics.WorkReconcileDurationSeconds.WithLabelValues(workKey.Namespace, workKey.Name).(prometheus.Histogram).Write(&m); err != nil {
+		t.Fatalf("failed to read the histogram: %v", err)
+	}
+	if got := m.GetHistogram().GetSampleCount(); got != 2 {
+		t.Errorf("Reconcile() observed %d reconcile durations of the work, want 2", got)
+	}
+
+	// the work is gone once its finalizer is removed.
+	reconcile()
+	if got := testutil.CollectAndCount(metrics.WorkReconcileDurationSeconds); got != 0 {
+		t.Errorf("Reconcile() kept the reconcile durations in %d series after the work is gone, want 0", got)
+	}
+
+	// the series of the per-work metrics are deleted along with the work.
+	perWorkMetrics := map[string]*prometheus.GaugeVec{
+		"fleet_work_desired_state_percentage":    metrics.WorkDesiredStatePercentage,
+		"fleet_work_rollout_progress_percentage": metrics.WorkRolloutProgressPercentage,
+		"fleet_work_spec_size_bytes":             metrics.WorkSpecSizeBytes,
+		"fleet_work_status_size_bytes":           metrics.WorkStatusSizeBytes,
+	}
+	for name, gauge := range perWorkMetrics {
+		if gauge.DeleteLabelValues(workKey.Namespace, workKey.Name) {
+			t.Errorf("Reconcile() kept the %s series of the deleted work", name)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/metrics"
+)
+
+const (
+	// deferredWorkRequeueDelay is how long a work whose estimated apply cost exceeds the threshold waits before it
+	// is applied, so that the cheaper works are not starved by it.
+	deferredWorkRequeueDelay = 15 * time.Second
+)
+
+// manifestKey identifies a manifest in the member cluster regardless of its version.
+type manifestKey struct {
+	group, kind, namespace, name string
+}
+
+// estimateAPICalls estimates the number of the member cluster API server calls the apply phase of the work would
+// make, which is
+//   - one create/patch call per manifest;
+//   - one call to update the appliedWork status;
+//   - one delete call per applied resource that is no longer in the work.
+func estimateAPICalls(work *fleetv1beta1.Work, appliedWork *fleetv1beta1.AppliedWork) int {
+	manifests := work.Spec.Workload.Manifests
+	inWork := make(map[manifestKey]bool, len(manifests))
+	for _, manifest := range manifests {
+		var obj unstructured.Unstructured
+		if err := obj.UnmarshalJSON(manifest.Raw); err != nil {
+			// the apply phase reports the decoding error without calling the API server.
+			continue
+		}
+		gvk := obj.GroupVersionKind()
+		inWork[manifestKey{group: gvk.Group, kind: gvk.Kind, namespace: obj.GetNamespace(), name: obj.GetName()}] = true
+	}
+
+	gcCalls := 0
+	if appliedWork != nil {
+		for _, res := range appliedWork.Status.AppliedResources {
+			if !inWork[manifestKey{group: res.Group, kind: res.Kind, namespace: res.Namespace, name: res.Name}] {
+				gcCalls++
+			}
+		}
+	}
+	return len(manifests) + 1 + gcCalls
+}
+
+// costLimiter defers the works whose estimated apply cost exceeds the threshold once per generation.
+type costLimiter struct {
+	// maxAPICallsPerWork is the threshold of the estimated API server calls; 0 disables the deferral.
+	maxAPICallsPerWork int
+
+	mu sync.Mutex
+	// observed keeps the generation of the works whose estimated cost has already been recorded.
+	observed map[types.NamespacedName]int64
+	// deferred keeps the generation of the works which have already been deferred.
+	deferred map[types.NamespacedName]int64
+}
+
+func newCostLimiter(maxAPICallsPerWork int) *costLimiter {
+	return &costLimiter{
+		maxAPICallsPerWork: maxAPICallsPerWork,
+		observed:           make(map[types.NamespacedName]int64),
+		deferred:           make(map[types.NamespacedName]int64),
+	}
+}
+
+// shouldDefer records the estimated cost of applying the work once per generation and returns true if the work
+// should be moved behind the other works. A deferred work is applied the next time it is reconciled with the same
+// generation.
+func (l *costLimiter) shouldDefer(work *fleetv1beta1.Work, appliedWork *fleetv1beta1.AppliedWork) bool {
+	cost := estimateAPICalls(work, appliedWork)
+
+	workKey := types.NamespacedName{Name: work.Name, Namespace: work.Namespace}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if generation, ok := l.observed[workKey]; !ok || generation != work.Generation {
+		metrics.WorkEstimatedAPICalls.Observe(float64(cost))
+		l.observed[workKey] = work.Generation
+	}
+	if l.maxAPICallsPerWork <= 0 || cost <= l.maxAPICallsPerWork {
+		delete(l.deferred, workKey)
+		return false
+	}
+	if generation, ok := l.deferred[workKey]; ok && generation == work.Generation {
+		delete(l.deferred, workKey)
+		return false
+	}
+	l.deferred[workKey] = work.Generation
+	klog.V(2).InfoS("Deferring the work as its estimated apply cost exceeds the threshold", "work", klog.KObj(work),
+		"estimatedAPICalls", cost, "maxAPICallsPerWork", l.maxAPICallsPerWork)
+	return true
+}
+
+// forget drops what the limiter keeps about the deleted work.
+func (l *costLimiter) forget(work *fleetv1beta1.Work) {
+	workKey := types.NamespacedName{Name: work.Name, Namespace: work.Namespace}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.observed, workKey)
+	delete(l.deferred, workKey)
+}
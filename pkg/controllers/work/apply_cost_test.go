@@ -0,0 +1,156 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"fmt"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/metrics"
+)
+
+func configMapManifest(name string) fleetv1beta1.Manifest {
+	return fleetv1beta1.Manifest{
+		RawExtension: runtime.RawExtension{
+			Raw: []byte(fmt.Sprintf(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":%q,"namespace":"default"}}`, name)),
+		},
+	}
+}
+
+func appliedConfigMap(name string) fleetv1beta1.AppliedResourceMeta {
+	return fleetv1beta1.AppliedResourceMeta{
+		WorkResourceIdentifier: fleetv1beta1.WorkResourceIdentifier{
+			Version:   "v1",
+			Kind:      "ConfigMap",
+			Resource:  "configmaps",
+			Namespace: "default",
+			Name:      name,
+		},
+	}
+}
+
+func TestEstimateAPICalls(t *testing.T) {
+	tests := map[string]struct {
+		manifests        []fleetv1beta1.Manifest
+		appliedResources []fleetv1beta1.AppliedResourceMeta
+		noAppliedWork    bool
+		want             int
+	}{
+		"empty work": {
+			want: 1,
+		},
+		"new work without applied work": {
+			manifests:     []fleetv1beta1.Manifest{configMapManifest("cm-1"), configMapManifest("cm-2")},
+			noAppliedWork: true,
+			want:          3,
+		},
+		"all applied resources are still in the work": {
+			manifests:        []fleetv1beta1.Manifest{configMapManifest("cm-1"), configMapManifest("cm-2"), configMapManifest("cm-3")},
+			appliedResources: []fleetv1beta1.AppliedResourceMeta{appliedConfigMap("cm-1"), appliedConfigMap("cm-2")},
+			want:             4,
+		},
+		"stale applied resources are garbage collected": {
+			manifests:        []fleetv1beta1.Manifest{configMapManifest("cm-1")},
+			appliedResources: []fleetv1beta1.AppliedResourceMeta{appliedConfigMap("cm-1"), appliedConfigMap("cm-2"), appliedConfigMap("cm-3")},
+			want:             4,
+		},
+		"all the manifests are removed": {
+			appliedResources: []fleetv1beta1.AppliedResourceMeta{appliedConfigMap("cm-1"), appliedConfigMap("cm-2")},
+			want:             3,
+		},
+		"undecodable manifest still counts as one call": {
+			manifests: []fleetv1beta1.Manifest{{RawExtension: runtime.RawExtension{Raw: []byte("not json")}}},
+			want:      2,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			work := &fleetv1beta1.Work{
+				Spec: fleetv1beta1.WorkSpec{
+					Workload: fleetv1beta1.WorkloadTemplate{Manifests: tt.manifests},
+				},
+			}
+			var appliedWork *fleetv1beta1.AppliedWork
+			if !tt.noAppliedWork {
+				appliedWork = &fleetv1beta1.AppliedWork{
+					Status: fleetv1beta1.AppliedWorkStatus{AppliedResources: tt.appliedResources},
+				}
+			}
+			assert.Equal(t, tt.want, estimateAPICalls(work, appliedWork), "estimateAPICalls() mismatch")
+		})
+	}
+}
+
+func TestCostLimiterShouldDefer(t *testing.T) {
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-work",
+			Namespace:  "fleet-member-test",
+			Generation: 1,
+		},
+		Spec: fleetv1beta1.WorkSpec{
+			Workload: fleetv1beta1.WorkloadTemplate{
+				Manifests: []fleetv1beta1.Manifest{configMapManifest("cm-1"), configMapManifest("cm-2")},
+			},
+		},
+	}
+
+	disabled := newCostLimiter(0)
+	assert.False(t, disabled.shouldDefer(work, nil), "shouldDefer() should not defer when the threshold is disabled")
+
+	cheap := newCostLimiter(3)
+	assert.False(t, cheap.shouldDefer(work, nil), "shouldDefer() should not defer a work within the threshold")
+
+	expensive := newCostLimiter(2)
+	assert.True(t, expensive.shouldDefer(work, nil), "shouldDefer() should defer a work above the threshold")
+	assert.False(t, expensive.shouldDefer(work, nil), "shouldDefer() should apply a deferred work on the next reconcile")
+	work.Generation++
+	assert.True(t, expensive.shouldDefer(work, nil), "shouldDefer() should defer a new generation above the threshold again")
+}
+
+// observedAPICallEstimates returns the number of the estimated apply costs recorded.
+func observedAPICallEstimates(t *testing.T) uint64 {
+	t.Helper()
+	var m dto.Metric
+	if err := metrics.WorkEstimatedAPICalls.Write(&m); err != nil {
+		t.Fatalf("failed to read the histogram: %v", err)
+	}
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestCostLimiterObservesOncePerGeneration(t *testing.T) {
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-work",
+			Namespace:  "fleet-member-test",
+			Generation: 1,
+		},
+		Spec: fleetv1beta1.WorkSpec{
+			Workload: fleetv1beta1.WorkloadTemplate{
+				Manifests: []fleetv1beta1.Manifest{configMapManifest("cm-1"), configMapManifest("cm-2")},
+			},
+		},
+	}
+	limiter := newCostLimiter(2)
+	before := observedAPICallEstimates(t)
+
+	assert.True(t, limiter.shouldDefer(work, nil), "shouldDefer() should defer a work above the threshold")
+	assert.False(t, limiter.shouldDefer(work, nil), "shouldDefer() should apply a deferred work on the next reconcile")
+	assert.Equal(t, before+1, observedAPICallEstimates(t), "the deferred work should be recorded once for its generation")
+	work.Generation++
+	limiter.shouldDefer(work, nil)
+	assert.Equal(t, before+2, observedAPICallEstimates(t), "a new generation of the work should be recorded again")
+
+	limiter.forget(work)
+	assert.Empty(t, limiter.observed, "forget() should drop the recorded generation of the work")
+	assert.Empty(t, limiter.deferred, "forget() should drop the deferral of the work")
+}
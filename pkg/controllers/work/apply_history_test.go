@@ -0,0 +1,128 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/atomic"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// alternatingApplier fails every other apply call; the applied resource gets a new generation on each success.
+type alternatingApplier struct {
+	calls int
+}
+
+func (a *alternatingApplier) ApplyUnstructured(_ context.Context, _ *fleetv1beta1.ApplyStrategy, _ schema.GroupVersionResource,
+	manifestObj *unstructured.Unstructured) (*unstructured.Unstructured, ApplyAction, error) {
+	a.calls++
+	if a.calls%2 == 0 {
+		return nil, errorApplyAction, errors.New("admission denied")
+	}
+	applied := manifestObj.DeepCopy()
+	applied.SetUID("deploy-uid")
+	applied.SetGeneration(int64(a.calls))
+	return applied, manifestThreeWayMergePatchAction, nil
+}
+
+func TestReconcileKeepsApplyHistory(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the fleet scheme: %v", err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the core scheme: %v", err)
+	}
+	workKey := types.NamespacedName{Name: "test-work", Namespace: "fleet-member-test"}
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: workKey.Name, Namespace: workKey.Namespace, Generation: 1},
+		Spec:       fleetv1beta1.WorkSpec{Workload: versionedWorkload(t, "v1")},
+	}
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(work).WithStatusSubresource(&fleetv1beta1.Work{}).Build()
+	spokeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&fleetv1beta1.AppliedWork{}).Build()
+	applier := &alternatingApplier{}
+	r := &ApplyWorkReconciler{
+		client:             hubClient,
+		spokeDynamicClient: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), liveDeployment("deploy", "deploy-uid")),
+		spokeClient:        spokeClient,
+		restMapper:         testMapper{},
+		recorder:           record.NewFakeRecorder(100),
+		joined:             atomic.NewBool(true),
+		appliers: map[fleetv1beta1.ApplyStrategyType]Applier{
+			fleetv1beta1.ApplyStrategyTypeClientSideApply: applier,
+			fleetv1beta1.ApplyStrategyTypeServerSideApply: applier,
+		},
+	}
+
+	// the failed applies requeue the work with an error, which does not matter here.
+	for i := 0; i < 6; i++ {
+		_, _ = r.Reconcile(context.Background(), ctrl.Request{NamespacedName: workKey})
+	}
+	if applier.calls != 6 {
+		t.Fatalf("the manifest is applied %d times, want 6", applier.calls)
+	}
+
+	got := &fleetv1beta1.Work{}
+	if err := hubClient.Get(context.Background(), workKey, got); err != nil {
+		t.Fatalf("failed to get the work: %v", err)
+	}
+	if len(got.Status.ManifestConditions) != 1 {
+		t.Fatalf("work status has %d manifest conditions, want 1", len(got.Status.ManifestConditions))
+	}
+	history := got.Status.ManifestConditions[0].ApplyHistory
+	type entry struct {
+		Result     fleetv1beta1.ManifestProcessingApplyResultType
+		Generation int64
+	}
+	var gotEntries []entry
+	for i, e := range history {
+		gotEntries = append(gotEntries, entry{Result: e.Result, Generation: e.ManifestGeneration})
+		if e.ApplyTime.IsZero() {
+			t.Errorf("apply history entry %d has no apply time", i)
+		}
+		if i > 0 && e.ApplyTime.Before(&history[i-1].ApplyTime) {
+			t.Errorf("apply history entry %d is older than the entry before it", i)
+		}
+	}
+	// the 1st apply is displaced by the 6th one; the live deployment is tracked as up to date after the applies.
+	wantEntries := []entry{
+		{Result: ManifestApplyFailedReason},
+		{Result: ManifestAlreadyUpToDateReason, Generation: 3},
+		{Result: ManifestApplyFailedReason},
+		{Result: ManifestAlreadyUpToDateReason, Generation: 5},
+		{Result: ManifestApplyFailedReason},
+	}
+	if diff := cmp.Diff(wantEntries, gotEntries); diff != "" {
+		t.Errorf("apply history mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAppendApplyHistory(t *testing.T) {
+	existing := &fleetv1beta1.ManifestCondition{
+		ApplyHistory: []fleetv1beta1.ApplyHistoryEntry{{Result: ManifestApplyFailedReason}},
+	}
+	manifestCondition := &fleetv1beta1.ManifestCondition{}
+	// a manifest which is not applied, e.g. skipped, keeps its history as is.
+	appendApplyHistory(manifestCondition, existing, applyResult{action: manifestSkippedAction})
+	if diff := cmp.Diff(existing.ApplyHistory, manifestCondition.ApplyHistory); diff != "" {
+		t.Errorf("appendApplyHistory() of a manifest not applied mismatch (-want +got):\n%s", diff)
+	}
+}
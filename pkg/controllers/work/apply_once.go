@@ -0,0 +1,59 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+// manifestSkippedAsAlreadyExistsMessage is the message of the apply condition of a manifest which is not applied as
+// the apply strategy applies the manifests only once and the resource already exists.
+const manifestSkippedAsAlreadyExistsMessage = "Manifest is not applied as it is applied only once and the resource already exists"
+
+// skipExistingResource returns the resource of the manifest in the member cluster along with its availability if
+// the apply strategy applies the manifests only once and the resource was already created by the work, in which case
+// skipped is true and the manifest must not be applied. The resources which are not created by the work, e.g. the
+// ones created outside of fleet, are left to the apply so that their ownership is validated as usual.
+func (r *ApplyWorkReconciler) skipExistingResource(ctx context.Context, gvr schema.GroupVersionResource, manifestObj *unstructured.Unstructured,
+	applyStrategy *fleetv1beta1.ApplyStrategy) (curObj *unstructured.Unstructured, action ApplyAction, skipped bool, err error) {
+	// the resources with generated names are created anew on every apply so they never exist yet.
+	if applyStrategy.WhenToApply != fleetv1beta1.WhenToApplyTypeOnce || manifestObj.GetName() == "" {
+		return nil, "", false, nil
+	}
+	curObj, err = r.spokeDynamicClient.Resource(gvr).Namespace(manifestObj.GetNamespace()).Get(ctx, manifestObj.GetName(), metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		return nil, "", false, nil
+	case err != nil:
+		klog.ErrorS(err, "Failed to get the resource to check if it already exists", "gvr", gvr, "manifest", klog.KObj(manifestObj))
+		return nil, errorApplyAction, false, controller.NewAPIServerError(false, err)
+	case !isCreatedByManifest(curObj, manifestObj):
+		return nil, "", false, nil
+	}
+	action, err = trackResourceAvailability(gvr, curObj)
+	return curObj, action, err == nil, err
+}
+
+// isCreatedByManifest returns true if the resource is owned by the appliedWork which the manifest is applied for.
+func isCreatedByManifest(curObj, manifestObj *unstructured.Unstructured) bool {
+	for _, owner := range manifestObj.GetOwnerReferences() {
+		for _, curOwner := range curObj.GetOwnerReferences() {
+			if owner.UID == curOwner.UID {
+				return true
+			}
+		}
+	}
+	return false
+}
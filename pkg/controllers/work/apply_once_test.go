@@ -0,0 +1,144 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// replacingApplier creates or replaces the resources with the manifests.
+type replacingApplier struct {
+	dynamicClient dynamic.Interface
+}
+
+func (a *replacingApplier) ApplyUnstructured(ctx context.Context, _ *fleetv1beta1.ApplyStrategy, gvr schema.GroupVersionResource,
+	manifestObj *unstructured.Unstructured) (*unstructured.Unstructured, ApplyAction, error) {
+	resourceClient := a.dynamicClient.Resource(gvr).Namespace(manifestObj.GetNamespace())
+	curObj, err := resourceClient.Get(ctx, manifestObj.GetName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		obj, err := resourceClient.Create(ctx, manifestObj, metav1.CreateOptions{})
+		return obj, manifestCreatedAction, err
+	}
+	if err != nil {
+		return nil, errorApplyAction, err
+	}
+	manifestObj.SetResourceVersion(curObj.GetResourceVersion())
+	obj, err := resourceClient.Update(ctx, manifestObj, metav1.UpdateOptions{})
+	return obj, manifestServerSideAppliedAction, err
+}
+
+func TestApplyManifestsOnce(t *testing.T) {
+	ctx := context.Background()
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	r := &ApplyWorkReconciler{
+		spokeDynamicClient: dynamicClient,
+		restMapper:         testMapper{},
+		appliers: map[fleetv1beta1.ApplyStrategyType]Applier{
+			fleetv1beta1.ApplyStrategyTypeClientSideApply: &replacingApplier{dynamicClient: dynamicClient},
+		},
+	}
+	strategy := &fleetv1beta1.ApplyStrategy{
+		Type:        fleetv1beta1.ApplyStrategyTypeClientSideApply,
+		WhenToApply: fleetv1beta1.WhenToApplyTypeOnce,
+	}
+	owner := metav1.OwnerReference{
+		APIVersion: fleetv1beta1.GroupVersion.String(),
+		Kind:       "AppliedWork",
+		Name:       "test-work",
+		UID:        "applied-work-uid",
+	}
+	deploymentGVR := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	work := &fleetv1beta1.Work{ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: "fleet-member-test"}}
+
+	reconcile := func(version string) applyResult {
+		work.Spec.Workload.Manifests = []fleetv1beta1.Manifest{labeledDeploymentManifest(t, version)}
+		results := r.applyManifests(ctx, work.Spec.Workload.Manifests, owner, strategy, nil, nil, "", nil, nil, nil)
+		constructWorkCondition(results, work)
+		return results[0]
+	}
+	liveVersion := func() string {
+		obj, err := dynamicClient.Resource(deploymentGVR).Namespace("default").Get(ctx, "web", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get the deployment: %v", err)
+		}
+		return obj.GetLabels()["app.kubernetes.io/version"]
+	}
+	setLiveVersion := func(version string) {
+		obj, err := dynamicClient.Resource(deploymentGVR).Namespace("default").Get(ctx, "web", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get the deployment: %v", err)
+		}
+		obj.SetLabels(map[string]string{"app.kubernetes.io/version": version})
+		if _, err := dynamicClient.Resource(deploymentGVR).Namespace("default").Update(ctx, obj, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("failed to update the deployment: %v", err)
+		}
+	}
+
+	// the manifest is applied to create the resource.
+	if result := reconcile("v1"); result.applyErr != nil || result.skippedAsAlreadyExists {
+		t.Fatalf("applyManifests() result = %+v, want the resource created", result)
+	}
+	if got := liveVersion(); got != "v1" {
+		t.Fatalf("deployment version = %s, want v1", got)
+	}
+
+	// the drift introduced after the creation is never corrected, neither by the same manifest nor by a new one.
+	setLiveVersion("drifted")
+	for _, version := range []string{"v1", "v2"} {
+		result := reconcile(version)
+		if result.applyErr != nil || !result.skippedAsAlreadyExists {
+			t.Fatalf("applyManifests() result of %s = %+v, want the manifest skipped", version, result)
+		}
+		if got := liveVersion(); got != "drifted" {
+			t.Errorf("deployment version after applying %s = %s, want the drift kept", version, got)
+		}
+		manifestCond := work.Status.ManifestConditions[0]
+		applyCond := meta.FindStatusCondition(manifestCond.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+		wantReason := string(fleetv1beta1.ManifestProcessingApplyResultTypeSkippedAsAlreadyExists)
+		if applyCond.Status != metav1.ConditionTrue || applyCond.Reason != wantReason {
+			t.Errorf("Applied condition of %s = %s/%s, want %s/%s", version, applyCond.Status, applyCond.Reason, metav1.ConditionTrue, wantReason)
+		}
+		if version == "v2" && manifestCond.LastAppliedHash == manifestContentHash(work.Spec.Workload.Manifests[0]) {
+			t.Errorf("last applied hash = %s, want the skipped manifest not recorded as applied", manifestCond.LastAppliedHash)
+		}
+	}
+
+	// the resource is applied as usual once the manifests are applied always.
+	strategy.WhenToApply = fleetv1beta1.WhenToApplyTypeAlways
+	if result := reconcile("v2"); result.applyErr != nil || result.skippedAsAlreadyExists {
+		t.Fatalf("applyManifests() result = %+v, want the manifest applied", result)
+	}
+	if got := liveVersion(); got != "v2" {
+		t.Errorf("deployment version = %s, want v2", got)
+	}
+}
+
+func TestSkipExistingResourceNotCreatedByWork(t *testing.T) {
+	ctx := context.Background()
+	// e.g. the deployment was created outside of fleet before the work was placed.
+	live := liveDeployment("web", "web-uid")
+	r := &ApplyWorkReconciler{spokeDynamicClient: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), live)}
+	gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	manifestObj := liveDeployment("web", "")
+	manifestObj.SetOwnerReferences([]metav1.OwnerReference{{Kind: "AppliedWork", Name: "test-work", UID: "applied-work-uid"}})
+	strategy := &fleetv1beta1.ApplyStrategy{WhenToApply: fleetv1beta1.WhenToApplyTypeOnce}
+
+	if _, _, skipped, err := r.skipExistingResource(ctx, gvr, manifestObj, strategy); skipped || err != nil {
+		t.Errorf("skipExistingResource() = %t, %v, want the resource not created by the work left to the apply", skipped, err)
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/metrics"
+)
+
+const (
+	// manifestApplyOutcomeApplied is the outcome of a manifest which is applied.
+	manifestApplyOutcomeApplied = "applied"
+	// manifestApplyOutcomeDrifted is the outcome of a manifest which is applied again as its resource drifted in the
+	// member cluster since the last apply.
+	manifestApplyOutcomeDrifted = "drifted"
+	// manifestApplyOutcomeFailed is the outcome of a manifest which fails to apply.
+	manifestApplyOutcomeFailed = "failed"
+	// manifestApplyOutcomeSkipped is the outcome of a manifest which is not applied in this reconcile, e.g. as it is
+	// skipped per the work annotation or left for a later batch or reconcile.
+	manifestApplyOutcomeSkipped = "skipped"
+)
+
+// manifestApplyOutcome returns the outcome of the manifest with the apply result reported in the metrics.
+func manifestApplyOutcome(result applyResult, drifted bool) string {
+	switch {
+	case result.skippedAsAlreadyExists:
+		return manifestApplyOutcomeSkipped
+	case result.action == manifestApplyPendingAction || result.action == manifestSkippedAction ||
+		result.action == manifestBatchPendingAction || result.action == manifestVersionRollbackBlockedAction:
+		return manifestApplyOutcomeSkipped
+	case result.applyErr != nil:
+		return manifestApplyOutcomeFailed
+	case drifted:
+		return manifestApplyOutcomeDrifted
+	default:
+		return manifestApplyOutcomeApplied
+	}
+}
+
+// recordApplyOutcomes counts the outcomes of the manifests with the apply results, where the drifts are the ones
+// observed by observeDrifts.
+func recordApplyOutcomes(results []applyResult, drifts map[fleetv1beta1.WorkResourceIdentifier]fleetv1beta1.DriftHistoryEntry) {
+	for _, result := range results {
+		_, drifted := drifts[result.identifier]
+		metrics.ManifestApplyOutcomesTotal.WithLabelValues(manifestApplyOutcome(result, drifted)).Inc()
+	}
+}
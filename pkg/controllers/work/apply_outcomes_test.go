@@ -0,0 +1,95 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/metrics"
+)
+
+func TestManifestApplyOutcome(t *testing.T) {
+	tests := map[string]struct {
+		result  applyResult
+		drifted bool
+		want    string
+	}{
+		"applied": {
+			result: applyResult{action: manifestServerSideAppliedAction},
+			want:   manifestApplyOutcomeApplied,
+		},
+		"applied after a drift": {
+			result:  applyResult{action: manifestAvailableAction},
+			drifted: true,
+			want:    manifestApplyOutcomeDrifted,
+		},
+		"apply error": {
+			result: applyResult{action: errorApplyAction, applyErr: errors.New("admission denied")},
+			want:   manifestApplyOutcomeFailed,
+		},
+		"skipped per the work annotation": {
+			result: applyResult{action: manifestSkippedAction},
+			want:   manifestApplyOutcomeSkipped,
+		},
+		"left for a later batch": {
+			result: applyResult{action: manifestBatchPendingAction},
+			want:   manifestApplyOutcomeSkipped,
+		},
+		"left for the next reconcile": {
+			result: applyResult{action: manifestApplyPendingAction},
+			want:   manifestApplyOutcomeSkipped,
+		},
+		"rollback blocked": {
+			result: applyResult{action: manifestVersionRollbackBlockedAction, applyErr: errors.New("rollback not allowed")},
+			want:   manifestApplyOutcomeSkipped,
+		},
+		"resource already exists": {
+			result: applyResult{action: manifestAvailableAction, skippedAsAlreadyExists: true},
+			want:   manifestApplyOutcomeSkipped,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := manifestApplyOutcome(tt.result, tt.drifted); got != tt.want {
+				t.Errorf("manifestApplyOutcome() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordApplyOutcomes(t *testing.T) {
+	metrics.ManifestApplyOutcomesTotal.Reset()
+	drifted := fleetv1beta1.WorkResourceIdentifier{Ordinal: 1, Kind: "Deployment", Name: "drifted"}
+	results := []applyResult{
+		{identifier: fleetv1beta1.WorkResourceIdentifier{Ordinal: 0, Kind: "ConfigMap", Name: "applied"}, action: manifestServerSideAppliedAction},
+		{identifier: drifted, action: manifestAvailableAction},
+		{identifier: fleetv1beta1.WorkResourceIdentifier{Ordinal: 2, Kind: "Deployment", Name: "failed"}, action: errorApplyAction, applyErr: errors.New("admission denied")},
+		{identifier: fleetv1beta1.WorkResourceIdentifier{Ordinal: 3, Kind: "Secret", Name: "skipped"}, action: manifestSkippedAction},
+		{identifier: fleetv1beta1.WorkResourceIdentifier{Ordinal: 4, Kind: "ConfigMap", Name: "applied-too"}, action: manifestCreatedAction},
+	}
+	drifts := map[fleetv1beta1.WorkResourceIdentifier]fleetv1beta1.DriftHistoryEntry{
+		drifted: {ObservedTime: metav1.Now(), AppliedGeneration: 1, ObservedGeneration: 2},
+	}
+	recordApplyOutcomes(results, drifts)
+
+	want := `
+# HELP fleet_manifest_apply_outcomes_total Number of the manifests in the works processed by the member agent, by their outcome: applied, drifted, failed or skipped
+# TYPE fleet_manifest_apply_outcomes_total counter
+fleet_manifest_apply_outcomes_total{result="applied"} 2
+fleet_manifest_apply_outcomes_total{result="drifted"} 1
+fleet_manifest_apply_outcomes_total{result="failed"} 1
+fleet_manifest_apply_outcomes_total{result="skipped"} 1
+`
+	if err := testutil.CollectAndCompare(metrics.ManifestApplyOutcomesTotal, strings.NewReader(want)); err != nil {
+		t.Errorf("recordApplyOutcomes() metrics mismatch: %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// ApplyStrategyDefaultsConfigMapName is the name of the config map in the member cluster reserved namespace
+	// on the hub cluster which holds the apply strategy defaults of all the works in the namespace.
+	ApplyStrategyDefaultsConfigMapName = "fleet-defaults"
+
+	// ApplyTypeDefaultsKey is the config map key of the default apply strategy type.
+	ApplyTypeDefaultsKey = "apply-type"
+	// AllowCoOwnershipDefaultsKey is the config map key of the default allowCoOwnership setting.
+	AllowCoOwnershipDefaultsKey = "allow-co-ownership"
+	// ForceConflictsDefaultsKey is the config map key of the default server side apply force conflicts setting.
+	ForceConflictsDefaultsKey = "force-conflicts"
+)
+
+// setApplyStrategyDefaultsFromConfigMap merges the apply strategy defaults stored in the well-known config map
+// of the work namespace into the work apply strategy.
+func (r *ApplyWorkReconciler) setApplyStrategyDefaultsFromConfigMap(ctx context.Context, work *fleetv1beta1.Work) error {
+	var cm corev1.ConfigMap
+	cmKey := types.NamespacedName{Name: ApplyStrategyDefaultsConfigMapName, Namespace: work.Namespace}
+	if err := r.client.Get(ctx, cmKey, &cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		klog.ErrorS(err, "Failed to get the apply strategy defaults", "configMap", cmKey)
+		return controller.NewAPIServerError(true, err)
+	}
+	mergeApplyStrategyDefaults(work, cm.Data)
+	return nil
+}
+
+// mergeApplyStrategyDefaults merges the given defaults into the work apply strategy; the fields set on the work
+// always take precedence over the defaults.
+// As allowCoOwnership defaults to false, its default is only honored when the work does not set any apply strategy.
+// Invalid defaults are logged and ignored.
+func mergeApplyStrategyDefaults(work *fleetv1beta1.Work, defaults map[string]string) {
+	if len(defaults) == 0 {
+		return
+	}
+	workRef := klog.KObj(work)
+	hasApplyStrategy := work.Spec.ApplyStrategy != nil
+	if !hasApplyStrategy {
+		work.Spec.ApplyStrategy = &fleetv1beta1.ApplyStrategy{}
+	}
+	strategy := work.Spec.ApplyStrategy
+
+	if v, ok := defaults[ApplyTypeDefaultsKey]; ok && strategy.Type == "" {
+		switch applyType := fleetv1beta1.ApplyStrategyType(v); applyType {
+		case fleetv1beta1.ApplyStrategyTypeClientSideApply, fleetv1beta1.ApplyStrategyTypeServerSideApply:
+			strategy.Type = applyType
+		default:
+			klog.V(2).InfoS("Ignoring the invalid default apply strategy type", "work", workRef, "key", ApplyTypeDefaultsKey, "value", v)
+		}
+	}
+
+	if v, ok := defaults[AllowCoOwnershipDefaultsKey]; ok && !hasApplyStrategy {
+		allowCoOwnership, err := strconv.ParseBool(v)
+		if err != nil {
+			klog.V(2).InfoS("Ignoring the invalid default allowCoOwnership", "work", workRef, "key", AllowCoOwnershipDefaultsKey, "value", v, "err", err)
+		} else {
+			strategy.AllowCoOwnership = allowCoOwnership
+		}
+	}
+
+	if v, ok := defaults[ForceConflictsDefaultsKey]; ok && strategy.ServerSideApplyConfig == nil {
+		forceConflicts, err := strconv.ParseBool(v)
+		if err != nil {
+			klog.V(2).InfoS("Ignoring the invalid default force conflicts", "work", workRef, "key", ForceConflictsDefaultsKey, "value", v, "err", err)
+		} else {
+			strategy.ServerSideApplyConfig = &fleetv1beta1.ServerSideApplyConfig{ForceConflicts: forceConflicts}
+		}
+	}
+}
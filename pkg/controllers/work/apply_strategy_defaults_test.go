@@ -0,0 +1,142 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func TestMergeApplyStrategyDefaults(t *testing.T) {
+	tests := map[string]struct {
+		applyStrategy *fleetv1beta1.ApplyStrategy
+		defaults      map[string]string
+		want          *fleetv1beta1.ApplyStrategy
+	}{
+		"no defaults": {
+			applyStrategy: nil,
+			defaults:      nil,
+			want:          nil,
+		},
+		"work without apply strategy picks up all the defaults": {
+			applyStrategy: nil,
+			defaults: map[string]string{
+				ApplyTypeDefaultsKey:        string(fleetv1beta1.ApplyStrategyTypeServerSideApply),
+				AllowCoOwnershipDefaultsKey: "true",
+				ForceConflictsDefaultsKey:   "true",
+			},
+			want: &fleetv1beta1.ApplyStrategy{
+				Type:                  fleetv1beta1.ApplyStrategyTypeServerSideApply,
+				AllowCoOwnership:      true,
+				ServerSideApplyConfig: &fleetv1beta1.ServerSideApplyConfig{ForceConflicts: true},
+			},
+		},
+		"work level apply strategy takes precedence": {
+			applyStrategy: &fleetv1beta1.ApplyStrategy{
+				Type:                  fleetv1beta1.ApplyStrategyTypeClientSideApply,
+				ServerSideApplyConfig: &fleetv1beta1.ServerSideApplyConfig{ForceConflicts: false},
+			},
+			defaults: map[string]string{
+				ApplyTypeDefaultsKey:        string(fleetv1beta1.ApplyStrategyTypeServerSideApply),
+				AllowCoOwnershipDefaultsKey: "true",
+				ForceConflictsDefaultsKey:   "true",
+			},
+			want: &fleetv1beta1.ApplyStrategy{
+				Type:                  fleetv1beta1.ApplyStrategyTypeClientSideApply,
+				ServerSideApplyConfig: &fleetv1beta1.ServerSideApplyConfig{ForceConflicts: false},
+			},
+		},
+		"work level apply strategy with unset fields picks up the defaults": {
+			applyStrategy: &fleetv1beta1.ApplyStrategy{
+				AllowCoOwnership: true,
+			},
+			defaults: map[string]string{
+				ApplyTypeDefaultsKey:      string(fleetv1beta1.ApplyStrategyTypeServerSideApply),
+				ForceConflictsDefaultsKey: "true",
+			},
+			want: &fleetv1beta1.ApplyStrategy{
+				Type:                  fleetv1beta1.ApplyStrategyTypeServerSideApply,
+				AllowCoOwnership:      true,
+				ServerSideApplyConfig: &fleetv1beta1.ServerSideApplyConfig{ForceConflicts: true},
+			},
+		},
+		"invalid defaults are ignored": {
+			applyStrategy: nil,
+			defaults: map[string]string{
+				ApplyTypeDefaultsKey:        "KubectlApply",
+				AllowCoOwnershipDefaultsKey: "yes please",
+				ForceConflictsDefaultsKey:   "maybe",
+			},
+			want: &fleetv1beta1.ApplyStrategy{},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			work := &fleetv1beta1.Work{
+				Spec: fleetv1beta1.WorkSpec{
+					ApplyStrategy: tt.applyStrategy,
+				},
+			}
+			mergeApplyStrategyDefaults(work, tt.defaults)
+			if diff := cmp.Diff(tt.want, work.Spec.ApplyStrategy); diff != "" {
+				t.Errorf("mergeApplyStrategyDefaults() apply strategy mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetApplyStrategyDefaultsFromConfigMap(t *testing.T) {
+	defaultsConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ApplyStrategyDefaultsConfigMapName,
+			Namespace: "fleet-member-test",
+		},
+		Data: map[string]string{
+			ApplyTypeDefaultsKey: string(fleetv1beta1.ApplyStrategyTypeServerSideApply),
+		},
+	}
+	tests := map[string]struct {
+		objects []client.Object
+		want    *fleetv1beta1.ApplyStrategy
+	}{
+		"config map does not exist": {
+			want: nil,
+		},
+		"config map exists": {
+			objects: []client.Object{defaultsConfigMap},
+			want: &fleetv1beta1.ApplyStrategy{
+				Type: fleetv1beta1.ApplyStrategyTypeServerSideApply,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &ApplyWorkReconciler{
+				client: fake.NewClientBuilder().WithObjects(tt.objects...).Build(),
+			}
+			work := &fleetv1beta1.Work{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-work",
+					Namespace: "fleet-member-test",
+				},
+			}
+			if err := r.setApplyStrategyDefaultsFromConfigMap(context.Background(), work); err != nil {
+				t.Fatalf("setApplyStrategyDefaultsFromConfigMap() = %v, want no error", err)
+			}
+			if diff := cmp.Diff(tt.want, work.Spec.ApplyStrategy); diff != "" {
+				t.Errorf("setApplyStrategyDefaultsFromConfigMap() apply strategy mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	// ReconcileTimedOutReason is the reason string of the work applied condition when the manifests are not all
+	// processed before the member cluster API timeout.
+	ReconcileTimedOutReason = "ReconcileTimedOut"
+	// ManifestApplyPendingReason is the reason string of condition when the manifest is not processed before the
+	// member cluster API timeout.
+	ManifestApplyPendingReason = "ManifestApplyPending"
+
+	// defaultMemberAPITimeoutSeconds is the time limit of applying the manifests if the work does not set one.
+	defaultMemberAPITimeoutSeconds = 30
+)
+
+// memberAPITimeout returns the time limit of applying the manifests of the work to the member cluster.
+// The timeout of the health criteria of the work takes precedence over the member API timeout.
+func memberAPITimeout(work *fleetv1beta1.Work) time.Duration {
+	if work.Spec.HealthCriteria != nil && work.Spec.HealthCriteria.TimeoutSeconds != nil {
+		return time.Duration(*work.Spec.HealthCriteria.TimeoutSeconds) * time.Second
+	}
+	if work.Spec.MemberAPITimeoutSeconds == nil {
+		return defaultMemberAPITimeoutSeconds * time.Second
+	}
+	return time.Duration(*work.Spec.MemberAPITimeoutSeconds) * time.Second
+}
+
+// pendingApplyResult returns the result of a manifest which is not processed before the member cluster API timeout.
+// The manifest is still identified so that the resource it applied before is not garbage collected.
+func (r *ApplyWorkReconciler) pendingApplyResult(index int, manifest fleetv1beta1.Manifest) applyResult {
+	result := applyResult{
+		identifier: fleetv1beta1.WorkResourceIdentifier{Ordinal: index},
+		action:     manifestApplyPendingAction,
+	}
+	if gvr, rawObj, err := r.decodeManifest(manifest); err == nil {
+		result.identifier = buildResourceIdentifier(index, rawObj, gvr)
+	}
+	return result
+}
+
+// processedOrdinals returns the ordinals of the manifests which are processed before the member cluster API timeout
+// and whether the timeout left any manifest pending.
+func processedOrdinals(manifestConditions []fleetv1beta1.ManifestCondition) ([]int, bool) {
+	processed := make([]int, 0, len(manifestConditions))
+	timedOut := false
+	for _, manifestCond := range manifestConditions {
+		applyCond := meta.FindStatusCondition(manifestCond.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+		if applyCond != nil && applyCond.Reason == ManifestApplyPendingReason {
+			timedOut = true
+			continue
+		}
+		processed = append(processed, manifestCond.Identifier.Ordinal)
+	}
+	return processed, timedOut
+}
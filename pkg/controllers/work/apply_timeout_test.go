@@ -0,0 +1,113 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// blockingApplier applies the manifests as they are except the one with the given name, whose apply call does not
+// return until the context expires.
+type blockingApplier struct {
+	blockedName string
+}
+
+func (a *blockingApplier) ApplyUnstructured(ctx context.Context, _ *fleetv1beta1.ApplyStrategy, _ schema.GroupVersionResource,
+	manifestObj *unstructured.Unstructured) (*unstructured.Unstructured, ApplyAction, error) {
+	if manifestObj.GetName() == a.blockedName {
+		<-ctx.Done()
+		return nil, errorApplyAction, ctx.Err()
+	}
+	return manifestObj, manifestCreatedAction, nil
+}
+
+func TestApplyManifestsTimeout(t *testing.T) {
+	manifests := make([]fleetv1beta1.Manifest, 5)
+	for i := range manifests {
+		deploy := testDeployment.DeepCopy()
+		deploy.Name = fmt.Sprintf("deploy-%d", i)
+		raw, err := json.Marshal(deploy)
+		if err != nil {
+			t.Fatalf("failed to marshal the deployment: %v", err)
+		}
+		manifests[i] = fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: raw}}
+	}
+	applyStrategy := &fleetv1beta1.ApplyStrategy{Type: fleetv1beta1.ApplyStrategyTypeClientSideApply}
+	r := &ApplyWorkReconciler{
+		restMapper: testMapper{},
+		appliers: map[fleetv1beta1.ApplyStrategyType]Applier{
+			fleetv1beta1.ApplyStrategyTypeClientSideApply: &blockingApplier{blockedName: "deploy-3"},
+		},
+	}
+
+	// the time limit is reached while applying the manifest with ordinal 3.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	results := r.applyManifests(ctx, manifests, ownerRef, applyStrategy, nil, nil, "", nil, nil, nil)
+	work := &fleetv1beta1.Work{ObjectMeta: metav1.ObjectMeta{Name: "test-work", Generation: 1}}
+	if errs := constructWorkCondition(results, work); len(errs) != 0 {
+		t.Errorf("constructWorkCondition() = %v, want no errors", errs)
+	}
+
+	for i, manifestCond := range work.Status.ManifestConditions {
+		if manifestCond.Identifier.Name != fmt.Sprintf("deploy-%d", i) {
+			t.Errorf("manifest condition %d identifier = %+v, want the deployment deploy-%d", i, manifestCond.Identifier, i)
+		}
+		applyCond := meta.FindStatusCondition(manifestCond.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+		if i < 3 {
+			if applyCond.Status != metav1.ConditionTrue {
+				t.Errorf("manifest %d applied condition = %+v, want true", i, applyCond)
+			}
+			continue
+		}
+		if applyCond.Status != metav1.ConditionUnknown || applyCond.Reason != ManifestApplyPendingReason {
+			t.Errorf("manifest %d applied condition = %+v, want pending", i, applyCond)
+		}
+	}
+	workApplyCond := meta.FindStatusCondition(work.Status.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+	if workApplyCond.Status != metav1.ConditionFalse || workApplyCond.Reason != ReconcileTimedOutReason {
+		t.Errorf("work applied condition = %+v, want false with reason %s", workApplyCond, ReconcileTimedOutReason)
+	}
+	if processed, _ := processedOrdinals(work.Status.ManifestConditions); fmt.Sprint(processed) != "[0 1 2]" {
+		t.Errorf("processedOrdinals() = %v, want [0 1 2]", processed)
+	}
+}
+
+func TestMemberAPITimeout(t *testing.T) {
+	tests := map[string]struct {
+		timeoutSeconds *int64
+		want           time.Duration
+	}{
+		"default timeout": {
+			want: 30 * time.Second,
+		},
+		"configured timeout": {
+			timeoutSeconds: ptr.To(int64(120)),
+			want:           2 * time.Minute,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			work := &fleetv1beta1.Work{Spec: fleetv1beta1.WorkSpec{MemberAPITimeoutSeconds: tt.timeoutSeconds}}
+			if got := memberAPITimeout(work); got != tt.want {
+				t.Errorf("memberAPITimeout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
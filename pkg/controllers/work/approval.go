@@ -0,0 +1,298 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/binarydata"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// WorkApprovedAnnotation is the annotation to approve the pending changes of a work whose apply strategy requires
+	// approval. The work applier applies the changes when its value is "true" and then removes it.
+	WorkApprovedAnnotation = "fleet.azure.com/approved"
+
+	// WorkPendingApprovalReason is the reason string of condition when the changes of the work wait for approval.
+	WorkPendingApprovalReason = "WorkPendingApproval"
+)
+
+// DefaultIgnoreAnnotationKeys are the keys of the annotations whose changes are excluded from the comparisons if the
+// apply strategy does not set its own.
+var DefaultIgnoreAnnotationKeys = []string{"kubectl.kubernetes.io/last-applied-configuration"}
+
+// annotationsPathPrefix is the prefix of the paths of the annotations in the compared objects.
+const annotationsPathPrefix = "metadata.annotations."
+
+// ignoredDiffFields are the fields which are changed by the API server on every apply and are not reported as the
+// pending changes.
+var ignoredDiffFields = map[string]bool{
+	"metadata.managedFields":     true,
+	"metadata.resourceVersion":   true,
+	"metadata.generation":        true,
+	"metadata.uid":               true,
+	"metadata.creationTimestamp": true,
+	"status":                     true,
+}
+
+// ignoredAnnotationKeys returns the set of the annotation keys whose changes the apply strategy excludes from the
+// comparisons.
+func ignoredAnnotationKeys(applyStrategy *fleetv1beta1.ApplyStrategy) map[string]bool {
+	keys := DefaultIgnoreAnnotationKeys
+	if applyStrategy != nil && applyStrategy.IgnoreAnnotationKeys != nil {
+		keys = applyStrategy.IgnoreAnnotationKeys
+	}
+	ignored := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		ignored[key] = true
+	}
+	return ignored
+}
+
+// gateOnApproval decides whether the manifests of a work requiring approval can be applied.
+// An approved work is applied and the approval annotation is removed, so that the next changes need a new approval.
+// Otherwise, the manifests are dry-run applied and the work waits for approval if the apply would change any resource.
+// It returns true if the work must not be applied yet.
+func (r *ApplyWorkReconciler) gateOnApproval(ctx context.Context, work *fleetv1beta1.Work, owner metav1.OwnerReference) (bool, error) {
+	logObjRef := klog.KObj(work)
+	if work.GetAnnotations()[WorkApprovedAnnotation] == "true" {
+		if err := r.removeApprovalAnnotation(ctx, work); err != nil {
+			return true, err
+		}
+		klog.V(2).InfoS("The pending changes of the work are approved", "work", logObjRef)
+		work.Status.PendingApprovalDiff = nil
+		return false, nil
+	}
+
+	changes, err := r.dryRunManifests(ctx, work.Spec.Workload.Manifests, owner, work.Spec.ApplyStrategy, propagatedAnnotations(work),
+		manifestTargetNamespaces(work), work.Spec.DefaultPriorityClassName)
+	if err != nil {
+		return true, err
+	}
+	if len(changes) == 0 {
+		// nothing would change so there is nothing to approve.
+		work.Status.PendingApprovalDiff = nil
+		return false, nil
+	}
+
+	klog.V(2).InfoS("The changes of the work are pending approval", "work", logObjRef, "changedManifests", len(changes))
+	work.Status.PendingApprovalDiff = changes
+	setPendingApprovalCondition(work, len(changes))
+	if err := r.updateWorkStatusIfChanged(ctx, work); err != nil {
+		klog.ErrorS(err, "Failed to update work status", "work", logObjRef)
+		return true, err
+	}
+	r.recorder.Event(work, v1.EventTypeNormal, WorkPendingApprovalReason,
+		fmt.Sprintf("%d manifest(s) would be changed, annotate the work with %s=true to apply them", len(changes), WorkApprovedAnnotation))
+	return true, nil
+}
+
+// removeApprovalAnnotation removes the approval annotation from the work on the hub cluster.
+// The patch is sent with a copy of the work so that the in-memory defaults of its spec are kept.
+func (r *ApplyWorkReconciler) removeApprovalAnnotation(ctx context.Context, work *fleetv1beta1.Work) error {
+	original := &fleetv1beta1.Work{ObjectMeta: *work.ObjectMeta.DeepCopy()}
+	patched := original.DeepCopy()
+	delete(patched.Annotations, WorkApprovedAnnotation)
+	if err := r.client.Patch(ctx, patched, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})); err != nil {
+		klog.ErrorS(err, "Failed to remove the approval annotation from the work", "work", klog.KObj(work))
+		return controller.NewAPIServerError(false, err)
+	}
+	work.SetAnnotations(patched.GetAnnotations())
+	work.SetResourceVersion(patched.GetResourceVersion())
+	return nil
+}
+
+// dryRunManifests performs a server-side dry-run apply of the manifests and returns the changes they would make to
+// the resources in the member cluster. Only the manifests which would change a resource are returned.
+func (r *ApplyWorkReconciler) dryRunManifests(ctx context.Context, manifests []fleetv1beta1.Manifest, owner metav1.OwnerReference,
+	applyStrategy *fleetv1beta1.ApplyStrategy, annotations map[string]string, targetNamespaces map[int]string, priorityClassName string) ([]fleetv1beta1.PendingManifestChange, error) {
+	var changes []fleetv1beta1.PendingManifestChange
+	for index, manifest := range manifests {
+		gvr, rawObj, manifestNamespace, err := r.prepareDryRunManifest(ctx, index, manifest, owner, applyStrategy, annotations, targetNamespaces, priorityClassName)
+		if err != nil {
+			return nil, err
+		}
+		change, err := r.dryRunManifest(ctx, gvr, rawObj, ignoredAnnotationKeys(applyStrategy), applyStrategy)
+		if err != nil {
+			return nil, err
+		}
+		if change != nil {
+			change.Identifier = buildResourceIdentifier(index, rawObj, gvr)
+			change.Identifier.Namespace = manifestNamespace
+			changes = append(changes, *change)
+		}
+	}
+	return changes, nil
+}
+
+// prepareDryRunManifest decodes the manifest and prepares it the same way as it is prepared to be applied.
+// It returns the namespace of the manifest as well since the manifest may be routed to another namespace.
+func (r *ApplyWorkReconciler) prepareDryRunManifest(ctx context.Context, index int, manifest fleetv1beta1.Manifest, owner metav1.OwnerReference,
+	applyStrategy *fleetv1beta1.ApplyStrategy, annotations map[string]string, targetNamespaces map[int]string,
+	priorityClassName string) (schema.GroupVersionResource, *unstructured.Unstructured, string, error) {
+	gvr, rawObj, err := r.decodeManifest(manifest)
+	if err == nil {
+		err = binarydata.Merge(rawObj, manifestBinaryData(ctx, index))
+	}
+	if err == nil {
+		err = injectPriorityClassName(rawObj, priorityClassName)
+	}
+	if err == nil && applyStrategy.ShadowApply {
+		err = r.redirectToShadowNamespace(ctx, rawObj, owner)
+	}
+	if err != nil {
+		klog.ErrorS(err, "Failed to prepare the manifest for the dry-run apply", "ordinal", index)
+		return gvr, nil, "", controller.NewUserError(fmt.Errorf("failed to dry-run apply the manifest with ordinal %d: %w", index, err))
+	}
+	addOwnerRef(owner, rawObj)
+	addPolicyAnnotations(ctx, rawObj)
+	addPropagatedAnnotations(rawObj, annotations)
+	manifestNamespace := rawObj.GetNamespace()
+	if targetNamespace, ok := targetNamespaces[index]; ok {
+		rawObj.SetNamespace(targetNamespace)
+	}
+	return gvr, rawObj, manifestNamespace, nil
+}
+
+// dryRunManifest returns the change a server-side dry-run apply of the manifest would make, or nil if the resource
+// would not change. A resource which does not exist is compared against an empty object, so that the change lists all
+// the fields it would be created with. The fields are compared per the apply strategy.
+func (r *ApplyWorkReconciler) dryRunManifest(ctx context.Context, gvr schema.GroupVersionResource, manifestObj *unstructured.Unstructured,
+	ignoredAnnotations map[string]bool, applyStrategy *fleetv1beta1.ApplyStrategy) (*fleetv1beta1.PendingManifestChange, error) {
+	manifestRef := klog.KObj(manifestObj)
+	resourceClient := r.spokeDynamicClient.Resource(gvr).Namespace(manifestObj.GetNamespace())
+	operation := fleetv1beta1.PendingOperationUpdate
+	liveObj, err := resourceClient.Get(ctx, manifestObj.GetName(), metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		operation = fleetv1beta1.PendingOperationCreate
+		liveObj = &unstructured.Unstructured{Object: map[string]interface{}{}}
+	case err != nil:
+		klog.ErrorS(err, "Failed to get the resource", "gvr", gvr, "manifest", manifestRef)
+		return nil, controller.NewAPIServerError(false, err)
+	}
+
+	options := metav1.ApplyOptions{
+		FieldManager: r.fieldManager,
+		Force:        true,
+		DryRun:       []string{metav1.DryRunAll},
+	}
+	dryRunObj, err := resourceClient.Apply(ctx, manifestObj.GetName(), manifestObj, options)
+	if err != nil {
+		klog.ErrorS(err, "Failed to dry-run apply the manifest", "gvr", gvr, "manifest", manifestRef)
+		return nil, controller.NewAPIServerError(false, err)
+	}
+	gvk := manifestObj.GroupVersionKind()
+	changedFields := diffFields("", comparableObject(liveObj.Object, gvk, applyStrategy), comparableObject(dryRunObj.Object, gvk, applyStrategy), ignoredAnnotations)
+	if len(changedFields) == 0 && operation == fleetv1beta1.PendingOperationUpdate {
+		return nil, nil
+	}
+	return &fleetv1beta1.PendingManifestChange{
+		Operation:              operation,
+		ChangedFields:          changedFields,
+		ResourceExistsInMember: operation == fleetv1beta1.PendingOperationUpdate,
+	}, nil
+}
+
+// diffFields returns the sorted paths of the fields which differ between the live and the desired objects.
+// Nested objects are compared field by field while any other value, including a list, is compared as a whole.
+// The changes of the annotations with the ignored keys are excluded.
+func diffFields(path string, live, desired interface{}, ignoredAnnotations map[string]bool) []string {
+	details := diffPatchDetails(path, live, desired, ignoredAnnotations)
+	if len(details) == 0 {
+		return nil
+	}
+	fields := make([]string, len(details))
+	for i := range details {
+		fields[i] = details[i].Path
+	}
+	return fields
+}
+
+// diffPatchDetails returns the changes of the fields which differ between the live and the desired objects, sorted
+// by their paths. The values are the JSON of the fields; a field absent on one side has an empty value. A nested
+// object absent on one side is compared against an empty object, so that its fields are listed one by one.
+func diffPatchDetails(path string, live, desired interface{}, ignoredAnnotations map[string]bool) []fleetv1beta1.PatchDetail {
+	if ignoredDiffFields[path] {
+		return nil
+	}
+	if strings.HasPrefix(path, annotationsPathPrefix) && ignoredAnnotations[strings.TrimPrefix(path, annotationsPathPrefix)] {
+		return nil
+	}
+	if live == nil && isNonEmptyObject(desired) {
+		live = map[string]interface{}{}
+	}
+	if desired == nil && isNonEmptyObject(live) {
+		desired = map[string]interface{}{}
+	}
+	liveMap, liveIsMap := live.(map[string]interface{})
+	desiredMap, desiredIsMap := desired.(map[string]interface{})
+	if !liveIsMap || !desiredIsMap {
+		if reflect.DeepEqual(live, desired) {
+			return nil
+		}
+		return []fleetv1beta1.PatchDetail{{Path: path, ValueInMember: fieldValue(live), ValueInHub: fieldValue(desired)}}
+	}
+
+	keys := make(map[string]bool, len(liveMap)+len(desiredMap))
+	for key := range liveMap {
+		keys[key] = true
+	}
+	for key := range desiredMap {
+		keys[key] = true
+	}
+	var details []fleetv1beta1.PatchDetail
+	for key := range keys {
+		details = append(details, diffPatchDetails(strings.TrimPrefix(path+"."+key, "."), liveMap[key], desiredMap[key], ignoredAnnotations)...)
+	}
+	sort.Slice(details, func(i, j int) bool { return details[i].Path < details[j].Path })
+	return details
+}
+
+// isNonEmptyObject returns true if the value is an object with at least one field.
+func isNonEmptyObject(value interface{}) bool {
+	object, ok := value.(map[string]interface{})
+	return ok && len(object) > 0
+}
+
+// fieldValue returns the JSON of the field value, or an empty string if the field is absent.
+func fieldValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(raw)
+}
+
+// setPendingApprovalCondition marks the work as not applied because its changes wait for approval.
+func setPendingApprovalCondition(work *fleetv1beta1.Work, changedManifests int) {
+	meta.SetStatusCondition(&work.Status.Conditions, metav1.Condition{
+		Type:               fleetv1beta1.WorkConditionTypeApplied,
+		Status:             metav1.ConditionFalse,
+		Reason:             WorkPendingApprovalReason,
+		Message:            fmt.Sprintf("%d manifest(s) would be changed and wait for the %s annotation", changedManifests, WorkApprovedAnnotation),
+		ObservedGeneration: work.Generation,
+	})
+}
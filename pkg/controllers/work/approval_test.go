@@ -0,0 +1,264 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic/fake"
+	testingclient "k8s.io/client-go/testing"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+)
+
+func approvalTestDeployment(replicas int64) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":      "Deployment",
+			"namespace": "default",
+		},
+		"spec": map[string]interface{}{
+			"replicas": replicas,
+		},
+	}}
+}
+
+func TestGateOnApproval(t *testing.T) {
+	manifestObj := approvalTestDeployment(3)
+	rawManifest, err := manifestObj.MarshalJSON()
+	if err != nil {
+		t.Fatalf("failed to marshal the manifest: %v", err)
+	}
+	identifier := fleetv1beta1.WorkResourceIdentifier{
+		Ordinal:   0,
+		Group:     "apps",
+		Version:   "v1",
+		Kind:      "Deployment",
+		Resource:  utils.DeploymentGVR.Resource,
+		Namespace: "default",
+		Name:      "Deployment",
+	}
+
+	tests := map[string]struct {
+		annotations map[string]string
+		liveObj     *unstructured.Unstructured
+		dryRunObj   *unstructured.Unstructured
+		wantPending bool
+		wantDiff    []fleetv1beta1.PendingManifestChange
+		wantApplies int
+	}{
+		"resource to create waits for approval": {
+			dryRunObj:   approvalTestDeployment(3),
+			wantPending: true,
+			wantDiff: []fleetv1beta1.PendingManifestChange{
+				{
+					Identifier:    identifier,
+					Operation:     fleetv1beta1.PendingOperationCreate,
+					ChangedFields: []string{"apiVersion", "kind", "metadata.name", "metadata.namespace", "spec.replicas"},
+				},
+			},
+			wantApplies: 1,
+		},
+		"resource to update waits for approval": {
+			liveObj:     approvalTestDeployment(1),
+			dryRunObj:   approvalTestDeployment(3),
+			wantPending: true,
+			wantDiff: []fleetv1beta1.PendingManifestChange{
+				{Identifier: identifier, Operation: fleetv1beta1.PendingOperationUpdate, ChangedFields: []string{"spec.replicas"}, ResourceExistsInMember: true},
+			},
+			wantApplies: 1,
+		},
+		"unchanged resource needs no approval": {
+			liveObj:     approvalTestDeployment(3),
+			dryRunObj:   approvalTestDeployment(3),
+			wantApplies: 1,
+		},
+		"approved work is applied without a dry-run": {
+			annotations: map[string]string{WorkApprovedAnnotation: "true"},
+			liveObj:     approvalTestDeployment(1),
+			dryRunObj:   approvalTestDeployment(3),
+		},
+		"work with a false approval waits for approval": {
+			annotations: map[string]string{WorkApprovedAnnotation: "false"},
+			liveObj:     approvalTestDeployment(1),
+			dryRunObj:   approvalTestDeployment(3),
+			wantPending: true,
+			wantDiff: []fleetv1beta1.PendingManifestChange{
+				{Identifier: identifier, Operation: fleetv1beta1.PendingOperationUpdate, ChangedFields: []string{"spec.replicas"}, ResourceExistsInMember: true},
+			},
+			wantApplies: 1,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+				t.Fatalf("failed to add the placement scheme: %v", err)
+			}
+			work := &fleetv1beta1.Work{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-work",
+					Namespace:   "fleet-member-test",
+					Generation:  1,
+					Annotations: tt.annotations,
+				},
+				Spec: fleetv1beta1.WorkSpec{
+					Workload: fleetv1beta1.WorkloadTemplate{
+						Manifests: []fleetv1beta1.Manifest{{RawExtension: runtime.RawExtension{Raw: rawManifest}}},
+					},
+					ApplyStrategy: &fleetv1beta1.ApplyStrategy{Type: fleetv1beta1.ApplyStrategyTypeServerSideApply, RequireApproval: true},
+				},
+			}
+			hubClient := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(work).WithStatusSubresource(work).Build()
+			if err := hubClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, work); err != nil {
+				t.Fatalf("failed to get the work: %v", err)
+			}
+
+			dynamicClient := fake.NewSimpleDynamicClient(runtime.NewScheme())
+			dynamicClient.PrependReactor("get", "deployments", func(action testingclient.Action) (bool, runtime.Object, error) {
+				if tt.liveObj == nil {
+					return true, nil, apierrors.NewNotFound(utils.DeploymentGVR.GroupResource(), "Deployment")
+				}
+				return true, tt.liveObj.DeepCopy(), nil
+			})
+			applies := 0
+			dynamicClient.PrependReactor("patch", "deployments", func(action testingclient.Action) (bool, runtime.Object, error) {
+				applies++
+				return true, tt.dryRunObj.DeepCopy(), nil
+			})
+			r := &ApplyWorkReconciler{
+				client:             hubClient,
+				spokeDynamicClient: dynamicClient,
+				restMapper:         testMapper{},
+				recorder:           utils.NewFakeRecorder(1),
+			}
+
+			pending, err := r.gateOnApproval(context.Background(), work, ownerRef)
+			if err != nil {
+				t.Fatalf("gateOnApproval() = %v, want no error", err)
+			}
+			if pending != tt.wantPending {
+				t.Errorf("gateOnApproval() pending = %t, want %t", pending, tt.wantPending)
+			}
+			if applies != tt.wantApplies {
+				t.Errorf("gateOnApproval() dry-run applies = %d, want %d", applies, tt.wantApplies)
+			}
+
+			var got fleetv1beta1.Work
+			if err := hubClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, &got); err != nil {
+				t.Fatalf("failed to get the work: %v", err)
+			}
+			if tt.wantPending {
+				if diff := cmp.Diff(tt.wantDiff, got.Status.PendingApprovalDiff); diff != "" {
+					t.Errorf("gateOnApproval() pendingApprovalDiff mismatch (-want +got):\n%s", diff)
+				}
+				appliedCond := meta.FindStatusCondition(got.Status.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+				if appliedCond == nil || appliedCond.Reason != WorkPendingApprovalReason {
+					t.Errorf("gateOnApproval() applied condition = %+v, want reason %s", appliedCond, WorkPendingApprovalReason)
+				}
+			} else if len(work.Status.PendingApprovalDiff) != 0 {
+				t.Errorf("gateOnApproval() pendingApprovalDiff = %+v, want empty", work.Status.PendingApprovalDiff)
+			}
+			if _, found := got.Annotations[WorkApprovedAnnotation]; found && tt.annotations[WorkApprovedAnnotation] == "true" {
+				t.Errorf("gateOnApproval() kept the approval annotation, want it removed")
+			}
+			if got.ResourceVersion != work.ResourceVersion {
+				t.Errorf("gateOnApproval() work resource version = %s, want %s", work.ResourceVersion, got.ResourceVersion)
+			}
+		})
+	}
+}
+
+func TestDiffFields(t *testing.T) {
+	annotated := func(annotations, labels map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{"metadata": map[string]interface{}{"annotations": annotations, "labels": labels}}
+	}
+	tests := map[string]struct {
+		live          map[string]interface{}
+		desired       map[string]interface{}
+		applyStrategy *fleetv1beta1.ApplyStrategy
+		want          []string
+	}{
+		"identical objects": {
+			live:    map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(1)}},
+			desired: map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(1)}},
+		},
+		"changed, added and removed fields": {
+			live: map[string]interface{}{
+				"data": map[string]interface{}{"a": "1", "b": "2"},
+			},
+			desired: map[string]interface{}{
+				"data": map[string]interface{}{"a": "2", "c": "3"},
+			},
+			want: []string{"data.a", "data.b", "data.c"},
+		},
+		"lists are compared as a whole": {
+			live:    map[string]interface{}{"spec": map[string]interface{}{"ports": []interface{}{int64(80)}}},
+			desired: map[string]interface{}{"spec": map[string]interface{}{"ports": []interface{}{int64(80), int64(443)}}},
+			want:    []string{"spec.ports"},
+		},
+		"fields changed by the API server are ignored": {
+			live: map[string]interface{}{
+				"metadata": map[string]interface{}{"resourceVersion": "1", "generation": int64(1), "managedFields": []interface{}{}},
+				"status":   map[string]interface{}{"ready": true},
+			},
+			desired: map[string]interface{}{
+				"metadata": map[string]interface{}{"resourceVersion": "2", "generation": int64(2)},
+				"status":   map[string]interface{}{"ready": false},
+			},
+		},
+		"last applied configuration annotation is ignored by default": {
+			live: annotated(map[string]interface{}{"kubectl.kubernetes.io/last-applied-configuration": "{}", "team": "a"}, nil),
+			desired: annotated(map[string]interface{}{"kubectl.kubernetes.io/last-applied-configuration": `{"a":1}`, "team": "b"},
+				nil),
+			want: []string{"metadata.annotations.team"},
+		},
+		"configured annotation keys replace the default ones": {
+			live: annotated(map[string]interface{}{"kubectl.kubernetes.io/last-applied-configuration": "{}", "example.com/build": "1"}, nil),
+			desired: annotated(map[string]interface{}{"kubectl.kubernetes.io/last-applied-configuration": `{"a":1}`, "example.com/build": "2"},
+				nil),
+			applyStrategy: &fleetv1beta1.ApplyStrategy{IgnoreAnnotationKeys: []string{"example.com/build"}},
+			want:          []string{"metadata.annotations.kubectl.kubernetes.io/last-applied-configuration"},
+		},
+		"annotation keys are only ignored in the annotations": {
+			live: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]interface{}{"example.com/build": "1"},
+					"labels":      map[string]interface{}{"example.com/build": "1"},
+				},
+				"data": map[string]interface{}{"example.com/build": "1"},
+			},
+			desired: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]interface{}{"example.com/build": "2"},
+					"labels":      map[string]interface{}{"example.com/build": "2"},
+				},
+				"data": map[string]interface{}{"example.com/build": "2"},
+			},
+			applyStrategy: &fleetv1beta1.ApplyStrategy{IgnoreAnnotationKeys: []string{"example.com/build"}},
+			want:          []string{"data.example.com/build", "metadata.labels.example.com/build"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, diffFields("", tt.live, tt.desired, ignoredAnnotationKeys(tt.applyStrategy))); diff != "" {
+				t.Errorf("diffFields() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/binarydata"
+)
+
+// manifestBinaryDataKey is the context key of the binary data of the manifests of the work.
+type manifestBinaryDataKey struct{}
+
+// withManifestBinaryData returns a context which carries the binary data of the manifests of the work, keyed by
+// their ordinals.
+func withManifestBinaryData(ctx context.Context, work *fleetv1beta1.Work) context.Context {
+	return context.WithValue(ctx, manifestBinaryDataKey{}, binarydata.ByOrdinal(work))
+}
+
+// manifestBinaryData returns the binary data of the manifest with the ordinal, if any.
+func manifestBinaryData(ctx context.Context, ordinal int) map[string][]byte {
+	data, _ := ctx.Value(manifestBinaryDataKey{}).(map[int]map[string][]byte)
+	return data[ordinal]
+}
@@ -0,0 +1,86 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// capturingApplier applies the manifests as they are and captures the last one applied.
+type capturingApplier struct {
+	applied *unstructured.Unstructured
+}
+
+func (a *capturingApplier) ApplyUnstructured(_ context.Context, _ *fleetv1beta1.ApplyStrategy, _ schema.GroupVersionResource,
+	manifestObj *unstructured.Unstructured) (*unstructured.Unstructured, ApplyAction, error) {
+	a.applied = manifestObj.DeepCopy()
+	return manifestObj, manifestCreatedAction, nil
+}
+
+func TestApplyManifestsBinaryData(t *testing.T) {
+	// the DER encoding of a certificate is not valid UTF-8.
+	cert := []byte{0x30, 0x82, 0x01, 0x0a, 0xff, 0xfe, 0x00, 0x80}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Secret"), meta.RESTScopeNamespace)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+	applier := &capturingApplier{}
+	r := &ApplyWorkReconciler{
+		restMapper: mapper,
+		appliers:   map[fleetv1beta1.ApplyStrategyType]Applier{fleetv1beta1.ApplyStrategyTypeClientSideApply: applier},
+	}
+	applyStrategy := &fleetv1beta1.ApplyStrategy{Type: fleetv1beta1.ApplyStrategyTypeClientSideApply}
+	manifests := []fleetv1beta1.Manifest{
+		{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"Secret","metadata":{"name":"tls","namespace":"app"},` +
+			`"type":"kubernetes.io/tls","data":{"tls.key":"a2V5"}}`)}},
+		{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"ca","namespace":"app"},` +
+			`"data":{"name":"ca"}}`)}},
+	}
+	work := &fleetv1beta1.Work{Spec: fleetv1beta1.WorkSpec{Workload: fleetv1beta1.WorkloadTemplate{
+		Manifests: manifests,
+		ManifestBinaryData: []fleetv1beta1.ManifestBinaryData{
+			{Ordinal: 0, BinaryData: map[string][]byte{"tls.crt": cert}},
+			{Ordinal: 1, BinaryData: map[string][]byte{"ca.der": cert}},
+		},
+	}}}
+	ctx := withManifestBinaryData(context.Background(), work)
+
+	results := r.applyManifests(ctx, manifests[:1], ownerRef, applyStrategy, nil, nil, "", nil, nil, nil)
+	if results[0].applyErr != nil {
+		t.Fatalf("applyManifests() = %v, want no error", results[0].applyErr)
+	}
+	var secret corev1.Secret
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(applier.applied.Object, &secret); err != nil {
+		t.Fatalf("failed to convert the applied secret: %v", err)
+	}
+	if !bytes.Equal(secret.Data["tls.crt"], cert) {
+		t.Errorf("applied secret tls.crt = %v, want %v", secret.Data["tls.crt"], cert)
+	}
+	if string(secret.Data["tls.key"]) != "key" {
+		t.Errorf("applied secret tls.key = %q, want the value of the manifest", secret.Data["tls.key"])
+	}
+
+	results = r.applyManifests(ctx, manifests, ownerRef, applyStrategy, nil, nil, "", map[int]bool{0: true}, nil, nil)
+	if results[1].applyErr != nil {
+		t.Fatalf("applyManifests() = %v, want no error", results[1].applyErr)
+	}
+	var configMap corev1.ConfigMap
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(applier.applied.Object, &configMap); err != nil {
+		t.Fatalf("failed to convert the applied config map: %v", err)
+	}
+	if !bytes.Equal(configMap.BinaryData["ca.der"], cert) || configMap.Data["name"] != "ca" {
+		t.Errorf("applied config map = %+v, want the binary data merged next to the data", configMap)
+	}
+}
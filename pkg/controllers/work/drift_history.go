@@ -0,0 +1,67 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// DefaultMaxDriftHistoryDepth is the default number of the drifts kept in the drift history of a manifest.
+const DefaultMaxDriftHistoryDepth = 5
+
+// observeDrifts returns the drifts of the resources of the manifests, i.e. the changes of the resource generations
+// since the manifests were last applied while the work spec stays the same, keyed by the manifest identifiers. It must
+// be called before the status is updated with the apply results.
+func observeDrifts(work *fleetv1beta1.Work, results []applyResult, now metav1.Time) map[fleetv1beta1.WorkResourceIdentifier]fleetv1beta1.DriftHistoryEntry {
+	workApplied := meta.FindStatusCondition(work.Status.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+	if workApplied == nil || workApplied.ObservedGeneration != work.Generation {
+		return nil
+	}
+	drifts := make(map[fleetv1beta1.WorkResourceIdentifier]fleetv1beta1.DriftHistoryEntry)
+	for _, result := range results {
+		if result.applyErr != nil || result.action == manifestApplyPendingAction || result.action == manifestSkippedAction || result.action == manifestBatchPendingAction {
+			continue
+		}
+		manifestCond := findManifestConditionByIdentifier(result.identifier, work.Status.ManifestConditions)
+		if manifestCond == nil {
+			continue
+		}
+		previous := meta.FindStatusCondition(manifestCond.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+		if previous == nil || previous.Status != metav1.ConditionTrue || previous.ObservedGeneration == result.generation {
+			continue
+		}
+		drifts[result.identifier] = fleetv1beta1.DriftHistoryEntry{
+			ObservedTime:       now,
+			AppliedGeneration:  previous.ObservedGeneration,
+			ObservedGeneration: result.generation,
+		}
+	}
+	return drifts
+}
+
+// updateDriftHistory prepends the drifts observed to the drift histories of the manifests and trims every history to
+// maxDepth entries, displacing the oldest ones. The drift histories are dropped if maxDepth is 0.
+func updateDriftHistory(work *fleetv1beta1.Work, drifts map[fleetv1beta1.WorkResourceIdentifier]fleetv1beta1.DriftHistoryEntry, maxDepth int) {
+	for i := range work.Status.ManifestConditions {
+		manifestCond := &work.Status.ManifestConditions[i]
+		if maxDepth <= 0 {
+			manifestCond.DriftHistory = nil
+			continue
+		}
+		history := manifestCond.DriftHistory
+		if drift, found := drifts[manifestCond.Identifier]; found {
+			history = append([]fleetv1beta1.DriftHistoryEntry{drift}, history...)
+		}
+		if len(history) > maxDepth {
+			// copy the kept entries so that the displaced ones are not retained by the backing array.
+			history = append([]fleetv1beta1.DriftHistoryEntry(nil), history[:maxDepth]...)
+		}
+		manifestCond.DriftHistory = history
+	}
+}
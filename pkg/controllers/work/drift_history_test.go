@@ -0,0 +1,125 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func TestObserveDrifts(t *testing.T) {
+	identifier := fleetv1beta1.WorkResourceIdentifier{Ordinal: 0, Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "default", Name: "app"}
+	now := metav1.Now()
+	appliedStatus := fleetv1beta1.WorkStatus{
+		Conditions: []metav1.Condition{
+			{Type: fleetv1beta1.WorkConditionTypeApplied, Status: metav1.ConditionTrue, ObservedGeneration: 1},
+		},
+		ManifestConditions: []fleetv1beta1.ManifestCondition{{
+			Identifier: identifier,
+			Conditions: []metav1.Condition{
+				{Type: fleetv1beta1.WorkConditionTypeApplied, Status: metav1.ConditionTrue, ObservedGeneration: 3},
+			},
+		}},
+	}
+	tests := map[string]struct {
+		generation int64
+		status     fleetv1beta1.WorkStatus
+		result     applyResult
+		want       map[fleetv1beta1.WorkResourceIdentifier]fleetv1beta1.DriftHistoryEntry
+	}{
+		"first apply": {
+			generation: 1,
+			result:     applyResult{identifier: identifier, generation: 1, action: manifestAvailableAction},
+		},
+		"resource unchanged": {
+			generation: 1,
+			status:     appliedStatus,
+			result:     applyResult{identifier: identifier, generation: 3, action: manifestAvailableAction},
+			want:       map[fleetv1beta1.WorkResourceIdentifier]fleetv1beta1.DriftHistoryEntry{},
+		},
+		"spec change applied to the resource": {
+			generation: 2,
+			status:     appliedStatus,
+			result:     applyResult{identifier: identifier, generation: 4, action: manifestAvailableAction},
+		},
+		"resource changed in the member cluster": {
+			generation: 1,
+			status:     appliedStatus,
+			result:     applyResult{identifier: identifier, generation: 5, action: manifestAvailableAction},
+			want: map[fleetv1beta1.WorkResourceIdentifier]fleetv1beta1.DriftHistoryEntry{
+				identifier: {ObservedTime: now, AppliedGeneration: 3, ObservedGeneration: 5},
+			},
+		},
+		"apply error": {
+			generation: 1,
+			status:     appliedStatus,
+			result:     applyResult{identifier: identifier, action: errorApplyAction, applyErr: errors.New("admission denied")},
+			want:       map[fleetv1beta1.WorkResourceIdentifier]fleetv1beta1.DriftHistoryEntry{},
+		},
+		"skipped manifest": {
+			generation: 1,
+			status:     appliedStatus,
+			result:     applyResult{identifier: identifier, action: manifestSkippedAction},
+			want:       map[fleetv1beta1.WorkResourceIdentifier]fleetv1beta1.DriftHistoryEntry{},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			work := &fleetv1beta1.Work{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-work", Generation: tt.generation},
+				Status:     *tt.status.DeepCopy(),
+			}
+			if diff := cmp.Diff(tt.want, observeDrifts(work, []applyResult{tt.result}, now)); diff != "" {
+				t.Errorf("observeDrifts() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateDriftHistory(t *testing.T) {
+	identifier := fleetv1beta1.WorkResourceIdentifier{Ordinal: 0, Kind: "Deployment", Name: "app"}
+	other := fleetv1beta1.WorkResourceIdentifier{Ordinal: 1, Kind: "Deployment", Name: "other"}
+	start := time.Now()
+	drift := func(i int) fleetv1beta1.DriftHistoryEntry {
+		return fleetv1beta1.DriftHistoryEntry{
+			ObservedTime:       metav1.NewTime(start.Add(time.Duration(i) * time.Minute)),
+			AppliedGeneration:  int64(i),
+			ObservedGeneration: int64(i + 1),
+		}
+	}
+	work := &fleetv1beta1.Work{
+		Status: fleetv1beta1.WorkStatus{
+			ManifestConditions: []fleetv1beta1.ManifestCondition{{Identifier: identifier}, {Identifier: other}},
+		},
+	}
+	for i := 1; i <= 4; i++ {
+		updateDriftHistory(work, map[fleetv1beta1.WorkResourceIdentifier]fleetv1beta1.DriftHistoryEntry{identifier: drift(i)}, 3)
+	}
+	// the 4th drift displaces the 1st one, and the newest drift comes first.
+	want := []fleetv1beta1.DriftHistoryEntry{drift(4), drift(3), drift(2)}
+	if diff := cmp.Diff(want, work.Status.ManifestConditions[0].DriftHistory); diff != "" {
+		t.Errorf("updateDriftHistory() drift history mismatch (-want +got):\n%s", diff)
+	}
+	if got := work.Status.ManifestConditions[1].DriftHistory; got != nil {
+		t.Errorf("updateDriftHistory() drift history of the manifest without drifts = %v, want nil", got)
+	}
+
+	updateDriftHistory(work, nil, 1)
+	if diff := cmp.Diff(want[:1], work.Status.ManifestConditions[0].DriftHistory); diff != "" {
+		t.Errorf("updateDriftHistory() drift history after the depth is lowered mismatch (-want +got):\n%s", diff)
+	}
+
+	updateDriftHistory(work, map[fleetv1beta1.WorkResourceIdentifier]fleetv1beta1.DriftHistoryEntry{identifier: drift(5)}, 0)
+	if got := work.Status.ManifestConditions[0].DriftHistory; got != nil {
+		t.Errorf("updateDriftHistory() drift history with the history disabled = %v, want nil", got)
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+// manifestDryRunFailedAction indicates that the manifest is not applied as the server-side dry-run of its apply
+// failed.
+const manifestDryRunFailedAction ApplyAction = ApplyAction(fleetv1beta1.ManifestProcessingApplyResultTypeDryRunFailed)
+
+// serverSideApply applies the manifest using server side apply, after a server-side dry-run of the same apply if
+// the apply strategy asks for one, so that a patch which the API server would reject never touches the resource.
+func (applier *ServerSideApplier) serverSideApply(ctx context.Context, applyStrategy *fleetv1beta1.ApplyStrategy, gvr schema.GroupVersionResource,
+	manifestObj *unstructured.Unstructured) (*unstructured.Unstructured, ApplyAction, error) {
+	force := applyStrategy.ServerSideApplyConfig.ForceConflicts
+	if applyStrategy.DryRunBeforeApply {
+		if err := applier.dryRunServerSideApply(ctx, force, gvr, manifestObj); err != nil {
+			return nil, manifestDryRunFailedAction, err
+		}
+	}
+	return serverSideApply(ctx, applier.SpokeDynamicClient, applier.FieldManager, force, gvr, manifestObj)
+}
+
+// dryRunServerSideApply performs a server-side dry-run of applying the manifest as the field manager of the applier.
+// The dry-run is skipped if the manifest is unchanged since its last apply, which the API server accepted already,
+// and for the resources with generated names, which cannot be applied by name.
+func (applier *ServerSideApplier) dryRunServerSideApply(ctx context.Context, force bool, gvr schema.GroupVersionResource,
+	manifestObj *unstructured.Unstructured) error {
+	if isManifestUnchanged(ctx) || manifestObj.GetName() == "" {
+		return nil
+	}
+	options := metav1.ApplyOptions{
+		FieldManager: applier.FieldManager,
+		Force:        force,
+		DryRun:       []string{metav1.DryRunAll},
+	}
+	if _, err := applier.SpokeDynamicClient.Resource(gvr).Namespace(manifestObj.GetNamespace()).Apply(ctx, manifestObj.GetName(), manifestObj, options); err != nil {
+		klog.ErrorS(err, "Failed to dry-run apply the manifest", "gvr", gvr, "manifest", klog.KObj(manifestObj))
+		return controller.NewAPIServerError(false, err)
+	}
+	klog.V(2).InfoS("Dry-run applied the manifest", "gvr", gvr, "manifest", klog.KObj(manifestObj))
+	return nil
+}
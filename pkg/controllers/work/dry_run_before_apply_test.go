@@ -0,0 +1,107 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	testingclient "k8s.io/client-go/testing"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+func TestServerSideApplyDryRunBeforeApply(t *testing.T) {
+	invalid := apierrors.NewInvalid(schema.GroupKind{Group: "apps", Kind: "Deployment"}, "web",
+		field.ErrorList{field.Invalid(field.NewPath("spec", "replicas"), -1, "must be greater than or equal to 0")})
+	tests := map[string]struct {
+		dryRunBeforeApply bool
+		unchanged         bool
+		// applyErrs are the errors of the apply calls in order; the calls beyond them succeed.
+		applyErrs  []error
+		wantCalls  int
+		wantAction ApplyAction
+		wantErr    bool
+	}{
+		"the manifest is applied without a dry-run by default": {
+			wantCalls:  1,
+			wantAction: manifestServerSideAppliedAction,
+		},
+		"the manifest is applied after its dry-run passes": {
+			dryRunBeforeApply: true,
+			wantCalls:         2,
+			wantAction:        manifestServerSideAppliedAction,
+		},
+		"the manifest is not applied if its dry-run fails": {
+			dryRunBeforeApply: true,
+			applyErrs:         []error{invalid},
+			wantCalls:         1,
+			wantAction:        manifestDryRunFailedAction,
+			wantErr:           true,
+		},
+		"the dry-run is skipped for the manifest unchanged since its last apply": {
+			dryRunBeforeApply: true,
+			unchanged:         true,
+			wantCalls:         1,
+			wantAction:        manifestServerSideAppliedAction,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			manifestObj := liveDeployment("web", "")
+			dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+			calls := 0
+			// the fake client does not support the apply patches, so the apply calls are answered here.
+			dynamicClient.PrependReactor("patch", "*", func(testingclient.Action) (bool, runtime.Object, error) {
+				calls++
+				if calls <= len(tt.applyErrs) {
+					return true, nil, tt.applyErrs[calls-1]
+				}
+				return true, manifestObj.DeepCopy(), nil
+			})
+			applier := &ServerSideApplier{SpokeDynamicClient: dynamicClient, FieldManager: DefaultFieldManagerName}
+			strategy := &fleetv1beta1.ApplyStrategy{
+				Type:                  fleetv1beta1.ApplyStrategyTypeServerSideApply,
+				ServerSideApplyConfig: &fleetv1beta1.ServerSideApplyConfig{},
+				DryRunBeforeApply:     tt.dryRunBeforeApply,
+			}
+			ctx := context.Background()
+			if tt.unchanged {
+				ctx = withUnchangedManifest(ctx)
+			}
+			gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+			_, action, err := applier.ApplyUnstructured(ctx, strategy, gvr, manifestObj)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("ApplyUnstructured() = %v, want error %t", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, controller.ErrAPIServerError) {
+				t.Errorf("ApplyUnstructured() = %v, want an API server error", err)
+			}
+			if action != tt.wantAction {
+				t.Errorf("ApplyUnstructured() action = %s, want %s", action, tt.wantAction)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("apply calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestBuildManifestConditionDryRunFailed(t *testing.T) {
+	conditions := buildManifestCondition(errors.New("rejected"), manifestDryRunFailedAction, 1)
+	want := string(fleetv1beta1.ManifestProcessingApplyResultTypeDryRunFailed)
+	if conditions[0].Type != fleetv1beta1.WorkConditionTypeApplied || conditions[0].Reason != want {
+		t.Errorf("buildManifestCondition() applied condition = %+v, want reason %s", conditions[0], want)
+	}
+}
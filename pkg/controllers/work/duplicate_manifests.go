@@ -0,0 +1,54 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/workdedup"
+)
+
+const (
+	// DuplicateManifestsRemovedReason is the reason of the work condition and the event when some manifests of the
+	// work duplicate a manifest before them and are not applied.
+	DuplicateManifestsRemovedReason = "DuplicateManifestsRemoved"
+)
+
+// reportDuplicateManifests sets the DuplicateManifestsRemoved condition of the work if some of its manifests duplicate
+// a manifest before them, and emits an event when the duplicates change. The condition is removed once the work has
+// no duplicates. The manifests are compared by the namespaces they are applied to.
+func (r *ApplyWorkReconciler) reportDuplicateManifests(work *fleetv1beta1.Work, targetNamespaces map[int]string) {
+	duplicates := workdedup.Duplicates(work.Spec.Workload.Manifests, targetNamespaces)
+	if len(duplicates) == 0 {
+		meta.RemoveStatusCondition(&work.Status.Conditions, fleetv1beta1.WorkConditionTypeDuplicateManifestsRemoved)
+		return
+	}
+	removed := make([]int, 0, len(duplicates))
+	for ordinal := range duplicates {
+		removed = append(removed, ordinal)
+	}
+	sort.Ints(removed)
+	message := fmt.Sprintf("The manifests with ordinals %v duplicate a manifest before them and are not applied", removed)
+
+	if cond := meta.FindStatusCondition(work.Status.Conditions, fleetv1beta1.WorkConditionTypeDuplicateManifestsRemoved); cond == nil || cond.Message != message {
+		klog.V(2).InfoS("Removed the duplicate manifests of the work", "work", klog.KObj(work), "ordinals", removed)
+		r.recorder.Event(work, v1.EventTypeWarning, DuplicateManifestsRemovedReason, message)
+	}
+	meta.SetStatusCondition(&work.Status.Conditions, metav1.Condition{
+		Type:               fleetv1beta1.WorkConditionTypeDuplicateManifestsRemoved,
+		Status:             metav1.ConditionTrue,
+		Reason:             DuplicateManifestsRemovedReason,
+		Message:            message,
+		ObservedGeneration: work.Generation,
+	})
+}
@@ -0,0 +1,87 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+)
+
+// deploymentManifests returns the manifests of the deployments with the given names.
+func deploymentManifests(t *testing.T, names ...string) []fleetv1beta1.Manifest {
+	t.Helper()
+	manifests := make([]fleetv1beta1.Manifest, len(names))
+	for i, name := range names {
+		raw, err := json.Marshal(liveDeployment(name, ""))
+		if err != nil {
+			t.Fatalf("failed to marshal the deployment: %v", err)
+		}
+		manifests[i] = fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: raw}}
+	}
+	return manifests
+}
+
+func TestApplyManifestsSkipsDuplicates(t *testing.T) {
+	applier := &priorityClassRecordingApplier{}
+	r := &ApplyWorkReconciler{
+		restMapper: testMapper{},
+		appliers:   map[fleetv1beta1.ApplyStrategyType]Applier{fleetv1beta1.ApplyStrategyTypeClientSideApply: applier},
+	}
+	manifests := deploymentManifests(t, "web", "api", "web")
+	results := r.applyManifests(context.Background(), manifests, ownerRef,
+		&fleetv1beta1.ApplyStrategy{Type: fleetv1beta1.ApplyStrategyTypeClientSideApply}, nil, nil, "", nil, nil, nil)
+
+	if got := len(applier.priorityClassNames); got != 2 {
+		t.Errorf("applyManifests() applied %d manifests, want 2", got)
+	}
+	duplicate := results[2]
+	if duplicate.identifier.Ordinal != 2 || duplicate.identifier.Name != "web" {
+		t.Errorf("duplicate manifest identifier = %+v, want ordinal 2 of deployment web", duplicate.identifier)
+	}
+	if duplicate.action != results[0].action || duplicate.applyErr != nil {
+		t.Errorf("duplicate manifest result = %+v, want the result of the manifest it duplicates %+v", duplicate, results[0])
+	}
+}
+
+func TestReportDuplicateManifests(t *testing.T) {
+	recorder := utils.NewFakeRecorder(10)
+	r := &ApplyWorkReconciler{recorder: recorder}
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: "fleet-member-test", Generation: 3},
+		Spec: fleetv1beta1.WorkSpec{
+			Workload: fleetv1beta1.WorkloadTemplate{Manifests: deploymentManifests(t, "web", "web", "api", "web")},
+		},
+	}
+
+	// the duplicates are reported with a single event across the reconciles.
+	r.reportDuplicateManifests(work, nil)
+	r.reportDuplicateManifests(work, nil)
+	cond := meta.FindStatusCondition(work.Status.Conditions, fleetv1beta1.WorkConditionTypeDuplicateManifestsRemoved)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != DuplicateManifestsRemovedReason || cond.ObservedGeneration != 3 {
+		t.Fatalf("DuplicateManifestsRemoved condition = %+v, want True for generation 3", cond)
+	}
+	if want := "The manifests with ordinals [1 3] duplicate a manifest before them and are not applied"; cond.Message != want {
+		t.Errorf("DuplicateManifestsRemoved condition message = %q, want %q", cond.Message, want)
+	}
+	if got := len(recorder.Events); got != 1 {
+		t.Errorf("emitted events = %d, want 1", got)
+	}
+
+	// the condition is removed once the duplicates are gone.
+	work.Spec.Workload.Manifests = deploymentManifests(t, "web", "api")
+	r.reportDuplicateManifests(work, nil)
+	if cond := meta.FindStatusCondition(work.Status.Conditions, fleetv1beta1.WorkConditionTypeDuplicateManifestsRemoved); cond != nil {
+		t.Errorf("DuplicateManifestsRemoved condition = %+v, want no condition", cond)
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// cleanUpStaleFieldManagement removes what the other apply strategy of the work applier left on the resource once the
+// apply strategy of the work is switched, which is
+//   - the managed fields entry of the server side apply after the resource is applied with the client side apply;
+//   - the last applied configuration annotation after the resource is applied with the server side apply.
+//
+// The leftovers are detected on the resource itself so that each of them is removed once per switch. A failed cleanup
+// does not fail the apply; it is tried again the next time the manifest is applied.
+func (r *ApplyWorkReconciler) cleanUpStaleFieldManagement(ctx context.Context, gvr schema.GroupVersionResource,
+	curObj *unstructured.Unstructured, strategyType fleetv1beta1.ApplyStrategyType, action ApplyAction) *unstructured.Unstructured {
+	if curObj == nil {
+		return curObj
+	}
+	var patch map[string]interface{}
+	switch strategyType {
+	case fleetv1beta1.ApplyStrategyTypeClientSideApply:
+		// the client side applier falls back to the server side apply for the manifests too large to be recorded.
+		if action == manifestServerSideAppliedAction {
+			return curObj
+		}
+		patch = staleServerSideApplyPatch(curObj, r.fieldManager)
+	case fleetv1beta1.ApplyStrategyTypeServerSideApply:
+		patch = staleLastAppliedConfigPatch(curObj)
+	}
+	if patch == nil {
+		return curObj
+	}
+
+	manifestRef := klog.KObj(curObj)
+	data, err := json.Marshal(patch)
+	if err != nil {
+		klog.ErrorS(err, "Failed to marshal the field management cleanup patch", "gvr", gvr, "manifest", manifestRef)
+		return curObj
+	}
+	// the JSON merge patch is used as the strategic merge patch is not supported by the custom resources; both replace
+	// the managed fields as a whole.
+	patched, err := r.spokeDynamicClient.Resource(gvr).Namespace(curObj.GetNamespace()).
+		Patch(ctx, curObj.GetName(), types.MergePatchType, data, metav1.PatchOptions{FieldManager: r.fieldManager})
+	if err != nil {
+		klog.ErrorS(err, "Failed to clean up the field management left by the previous apply strategy", "gvr", gvr,
+			"manifest", manifestRef, "applyStrategyType", strategyType)
+		return curObj
+	}
+	klog.V(2).InfoS("Cleaned up the field management left by the previous apply strategy", "gvr", gvr,
+		"manifest", manifestRef, "applyStrategyType", strategyType)
+	return patched
+}
+
+// staleServerSideApplyPatch returns the patch which drops the managed fields entries of the server side apply of the
+// work applier, i.e. of the field manager, from the resource, or nil if there is none. The entries of the other
+// field managers are kept, including those of the work appliers of the other fleets.
+func staleServerSideApplyPatch(curObj *unstructured.Unstructured, fieldManager string) map[string]interface{} {
+	var kept []metav1.ManagedFieldsEntry
+	for _, entry := range curObj.GetManagedFields() {
+		if entry.Manager == fieldManager && entry.Operation == metav1.ManagedFieldsOperationApply {
+			continue
+		}
+		kept = append(kept, entry)
+	}
+	// the API server ignores an empty list of managed fields.
+	if len(kept) == len(curObj.GetManagedFields()) || len(kept) == 0 {
+		return nil
+	}
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"managedFields": kept,
+			// the managed fields are replaced as a whole so the patch must not overwrite a concurrent change.
+			"resourceVersion": curObj.GetResourceVersion(),
+		},
+	}
+}
+
+// staleLastAppliedConfigPatch returns the patch which removes the last applied configuration annotation of the client
+// side apply of the work applier from the resource, or nil if there is none.
+func staleLastAppliedConfigPatch(curObj *unstructured.Unstructured) map[string]interface{} {
+	if _, found := curObj.GetAnnotations()[fleetv1beta1.LastAppliedConfigAnnotation]; !found {
+		return nil
+	}
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				fleetv1beta1.LastAppliedConfigAnnotation: nil,
+			},
+		},
+	}
+}
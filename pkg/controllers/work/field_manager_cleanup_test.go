@@ -0,0 +1,113 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+)
+
+func TestCleanUpStaleFieldManagement(t *testing.T) {
+	ssaEntry := metav1.ManagedFieldsEntry{Manager: DefaultFieldManagerName, Operation: metav1.ManagedFieldsOperationApply, APIVersion: "apps/v1"}
+	csaEntry := metav1.ManagedFieldsEntry{Manager: DefaultFieldManagerName, Operation: metav1.ManagedFieldsOperationUpdate, APIVersion: "apps/v1"}
+	otherEntry := metav1.ManagedFieldsEntry{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationApply, APIVersion: "apps/v1"}
+	tests := map[string]struct {
+		strategyType    fleetv1beta1.ApplyStrategyType
+		action          ApplyAction
+		managedFields   []metav1.ManagedFieldsEntry
+		annotations     map[string]string
+		wantPatched     bool
+		wantFields      []metav1.ManagedFieldsEntry
+		wantAnnotations map[string]string
+	}{
+		"client side apply removes the server side apply entry of the work applier only": {
+			strategyType:  fleetv1beta1.ApplyStrategyTypeClientSideApply,
+			action:        manifestThreeWayMergePatchAction,
+			managedFields: []metav1.ManagedFieldsEntry{ssaEntry, csaEntry, otherEntry},
+			wantPatched:   true,
+			wantFields:    []metav1.ManagedFieldsEntry{csaEntry, otherEntry},
+		},
+		"client side apply without the server side apply entry": {
+			strategyType:  fleetv1beta1.ApplyStrategyTypeClientSideApply,
+			action:        manifestThreeWayMergePatchAction,
+			managedFields: []metav1.ManagedFieldsEntry{csaEntry, otherEntry},
+			wantFields:    []metav1.ManagedFieldsEntry{csaEntry, otherEntry},
+		},
+		"client side apply falling back to the server side apply keeps its entry": {
+			strategyType:  fleetv1beta1.ApplyStrategyTypeClientSideApply,
+			action:        manifestServerSideAppliedAction,
+			managedFields: []metav1.ManagedFieldsEntry{ssaEntry, csaEntry},
+			wantFields:    []metav1.ManagedFieldsEntry{ssaEntry, csaEntry},
+		},
+		"server side apply removes the last applied configuration annotation only": {
+			strategyType:    fleetv1beta1.ApplyStrategyTypeServerSideApply,
+			action:          manifestServerSideAppliedAction,
+			managedFields:   []metav1.ManagedFieldsEntry{ssaEntry, csaEntry, otherEntry},
+			annotations:     map[string]string{fleetv1beta1.LastAppliedConfigAnnotation: "{}", "example.com/team": "a"},
+			wantPatched:     true,
+			wantFields:      []metav1.ManagedFieldsEntry{ssaEntry, csaEntry, otherEntry},
+			wantAnnotations: map[string]string{"example.com/team": "a"},
+		},
+		"server side apply without the last applied configuration annotation": {
+			strategyType:    fleetv1beta1.ApplyStrategyTypeServerSideApply,
+			action:          manifestServerSideAppliedAction,
+			managedFields:   []metav1.ManagedFieldsEntry{ssaEntry},
+			annotations:     map[string]string{"example.com/team": "a"},
+			wantFields:      []metav1.ManagedFieldsEntry{ssaEntry},
+			wantAnnotations: map[string]string{"example.com/team": "a"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			deploy := liveDeployment("deploy", "deploy-uid")
+			deploy.SetManagedFields(tt.managedFields)
+			deploy.SetAnnotations(tt.annotations)
+			dynamicClient := fake.NewSimpleDynamicClient(runtime.NewScheme(), deploy)
+			r := &ApplyWorkReconciler{spokeDynamicClient: dynamicClient, fieldManager: DefaultFieldManagerName}
+
+			// the cleanup happens once however many times the manifest is applied after the switch.
+			curObj := deploy.DeepCopy()
+			for i := 0; i < 2; i++ {
+				curObj = r.cleanUpStaleFieldManagement(context.Background(), utils.DeploymentGVR, curObj, tt.strategyType, tt.action)
+			}
+			patches := 0
+			for _, action := range dynamicClient.Actions() {
+				if action.GetVerb() == "patch" {
+					patches++
+				}
+			}
+			wantPatches := 0
+			if tt.wantPatched {
+				wantPatches = 1
+			}
+			if patches != wantPatches {
+				t.Errorf("cleanUpStaleFieldManagement() patched the resource %d times, want %d", patches, wantPatches)
+			}
+
+			got, err := dynamicClient.Resource(utils.DeploymentGVR).Namespace("default").Get(context.Background(), "deploy", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get the deployment: %v", err)
+			}
+			for _, obj := range []*unstructured.Unstructured{got, curObj} {
+				if diff := cmp.Diff(tt.wantFields, obj.GetManagedFields()); diff != "" {
+					t.Errorf("cleanUpStaleFieldManagement() managed fields mismatch (-want +got):\n%s", diff)
+				}
+				if diff := cmp.Diff(tt.wantAnnotations, obj.GetAnnotations()); diff != "" {
+					t.Errorf("cleanUpStaleFieldManagement() annotations mismatch (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+	"sigs.k8s.io/structured-merge-diff/v4/value"
+)
+
+// conflictingFieldManagers returns the names of the other field managers which own, through server side apply, the
+// fields the manifest would change in the resource, e.g. the work appliers of the other fleets which manage the same
+// member cluster. The entries of the field manager itself never conflict. A field the manifest sets to the value it
+// already has does not conflict either, as server side apply shares the ownership of such a field.
+func conflictingFieldManagers(manifestObj, curObj *unstructured.Unstructured, fieldManager string) []string {
+	var managers []string
+	for _, entry := range curObj.GetManagedFields() {
+		if entry.Manager == fieldManager || entry.Operation != metav1.ManagedFieldsOperationApply || entry.FieldsV1 == nil {
+			continue
+		}
+		owned := &fieldpath.Set{}
+		if err := owned.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+			klog.V(2).InfoS("Skip the managed fields entry which cannot be parsed", "manager", entry.Manager,
+				"manifest", klog.KObj(manifestObj), "err", err)
+			continue
+		}
+		conflicted := false
+		owned.Leaves().Iterate(func(path fieldpath.Path) {
+			if conflicted {
+				return
+			}
+			want, found := valueAtFieldPath(manifestObj.Object, path)
+			if !found {
+				return
+			}
+			got, _ := valueAtFieldPath(curObj.Object, path)
+			conflicted = !reflect.DeepEqual(want, got)
+		})
+		if conflicted {
+			managers = append(managers, entry.Manager)
+		}
+	}
+	sort.Strings(managers)
+	return managers
+}
+
+// valueAtFieldPath returns the value at the path of a managed field in the object, and whether it is found.
+func valueAtFieldPath(obj interface{}, path fieldpath.Path) (interface{}, bool) {
+	cur := obj
+	for _, element := range path {
+		switch {
+		case element.FieldName != nil:
+			fields, ok := cur.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			if cur, ok = fields[*element.FieldName]; !ok {
+				return nil, false
+			}
+		case element.Index != nil:
+			items, ok := cur.([]interface{})
+			if !ok || *element.Index >= len(items) {
+				return nil, false
+			}
+			cur = items[*element.Index]
+		case element.Key != nil:
+			items, ok := cur.([]interface{})
+			if !ok {
+				return nil, false
+			}
+			var item interface{}
+			for _, candidate := range items {
+				if matchesListKey(candidate, element.Key) {
+					item = candidate
+					break
+				}
+			}
+			if item == nil {
+				return nil, false
+			}
+			cur = item
+		case element.Value != nil:
+			items, ok := cur.([]interface{})
+			if !ok {
+				return nil, false
+			}
+			want := (*element.Value).Unstructured()
+			found := false
+			for _, candidate := range items {
+				if reflect.DeepEqual(candidate, want) {
+					cur, found = candidate, true
+					break
+				}
+			}
+			if !found {
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// matchesListKey returns whether the item of an associative list has the values of the key fields.
+func matchesListKey(item interface{}, key *value.FieldList) bool {
+	fields, ok := item.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for _, field := range *key {
+		if !reflect.DeepEqual(fields[field.Name], field.Value.Unstructured()) {
+			return false
+		}
+	}
+	return true
+}
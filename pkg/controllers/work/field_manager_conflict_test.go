@@ -0,0 +1,197 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	testingclient "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	fleetAFieldManager = "fleet-a"
+	fleetBFieldManager = "fleet-b"
+)
+
+// sharedDeployment returns the deployment applied by two fleets: the fleet A applies the replicas and the web
+// container, the fleet B applies the sidecar container.
+func sharedDeployment() *unstructured.Unstructured {
+	deploy := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "web", "namespace": "default"},
+		"spec": map[string]interface{}{
+			"replicas": int64(2),
+			"template": map[string]interface{}{"spec": map[string]interface{}{"containers": []interface{}{
+				map[string]interface{}{"name": "web", "image": "web:v1"},
+				map[string]interface{}{"name": "sidecar", "image": "sidecar:v1"},
+			}}},
+		},
+	}}
+	deploy.SetManagedFields([]metav1.ManagedFieldsEntry{
+		{
+			Manager:    fleetAFieldManager,
+			Operation:  metav1.ManagedFieldsOperationApply,
+			APIVersion: "apps/v1",
+			FieldsType: "FieldsV1",
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{},"f:template":{"f:spec":{"f:containers":` +
+				`{"k:{\"name\":\"web\"}":{".":{},"f:image":{},"f:name":{}}}}}}}`)},
+		},
+		{
+			Manager:    fleetBFieldManager,
+			Operation:  metav1.ManagedFieldsOperationApply,
+			APIVersion: "apps/v1",
+			FieldsType: "FieldsV1",
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:template":{"f:spec":{"f:containers":` +
+				`{"k:{\"name\":\"sidecar\"}":{".":{},"f:image":{},"f:name":{}}}}}}}`)},
+		},
+		{
+			// the fields changed by the updates are taken over by the applies without conflicts.
+			Manager:    "kube-controller-manager",
+			Operation:  metav1.ManagedFieldsOperationUpdate,
+			APIVersion: "apps/v1",
+			FieldsType: "FieldsV1",
+			FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)},
+		},
+	})
+	return deploy
+}
+
+// deploymentManifest returns the manifest of the shared deployment with the given replicas and containers.
+func deploymentManifest(replicas int64, containers ...map[string]interface{}) *unstructured.Unstructured {
+	spec := map[string]interface{}{}
+	if replicas > 0 {
+		spec["replicas"] = replicas
+	}
+	if len(containers) > 0 {
+		items := make([]interface{}, len(containers))
+		for i := range containers {
+			items[i] = containers[i]
+		}
+		spec["template"] = map[string]interface{}{"spec": map[string]interface{}{"containers": items}}
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "web", "namespace": "default"},
+		"spec":       spec,
+	}}
+}
+
+func TestConflictingFieldManagers(t *testing.T) {
+	tests := map[string]struct {
+		fieldManager string
+		manifest     *unstructured.Unstructured
+		want         []string
+	}{
+		"fleet A changes its own fields": {
+			fieldManager: fleetAFieldManager,
+			manifest:     deploymentManifest(3, map[string]interface{}{"name": "web", "image": "web:v2"}),
+		},
+		"fleet B changes its own fields": {
+			fieldManager: fleetBFieldManager,
+			manifest:     deploymentManifest(0, map[string]interface{}{"name": "sidecar", "image": "sidecar:v2"}),
+		},
+		"fleet B changes the replicas of fleet A": {
+			fieldManager: fleetBFieldManager,
+			manifest:     deploymentManifest(5, map[string]interface{}{"name": "sidecar", "image": "sidecar:v1"}),
+			want:         []string{fleetAFieldManager},
+		},
+		"fleet B sets the replicas of fleet A to the same value": {
+			fieldManager: fleetBFieldManager,
+			manifest:     deploymentManifest(2),
+		},
+		"fleet A changes the sidecar of fleet B": {
+			fieldManager: fleetAFieldManager,
+			manifest:     deploymentManifest(2, map[string]interface{}{"name": "sidecar", "image": "sidecar:v2"}),
+			want:         []string{fleetBFieldManager},
+		},
+		"a third fleet changes the fields of both": {
+			fieldManager: "fleet-c",
+			manifest: deploymentManifest(4, map[string]interface{}{"name": "web", "image": "web:v3"},
+				map[string]interface{}{"name": "sidecar", "image": "sidecar:v3"}),
+			want: []string{fleetAFieldManager, fleetBFieldManager},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := conflictingFieldManagers(tt.manifest, sharedDeployment(), tt.fieldManager)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("conflictingFieldManagers() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestServerSideApplierFieldManagers(t *testing.T) {
+	tests := map[string]struct {
+		fieldManager   string
+		forceConflicts bool
+		manifest       *unstructured.Unstructured
+		wantAction     ApplyAction
+		wantErr        bool
+	}{
+		"fleet A applies its fields next to fleet B": {
+			fieldManager: fleetAFieldManager,
+			manifest:     deploymentManifest(3, map[string]interface{}{"name": "web", "image": "web:v2"}),
+			wantAction:   manifestServerSideAppliedAction,
+		},
+		"fleet B applies its fields next to fleet A": {
+			fieldManager: fleetBFieldManager,
+			manifest:     deploymentManifest(0, map[string]interface{}{"name": "sidecar", "image": "sidecar:v2"}),
+			wantAction:   manifestServerSideAppliedAction,
+		},
+		"fleet B does not take over the fields of fleet A": {
+			fieldManager: fleetBFieldManager,
+			manifest:     deploymentManifest(5),
+			wantAction:   applyConflictWithOtherFieldManagers,
+			wantErr:      true,
+		},
+		"fleet B takes over the fields of fleet A when forced": {
+			fieldManager:   fleetBFieldManager,
+			forceConflicts: true,
+			manifest:       deploymentManifest(5),
+			wantAction:     manifestServerSideAppliedAction,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), sharedDeployment())
+			// the fake client does not support the server side apply.
+			dynamicClient.PrependReactor("patch", "*", func(testingclient.Action) (bool, runtime.Object, error) {
+				return true, tt.manifest.DeepCopy(), nil
+			})
+			applier := &ServerSideApplier{
+				HubClient:          fake.NewClientBuilder().Build(),
+				WorkNamespace:      testWorkNamespace,
+				SpokeDynamicClient: dynamicClient,
+				FieldManager:       tt.fieldManager,
+			}
+			strategy := &fleetv1beta1.ApplyStrategy{
+				Type:                  fleetv1beta1.ApplyStrategyTypeServerSideApply,
+				ServerSideApplyConfig: &fleetv1beta1.ServerSideApplyConfig{ForceConflicts: tt.forceConflicts},
+			}
+			gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+			_, action, err := applier.ApplyUnstructured(context.Background(), strategy, gvr, tt.manifest)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ApplyUnstructured() = %v, want error %t", err, tt.wantErr)
+			}
+			if action != tt.wantAction {
+				t.Errorf("ApplyUnstructured() action = %s, want %s", action, tt.wantAction)
+			}
+		})
+	}
+}
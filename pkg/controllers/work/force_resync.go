@@ -0,0 +1,41 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// forcedApplyKey is the context key which marks the applies of a forced resync.
+type forcedApplyKey struct{}
+
+// withForcedApply returns a context in which the manifests are applied even if their spec hashes are unchanged.
+func withForcedApply(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forcedApplyKey{}, true)
+}
+
+// isForcedApply returns true if the manifests are applied as part of a forced resync.
+func isForcedApply(ctx context.Context) bool {
+	forced, _ := ctx.Value(forcedApplyKey{}).(bool)
+	return forced
+}
+
+// isForcedResync returns true if a forced resync of the work has been requested since the work was processed last.
+// A request is honoured again after the work applier restarts since the processed versions are kept in memory.
+func (t *processedVersionTracker) isForcedResync(work *fleetv1beta1.Work) bool {
+	requested := work.GetAnnotations()[fleetv1beta1.ForceResyncAnnotation]
+	if requested == "" {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	processed, ok := t.versions[types.NamespacedName{Name: work.Name, Namespace: work.Namespace}]
+	return !ok || processed.forcedResync != requested
+}
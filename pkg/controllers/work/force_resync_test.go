@@ -0,0 +1,163 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/atomic"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+)
+
+// forceRecordingApplier applies the manifests as they are and records whether each apply is forced.
+type forceRecordingApplier struct {
+	forced []bool
+}
+
+func (a *forceRecordingApplier) ApplyUnstructured(ctx context.Context, _ *fleetv1beta1.ApplyStrategy, _ schema.GroupVersionResource,
+	manifestObj *unstructured.Unstructured) (*unstructured.Unstructured, ApplyAction, error) {
+	a.forced = append(a.forced, isForcedApply(ctx))
+	applied := manifestObj.DeepCopy()
+	applied.SetUID("deploy-uid")
+	return applied, manifestCreatedAction, nil
+}
+
+func TestReconcileForcedResync(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the fleet scheme: %v", err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the core scheme: %v", err)
+	}
+	workKey := types.NamespacedName{Name: "test-work", Namespace: "fleet-member-test"}
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: workKey.Name, Namespace: workKey.Namespace, Generation: 1},
+		Spec:       fleetv1beta1.WorkSpec{Workload: versionedWorkload(t, "v1")},
+	}
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(work).WithStatusSubresource(&fleetv1beta1.Work{}).Build()
+	spokeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&fleetv1beta1.AppliedWork{}).Build()
+	applier := &forceRecordingApplier{}
+	r := &ApplyWorkReconciler{
+		client:             hubClient,
+		spokeDynamicClient: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), liveDeployment("deploy", "deploy-uid")),
+		spokeClient:        spokeClient,
+		restMapper:         testMapper{},
+		recorder:           record.NewFakeRecorder(100),
+		joined:             atomic.NewBool(true),
+		appliers: map[fleetv1beta1.ApplyStrategyType]Applier{
+			fleetv1beta1.ApplyStrategyTypeClientSideApply: applier,
+			fleetv1beta1.ApplyStrategyTypeServerSideApply: applier,
+		},
+		processedVersions: newProcessedVersionTracker(),
+	}
+	reconcile := func() {
+		t.Helper()
+		if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: workKey}); err != nil {
+			t.Fatalf("Reconcile() = %v, want no error", err)
+		}
+	}
+	requestResync := func(requestedAt time.Time) {
+		t.Helper()
+		current := &fleetv1beta1.Work{}
+		if err := hubClient.Get(context.Background(), workKey, current); err != nil {
+			t.Fatalf("failed to get the work: %v", err)
+		}
+		current.SetAnnotations(map[string]string{fleetv1beta1.ForceResyncAnnotation: requestedAt.Format(time.RFC3339Nano)})
+		if err := hubClient.Update(context.Background(), current); err != nil {
+			t.Fatalf("failed to annotate the work: %v", err)
+		}
+	}
+
+	reconcile()
+	// the processed version is not applied again.
+	reconcile()
+	if diff := cmp.Diff([]bool{false}, applier.forced); diff != "" {
+		t.Fatalf("forced applies before the resync request mismatch (-want +got):\n%s", diff)
+	}
+
+	requestedAt := time.Now()
+	requestResync(requestedAt)
+	reconcile()
+	if diff := cmp.Diff([]bool{false, true}, applier.forced); diff != "" {
+		t.Errorf("forced applies after the resync request mismatch (-want +got):\n%s", diff)
+	}
+
+	// the same request is served only once.
+	reconcile()
+	if diff := cmp.Diff([]bool{false, true}, applier.forced); diff != "" {
+		t.Errorf("forced applies after the resync is served mismatch (-want +got):\n%s", diff)
+	}
+
+	requestResync(requestedAt.Add(time.Minute))
+	reconcile()
+	if diff := cmp.Diff([]bool{false, true, true}, applier.forced); diff != "" {
+		t.Errorf("forced applies after the second resync request mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestClientSideApplierForcedApply(t *testing.T) {
+	tests := map[string]struct {
+		forced    bool
+		wantPatch bool
+	}{
+		"unchanged manifest is not applied": {
+			forced:    false,
+			wantPatch: false,
+		},
+		"unchanged manifest is applied when forced": {
+			forced:    true,
+			wantPatch: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			manifest := liveDeployment("deploy", "")
+			hashed := manifest.DeepCopy()
+			if err := setManifestHashAnnotation(hashed); err != nil {
+				t.Fatalf("failed to compute the manifest hash: %v", err)
+			}
+			live := liveDeployment("deploy", "deploy-uid")
+			live.SetAnnotations(map[string]string{
+				fleetv1beta1.ManifestHashAnnotation: hashed.GetAnnotations()[fleetv1beta1.ManifestHashAnnotation],
+			})
+			dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), live)
+			applier := &ClientSideApplier{SpokeDynamicClient: dynamicClient}
+
+			ctx := context.Background()
+			if tt.forced {
+				ctx = withForcedApply(ctx)
+			}
+			// the fake client cannot always serve the patch; only whether the patch is sent matters here.
+			_, _, _ = applier.ApplyUnstructured(ctx, &fleetv1beta1.ApplyStrategy{}, utils.DeploymentGVR, manifest)
+
+			patched := false
+			for _, action := range dynamicClient.Actions() {
+				if action.GetVerb() == "patch" {
+					patched = true
+				}
+			}
+			if patched != tt.wantPatch {
+				t.Errorf("ApplyUnstructured() sent a patch = %t, want %t", patched, tt.wantPatch)
+			}
+		})
+	}
+}
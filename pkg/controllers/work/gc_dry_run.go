@@ -0,0 +1,72 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// WorkGCDryRunAnnotation is the annotation to preview the resources which the deletion of a work would garbage
+	// collect. When its value is "true", the deleting work keeps its finalizer and lists the resources in its status
+	// instead of deleting them; removing the annotation resumes the deletion.
+	WorkGCDryRunAnnotation = "fleet.azure.com/gc-dry-run"
+
+	// GCDryRunCompletedReason is the reason string of condition when the resources to garbage collect are listed.
+	GCDryRunCompletedReason = "GCDryRunCompleted"
+)
+
+// isGCDryRun returns true if the deletion of the work should only list the resources to garbage collect.
+func isGCDryRun(work *fleetv1beta1.Work) bool {
+	return work.GetAnnotations()[WorkGCDryRunAnnotation] == "true"
+}
+
+// dryRunGarbageCollection lists the resources owned by the appliedWork of the deleting work in its status without
+// deleting anything. Nothing is listed if the work orphans its resources on delete.
+func (r *ApplyWorkReconciler) dryRunGarbageCollection(ctx context.Context, work *fleetv1beta1.Work) error {
+	workRef := klog.KObj(work)
+	var appliedWork fleetv1beta1.AppliedWork
+	err := r.spokeClient.Get(ctx, types.NamespacedName{Name: work.Name}, &appliedWork)
+	switch {
+	case apierrors.IsNotFound(err):
+		klog.V(2).InfoS("The appliedWork is already deleted, nothing to garbage collect", "appliedWork", work.Name)
+	case err != nil:
+		klog.ErrorS(err, "Failed to retrieve the appliedWork", "appliedWork", work.Name)
+		return controller.NewAPIServerError(false, err)
+	}
+
+	resources := make([]fleetv1beta1.WorkResourceIdentifier, 0, len(appliedWork.Status.AppliedResources))
+	// the resources which the work orphans on delete are not garbage collected.
+	if !isOrphanOnDelete(work) {
+		for _, res := range appliedWork.Status.AppliedResources {
+			resources = append(resources, res.WorkResourceIdentifier)
+		}
+	}
+	work.Status.PendingGCResources = resources
+	meta.SetStatusCondition(&work.Status.Conditions, metav1.Condition{
+		Type:               fleetv1beta1.WorkConditionTypeGCDryRunCompleted,
+		Status:             metav1.ConditionTrue,
+		Reason:             GCDryRunCompletedReason,
+		Message:            fmt.Sprintf("%d resource(s) would be garbage collected, remove the %s annotation to delete them", len(resources), WorkGCDryRunAnnotation),
+		ObservedGeneration: work.Generation,
+	})
+	if err := r.updateWorkStatusIfChanged(ctx, work); err != nil {
+		klog.ErrorS(err, "Failed to update work status", "work", workRef)
+		return err
+	}
+	klog.V(2).InfoS("Listed the resources to garbage collect without deleting them", "work", workRef, "resources", len(resources))
+	return nil
+}
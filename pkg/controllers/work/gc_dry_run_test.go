@@ -0,0 +1,189 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func TestGarbageCollectAppliedWorkDryRun(t *testing.T) {
+	resources := []fleetv1beta1.WorkResourceIdentifier{
+		{Ordinal: 0, Version: "v1", Kind: "ConfigMap", Resource: "configmaps", Namespace: "default", Name: "cm"},
+		{Ordinal: 1, Group: "apps", Version: "v1", Kind: "Deployment", Resource: "deployments", Namespace: "default", Name: "deploy"},
+	}
+	tests := map[string]struct {
+		annotations     map[string]string
+		wantDryRun      bool
+		wantDeleteCalls int
+	}{
+		"dry-run lists the resources without deleting them": {
+			annotations: map[string]string{WorkGCDryRunAnnotation: "true"},
+			wantDryRun:  true,
+		},
+		"deletion without the dry-run annotation": {
+			wantDeleteCalls: 1,
+		},
+		"deletion with a false dry-run annotation": {
+			annotations:     map[string]string{WorkGCDryRunAnnotation: "false"},
+			wantDeleteCalls: 1,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+				t.Fatalf("failed to add the placement scheme: %v", err)
+			}
+			now := metav1.Now()
+			work := &fleetv1beta1.Work{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "test-work",
+					Namespace:         "fleet-member-test",
+					Generation:        1,
+					Annotations:       tt.annotations,
+					Finalizers:        []string{fleetv1beta1.WorkFinalizer},
+					DeletionTimestamp: &now,
+				},
+			}
+			appliedWork := &fleetv1beta1.AppliedWork{ObjectMeta: metav1.ObjectMeta{Name: work.Name}}
+			for _, res := range resources {
+				appliedWork.Status.AppliedResources = append(appliedWork.Status.AppliedResources,
+					fleetv1beta1.AppliedResourceMeta{WorkResourceIdentifier: res, UID: types.UID(res.Name)})
+			}
+			hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(work).WithStatusSubresource(work).Build()
+			deleteCalls := 0
+			spokeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(appliedWork).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+						deleteCalls++
+						return c.Delete(ctx, obj, opts...)
+					},
+				}).
+				Build()
+			r := &ApplyWorkReconciler{client: hubClient, spokeClient: spokeClient}
+
+			key := types.NamespacedName{Name: work.Name, Namespace: work.Namespace}
+			if err := hubClient.Get(context.Background(), key, work); err != nil {
+				t.Fatalf("failed to get the work: %v", err)
+			}
+			if _, err := r.garbageCollectAppliedWork(context.Background(), work); err != nil {
+				t.Fatalf("garbageCollectAppliedWork() = %v, want no error", err)
+			}
+			if deleteCalls != tt.wantDeleteCalls {
+				t.Errorf("garbageCollectAppliedWork() delete calls = %d, want %d", deleteCalls, tt.wantDeleteCalls)
+			}
+
+			var got fleetv1beta1.Work
+			err := hubClient.Get(context.Background(), key, &got)
+			if !tt.wantDryRun {
+				if !apierrors.IsNotFound(err) {
+					t.Errorf("work after the garbage collection: %v, want it deleted", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to get the work: %v", err)
+			}
+			if diff := cmp.Diff(resources, got.Status.PendingGCResources); diff != "" {
+				t.Errorf("garbageCollectAppliedWork() pendingGCResources mismatch (-want +got):\n%s", diff)
+			}
+			if !meta.IsStatusConditionTrue(got.Status.Conditions, fleetv1beta1.WorkConditionTypeGCDryRunCompleted) {
+				t.Errorf("garbageCollectAppliedWork() conditions = %+v, want %s to be true", got.Status.Conditions, fleetv1beta1.WorkConditionTypeGCDryRunCompleted)
+			}
+			if len(got.Finalizers) != 1 {
+				t.Errorf("garbageCollectAppliedWork() finalizers = %v, want the work finalizer kept", got.Finalizers)
+			}
+			var gotAppliedWork fleetv1beta1.AppliedWork
+			if err := spokeClient.Get(context.Background(), types.NamespacedName{Name: work.Name}, &gotAppliedWork); err != nil {
+				t.Errorf("failed to get the appliedWork after the dry-run: %v", err)
+			}
+		})
+	}
+}
+
+func TestGarbageCollectAppliedWorkNamespaceTerminating(t *testing.T) {
+	tests := map[string]struct {
+		namespaceTerminating bool
+		wantDeleteCalls      int
+	}{
+		"the appliedWork is deleted while the namespace is active": {
+			wantDeleteCalls: 1,
+		},
+		"the member cluster is left alone while the namespace is terminating": {
+			namespaceTerminating: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+				t.Fatalf("failed to add the placement scheme: %v", err)
+			}
+			if err := corev1.AddToScheme(scheme); err != nil {
+				t.Fatalf("failed to add the core scheme: %v", err)
+			}
+			now := metav1.Now()
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "fleet-member-test"}}
+			if tt.namespaceTerminating {
+				// the namespace controller deletes the works in the namespace once it is terminating.
+				namespace.Finalizers = []string{"kubernetes"}
+				namespace.DeletionTimestamp = &now
+			}
+			work := &fleetv1beta1.Work{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "test-work",
+					Namespace:         namespace.Name,
+					Finalizers:        []string{fleetv1beta1.WorkFinalizer},
+					DeletionTimestamp: &now,
+				},
+			}
+			hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(namespace, work).Build()
+			deleteCalls := 0
+			spokeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(&fleetv1beta1.AppliedWork{ObjectMeta: metav1.ObjectMeta{Name: work.Name}}).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+						deleteCalls++
+						return c.Delete(ctx, obj, opts...)
+					},
+				}).
+				Build()
+			r := &ApplyWorkReconciler{client: hubClient, spokeClient: spokeClient}
+
+			key := types.NamespacedName{Name: work.Name, Namespace: work.Namespace}
+			if err := hubClient.Get(context.Background(), key, work); err != nil {
+				t.Fatalf("failed to get the work: %v", err)
+			}
+			if _, err := r.garbageCollectAppliedWork(context.Background(), work); err != nil {
+				t.Fatalf("garbageCollectAppliedWork() = %v, want no error", err)
+			}
+			if deleteCalls != tt.wantDeleteCalls {
+				t.Errorf("garbageCollectAppliedWork() delete calls on the member cluster = %d, want %d", deleteCalls, tt.wantDeleteCalls)
+			}
+			// the work is deleted once its finalizer is removed.
+			if err := hubClient.Get(context.Background(), key, &fleetv1beta1.Work{}); !apierrors.IsNotFound(err) {
+				t.Errorf("work after the garbage collection: %v, want it deleted", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// defaultAvailabilityProbePeriod is how often the availability of a work which is not available is checked again
+	// if its health criteria do not set one.
+	defaultAvailabilityProbePeriod = time.Second * 3
+	// defaultDriftTolerance is how often an available work is applied again to correct the drifts if its health
+	// criteria do not set one.
+	defaultDriftTolerance = time.Minute * 5
+)
+
+// setHealthCriteriaFromPolicy merges the health criteria of the WorkHealthPolicy referenced by the work into the
+// work health criteria. The policy is read again on every reconcile so its changes are picked up by the next one.
+func (r *ApplyWorkReconciler) setHealthCriteriaFromPolicy(ctx context.Context, work *fleetv1beta1.Work) error {
+	if work.Spec.HealthPolicyRef == nil {
+		return nil
+	}
+	var policy fleetv1beta1.WorkHealthPolicy
+	policyKey := types.NamespacedName{Name: work.Spec.HealthPolicyRef.Name, Namespace: work.Namespace}
+	if err := r.client.Get(ctx, policyKey, &policy); err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.V(2).InfoS("The health policy of the work does not exist, use the health criteria of the work", "work", klog.KObj(work), "healthPolicy", policyKey)
+			return nil
+		}
+		klog.ErrorS(err, "Failed to get the health policy", "work", klog.KObj(work), "healthPolicy", policyKey)
+		return controller.NewAPIServerError(true, err)
+	}
+	mergeHealthCriteria(work, &policy.Spec)
+	return nil
+}
+
+// mergeHealthCriteria merges the given inherited criteria into the work health criteria; the criteria set on the work
+// always take precedence over the inherited ones.
+func mergeHealthCriteria(work *fleetv1beta1.Work, inherited *fleetv1beta1.WorkHealthCriteria) {
+	if work.Spec.HealthCriteria == nil {
+		work.Spec.HealthCriteria = &fleetv1beta1.WorkHealthCriteria{}
+	}
+	criteria := work.Spec.HealthCriteria
+	if criteria.AvailabilityProbePeriodSeconds == nil {
+		criteria.AvailabilityProbePeriodSeconds = inherited.AvailabilityProbePeriodSeconds
+	}
+	if criteria.DriftToleranceSeconds == nil {
+		criteria.DriftToleranceSeconds = inherited.DriftToleranceSeconds
+	}
+	if criteria.TimeoutSeconds == nil {
+		criteria.TimeoutSeconds = inherited.TimeoutSeconds
+	}
+}
+
+// availabilityProbePeriod returns how long to wait before checking the availability of the work again.
+func availabilityProbePeriod(work *fleetv1beta1.Work) time.Duration {
+	if work.Spec.HealthCriteria == nil || work.Spec.HealthCriteria.AvailabilityProbePeriodSeconds == nil {
+		return defaultAvailabilityProbePeriod
+	}
+	return time.Duration(*work.Spec.HealthCriteria.AvailabilityProbePeriodSeconds) * time.Second
+}
+
+// driftTolerance returns how long to wait before applying the available work again to correct the drifts.
+func driftTolerance(work *fleetv1beta1.Work) time.Duration {
+	if work.Spec.HealthCriteria == nil || work.Spec.HealthCriteria.DriftToleranceSeconds == nil {
+		return defaultDriftTolerance
+	}
+	return time.Duration(*work.Spec.HealthCriteria.DriftToleranceSeconds) * time.Second
+}
@@ -0,0 +1,149 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func TestMergeHealthCriteria(t *testing.T) {
+	inherited := &fleetv1beta1.WorkHealthCriteria{
+		AvailabilityProbePeriodSeconds: ptr.To[int32](10),
+		DriftToleranceSeconds:          ptr.To[int32](600),
+		TimeoutSeconds:                 ptr.To[int32](60),
+	}
+	tests := map[string]struct {
+		criteria *fleetv1beta1.WorkHealthCriteria
+		want     *fleetv1beta1.WorkHealthCriteria
+	}{
+		"work without health criteria inherits all the criteria": {
+			criteria: nil,
+			want:     inherited,
+		},
+		"work level criteria take precedence": {
+			criteria: &fleetv1beta1.WorkHealthCriteria{
+				AvailabilityProbePeriodSeconds: ptr.To[int32](1),
+				DriftToleranceSeconds:          ptr.To[int32](30),
+				TimeoutSeconds:                 ptr.To[int32](5),
+			},
+			want: &fleetv1beta1.WorkHealthCriteria{
+				AvailabilityProbePeriodSeconds: ptr.To[int32](1),
+				DriftToleranceSeconds:          ptr.To[int32](30),
+				TimeoutSeconds:                 ptr.To[int32](5),
+			},
+		},
+		"work level criteria with unset fields inherit the rest": {
+			criteria: &fleetv1beta1.WorkHealthCriteria{
+				DriftToleranceSeconds: ptr.To[int32](30),
+			},
+			want: &fleetv1beta1.WorkHealthCriteria{
+				AvailabilityProbePeriodSeconds: ptr.To[int32](10),
+				DriftToleranceSeconds:          ptr.To[int32](30),
+				TimeoutSeconds:                 ptr.To[int32](60),
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			work := &fleetv1beta1.Work{Spec: fleetv1beta1.WorkSpec{HealthCriteria: tt.criteria}}
+			mergeHealthCriteria(work, inherited)
+			if diff := cmp.Diff(tt.want, work.Spec.HealthCriteria); diff != "" {
+				t.Errorf("mergeHealthCriteria() health criteria mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetHealthCriteriaFromPolicy(t *testing.T) {
+	policy := &fleetv1beta1.WorkHealthPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "strict", Namespace: "fleet-member-test"},
+		Spec: fleetv1beta1.WorkHealthCriteria{
+			AvailabilityProbePeriodSeconds: ptr.To[int32](10),
+			DriftToleranceSeconds:          ptr.To[int32](60),
+		},
+	}
+	tests := map[string]struct {
+		objects                     []client.Object
+		healthPolicyRef             *corev1.LocalObjectReference
+		criteria                    *fleetv1beta1.WorkHealthCriteria
+		wantAvailabilityProbePeriod time.Duration
+		wantDriftTolerance          time.Duration
+		wantMemberAPITimeout        time.Duration
+	}{
+		"work without policy uses the built-in defaults": {
+			objects:                     []client.Object{policy},
+			wantAvailabilityProbePeriod: defaultAvailabilityProbePeriod,
+			wantDriftTolerance:          defaultDriftTolerance,
+			wantMemberAPITimeout:        defaultMemberAPITimeoutSeconds * time.Second,
+		},
+		"referenced policy does not exist": {
+			healthPolicyRef:             &corev1.LocalObjectReference{Name: "strict"},
+			wantAvailabilityProbePeriod: defaultAvailabilityProbePeriod,
+			wantDriftTolerance:          defaultDriftTolerance,
+			wantMemberAPITimeout:        defaultMemberAPITimeoutSeconds * time.Second,
+		},
+		"work inherits the referenced policy": {
+			objects:                     []client.Object{policy},
+			healthPolicyRef:             &corev1.LocalObjectReference{Name: "strict"},
+			wantAvailabilityProbePeriod: 10 * time.Second,
+			wantDriftTolerance:          time.Minute,
+			wantMemberAPITimeout:        defaultMemberAPITimeoutSeconds * time.Second,
+		},
+		"local overrides take precedence over the referenced policy": {
+			objects:         []client.Object{policy},
+			healthPolicyRef: &corev1.LocalObjectReference{Name: "strict"},
+			criteria: &fleetv1beta1.WorkHealthCriteria{
+				DriftToleranceSeconds: ptr.To[int32](30),
+				TimeoutSeconds:        ptr.To[int32](5),
+			},
+			wantAvailabilityProbePeriod: 10 * time.Second,
+			wantDriftTolerance:          30 * time.Second,
+			wantMemberAPITimeout:        5 * time.Second,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+				t.Fatalf("failed to add the placement scheme: %v", err)
+			}
+			r := &ApplyWorkReconciler{
+				client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build(),
+			}
+			work := &fleetv1beta1.Work{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: "fleet-member-test"},
+				Spec: fleetv1beta1.WorkSpec{
+					HealthPolicyRef: tt.healthPolicyRef,
+					HealthCriteria:  tt.criteria,
+				},
+			}
+			if err := r.setHealthCriteriaFromPolicy(context.Background(), work); err != nil {
+				t.Fatalf("setHealthCriteriaFromPolicy() = %v, want no error", err)
+			}
+			if got := availabilityProbePeriod(work); got != tt.wantAvailabilityProbePeriod {
+				t.Errorf("availabilityProbePeriod() = %v, want %v", got, tt.wantAvailabilityProbePeriod)
+			}
+			if got := driftTolerance(work); got != tt.wantDriftTolerance {
+				t.Errorf("driftTolerance() = %v, want %v", got, tt.wantDriftTolerance)
+			}
+			if got := memberAPITimeout(work); got != tt.wantMemberAPITimeout {
+				t.Errorf("memberAPITimeout() = %v, want %v", got, tt.wantMemberAPITimeout)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	// hubHealthzPath is the path to the hub cluster API server which the connectivity checks are performed against.
+	hubHealthzPath = "/healthz"
+	// hubConnectivityCheckInterval is the interval between two hub connectivity checks.
+	hubConnectivityCheckInterval = 30 * time.Second
+	// hubConnectivityCheckTimeout is the timeout of a single hub connectivity check.
+	hubConnectivityCheckTimeout = 5 * time.Second
+	// hubConnectivityFailureThreshold is the number of consecutive failed checks after which the hub connectivity
+	// is considered lost, so that a single network blip does not mark the works.
+	hubConnectivityFailureThreshold = 3
+)
+
+// hubConnectivityTransition is a change of the hub connectivity observed by a check.
+type hubConnectivityTransition int
+
+const (
+	// hubConnectivityUnchanged means the check does not change whether the hub connectivity is lost.
+	hubConnectivityUnchanged hubConnectivityTransition = iota
+	// hubConnectivityLost means the check is the last of the consecutive failed checks which lose the connectivity.
+	hubConnectivityLost
+	// hubConnectivityRecovered means the check is the first successful check since the connectivity was lost.
+	hubConnectivityRecovered
+)
+
+// make sure that our monitor implements controller runtime interfaces
+var (
+	_ manager.Runnable               = &hubConnectivityMonitor{}
+	_ manager.LeaderElectionRunnable = &hubConnectivityMonitor{}
+)
+
+// hubConnectivityMonitor periodically checks whether the hub cluster API server is reachable from the member agent,
+// to tell the network issues apart from the agent crashes. Once the connectivity is lost, the works in the local
+// cache are marked with HubConnectivityLost, without any call to the hub cluster; once it recovers, the mark is
+// cleared and the works are requeued right away instead of waiting for their next resync.
+type hubConnectivityMonitor struct {
+	restClient rest.Interface
+	interval   time.Duration
+	// store returns the local cache store of the works.
+	store func(ctx context.Context) (toolscache.Store, error)
+	// requeue receives the works to requeue once the connectivity recovers.
+	requeue chan event.GenericEvent
+
+	mu                  sync.RWMutex
+	consecutiveFailures int
+	lost                bool
+}
+
+// newHubConnectivityMonitor returns a monitor which checks the hub cluster API server with the REST client, and marks
+// the works in the informer cache.
+func newHubConnectivityMonitor(restClient rest.Interface, informerCache cache.Cache) *hubConnectivityMonitor {
+	return &hubConnectivityMonitor{
+		restClient: restClient,
+		interval:   hubConnectivityCheckInterval,
+		store: func(ctx context.Context) (toolscache.Store, error) {
+			informer, err := informerCache.GetInformer(ctx, &fleetv1beta1.Work{})
+			if err != nil {
+				return nil, err
+			}
+			sharedInformer, ok := informer.(toolscache.SharedIndexInformer)
+			if !ok {
+				return nil, fmt.Errorf("the work informer %T has no local store", informer)
+			}
+			return sharedInformer.GetStore(), nil
+		},
+		requeue: make(chan event.GenericEvent, 1024),
+	}
+}
+
+// Start implements the Runnable interface; it keeps checking the hub cluster API server until the context is done.
+func (m *hubConnectivityMonitor) Start(ctx context.Context) error {
+	klog.V(2).InfoS("Starting the hub connectivity monitor", "interval", m.interval)
+	defer klog.V(2).InfoS("Stopping the hub connectivity monitor")
+	wait.UntilWithContext(ctx, m.check, m.interval)
+	return nil
+}
+
+// NeedLeaderElection implements the LeaderElectionRunnable interface.
+// The connectivity is local to each agent so every agent checks on its own.
+func (m *hubConnectivityMonitor) NeedLeaderElection() bool {
+	return false
+}
+
+// isLost returns true if the hub connectivity is lost.
+func (m *hubConnectivityMonitor) isLost() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.lost
+}
+
+func (m *hubConnectivityMonitor) check(ctx context.Context) {
+	checkCtx, cancel := context.WithTimeout(ctx, hubConnectivityCheckTimeout)
+	defer cancel()
+	var statusCode int
+	err := m.restClient.Get().AbsPath(hubHealthzPath).Do(checkCtx).StatusCode(&statusCode).Error()
+	if err == nil && statusCode != http.StatusOK {
+		err = fmt.Errorf("hub connectivity check failed with status code %d", statusCode)
+	}
+	m.observe(ctx, err)
+}
+
+// observe records the result of a check and marks, or clears, the works in the local cache when the connectivity
+// is lost, or recovers.
+func (m *hubConnectivityMonitor) observe(ctx context.Context, checkErr error) {
+	switch m.recordCheck(checkErr) {
+	case hubConnectivityLost:
+		klog.ErrorS(checkErr, "Lost the connectivity to the hub cluster", "consecutiveFailures", hubConnectivityFailureThreshold)
+		m.markWorks(ctx, true)
+	case hubConnectivityRecovered:
+		klog.InfoS("The connectivity to the hub cluster recovered")
+		for _, work := range m.markWorks(ctx, false) {
+			m.requeue <- event.GenericEvent{Object: work}
+		}
+	default:
+		if checkErr != nil {
+			klog.V(2).InfoS("Hub connectivity check failed", "err", checkErr)
+		}
+	}
+}
+
+// recordCheck records the result of a check and returns the transition of the connectivity it causes.
+func (m *hubConnectivityMonitor) recordCheck(checkErr error) hubConnectivityTransition {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if checkErr == nil {
+		m.consecutiveFailures = 0
+		if m.lost {
+			m.lost = false
+			return hubConnectivityRecovered
+		}
+		return hubConnectivityUnchanged
+	}
+	m.consecutiveFailures++
+	if !m.lost && m.consecutiveFailures >= hubConnectivityFailureThreshold {
+		m.lost = true
+		return hubConnectivityLost
+	}
+	return hubConnectivityUnchanged
+}
+
+// markWorks sets HubConnectivityLost of the works in the local cache and returns the works.
+// The cached works are replaced with the marked copies so that the cached objects shared with the readers are never
+// modified in place.
+func (m *hubConnectivityMonitor) markWorks(ctx context.Context, lost bool) []*fleetv1beta1.Work {
+	store, err := m.store(ctx)
+	if err != nil {
+		klog.ErrorS(err, "Failed to get the local cache of the works")
+		return nil
+	}
+	objs := store.List()
+	works := make([]*fleetv1beta1.Work, 0, len(objs))
+	for _, obj := range objs {
+		work, ok := obj.(*fleetv1beta1.Work)
+		if !ok {
+			continue
+		}
+		if work.Status.HubConnectivityLost != lost {
+			work = work.DeepCopy()
+			work.Status.HubConnectivityLost = lost
+			if err := store.Update(work); err != nil {
+				klog.ErrorS(err, "Failed to mark the work in the local cache", "work", klog.KObj(work), "hubConnectivityLost", lost)
+			}
+		}
+		works = append(works, work)
+	}
+	klog.V(2).InfoS("Marked the works in the local cache", "works", len(works), "hubConnectivityLost", lost)
+	return works
+}
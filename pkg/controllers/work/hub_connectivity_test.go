@@ -0,0 +1,144 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func TestHubConnectivityMonitorRecordCheck(t *testing.T) {
+	errCheck := errors.New("connection refused")
+	checks := []struct {
+		err            error
+		wantTransition hubConnectivityTransition
+		wantLost       bool
+	}{
+		{err: nil, wantTransition: hubConnectivityUnchanged},
+		{err: errCheck, wantTransition: hubConnectivityUnchanged},
+		{err: errCheck, wantTransition: hubConnectivityUnchanged},
+		// a success resets the consecutive failures.
+		{err: nil, wantTransition: hubConnectivityUnchanged},
+		{err: errCheck, wantTransition: hubConnectivityUnchanged},
+		{err: errCheck, wantTransition: hubConnectivityUnchanged},
+		{err: errCheck, wantTransition: hubConnectivityLost, wantLost: true},
+		{err: errCheck, wantTransition: hubConnectivityUnchanged, wantLost: true},
+		{err: nil, wantTransition: hubConnectivityRecovered},
+		{err: nil, wantTransition: hubConnectivityUnchanged},
+	}
+	m := &hubConnectivityMonitor{}
+	for i, check := range checks {
+		if got := m.recordCheck(check.err); got != check.wantTransition {
+			t.Errorf("check %d: recordCheck() = %v, want %v", i, got, check.wantTransition)
+		}
+		if got := m.isLost(); got != check.wantLost {
+			t.Errorf("check %d: isLost() = %t, want %t", i, got, check.wantLost)
+		}
+	}
+}
+
+func TestHubConnectivityMonitorObserve(t *testing.T) {
+	store := toolscache.NewStore(toolscache.MetaNamespaceKeyFunc)
+	cached := []*fleetv1beta1.Work{
+		{ObjectMeta: metav1.ObjectMeta{Name: "work-1", Namespace: "fleet-member-test"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "work-2", Namespace: "fleet-member-test"}},
+	}
+	for _, work := range cached {
+		if err := store.Add(work); err != nil {
+			t.Fatalf("failed to add the work to the store: %v", err)
+		}
+	}
+	m := &hubConnectivityMonitor{
+		store:   func(context.Context) (toolscache.Store, error) { return store, nil },
+		requeue: make(chan event.GenericEvent, len(cached)),
+	}
+	markedWorks := func() map[string]bool {
+		marked := make(map[string]bool)
+		for _, obj := range store.List() {
+			work := obj.(*fleetv1beta1.Work)
+			marked[work.Name] = work.Status.HubConnectivityLost
+		}
+		return marked
+	}
+	ctx := context.Background()
+
+	for i := 0; i < hubConnectivityFailureThreshold; i++ {
+		m.observe(ctx, errors.New("connection refused"))
+	}
+	if diff := cmp.Diff(map[string]bool{"work-1": true, "work-2": true}, markedWorks()); diff != "" {
+		t.Errorf("works marked after the connectivity is lost mismatch (-want +got):\n%s", diff)
+	}
+	// the objects shared with the readers of the cache are not modified in place.
+	for _, work := range cached {
+		if work.Status.HubConnectivityLost {
+			t.Errorf("cached work %s is modified in place", work.Name)
+		}
+	}
+	if len(m.requeue) != 0 {
+		t.Errorf("requeued %d works when the connectivity is lost, want none", len(m.requeue))
+	}
+
+	m.observe(ctx, nil)
+	if diff := cmp.Diff(map[string]bool{"work-1": false, "work-2": false}, markedWorks()); diff != "" {
+		t.Errorf("works marked after the connectivity recovers mismatch (-want +got):\n%s", diff)
+	}
+	requeued := make(map[string]bool)
+	for len(m.requeue) > 0 {
+		requeued[(<-m.requeue).Object.GetName()] = true
+	}
+	if diff := cmp.Diff(map[string]bool{"work-1": true, "work-2": true}, requeued); diff != "" {
+		t.Errorf("works requeued after the connectivity recovers mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestHubConnectivityMonitorCheck(t *testing.T) {
+	statusCode := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != hubHealthzPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(statusCode)
+	}))
+	defer server.Close()
+	clientSet, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("failed to create the client set: %v", err)
+	}
+	m := &hubConnectivityMonitor{
+		restClient: clientSet.Discovery().RESTClient(),
+		store: func(context.Context) (toolscache.Store, error) {
+			return toolscache.NewStore(toolscache.MetaNamespaceKeyFunc), nil
+		},
+		requeue: make(chan event.GenericEvent),
+	}
+	ctx := context.Background()
+
+	statusCode = http.StatusInternalServerError
+	for i := 0; i < hubConnectivityFailureThreshold; i++ {
+		m.check(ctx)
+	}
+	if !m.isLost() {
+		t.Errorf("isLost() after %d failed checks = false, want true", hubConnectivityFailureThreshold)
+	}
+	statusCode = http.StatusOK
+	m.check(ctx)
+	if m.isLost() {
+		t.Errorf("isLost() after a successful check = true, want false")
+	}
+}
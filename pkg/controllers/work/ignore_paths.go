@@ -0,0 +1,77 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// withoutIgnoredPaths returns a copy of the object without the fields at the ignored paths, which are JSON pointers
+// (RFC 6901), so that the fields are excluded from the comparisons. The object itself is returned if there is no path
+// to ignore. The paths which are not valid JSON pointers or do not exist in the object are skipped.
+func withoutIgnoredPaths(obj map[string]interface{}, ignorePaths []string) map[string]interface{} {
+	if len(ignorePaths) == 0 || obj == nil {
+		return obj
+	}
+	trimmed := runtime.DeepCopyJSON(obj)
+	for _, path := range ignorePaths {
+		tokens, ok := parseJSONPointer(path)
+		if !ok {
+			continue
+		}
+		removeField(trimmed, tokens)
+	}
+	return trimmed
+}
+
+// parseJSONPointer returns the unescaped reference tokens of the JSON pointer, or false if it is not a valid pointer
+// to a field. The empty pointer, which refers to the whole object, is not a field.
+func parseJSONPointer(pointer string) ([]string, bool) {
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, false
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		// "~1" is unescaped before "~0" so that "~01" becomes "~1" rather than "/".
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, true
+}
+
+// removeField removes the field referred to by the tokens from the value, walking into the objects by their keys and
+// into the lists by their indexes. It returns the value without the field, which differs from the given one only if
+// a list item is removed.
+func removeField(value interface{}, tokens []string) interface{} {
+	token, rest := tokens[0], tokens[1:]
+	switch node := value.(type) {
+	case map[string]interface{}:
+		child, ok := node[token]
+		if !ok {
+			return value
+		}
+		if len(rest) == 0 {
+			delete(node, token)
+			return node
+		}
+		node[token] = removeField(child, rest)
+		return node
+	case []interface{}:
+		index, err := strconv.Atoi(token)
+		if err != nil || index < 0 || index >= len(node) {
+			return value
+		}
+		if len(rest) == 0 {
+			return append(node[:index:index], node[index+1:]...)
+		}
+		node[index] = removeField(node[index], rest)
+		return node
+	default:
+		return value
+	}
+}
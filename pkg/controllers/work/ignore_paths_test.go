@@ -0,0 +1,93 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWithoutIgnoredPaths(t *testing.T) {
+	newObj := func() map[string]interface{} {
+		return map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": map[string]interface{}{
+					"example.com/owner": "team-a",
+					"a~b":               "tilde",
+				},
+			},
+			"spec": map[string]interface{}{
+				"replicas": int64(3),
+				"containers": []interface{}{
+					map[string]interface{}{"name": "app", "image": "app:v1"},
+					map[string]interface{}{"name": "sidecar", "image": "sidecar:v1"},
+				},
+			},
+		}
+	}
+	tests := map[string]struct {
+		ignorePaths []string
+		want        map[string]interface{}
+	}{
+		"no ignored path": {
+			want: newObj(),
+		},
+		"nested field": {
+			ignorePaths: []string{"/spec/replicas"},
+			want: func() map[string]interface{} {
+				obj := newObj()
+				delete(obj["spec"].(map[string]interface{}), "replicas")
+				return obj
+			}(),
+		},
+		"escaped tokens": {
+			ignorePaths: []string{"/metadata/annotations/example.com~1owner", "/metadata/annotations/a~0b"},
+			want: func() map[string]interface{} {
+				obj := newObj()
+				obj["metadata"].(map[string]interface{})["annotations"] = map[string]interface{}{}
+				return obj
+			}(),
+		},
+		"field of a list item": {
+			ignorePaths: []string{"/spec/containers/1/image"},
+			want: func() map[string]interface{} {
+				obj := newObj()
+				delete(obj["spec"].(map[string]interface{})["containers"].([]interface{})[1].(map[string]interface{}), "image")
+				return obj
+			}(),
+		},
+		"list item": {
+			ignorePaths: []string{"/spec/containers/0"},
+			want: func() map[string]interface{} {
+				obj := newObj()
+				spec := obj["spec"].(map[string]interface{})
+				spec["containers"] = spec["containers"].([]interface{})[1:]
+				return obj
+			}(),
+		},
+		"missing paths and out of range indexes": {
+			ignorePaths: []string{"/spec/paused", "/status/replicas", "/spec/containers/2", "/spec/containers/name", "/spec/replicas/value"},
+			want:        newObj(),
+		},
+		"invalid pointers": {
+			ignorePaths: []string{"", "spec/replicas"},
+			want:        newObj(),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			obj := newObj()
+			got := withoutIgnoredPaths(obj, tt.ignorePaths)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("withoutIgnoredPaths() mismatch (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(newObj(), obj); diff != "" {
+				t.Errorf("withoutIgnoredPaths() mutated the object (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	// OutsideMaintenanceWindowReason is the reason string of condition when the maintenance window of the work has
+	// not started yet.
+	OutsideMaintenanceWindowReason = "OutsideMaintenanceWindow"
+
+	// MaintenanceWindowExpiredReason is the reason string of condition when the maintenance window of the work has
+	// ended.
+	MaintenanceWindowExpiredReason = "MaintenanceWindowExpired"
+)
+
+// gateOnMaintenanceWindow checks that the given time is within the maintenance window of the work before its
+// manifests are applied. It returns true if the work must not be applied, along with how long to wait before the
+// window starts; there is nothing to wait for once the window has ended.
+func (r *ApplyWorkReconciler) gateOnMaintenanceWindow(ctx context.Context, work *fleetv1beta1.Work, now time.Time) (bool, time.Duration, error) {
+	var reason, message string
+	var requeueAfter time.Duration
+	switch {
+	case work.Spec.NotBeforeTime != nil && now.Before(work.Spec.NotBeforeTime.Time):
+		reason = OutsideMaintenanceWindowReason
+		message = fmt.Sprintf("The maintenance window starts at %s", work.Spec.NotBeforeTime.UTC().Format(time.RFC3339))
+		requeueAfter = work.Spec.NotBeforeTime.Sub(now)
+	case work.Spec.NotAfterTime != nil && now.After(work.Spec.NotAfterTime.Time):
+		reason = MaintenanceWindowExpiredReason
+		message = fmt.Sprintf("The maintenance window ended at %s", work.Spec.NotAfterTime.UTC().Format(time.RFC3339))
+	default:
+		return false, 0, nil
+	}
+	logObjRef := klog.KObj(work)
+	klog.V(2).InfoS("The work is outside of its maintenance window", "work", logObjRef, "reason", reason, "requeueAfter", requeueAfter)
+	meta.SetStatusCondition(&work.Status.Conditions, metav1.Condition{
+		Type:               fleetv1beta1.WorkConditionTypeApplied,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: work.Generation,
+	})
+	if err := r.updateWorkStatusIfChanged(ctx, work); err != nil {
+		klog.ErrorS(err, "Failed to update work status", "work", logObjRef)
+		return true, 0, err
+	}
+	return true, requeueAfter, nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func TestGateOnMaintenanceWindow(t *testing.T) {
+	start := time.Date(2024, 6, 1, 2, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+	// the steps walk the time through the maintenance window of the same work.
+	steps := []struct {
+		name             string
+		now              time.Time
+		wantPending      bool
+		wantRequeueAfter time.Duration
+		wantReason       string
+	}{
+		{
+			name:             "before the window",
+			now:              start.Add(-90 * time.Minute),
+			wantPending:      true,
+			wantRequeueAfter: 90 * time.Minute,
+			wantReason:       OutsideMaintenanceWindowReason,
+		},
+		{
+			name:             "right before the window",
+			now:              start.Add(-time.Second),
+			wantPending:      true,
+			wantRequeueAfter: time.Second,
+			wantReason:       OutsideMaintenanceWindowReason,
+		},
+		{
+			name: "start of the window",
+			now:  start,
+		},
+		{
+			name: "within the window",
+			now:  start.Add(time.Hour),
+		},
+		{
+			name: "end of the window",
+			now:  end,
+		},
+		{
+			name:        "after the window",
+			now:         end.Add(time.Second),
+			wantPending: true,
+			wantReason:  MaintenanceWindowExpiredReason,
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: "fleet-member-test", Generation: 1},
+		Spec: fleetv1beta1.WorkSpec{
+			NotBeforeTime: &metav1.Time{Time: start},
+			NotAfterTime:  &metav1.Time{Time: end},
+		},
+	}
+	workKey := types.NamespacedName{Name: work.Name, Namespace: work.Namespace}
+	hubClient := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(work).WithStatusSubresource(work).Build()
+	r := &ApplyWorkReconciler{client: hubClient}
+	for _, step := range steps {
+		current := &fleetv1beta1.Work{}
+		if err := hubClient.Get(context.Background(), workKey, current); err != nil {
+			t.Fatalf("%s: failed to get the work: %v", step.name, err)
+		}
+		before := meta.FindStatusCondition(current.Status.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+		pending, requeueAfter, err := r.gateOnMaintenanceWindow(context.Background(), current, step.now)
+		if err != nil {
+			t.Fatalf("%s: gateOnMaintenanceWindow() = %v, want no error", step.name, err)
+		}
+		if pending != step.wantPending || requeueAfter != step.wantRequeueAfter {
+			t.Errorf("%s: gateOnMaintenanceWindow() = (%t, %v), want (%t, %v)", step.name, pending, requeueAfter, step.wantPending, step.wantRequeueAfter)
+		}
+
+		got := &fleetv1beta1.Work{}
+		if err := hubClient.Get(context.Background(), workKey, got); err != nil {
+			t.Fatalf("%s: failed to get the work: %v", step.name, err)
+		}
+		appliedCond := meta.FindStatusCondition(got.Status.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+		if step.wantPending {
+			if appliedCond == nil || appliedCond.Status != metav1.ConditionFalse || appliedCond.Reason != step.wantReason {
+				t.Errorf("%s: gateOnMaintenanceWindow() applied condition = %+v, want false with reason %s", step.name, appliedCond, step.wantReason)
+			}
+			continue
+		}
+		// the gate leaves the status of a work within its window to the apply.
+		if diff := cmp.Diff(before, appliedCond); diff != "" {
+			t.Errorf("%s: gateOnMaintenanceWindow() changed the applied condition (-want +got):\n%s", step.name, diff)
+		}
+		// simulate the apply of the manifests within the window.
+		meta.SetStatusCondition(&got.Status.Conditions, metav1.Condition{
+			Type: fleetv1beta1.WorkConditionTypeApplied, Status: metav1.ConditionTrue, Reason: workAppliedCompletedReason, ObservedGeneration: 1,
+		})
+		if err := hubClient.Status().Update(context.Background(), got); err != nil {
+			t.Fatalf("%s: failed to update the work status: %v", step.name, err)
+		}
+	}
+}
+
+func TestGateOnMaintenanceWindowWithoutWindow(t *testing.T) {
+	r := &ApplyWorkReconciler{}
+	work := &fleetv1beta1.Work{ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: "fleet-member-test"}}
+	pending, requeueAfter, err := r.gateOnMaintenanceWindow(context.Background(), work, time.Now())
+	if err != nil || pending || requeueAfter != 0 {
+		t.Errorf("gateOnMaintenanceWindow() = (%t, %v, %v), want the work applied", pending, requeueAfter, err)
+	}
+	if len(work.Status.Conditions) != 0 {
+		t.Errorf("gateOnMaintenanceWindow() set the conditions %+v, want none", work.Status.Conditions)
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/resource"
+)
+
+// lastAppliedHashesKey is the context key of the hashes of the manifests last applied by the work.
+type lastAppliedHashesKey struct{}
+
+// unchangedManifestKey is the context key which marks the apply of a manifest unchanged since its last apply.
+type unchangedManifestKey struct{}
+
+// withLastAppliedHashes returns a context which carries the hashes of the manifests last applied by the work,
+// keyed by their ordinals.
+func withLastAppliedHashes(ctx context.Context, work *fleetv1beta1.Work) context.Context {
+	hashes := make(map[int]string, len(work.Status.ManifestConditions))
+	for _, manifestCond := range work.Status.ManifestConditions {
+		if manifestCond.LastAppliedHash != "" {
+			hashes[manifestCond.Identifier.Ordinal] = manifestCond.LastAppliedHash
+		}
+	}
+	return context.WithValue(ctx, lastAppliedHashesKey{}, hashes)
+}
+
+// lastAppliedHash returns the hash of the manifest with the ordinal which was last applied, if any.
+func lastAppliedHash(ctx context.Context, ordinal int) string {
+	hashes, _ := ctx.Value(lastAppliedHashesKey{}).(map[int]string)
+	return hashes[ordinal]
+}
+
+// withUnchangedManifest returns a context in which the manifest is known to be unchanged since its last apply.
+func withUnchangedManifest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, unchangedManifestKey{}, true)
+}
+
+// isManifestUnchanged returns true if the manifest is unchanged since its last apply.
+func isManifestUnchanged(ctx context.Context) bool {
+	unchanged, _ := ctx.Value(unchangedManifestKey{}).(bool)
+	return unchanged
+}
+
+// manifestContentHash returns the SHA-256 hash of the manifest bytes.
+func manifestContentHash(manifest fleetv1beta1.Manifest) string {
+	return fmt.Sprintf("%x", sha256.Sum256(manifest.Raw))
+}
+
+// isResourceUpToDate returns true if the manifest is unchanged since its last apply and the resource on the member
+// cluster still has the values the manifest sets, so that applying the manifest again is a no-op. Only the fields
+// set by the manifest are compared, as the rest of the resource is defaulted or owned by others, and they are compared
+// per the apply strategy, e.g. the fields at the ignored paths are not compared at all.
+func isResourceUpToDate(ctx context.Context, manifestObj, curObj *unstructured.Unstructured, applyStrategy *fleetv1beta1.ApplyStrategy) bool {
+	if !isManifestUnchanged(ctx) || isForcedApply(ctx) {
+		return false
+	}
+	manifest := manifestObj.DeepCopy()
+	// the creation timestamp is always set by the API server.
+	unstructured.RemoveNestedField(manifest.Object, "metadata", "creationTimestamp")
+	gvk := manifestObj.GroupVersionKind()
+	manifest.Object = comparableObject(manifest.Object, gvk, applyStrategy)
+	wantHash, err := resource.HashOf(manifest.Object)
+	if err != nil {
+		klog.ErrorS(err, "Failed to hash the manifest", "manifest", klog.KObj(manifestObj))
+		return false
+	}
+	gotHash, err := resource.HashOf(projectFields(manifest.Object, comparableObject(curObj.Object, gvk, applyStrategy)))
+	if err != nil {
+		klog.ErrorS(err, "Failed to hash the resource", "resource", klog.KObj(curObj))
+		return false
+	}
+	return wantHash == gotHash
+}
+
+// projectFields returns the fields of the live object which are set in the manifest. The lists and the values are
+// returned as a whole.
+func projectFields(manifest, live interface{}) interface{} {
+	manifestMap, ok := manifest.(map[string]interface{})
+	if !ok {
+		return live
+	}
+	liveMap, ok := live.(map[string]interface{})
+	if !ok {
+		return live
+	}
+	projected := make(map[string]interface{}, len(manifestMap))
+	for key, value := range manifestMap {
+		if liveValue, ok := liveMap[key]; ok {
+			projected[key] = projectFields(value, liveValue)
+		}
+	}
+	return projected
+}
+
+// setLastAppliedHash records the hash of the manifest if it is applied successfully. The hash is kept if the manifest
+// is not applied in this reconcile and dropped if the apply fails, so that the manifest is applied in full next time.
+func setLastAppliedHash(manifestCondition, existing *fleetv1beta1.ManifestCondition, result applyResult) {
+	switch {
+	case result.applyErr != nil:
+		return
+	case result.lastAppliedHash != "":
+		manifestCondition.LastAppliedHash = result.lastAppliedHash
+	case existing != nil && result.applyCompletedAt.IsZero():
+		manifestCondition.LastAppliedHash = existing.LastAppliedHash
+	}
+}
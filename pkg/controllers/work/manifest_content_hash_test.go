@@ -0,0 +1,254 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	testingclient "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// upToDateDeployment returns the manifest of a deployment and the deployment on the member cluster which has the
+// values the manifest sets along with the fields set by the API server.
+func upToDateDeployment(t testing.TB, name string) (fleetv1beta1.Manifest, *unstructured.Unstructured) {
+	obj := liveDeployment(name, "")
+	obj.SetLabels(map[string]string{"app": name})
+	if err := unstructured.SetNestedField(obj.Object, int64(3), "spec", "replicas"); err != nil {
+		t.Fatalf("failed to set the replicas: %v", err)
+	}
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("failed to marshal the deployment: %v", err)
+	}
+	live := obj.DeepCopy()
+	live.SetOwnerReferences([]metav1.OwnerReference{ownerRef})
+	live.SetUID("deploy-uid")
+	live.SetResourceVersion("42")
+	live.SetCreationTimestamp(metav1.Now())
+	if err := unstructured.SetNestedField(live.Object, int64(600), "spec", "progressDeadlineSeconds"); err != nil {
+		t.Fatalf("failed to set the progress deadline: %v", err)
+	}
+	if err := unstructured.SetNestedField(live.Object, int64(3), "status", "availableReplicas"); err != nil {
+		t.Fatalf("failed to set the status: %v", err)
+	}
+	return fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: raw}}, live
+}
+
+func TestIsResourceUpToDate(t *testing.T) {
+	manifest, live := upToDateDeployment(t, "web")
+	manifestObj := &unstructured.Unstructured{}
+	if err := manifestObj.UnmarshalJSON(manifest.Raw); err != nil {
+		t.Fatalf("failed to decode the manifest: %v", err)
+	}
+	addOwnerRef(ownerRef, manifestObj)
+	drifted := live.DeepCopy()
+	if err := unstructured.SetNestedField(drifted.Object, int64(5), "spec", "replicas"); err != nil {
+		t.Fatalf("failed to set the replicas: %v", err)
+	}
+	relabeled := live.DeepCopy()
+	relabeled.SetLabels(map[string]string{"app": "web", "team": "blue"})
+
+	tests := map[string]struct {
+		ctx           context.Context
+		live          *unstructured.Unstructured
+		applyStrategy *fleetv1beta1.ApplyStrategy
+		want          bool
+	}{
+		"unchanged manifest without drift": {
+			ctx:  withUnchangedManifest(context.Background()),
+			live: live,
+			want: true,
+		},
+		"fields not set by the manifest are ignored": {
+			ctx:  withUnchangedManifest(context.Background()),
+			live: relabeled,
+			want: true,
+		},
+		"unchanged manifest with drift": {
+			ctx:  withUnchangedManifest(context.Background()),
+			live: drifted,
+		},
+		"unchanged manifest with drift in an ignored path": {
+			ctx:           withUnchangedManifest(context.Background()),
+			live:          drifted,
+			applyStrategy: &fleetv1beta1.ApplyStrategy{IgnorePaths: []string{"/spec/replicas"}},
+			want:          true,
+		},
+		"changed manifest": {
+			ctx:  context.Background(),
+			live: live,
+		},
+		"forced resync": {
+			ctx:  withForcedApply(withUnchangedManifest(context.Background())),
+			live: live,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := isResourceUpToDate(tt.ctx, manifestObj, tt.live, tt.applyStrategy); got != tt.want {
+				t.Errorf("isResourceUpToDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetLastAppliedHash(t *testing.T) {
+	existing := &fleetv1beta1.ManifestCondition{LastAppliedHash: "old"}
+	tests := map[string]struct {
+		existing *fleetv1beta1.ManifestCondition
+		result   applyResult
+		want     string
+	}{
+		"applied": {
+			existing: existing,
+			result:   applyResult{lastAppliedHash: "new", applyCompletedAt: time.Now()},
+			want:     "new",
+		},
+		"failed to apply": {
+			existing: existing,
+			result:   applyResult{applyErr: fmt.Errorf("boom"), applyCompletedAt: time.Now()},
+		},
+		"not applied in this reconcile": {
+			existing: existing,
+			result:   applyResult{action: manifestBatchPendingAction},
+			want:     "old",
+		},
+		"never applied": {
+			result: applyResult{action: manifestSkippedAction},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := &fleetv1beta1.ManifestCondition{}
+			setLastAppliedHash(got, tt.existing, tt.result)
+			if got.LastAppliedHash != tt.want {
+				t.Errorf("setLastAppliedHash() = %q, want %q", got.LastAppliedHash, tt.want)
+			}
+		})
+	}
+}
+
+// newServerSideApplyReconciler returns a reconciler which applies the manifests with the server side applier to the
+// given deployments; the apply calls are counted since the fake client does not support them.
+func newServerSideApplyReconciler(t testing.TB, lives []runtime.Object, applies *int) *ApplyWorkReconciler {
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), lives...)
+	dynamicClient.PrependReactor("patch", "*", func(action testingclient.Action) (bool, runtime.Object, error) {
+		*applies++
+		obj, err := dynamicClient.Tracker().Get(action.GetResource(), action.GetNamespace(), action.(testingclient.PatchAction).GetName())
+		return true, obj, err
+	})
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the fleet scheme: %v", err)
+	}
+	return &ApplyWorkReconciler{
+		spokeDynamicClient: dynamicClient,
+		restMapper:         testMapper{},
+		appliers: map[fleetv1beta1.ApplyStrategyType]Applier{
+			fleetv1beta1.ApplyStrategyTypeServerSideApply: &ServerSideApplier{
+				HubClient:          fake.NewClientBuilder().WithScheme(scheme).Build(),
+				WorkNamespace:      testWorkNamespace,
+				SpokeDynamicClient: dynamicClient,
+			},
+		},
+	}
+}
+
+// lastAppliedWork returns a work whose manifests were last applied with the given results.
+func lastAppliedWork(manifests []fleetv1beta1.Manifest, results []applyResult) *fleetv1beta1.Work {
+	work := &fleetv1beta1.Work{Spec: fleetv1beta1.WorkSpec{Workload: fleetv1beta1.WorkloadTemplate{Manifests: manifests}}}
+	for _, result := range results {
+		work.Status.ManifestConditions = append(work.Status.ManifestConditions, fleetv1beta1.ManifestCondition{
+			Identifier:      result.identifier,
+			LastAppliedHash: result.lastAppliedHash,
+		})
+	}
+	return work
+}
+
+func TestApplyManifestsSkipsUpToDateResources(t *testing.T) {
+	var manifests []fleetv1beta1.Manifest
+	var lives []runtime.Object
+	for _, name := range []string{"web", "api"} {
+		manifest, live := upToDateDeployment(t, name)
+		manifests = append(manifests, manifest)
+		lives = append(lives, live)
+	}
+	applies := 0
+	r := newServerSideApplyReconciler(t, lives, &applies)
+	strategy := &fleetv1beta1.ApplyStrategy{Type: fleetv1beta1.ApplyStrategyTypeServerSideApply, ServerSideApplyConfig: &fleetv1beta1.ServerSideApplyConfig{}}
+
+	// the first apply has no hashes to compare with.
+	results := r.applyManifests(context.Background(), manifests, ownerRef, strategy, nil, nil, "", nil, nil, nil)
+	if applies != 2 {
+		t.Fatalf("first applyManifests() applied %d manifests, want 2", applies)
+	}
+	for _, result := range results {
+		if want := manifestContentHash(manifests[result.identifier.Ordinal]); result.lastAppliedHash != want {
+			t.Errorf("last applied hash of manifest %d = %q, want %q", result.identifier.Ordinal, result.lastAppliedHash, want)
+		}
+	}
+
+	// the resource which drifted on the member cluster is applied again while the other one is not.
+	drifted := lives[1].(*unstructured.Unstructured).DeepCopy()
+	if err := unstructured.SetNestedField(drifted.Object, int64(1), "spec", "replicas"); err != nil {
+		t.Fatalf("failed to set the replicas: %v", err)
+	}
+	if err := r.spokeDynamicClient.(*dynamicfake.FakeDynamicClient).Tracker().Update(drifted.GroupVersionKind().GroupVersion().WithResource("deployments"), drifted, drifted.GetNamespace()); err != nil {
+		t.Fatalf("failed to update the deployment: %v", err)
+	}
+	applies = 0
+	ctx := withLastAppliedHashes(context.Background(), lastAppliedWork(manifests, results))
+	results = r.applyManifests(ctx, manifests, ownerRef, strategy, nil, nil, "", nil, nil, nil)
+	if applies != 1 {
+		t.Errorf("second applyManifests() applied %d manifests, want only the drifted one", applies)
+	}
+	for _, result := range results {
+		if result.applyErr != nil || result.lastAppliedHash == "" {
+			t.Errorf("result of manifest %d = %+v, want applied", result.identifier.Ordinal, result)
+		}
+	}
+}
+
+// BenchmarkApplyUnchangedWork measures applying a work of 100 manifests which has not changed since the last apply
+// and has no drift, in full and with the last applied hashes.
+func BenchmarkApplyUnchangedWork(b *testing.B) {
+	const manifestCount = 100
+	manifests := make([]fleetv1beta1.Manifest, manifestCount)
+	lives := make([]runtime.Object, manifestCount)
+	for i := range manifests {
+		manifests[i], lives[i] = upToDateDeployment(b, fmt.Sprintf("deploy-%d", i))
+	}
+	strategy := &fleetv1beta1.ApplyStrategy{Type: fleetv1beta1.ApplyStrategyTypeServerSideApply, ServerSideApplyConfig: &fleetv1beta1.ServerSideApplyConfig{}}
+	applies := 0
+	r := newServerSideApplyReconciler(b, lives, &applies)
+	work := lastAppliedWork(manifests, r.applyManifests(context.Background(), manifests, ownerRef, strategy, nil, nil, "", nil, nil, nil))
+
+	for name, ctx := range map[string]context.Context{
+		"full apply":          context.Background(),
+		"last applied hashes": withLastAppliedHashes(context.Background(), work),
+	} {
+		b.Run(name, func(b *testing.B) {
+			applies = 0
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				r.applyManifests(ctx, manifests, ownerRef, strategy, nil, nil, "", nil, nil, nil)
+			}
+			b.ReportMetric(float64(applies)/float64(b.N), "applies/op")
+		})
+	}
+}
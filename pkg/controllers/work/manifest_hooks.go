@@ -0,0 +1,100 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// defaultHookTimeout is how long to wait for a manifest hook which does not set its timeout.
+	defaultHookTimeout = 10 * time.Second
+)
+
+// manifestHooksKey is the context key of the hooks of the manifests of the work.
+type manifestHooksKey struct{}
+
+// withManifestHooks returns a context which carries the hooks of the manifests of the work, keyed by their ordinals.
+func withManifestHooks(ctx context.Context, work *fleetv1beta1.Work) context.Context {
+	if len(work.Spec.Workload.ManifestHooks) == 0 {
+		return ctx
+	}
+	hooks := make(map[int]*fleetv1beta1.ManifestHooks, len(work.Spec.Workload.ManifestHooks))
+	for i := range work.Spec.Workload.ManifestHooks {
+		hooks[work.Spec.Workload.ManifestHooks[i].Ordinal] = &work.Spec.Workload.ManifestHooks[i]
+	}
+	return context.WithValue(ctx, manifestHooksKey{}, hooks)
+}
+
+// manifestHooks returns the hooks of the manifest with the ordinal, if any.
+func manifestHooks(ctx context.Context, ordinal int) *fleetv1beta1.ManifestHooks {
+	hooks, _ := ctx.Value(manifestHooksKey{}).(map[int]*fleetv1beta1.ManifestHooks)
+	return hooks[ordinal]
+}
+
+// applyWithHooks applies the manifest with the ordinal between its pre-apply and post-apply hooks, if any. The hooks
+// are called only when the manifest changed since it was last applied, or a forced resync is requested.
+func (r *ApplyWorkReconciler) applyWithHooks(ctx context.Context, ordinal int, gvr schema.GroupVersionResource,
+	manifestObj *unstructured.Unstructured, applyStrategy *fleetv1beta1.ApplyStrategy) (*unstructured.Unstructured, ApplyAction, error) {
+	hooks := manifestHooks(ctx, ordinal)
+	if hooks == nil || (isManifestUnchanged(ctx) && !isForcedApply(ctx)) {
+		return r.applyUnstructuredAndTrackAvailability(ctx, gvr, manifestObj, applyStrategy)
+	}
+	if hooks.PreApply != nil {
+		if err := callManifestHook(ctx, hooks.PreApply, manifestObj); err != nil {
+			klog.ErrorS(err, "The pre-apply hook rejected the manifest", "gvr", gvr, "manifest", klog.KObj(manifestObj), "hook", hooks.PreApply.WebhookURL)
+			return nil, preApplyHookRejectedAction, controller.NewUserError(fmt.Errorf("the pre-apply hook rejected the manifest: %w", err))
+		}
+	}
+	appliedObj, action, err := r.applyUnstructuredAndTrackAvailability(ctx, gvr, manifestObj, applyStrategy)
+	if err != nil || hooks.PostApply == nil {
+		return appliedObj, action, err
+	}
+	if err := callManifestHook(ctx, hooks.PostApply, appliedObj); err != nil {
+		klog.ErrorS(err, "The post-apply hook failed", "gvr", gvr, "manifest", klog.KObj(manifestObj), "hook", hooks.PostApply.WebhookURL)
+		return nil, postApplyHookFailedAction, fmt.Errorf("the post-apply hook failed: %w", err)
+	}
+	return appliedObj, action, nil
+}
+
+// callManifestHook posts the JSON of the object to the hook and returns an error unless the hook responds with 200.
+func callManifestHook(ctx context.Context, hook *fleetv1beta1.HookRef, obj *unstructured.Unstructured) error {
+	payload, err := obj.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal the object: %w", err)
+	}
+	timeout := defaultHookTimeout
+	if hook.TimeoutSeconds > 0 {
+		timeout = time.Duration(hook.TimeoutSeconds) * time.Second
+	}
+	hookCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(hookCtx, http.MethodPost, hook.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to build the hook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call the hook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("the hook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// sequenceApplier applies the manifests as they are, sets their generation, and records the apply in the sequence.
+type sequenceApplier struct {
+	sequence *[]string
+}
+
+func (a *sequenceApplier) ApplyUnstructured(_ context.Context, _ *fleetv1beta1.ApplyStrategy, _ schema.GroupVersionResource,
+	manifestObj *unstructured.Unstructured) (*unstructured.Unstructured, ApplyAction, error) {
+	*a.sequence = append(*a.sequence, "apply")
+	applied := manifestObj.DeepCopy()
+	applied.SetGeneration(7)
+	return applied, manifestCreatedAction, nil
+}
+
+// hookServer returns a webhook endpoint which responds with the status code and records its calls in the sequence
+// along with the generation of the object it receives.
+func hookServer(t *testing.T, name string, statusCode int, sequence *[]string, generations *[]int64) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read the hook request: %v", err)
+		}
+		var obj unstructured.Unstructured
+		if err := obj.UnmarshalJSON(body); err != nil {
+			t.Errorf("failed to decode the hook request: %v", err)
+		}
+		*sequence = append(*sequence, name)
+		*generations = append(*generations, obj.GetGeneration())
+		w.WriteHeader(statusCode)
+	}))
+}
+
+func TestApplyManifestsHooks(t *testing.T) {
+	raw, err := json.Marshal(routedTestDeployment("default"))
+	if err != nil {
+		t.Fatalf("failed to marshal the deployment: %v", err)
+	}
+	manifests := []fleetv1beta1.Manifest{{RawExtension: runtime.RawExtension{Raw: raw}}}
+	tests := map[string]struct {
+		preApplyStatus  int
+		unchanged       bool
+		wantSequence    []string
+		wantGenerations []int64
+		// wantAction is the action of a manifest which fails to apply; the action of an applied manifest is the
+		// availability of the resource.
+		wantAction ApplyAction
+		wantErr    bool
+	}{
+		"manifest is applied between its hooks": {
+			preApplyStatus: http.StatusOK,
+			wantSequence:   []string{"pre-apply", "apply", "post-apply"},
+			// the post-apply hook receives the applied resource.
+			wantGenerations: []int64{0, 7},
+		},
+		"pre-apply hook rejection blocks the apply": {
+			preApplyStatus:  http.StatusForbidden,
+			wantSequence:    []string{"pre-apply"},
+			wantGenerations: []int64{0},
+			wantAction:      preApplyHookRejectedAction,
+			wantErr:         true,
+		},
+		"unchanged manifest is applied without its hooks": {
+			preApplyStatus: http.StatusOK,
+			unchanged:      true,
+			wantSequence:   []string{"apply"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var sequence []string
+			var generations []int64
+			preApply := hookServer(t, "pre-apply", tt.preApplyStatus, &sequence, &generations)
+			defer preApply.Close()
+			postApply := hookServer(t, "post-apply", http.StatusOK, &sequence, &generations)
+			defer postApply.Close()
+			r := &ApplyWorkReconciler{
+				restMapper: testMapper{},
+				appliers: map[fleetv1beta1.ApplyStrategyType]Applier{
+					fleetv1beta1.ApplyStrategyTypeClientSideApply: &sequenceApplier{sequence: &sequence},
+				},
+			}
+			work := &fleetv1beta1.Work{Spec: fleetv1beta1.WorkSpec{Workload: fleetv1beta1.WorkloadTemplate{
+				Manifests: manifests,
+				ManifestHooks: []fleetv1beta1.ManifestHooks{{
+					Ordinal:   0,
+					PreApply:  &fleetv1beta1.HookRef{WebhookURL: preApply.URL},
+					PostApply: &fleetv1beta1.HookRef{WebhookURL: postApply.URL, TimeoutSeconds: 5},
+				}},
+			}}}
+			if tt.unchanged {
+				work.Status.ManifestConditions = []fleetv1beta1.ManifestCondition{{
+					Identifier:      fleetv1beta1.WorkResourceIdentifier{Ordinal: 0},
+					LastAppliedHash: manifestContentHash(manifests[0]),
+				}}
+			}
+			ctx := withManifestHooks(withLastAppliedHashes(context.Background(), work), work)
+			applyStrategy := &fleetv1beta1.ApplyStrategy{Type: fleetv1beta1.ApplyStrategyTypeClientSideApply}
+
+			results := r.applyManifests(ctx, manifests, ownerRef, applyStrategy, nil, nil, "", nil, nil, nil)
+			if (results[0].applyErr != nil) != tt.wantErr {
+				t.Fatalf("applyManifests() = %v, want error %t", results[0].applyErr, tt.wantErr)
+			}
+			if tt.wantErr && results[0].action != tt.wantAction {
+				t.Errorf("applyManifests() action = %s, want %s", results[0].action, tt.wantAction)
+			}
+			if diff := cmp.Diff(tt.wantSequence, sequence); diff != "" {
+				t.Errorf("applyManifests() call sequence mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantGenerations, generations); diff != "" {
+				t.Errorf("hook object generations mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCallManifestHookFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		// any status other than 200 is a failure, even a success status.
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+	obj := routedTestDeployment("default")
+	if err := callManifestHook(context.Background(), &fleetv1beta1.HookRef{WebhookURL: server.URL}, obj); err == nil {
+		t.Errorf("callManifestHook() with status 202 = nil, want error")
+	}
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+	if err := callManifestHook(context.Background(), &fleetv1beta1.HookRef{WebhookURL: unreachable.URL}, obj); err == nil {
+		t.Errorf("callManifestHook() with an unreachable hook = nil, want error")
+	}
+}
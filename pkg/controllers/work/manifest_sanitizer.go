@@ -0,0 +1,53 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// DefaultSanitizedManifestFields are the fields set by the Kubernetes API server and controllers which are stripped
+// from the manifests by default.
+var DefaultSanitizedManifestFields = []string{
+	"status",
+	"metadata.uid",
+	"metadata.resourceVersion",
+	"metadata.generation",
+	"metadata.creationTimestamp",
+}
+
+// ManifestSanitizer strips the fields set at runtime from the manifests before they are applied, so that the manifests
+// exported from a running cluster can be applied as they are.
+type ManifestSanitizer struct {
+	// fields are the paths of the stripped fields.
+	fields [][]string
+}
+
+// NewManifestSanitizer creates a sanitizer which strips the given fields, each of which is a dot-separated path such
+// as "spec.clusterIP".
+func NewManifestSanitizer(fields []string) *ManifestSanitizer {
+	s := &ManifestSanitizer{}
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		s.fields = append(s.fields, strings.Split(field, "."))
+	}
+	return s
+}
+
+// Sanitize strips the fields from the manifest; the fields which are not set are ignored.
+func (s *ManifestSanitizer) Sanitize(manifestObj *unstructured.Unstructured) {
+	if s == nil {
+		return
+	}
+	for _, field := range s.fields {
+		unstructured.RemoveNestedField(manifestObj.Object, field...)
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// exportedService returns a service as it is exported from a running cluster.
+func exportedService() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata": map[string]interface{}{
+			"name":              "svc",
+			"namespace":         "default",
+			"uid":               "svc-uid",
+			"resourceVersion":   "12345",
+			"generation":        int64(1),
+			"creationTimestamp": "2024-01-01T00:00:00Z",
+			"labels":            map[string]interface{}{"app": "svc"},
+		},
+		"spec": map[string]interface{}{
+			"clusterIP": "10.0.0.10",
+			"ports":     []interface{}{map[string]interface{}{"port": int64(80)}},
+		},
+		"status": map[string]interface{}{
+			"loadBalancer": map[string]interface{}{},
+		},
+	}}
+}
+
+func TestManifestSanitizerSanitize(t *testing.T) {
+	tests := map[string]struct {
+		sanitizer *ManifestSanitizer
+		want      map[string]interface{}
+	}{
+		"default fields are stripped": {
+			sanitizer: NewManifestSanitizer(DefaultSanitizedManifestFields),
+			want: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Service",
+				"metadata": map[string]interface{}{
+					"name":      "svc",
+					"namespace": "default",
+					"labels":    map[string]interface{}{"app": "svc"},
+				},
+				"spec": map[string]interface{}{
+					"clusterIP": "10.0.0.10",
+					"ports":     []interface{}{map[string]interface{}{"port": int64(80)}},
+				},
+			},
+		},
+		"configured fields are stripped and the missing ones are ignored": {
+			sanitizer: NewManifestSanitizer([]string{"spec.clusterIP", " metadata.labels ", "", "spec.notSet.field"}),
+			want: func() map[string]interface{} {
+				obj := exportedService().Object
+				delete(obj["spec"].(map[string]interface{}), "clusterIP")
+				delete(obj["metadata"].(map[string]interface{}), "labels")
+				return obj
+			}(),
+		},
+		"nil sanitizer keeps the manifest as it is": {
+			sanitizer: nil,
+			want:      exportedService().Object,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			manifestObj := exportedService()
+			tt.sanitizer.Sanitize(manifestObj)
+			if diff := cmp.Diff(tt.want, manifestObj.Object); diff != "" {
+				t.Errorf("Sanitize() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
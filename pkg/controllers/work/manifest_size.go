@@ -0,0 +1,42 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"fmt"
+
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+// DefaultMaxManifestSizeBytes is the default maximum size of the raw JSON of a manifest the work applier applies.
+const DefaultMaxManifestSizeBytes = 1024 * 1024
+
+// manifestTooLargeAction indicates that the manifest is not applied as its raw JSON is larger than the limit.
+const manifestTooLargeAction ApplyAction = ApplyAction(fleetv1beta1.ManifestProcessingApplyResultTypeTooLarge)
+
+// manifestTooLargeError is the error of a manifest whose raw JSON is larger than the limit.
+type manifestTooLargeError struct {
+	err error
+}
+
+func (e *manifestTooLargeError) Error() string {
+	return e.err.Error()
+}
+
+// checkManifestSize returns an error if the raw JSON of the manifest is larger than maxBytes, so that the manifest is
+// rejected before it is decoded. The size is not checked if maxBytes is 0.
+func checkManifestSize(index int, manifest fleetv1beta1.Manifest, maxBytes int64) error {
+	size := int64(len(manifest.Raw))
+	if maxBytes <= 0 || size <= maxBytes {
+		return nil
+	}
+	err := fmt.Errorf("the manifest is %d bytes, larger than the limit of %d bytes", size, maxBytes)
+	klog.ErrorS(err, "The manifest is too large to apply", "ordinal", index)
+	return &manifestTooLargeError{err: controller.NewUserError(err)}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+func TestCheckManifestSize(t *testing.T) {
+	manifest := fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{
+		Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm","namespace":"default"},"data":{"key":"` + strings.Repeat("x", 100) + `"}}`),
+	}}
+	size := int64(len(manifest.Raw))
+	tests := map[string]struct {
+		maxBytes int64
+		wantErr  bool
+	}{
+		"the size is not checked without a limit": {
+			maxBytes: 0,
+		},
+		"the manifest below the limit": {
+			maxBytes: size + 1,
+		},
+		"the manifest at the limit": {
+			maxBytes: size,
+		},
+		"the manifest above the limit": {
+			maxBytes: size - 1,
+			wantErr:  true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := checkManifestSize(0, manifest, tt.maxBytes)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("checkManifestSize() = %v, want error %t", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+			var tooLargeErr *manifestTooLargeError
+			if !errors.As(err, &tooLargeErr) {
+				t.Fatalf("checkManifestSize() = %T, want a manifestTooLargeError", err)
+			}
+			if !errors.Is(tooLargeErr.err, controller.ErrUserError) {
+				t.Errorf("checkManifestSize() = %v, want a user error", err)
+			}
+		})
+	}
+}
+
+func TestBuildManifestConditionTooLarge(t *testing.T) {
+	conditions := buildManifestCondition(errors.New("too large"), manifestTooLargeAction, 0)
+	want := string(fleetv1beta1.ManifestProcessingApplyResultTypeTooLarge)
+	if conditions[0].Type != fleetv1beta1.WorkConditionTypeApplied || conditions[0].Reason != want {
+		t.Errorf("buildManifestCondition() applied condition = %+v, want reason %s", conditions[0], want)
+	}
+}
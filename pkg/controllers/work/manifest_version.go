@@ -0,0 +1,177 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// ManifestVersionRollbackBlockedReason is the reason string of the conditions when a manifest goes back to an
+	// earlier version and is not applied as the apply strategy does not allow rollbacks.
+	ManifestVersionRollbackBlockedReason = "ManifestVersionRollbackBlocked"
+	// ManifestVersionRollbackAllowedReason is the reason string of the work condition when a manifest goes back to an
+	// earlier version and is applied as the apply strategy allows rollbacks.
+	ManifestVersionRollbackAllowedReason = "ManifestVersionRollbackAllowed"
+
+	// manifestVersionRollbackBlockedAction indicates that the manifest is not applied as it goes back to an earlier
+	// version which the apply strategy does not allow.
+	manifestVersionRollbackBlockedAction ApplyAction = "ManifestVersionRollbackBlocked"
+)
+
+// manifestVersion is the version of the content of a manifest in the current spec of the work.
+type manifestVersion struct {
+	// pinned is the version the manifest is pinned to after this reconcile.
+	pinned int64
+	// history are the versions of the manifest content, oldest first, after this reconcile.
+	history []fleetv1beta1.ManifestVersionEntry
+	// rollbackTo is the earlier version the manifest content goes back to, if any.
+	rollbackTo int64
+	// blocked is true if the rollback is not allowed so the manifest must not be applied.
+	blocked bool
+}
+
+// manifestVersionsKey is the context key of the versions of the manifests of the work.
+type manifestVersionsKey struct{}
+
+// withManifestVersions returns a context which carries the versions of the manifests of the work, keyed by their
+// ordinals.
+func withManifestVersions(ctx context.Context, versions map[int]*manifestVersion) context.Context {
+	return context.WithValue(ctx, manifestVersionsKey{}, versions)
+}
+
+// blockedRollback returns the version of the manifest with the ordinal if it goes back to an earlier version which
+// must not be applied.
+func blockedRollback(ctx context.Context, ordinal int) *manifestVersion {
+	versions, _ := ctx.Value(manifestVersionsKey{}).(map[int]*manifestVersion)
+	if version := versions[ordinal]; version != nil && version.blocked {
+		return version
+	}
+	return nil
+}
+
+// pinManifestVersions assigns a version to the content of every manifest of the work and detects the manifests whose
+// content goes back to an earlier version. A new content gets the next version of the work; a rollback is applied,
+// as a new version, only if the apply strategy allows it, otherwise the manifest stays pinned to its version.
+// The rollbacks are reported in the ManifestVersionRollbackDetected condition of the work.
+func (r *ApplyWorkReconciler) pinManifestVersions(work *fleetv1beta1.Work) map[int]*manifestVersion {
+	allowRollback := work.Spec.ApplyStrategy != nil && work.Spec.ApplyStrategy.AllowRollback
+	histories := make(map[int][]fleetv1beta1.ManifestVersionEntry, len(work.Status.ManifestConditions))
+	for _, manifestCond := range work.Status.ManifestConditions {
+		histories[manifestCond.Identifier.Ordinal] = manifestCond.ManifestVersionHistory
+	}
+
+	versions := make(map[int]*manifestVersion, len(work.Spec.Workload.Manifests))
+	var rollbacks []string
+	for ordinal, manifest := range work.Spec.Workload.Manifests {
+		version := nextManifestVersion(histories[ordinal], manifestContentHash(manifest), allowRollback, &work.Status.CurrentManifestVersion)
+		if version.rollbackTo > 0 {
+			rollbacks = append(rollbacks, fmt.Sprintf("%d (version %d)", ordinal, version.rollbackTo))
+		}
+		versions[ordinal] = version
+	}
+	r.reportManifestVersionRollbacks(work, rollbacks, allowRollback)
+	return versions
+}
+
+// nextManifestVersion returns the version of the manifest content with the hash given the versions of the manifest
+// so far, advancing the current version of the work if the content gets a new version.
+func nextManifestVersion(history []fleetv1beta1.ManifestVersionEntry, hash string, allowRollback bool, current *int64) *manifestVersion {
+	if len(history) > 0 && history[len(history)-1].Hash == hash {
+		return &manifestVersion{pinned: history[len(history)-1].Version, history: history}
+	}
+	version := &manifestVersion{}
+	kept := make([]fleetv1beta1.ManifestVersionEntry, 0, len(history)+1)
+	for _, entry := range history {
+		if entry.Hash == hash {
+			version.rollbackTo = entry.Version
+			continue
+		}
+		kept = append(kept, entry)
+	}
+	if version.rollbackTo > 0 && !allowRollback {
+		version.blocked = true
+		version.pinned = history[len(history)-1].Version
+		version.history = history
+		return version
+	}
+	*current++
+	version.pinned = *current
+	kept = append(kept, fleetv1beta1.ManifestVersionEntry{Version: *current, Hash: hash})
+	if len(kept) > fleetv1beta1.MaxManifestVersionHistory {
+		kept = kept[len(kept)-fleetv1beta1.MaxManifestVersionHistory:]
+	}
+	version.history = kept
+	return version
+}
+
+// reportManifestVersionRollbacks sets the ManifestVersionRollbackDetected condition of the work if some manifests go
+// back to an earlier version. An allowed rollback is reported until the next spec change of the work, while a blocked
+// one is reported for as long as it is blocked.
+func (r *ApplyWorkReconciler) reportManifestVersionRollbacks(work *fleetv1beta1.Work, rollbacks []string, allowRollback bool) {
+	cond := meta.FindStatusCondition(work.Status.Conditions, fleetv1beta1.WorkConditionTypeManifestVersionRollbackDetected)
+	if len(rollbacks) == 0 {
+		if cond == nil || cond.Reason != ManifestVersionRollbackAllowedReason || cond.ObservedGeneration != work.Generation {
+			meta.RemoveStatusCondition(&work.Status.Conditions, fleetv1beta1.WorkConditionTypeManifestVersionRollbackDetected)
+		}
+		return
+	}
+	sort.Strings(rollbacks)
+	reason := ManifestVersionRollbackBlockedReason
+	message := fmt.Sprintf("The manifests with ordinals %v go back to an earlier version and are not applied as the apply strategy does not allow rollbacks", rollbacks)
+	if allowRollback {
+		reason = ManifestVersionRollbackAllowedReason
+		message = fmt.Sprintf("The manifests with ordinals %v go back to an earlier version and are applied as a new version", rollbacks)
+	}
+
+	if cond == nil || cond.Message != message {
+		klog.V(2).InfoS("Detected the rollbacks of the manifests of the work", "work", klog.KObj(work), "manifests", rollbacks, "allowRollback", allowRollback)
+		r.recorder.Event(work, v1.EventTypeWarning, reason, message)
+	}
+	meta.SetStatusCondition(&work.Status.Conditions, metav1.Condition{
+		Type:               fleetv1beta1.WorkConditionTypeManifestVersionRollbackDetected,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: work.Generation,
+	})
+}
+
+// setManifestVersions records the versions of the manifests in their manifest conditions.
+func setManifestVersions(work *fleetv1beta1.Work, versions map[int]*manifestVersion) {
+	for i := range work.Status.ManifestConditions {
+		manifestCond := &work.Status.ManifestConditions[i]
+		if version, ok := versions[manifestCond.Identifier.Ordinal]; ok {
+			manifestCond.ManifestVersion = version.pinned
+			manifestCond.ManifestVersionHistory = version.history
+		}
+	}
+}
+
+// rollbackBlockedApplyResult returns the apply result of a manifest which is not applied as it goes back to an earlier
+// version which the apply strategy does not allow.
+func (r *ApplyWorkReconciler) rollbackBlockedApplyResult(index int, manifest fleetv1beta1.Manifest, version *manifestVersion) applyResult {
+	result := applyResult{
+		identifier: fleetv1beta1.WorkResourceIdentifier{Ordinal: index},
+		action:     manifestVersionRollbackBlockedAction,
+		applyErr: controller.NewUserError(fmt.Errorf("the manifest goes back to version %d from version %d, which the apply strategy does not allow",
+			version.rollbackTo, version.pinned)),
+	}
+	if gvr, rawObj, err := r.decodeManifest(manifest); err == nil {
+		result.identifier = buildResourceIdentifier(index, rawObj, gvr)
+	}
+	return result
+}
@@ -0,0 +1,134 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+// labeledDeploymentManifest returns the manifest of the deployment web with the version label.
+func labeledDeploymentManifest(t *testing.T, version string) fleetv1beta1.Manifest {
+	t.Helper()
+	obj := liveDeployment("web", "")
+	obj.SetLabels(map[string]string{"app.kubernetes.io/version": version})
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("failed to marshal the deployment: %v", err)
+	}
+	return fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: raw}}
+}
+
+// reconcileManifestVersions updates the spec of the work to the manifest and pins the manifest versions as a reconcile
+// of the new generation does.
+func reconcileManifestVersions(t *testing.T, r *ApplyWorkReconciler, work *fleetv1beta1.Work, label string) map[int]*manifestVersion {
+	t.Helper()
+	work.Generation++
+	work.Spec.Workload.Manifests = []fleetv1beta1.Manifest{labeledDeploymentManifest(t, label)}
+	versions := r.pinManifestVersions(work)
+	work.Status.ManifestConditions = []fleetv1beta1.ManifestCondition{{Identifier: fleetv1beta1.WorkResourceIdentifier{Ordinal: 0}}}
+	setManifestVersions(work, versions)
+	return versions
+}
+
+func TestPinManifestVersions(t *testing.T) {
+	recorder := utils.NewFakeRecorder(10)
+	r := &ApplyWorkReconciler{recorder: recorder}
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: "fleet-member-test"},
+		Spec:       fleetv1beta1.WorkSpec{ApplyStrategy: &fleetv1beta1.ApplyStrategy{}},
+	}
+
+	// every new content gets the next version.
+	reconcileManifestVersions(t, r, work, "v1")
+	reconcileManifestVersions(t, r, work, "v2")
+	if got := work.Status.ManifestConditions[0].ManifestVersion; got != 2 || work.Status.CurrentManifestVersion != 2 {
+		t.Fatalf("manifest version = %d, current version = %d, want 2 and 2", got, work.Status.CurrentManifestVersion)
+	}
+	if cond := meta.FindStatusCondition(work.Status.Conditions, fleetv1beta1.WorkConditionTypeManifestVersionRollbackDetected); cond != nil {
+		t.Fatalf("ManifestVersionRollbackDetected condition = %+v, want no condition", cond)
+	}
+
+	// going back to the content of version 1 is blocked and the manifest stays pinned to version 2.
+	versions := reconcileManifestVersions(t, r, work, "v1")
+	if !versions[0].blocked || versions[0].rollbackTo != 1 {
+		t.Errorf("manifest version = %+v, want a blocked rollback to version 1", versions[0])
+	}
+	if got := work.Status.ManifestConditions[0].ManifestVersion; got != 2 || work.Status.CurrentManifestVersion != 2 {
+		t.Errorf("manifest version = %d, current version = %d, want 2 and 2", got, work.Status.CurrentManifestVersion)
+	}
+	cond := meta.FindStatusCondition(work.Status.Conditions, fleetv1beta1.WorkConditionTypeManifestVersionRollbackDetected)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != ManifestVersionRollbackBlockedReason {
+		t.Fatalf("ManifestVersionRollbackDetected condition = %+v, want True with reason %s", cond, ManifestVersionRollbackBlockedReason)
+	}
+	if got := len(recorder.Events); got != 1 {
+		t.Errorf("emitted events = %d, want 1", got)
+	}
+
+	// the rollback is applied as a new version once it is allowed, and reported until the next spec change.
+	work.Spec.ApplyStrategy.AllowRollback = true
+	versions = reconcileManifestVersions(t, r, work, "v1")
+	if versions[0].blocked || versions[0].rollbackTo != 1 {
+		t.Errorf("manifest version = %+v, want an allowed rollback to version 1", versions[0])
+	}
+	if got := work.Status.ManifestConditions[0].ManifestVersion; got != 3 || work.Status.CurrentManifestVersion != 3 {
+		t.Errorf("manifest version = %d, current version = %d, want 3 and 3", got, work.Status.CurrentManifestVersion)
+	}
+	wantHistory := []fleetv1beta1.ManifestVersionEntry{
+		{Version: 2, Hash: manifestContentHash(labeledDeploymentManifest(t, "v2"))},
+		{Version: 3, Hash: manifestContentHash(labeledDeploymentManifest(t, "v1"))},
+	}
+	if got := work.Status.ManifestConditions[0].ManifestVersionHistory; len(got) != 2 || got[0] != wantHistory[0] || got[1] != wantHistory[1] {
+		t.Errorf("manifest version history = %+v, want %+v", got, wantHistory)
+	}
+	r.pinManifestVersions(work)
+	cond = meta.FindStatusCondition(work.Status.Conditions, fleetv1beta1.WorkConditionTypeManifestVersionRollbackDetected)
+	if cond == nil || cond.Reason != ManifestVersionRollbackAllowedReason {
+		t.Errorf("ManifestVersionRollbackDetected condition = %+v, want True with reason %s", cond, ManifestVersionRollbackAllowedReason)
+	}
+	reconcileManifestVersions(t, r, work, "v3")
+	if cond := meta.FindStatusCondition(work.Status.Conditions, fleetv1beta1.WorkConditionTypeManifestVersionRollbackDetected); cond != nil {
+		t.Errorf("ManifestVersionRollbackDetected condition = %+v, want no condition", cond)
+	}
+	if got := work.Status.CurrentManifestVersion; got != 4 {
+		t.Errorf("current version = %d, want 4", got)
+	}
+}
+
+func TestApplyManifestsBlocksRollback(t *testing.T) {
+	applier := &priorityClassRecordingApplier{}
+	r := &ApplyWorkReconciler{
+		restMapper: testMapper{},
+		appliers:   map[fleetv1beta1.ApplyStrategyType]Applier{fleetv1beta1.ApplyStrategyTypeClientSideApply: applier},
+	}
+	versions := map[int]*manifestVersion{0: {pinned: 2, rollbackTo: 1, blocked: true}}
+	results := r.applyManifests(withManifestVersions(context.Background(), versions), []fleetv1beta1.Manifest{labeledDeploymentManifest(t, "v1")},
+		ownerRef, &fleetv1beta1.ApplyStrategy{Type: fleetv1beta1.ApplyStrategyTypeClientSideApply}, nil, nil, "", nil, nil, nil)
+
+	if got := len(applier.priorityClassNames); got != 0 {
+		t.Errorf("applyManifests() applied %d manifests, want 0", got)
+	}
+	if results[0].action != manifestVersionRollbackBlockedAction || !errors.Is(results[0].applyErr, controller.ErrUserError) {
+		t.Errorf("applyManifests() result = %+v, want a user error with action %s", results[0], manifestVersionRollbackBlockedAction)
+	}
+	if results[0].identifier.Name != "web" {
+		t.Errorf("applyManifests() identifier = %+v, want deployment web", results[0].identifier)
+	}
+	conds := buildManifestCondition(results[0].applyErr, results[0].action, 0)
+	if applyCond := meta.FindStatusCondition(conds, fleetv1beta1.WorkConditionTypeApplied); applyCond.Reason != ManifestVersionRollbackBlockedReason {
+		t.Errorf("Applied condition reason = %s, want %s", applyCond.Reason, ManifestVersionRollbackBlockedReason)
+	}
+}
@@ -0,0 +1,38 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// manifestTargetNamespaces returns the namespaces the manifests of the work are routed to, keyed by their ordinals.
+func manifestTargetNamespaces(work *fleetv1beta1.Work) map[int]string {
+	if len(work.Spec.Workload.ManifestTargetNamespaces) == 0 {
+		return nil
+	}
+	targetNamespaces := make(map[int]string, len(work.Spec.Workload.ManifestTargetNamespaces))
+	for _, route := range work.Spec.Workload.ManifestTargetNamespaces {
+		targetNamespaces[route.Ordinal] = route.TargetNamespace
+	}
+	return targetNamespaces
+}
+
+// appliedNamespace returns the namespace the applied resource is in.
+func appliedNamespace(resourceMeta fleetv1beta1.AppliedResourceMeta) string {
+	if resourceMeta.AppliedNamespace != "" {
+		return resourceMeta.AppliedNamespace
+	}
+	return resourceMeta.Namespace
+}
+
+// routedNamespace returns the namespace the resource of the manifest is applied to.
+func routedNamespace(identifier fleetv1beta1.WorkResourceIdentifier, targetNamespaces map[int]string) string {
+	if targetNamespace, ok := targetNamespaces[identifier.Ordinal]; ok {
+		return targetNamespace
+	}
+	return identifier.Namespace
+}
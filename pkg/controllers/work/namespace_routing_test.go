@@ -0,0 +1,193 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+)
+
+// recordingApplier applies the manifests as they are and records the namespaces they are applied to.
+type recordingApplier struct {
+	namespaces []string
+}
+
+func (a *recordingApplier) ApplyUnstructured(_ context.Context, _ *fleetv1beta1.ApplyStrategy, _ schema.GroupVersionResource,
+	manifestObj *unstructured.Unstructured) (*unstructured.Unstructured, ApplyAction, error) {
+	a.namespaces = append(a.namespaces, manifestObj.GetNamespace())
+	return manifestObj, manifestCreatedAction, nil
+}
+
+// routedTestDeployment returns the test deployment in the given namespace owned by the test owner.
+func routedTestDeployment(namespace string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("apps/v1")
+	obj.SetKind("Deployment")
+	obj.SetName("deploy")
+	obj.SetNamespace(namespace)
+	obj.SetUID(types.UID(namespace + "-uid"))
+	obj.SetOwnerReferences([]metav1.OwnerReference{ownerRef})
+	return obj
+}
+
+func TestApplyManifestsRouting(t *testing.T) {
+	raw, err := json.Marshal(routedTestDeployment("default"))
+	if err != nil {
+		t.Fatalf("failed to marshal the deployment: %v", err)
+	}
+	manifests := []fleetv1beta1.Manifest{
+		{RawExtension: runtime.RawExtension{Raw: raw}},
+		{RawExtension: runtime.RawExtension{Raw: raw}},
+	}
+	applier := &recordingApplier{}
+	r := &ApplyWorkReconciler{
+		restMapper: testMapper{},
+		appliers:   map[fleetv1beta1.ApplyStrategyType]Applier{fleetv1beta1.ApplyStrategyTypeClientSideApply: applier},
+	}
+	applyStrategy := &fleetv1beta1.ApplyStrategy{Type: fleetv1beta1.ApplyStrategyTypeClientSideApply}
+
+	results := r.applyManifests(context.Background(), manifests, ownerRef, applyStrategy, nil, map[int]string{1: "target"}, "", nil, nil, nil)
+	if diff := cmp.Diff([]string{"default", "target"}, applier.namespaces); diff != "" {
+		t.Errorf("applyManifests() applied namespaces mismatch (-want +got):\n%s", diff)
+	}
+	for _, result := range results {
+		if result.applyErr != nil {
+			t.Fatalf("applyManifests() = %v, want no error", result.applyErr)
+		}
+		// the manifests are identified by their own namespace.
+		if result.identifier.Namespace != "default" {
+			t.Errorf("applyManifests() identifier namespace = %s, want default", result.identifier.Namespace)
+		}
+	}
+}
+
+func TestGenerateDiffRouting(t *testing.T) {
+	identifier := fleetv1beta1.WorkResourceIdentifier{
+		Group:     "apps",
+		Version:   "v1",
+		Kind:      "Deployment",
+		Resource:  "deployments",
+		Namespace: "default",
+		Name:      "deploy",
+	}
+	tests := map[string]struct {
+		appliedResources []fleetv1beta1.AppliedResourceMeta
+		targetNamespace  string
+		wantNewRes       []fleetv1beta1.AppliedResourceMeta
+		wantStaleRes     []fleetv1beta1.AppliedResourceMeta
+	}{
+		"manifest is routed to a target namespace": {
+			targetNamespace: "target",
+			wantNewRes: []fleetv1beta1.AppliedResourceMeta{
+				{WorkResourceIdentifier: identifier, UID: "target-uid", AppliedNamespace: "target"},
+			},
+		},
+		"manifest is re-routed to another target namespace": {
+			appliedResources: []fleetv1beta1.AppliedResourceMeta{
+				{WorkResourceIdentifier: identifier, UID: "old-target-uid", AppliedNamespace: "old-target"},
+			},
+			targetNamespace: "target",
+			wantNewRes: []fleetv1beta1.AppliedResourceMeta{
+				{WorkResourceIdentifier: identifier, UID: "target-uid", AppliedNamespace: "target"},
+			},
+			wantStaleRes: []fleetv1beta1.AppliedResourceMeta{
+				{WorkResourceIdentifier: identifier, UID: "old-target-uid", AppliedNamespace: "old-target"},
+			},
+		},
+		"manifest is no longer routed": {
+			appliedResources: []fleetv1beta1.AppliedResourceMeta{
+				{WorkResourceIdentifier: identifier, UID: "target-uid", AppliedNamespace: "target"},
+			},
+			wantNewRes: []fleetv1beta1.AppliedResourceMeta{
+				{WorkResourceIdentifier: identifier, UID: "default-uid"},
+			},
+			wantStaleRes: []fleetv1beta1.AppliedResourceMeta{
+				{WorkResourceIdentifier: identifier, UID: "target-uid", AppliedNamespace: "target"},
+			},
+		},
+		"routed manifest is already recorded": {
+			appliedResources: []fleetv1beta1.AppliedResourceMeta{
+				{WorkResourceIdentifier: identifier, UID: "target-uid", AppliedNamespace: "target"},
+			},
+			targetNamespace: "target",
+			wantNewRes: []fleetv1beta1.AppliedResourceMeta{
+				{WorkResourceIdentifier: identifier, UID: "target-uid", AppliedNamespace: "target"},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			work := &fleetv1beta1.Work{
+				Status: fleetv1beta1.WorkStatus{
+					ManifestConditions: []fleetv1beta1.ManifestCondition{{
+						Identifier: identifier,
+						Conditions: []metav1.Condition{{Type: fleetv1beta1.WorkConditionTypeApplied, Status: metav1.ConditionTrue}},
+					}},
+				},
+			}
+			if tt.targetNamespace != "" {
+				work.Spec.Workload.ManifestTargetNamespaces = []fleetv1beta1.ManifestTargetNamespace{
+					{Ordinal: 0, TargetNamespace: tt.targetNamespace},
+				}
+			}
+			appliedWork := &fleetv1beta1.AppliedWork{Status: fleetv1beta1.AppliedWorkStatus{AppliedResources: tt.appliedResources}}
+			r := &ApplyWorkReconciler{
+				spokeDynamicClient: fake.NewSimpleDynamicClient(runtime.NewScheme(),
+					routedTestDeployment("default"), routedTestDeployment("target")),
+			}
+
+			newRes, staleRes, err := r.generateDiff(context.Background(), work, appliedWork)
+			if err != nil {
+				t.Fatalf("generateDiff() = %v, want no error", err)
+			}
+			if diff := cmp.Diff(tt.wantNewRes, newRes); diff != "" {
+				t.Errorf("generateDiff() newRes mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantStaleRes, staleRes); diff != "" {
+				t.Errorf("generateDiff() staleRes mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDeleteStaleManifestInAppliedNamespace(t *testing.T) {
+	dynamicClient := fake.NewSimpleDynamicClient(runtime.NewScheme(), routedTestDeployment("default"), routedTestDeployment("target"))
+	r := &ApplyWorkReconciler{spokeDynamicClient: dynamicClient}
+	staleManifests := []fleetv1beta1.AppliedResourceMeta{{
+		WorkResourceIdentifier: fleetv1beta1.WorkResourceIdentifier{
+			Group:     "apps",
+			Version:   "v1",
+			Kind:      "Deployment",
+			Resource:  "deployments",
+			Namespace: "default",
+			Name:      "deploy",
+		},
+		AppliedNamespace: "target",
+	}}
+
+	if err := r.deleteStaleManifest(context.Background(), staleManifests, ownerRef); err != nil {
+		t.Fatalf("deleteStaleManifest() = %v, want no error", err)
+	}
+	if _, err := dynamicClient.Resource(utils.DeploymentGVR).Namespace("target").Get(context.Background(), "deploy", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("get the deployment in the applied namespace = %v, want not found", err)
+	}
+	if _, err := dynamicClient.Resource(utils.DeploymentGVR).Namespace("default").Get(context.Background(), "deploy", metav1.GetOptions{}); err != nil {
+		t.Errorf("get the deployment in the manifest namespace = %v, want no error", err)
+	}
+}
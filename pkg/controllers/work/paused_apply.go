@@ -0,0 +1,50 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	// ApplyPausedReason is the reason string of condition when the work is paused per its apply strategy.
+	ApplyPausedReason = "ApplyPaused"
+)
+
+// gateOnPause checks that the apply strategy of the work does not pause it at the given time before anything is
+// applied, so that neither the resources nor the appliedWork are changed while the work is paused. It returns true if
+// the work must not be applied, along with how long to wait before the pause ends. The Paused condition is dropped
+// once the pause ends; the status update of the reconcile carries the change.
+func (r *ApplyWorkReconciler) gateOnPause(ctx context.Context, work *fleetv1beta1.Work, now time.Time) (bool, time.Duration, error) {
+	pausedUntil := work.Spec.ApplyStrategy.PausedUntil
+	if pausedUntil == nil || !now.Before(pausedUntil.Time) {
+		meta.RemoveStatusCondition(&work.Status.Conditions, fleetv1beta1.WorkConditionTypePaused)
+		return false, 0, nil
+	}
+	logObjRef := klog.KObj(work)
+	requeueAfter := pausedUntil.Sub(now)
+	klog.V(2).InfoS("The work is paused, skip applying it", "work", logObjRef, "pausedUntil", pausedUntil, "requeueAfter", requeueAfter)
+	meta.SetStatusCondition(&work.Status.Conditions, metav1.Condition{
+		Type:               fleetv1beta1.WorkConditionTypePaused,
+		Status:             metav1.ConditionTrue,
+		Reason:             ApplyPausedReason,
+		Message:            fmt.Sprintf("Applying the manifests is paused until %s", pausedUntil.UTC().Format(time.RFC3339)),
+		ObservedGeneration: work.Generation,
+	})
+	if err := r.updateWorkStatusIfChanged(ctx, work); err != nil {
+		klog.ErrorS(err, "Failed to update work status", "work", logObjRef)
+		return true, 0, err
+	}
+	return true, requeueAfter, nil
+}
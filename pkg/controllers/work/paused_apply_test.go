@@ -0,0 +1,112 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func TestGateOnPause(t *testing.T) {
+	pausedUntil := time.Date(2024, 6, 1, 2, 0, 0, 0, time.UTC)
+	// the steps walk the time through the pause of the same work.
+	steps := []struct {
+		name             string
+		now              time.Time
+		wantPending      bool
+		wantRequeueAfter time.Duration
+	}{
+		{
+			name:             "paused",
+			now:              pausedUntil.Add(-90 * time.Minute),
+			wantPending:      true,
+			wantRequeueAfter: 90 * time.Minute,
+		},
+		{
+			name:             "right before the pause ends",
+			now:              pausedUntil.Add(-time.Second),
+			wantPending:      true,
+			wantRequeueAfter: time.Second,
+		},
+		{
+			name: "end of the pause",
+			now:  pausedUntil,
+		},
+		{
+			name: "after the pause",
+			now:  pausedUntil.Add(time.Hour),
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: "fleet-member-test", Generation: 1},
+		Spec: fleetv1beta1.WorkSpec{
+			ApplyStrategy: &fleetv1beta1.ApplyStrategy{PausedUntil: &metav1.Time{Time: pausedUntil}},
+		},
+	}
+	workKey := types.NamespacedName{Name: work.Name, Namespace: work.Namespace}
+	hubClient := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(work).WithStatusSubresource(work).Build()
+	r := &ApplyWorkReconciler{client: hubClient}
+	for _, step := range steps {
+		current := &fleetv1beta1.Work{}
+		if err := hubClient.Get(context.Background(), workKey, current); err != nil {
+			t.Fatalf("%s: failed to get the work: %v", step.name, err)
+		}
+		pending, requeueAfter, err := r.gateOnPause(context.Background(), current, step.now)
+		if err != nil {
+			t.Fatalf("%s: gateOnPause() = %v, want no error", step.name, err)
+		}
+		if pending != step.wantPending || requeueAfter != step.wantRequeueAfter {
+			t.Errorf("%s: gateOnPause() = (%t, %v), want (%t, %v)", step.name, pending, requeueAfter, step.wantPending, step.wantRequeueAfter)
+		}
+		pausedCond := meta.FindStatusCondition(current.Status.Conditions, fleetv1beta1.WorkConditionTypePaused)
+		if !step.wantPending {
+			// the status update of the reconcile carries the removal of the condition.
+			if pausedCond != nil {
+				t.Errorf("%s: gateOnPause() paused condition = %+v, want none", step.name, pausedCond)
+			}
+			continue
+		}
+		if pausedCond == nil || pausedCond.Status != metav1.ConditionTrue || pausedCond.Reason != ApplyPausedReason {
+			t.Errorf("%s: gateOnPause() paused condition = %+v, want true with reason %s", step.name, pausedCond, ApplyPausedReason)
+		}
+		got := &fleetv1beta1.Work{}
+		if err := hubClient.Get(context.Background(), workKey, got); err != nil {
+			t.Fatalf("%s: failed to get the work: %v", step.name, err)
+		}
+		if !meta.IsStatusConditionTrue(got.Status.Conditions, fleetv1beta1.WorkConditionTypePaused) {
+			t.Errorf("%s: gateOnPause() did not update the work status with the paused condition, got %+v", step.name, got.Status.Conditions)
+		}
+	}
+}
+
+func TestGateOnPauseWithoutPause(t *testing.T) {
+	r := &ApplyWorkReconciler{}
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: "fleet-member-test"},
+		Spec:       fleetv1beta1.WorkSpec{ApplyStrategy: &fleetv1beta1.ApplyStrategy{}},
+	}
+	pending, requeueAfter, err := r.gateOnPause(context.Background(), work, time.Now())
+	if err != nil || pending || requeueAfter != 0 {
+		t.Errorf("gateOnPause() = (%t, %v, %v), want the work applied", pending, requeueAfter, err)
+	}
+	if len(work.Status.Conditions) != 0 {
+		t.Errorf("gateOnPause() set the conditions %+v, want none", work.Status.Conditions)
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// WorkPreApplyDryRunAnnotation is the annotation to dry-run apply the manifests of a work instead of applying them.
+	// The work applier reports the resources the apply would produce when its value is "true".
+	WorkPreApplyDryRunAnnotation = "fleet.azure.com/pre-apply-dry-run"
+
+	// WorkDryRunCompletedReason is the reason string of condition when the manifests of the work are dry-run applied.
+	WorkDryRunCompletedReason = "WorkDryRunCompleted"
+
+	// WorkDryRunNoDiffFoundReason is the reason string of condition when the manifests of the work are dry-run
+	// applied and none of them differs from its resource in the member cluster.
+	WorkDryRunNoDiffFoundReason = "WorkDryRunNoDiffFound"
+
+	// dryRunDiffRecheckInterval is how often the diffs of a dry-run work are computed again while some manifests
+	// still differ from their resources, so that the diffs of the resources corrected in the member cluster expire.
+	dryRunDiffRecheckInterval = time.Minute * 5
+)
+
+// isPreApplyDryRun returns true if the work asks for a dry-run apply of its manifests instead of applying them.
+func isPreApplyDryRun(work *fleetv1beta1.Work) bool {
+	return work.GetAnnotations()[WorkPreApplyDryRunAnnotation] == "true"
+}
+
+// hasDryRunChanges returns true if any manifest of the work differs from its resource in the member cluster as of the
+// latest dry-run.
+func hasDryRunChanges(work *fleetv1beta1.Work) bool {
+	for _, result := range work.Status.DryRunResults {
+		if len(result.Changes) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// preApplyDryRun performs a server-side dry-run apply of every manifest of the work and reports the resulting
+// resources in the work status. The manifests of the same generation are not dry-run applied again once the dry-run
+// is completed, unless some of them differ from their resources: the dry-run is then repeated so that the diffs of
+// the resources corrected in the member cluster in the meantime are cleared instead of being reported forever.
+func (r *ApplyWorkReconciler) preApplyDryRun(ctx context.Context, work *fleetv1beta1.Work, owner metav1.OwnerReference) error {
+	logObjRef := klog.KObj(work)
+	completed := meta.FindStatusCondition(work.Status.Conditions, fleetv1beta1.WorkConditionTypeDryRunCompleted)
+	recheck := completed != nil && completed.Status == metav1.ConditionTrue && completed.ObservedGeneration == work.Generation
+	if recheck && !hasDryRunChanges(work) {
+		klog.V(2).InfoS("The manifests of the work are already dry-run applied", "work", logObjRef, "generation", work.Generation)
+		return nil
+	}
+
+	annotations := propagatedAnnotations(work)
+	targetNamespaces := manifestTargetNamespaces(work)
+	ignoredAnnotations := ignoredAnnotationKeys(work.Spec.ApplyStrategy)
+	results := make([]fleetv1beta1.ManifestDryRunResult, 0, len(work.Spec.Workload.Manifests))
+	for index, manifest := range work.Spec.Workload.Manifests {
+		gvr, rawObj, _, err := r.prepareDryRunManifest(ctx, index, manifest, owner, work.Spec.ApplyStrategy, annotations, targetNamespaces,
+			work.Spec.DefaultPriorityClassName)
+		if err != nil {
+			return err
+		}
+		resourceClient := r.spokeDynamicClient.Resource(gvr).Namespace(rawObj.GetNamespace())
+		// compare against an empty object if the resource would be created.
+		liveObj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+		existing, err := resourceClient.Get(ctx, rawObj.GetName(), metav1.GetOptions{})
+		exists := err == nil
+		switch {
+		case exists:
+			liveObj = existing
+		case !apierrors.IsNotFound(err):
+			klog.ErrorS(err, "Failed to get the resource", "gvr", gvr, "manifest", klog.KObj(rawObj))
+			return controller.NewAPIServerError(false, err)
+		}
+
+		options := metav1.ApplyOptions{
+			FieldManager: r.fieldManager,
+			Force:        true,
+			DryRun:       []string{metav1.DryRunAll},
+		}
+		dryRunObj, err := resourceClient.Apply(ctx, rawObj.GetName(), rawObj, options)
+		if err != nil {
+			klog.ErrorS(err, "Failed to dry-run apply the manifest", "gvr", gvr, "manifest", klog.KObj(rawObj))
+			return controller.NewAPIServerError(false, err)
+		}
+		gvk := rawObj.GroupVersionKind()
+		// the managed fields are noise to the readers of the result.
+		dryRunObj.SetManagedFields(nil)
+		resultJSON, err := json.Marshal(dryRunObj.Object)
+		if err != nil {
+			return controller.NewUnexpectedBehaviorError(err)
+		}
+		results = append(results, fleetv1beta1.ManifestDryRunResult{
+			Ordinal:                index,
+			ResultJSON:             resultJSON,
+			Changes:                diffPatchDetails("", comparableObject(liveObj.Object, gvk, work.Spec.ApplyStrategy), comparableObject(dryRunObj.Object, gvk, work.Spec.ApplyStrategy), ignoredAnnotations),
+			ResourceExistsInMember: exists,
+		})
+	}
+
+	klog.V(2).InfoS("Dry-run applied the manifests of the work", "work", logObjRef, "manifests", len(results), "recheck", recheck)
+	work.Status.DryRunResults = results
+	reason, message := WorkDryRunCompletedReason, fmt.Sprintf("%d manifest(s) are dry-run applied to the member cluster", len(results))
+	if !hasDryRunChanges(work) {
+		reason, message = WorkDryRunNoDiffFoundReason, fmt.Sprintf("%d manifest(s) are dry-run applied to the member cluster and none of them differs from its resource", len(results))
+	}
+	meta.SetStatusCondition(&work.Status.Conditions, metav1.Condition{
+		Type:               fleetv1beta1.WorkConditionTypeDryRunCompleted,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: work.Generation,
+	})
+	if err := r.updateWorkStatusIfChanged(ctx, work); err != nil {
+		klog.ErrorS(err, "Failed to update work status", "work", logObjRef)
+		return err
+	}
+	switch {
+	case !recheck:
+		r.recorder.Event(work, v1.EventTypeNormal, WorkDryRunCompletedReason,
+			fmt.Sprintf("%d manifest(s) are dry-run applied, remove the %s annotation to apply them", len(results), WorkPreApplyDryRunAnnotation))
+	case reason == WorkDryRunNoDiffFoundReason:
+		r.recorder.Event(work, v1.EventTypeNormal, WorkDryRunNoDiffFoundReason,
+			"the resources are corrected in the member cluster and none of the manifests differs from them anymore")
+	}
+	return nil
+}
+
+// clearDryRunResults removes the results of a previous dry-run once the work is applied for real.
+func clearDryRunResults(work *fleetv1beta1.Work) {
+	work.Status.DryRunResults = nil
+	meta.RemoveStatusCondition(&work.Status.Conditions, fleetv1beta1.WorkConditionTypeDryRunCompleted)
+}
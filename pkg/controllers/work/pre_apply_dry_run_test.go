@@ -0,0 +1,226 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic/fake"
+	testingclient "k8s.io/client-go/testing"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+)
+
+func TestPreApplyDryRun(t *testing.T) {
+	manifestObj := approvalTestDeployment(3)
+	rawManifest, err := manifestObj.MarshalJSON()
+	if err != nil {
+		t.Fatalf("failed to marshal the manifest: %v", err)
+	}
+	// the dry-run apply returns the manifest as the API server would apply it.
+	wantResult := manifestObj.DeepCopy()
+	addOwnerRef(ownerRef, wantResult)
+	ownedDeployment := func(replicas int64) *unstructured.Unstructured {
+		obj := approvalTestDeployment(replicas)
+		addOwnerRef(ownerRef, obj)
+		return obj
+	}
+
+	tests := map[string]struct {
+		liveObj       *unstructured.Unstructured
+		conditions    []metav1.Condition
+		dryRunResults []fleetv1beta1.ManifestDryRunResult
+		wantApplies   int
+		wantChanges   []fleetv1beta1.PatchDetail
+		wantReason    string
+	}{
+		"resource to create": {
+			wantApplies: 1,
+			wantChanges: []fleetv1beta1.PatchDetail{
+				{Path: "apiVersion", ValueInHub: `"apps/v1"`},
+				{Path: "kind", ValueInHub: `"Deployment"`},
+				// an absent object is compared against an empty one, field by field.
+				{Path: "metadata.name", ValueInHub: `"Deployment"`},
+				{Path: "metadata.namespace", ValueInHub: `"default"`},
+				{Path: "metadata.ownerReferences", ValueInHub: fieldValue(wantResult.Object["metadata"].(map[string]interface{})["ownerReferences"])},
+				{Path: "spec.replicas", ValueInHub: "3"},
+			},
+			wantReason: WorkDryRunCompletedReason,
+		},
+		"resource to update": {
+			liveObj:     ownedDeployment(1),
+			wantApplies: 1,
+			wantChanges: []fleetv1beta1.PatchDetail{
+				{Path: "spec.replicas", ValueInMember: "1", ValueInHub: "3"},
+			},
+			wantReason: WorkDryRunCompletedReason,
+		},
+		"unchanged resource": {
+			liveObj:     ownedDeployment(3),
+			wantApplies: 1,
+			wantReason:  WorkDryRunNoDiffFoundReason,
+		},
+		"dry-run of the generation is already completed": {
+			liveObj: ownedDeployment(1),
+			conditions: []metav1.Condition{{
+				Type:               fleetv1beta1.WorkConditionTypeDryRunCompleted,
+				Status:             metav1.ConditionTrue,
+				Reason:             WorkDryRunCompletedReason,
+				ObservedGeneration: 1,
+				LastTransitionTime: metav1.Now(),
+			}},
+		},
+		"stale diff of a resource corrected in the member cluster": {
+			liveObj: ownedDeployment(3),
+			conditions: []metav1.Condition{{
+				Type:               fleetv1beta1.WorkConditionTypeDryRunCompleted,
+				Status:             metav1.ConditionTrue,
+				Reason:             WorkDryRunCompletedReason,
+				ObservedGeneration: 1,
+				LastTransitionTime: metav1.Now(),
+			}},
+			dryRunResults: []fleetv1beta1.ManifestDryRunResult{{
+				Ordinal:                0,
+				Changes:                []fleetv1beta1.PatchDetail{{Path: "spec.replicas", ValueInMember: "1", ValueInHub: "3"}},
+				ResourceExistsInMember: true,
+			}},
+			wantApplies: 1,
+			wantReason:  WorkDryRunNoDiffFoundReason,
+		},
+		"diff of a resource still differing is kept": {
+			liveObj: ownedDeployment(2),
+			conditions: []metav1.Condition{{
+				Type:               fleetv1beta1.WorkConditionTypeDryRunCompleted,
+				Status:             metav1.ConditionTrue,
+				Reason:             WorkDryRunCompletedReason,
+				ObservedGeneration: 1,
+				LastTransitionTime: metav1.Now(),
+			}},
+			dryRunResults: []fleetv1beta1.ManifestDryRunResult{{
+				Ordinal:                0,
+				Changes:                []fleetv1beta1.PatchDetail{{Path: "spec.replicas", ValueInMember: "1", ValueInHub: "3"}},
+				ResourceExistsInMember: true,
+			}},
+			wantApplies: 1,
+			wantChanges: []fleetv1beta1.PatchDetail{
+				{Path: "spec.replicas", ValueInMember: "2", ValueInHub: "3"},
+			},
+			wantReason: WorkDryRunCompletedReason,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+				t.Fatalf("failed to add the placement scheme: %v", err)
+			}
+			work := &fleetv1beta1.Work{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-work",
+					Namespace:   "fleet-member-test",
+					Generation:  1,
+					Annotations: map[string]string{WorkPreApplyDryRunAnnotation: "true"},
+				},
+				Spec: fleetv1beta1.WorkSpec{
+					Workload: fleetv1beta1.WorkloadTemplate{
+						Manifests: []fleetv1beta1.Manifest{{RawExtension: runtime.RawExtension{Raw: rawManifest}}},
+					},
+					ApplyStrategy: &fleetv1beta1.ApplyStrategy{Type: fleetv1beta1.ApplyStrategyTypeServerSideApply},
+				},
+				Status: fleetv1beta1.WorkStatus{Conditions: tt.conditions, DryRunResults: tt.dryRunResults},
+			}
+			hubClient := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(work).WithStatusSubresource(work).Build()
+			if err := hubClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, work); err != nil {
+				t.Fatalf("failed to get the work: %v", err)
+			}
+
+			dynamicClient := fake.NewSimpleDynamicClient(runtime.NewScheme())
+			dynamicClient.PrependReactor("get", "deployments", func(action testingclient.Action) (bool, runtime.Object, error) {
+				if tt.liveObj == nil {
+					return true, nil, apierrors.NewNotFound(utils.DeploymentGVR.GroupResource(), "Deployment")
+				}
+				return true, tt.liveObj.DeepCopy(), nil
+			})
+			applies := 0
+			dynamicClient.PrependReactor("patch", "deployments", func(action testingclient.Action) (bool, runtime.Object, error) {
+				applies++
+				patchAction := action.(testingclient.PatchAction)
+				if len(patchAction.GetPatch()) == 0 {
+					t.Errorf("preApplyDryRun() applied an empty patch")
+				}
+				var applied unstructured.Unstructured
+				if err := applied.UnmarshalJSON(patchAction.GetPatch()); err != nil {
+					return true, nil, err
+				}
+				return true, &applied, nil
+			})
+			r := &ApplyWorkReconciler{
+				client:             hubClient,
+				spokeDynamicClient: dynamicClient,
+				restMapper:         testMapper{},
+				recorder:           utils.NewFakeRecorder(1),
+			}
+
+			if err := r.preApplyDryRun(context.Background(), work, ownerRef); err != nil {
+				t.Fatalf("preApplyDryRun() = %v, want no error", err)
+			}
+			if applies != tt.wantApplies {
+				t.Errorf("preApplyDryRun() dry-run applies = %d, want %d", applies, tt.wantApplies)
+			}
+			if tt.wantApplies == 0 {
+				return
+			}
+
+			var got fleetv1beta1.Work
+			if err := hubClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, &got); err != nil {
+				t.Fatalf("failed to get the work: %v", err)
+			}
+			if len(got.Status.DryRunResults) != 1 {
+				t.Fatalf("preApplyDryRun() dryRunResults = %+v, want one result", got.Status.DryRunResults)
+			}
+			result := got.Status.DryRunResults[0]
+			if result.Ordinal != 0 {
+				t.Errorf("preApplyDryRun() result ordinal = %d, want 0", result.Ordinal)
+			}
+			var gotResult map[string]interface{}
+			if err := json.Unmarshal(result.ResultJSON, &gotResult); err != nil {
+				t.Fatalf("failed to unmarshal the dry-run result: %v", err)
+			}
+			wantResultJSON, err := json.Marshal(wantResult.Object)
+			if err != nil {
+				t.Fatalf("failed to marshal the expected result: %v", err)
+			}
+			var wantResultObj map[string]interface{}
+			if err := json.Unmarshal(wantResultJSON, &wantResultObj); err != nil {
+				t.Fatalf("failed to unmarshal the expected result: %v", err)
+			}
+			if diff := cmp.Diff(wantResultObj, gotResult); diff != "" {
+				t.Errorf("preApplyDryRun() result mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantChanges, result.Changes); diff != "" {
+				t.Errorf("preApplyDryRun() changes mismatch (-want +got):\n%s", diff)
+			}
+			if want := tt.liveObj != nil; result.ResourceExistsInMember != want {
+				t.Errorf("preApplyDryRun() resourceExistsInMember = %t, want %t", result.ResourceExistsInMember, want)
+			}
+			cond := meta.FindStatusCondition(got.Status.Conditions, fleetv1beta1.WorkConditionTypeDryRunCompleted)
+			if cond == nil || cond.Status != metav1.ConditionTrue || cond.ObservedGeneration != work.Generation || cond.Reason != tt.wantReason {
+				t.Errorf("preApplyDryRun() dryRunCompleted condition = %+v, want true with reason %s for generation %d", cond, tt.wantReason, work.Generation)
+			}
+		})
+	}
+}
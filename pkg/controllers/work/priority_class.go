@@ -0,0 +1,91 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// WorkPriorityClassNotFoundReason is the reason string of condition when the default priority class of the work
+	// does not exist in the member cluster.
+	WorkPriorityClassNotFoundReason = "WorkPriorityClassNotFound"
+)
+
+// podTemplateKinds are the kinds of the workloads whose pod template gets the default priority class of the work.
+var podTemplateKinds = map[schema.GroupKind]bool{
+	{Group: "apps", Kind: "Deployment"}:  true,
+	{Group: "apps", Kind: "StatefulSet"}: true,
+	{Group: "apps", Kind: "DaemonSet"}:   true,
+	{Group: "batch", Kind: "Job"}:        true,
+}
+
+// priorityClassPath is the path of the priority class name in the pod template of a workload.
+var priorityClassPath = []string{"spec", "template", "spec", "priorityClassName"}
+
+// injectPriorityClassName sets the priority class name on the pod template of a workload manifest which does not
+// set one itself.
+func injectPriorityClassName(obj *unstructured.Unstructured, priorityClassName string) error {
+	if priorityClassName == "" || !podTemplateKinds[obj.GroupVersionKind().GroupKind()] {
+		return nil
+	}
+	current, found, err := unstructured.NestedString(obj.Object, priorityClassPath...)
+	if err != nil {
+		return fmt.Errorf("failed to read the priority class name of the pod template: %w", err)
+	}
+	if found && current != "" {
+		return nil
+	}
+	return unstructured.SetNestedField(obj.Object, priorityClassName, priorityClassPath...)
+}
+
+// gateOnPriorityClass checks that the default priority class of the work exists in the member cluster, since the
+// pods of the workloads using a missing priority class are rejected. It returns true if the work must not be applied.
+func (r *ApplyWorkReconciler) gateOnPriorityClass(ctx context.Context, work *fleetv1beta1.Work) (bool, error) {
+	name := work.Spec.DefaultPriorityClassName
+	if name == "" {
+		return false, nil
+	}
+	logObjRef := klog.KObj(work)
+	err := r.spokeClient.Get(ctx, client.ObjectKey{Name: name}, &schedulingv1.PriorityClass{})
+	switch {
+	case err == nil:
+		return false, nil
+	case !apierrors.IsNotFound(err):
+		klog.ErrorS(err, "Failed to get the default priority class of the work", "work", logObjRef, "priorityClass", name)
+		return true, controller.NewAPIServerError(false, err)
+	}
+
+	klog.V(2).InfoS("The default priority class of the work does not exist in the member cluster", "work", logObjRef, "priorityClass", name)
+	meta.SetStatusCondition(&work.Status.Conditions, metav1.Condition{
+		Type:               fleetv1beta1.WorkConditionTypeApplied,
+		Status:             metav1.ConditionFalse,
+		Reason:             WorkPriorityClassNotFoundReason,
+		Message:            fmt.Sprintf("The default priority class %s does not exist in the member cluster", name),
+		ObservedGeneration: work.Generation,
+	})
+	if err := r.updateWorkStatusIfChanged(ctx, work); err != nil {
+		klog.ErrorS(err, "Failed to update work status", "work", logObjRef)
+		return true, err
+	}
+	r.recorder.Event(work, v1.EventTypeWarning, WorkPriorityClassNotFoundReason,
+		fmt.Sprintf("The default priority class %s does not exist in the member cluster", name))
+	return true, nil
+}
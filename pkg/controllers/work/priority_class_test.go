@@ -0,0 +1,207 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+)
+
+// priorityClassRecordingApplier applies the manifests as they are and records their pod template priority classes.
+type priorityClassRecordingApplier struct {
+	priorityClassNames []string
+}
+
+func (a *priorityClassRecordingApplier) ApplyUnstructured(_ context.Context, _ *fleetv1beta1.ApplyStrategy, _ schema.GroupVersionResource,
+	manifestObj *unstructured.Unstructured) (*unstructured.Unstructured, ApplyAction, error) {
+	name, _, _ := unstructured.NestedString(manifestObj.Object, priorityClassPath...)
+	a.priorityClassNames = append(a.priorityClassNames, name)
+	return manifestObj, manifestCreatedAction, nil
+}
+
+// podTemplateWorkload returns a workload of the given kind whose pod template uses the given priority class.
+func podTemplateWorkload(apiVersion, kind, priorityClassName string) *unstructured.Unstructured {
+	podSpec := map[string]interface{}{
+		"containers": []interface{}{map[string]interface{}{"name": "app", "image": "nginx"}},
+	}
+	if priorityClassName != "" {
+		podSpec["priorityClassName"] = priorityClassName
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"name": "app", "namespace": "default"},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{"spec": podSpec},
+		},
+	}}
+}
+
+func TestInjectPriorityClassName(t *testing.T) {
+	tests := map[string]struct {
+		obj               *unstructured.Unstructured
+		priorityClassName string
+		want              string
+	}{
+		"deployment gets the default priority class": {
+			obj:               podTemplateWorkload("apps/v1", "Deployment", ""),
+			priorityClassName: "high",
+			want:              "high",
+		},
+		"stateful set gets the default priority class": {
+			obj:               podTemplateWorkload("apps/v1", "StatefulSet", ""),
+			priorityClassName: "high",
+			want:              "high",
+		},
+		"daemon set gets the default priority class": {
+			obj:               podTemplateWorkload("apps/v1", "DaemonSet", ""),
+			priorityClassName: "high",
+			want:              "high",
+		},
+		"job gets the default priority class": {
+			obj:               podTemplateWorkload("batch/v1", "Job", ""),
+			priorityClassName: "high",
+			want:              "high",
+		},
+		"priority class of the manifest is kept": {
+			obj:               podTemplateWorkload("apps/v1", "Deployment", "low"),
+			priorityClassName: "high",
+			want:              "low",
+		},
+		"other kinds are not changed": {
+			obj:               podTemplateWorkload("batch/v1", "CronJob", ""),
+			priorityClassName: "high",
+		},
+		"no default priority class": {
+			obj: podTemplateWorkload("apps/v1", "Deployment", ""),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := injectPriorityClassName(tt.obj, tt.priorityClassName); err != nil {
+				t.Fatalf("injectPriorityClassName() = %v, want no error", err)
+			}
+			got, _, err := unstructured.NestedString(tt.obj.Object, priorityClassPath...)
+			if err != nil {
+				t.Fatalf("failed to read the priority class name: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("injectPriorityClassName() priority class name = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyManifestsPriorityClass(t *testing.T) {
+	withoutPriorityClass, err := json.Marshal(podTemplateWorkload("apps/v1", "Deployment", ""))
+	if err != nil {
+		t.Fatalf("failed to marshal the deployment: %v", err)
+	}
+	// the deployments have different names so that they are not duplicates.
+	withPriorityClassObj := podTemplateWorkload("apps/v1", "Deployment", "low")
+	withPriorityClassObj.SetName("app-low")
+	withPriorityClass, err := json.Marshal(withPriorityClassObj)
+	if err != nil {
+		t.Fatalf("failed to marshal the deployment: %v", err)
+	}
+	manifests := []fleetv1beta1.Manifest{
+		{RawExtension: runtime.RawExtension{Raw: withoutPriorityClass}},
+		{RawExtension: runtime.RawExtension{Raw: withPriorityClass}},
+	}
+	applier := &priorityClassRecordingApplier{}
+	r := &ApplyWorkReconciler{
+		restMapper: testMapper{},
+		appliers:   map[fleetv1beta1.ApplyStrategyType]Applier{fleetv1beta1.ApplyStrategyTypeClientSideApply: applier},
+	}
+	applyStrategy := &fleetv1beta1.ApplyStrategy{Type: fleetv1beta1.ApplyStrategyTypeClientSideApply}
+
+	results := r.applyManifests(context.Background(), manifests, ownerRef, applyStrategy, nil, nil, "high", nil, nil, nil)
+	for _, result := range results {
+		if result.applyErr != nil {
+			t.Fatalf("applyManifests() = %v, want no error", result.applyErr)
+		}
+	}
+	if diff := cmp.Diff([]string{"high", "low"}, applier.priorityClassNames); diff != "" {
+		t.Errorf("applyManifests() applied priority classes mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGateOnPriorityClass(t *testing.T) {
+	tests := map[string]struct {
+		priorityClassName string
+		wantPending       bool
+	}{
+		"existing priority class": {
+			priorityClassName: "high",
+		},
+		"missing priority class": {
+			priorityClassName: "unknown",
+			wantPending:       true,
+		},
+		"no default priority class": {},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+				t.Fatalf("failed to add the placement scheme: %v", err)
+			}
+			if err := schedulingv1.AddToScheme(scheme); err != nil {
+				t.Fatalf("failed to add the scheduling scheme: %v", err)
+			}
+			work := &fleetv1beta1.Work{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: "fleet-member-test", Generation: 1},
+				Spec:       fleetv1beta1.WorkSpec{DefaultPriorityClassName: tt.priorityClassName},
+			}
+			hubClient := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(work).WithStatusSubresource(work).Build()
+			if err := hubClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, work); err != nil {
+				t.Fatalf("failed to get the work: %v", err)
+			}
+			memberClient := clientfake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(&schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: "high"}, Value: 1000}).Build()
+			r := &ApplyWorkReconciler{
+				client:      hubClient,
+				spokeClient: memberClient,
+				recorder:    utils.NewFakeRecorder(1),
+			}
+
+			pending, err := r.gateOnPriorityClass(context.Background(), work)
+			if err != nil {
+				t.Fatalf("gateOnPriorityClass() = %v, want no error", err)
+			}
+			if pending != tt.wantPending {
+				t.Errorf("gateOnPriorityClass() pending = %t, want %t", pending, tt.wantPending)
+			}
+
+			var got fleetv1beta1.Work
+			if err := hubClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, &got); err != nil {
+				t.Fatalf("failed to get the work: %v", err)
+			}
+			appliedCond := meta.FindStatusCondition(got.Status.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+			if tt.wantPending {
+				if appliedCond == nil || appliedCond.Status != metav1.ConditionFalse || appliedCond.Reason != WorkPriorityClassNotFoundReason {
+					t.Errorf("gateOnPriorityClass() applied condition = %+v, want false with reason %s", appliedCond, WorkPriorityClassNotFoundReason)
+				}
+			} else if appliedCond != nil {
+				t.Errorf("gateOnPriorityClass() applied condition = %+v, want none", appliedCond)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	// staleWorkRequeueDelay is how long a request waits when the cached work is older than the one processed last,
+	// which gives the cache time to catch up with the hub cluster.
+	staleWorkRequeueDelay = time.Second
+)
+
+// processedVersion is the version of a work which the work applier processed last.
+type processedVersion struct {
+	// resourceVersion is the resource version of the work after the work applier updates its status.
+	resourceVersion string
+	generation      int64
+	// dueAt is when the work is processed again even if it does not change, e.g. to check its availability or drift.
+	dueAt time.Time
+	// forcedResync is the value of the force resync annotation of the work when it was processed.
+	forcedResync string
+}
+
+// processedVersionTracker remembers the versions of the works processed by the work applier so that the requests
+// for the versions which are processed already or superseded are skipped instead of applying stale manifests.
+type processedVersionTracker struct {
+	mu       sync.Mutex
+	versions map[types.NamespacedName]processedVersion
+}
+
+func newProcessedVersionTracker() *processedVersionTracker {
+	return &processedVersionTracker{
+		versions: make(map[types.NamespacedName]processedVersion),
+	}
+}
+
+// record remembers that the work is processed and is due to be processed again after the given delay.
+func (t *processedVersionTracker) record(work *fleetv1beta1.Work, requeueAfter time.Duration) {
+	workKey := types.NamespacedName{Name: work.Name, Namespace: work.Namespace}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.versions[workKey] = processedVersion{
+		resourceVersion: work.ResourceVersion,
+		generation:      work.Generation,
+		dueAt:           time.Now().Add(requeueAfter),
+		forcedResync:    work.GetAnnotations()[fleetv1beta1.ForceResyncAnnotation],
+	}
+}
+
+// forget drops the record of a work which no longer exists.
+func (t *processedVersionTracker) forget(workKey types.NamespacedName) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.versions, workKey)
+}
+
+// shouldSkip returns true and how long to wait before checking again if the work does not need to be processed, which
+// is the case when
+//   - it is the version processed last and is not due yet;
+//   - it is older than the version processed last, which happens when the cache lags behind the hub cluster.
+func (t *processedVersionTracker) shouldSkip(work *fleetv1beta1.Work) (bool, time.Duration) {
+	workKey := types.NamespacedName{Name: work.Name, Namespace: work.Namespace}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	processed, ok := t.versions[workKey]
+	if !ok {
+		return false, 0
+	}
+	if work.Generation < processed.generation {
+		return true, staleWorkRequeueDelay
+	}
+	if work.ResourceVersion == processed.resourceVersion {
+		if wait := time.Until(processed.dueAt); wait > 0 {
+			return true, wait
+		}
+	}
+	return false, 0
+}
+
+// requeueProcessedWork records the version of the work which has just been processed and requeues it after the delay.
+func (r *ApplyWorkReconciler) requeueProcessedWork(work *fleetv1beta1.Work, requeueAfter time.Duration) ctrl.Result {
+	if r.processedVersions != nil {
+		r.processedVersions.record(work, requeueAfter)
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}
+}
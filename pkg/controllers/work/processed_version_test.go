@@ -0,0 +1,219 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/atomic"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// versionRecordingApplier applies the manifests as they are and records the version label of each applied manifest.
+type versionRecordingApplier struct {
+	versions []string
+}
+
+func (a *versionRecordingApplier) ApplyUnstructured(_ context.Context, _ *fleetv1beta1.ApplyStrategy, _ schema.GroupVersionResource,
+	manifestObj *unstructured.Unstructured) (*unstructured.Unstructured, ApplyAction, error) {
+	a.versions = append(a.versions, manifestObj.GetLabels()["version"])
+	applied := manifestObj.DeepCopy()
+	applied.SetUID("deploy-uid")
+	return applied, manifestCreatedAction, nil
+}
+
+// versionedWorkload returns the workload of a deployment labeled with the given version.
+func versionedWorkload(t *testing.T, version string) fleetv1beta1.WorkloadTemplate {
+	deploy := liveDeployment("deploy", "")
+	deploy.SetLabels(map[string]string{"version": version})
+	raw, err := json.Marshal(deploy)
+	if err != nil {
+		t.Fatalf("failed to marshal the deployment: %v", err)
+	}
+	return fleetv1beta1.WorkloadTemplate{Manifests: []fleetv1beta1.Manifest{{RawExtension: runtime.RawExtension{Raw: raw}}}}
+}
+
+// staleWorkClient serves a stale copy of the work, like a cache which lags behind the hub cluster.
+type staleWorkClient struct {
+	client.Client
+	stale *fleetv1beta1.Work
+}
+
+func (c *staleWorkClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if work, ok := obj.(*fleetv1beta1.Work); ok {
+		c.stale.DeepCopyInto(work)
+		return nil
+	}
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func TestReconcileProcessesLatestWorkVersion(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the fleet scheme: %v", err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the core scheme: %v", err)
+	}
+	workKey := types.NamespacedName{Name: "test-work", Namespace: "fleet-member-test"}
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: workKey.Name, Namespace: workKey.Namespace, Generation: 1},
+		Spec:       fleetv1beta1.WorkSpec{Workload: versionedWorkload(t, "v1")},
+	}
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(work).WithStatusSubresource(&fleetv1beta1.Work{}).Build()
+	spokeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&fleetv1beta1.AppliedWork{}).Build()
+	applier := &versionRecordingApplier{}
+	r := &ApplyWorkReconciler{
+		client:             hubClient,
+		spokeDynamicClient: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), liveDeployment("deploy", "deploy-uid")),
+		spokeClient:        spokeClient,
+		restMapper:         testMapper{},
+		recorder:           record.NewFakeRecorder(100),
+		joined:             atomic.NewBool(true),
+		appliers: map[fleetv1beta1.ApplyStrategyType]Applier{
+			fleetv1beta1.ApplyStrategyTypeClientSideApply: applier,
+			fleetv1beta1.ApplyStrategyTypeServerSideApply: applier,
+		},
+		processedVersions: newProcessedVersionTracker(),
+	}
+	reconcile := func() ctrl.Result {
+		t.Helper()
+		result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: workKey})
+		if err != nil {
+			t.Fatalf("Reconcile() = %v, want no error", err)
+		}
+		return result
+	}
+	updateSpec := func(version string) *fleetv1beta1.Work {
+		t.Helper()
+		current := &fleetv1beta1.Work{}
+		if err := hubClient.Get(context.Background(), workKey, current); err != nil {
+			t.Fatalf("failed to get the work: %v", err)
+		}
+		current.Spec.Workload = versionedWorkload(t, version)
+		current.Generation++
+		if err := hubClient.Update(context.Background(), current); err != nil {
+			t.Fatalf("failed to update the work: %v", err)
+		}
+		return current
+	}
+
+	reconcile()
+	if diff := cmp.Diff([]string{"v1"}, applier.versions); diff != "" {
+		t.Fatalf("applied versions after the first reconcile mismatch (-want +got):\n%s", diff)
+	}
+	processed := &fleetv1beta1.Work{}
+	if err := hubClient.Get(context.Background(), workKey, processed); err != nil {
+		t.Fatalf("failed to get the work: %v", err)
+	}
+	if processed.Status.ProcessedResourceVersion == "" {
+		t.Errorf("work status processedResourceVersion is empty, want the processed resource version")
+	}
+
+	// the request triggered by the status update of the applier itself does not apply the work again.
+	if result := reconcile(); result.RequeueAfter <= 0 {
+		t.Errorf("Reconcile() of the processed version = %+v, want it requeued until due", result)
+	}
+	if diff := cmp.Diff([]string{"v1"}, applier.versions); diff != "" {
+		t.Errorf("applied versions after reconciling the processed version mismatch (-want +got):\n%s", diff)
+	}
+
+	// the spec is updated several times in a row before the next reconcile which applies the latest version only.
+	updateSpec("v2")
+	stale := updateSpec("v3")
+	updateSpec("v4")
+	reconcile()
+	if diff := cmp.Diff([]string{"v1", "v4"}, applier.versions); diff != "" {
+		t.Errorf("applied versions after the rapid updates mismatch (-want +got):\n%s", diff)
+	}
+
+	// a superseded version served by a lagging cache is not applied.
+	r.client = &staleWorkClient{Client: hubClient, stale: stale}
+	if result := reconcile(); result.RequeueAfter != staleWorkRequeueDelay {
+		t.Errorf("Reconcile() of the superseded version = %+v, want it requeued after %v", result, staleWorkRequeueDelay)
+	}
+	if diff := cmp.Diff([]string{"v1", "v4"}, applier.versions); diff != "" {
+		t.Errorf("applied versions after reconciling the superseded version mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestProcessedVersionTrackerShouldSkip(t *testing.T) {
+	processed := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: "fleet-member-test", ResourceVersion: "10", Generation: 2},
+	}
+	tests := map[string]struct {
+		requeueAfter    time.Duration
+		resourceVersion string
+		generation      int64
+		wantSkip        bool
+	}{
+		"processed version which is not due yet is skipped": {
+			requeueAfter:    time.Minute,
+			resourceVersion: "10",
+			generation:      2,
+			wantSkip:        true,
+		},
+		"processed version which is due is processed": {
+			requeueAfter:    0,
+			resourceVersion: "10",
+			generation:      2,
+			wantSkip:        false,
+		},
+		"newer version is processed": {
+			requeueAfter:    time.Minute,
+			resourceVersion: "11",
+			generation:      3,
+			wantSkip:        false,
+		},
+		"metadata change of the processed generation is processed": {
+			requeueAfter:    time.Minute,
+			resourceVersion: "11",
+			generation:      2,
+			wantSkip:        false,
+		},
+		"superseded version is skipped": {
+			requeueAfter:    0,
+			resourceVersion: "8",
+			generation:      1,
+			wantSkip:        true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tracker := newProcessedVersionTracker()
+			tracker.record(processed, tt.requeueAfter)
+			work := processed.DeepCopy()
+			work.ResourceVersion = tt.resourceVersion
+			work.Generation = tt.generation
+			if gotSkip, _ := tracker.shouldSkip(work); gotSkip != tt.wantSkip {
+				t.Errorf("shouldSkip() = %t, want %t", gotSkip, tt.wantSkip)
+			}
+		})
+	}
+
+	tracker := newProcessedVersionTracker()
+	tracker.record(processed, time.Minute)
+	tracker.forget(types.NamespacedName{Name: processed.Name, Namespace: processed.Namespace})
+	if gotSkip, _ := tracker.shouldSkip(processed); gotSkip {
+		t.Errorf("shouldSkip() of a forgotten work = true, want false")
+	}
+}
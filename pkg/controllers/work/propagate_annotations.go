@@ -0,0 +1,46 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// propagatedAnnotations returns the annotations of the work listed in its propagateAnnotations.
+// The listed keys which are not present on the work are skipped.
+func propagatedAnnotations(work *fleetv1beta1.Work) map[string]string {
+	if len(work.Spec.PropagateAnnotations) == 0 {
+		return nil
+	}
+	workAnnotations := work.GetAnnotations()
+	annotations := make(map[string]string, len(work.Spec.PropagateAnnotations))
+	for _, key := range work.Spec.PropagateAnnotations {
+		if value, ok := workAnnotations[key]; ok {
+			annotations[key] = value
+		}
+	}
+	return annotations
+}
+
+// addPropagatedAnnotations adds the propagated annotations to the manifest object, overriding the values set in the
+// manifest itself.
+// An annotation removed from the propagated list is no longer in the manifest, so the appliers remove it from the
+// applied resource as well.
+func addPropagatedAnnotations(obj *unstructured.Unstructured, annotations map[string]string) {
+	if len(annotations) == 0 {
+		return
+	}
+	objAnnotations := obj.GetAnnotations()
+	if objAnnotations == nil {
+		objAnnotations = make(map[string]string, len(annotations))
+	}
+	for key, value := range annotations {
+		objAnnotations[key] = value
+	}
+	obj.SetAnnotations(objAnnotations)
+}
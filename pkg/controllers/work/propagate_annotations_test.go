@@ -0,0 +1,82 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func TestPropagatedAnnotations(t *testing.T) {
+	tests := map[string]struct {
+		annotations          map[string]string
+		propagateAnnotations []string
+		want                 map[string]string
+	}{
+		"no annotations to propagate": {
+			annotations: map[string]string{"team": "a"},
+			want:        nil,
+		},
+		"only the listed annotations are propagated": {
+			annotations:          map[string]string{"team": "a", "cost-center": "cc-1", "other": "x"},
+			propagateAnnotations: []string{"team", "cost-center"},
+			want:                 map[string]string{"team": "a", "cost-center": "cc-1"},
+		},
+		"missing annotations are skipped": {
+			annotations:          map[string]string{"team": "a"},
+			propagateAnnotations: []string{"team", "cost-center"},
+			want:                 map[string]string{"team": "a"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			work := &fleetv1beta1.Work{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations},
+				Spec:       fleetv1beta1.WorkSpec{PropagateAnnotations: tt.propagateAnnotations},
+			}
+			if diff := cmp.Diff(tt.want, propagatedAnnotations(work)); diff != "" {
+				t.Errorf("propagatedAnnotations() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAddPropagatedAnnotations(t *testing.T) {
+	tests := map[string]struct {
+		objAnnotations map[string]string
+		annotations    map[string]string
+		want           map[string]string
+	}{
+		"no annotations to add": {
+			objAnnotations: map[string]string{"app": "x"},
+			want:           map[string]string{"app": "x"},
+		},
+		"add to an object without annotations": {
+			annotations: map[string]string{"team": "a"},
+			want:        map[string]string{"team": "a"},
+		},
+		"propagated annotations override the manifest ones": {
+			objAnnotations: map[string]string{"team": "b", "app": "x"},
+			annotations:    map[string]string{"team": "a"},
+			want:           map[string]string{"team": "a", "app": "x"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			obj.SetAnnotations(tt.objAnnotations)
+			addPropagatedAnnotations(obj, tt.annotations)
+			if diff := cmp.Diff(tt.want, obj.GetAnnotations()); diff != "" {
+				t.Errorf("addPropagatedAnnotations() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	// ReadinessGatesNotMetReason is the reason string of condition when the readiness gates of the work are not met.
+	ReadinessGatesNotMetReason = "ReadinessGatesNotMet"
+
+	// readinessGatesRequeueDelay is how often a work whose readiness gates are not met is checked again, since the
+	// status changes of the works are not watched.
+	readinessGatesRequeueDelay = 15 * time.Second
+)
+
+// unmetReadinessGates returns the readiness gates of the work which its status conditions do not satisfy.
+func unmetReadinessGates(work *fleetv1beta1.Work) []fleetv1beta1.WorkReadinessGate {
+	var unmet []fleetv1beta1.WorkReadinessGate
+	for _, gate := range work.Spec.ReadinessGates {
+		cond := meta.FindStatusCondition(work.Status.Conditions, gate.ConditionType)
+		if cond == nil || cond.Status != gate.ConditionStatus {
+			unmet = append(unmet, gate)
+		}
+	}
+	return unmet
+}
+
+// gateOnReadinessGates checks that the readiness gates of the work are met before its manifests are applied.
+// It returns true if the work must not be applied.
+func (r *ApplyWorkReconciler) gateOnReadinessGates(ctx context.Context, work *fleetv1beta1.Work) (bool, error) {
+	unmet := unmetReadinessGates(work)
+	if len(unmet) == 0 {
+		return false, nil
+	}
+	logObjRef := klog.KObj(work)
+	gates := make([]string, len(unmet))
+	for i, gate := range unmet {
+		gates[i] = fmt.Sprintf("%s=%s", gate.ConditionType, gate.ConditionStatus)
+	}
+	klog.V(2).InfoS("The readiness gates of the work are not met", "work", logObjRef, "unmetGates", gates)
+	meta.SetStatusCondition(&work.Status.Conditions, metav1.Condition{
+		Type:               fleetv1beta1.WorkConditionTypeApplied,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReadinessGatesNotMetReason,
+		Message:            fmt.Sprintf("The readiness gates are not met: %s", strings.Join(gates, ", ")),
+		ObservedGeneration: work.Generation,
+	})
+	if err := r.updateWorkStatusIfChanged(ctx, work); err != nil {
+		klog.ErrorS(err, "Failed to update work status", "work", logObjRef)
+		return true, err
+	}
+	return true, nil
+}
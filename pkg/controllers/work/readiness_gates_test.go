@@ -0,0 +1,105 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func TestGateOnReadinessGates(t *testing.T) {
+	gates := []fleetv1beta1.WorkReadinessGate{
+		{ConditionType: "example.com/CanaryPassed", ConditionStatus: metav1.ConditionTrue},
+		{ConditionType: "example.com/Frozen", ConditionStatus: metav1.ConditionFalse},
+	}
+	tests := map[string]struct {
+		gates       []fleetv1beta1.WorkReadinessGate
+		conditions  []metav1.Condition
+		wantPending bool
+		wantMessage string
+	}{
+		"no readiness gates": {},
+		"all the readiness gates are satisfied": {
+			gates: gates,
+			conditions: []metav1.Condition{
+				{Type: "example.com/CanaryPassed", Status: metav1.ConditionTrue, Reason: "Passed"},
+				{Type: "example.com/Frozen", Status: metav1.ConditionFalse, Reason: "NotFrozen"},
+			},
+		},
+		"none of the readiness gates is satisfied": {
+			gates: gates,
+			conditions: []metav1.Condition{
+				{Type: "example.com/Frozen", Status: metav1.ConditionTrue, Reason: "Frozen"},
+			},
+			wantPending: true,
+			wantMessage: "The readiness gates are not met: example.com/CanaryPassed=True, example.com/Frozen=False",
+		},
+		"some of the readiness gates are satisfied": {
+			gates: gates,
+			conditions: []metav1.Condition{
+				{Type: "example.com/CanaryPassed", Status: metav1.ConditionUnknown, Reason: "InProgress"},
+				{Type: "example.com/Frozen", Status: metav1.ConditionFalse, Reason: "NotFrozen"},
+			},
+			wantPending: true,
+			wantMessage: "The readiness gates are not met: example.com/CanaryPassed=True",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+				t.Fatalf("failed to add the placement scheme: %v", err)
+			}
+			work := &fleetv1beta1.Work{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: "fleet-member-test", Generation: 1},
+				Spec:       fleetv1beta1.WorkSpec{ReadinessGates: tt.gates},
+				Status:     fleetv1beta1.WorkStatus{Conditions: tt.conditions},
+			}
+			hubClient := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(work).WithStatusSubresource(work).Build()
+			if err := hubClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, work); err != nil {
+				t.Fatalf("failed to get the work: %v", err)
+			}
+			r := &ApplyWorkReconciler{client: hubClient}
+
+			pending, err := r.gateOnReadinessGates(context.Background(), work)
+			if err != nil {
+				t.Fatalf("gateOnReadinessGates() = %v, want no error", err)
+			}
+			if pending != tt.wantPending {
+				t.Errorf("gateOnReadinessGates() pending = %t, want %t", pending, tt.wantPending)
+			}
+
+			var got fleetv1beta1.Work
+			if err := hubClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, &got); err != nil {
+				t.Fatalf("failed to get the work: %v", err)
+			}
+			appliedCond := meta.FindStatusCondition(got.Status.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+			if tt.wantPending {
+				if appliedCond == nil || appliedCond.Status != metav1.ConditionFalse || appliedCond.Reason != ReadinessGatesNotMetReason ||
+					appliedCond.Message != tt.wantMessage {
+					t.Errorf("gateOnReadinessGates() applied condition = %+v, want false with reason %s and message %q",
+						appliedCond, ReadinessGatesNotMetReason, tt.wantMessage)
+				}
+			} else if appliedCond != nil {
+				t.Errorf("gateOnReadinessGates() applied condition = %+v, want none", appliedCond)
+			}
+			// the conditions set by the external systems are kept.
+			for _, cond := range tt.conditions {
+				if meta.FindStatusCondition(got.Status.Conditions, cond.Type) == nil {
+					t.Errorf("gateOnReadinessGates() dropped the condition %s", cond.Type)
+				}
+			}
+		})
+	}
+}
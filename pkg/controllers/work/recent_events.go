@@ -0,0 +1,71 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// recordWorkEvent appends the event to the recent events of the work, displacing the oldest events once there are
+// more than MaxWorkRecentEvents of them.
+func recordWorkEvent(work *fleetv1beta1.Work, eventType string, ordinal *int, message string) {
+	events := append(work.Status.RecentEvents, fleetv1beta1.WorkEvent{
+		Timestamp:       metav1.Now(),
+		Type:            eventType,
+		ManifestOrdinal: ordinal,
+		Message:         message,
+	})
+	if overflow := len(events) - fleetv1beta1.MaxWorkRecentEvents; overflow > 0 {
+		// copy the kept events so that the displaced ones are not retained by the backing array.
+		events = append([]fleetv1beta1.WorkEvent(nil), events[overflow:]...)
+	}
+	work.Status.RecentEvents = events
+}
+
+// recordApplyEvents records the events of applying the manifests of the work, comparing the apply results against the
+// status of the previous apply. It must be called before the status is updated with the results. Only the changes are
+// recorded so that a steady work does not get a new event, nor a status update, on every reconcile.
+func recordApplyEvents(work *fleetv1beta1.Work, results []applyResult) {
+	workApplied := meta.FindStatusCondition(work.Status.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+	specChanged := workApplied == nil || workApplied.ObservedGeneration != work.Generation
+	if specChanged {
+		recordWorkEvent(work, fleetv1beta1.WorkEventTypeSpecChanged, nil,
+			fmt.Sprintf("The work spec of generation %d is observed", work.Generation))
+	}
+
+	for _, result := range results {
+		if result.action == manifestApplyPendingAction || result.action == manifestSkippedAction || result.action == manifestBatchPendingAction {
+			continue
+		}
+		ordinal := result.identifier.Ordinal
+		var previous *metav1.Condition
+		if manifestCond := findManifestConditionByIdentifier(result.identifier, work.Status.ManifestConditions); manifestCond != nil {
+			previous = meta.FindStatusCondition(manifestCond.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+		}
+		switch {
+		case result.applyErr != nil:
+			message := fmt.Sprintf("Failed to apply manifest: %v", result.applyErr)
+			if previous == nil || previous.Status != metav1.ConditionFalse || previous.Message != message {
+				recordWorkEvent(work, fleetv1beta1.WorkEventTypeError, &ordinal, message)
+			}
+		case previous == nil || previous.Status != metav1.ConditionTrue:
+			recordWorkEvent(work, fleetv1beta1.WorkEventTypeManifestApplied, &ordinal, "The manifest is applied")
+		case previous.ObservedGeneration == result.generation:
+			// the resource has not changed since the last apply.
+		case specChanged:
+			recordWorkEvent(work, fleetv1beta1.WorkEventTypeManifestApplied, &ordinal,
+				fmt.Sprintf("The manifest is applied, the resource is at generation %d", result.generation))
+		default:
+			recordWorkEvent(work, fleetv1beta1.WorkEventTypeDriftFound, &ordinal,
+				fmt.Sprintf("The resource changed from generation %d to %d since the last apply", previous.ObservedGeneration, result.generation))
+		}
+	}
+}
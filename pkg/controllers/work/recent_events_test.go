@@ -0,0 +1,135 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func TestRecordWorkEvent(t *testing.T) {
+	work := &fleetv1beta1.Work{}
+	for i := 1; i <= 50; i++ {
+		recordWorkEvent(work, fleetv1beta1.WorkEventTypeManifestApplied, nil, fmt.Sprintf("event %d", i))
+		if len(work.Status.RecentEvents) > fleetv1beta1.MaxWorkRecentEvents {
+			t.Fatalf("recordWorkEvent() kept %d events, want at most %d", len(work.Status.RecentEvents), fleetv1beta1.MaxWorkRecentEvents)
+		}
+		if i == fleetv1beta1.MaxWorkRecentEvents+1 {
+			// the 21st event displaces the 1st one.
+			if got := work.Status.RecentEvents[0].Message; got != "event 2" {
+				t.Errorf("recordWorkEvent() oldest event = %q after 21 events, want %q", got, "event 2")
+			}
+			if got := work.Status.RecentEvents[fleetv1beta1.MaxWorkRecentEvents-1].Message; got != "event 21" {
+				t.Errorf("recordWorkEvent() newest event = %q after 21 events, want %q", got, "event 21")
+			}
+		}
+	}
+	if len(work.Status.RecentEvents) != fleetv1beta1.MaxWorkRecentEvents {
+		t.Errorf("recordWorkEvent() kept %d events, want %d", len(work.Status.RecentEvents), fleetv1beta1.MaxWorkRecentEvents)
+	}
+	for i, event := range work.Status.RecentEvents {
+		if want := fmt.Sprintf("event %d", 31+i); event.Message != want {
+			t.Errorf("recordWorkEvent() event %d = %q, want %q", i, event.Message, want)
+		}
+		if i > 0 && event.Timestamp.Before(&work.Status.RecentEvents[i-1].Timestamp) {
+			t.Errorf("recordWorkEvent() event %d is older than the event before it", i)
+		}
+	}
+}
+
+func TestRecordApplyEvents(t *testing.T) {
+	identifier := fleetv1beta1.WorkResourceIdentifier{Ordinal: 0, Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "default", Name: "app"}
+	applyErr := errors.New("admission denied")
+	appliedStatus := func(observedGeneration int64, status metav1.ConditionStatus, message string) fleetv1beta1.WorkStatus {
+		return fleetv1beta1.WorkStatus{
+			Conditions: []metav1.Condition{
+				{Type: fleetv1beta1.WorkConditionTypeApplied, Status: metav1.ConditionTrue, ObservedGeneration: 1},
+			},
+			ManifestConditions: []fleetv1beta1.ManifestCondition{{
+				Identifier: identifier,
+				Conditions: []metav1.Condition{
+					{Type: fleetv1beta1.WorkConditionTypeApplied, Status: status, ObservedGeneration: observedGeneration, Message: message},
+				},
+			}},
+		}
+	}
+	tests := map[string]struct {
+		generation int64
+		status     fleetv1beta1.WorkStatus
+		result     applyResult
+		wantTypes  []string
+	}{
+		"first apply": {
+			generation: 1,
+			result:     applyResult{identifier: identifier, generation: 1, action: manifestAvailableAction},
+			wantTypes:  []string{fleetv1beta1.WorkEventTypeSpecChanged, fleetv1beta1.WorkEventTypeManifestApplied},
+		},
+		"steady work records nothing": {
+			generation: 1,
+			status:     appliedStatus(3, metav1.ConditionTrue, ""),
+			result:     applyResult{identifier: identifier, generation: 3, action: manifestAvailableAction},
+		},
+		"spec change applied to the resource": {
+			generation: 2,
+			status:     appliedStatus(3, metav1.ConditionTrue, ""),
+			result:     applyResult{identifier: identifier, generation: 4, action: manifestAvailableAction},
+			wantTypes:  []string{fleetv1beta1.WorkEventTypeSpecChanged, fleetv1beta1.WorkEventTypeManifestApplied},
+		},
+		"resource changed in the member cluster": {
+			generation: 1,
+			status:     appliedStatus(3, metav1.ConditionTrue, ""),
+			result:     applyResult{identifier: identifier, generation: 5, action: manifestAvailableAction},
+			wantTypes:  []string{fleetv1beta1.WorkEventTypeDriftFound},
+		},
+		"new apply error": {
+			generation: 1,
+			status:     appliedStatus(3, metav1.ConditionTrue, ""),
+			result:     applyResult{identifier: identifier, action: errorApplyAction, applyErr: applyErr},
+			wantTypes:  []string{fleetv1beta1.WorkEventTypeError},
+		},
+		"repeated apply error records nothing": {
+			generation: 1,
+			status:     appliedStatus(0, metav1.ConditionFalse, fmt.Sprintf("Failed to apply manifest: %v", applyErr)),
+			result:     applyResult{identifier: identifier, action: errorApplyAction, applyErr: applyErr},
+		},
+		"recovered from the apply error": {
+			generation: 1,
+			status:     appliedStatus(0, metav1.ConditionFalse, fmt.Sprintf("Failed to apply manifest: %v", applyErr)),
+			result:     applyResult{identifier: identifier, generation: 3, action: manifestAvailableAction},
+			wantTypes:  []string{fleetv1beta1.WorkEventTypeManifestApplied},
+		},
+		"skipped manifest records nothing": {
+			generation: 1,
+			status:     appliedStatus(3, metav1.ConditionTrue, ""),
+			result:     applyResult{identifier: identifier, action: manifestSkippedAction},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			work := &fleetv1beta1.Work{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-work", Generation: tt.generation},
+				Status:     tt.status,
+			}
+			recordApplyEvents(work, []applyResult{tt.result})
+			var gotTypes []string
+			for _, event := range work.Status.RecentEvents {
+				gotTypes = append(gotTypes, event.Type)
+				if event.Type != fleetv1beta1.WorkEventTypeSpecChanged && (event.ManifestOrdinal == nil || *event.ManifestOrdinal != 0) {
+					t.Errorf("recordApplyEvents() event %s manifest ordinal = %v, want 0", event.Type, event.ManifestOrdinal)
+				}
+			}
+			if diff := cmp.Diff(tt.wantTypes, gotTypes); diff != "" {
+				t.Errorf("recordApplyEvents() event types mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
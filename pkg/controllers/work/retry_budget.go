@@ -0,0 +1,176 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	// DefaultRetryBudgetMaxAttempts is the default maximum number of attempts to apply a manifest within a reconcile.
+	DefaultRetryBudgetMaxAttempts = 3
+	// DefaultRetryBudgetInitialDelay is the default delay before the first retry of a manifest within a reconcile.
+	DefaultRetryBudgetInitialDelay = 200 * time.Millisecond
+	// DefaultRetryBudgetMaxDelay is the default maximum delay between two retries of a manifest within a reconcile.
+	DefaultRetryBudgetMaxDelay = 2 * time.Second
+)
+
+// RetryBudgetConfig configures how many times a manifest which fails to apply with a transient API server error is
+// retried within a reconcile before its failure is reported in the work status, so that a few flaky manifests do not
+// requeue the whole work.
+type RetryBudgetConfig struct {
+	// MaxAttempts is the maximum number of attempts to apply a manifest within a reconcile, including the first one.
+	// The retries are disabled if it is 1 or less.
+	MaxAttempts int
+	// InitialDelay is the delay before the first retry; every further retry waits twice as long as the previous one.
+	InitialDelay time.Duration
+	// MaxDelay caps the delay between two retries; it is not capped if 0.
+	MaxDelay time.Duration
+}
+
+// retryBudgetKey is the context key of the retry budget of the work.
+type retryBudgetKey struct{}
+
+// manifestRetryBudget is the retry budget of the manifests of a generation of a work. A reconcile processes a work
+// at a time so the budget is not shared by concurrent reconciles, but the manifests of the work are applied
+// concurrently.
+type manifestRetryBudget struct {
+	config     RetryBudgetConfig
+	generation int64
+	mu         sync.Mutex
+	// retries are the numbers of the retries spent on the manifests since they last applied successfully.
+	retries map[fleetv1beta1.WorkResourceIdentifier]int
+}
+
+// retryBudgetTracker keeps the retry budgets of the works across the reconciles.
+type retryBudgetTracker struct {
+	config  RetryBudgetConfig
+	mu      sync.Mutex
+	budgets map[types.NamespacedName]*manifestRetryBudget
+}
+
+// newRetryBudgetTracker returns the tracker of the retry budgets per the config, or nil if the retries are disabled.
+func newRetryBudgetTracker(config RetryBudgetConfig) *retryBudgetTracker {
+	if config.MaxAttempts <= 1 {
+		return nil
+	}
+	return &retryBudgetTracker{
+		config:  config,
+		budgets: make(map[types.NamespacedName]*manifestRetryBudget),
+	}
+}
+
+// withRetryBudget returns a context which carries the retry budget of the work. The budget is drained when the
+// generation of the work changes as the manifests are new.
+func (t *retryBudgetTracker) withRetryBudget(ctx context.Context, work *fleetv1beta1.Work) context.Context {
+	if t == nil {
+		return ctx
+	}
+	workKey := types.NamespacedName{Namespace: work.Namespace, Name: work.Name}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	budget, ok := t.budgets[workKey]
+	if !ok || budget.generation != work.Generation {
+		budget = &manifestRetryBudget{
+			config:     t.config,
+			generation: work.Generation,
+			retries:    make(map[fleetv1beta1.WorkResourceIdentifier]int),
+		}
+		t.budgets[workKey] = budget
+	}
+	return context.WithValue(ctx, retryBudgetKey{}, budget)
+}
+
+// forget drops the retry budget of the deleted work.
+func (t *retryBudgetTracker) forget(workKey types.NamespacedName) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.budgets, workKey)
+}
+
+// spend returns how long to wait before retrying the manifest which failed with the error, and false if the error
+// is not transient or the budget of the manifest is used up.
+func (b *manifestRetryBudget) spend(identifier fleetv1beta1.WorkResourceIdentifier, err error) (time.Duration, bool) {
+	if b == nil || !isTransientAPIError(err) {
+		return 0, false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	retries := b.retries[identifier]
+	if retries+1 >= b.config.MaxAttempts {
+		return 0, false
+	}
+	b.retries[identifier] = retries + 1
+	delay := b.config.InitialDelay << retries
+	if b.config.MaxDelay > 0 && delay > b.config.MaxDelay {
+		delay = b.config.MaxDelay
+	}
+	return delay, true
+}
+
+// refund gives the budget of the manifest back once it applies successfully.
+func (b *manifestRetryBudget) refund(identifier fleetv1beta1.WorkResourceIdentifier) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.retries, identifier)
+}
+
+// isTransientAPIError returns true if the error is one which the API server may not return on the next call.
+func isTransientAPIError(err error) bool {
+	return apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) || apierrors.IsTooManyRequests(err) ||
+		apierrors.IsInternalError(err) || apierrors.IsServiceUnavailable(err) || apierrors.IsConflict(err)
+}
+
+// applyWithRetryBudget applies the manifest with the ordinal and retries it with an exponential delay while it fails
+// with a transient API server error, as far as the retry budget of the work in the context allows. The last result
+// is returned once the budget is used up or the context is done.
+func (r *ApplyWorkReconciler) applyWithRetryBudget(ctx context.Context, index int, gvr schema.GroupVersionResource,
+	manifestObj *unstructured.Unstructured, applyStrategy *fleetv1beta1.ApplyStrategy) (*unstructured.Unstructured, ApplyAction, error) {
+	budget, _ := ctx.Value(retryBudgetKey{}).(*manifestRetryBudget)
+	identifier := buildResourceIdentifier(index, manifestObj, gvr)
+	// the appliers may change the manifest object, e.g. to set its resource version, so every retry starts over
+	// from a copy of the manifest.
+	var pristine *unstructured.Unstructured
+	if budget != nil {
+		pristine = manifestObj.DeepCopy()
+	}
+	obj := manifestObj
+	for attempt := 1; ; attempt++ {
+		curObj, action, err := r.applyWithHooks(ctx, index, gvr, obj, applyStrategy)
+		if err == nil {
+			budget.refund(identifier)
+			return curObj, action, nil
+		}
+		delay, ok := budget.spend(identifier, err)
+		if !ok {
+			return curObj, action, err
+		}
+		klog.V(2).InfoS("Retry applying the manifest after a transient error", "gvr", gvr, "manifest", klog.KObj(manifestObj),
+			"attempt", attempt, "delay", delay, "err", err)
+		select {
+		case <-ctx.Done():
+			return curObj, action, err
+		case <-time.After(delay):
+		}
+		obj = pristine.DeepCopy()
+	}
+}
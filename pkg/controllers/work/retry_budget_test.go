@@ -0,0 +1,132 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// flakyApplier fails the first applies with the error before it creates or replaces the resources.
+type flakyApplier struct {
+	replacingApplier
+	err      error
+	failures int
+	calls    int
+}
+
+func (a *flakyApplier) ApplyUnstructured(ctx context.Context, strategy *fleetv1beta1.ApplyStrategy, gvr schema.GroupVersionResource,
+	manifestObj *unstructured.Unstructured) (*unstructured.Unstructured, ApplyAction, error) {
+	a.calls++
+	if a.calls <= a.failures {
+		return nil, errorApplyAction, a.err
+	}
+	return a.replacingApplier.ApplyUnstructured(ctx, strategy, gvr, manifestObj)
+}
+
+func TestApplyWithRetryBudget(t *testing.T) {
+	unavailable := apierrors.NewServiceUnavailable("etcd leader changed")
+	tests := map[string]struct {
+		err       error
+		failures  int
+		wantCalls int
+		wantErr   bool
+	}{
+		"transient errors are retried within the reconcile": {
+			err:       unavailable,
+			failures:  2,
+			wantCalls: 3,
+		},
+		"transient errors beyond the budget are reported": {
+			err:       unavailable,
+			failures:  5,
+			wantCalls: 3,
+			wantErr:   true,
+		},
+		"conflicts are retried": {
+			err:       apierrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, "web", errors.New("modified")),
+			failures:  1,
+			wantCalls: 2,
+		},
+		"permanent errors are not retried": {
+			err:       apierrors.NewBadRequest("invalid"),
+			failures:  1,
+			wantCalls: 1,
+			wantErr:   true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+			applier := &flakyApplier{replacingApplier: replacingApplier{dynamicClient: dynamicClient}, err: tt.err, failures: tt.failures}
+			r := &ApplyWorkReconciler{
+				appliers:     map[fleetv1beta1.ApplyStrategyType]Applier{fleetv1beta1.ApplyStrategyTypeClientSideApply: applier},
+				retryBudgets: newRetryBudgetTracker(RetryBudgetConfig{MaxAttempts: 3, InitialDelay: time.Millisecond}),
+			}
+			work := &fleetv1beta1.Work{ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: "fleet-member-test", Generation: 1}}
+			ctx := r.retryBudgets.withRetryBudget(context.Background(), work)
+			gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+			strategy := &fleetv1beta1.ApplyStrategy{Type: fleetv1beta1.ApplyStrategyTypeClientSideApply}
+
+			_, _, err := r.applyWithRetryBudget(ctx, 0, gvr, liveDeployment("web", ""), strategy)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Errorf("applyWithRetryBudget() = %v, want error %t", err, tt.wantErr)
+			}
+			if applier.calls != tt.wantCalls {
+				t.Errorf("apply calls = %d, want %d", applier.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryBudgetAcrossReconciles(t *testing.T) {
+	applier := &flakyApplier{err: apierrors.NewTooManyRequests("throttled", 1), failures: 100}
+	r := &ApplyWorkReconciler{
+		appliers:     map[fleetv1beta1.ApplyStrategyType]Applier{fleetv1beta1.ApplyStrategyTypeClientSideApply: applier},
+		retryBudgets: newRetryBudgetTracker(RetryBudgetConfig{MaxAttempts: 3, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond}),
+	}
+	work := &fleetv1beta1.Work{ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: "fleet-member-test", Generation: 1}}
+	gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	strategy := &fleetv1beta1.ApplyStrategy{Type: fleetv1beta1.ApplyStrategyTypeClientSideApply}
+	reconcile := func() int {
+		applier.calls = 0
+		ctx := r.retryBudgets.withRetryBudget(context.Background(), work)
+		if _, _, err := r.applyWithRetryBudget(ctx, 0, gvr, liveDeployment("web", ""), strategy); err == nil {
+			t.Fatalf("applyWithRetryBudget() = nil, want an error")
+		}
+		return applier.calls
+	}
+
+	if got := reconcile(); got != 3 {
+		t.Errorf("apply calls of the first reconcile = %d, want 3", got)
+	}
+	// the budget used up is not spent again on the same generation of the work.
+	if got := reconcile(); got != 1 {
+		t.Errorf("apply calls of the second reconcile = %d, want 1", got)
+	}
+	// the budget is drained when the generation of the work changes.
+	work.Generation++
+	if got := reconcile(); got != 3 {
+		t.Errorf("apply calls of the reconcile of the new generation = %d, want 3", got)
+	}
+	// the budget of a deleted work is dropped.
+	r.retryBudgets.forget(types.NamespacedName{Namespace: work.Namespace, Name: work.Name})
+	if len(r.retryBudgets.budgets) != 0 {
+		t.Errorf("retry budgets = %v, want none after the work is forgotten", r.retryBudgets.budgets)
+	}
+}
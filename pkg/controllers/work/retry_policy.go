@@ -0,0 +1,100 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	// MaxRetriesExceededReason is the reason string of condition when the manifest still fails to apply after it has
+	// been retried for the maximum number of times allowed by its retry policy.
+	MaxRetriesExceededReason = "MaxRetriesExceeded"
+
+	// defaultRetryBackoffSeconds is the backoff of a retry policy which does not set one.
+	defaultRetryBackoffSeconds = 5
+)
+
+// evaluateRetryPolicies applies the manifest retry policies of the work to the apply results and returns how long
+// to wait before retrying the failed manifests; 0 means no manifest has to be retried per its retry policy.
+// The errors of the manifests with a retry policy are handled here instead of requeueing the work with the
+// controller backoff.
+// It must be called before the work status is updated with the results as it reads the previous retry counts.
+func evaluateRetryPolicies(results []applyResult, work *fleetv1beta1.Work) time.Duration {
+	if len(work.Spec.Workload.ManifestRetryPolicies) == 0 {
+		return 0
+	}
+	policies := make(map[int]*fleetv1beta1.RetryPolicy, len(work.Spec.Workload.ManifestRetryPolicies))
+	for i := range work.Spec.Workload.ManifestRetryPolicies {
+		policy := &work.Spec.Workload.ManifestRetryPolicies[i]
+		policies[policy.Ordinal] = &policy.RetryPolicy
+	}
+	// the retry counts start over for every generation of the work.
+	appliedCond := meta.FindStatusCondition(work.Status.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+	isNewGeneration := appliedCond == nil || appliedCond.ObservedGeneration != work.Generation
+
+	var retryAfter time.Duration
+	for i := range results {
+		result := &results[i]
+		policy, ok := policies[result.identifier.Ordinal]
+		if !ok {
+			continue
+		}
+		result.retryHandled = true
+		if result.applyErr == nil {
+			continue
+		}
+		retryCount := 0
+		if existing := findManifestConditionByIdentifier(result.identifier, work.Status.ManifestConditions); existing != nil && !isNewGeneration {
+			retryCount = existing.RetryCount
+		}
+		result.retryCount = retryCount
+		if !shouldRetryOn(result.applyErr, policy.RetryOn) {
+			continue
+		}
+		if retryCount >= policy.MaxRetries {
+			result.retriesExceeded = true
+			continue
+		}
+		result.retryCount++
+		backoffSeconds := policy.BackoffSeconds
+		if backoffSeconds <= 0 {
+			backoffSeconds = defaultRetryBackoffSeconds
+		}
+		if backoff := time.Duration(backoffSeconds) * time.Second; retryAfter == 0 || backoff < retryAfter {
+			retryAfter = backoff
+		}
+	}
+	return retryAfter
+}
+
+// shouldRetryOn returns true if the apply error falls in any of the error codes.
+func shouldRetryOn(err error, retryOn []fleetv1beta1.ErrorCode) bool {
+	for _, code := range retryOn {
+		switch code {
+		case fleetv1beta1.ErrorCodeConflict:
+			if apierrors.IsConflict(err) {
+				return true
+			}
+		case fleetv1beta1.ErrorCodeForbidden:
+			if apierrors.IsForbidden(err) {
+				return true
+			}
+		case fleetv1beta1.ErrorCodeTimeout:
+			if apierrors.IsTimeout(err) || apierrors.IsServerTimeout(err) || errors.Is(err, context.DeadlineExceeded) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func TestEvaluateRetryPoliciesOnRepeatedConflicts(t *testing.T) {
+	conflictErr := apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "cm", errors.New("the object has been modified"))
+	identifier := fleetv1beta1.WorkResourceIdentifier{Ordinal: 0, Version: "v1", Kind: "ConfigMap", Resource: "configmaps", Name: "cm"}
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Generation: 1},
+		Spec: fleetv1beta1.WorkSpec{
+			Workload: fleetv1beta1.WorkloadTemplate{
+				ManifestRetryPolicies: []fleetv1beta1.ManifestRetryPolicy{
+					{
+						Ordinal: 0,
+						RetryPolicy: fleetv1beta1.RetryPolicy{
+							MaxRetries:     3,
+							RetryOn:        []fleetv1beta1.ErrorCode{fleetv1beta1.ErrorCodeConflict},
+							BackoffSeconds: 2,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for wantRetryCount := 1; wantRetryCount <= 3; wantRetryCount++ {
+		results := []applyResult{{identifier: identifier, action: errorApplyAction, applyErr: conflictErr}}
+		retryAfter := evaluateRetryPolicies(results, work)
+		errs := constructWorkCondition(results, work)
+		assert.Equal(t, 2*time.Second, retryAfter, "evaluateRetryPolicies() retry after mismatch for retry %d", wantRetryCount)
+		assert.Empty(t, errs, "constructWorkCondition() should not return the errors handled by the retry policy")
+		assert.Equal(t, wantRetryCount, work.Status.ManifestConditions[0].RetryCount, "retry count mismatch")
+		applyCond := meta.FindStatusCondition(work.Status.ManifestConditions[0].Conditions, fleetv1beta1.WorkConditionTypeApplied)
+		assert.Equal(t, ManifestApplyFailedReason, applyCond.Reason, "apply condition reason mismatch for retry %d", wantRetryCount)
+	}
+
+	step := "the retries stop once the max retries is reached"
+	results := []applyResult{{identifier: identifier, action: errorApplyAction, applyErr: conflictErr}}
+	retryAfter := evaluateRetryPolicies(results, work)
+	constructWorkCondition(results, work)
+	assert.Zero(t, retryAfter, step)
+	assert.Equal(t, 3, work.Status.ManifestConditions[0].RetryCount, step)
+	applyCond := meta.FindStatusCondition(work.Status.ManifestConditions[0].Conditions, fleetv1beta1.WorkConditionTypeApplied)
+	assert.Equal(t, MaxRetriesExceededReason, applyCond.Reason, step)
+
+	step = "the retry count starts over for a new generation"
+	work.Generation++
+	results = []applyResult{{identifier: identifier, action: errorApplyAction, applyErr: conflictErr}}
+	retryAfter = evaluateRetryPolicies(results, work)
+	constructWorkCondition(results, work)
+	assert.Equal(t, 2*time.Second, retryAfter, step)
+	assert.Equal(t, 1, work.Status.ManifestConditions[0].RetryCount, step)
+}
+
+func TestEvaluateRetryPolicies(t *testing.T) {
+	identifier := fleetv1beta1.WorkResourceIdentifier{Ordinal: 0, Version: "v1", Kind: "ConfigMap", Resource: "configmaps", Name: "cm"}
+	policy := fleetv1beta1.ManifestRetryPolicy{
+		Ordinal: 0,
+		RetryPolicy: fleetv1beta1.RetryPolicy{
+			MaxRetries: 1,
+			RetryOn:    []fleetv1beta1.ErrorCode{fleetv1beta1.ErrorCodeTimeout},
+		},
+	}
+	tests := map[string]struct {
+		policies         []fleetv1beta1.ManifestRetryPolicy
+		applyErr         error
+		wantRetryAfter   time.Duration
+		wantRetryHandled bool
+		wantRetryCount   int
+	}{
+		"manifest without retry policy": {
+			applyErr:         apierrors.NewTimeoutError("timeout", 1),
+			wantRetryHandled: false,
+		},
+		"retry on a listed error with the default backoff": {
+			policies:         []fleetv1beta1.ManifestRetryPolicy{policy},
+			applyErr:         apierrors.NewTimeoutError("timeout", 1),
+			wantRetryAfter:   defaultRetryBackoffSeconds * time.Second,
+			wantRetryHandled: true,
+			wantRetryCount:   1,
+		},
+		"no retry on an unlisted error": {
+			policies:         []fleetv1beta1.ManifestRetryPolicy{policy},
+			applyErr:         apierrors.NewForbidden(schema.GroupResource{Resource: "configmaps"}, "cm", errors.New("forbidden")),
+			wantRetryHandled: true,
+		},
+		"applied manifest is not retried": {
+			policies:         []fleetv1beta1.ManifestRetryPolicy{policy},
+			wantRetryHandled: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			work := &fleetv1beta1.Work{
+				Spec: fleetv1beta1.WorkSpec{
+					Workload: fleetv1beta1.WorkloadTemplate{ManifestRetryPolicies: tt.policies},
+				},
+			}
+			results := []applyResult{{identifier: identifier, applyErr: tt.applyErr}}
+			assert.Equal(t, tt.wantRetryAfter, evaluateRetryPolicies(results, work), "evaluateRetryPolicies() retry after mismatch")
+			assert.Equal(t, tt.wantRetryHandled, results[0].retryHandled, "retry handled mismatch")
+			assert.Equal(t, tt.wantRetryCount, results[0].retryCount, "retry count mismatch")
+		})
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+// restartedAtAnnotation is the pod template annotation which `kubectl rollout restart` sets to restart the pods.
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// appliedAnnotations are the annotations the work applier changes on every change of the manifest, which are not
+// annotation changes of the manifest itself.
+var appliedAnnotations = []string{fleetv1beta1.ManifestHashAnnotation, fleetv1beta1.LastAppliedConfigAnnotation}
+
+// getRollingRestartBaseline returns the deployment in the member cluster before the manifest is applied if the pods
+// are restarted on the annotation updates; it returns nil if they are not or the deployment does not exist yet.
+func (r *ApplyWorkReconciler) getRollingRestartBaseline(ctx context.Context, gvr schema.GroupVersionResource,
+	manifestObj *unstructured.Unstructured, applyStrategy *fleetv1beta1.ApplyStrategy) (*unstructured.Unstructured, error) {
+	if !applyStrategy.TriggerRollingRestartOnAnnotationUpdate || gvr != utils.DeploymentGVR || manifestObj.GetName() == "" {
+		return nil, nil
+	}
+	curObj, err := r.spokeDynamicClient.Resource(gvr).Namespace(manifestObj.GetNamespace()).Get(ctx, manifestObj.GetName(), metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		klog.ErrorS(err, "Failed to get the deployment before applying it", "gvr", gvr, "manifest", klog.KObj(manifestObj))
+		return nil, controller.NewAPIServerError(false, err)
+	}
+	return curObj, nil
+}
+
+// triggerRollingRestart restarts the pods of the deployment if the apply changed its annotations but not its spec,
+// the same way as `kubectl rollout restart` does.
+func (r *ApplyWorkReconciler) triggerRollingRestart(ctx context.Context, gvr schema.GroupVersionResource,
+	prevObj, curObj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	if prevObj == nil || curObj == nil || !isAnnotationOnlyUpdate(prevObj, curObj) {
+		return curObj, nil
+	}
+	manifestRef := klog.KObj(curObj)
+	restartedAt := time.Now().UTC().Format(time.RFC3339)
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{restartedAtAnnotation: restartedAt},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, controller.NewUnexpectedBehaviorError(err)
+	}
+	restarted, err := r.spokeDynamicClient.Resource(gvr).Namespace(curObj.GetNamespace()).
+		Patch(ctx, curObj.GetName(), types.MergePatchType, patch, metav1.PatchOptions{FieldManager: r.fieldManager})
+	if err != nil {
+		klog.ErrorS(err, "Failed to restart the deployment after its annotations are updated", "gvr", gvr, "manifest", manifestRef)
+		return nil, controller.NewAPIServerError(false, err)
+	}
+	klog.V(2).InfoS("Restarted the deployment as only its annotations are updated", "gvr", gvr, "manifest", manifestRef,
+		"restartedAt", restartedAt)
+	return restarted, nil
+}
+
+// isAnnotationOnlyUpdate returns true if the annotations of the object are changed while its spec is not. The
+// annotations the work applier sets on every apply are not compared.
+func isAnnotationOnlyUpdate(prevObj, curObj *unstructured.Unstructured) bool {
+	if !equality.Semantic.DeepEqual(prevObj.Object["spec"], curObj.Object["spec"]) {
+		return false
+	}
+	prevAnnotations, curAnnotations := prevObj.GetAnnotations(), curObj.GetAnnotations()
+	for _, key := range appliedAnnotations {
+		delete(prevAnnotations, key)
+		delete(curAnnotations, key)
+	}
+	return !equality.Semantic.DeepEqual(nonNilMap(prevAnnotations), nonNilMap(curAnnotations))
+}
+
+func nonNilMap(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
@@ -0,0 +1,128 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+)
+
+// updatingApplier writes the manifests to the member cluster as they are.
+type updatingApplier struct {
+	client dynamic.Interface
+}
+
+func (a *updatingApplier) ApplyUnstructured(ctx context.Context, _ *fleetv1beta1.ApplyStrategy, gvr schema.GroupVersionResource,
+	manifestObj *unstructured.Unstructured) (*unstructured.Unstructured, ApplyAction, error) {
+	resource := a.client.Resource(gvr).Namespace(manifestObj.GetNamespace())
+	if _, err := resource.Get(ctx, manifestObj.GetName(), metav1.GetOptions{}); err != nil {
+		created, err := resource.Create(ctx, manifestObj, metav1.CreateOptions{})
+		return created, manifestCreatedAction, err
+	}
+	updated, err := resource.Update(ctx, manifestObj, metav1.UpdateOptions{})
+	return updated, manifestThreeWayMergePatchAction, err
+}
+
+// annotatedDeployment returns a deployment with the given replicas and annotations.
+func annotatedDeployment(replicas int64, annotations map[string]string) *unstructured.Unstructured {
+	deploy := liveDeployment("deploy", "")
+	deploy.SetAnnotations(annotations)
+	deploy.Object["spec"] = map[string]interface{}{"replicas": replicas}
+	return deploy
+}
+
+func TestApplyTriggersRollingRestart(t *testing.T) {
+	tests := map[string]struct {
+		disabled    bool
+		live        *unstructured.Unstructured
+		manifest    *unstructured.Unstructured
+		wantRestart bool
+	}{
+		"annotation only update restarts the pods": {
+			live: annotatedDeployment(1, map[string]string{
+				"config-version": "1", fleetv1beta1.ManifestHashAnnotation: "hash-1",
+			}),
+			manifest: annotatedDeployment(1, map[string]string{
+				"config-version": "2", fleetv1beta1.ManifestHashAnnotation: "hash-2",
+			}),
+			wantRestart: true,
+		},
+		"added annotation restarts the pods": {
+			live:        annotatedDeployment(1, nil),
+			manifest:    annotatedDeployment(1, map[string]string{"config-version": "1"}),
+			wantRestart: true,
+		},
+		"spec update does not restart the pods": {
+			live:     annotatedDeployment(1, map[string]string{"config-version": "1"}),
+			manifest: annotatedDeployment(2, map[string]string{"config-version": "2"}),
+		},
+		"update of the applied annotations only does not restart the pods": {
+			live: annotatedDeployment(1, map[string]string{
+				fleetv1beta1.ManifestHashAnnotation: "hash-1", fleetv1beta1.LastAppliedConfigAnnotation: "{}",
+			}),
+			manifest: annotatedDeployment(1, map[string]string{
+				fleetv1beta1.ManifestHashAnnotation: "hash-2", fleetv1beta1.LastAppliedConfigAnnotation: `{"labels":{}}`,
+			}),
+		},
+		"unchanged deployment does not restart the pods": {
+			live:     annotatedDeployment(1, map[string]string{"config-version": "1"}),
+			manifest: annotatedDeployment(1, map[string]string{"config-version": "1"}),
+		},
+		"new deployment does not restart the pods": {
+			manifest: annotatedDeployment(1, map[string]string{"config-version": "1"}),
+		},
+		"annotation only update does not restart the pods if disabled": {
+			disabled: true,
+			live:     annotatedDeployment(1, map[string]string{"config-version": "1"}),
+			manifest: annotatedDeployment(1, map[string]string{"config-version": "2"}),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var objects []runtime.Object
+			if tt.live != nil {
+				objects = append(objects, tt.live)
+			}
+			dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{utils.DeploymentGVR: "DeploymentList"}, objects...)
+			r := &ApplyWorkReconciler{
+				spokeDynamicClient: dynamicClient,
+				appliers: map[fleetv1beta1.ApplyStrategyType]Applier{
+					fleetv1beta1.ApplyStrategyTypeClientSideApply: &updatingApplier{client: dynamicClient},
+				},
+			}
+			applyStrategy := &fleetv1beta1.ApplyStrategy{
+				Type:                                    fleetv1beta1.ApplyStrategyTypeClientSideApply,
+				TriggerRollingRestartOnAnnotationUpdate: !tt.disabled,
+			}
+			if _, _, err := r.applyUnstructuredAndTrackAvailability(context.Background(), utils.DeploymentGVR, tt.manifest, applyStrategy); err != nil {
+				t.Fatalf("applyUnstructuredAndTrackAvailability() = %v, want no error", err)
+			}
+
+			got, err := dynamicClient.Resource(utils.DeploymentGVR).Namespace("default").Get(context.Background(), "deploy", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get the deployment: %v", err)
+			}
+			restartedAt, found, err := unstructured.NestedString(got.Object, "spec", "template", "metadata", "annotations", restartedAtAnnotation)
+			if err != nil {
+				t.Fatalf("failed to read the restart annotation: %v", err)
+			}
+			if found != tt.wantRestart {
+				t.Errorf("deployment restart annotation found = %t (%q), want %t", found, restartedAt, tt.wantRestart)
+			}
+		})
+	}
+}
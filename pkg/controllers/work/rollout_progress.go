@@ -0,0 +1,143 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/metrics"
+)
+
+const (
+	// ManifestBatchPendingReason is the reason string of the manifest conditions when the manifest waits for the
+	// manifests of the previous batches to be applied.
+	ManifestBatchPendingReason = "ManifestBatchPending"
+	// workRolloutInProgressReason is the reason string of the work applied condition when the manifests of some
+	// batches are not applied yet.
+	workRolloutInProgressReason = "WorkRolloutInProgress"
+
+	// rolloutBatchRequeueDelay is how long the work applier waits before it applies the next batch of a work.
+	rolloutBatchRequeueDelay = 5 * time.Second
+)
+
+// rolloutPlan is the batches the manifests of a work are applied in and the batch applied in this reconcile.
+type rolloutPlan struct {
+	// batches are the ordinals of the manifests in every batch, in the order they are applied in.
+	batches [][]int
+	// current is the index of the batch applied in this reconcile; the batches before it are applied again.
+	current int
+}
+
+// planRollout splits the manifests of the work into the batches of its apply strategy and picks the first batch which
+// is not completed yet. It returns nil if the manifests of the work are all applied at once.
+func planRollout(work *fleetv1beta1.Work) *rolloutPlan {
+	batchSize := work.Spec.ApplyStrategy.BatchSize
+	manifests := work.Spec.Workload.Manifests
+	if batchSize <= 0 || len(manifests) <= batchSize {
+		return nil
+	}
+	order := applyOrder(manifests)
+	plan := &rolloutPlan{}
+	for start := 0; start < len(order); start += batchSize {
+		plan.batches = append(plan.batches, order[start:min(start+batchSize, len(order))])
+	}
+	// the rollout starts over from the first batch when the work spec changes.
+	if progress := work.Status.RolloutProgress; progress != nil && progress.ObservedGeneration == work.Generation {
+		plan.current = min(progress.CompletedBatches, len(plan.batches)-1)
+	}
+	return plan
+}
+
+// pendingOrdinals returns the ordinals of the manifests in the batches after the current one.
+func (p *rolloutPlan) pendingOrdinals() map[int]bool {
+	if p == nil {
+		return nil
+	}
+	pending := make(map[int]bool)
+	for _, batch := range p.batches[p.current+1:] {
+		for _, ordinal := range batch {
+			pending[ordinal] = true
+		}
+	}
+	return pending
+}
+
+// updateRolloutProgress reports the progress of the rollout in the work status and the metrics; the current batch is
+// completed once all its manifests are applied, or skipped per the annotation of the work. It returns whether there
+// are batches left to apply.
+func updateRolloutProgress(work *fleetv1beta1.Work, plan *rolloutPlan, results []applyResult) bool {
+	if plan == nil {
+		work.Status.RolloutProgress = nil
+		metrics.WorkRolloutProgressPercentage.DeleteLabelValues(work.Namespace, work.Name)
+		return false
+	}
+	completed := plan.current
+	if isBatchApplied(plan.batches[plan.current], results) {
+		completed++
+	}
+	progress := &fleetv1beta1.RolloutProgress{
+		ObservedGeneration: work.Generation,
+		TotalBatches:       len(plan.batches),
+		CompletedBatches:   completed,
+		ProgressPercentage: float32(completed) * 100 / float32(len(plan.batches)),
+	}
+	if completed < len(plan.batches) {
+		progress.CurrentBatchOrdinals = plan.batches[completed]
+	}
+	if previous := work.Status.RolloutProgress; previous == nil || previous.CompletedBatches != completed ||
+		previous.ObservedGeneration != work.Generation {
+		klog.V(2).InfoS("Work rollout progressed", "work", klog.KObj(work), "completedBatches", completed,
+			"totalBatches", len(plan.batches))
+	}
+	work.Status.RolloutProgress = progress
+	metrics.WorkRolloutProgressPercentage.WithLabelValues(work.Namespace, work.Name).Set(float64(progress.ProgressPercentage))
+	return completed < len(plan.batches)
+}
+
+// isBatchApplied returns whether all the manifests of the batch are applied or skipped.
+func isBatchApplied(batch []int, results []applyResult) bool {
+	for _, ordinal := range batch {
+		result := results[ordinal]
+		if result.action == manifestSkippedAction {
+			continue
+		}
+		if result.applyErr != nil || result.action == manifestApplyPendingAction || result.action == manifestBatchPendingAction {
+			return false
+		}
+	}
+	return true
+}
+
+// batchPendingApplyResult returns the result of a manifest which waits for the previous batches to be applied.
+// The manifest is still identified so that the resource it applied before is not garbage collected.
+func (r *ApplyWorkReconciler) batchPendingApplyResult(index int, manifest fleetv1beta1.Manifest) applyResult {
+	result := applyResult{
+		identifier: fleetv1beta1.WorkResourceIdentifier{Ordinal: index},
+		action:     manifestBatchPendingAction,
+	}
+	if gvr, rawObj, err := r.decodeManifest(manifest); err == nil {
+		result.identifier = buildResourceIdentifier(index, rawObj, gvr)
+	}
+	return result
+}
+
+// batchPendingOrdinals returns the ordinals of the manifests which wait for the previous batches, in order.
+func batchPendingOrdinals(manifestConditions []fleetv1beta1.ManifestCondition) []int {
+	var pending []int
+	for _, manifestCond := range manifestConditions {
+		applyCond := meta.FindStatusCondition(manifestCond.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+		if applyCond != nil && applyCond.Reason == ManifestBatchPendingReason {
+			pending = append(pending, manifestCond.Identifier.Ordinal)
+		}
+	}
+	sort.Ints(pending)
+	return pending
+}
@@ -0,0 +1,198 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/atomic"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/metrics"
+)
+
+// ordinalRange returns the ordinals from start to end, excluded.
+func ordinalRange(start, end int) []int {
+	ordinals := make([]int, 0, end-start)
+	for i := start; i < end; i++ {
+		ordinals = append(ordinals, i)
+	}
+	return ordinals
+}
+
+func TestReconcileRolloutProgress(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the fleet scheme: %v", err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the core scheme: %v", err)
+	}
+	names := make([]string, 30)
+	for i := range names {
+		names[i] = fmt.Sprintf("deploy-%d", i)
+	}
+	workKey := types.NamespacedName{Name: "test-work", Namespace: "fleet-member-test"}
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: workKey.Name, Namespace: workKey.Namespace, Generation: 1},
+		Spec: fleetv1beta1.WorkSpec{
+			Workload:      fleetv1beta1.WorkloadTemplate{Manifests: deploymentManifests(t, names...)},
+			ApplyStrategy: &fleetv1beta1.ApplyStrategy{Type: fleetv1beta1.ApplyStrategyTypeClientSideApply, BatchSize: 5},
+		},
+	}
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(work).WithStatusSubresource(&fleetv1beta1.Work{}).Build()
+	spokeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&fleetv1beta1.AppliedWork{}).Build()
+	applier := &forceRecordingApplier{}
+	r := &ApplyWorkReconciler{
+		client:             hubClient,
+		spokeDynamicClient: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()),
+		spokeClient:        spokeClient,
+		restMapper:         testMapper{},
+		recorder:           record.NewFakeRecorder(1000),
+		joined:             atomic.NewBool(true),
+		appliers: map[fleetv1beta1.ApplyStrategyType]Applier{
+			fleetv1beta1.ApplyStrategyTypeClientSideApply: applier,
+		},
+	}
+
+	applied := 0
+	for batch := 1; batch <= 6; batch++ {
+		result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: workKey})
+		if err != nil {
+			t.Fatalf("Reconcile() of batch %d = %v, want no error", batch, err)
+		}
+		// the completed batches are applied again along with the current one.
+		applied += batch * 5
+		if got := len(applier.forced); got != applied {
+			t.Errorf("applied manifests after batch %d = %d, want %d", batch, got, applied)
+		}
+
+		var got fleetv1beta1.Work
+		if err := hubClient.Get(context.Background(), workKey, &got); err != nil {
+			t.Fatalf("failed to get the work: %v", err)
+		}
+		want := &fleetv1beta1.RolloutProgress{
+			ObservedGeneration: 1,
+			TotalBatches:       6,
+			CompletedBatches:   batch,
+			ProgressPercentage: float32(batch) * 100 / 6,
+		}
+		if batch < 6 {
+			want.CurrentBatchOrdinals = ordinalRange(batch*5, batch*5+5)
+		}
+		if diff := cmp.Diff(want, got.Status.RolloutProgress); diff != "" {
+			t.Errorf("rollout progress after batch %d mismatch (-want +got):\n%s", batch, diff)
+		}
+		if gotPercentage := testutil.ToFloat64(metrics.WorkRolloutProgressPercentage.WithLabelValues(workKey.Namespace, workKey.Name)); gotPercentage != float64(want.ProgressPercentage) {
+			t.Errorf("fleet_work_rollout_progress_percentage after batch %d = %v, want %v", batch, gotPercentage, want.ProgressPercentage)
+		}
+
+		appliedCond := meta.FindStatusCondition(got.Status.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+		pendingCond := meta.FindStatusCondition(got.Status.ManifestConditions[29].Conditions, fleetv1beta1.WorkConditionTypeApplied)
+		if batch < 6 {
+			if appliedCond == nil || appliedCond.Status != metav1.ConditionFalse || appliedCond.Reason != workRolloutInProgressReason {
+				t.Errorf("work applied condition after batch %d = %+v, want the rollout in progress", batch, appliedCond)
+			}
+			if pendingCond == nil || pendingCond.Reason != ManifestBatchPendingReason {
+				t.Errorf("applied condition of the last manifest after batch %d = %+v, want it pending", batch, pendingCond)
+			}
+			if result.RequeueAfter != rolloutBatchRequeueDelay {
+				t.Errorf("Reconcile() of batch %d = %+v, want the next batch applied after %v", batch, result, rolloutBatchRequeueDelay)
+			}
+			continue
+		}
+		if appliedCond == nil || appliedCond.Status != metav1.ConditionTrue {
+			t.Errorf("work applied condition after the last batch = %+v, want True", appliedCond)
+		}
+	}
+}
+
+func TestPlanRollout(t *testing.T) {
+	manifests := deploymentManifests(t, "a", "b", "c", "d", "e")
+	tests := map[string]struct {
+		batchSize   int
+		generation  int64
+		progress    *fleetv1beta1.RolloutProgress
+		wantBatches [][]int
+		wantCurrent int
+		wantPending map[int]bool
+	}{
+		"not applied in batches": {},
+		"batch size covers all the manifests": {
+			batchSize: 5,
+		},
+		"first batch": {
+			batchSize:   2,
+			generation:  1,
+			wantBatches: [][]int{{0, 1}, {2, 3}, {4}},
+			wantPending: map[int]bool{2: true, 3: true, 4: true},
+		},
+		"next batch": {
+			batchSize:   2,
+			generation:  1,
+			progress:    &fleetv1beta1.RolloutProgress{ObservedGeneration: 1, CompletedBatches: 1},
+			wantBatches: [][]int{{0, 1}, {2, 3}, {4}},
+			wantCurrent: 1,
+			wantPending: map[int]bool{4: true},
+		},
+		"completed rollout applies all the batches": {
+			batchSize:   2,
+			generation:  1,
+			progress:    &fleetv1beta1.RolloutProgress{ObservedGeneration: 1, CompletedBatches: 3},
+			wantBatches: [][]int{{0, 1}, {2, 3}, {4}},
+			wantCurrent: 2,
+			wantPending: map[int]bool{},
+		},
+		"spec change starts over": {
+			batchSize:   2,
+			generation:  2,
+			progress:    &fleetv1beta1.RolloutProgress{ObservedGeneration: 1, CompletedBatches: 3},
+			wantBatches: [][]int{{0, 1}, {2, 3}, {4}},
+			wantPending: map[int]bool{2: true, 3: true, 4: true},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			work := &fleetv1beta1.Work{
+				ObjectMeta: metav1.ObjectMeta{Generation: tt.generation},
+				Spec: fleetv1beta1.WorkSpec{
+					Workload:      fleetv1beta1.WorkloadTemplate{Manifests: manifests},
+					ApplyStrategy: &fleetv1beta1.ApplyStrategy{BatchSize: tt.batchSize},
+				},
+				Status: fleetv1beta1.WorkStatus{RolloutProgress: tt.progress},
+			}
+			plan := planRollout(work)
+			if tt.wantBatches == nil {
+				if plan != nil {
+					t.Errorf("planRollout() = %+v, want nil", plan)
+				}
+				return
+			}
+			if diff := cmp.Diff(tt.wantBatches, plan.batches); diff != "" {
+				t.Errorf("planRollout() batches mismatch (-want +got):\n%s", diff)
+			}
+			if plan.current != tt.wantCurrent {
+				t.Errorf("planRollout() current batch = %d, want %d", plan.current, tt.wantCurrent)
+			}
+			if diff := cmp.Diff(tt.wantPending, plan.pendingOrdinals()); diff != "" {
+				t.Errorf("pendingOrdinals() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
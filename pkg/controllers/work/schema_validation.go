@@ -0,0 +1,105 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+// manifestSchema is the validation schema of the manifests of a kind.
+type manifestSchema struct {
+	// source describes where the schema is stored, for the error messages.
+	source    string
+	validator *validate.SchemaValidator
+	// loadErr is the error of loading the schema; every manifest of the kind fails the validation if it is set, so that
+	// the manifests are not applied unvalidated.
+	loadErr error
+}
+
+// manifestSchemas are the validation schemas of the manifests keyed by their kinds.
+type manifestSchemas map[schema.GroupVersionKind]*manifestSchema
+
+// schemaValidationError is the error of a manifest which does not match the validation schema of its kind.
+type schemaValidationError struct {
+	err error
+}
+
+func (e *schemaValidationError) Error() string {
+	return e.err.Error()
+}
+
+// loadValidationSchemas reads the validation schemas of the work from their ConfigMaps. The schemas are read again on
+// every reconcile so their changes are picked up by the next one.
+func (r *ApplyWorkReconciler) loadValidationSchemas(ctx context.Context, work *fleetv1beta1.Work) (manifestSchemas, error) {
+	if len(work.Spec.ValidationSchemas) == 0 {
+		return nil, nil
+	}
+	schemas := make(manifestSchemas, len(work.Spec.ValidationSchemas))
+	for _, ref := range work.Spec.ValidationSchemas {
+		gvk := schema.GroupVersionKind{Group: ref.Group, Version: ref.Version, Kind: ref.Kind}
+		configMapKey := types.NamespacedName{Name: ref.ConfigMapName, Namespace: work.Namespace}
+		s := &manifestSchema{source: fmt.Sprintf("key %s of ConfigMap %s", ref.Key, configMapKey)}
+		schemas[gvk] = s
+
+		var configMap corev1.ConfigMap
+		if err := r.client.Get(ctx, configMapKey, &configMap); err != nil {
+			if !apierrors.IsNotFound(err) {
+				klog.ErrorS(err, "Failed to get the validation schema", "work", klog.KObj(work), "configMap", configMapKey)
+				return nil, controller.NewAPIServerError(true, err)
+			}
+			s.loadErr = fmt.Errorf("the validation schema is not found: %w", err)
+			continue
+		}
+		raw, ok := configMap.Data[ref.Key]
+		if !ok {
+			s.loadErr = fmt.Errorf("the validation schema is not found: the ConfigMap %s has no key %s", configMapKey, ref.Key)
+			continue
+		}
+		var jsonSchema spec.Schema
+		if err := json.Unmarshal([]byte(raw), &jsonSchema); err != nil {
+			s.loadErr = fmt.Errorf("failed to decode the validation schema in %s: %w", s.source, err)
+			continue
+		}
+		s.validator = validate.NewSchemaValidator(&jsonSchema, nil, "", strfmt.Default)
+	}
+	return schemas, nil
+}
+
+// validate validates the manifest against the schema of its kind; the manifests of the kinds without a schema are
+// always valid.
+func (schemas manifestSchemas) validate(manifestObj *unstructured.Unstructured) error {
+	s, ok := schemas[manifestObj.GroupVersionKind()]
+	if !ok {
+		return nil
+	}
+	if s.loadErr != nil {
+		klog.ErrorS(s.loadErr, "Failed to load the validation schema of the manifest", "manifest", klog.KObj(manifestObj), "schema", s.source)
+		return &schemaValidationError{err: controller.NewUserError(s.loadErr)}
+	}
+	result := s.validator.Validate(manifestObj.Object)
+	if result.IsValid() {
+		return nil
+	}
+	err := fmt.Errorf("the manifest does not match the validation schema in %s: %w", s.source, utilerrors.NewAggregate(result.Errors))
+	klog.ErrorS(err, "The manifest failed the schema validation", "manifest", klog.KObj(manifestObj))
+	return &schemaValidationError{err: controller.NewUserError(err)}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// deploymentImageSchema requires every container of a deployment to set its image.
+const deploymentImageSchema = `{
+  "type": "object",
+  "properties": {
+    "spec": {
+      "type": "object",
+      "properties": {
+        "template": {
+          "type": "object",
+          "properties": {
+            "spec": {
+              "type": "object",
+              "properties": {
+                "containers": {
+                  "type": "array",
+                  "items": {"type": "object", "required": ["name", "image"]}
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+func TestLoadValidationSchemas(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the core scheme: %v", err)
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "schemas", Namespace: "fleet-member-test"},
+		Data:       map[string]string{"deployment": deploymentImageSchema, "broken": "{"},
+	}
+	r := &ApplyWorkReconciler{client: clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(configMap).Build()}
+
+	tests := map[string]struct {
+		ref         fleetv1beta1.ValidationSchemaRef
+		wantLoadErr string
+	}{
+		"schema is loaded": {
+			ref: fleetv1beta1.ValidationSchemaRef{ConfigMapName: "schemas", Key: "deployment"},
+		},
+		"missing ConfigMap": {
+			ref:         fleetv1beta1.ValidationSchemaRef{ConfigMapName: "missing", Key: "deployment"},
+			wantLoadErr: "the validation schema is not found",
+		},
+		"missing key": {
+			ref:         fleetv1beta1.ValidationSchemaRef{ConfigMapName: "schemas", Key: "missing"},
+			wantLoadErr: "has no key missing",
+		},
+		"invalid schema": {
+			ref:         fleetv1beta1.ValidationSchemaRef{ConfigMapName: "schemas", Key: "broken"},
+			wantLoadErr: "failed to decode the validation schema",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tt.ref.Group, tt.ref.Version, tt.ref.Kind = "apps", "v1", "Deployment"
+			work := &fleetv1beta1.Work{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: "fleet-member-test"},
+				Spec:       fleetv1beta1.WorkSpec{ValidationSchemas: []fleetv1beta1.ValidationSchemaRef{tt.ref}},
+			}
+			schemas, err := r.loadValidationSchemas(context.Background(), work)
+			if err != nil {
+				t.Fatalf("loadValidationSchemas() = %v, want no error", err)
+			}
+			// a schema which fails to load fails the validation of every manifest of its kind.
+			err = schemas.validate(podTemplateWorkload("apps/v1", "Deployment", ""))
+			if tt.wantLoadErr == "" {
+				if err != nil {
+					t.Errorf("validate() = %v, want no error", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantLoadErr) {
+				t.Errorf("validate() = %v, want an error containing %q", err, tt.wantLoadErr)
+			}
+		})
+	}
+}
+
+func TestApplyManifestsSchemaValidation(t *testing.T) {
+	valid := podTemplateWorkload("apps/v1", "Deployment", "")
+	withoutImage := podTemplateWorkload("apps/v1", "Deployment", "")
+	withoutImage.SetName("app-without-image")
+	if err := unstructured.SetNestedSlice(withoutImage.Object, []interface{}{map[string]interface{}{"name": "app"}},
+		"spec", "template", "spec", "containers"); err != nil {
+		t.Fatalf("failed to set the containers: %v", err)
+	}
+	var manifests []fleetv1beta1.Manifest
+	for _, obj := range []*unstructured.Unstructured{valid, withoutImage} {
+		raw, err := json.Marshal(obj)
+		if err != nil {
+			t.Fatalf("failed to marshal the deployment: %v", err)
+		}
+		manifests = append(manifests, fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: raw}})
+	}
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the core scheme: %v", err)
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "schemas", Namespace: "fleet-member-test"},
+		Data:       map[string]string{"deployment": deploymentImageSchema},
+	}
+	applier := &priorityClassRecordingApplier{}
+	r := &ApplyWorkReconciler{
+		client:     clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(configMap).Build(),
+		restMapper: testMapper{},
+		appliers:   map[fleetv1beta1.ApplyStrategyType]Applier{fleetv1beta1.ApplyStrategyTypeClientSideApply: applier},
+	}
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: "fleet-member-test"},
+		Spec: fleetv1beta1.WorkSpec{ValidationSchemas: []fleetv1beta1.ValidationSchemaRef{
+			{Group: "apps", Version: "v1", Kind: "Deployment", ConfigMapName: "schemas", Key: "deployment"},
+		}},
+	}
+	schemas, err := r.loadValidationSchemas(context.Background(), work)
+	if err != nil {
+		t.Fatalf("loadValidationSchemas() = %v, want no error", err)
+	}
+	applyStrategy := &fleetv1beta1.ApplyStrategy{Type: fleetv1beta1.ApplyStrategyTypeClientSideApply}
+
+	results := r.applyManifests(context.Background(), manifests, ownerRef, applyStrategy, nil, nil, "", nil, nil, schemas)
+	if results[0].applyErr != nil {
+		t.Errorf("applyManifests() of the valid deployment = %v, want no error", results[0].applyErr)
+	}
+	if results[1].applyErr == nil || !strings.Contains(results[1].applyErr.Error(), ".image in body is required") {
+		t.Errorf("applyManifests() of the deployment without image = %v, want the missing image reported", results[1].applyErr)
+	}
+	if results[1].identifier.Name != "app-without-image" {
+		t.Errorf("applyManifests() identifier of the invalid deployment = %+v, want it identified", results[1].identifier)
+	}
+	// the invalid deployment is never sent to the member cluster.
+	if got := len(applier.priorityClassNames); got != 1 {
+		t.Errorf("applyManifests() applied %d manifests, want 1", got)
+	}
+
+	applyCond := meta.FindStatusCondition(buildManifestCondition(results[1].applyErr, results[1].action, results[1].generation),
+		fleetv1beta1.WorkConditionTypeApplied)
+	if applyCond.Status != metav1.ConditionFalse || applyCond.Reason != string(fleetv1beta1.ManifestProcessingApplyResultTypeSchemaValidationFailed) {
+		t.Errorf("applied condition of the invalid deployment = %+v, want false with reason %s", applyCond,
+			fleetv1beta1.ManifestProcessingApplyResultTypeSchemaValidationFailed)
+	}
+}
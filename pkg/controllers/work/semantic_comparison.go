@@ -0,0 +1,150 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// ListKeyRegistry registers, per GVK, the list fields which the semantic comparison sorts before comparing the
+// resources. The list fields are keyed by their paths, where a "[]" suffix walks into every item of a list, e.g.
+// "spec.template.spec.containers[].env". The value is the field which uniquely identifies the items of the list, e.g.
+// "name"; the items of a list registered with an empty key are identified by their content. The lists which are not
+// registered keep their order, as the order matters for many of them, e.g. the args of a container.
+type ListKeyRegistry map[schema.GroupVersionKind]map[string]string
+
+// DefaultListKeyRegistry is the registry of the list fields sorted by the semantic comparison of the work applier. It
+// covers the pod templates of the built-in workloads and the ports of the services; more GVKs can be registered in it
+// before the work applier starts.
+var DefaultListKeyRegistry = ListKeyRegistry{
+	{Group: "", Version: "v1", Kind: "Pod"}:             podSpecListKeys("spec"),
+	{Group: "apps", Version: "v1", Kind: "Deployment"}:  podSpecListKeys("spec.template.spec"),
+	{Group: "apps", Version: "v1", Kind: "StatefulSet"}: podSpecListKeys("spec.template.spec"),
+	{Group: "apps", Version: "v1", Kind: "DaemonSet"}:   podSpecListKeys("spec.template.spec"),
+	{Group: "apps", Version: "v1", Kind: "ReplicaSet"}:  podSpecListKeys("spec.template.spec"),
+	{Group: "batch", Version: "v1", Kind: "Job"}:        podSpecListKeys("spec.template.spec"),
+	{Group: "batch", Version: "v1", Kind: "CronJob"}:    podSpecListKeys("spec.jobTemplate.spec.template.spec"),
+	{Group: "", Version: "v1", Kind: "Service"}:         {"spec.ports": "port"},
+}
+
+// podSpecListKeys returns the list fields of the pod spec at the path which are sorted by the semantic comparison.
+func podSpecListKeys(path string) map[string]string {
+	keys := map[string]string{
+		path + ".volumes":          "name",
+		path + ".imagePullSecrets": "name",
+		path + ".tolerations":      "",
+	}
+	for _, containers := range []string{"containers", "initContainers"} {
+		keys[path+"."+containers] = "name"
+		keys[path+"."+containers+"[].env"] = "name"
+		keys[path+"."+containers+"[].envFrom"] = ""
+		keys[path+"."+containers+"[].volumeMounts"] = "mountPath"
+	}
+	return keys
+}
+
+// comparableObject returns the object as the work applier compares it per the apply strategy, i.e. without the fields
+// at the ignored paths and, in the semantic comparison, with the list fields registered for its GVK sorted. The object
+// itself is returned if there is nothing to change.
+func comparableObject(obj map[string]interface{}, gvk schema.GroupVersionKind, applyStrategy *fleetv1beta1.ApplyStrategy) map[string]interface{} {
+	if applyStrategy == nil {
+		return obj
+	}
+	obj = withoutIgnoredPaths(obj, applyStrategy.IgnorePaths)
+	if applyStrategy.ComparisonOption == fleetv1beta1.ComparisonOptionTypeSemanticComparison {
+		obj = withSortedLists(obj, DefaultListKeyRegistry[gvk])
+	}
+	return obj
+}
+
+// withSortedLists returns a copy of the object with the items of the list fields sorted by the keys which identify
+// them. A list is kept in its order if any of its items cannot be identified, e.g. the key is missing or duplicated.
+func withSortedLists(obj map[string]interface{}, listKeys map[string]string) map[string]interface{} {
+	if len(listKeys) == 0 || obj == nil {
+		return obj
+	}
+	sorted := runtime.DeepCopyJSON(obj)
+	for path, key := range listKeys {
+		sortListAt(sorted, strings.Split(path, "."), key)
+	}
+	return sorted
+}
+
+// sortListAt sorts the items of the list field referred to by the path tokens in the value.
+func sortListAt(value interface{}, tokens []string, key string) {
+	node, ok := value.(map[string]interface{})
+	if !ok {
+		return
+	}
+	field, eachItem := strings.CutSuffix(tokens[0], "[]")
+	child, ok := node[field]
+	if !ok {
+		return
+	}
+	if len(tokens) == 1 {
+		if list, ok := child.([]interface{}); ok {
+			sortListItems(list, key)
+		}
+		return
+	}
+	if !eachItem {
+		sortListAt(child, tokens[1:], key)
+		return
+	}
+	list, _ := child.([]interface{})
+	for _, item := range list {
+		sortListAt(item, tokens[1:], key)
+	}
+}
+
+// sortListItems sorts the items of the list in place by the JSON of their key field, or of the whole items if the
+// key is empty.
+func sortListItems(list []interface{}, key string) {
+	itemKeys := make([]string, len(list))
+	seen := make(map[string]bool, len(list))
+	for i, item := range list {
+		identity := item
+		if key != "" {
+			itemMap, ok := item.(map[string]interface{})
+			if !ok {
+				return
+			}
+			if identity, ok = itemMap[key]; !ok {
+				return
+			}
+		}
+		itemKey, err := json.Marshal(identity)
+		if err != nil {
+			return
+		}
+		if key != "" && seen[string(itemKey)] {
+			return
+		}
+		seen[string(itemKey)] = true
+		itemKeys[i] = string(itemKey)
+	}
+	sort.Stable(&keyedList{items: list, keys: itemKeys})
+}
+
+// keyedList sorts the items of a list by their keys.
+type keyedList struct {
+	items []interface{}
+	keys  []string
+}
+
+func (l *keyedList) Len() int           { return len(l.items) }
+func (l *keyedList) Less(i, j int) bool { return l.keys[i] < l.keys[j] }
+func (l *keyedList) Swap(i, j int) {
+	l.items[i], l.items[j] = l.items[j], l.items[i]
+	l.keys[i], l.keys[j] = l.keys[j], l.keys[i]
+}
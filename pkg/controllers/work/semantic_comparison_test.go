@@ -0,0 +1,162 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// deploymentWithEnv returns a deployment whose container has the environment variables in the given order.
+func deploymentWithEnv(names ...string) *unstructured.Unstructured {
+	env := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		env = append(env, map[string]interface{}{"name": name, "value": name + "-value"})
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "web", "namespace": "default"},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "app", "image": "app:v1", "env": env, "args": []interface{}{"--b", "--a"}},
+					},
+				},
+			},
+		},
+	}}
+}
+
+func TestWithSortedLists(t *testing.T) {
+	tests := map[string]struct {
+		obj      map[string]interface{}
+		listKeys map[string]string
+		want     map[string]interface{}
+	}{
+		"items sorted by their key": {
+			obj: map[string]interface{}{"spec": map[string]interface{}{"ports": []interface{}{
+				map[string]interface{}{"port": int64(8080)},
+				map[string]interface{}{"port": int64(80)},
+			}}},
+			listKeys: map[string]string{"spec.ports": "port"},
+			want: map[string]interface{}{"spec": map[string]interface{}{"ports": []interface{}{
+				map[string]interface{}{"port": int64(80)},
+				map[string]interface{}{"port": int64(8080)},
+			}}},
+		},
+		"items sorted by their content": {
+			obj:      map[string]interface{}{"spec": map[string]interface{}{"accessModes": []interface{}{"ReadWriteOnce", "ReadOnlyMany"}}},
+			listKeys: map[string]string{"spec.accessModes": ""},
+			want:     map[string]interface{}{"spec": map[string]interface{}{"accessModes": []interface{}{"ReadOnlyMany", "ReadWriteOnce"}}},
+		},
+		"items of the lists in every list item": {
+			obj: map[string]interface{}{"containers": []interface{}{
+				map[string]interface{}{"name": "b", "env": []interface{}{map[string]interface{}{"name": "Y"}, map[string]interface{}{"name": "X"}}},
+				map[string]interface{}{"name": "a", "env": []interface{}{map[string]interface{}{"name": "Z"}, map[string]interface{}{"name": "W"}}},
+			}},
+			listKeys: map[string]string{"containers[].env": "name"},
+			want: map[string]interface{}{"containers": []interface{}{
+				map[string]interface{}{"name": "b", "env": []interface{}{map[string]interface{}{"name": "X"}, map[string]interface{}{"name": "Y"}}},
+				map[string]interface{}{"name": "a", "env": []interface{}{map[string]interface{}{"name": "W"}, map[string]interface{}{"name": "Z"}}},
+			}},
+		},
+		"items with a missing key are kept in order": {
+			obj:      map[string]interface{}{"volumes": []interface{}{map[string]interface{}{"name": "b"}, map[string]interface{}{"secret": "a"}}},
+			listKeys: map[string]string{"volumes": "name"},
+			want:     map[string]interface{}{"volumes": []interface{}{map[string]interface{}{"name": "b"}, map[string]interface{}{"secret": "a"}}},
+		},
+		"items with a duplicated key are kept in order": {
+			obj:      map[string]interface{}{"volumes": []interface{}{map[string]interface{}{"name": "b", "size": "2"}, map[string]interface{}{"name": "a"}, map[string]interface{}{"name": "b", "size": "1"}}},
+			listKeys: map[string]string{"volumes": "name"},
+			want:     map[string]interface{}{"volumes": []interface{}{map[string]interface{}{"name": "b", "size": "2"}, map[string]interface{}{"name": "a"}, map[string]interface{}{"name": "b", "size": "1"}}},
+		},
+		"missing and unregistered lists": {
+			obj:      map[string]interface{}{"args": []interface{}{"--b", "--a"}},
+			listKeys: map[string]string{"spec.ports": "port", "args.value": ""},
+			want:     map[string]interface{}{"args": []interface{}{"--b", "--a"}},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			original := runtime.DeepCopyJSON(tt.obj)
+			if diff := cmp.Diff(tt.want, withSortedLists(tt.obj, tt.listKeys)); diff != "" {
+				t.Errorf("withSortedLists() mismatch (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(original, tt.obj); diff != "" {
+				t.Errorf("withSortedLists() mutated the object (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsResourceUpToDateWithSemanticComparison(t *testing.T) {
+	manifestObj := deploymentWithEnv("A", "B", "C")
+	reordered := deploymentWithEnv("C", "A", "B")
+	changed := deploymentWithEnv("A", "B", "D")
+	semantic := &fleetv1beta1.ApplyStrategy{ComparisonOption: fleetv1beta1.ComparisonOptionTypeSemanticComparison}
+
+	tests := map[string]struct {
+		live          *unstructured.Unstructured
+		applyStrategy *fleetv1beta1.ApplyStrategy
+		want          bool
+	}{
+		"reordered env in the partial comparison": {
+			live:          reordered,
+			applyStrategy: &fleetv1beta1.ApplyStrategy{ComparisonOption: fleetv1beta1.ComparisonOptionTypePartialComparison},
+		},
+		"reordered env in the semantic comparison": {
+			live:          reordered,
+			applyStrategy: semantic,
+			want:          true,
+		},
+		"changed env in the semantic comparison": {
+			live:          changed,
+			applyStrategy: semantic,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := isResourceUpToDate(withUnchangedManifest(context.Background()), manifestObj, tt.live, tt.applyStrategy); got != tt.want {
+				t.Errorf("isResourceUpToDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffFieldsWithSemanticComparison(t *testing.T) {
+	live := deploymentWithEnv("B", "A")
+	desired := deploymentWithEnv("A", "B")
+	gvk := desired.GroupVersionKind()
+
+	tests := map[string]struct {
+		applyStrategy *fleetv1beta1.ApplyStrategy
+		want          []string
+	}{
+		"partial comparison": {
+			applyStrategy: &fleetv1beta1.ApplyStrategy{},
+			want:          []string{"spec.template.spec.containers"},
+		},
+		"semantic comparison": {
+			applyStrategy: &fleetv1beta1.ApplyStrategy{ComparisonOption: fleetv1beta1.ComparisonOptionTypeSemanticComparison},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := diffFields("", comparableObject(live.Object, gvk, tt.applyStrategy), comparableObject(desired.Object, gvk, tt.applyStrategy), nil)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diffFields() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
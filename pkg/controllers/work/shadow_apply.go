@@ -0,0 +1,70 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// shadowNamespaceFmt is the format of the name of the shadow namespace of a namespace.
+	shadowNamespaceFmt = "%s-shadow"
+)
+
+// redirectToShadowNamespace moves the manifest object into the shadow namespace of its namespace and makes sure the
+// shadow namespace exists in the member cluster.
+// A namespace object is renamed to its shadow namespace while the other cluster scoped objects cannot be shadow applied.
+func (r *ApplyWorkReconciler) redirectToShadowNamespace(ctx context.Context, obj *unstructured.Unstructured, owner metav1.OwnerReference) error {
+	gvk := obj.GroupVersionKind()
+	switch {
+	case gvk.Group == "" && gvk.Kind == "Namespace":
+		obj.SetName(fmt.Sprintf(shadowNamespaceFmt, obj.GetName()))
+		return nil
+	case obj.GetNamespace() == "":
+		return controller.NewUserError(fmt.Errorf("cluster scoped resource %s %s cannot be shadow applied", gvk, obj.GetName()))
+	}
+
+	shadowNamespace := fmt.Sprintf(shadowNamespaceFmt, obj.GetNamespace())
+	obj.SetNamespace(shadowNamespace)
+	return r.ensureShadowNamespace(ctx, shadowNamespace, owner)
+}
+
+// ensureShadowNamespace creates the shadow namespace if it does not exist yet.
+// The shadow namespace is owned by the appliedWork so that it is garbage collected together with the work.
+func (r *ApplyWorkReconciler) ensureShadowNamespace(ctx context.Context, name string, owner metav1.OwnerReference) error {
+	var ns corev1.Namespace
+	err := r.spokeClient.Get(ctx, types.NamespacedName{Name: name}, &ns)
+	switch {
+	case err == nil:
+		return nil
+	case !apierrors.IsNotFound(err):
+		klog.ErrorS(err, "Failed to get the shadow namespace", "namespace", name)
+		return controller.NewAPIServerError(false, err)
+	}
+
+	ns = corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			OwnerReferences: []metav1.OwnerReference{owner},
+		},
+	}
+	if err := r.spokeClient.Create(ctx, &ns); err != nil && !apierrors.IsAlreadyExists(err) {
+		klog.ErrorS(err, "Failed to create the shadow namespace", "namespace", name)
+		return controller.NewAPIServerError(false, err)
+	}
+	klog.V(2).InfoS("Created the shadow namespace", "namespace", name)
+	return nil
+}
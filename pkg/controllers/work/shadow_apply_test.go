@@ -0,0 +1,88 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+func TestRedirectToShadowNamespace(t *testing.T) {
+	owner := metav1.OwnerReference{
+		APIVersion: "placement.kubernetes-fleet.io/v1beta1",
+		Kind:       "AppliedWork",
+		Name:       "test-work",
+		UID:        "test-uid",
+	}
+	tests := map[string]struct {
+		obj                 *unstructured.Unstructured
+		wantName            string
+		wantNamespace       string
+		wantShadowNamespace string
+		wantErr             error
+	}{
+		"namespaced object is moved to the shadow namespace": {
+			obj: &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata":   map[string]interface{}{"name": "cm", "namespace": "app"},
+			}},
+			wantName:            "cm",
+			wantNamespace:       "app-shadow",
+			wantShadowNamespace: "app-shadow",
+		},
+		"namespace object is renamed to the shadow namespace": {
+			obj: &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Namespace",
+				"metadata":   map[string]interface{}{"name": "app"},
+			}},
+			wantName: "app-shadow",
+		},
+		"cluster scoped object cannot be shadow applied": {
+			obj: &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "rbac.authorization.k8s.io/v1",
+				"kind":       "ClusterRole",
+				"metadata":   map[string]interface{}{"name": "role"},
+			}},
+			wantName: "role",
+			wantErr:  controller.ErrUserError,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			spokeClient := fake.NewClientBuilder().Build()
+			r := &ApplyWorkReconciler{spokeClient: spokeClient}
+			err := r.redirectToShadowNamespace(context.Background(), tt.obj, owner)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("redirectToShadowNamespace() = %v, want %v", err, tt.wantErr)
+			}
+			if tt.obj.GetName() != tt.wantName || tt.obj.GetNamespace() != tt.wantNamespace {
+				t.Errorf("redirectToShadowNamespace() object = %s/%s, want %s/%s", tt.obj.GetNamespace(), tt.obj.GetName(), tt.wantNamespace, tt.wantName)
+			}
+			if tt.wantShadowNamespace == "" {
+				return
+			}
+			var ns corev1.Namespace
+			if err := spokeClient.Get(context.Background(), types.NamespacedName{Name: tt.wantShadowNamespace}, &ns); err != nil {
+				t.Fatalf("failed to get the shadow namespace: %v", err)
+			}
+			if diff := cmp.Diff([]metav1.OwnerReference{owner}, ns.OwnerReferences); diff != "" {
+				t.Errorf("shadow namespace owner references mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
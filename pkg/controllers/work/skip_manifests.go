@@ -0,0 +1,80 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	// WorkSkipManifestOrdinalsAnnotation is the annotation to skip applying the manifests of a work temporarily, e.g.
+	// a broken update of a manifest, without removing them from the work. Its value is a comma separated list of the
+	// ordinals of the manifests to skip, e.g. "1,3". The resources applied before by the skipped manifests are kept.
+	WorkSkipManifestOrdinalsAnnotation = "fleet.azure.com/skip-manifest-ordinals"
+
+	// ManifestSkippedByAnnotationReason is the reason string of the manifest conditions when the manifest is skipped
+	// per the skip-manifest-ordinals annotation of the work.
+	ManifestSkippedByAnnotationReason = "ManifestSkippedByAnnotation"
+	// workManifestsSkippedReason is the reason string of the work applied condition when some manifests are skipped
+	// per the skip-manifest-ordinals annotation of the work.
+	workManifestsSkippedReason = "WorkManifestsSkipped"
+)
+
+// skippedManifestOrdinals returns the ordinals of the manifests to skip per the annotation of the work.
+// The invalid ordinals are ignored.
+func skippedManifestOrdinals(work *fleetv1beta1.Work) map[int]bool {
+	value, ok := work.GetAnnotations()[WorkSkipManifestOrdinalsAnnotation]
+	if !ok {
+		return nil
+	}
+	skipped := make(map[int]bool)
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		ordinal, err := strconv.Atoi(field)
+		if err != nil || ordinal < 0 {
+			klog.ErrorS(err, "Ignoring the invalid manifest ordinal to skip", "work", klog.KObj(work), "ordinal", field)
+			continue
+		}
+		skipped[ordinal] = true
+	}
+	return skipped
+}
+
+// skippedApplyResult returns the result of a manifest which is skipped per the annotation of the work.
+// The manifest is still identified so that the resource it applied before is not garbage collected.
+func (r *ApplyWorkReconciler) skippedApplyResult(index int, manifest fleetv1beta1.Manifest) applyResult {
+	result := applyResult{
+		identifier: fleetv1beta1.WorkResourceIdentifier{Ordinal: index},
+		action:     manifestSkippedAction,
+	}
+	if gvr, rawObj, err := r.decodeManifest(manifest); err == nil {
+		result.identifier = buildResourceIdentifier(index, rawObj, gvr)
+	}
+	return result
+}
+
+// skippedOrdinals returns the ordinals of the manifests which are skipped per the annotation of the work, in order.
+func skippedOrdinals(manifestConditions []fleetv1beta1.ManifestCondition) []int {
+	var skipped []int
+	for _, manifestCond := range manifestConditions {
+		applyCond := meta.FindStatusCondition(manifestCond.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+		if applyCond != nil && applyCond.Reason == ManifestSkippedByAnnotationReason {
+			skipped = append(skipped, manifestCond.Identifier.Ordinal)
+		}
+	}
+	sort.Ints(skipped)
+	return skipped
+}
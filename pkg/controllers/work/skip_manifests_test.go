@@ -0,0 +1,123 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func TestSkippedManifestOrdinals(t *testing.T) {
+	tests := map[string]struct {
+		annotations map[string]string
+		want        map[int]bool
+	}{
+		"no annotation": {
+			want: nil,
+		},
+		"ordinals to skip": {
+			annotations: map[string]string{WorkSkipManifestOrdinalsAnnotation: "1, 3"},
+			want:        map[int]bool{1: true, 3: true},
+		},
+		"invalid ordinals are ignored": {
+			annotations: map[string]string{WorkSkipManifestOrdinalsAnnotation: "0,x,-1,,2"},
+			want:        map[int]bool{0: true, 2: true},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			work := &fleetv1beta1.Work{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+			if diff := cmp.Diff(tt.want, skippedManifestOrdinals(work)); diff != "" {
+				t.Errorf("skippedManifestOrdinals() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyManifestsSkipped(t *testing.T) {
+	var manifests []fleetv1beta1.Manifest
+	for i := 0; i < 3; i++ {
+		raw, err := json.Marshal(liveDeployment(fmt.Sprintf("deploy-%d", i), ""))
+		if err != nil {
+			t.Fatalf("failed to marshal the deployment: %v", err)
+		}
+		manifests = append(manifests, fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: raw}})
+	}
+	applyStrategy := &fleetv1beta1.ApplyStrategy{Type: fleetv1beta1.ApplyStrategyTypeClientSideApply}
+	skippedMeta := appliedDeploymentMeta("deploy-1", "deploy-1-uid")
+	skippedMeta.Ordinal = 1
+	skippedMeta.Resource = "deployments"
+	work := &fleetv1beta1.Work{ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: "fleet-member-test"}}
+	// the resource of the skipped manifest was applied by an earlier reconcile.
+	appliedWork := &fleetv1beta1.AppliedWork{
+		Status: fleetv1beta1.AppliedWorkStatus{AppliedResources: []fleetv1beta1.AppliedResourceMeta{skippedMeta}},
+	}
+
+	applier := &recordingApplier{}
+	r := &ApplyWorkReconciler{
+		restMapper: testMapper{},
+		appliers:   map[fleetv1beta1.ApplyStrategyType]Applier{fleetv1beta1.ApplyStrategyTypeClientSideApply: applier},
+		spokeDynamicClient: fake.NewSimpleDynamicClient(runtime.NewScheme(),
+			liveDeployment("deploy-0", "deploy-0-uid"), liveDeployment("deploy-1", "deploy-1-uid"), liveDeployment("deploy-2", "deploy-2-uid")),
+	}
+	results := r.applyManifests(context.Background(), manifests, ownerRef, applyStrategy, nil, nil, "", map[int]bool{1: true}, nil, nil)
+	if len(applier.namespaces) != 2 {
+		t.Errorf("applyManifests() applied %d manifests, want 2", len(applier.namespaces))
+	}
+	if results[1].action != manifestSkippedAction || results[1].identifier.Name != "deploy-1" {
+		t.Errorf("applyManifests() result of the skipped manifest = %+v, want the identified manifest skipped", results[1])
+	}
+	if errs := constructWorkCondition(results, work); len(errs) != 0 {
+		t.Fatalf("constructWorkCondition() = %v, want no error", errs)
+	}
+	manifestApplyCond := meta.FindStatusCondition(work.Status.ManifestConditions[1].Conditions, fleetv1beta1.WorkConditionTypeApplied)
+	if manifestApplyCond == nil || manifestApplyCond.Reason != ManifestSkippedByAnnotationReason {
+		t.Errorf("applied condition of the skipped manifest = %+v, want reason %s", manifestApplyCond, ManifestSkippedByAnnotationReason)
+	}
+	workApplyCond := meta.FindStatusCondition(work.Status.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+	wantMessage := fmt.Sprintf("The manifests with ordinals [1] are skipped per the %s annotation", WorkSkipManifestOrdinalsAnnotation)
+	if workApplyCond == nil || workApplyCond.Status != metav1.ConditionFalse || workApplyCond.Reason != workManifestsSkippedReason ||
+		workApplyCond.Message != wantMessage {
+		t.Errorf("work applied condition = %+v, want %s with reason %s and message %q", workApplyCond, metav1.ConditionFalse,
+			workManifestsSkippedReason, wantMessage)
+	}
+
+	// the resource of the skipped manifest is neither garbage collected nor untracked.
+	newRes, staleRes, err := r.generateDiff(context.Background(), work, appliedWork)
+	if err != nil {
+		t.Fatalf("generateDiff() = %v, want no error", err)
+	}
+	if len(staleRes) != 0 {
+		t.Errorf("generateDiff() stale resources = %v, want none", staleRes)
+	}
+	var tracked []string
+	for _, res := range newRes {
+		tracked = append(tracked, string(res.UID))
+	}
+	if diff := cmp.Diff([]string{"deploy-0-uid", "deploy-1-uid", "deploy-2-uid"}, tracked); diff != "" {
+		t.Errorf("generateDiff() tracked resources mismatch (-want +got):\n%s", diff)
+	}
+
+	// the manifest is applied once the annotation is removed.
+	applier.namespaces = nil
+	results = r.applyManifests(context.Background(), manifests, ownerRef, applyStrategy, nil, nil, "", skippedManifestOrdinals(work), nil, nil)
+	if len(applier.namespaces) != 3 {
+		t.Errorf("applyManifests() applied %d manifests after the annotation is removed, want 3", len(applier.namespaces))
+	}
+	if results[1].action == manifestSkippedAction || results[1].applyErr != nil {
+		t.Errorf("applyManifests() result of the manifest = %+v, want it applied", results[1])
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// DefaultSlowReconcileThreshold is how long a work reconcile takes before it is profiled by default.
+	DefaultSlowReconcileThreshold = 5 * time.Second
+	// DefaultMaxProfileFiles is the number of the profiles kept in the profile directory by default.
+	DefaultMaxProfileFiles = 10
+
+	// profileFileSuffix is the suffix of the names of the profile files.
+	profileFileSuffix = ".prof"
+	// profileUploadTimeout is the timeout of uploading a profile to the object storage.
+	profileUploadTimeout = 30 * time.Second
+)
+
+// SlowReconcileProfiler captures a CPU profile of the work reconciles which take longer than the threshold.
+// Once a reconcile reaches the threshold, the CPU profiling starts and the rest of the reconcile is profiled; the
+// profile is stored as {workName}-{timestamp}.prof when the reconcile ends. The Go runtime profiles one thing at a
+// time, so the reconciles which reach the threshold while another one is profiled are not profiled.
+type SlowReconcileProfiler struct {
+	threshold time.Duration
+	// dir is the directory the profiles are written to, e.g. a mounted PVC; it is empty if they are uploaded.
+	dir string
+	// uploadURL is the object storage endpoint the profiles are uploaded to with PUT; it is nil if they are written
+	// to the directory.
+	uploadURL *url.URL
+	// maxFiles is the number of the profiles kept in the directory; the oldest ones are removed beyond it.
+	maxFiles int
+
+	// mu guards the CPU profiling of the process, which only one reconcile can hold at a time.
+	mu sync.Mutex
+}
+
+// NewSlowReconcileProfiler returns a profiler which stores the profiles of the work reconciles slower than the
+// threshold at the storage URL: a local directory as a path or a file:// URL, or an object storage endpoint as an
+// http:// or https:// URL. At most maxFiles profiles are kept in a local directory.
+func NewSlowReconcileProfiler(threshold time.Duration, storageURL string, maxFiles int) (*SlowReconcileProfiler, error) {
+	if threshold <= 0 {
+		return nil, fmt.Errorf("the slow reconcile threshold must be positive, got %v", threshold)
+	}
+	if maxFiles <= 0 {
+		return nil, fmt.Errorf("the maximum number of the profile files must be positive, got %d", maxFiles)
+	}
+	p := &SlowReconcileProfiler{threshold: threshold, maxFiles: maxFiles}
+	u, err := url.Parse(storageURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid profile storage URL %q: %w", storageURL, err)
+	}
+	switch u.Scheme {
+	case "http", "https":
+		p.uploadURL = u
+	case "file":
+		p.dir = u.Path
+	case "":
+		p.dir = storageURL
+	default:
+		return nil, fmt.Errorf("unsupported scheme %q of the profile storage URL %q", u.Scheme, storageURL)
+	}
+	if p.uploadURL == nil {
+		if p.dir == "" {
+			return nil, fmt.Errorf("the profile storage URL is empty")
+		}
+		if err := os.MkdirAll(p.dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create the profile directory %q: %w", p.dir, err)
+		}
+	}
+	return p, nil
+}
+
+// watch starts watching a reconcile of the work and returns the function to call once the reconcile ends, which
+// stores the profile if the reconcile reached the threshold. It is a no-op if the profiler is nil.
+func (p *SlowReconcileProfiler) watch(ctx context.Context, workName string) func() {
+	if p == nil {
+		return func() {}
+	}
+	var (
+		mu        sync.Mutex
+		done      bool
+		profiling bool
+		buf       bytes.Buffer
+	)
+	timer := time.AfterFunc(p.threshold, func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if done || !p.mu.TryLock() {
+			return
+		}
+		if err := pprof.StartCPUProfile(&buf); err != nil {
+			p.mu.Unlock()
+			klog.V(2).InfoS("Failed to start profiling the slow reconcile", "work", workName, "err", err)
+			return
+		}
+		profiling = true
+		klog.V(2).InfoS("Profiling the slow reconcile", "work", workName, "threshold", p.threshold)
+	})
+	return func() {
+		timer.Stop()
+		mu.Lock()
+		defer mu.Unlock()
+		done = true
+		if !profiling {
+			return
+		}
+		pprof.StopCPUProfile()
+		p.mu.Unlock()
+		name := fmt.Sprintf("%s-%s%s", workName, time.Now().UTC().Format("20060102T150405.000Z"), profileFileSuffix)
+		if err := p.store(ctx, name, buf.Bytes()); err != nil {
+			klog.ErrorS(err, "Failed to store the profile of the slow reconcile", "work", workName, "profile", name)
+			return
+		}
+		klog.InfoS("Stored the profile of the slow reconcile", "work", workName, "profile", name)
+	}
+}
+
+// store writes the profile to the directory, removing the oldest profiles beyond the limit, or uploads it to the
+// object storage.
+func (p *SlowReconcileProfiler) store(ctx context.Context, name string, profile []byte) error {
+	if p.uploadURL != nil {
+		return p.upload(ctx, name, profile)
+	}
+	if err := os.WriteFile(filepath.Join(p.dir, name), profile, 0o644); err != nil {
+		return err
+	}
+	return p.prune()
+}
+
+// upload puts the profile under its name at the object storage endpoint.
+func (p *SlowReconcileProfiler) upload(ctx context.Context, name string, profile []byte) error {
+	target := *p.uploadURL
+	target.Path = path.Join(target.Path, name)
+	uploadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), profileUploadTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(uploadCtx, http.MethodPut, target.String(), bytes.NewReader(profile))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("the profile storage responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// prune removes the oldest profiles in the directory so that at most maxFiles of them are kept.
+func (p *SlowReconcileProfiler) prune() error {
+	entries, err := os.ReadDir(p.dir)
+	if err != nil {
+		return err
+	}
+	type profileFile struct {
+		name    string
+		modTime time.Time
+	}
+	var files []profileFile
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), profileFileSuffix) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, profileFile{name: entry.Name(), modTime: info.ModTime()})
+	}
+	if len(files) <= p.maxFiles {
+		return nil
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].modTime.Equal(files[j].modTime) {
+			return files[i].name < files[j].name
+		}
+		return files[i].modTime.Before(files[j].modTime)
+	})
+	for _, file := range files[:len(files)-p.maxFiles] {
+		if err := os.Remove(filepath.Join(p.dir, file.name)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/atomic"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// slowApplier applies the manifests as they are after the delay.
+type slowApplier struct {
+	delay time.Duration
+}
+
+func (a *slowApplier) ApplyUnstructured(_ context.Context, _ *fleetv1beta1.ApplyStrategy, _ schema.GroupVersionResource,
+	manifestObj *unstructured.Unstructured) (*unstructured.Unstructured, ApplyAction, error) {
+	time.Sleep(a.delay)
+	applied := manifestObj.DeepCopy()
+	applied.SetUID("deploy-uid")
+	return applied, manifestCreatedAction, nil
+}
+
+// reconcileWithApplyDelay reconciles a new work whose manifest takes the delay to apply with the profiler.
+func reconcileWithApplyDelay(t *testing.T, profiler *SlowReconcileProfiler, delay time.Duration) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the fleet scheme: %v", err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the core scheme: %v", err)
+	}
+	workKey := types.NamespacedName{Name: "test-work", Namespace: "fleet-member-test"}
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: workKey.Name, Namespace: workKey.Namespace, Generation: 1},
+		Spec:       fleetv1beta1.WorkSpec{Workload: versionedWorkload(t, "v1")},
+	}
+	applier := &slowApplier{delay: delay}
+	r := &ApplyWorkReconciler{
+		client:             fake.NewClientBuilder().WithScheme(scheme).WithObjects(work).WithStatusSubresource(&fleetv1beta1.Work{}).Build(),
+		spokeDynamicClient: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), liveDeployment("deploy", "deploy-uid")),
+		spokeClient:        fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&fleetv1beta1.AppliedWork{}).Build(),
+		restMapper:         testMapper{},
+		recorder:           record.NewFakeRecorder(100),
+		joined:             atomic.NewBool(true),
+		appliers: map[fleetv1beta1.ApplyStrategyType]Applier{
+			fleetv1beta1.ApplyStrategyTypeClientSideApply: applier,
+			fleetv1beta1.ApplyStrategyTypeServerSideApply: applier,
+		},
+		profiler: profiler,
+	}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: workKey}); err != nil {
+		t.Fatalf("Reconcile() = %v, want no error", err)
+	}
+}
+
+// profileFiles returns the names of the profiles in the directory.
+func profileFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read the profile directory: %v", err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+func TestSlowReconcileProfiler(t *testing.T) {
+	dir := t.TempDir()
+	profiler, err := NewSlowReconcileProfiler(50*time.Millisecond, "file://"+dir, 2)
+	if err != nil {
+		t.Fatalf("NewSlowReconcileProfiler() = %v, want no error", err)
+	}
+
+	// the reconciles within the threshold are not profiled.
+	reconcileWithApplyDelay(t, profiler, 0)
+	if got := profileFiles(t, dir); len(got) != 0 {
+		t.Fatalf("profiles = %v, want none", got)
+	}
+
+	// the reconcile beyond the threshold is profiled.
+	reconcileWithApplyDelay(t, profiler, 300*time.Millisecond)
+	got := profileFiles(t, dir)
+	if len(got) != 1 || !strings.HasPrefix(got[0], "test-work-") || !strings.HasSuffix(got[0], ".prof") {
+		t.Fatalf("profiles = %v, want a single test-work-{timestamp}.prof", got)
+	}
+	if info, err := os.Stat(filepath.Join(dir, got[0])); err != nil || info.Size() == 0 {
+		t.Errorf("profile %s = %v, %v, want a non-empty file", got[0], info, err)
+	}
+
+	// the oldest profiles are removed beyond the limit.
+	for i := 0; i < 2; i++ {
+		time.Sleep(10 * time.Millisecond)
+		reconcileWithApplyDelay(t, profiler, 300*time.Millisecond)
+	}
+	if kept := profileFiles(t, dir); len(kept) != 2 || kept[0] == got[0] || kept[1] == got[0] {
+		t.Errorf("profiles = %v, want the latest 2 without %s", kept, got[0])
+	}
+}
+
+func TestSlowReconcileProfilerUpload(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		uploaded []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if req.Method != http.MethodPut {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		uploaded = append(uploaded, req.URL.Path)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+	profiler, err := NewSlowReconcileProfiler(50*time.Millisecond, server.URL+"/profiles", DefaultMaxProfileFiles)
+	if err != nil {
+		t.Fatalf("NewSlowReconcileProfiler() = %v, want no error", err)
+	}
+
+	reconcileWithApplyDelay(t, profiler, 300*time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	if len(uploaded) != 1 || !strings.HasPrefix(uploaded[0], "/profiles/test-work-") || !strings.HasSuffix(uploaded[0], ".prof") {
+		t.Errorf("uploaded profiles = %v, want a single /profiles/test-work-{timestamp}.prof", uploaded)
+	}
+}
+
+func TestNewSlowReconcileProfiler(t *testing.T) {
+	tests := map[string]struct {
+		threshold  time.Duration
+		storageURL string
+		maxFiles   int
+		wantErr    bool
+	}{
+		"directory path": {
+			threshold:  time.Second,
+			storageURL: t.TempDir(),
+			maxFiles:   1,
+		},
+		"object storage endpoint": {
+			threshold:  time.Second,
+			storageURL: "https://storage.example.com/profiles",
+			maxFiles:   1,
+		},
+		"unsupported scheme": {
+			threshold:  time.Second,
+			storageURL: "s3://profiles",
+			maxFiles:   1,
+			wantErr:    true,
+		},
+		"no threshold": {
+			storageURL: t.TempDir(),
+			maxFiles:   1,
+			wantErr:    true,
+		},
+		"no profile files": {
+			threshold:  time.Second,
+			storageURL: t.TempDir(),
+			wantErr:    true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewSlowReconcileProfiler(tt.threshold, tt.storageURL, tt.maxFiles)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Errorf("NewSlowReconcileProfiler() = %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// pruneStaleManifestConditions removes the manifest conditions whose ordinals are no longer in the manifests of the
+// work and saves the status right away. The work applier rewrites all the manifest conditions once the work is
+// applied, but the conditions of the removed manifests linger if the agent crashes between the garbage collection of
+// their resources and the status update, or if the work does not get to be applied, e.g. while it waits for approval.
+// The work must be decompressed; it is decompressed again after the status update.
+func (r *ApplyWorkReconciler) pruneStaleManifestConditions(ctx context.Context, work *fleetv1beta1.Work) error {
+	stale := staleManifestOrdinals(work)
+	if len(stale) == 0 {
+		return nil
+	}
+	kept := make([]fleetv1beta1.ManifestCondition, 0, len(work.Status.ManifestConditions)-len(stale))
+	for _, manifestCond := range work.Status.ManifestConditions {
+		if isManifestOrdinal(work, manifestCond.Identifier.Ordinal) {
+			kept = append(kept, manifestCond)
+		}
+	}
+	work.Status.ManifestConditions = kept
+	klog.V(2).InfoS("Pruning the manifest conditions of the removed manifests", "work", klog.KObj(work), "ordinals", stale)
+	if err := r.updateWorkStatusIfChanged(ctx, work); err != nil {
+		klog.ErrorS(err, "Failed to prune the stale manifest conditions of the work", "work", klog.KObj(work))
+		return err
+	}
+	// the status update overwrites the work with the stored one.
+	return r.decompressWork(work)
+}
+
+// staleManifestOrdinals returns the ordinals of the manifest conditions which match no manifest of the work, in the
+// order of the conditions.
+func staleManifestOrdinals(work *fleetv1beta1.Work) []int {
+	var stale []int
+	for _, manifestCond := range work.Status.ManifestConditions {
+		if !isManifestOrdinal(work, manifestCond.Identifier.Ordinal) {
+			stale = append(stale, manifestCond.Identifier.Ordinal)
+		}
+	}
+	return stale
+}
+
+// isManifestOrdinal returns true if the ordinal is the ordinal of a manifest of the work.
+func isManifestOrdinal(work *fleetv1beta1.Work, ordinal int) bool {
+	return ordinal >= 0 && ordinal < len(work.Spec.Workload.Manifests)
+}
@@ -0,0 +1,77 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func TestPruneStaleManifestConditions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the fleet scheme: %v", err)
+	}
+	manifestCondition := func(ordinal int, name string) fleetv1beta1.ManifestCondition {
+		return fleetv1beta1.ManifestCondition{
+			Identifier: fleetv1beta1.WorkResourceIdentifier{Ordinal: ordinal, Group: "apps", Version: "v1", Kind: "Deployment", Name: name, Namespace: "default"},
+			Conditions: []metav1.Condition{{Type: fleetv1beta1.WorkConditionTypeApplied, Status: metav1.ConditionTrue, Reason: string(manifestCreatedAction)}},
+		}
+	}
+	// the work had the manifests web, api and db; api and db are removed and their resources are garbage collected,
+	// but the agent crashed before the status is updated.
+	workKey := types.NamespacedName{Name: "test-work", Namespace: "fleet-member-test"}
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: workKey.Name, Namespace: workKey.Namespace, Generation: 2},
+		Spec:       fleetv1beta1.WorkSpec{Workload: fleetv1beta1.WorkloadTemplate{Manifests: deploymentManifests(t, "web")}},
+		Status: fleetv1beta1.WorkStatus{
+			ManifestConditions: []fleetv1beta1.ManifestCondition{manifestCondition(0, "web"), manifestCondition(1, "api"), manifestCondition(2, "db")},
+		},
+	}
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(work).WithStatusSubresource(&fleetv1beta1.Work{}).Build()
+	r := &ApplyWorkReconciler{client: hubClient}
+
+	if got, want := staleManifestOrdinals(work), []int{1, 2}; !cmp.Equal(got, want) {
+		t.Errorf("staleManifestOrdinals() = %v, want %v", got, want)
+	}
+	if err := r.pruneStaleManifestConditions(context.Background(), work); err != nil {
+		t.Fatalf("pruneStaleManifestConditions() = %v, want no error", err)
+	}
+	want := []fleetv1beta1.ManifestCondition{manifestCondition(0, "web")}
+	if diff := cmp.Diff(want, work.Status.ManifestConditions); diff != "" {
+		t.Errorf("manifest conditions mismatch (-want, +got):\n%s", diff)
+	}
+	if len(work.Spec.Workload.Manifests) != 1 {
+		t.Errorf("manifests = %d, want the manifests of the work kept", len(work.Spec.Workload.Manifests))
+	}
+	var stored fleetv1beta1.Work
+	if err := hubClient.Get(context.Background(), workKey, &stored); err != nil {
+		t.Fatalf("failed to get the work: %v", err)
+	}
+	if diff := cmp.Diff(want, stored.Status.ManifestConditions); diff != "" {
+		t.Errorf("stored manifest conditions mismatch (-want, +got):\n%s", diff)
+	}
+
+	// nothing is updated once the stale conditions are gone.
+	resourceVersion := stored.ResourceVersion
+	if err := r.pruneStaleManifestConditions(context.Background(), &stored); err != nil {
+		t.Fatalf("pruneStaleManifestConditions() = %v, want no error", err)
+	}
+	if err := hubClient.Get(context.Background(), workKey, &stored); err != nil {
+		t.Fatalf("failed to get the work: %v", err)
+	}
+	if stored.ResourceVersion != resourceVersion {
+		t.Errorf("work resource version = %s, want unchanged %s", stored.ResourceVersion, resourceVersion)
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/history"
+)
+
+// conditionHistoryCompactor keeps the most recent transitions of each condition type of the works.
+var conditionHistoryCompactor = history.NewConditionHistoryCompactor(fleetv1beta1.MaxConditionHistory)
+
+// updateWorkStatusIfChanged updates the work status on the hub cluster only if it differs from the status the work
+// was fetched with, which is detected by comparing the hash of the computed status against the stored StatusHash.
+// The new hash is written in the same update call.
+func (r *ApplyWorkReconciler) updateWorkStatusIfChanged(ctx context.Context, work *fleetv1beta1.Work) error {
+	// only the transitions are recorded, so the history does not change the hash of an otherwise unchanged status.
+	work.Status.ConditionHistory = conditionHistoryCompactor.Record(work.Status.ConditionHistory, work.Status.Conditions, metav1.Now())
+	hash, err := computeStatusHash(&work.Status)
+	if err != nil {
+		return err
+	}
+	if hash == work.Status.StatusHash {
+		klog.V(2).InfoS("Skip updating the unchanged work status", "work", klog.KObj(work))
+		return nil
+	}
+	work.Status.StatusHash = hash
+	if r.statusPageSize > 0 || len(work.Status.WorkStatusPageRef) > 0 {
+		return r.updatePaginatedWorkStatus(ctx, work)
+	}
+	return r.client.Status().Update(ctx, work, &client.SubResourceUpdateOptions{})
+}
+
+// computeStatusHash returns the SHA-256 hash of the work status excluding the hash itself.
+// The apply timings of the manifests change on every apply so they are excluded too; they are refreshed together
+// with the next status change, and so is the processed resource version which would otherwise change on every update.
+// The status pagination is derived from the manifest conditions so it is excluded as well.
+func computeStatusHash(status *fleetv1beta1.WorkStatus) (string, error) {
+	s := status.DeepCopy()
+	s.StatusHash = ""
+	s.ProcessedResourceVersion = ""
+	s.StatusPageCount = 0
+	s.WorkStatusPageRef = nil
+	for i := range s.ManifestConditions {
+		s.ManifestConditions[i].ApplyStartedAt = nil
+		s.ManifestConditions[i].ApplyCompletedAt = nil
+		s.ManifestConditions[i].ApplyDurationMs = 0
+	}
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the work status: %w", err)
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(raw)), nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// newStatusUpdateCountingClient returns a fake hub client holding the works which counts the work status updates.
+func newStatusUpdateCountingClient(t testing.TB, works []client.Object, statusUpdates *int) client.Client {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(works...).
+		WithStatusSubresource(&fleetv1beta1.Work{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				*statusUpdates++
+				return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+}
+
+// reconcileWorkStatus mimics the status handling of a reconcile loop of a work whose manifests are all available.
+func reconcileWorkStatus(t testing.TB, r *ApplyWorkReconciler, key types.NamespacedName) {
+	var work fleetv1beta1.Work
+	if err := r.client.Get(context.Background(), key, &work); err != nil {
+		t.Fatalf("failed to get the work: %v", err)
+	}
+	identifier := fleetv1beta1.WorkResourceIdentifier{Ordinal: 0, Version: "v1", Kind: "ConfigMap", Name: "cm"}
+	constructWorkCondition([]applyResult{{identifier: identifier, action: manifestAvailableAction}}, &work)
+	if err := r.updateWorkStatusIfChanged(context.Background(), &work); err != nil {
+		t.Fatalf("updateWorkStatusIfChanged() = %v, want no error", err)
+	}
+}
+
+func TestUpdateWorkStatusIfChanged(t *testing.T) {
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: "fleet-member-test", Generation: 1},
+	}
+	key := types.NamespacedName{Name: work.Name, Namespace: work.Namespace}
+	statusUpdates := 0
+	r := &ApplyWorkReconciler{client: newStatusUpdateCountingClient(t, []client.Object{work}, &statusUpdates)}
+
+	reconcileWorkStatus(t, r, key)
+	if statusUpdates != 1 {
+		t.Fatalf("status updates after the first reconcile = %d, want 1", statusUpdates)
+	}
+
+	// the status does not change in the following reconciles.
+	reconcileWorkStatus(t, r, key)
+	reconcileWorkStatus(t, r, key)
+	if statusUpdates != 1 {
+		t.Errorf("status updates after reconciling an unchanged status = %d, want 1", statusUpdates)
+	}
+
+	// a new generation changes the observed generation of the conditions.
+	var current fleetv1beta1.Work
+	if err := r.client.Get(context.Background(), key, &current); err != nil {
+		t.Fatalf("failed to get the work: %v", err)
+	}
+	current.Generation = 2
+	if err := r.client.Update(context.Background(), &current); err != nil {
+		t.Fatalf("failed to update the work: %v", err)
+	}
+	reconcileWorkStatus(t, r, key)
+	if statusUpdates != 2 {
+		t.Errorf("status updates after the status changes = %d, want 2", statusUpdates)
+	}
+}
+
+func TestComputeStatusHashIgnoresApplyTiming(t *testing.T) {
+	status := &fleetv1beta1.WorkStatus{
+		ManifestConditions: []fleetv1beta1.ManifestCondition{{Identifier: fleetv1beta1.WorkResourceIdentifier{Ordinal: 0}}},
+	}
+	want, err := computeStatusHash(status)
+	if err != nil {
+		t.Fatalf("computeStatusHash() = %v, want no error", err)
+	}
+	now := metav1.Now()
+	status.ManifestConditions[0].ApplyStartedAt = &now
+	status.ManifestConditions[0].ApplyCompletedAt = &now
+	status.ManifestConditions[0].ApplyDurationMs = 10
+	got, err := computeStatusHash(status)
+	if err != nil {
+		t.Fatalf("computeStatusHash() = %v, want no error", err)
+	}
+	if got != want {
+		t.Errorf("computeStatusHash() = %s after the apply timing changes, want %s", got, want)
+	}
+}
+
+// BenchmarkSteadyStateWorkStatusUpdates reconciles 100 works whose status does not change and reports the number
+// of the work status updates sent to the hub cluster per reconcile.
+func BenchmarkSteadyStateWorkStatusUpdates(b *testing.B) {
+	const workCount = 100
+	works := make([]client.Object, workCount)
+	keys := make([]types.NamespacedName, workCount)
+	for i := range works {
+		works[i] = &fleetv1beta1.Work{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("work-%d", i), Namespace: "fleet-member-test", Generation: 1},
+		}
+		keys[i] = types.NamespacedName{Name: works[i].GetName(), Namespace: works[i].GetNamespace()}
+	}
+	statusUpdates := 0
+	r := &ApplyWorkReconciler{client: newStatusUpdateCountingClient(b, works, &statusUpdates)}
+	// bring the works to the steady state.
+	for _, key := range keys {
+		reconcileWorkStatus(b, r, key)
+	}
+	statusUpdates = 0
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, key := range keys {
+			reconcileWorkStatus(b, r, key)
+		}
+	}
+	b.ReportMetric(float64(statusUpdates)/float64(b.N*workCount), "statusupdates/reconcile")
+}
@@ -0,0 +1,97 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+	"go.goms.io/fleet/pkg/utils/workstatuspage"
+)
+
+// updatePaginatedWorkStatus keeps the first statusPageSize manifest conditions in the work status and writes the
+// rest into the numbered WorkStatusPages of the work before updating the work status.
+// The work keeps all its manifest conditions in memory after the update; the pages which are no longer needed are
+// deleted once the work status no longer references them.
+func (r *ApplyWorkReconciler) updatePaginatedWorkStatus(ctx context.Context, work *fleetv1beta1.Work) error {
+	conditions := work.Status.ManifestConditions
+	staleRefs := work.Status.WorkStatusPageRef
+	kept, pages := workstatuspage.Split(conditions, r.statusPageSize)
+	var refs []string
+	for i, page := range pages {
+		name := workstatuspage.PageName(work.Name, i+1)
+		if err := r.upsertWorkStatusPage(ctx, work, name, page); err != nil {
+			return err
+		}
+		refs = append(refs, name)
+	}
+	work.Status.ManifestConditions = kept
+	work.Status.StatusPageCount = len(pages)
+	work.Status.WorkStatusPageRef = refs
+	err := r.client.Status().Update(ctx, work, &client.SubResourceUpdateOptions{})
+	// the update overwrites the work with the stored status which only has the first page of the conditions.
+	work.Status.ManifestConditions = conditions
+	if err != nil {
+		return err
+	}
+	for i := len(refs); i < len(staleRefs); i++ {
+		page := &fleetv1beta1.WorkStatusPage{ObjectMeta: metav1.ObjectMeta{Name: staleRefs[i], Namespace: work.Namespace}}
+		if err := r.client.Delete(ctx, page); err != nil && !apierrors.IsNotFound(err) {
+			klog.ErrorS(err, "Failed to delete the stale work status page", "workStatusPage", klog.KObj(page))
+			return controller.NewAPIServerError(false, err)
+		}
+	}
+	return nil
+}
+
+// upsertWorkStatusPage creates or updates the status page of the work with the given manifest conditions.
+// The page is owned by the work so that it is garbage collected together with the work.
+func (r *ApplyWorkReconciler) upsertWorkStatusPage(ctx context.Context, work *fleetv1beta1.Work, name string,
+	conditions []fleetv1beta1.ManifestCondition) error {
+	page := &fleetv1beta1.WorkStatusPage{}
+	err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: work.Namespace}, page)
+	switch {
+	case apierrors.IsNotFound(err):
+		page = &fleetv1beta1.WorkStatusPage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: work.Namespace,
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion:         fleetv1beta1.GroupVersion.String(),
+					Kind:               fleetv1beta1.WorkKind,
+					Name:               work.Name,
+					UID:                work.UID,
+					BlockOwnerDeletion: ptr.To(false),
+				}},
+			},
+			WorkName:           work.Name,
+			ManifestConditions: conditions,
+		}
+		if err := r.client.Create(ctx, page); err != nil {
+			klog.ErrorS(err, "Failed to create the work status page", "workStatusPage", klog.KObj(page))
+			return controller.NewAPIServerError(false, err)
+		}
+		return nil
+	case err != nil:
+		klog.ErrorS(err, "Failed to get the work status page", "workStatusPage", klog.KRef(work.Namespace, name))
+		return controller.NewAPIServerError(true, err)
+	}
+	page.WorkName = work.Name
+	page.ManifestConditions = conditions
+	if err := r.client.Update(ctx, page); err != nil {
+		klog.ErrorS(err, "Failed to update the work status page", "workStatusPage", klog.KObj(page))
+		return controller.NewAPIServerError(false, err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/workstatuspage"
+)
+
+// manifestApplyResults returns the results of count available config map manifests.
+func manifestApplyResults(count int) []applyResult {
+	results := make([]applyResult, count)
+	for i := range results {
+		results[i] = applyResult{
+			identifier: fleetv1beta1.WorkResourceIdentifier{Ordinal: i, Version: "v1", Kind: "ConfigMap", Name: fmt.Sprintf("cm-%d", i)},
+			action:     manifestAvailableAction,
+		}
+	}
+	return results
+}
+
+// manifestIdentifiers returns the identifiers of the manifest conditions.
+func manifestIdentifiers(conditions []fleetv1beta1.ManifestCondition) []fleetv1beta1.WorkResourceIdentifier {
+	identifiers := make([]fleetv1beta1.WorkResourceIdentifier, len(conditions))
+	for i := range conditions {
+		identifiers[i] = conditions[i].Identifier
+	}
+	return identifiers
+}
+
+func TestUpdatePaginatedWorkStatus(t *testing.T) {
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: "fleet-member-test", Generation: 1},
+	}
+	key := types.NamespacedName{Name: work.Name, Namespace: work.Namespace}
+	statusUpdates := 0
+	r := &ApplyWorkReconciler{
+		client:         newStatusUpdateCountingClient(t, []client.Object{work}, &statusUpdates),
+		statusPageSize: 50,
+	}
+
+	var current fleetv1beta1.Work
+	if err := r.client.Get(context.Background(), key, &current); err != nil {
+		t.Fatalf("failed to get the work: %v", err)
+	}
+	constructWorkCondition(manifestApplyResults(200), &current)
+	if err := r.updateWorkStatusIfChanged(context.Background(), &current); err != nil {
+		t.Fatalf("updateWorkStatusIfChanged() = %v, want no error", err)
+	}
+	if len(current.Status.ManifestConditions) != 200 {
+		t.Errorf("manifest conditions in memory = %d, want 200", len(current.Status.ManifestConditions))
+	}
+	allConditions := current.Status.ManifestConditions
+
+	var stored fleetv1beta1.Work
+	if err := r.client.Get(context.Background(), key, &stored); err != nil {
+		t.Fatalf("failed to get the work: %v", err)
+	}
+	if len(stored.Status.ManifestConditions) != 50 {
+		t.Errorf("manifest conditions in the work status = %d, want 50", len(stored.Status.ManifestConditions))
+	}
+	wantRefs := []string{"test-work-page-1", "test-work-page-2", "test-work-page-3"}
+	if stored.Status.StatusPageCount != 3 {
+		t.Errorf("StatusPageCount = %d, want 3", stored.Status.StatusPageCount)
+	}
+	if diff := cmp.Diff(wantRefs, stored.Status.WorkStatusPageRef); diff != "" {
+		t.Errorf("WorkStatusPageRef mismatch (-want +got):\n%s", diff)
+	}
+	for i, name := range wantRefs {
+		var page fleetv1beta1.WorkStatusPage
+		if err := r.client.Get(context.Background(), types.NamespacedName{Name: name, Namespace: work.Namespace}, &page); err != nil {
+			t.Fatalf("failed to get the status page %s: %v", name, err)
+		}
+		if page.WorkName != work.Name {
+			t.Errorf("status page %s work name = %s, want %s", name, page.WorkName, work.Name)
+		}
+		if len(page.ManifestConditions) != 50 {
+			t.Fatalf("manifest conditions in the status page %s = %d, want 50", name, len(page.ManifestConditions))
+		}
+		if got, want := page.ManifestConditions[0].Identifier.Ordinal, (i+1)*50; got != want {
+			t.Errorf("first ordinal in the status page %s = %d, want %d", name, got, want)
+		}
+	}
+
+	// the pages are merged back into the work.
+	if err := workstatuspage.Merge(context.Background(), r.client, &stored); err != nil {
+		t.Fatalf("Merge() = %v, want no error", err)
+	}
+	// the stored times are truncated to seconds so the identifiers are compared.
+	if diff := cmp.Diff(manifestIdentifiers(allConditions), manifestIdentifiers(stored.Status.ManifestConditions)); diff != "" {
+		t.Errorf("merged manifest conditions mismatch (-want +got):\n%s", diff)
+	}
+
+	// the unchanged status is not written again.
+	if err := r.updateWorkStatusIfChanged(context.Background(), &stored); err != nil {
+		t.Fatalf("updateWorkStatusIfChanged() = %v, want no error", err)
+	}
+	if statusUpdates != 1 {
+		t.Errorf("status updates after an unchanged status = %d, want 1", statusUpdates)
+	}
+
+	// the pages which are no longer needed are deleted when the manifests shrink.
+	constructWorkCondition(manifestApplyResults(60), &stored)
+	if err := r.updateWorkStatusIfChanged(context.Background(), &stored); err != nil {
+		t.Fatalf("updateWorkStatusIfChanged() = %v, want no error", err)
+	}
+	if err := r.client.Get(context.Background(), key, &current); err != nil {
+		t.Fatalf("failed to get the work: %v", err)
+	}
+	if diff := cmp.Diff(wantRefs[:1], current.Status.WorkStatusPageRef); diff != "" {
+		t.Errorf("WorkStatusPageRef mismatch (-want +got):\n%s", diff)
+	}
+	for _, name := range wantRefs[1:] {
+		var page fleetv1beta1.WorkStatusPage
+		if err := r.client.Get(context.Background(), types.NamespacedName{Name: name, Namespace: work.Namespace}, &page); !apierrors.IsNotFound(err) {
+			t.Errorf("get the stale status page %s = %v, want not found", name, err)
+		}
+	}
+}
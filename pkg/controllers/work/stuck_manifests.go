@@ -0,0 +1,83 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	// ManifestStuckReason is the reason string of the stuck condition when the manifest has not been available for
+	// longer than the stuck threshold, or some manifests of the work have not.
+	ManifestStuckReason = "ManifestStuck"
+	// ManifestNotStuckReason is the reason string of the stuck condition when the manifest is available or has not
+	// been unavailable for longer than the stuck threshold, or no manifest of the work is stuck.
+	ManifestNotStuckReason = "ManifestNotStuck"
+)
+
+// reportStuckManifests sets the Stuck conditions of the manifests whose Available condition has been false for
+// longer than the stuck threshold of the apply strategy, and rolls them up to the Stuck condition of the work. The
+// Stuck conditions are removed if the apply strategy does not set a stuck threshold.
+// It must be called after the manifest conditions are built from the apply results.
+func reportStuckManifests(work *fleetv1beta1.Work, now time.Time) {
+	var threshold *metav1.Duration
+	if work.Spec.ApplyStrategy != nil {
+		threshold = work.Spec.ApplyStrategy.StuckThreshold
+	}
+	if threshold == nil {
+		for i := range work.Status.ManifestConditions {
+			meta.RemoveStatusCondition(&work.Status.ManifestConditions[i].Conditions, fleetv1beta1.WorkConditionTypeStuck)
+		}
+		meta.RemoveStatusCondition(&work.Status.Conditions, fleetv1beta1.WorkConditionTypeStuck)
+		return
+	}
+
+	var stuck []int
+	for i := range work.Status.ManifestConditions {
+		manifestCond := &work.Status.ManifestConditions[i]
+		stuckCond := metav1.Condition{
+			Type:    fleetv1beta1.WorkConditionTypeStuck,
+			Status:  metav1.ConditionFalse,
+			Reason:  ManifestNotStuckReason,
+			Message: "Manifest is not stuck",
+		}
+		availableCond := meta.FindStatusCondition(manifestCond.Conditions, fleetv1beta1.WorkConditionTypeAvailable)
+		if availableCond != nil {
+			stuckCond.ObservedGeneration = availableCond.ObservedGeneration
+			if unavailableFor := now.Sub(availableCond.LastTransitionTime.Time); availableCond.Status == metav1.ConditionFalse && unavailableFor > threshold.Duration {
+				stuckCond.Status = metav1.ConditionTrue
+				stuckCond.Reason = ManifestStuckReason
+				stuckCond.Message = fmt.Sprintf("Manifest has not been available since %s, longer than the stuck threshold %s",
+					availableCond.LastTransitionTime.UTC().Format(time.RFC3339), threshold.Duration)
+				stuck = append(stuck, manifestCond.Identifier.Ordinal)
+			}
+		}
+		meta.SetStatusCondition(&manifestCond.Conditions, stuckCond)
+	}
+
+	workCond := metav1.Condition{
+		Type:               fleetv1beta1.WorkConditionTypeStuck,
+		Status:             metav1.ConditionFalse,
+		Reason:             ManifestNotStuckReason,
+		Message:            "No manifest is stuck",
+		ObservedGeneration: work.Generation,
+	}
+	if len(stuck) > 0 {
+		klog.V(2).InfoS("Some manifests of the work are stuck", "work", klog.KObj(work), "manifests", stuck, "stuckThreshold", threshold.Duration)
+		workCond.Status = metav1.ConditionTrue
+		workCond.Reason = ManifestStuckReason
+		workCond.Message = fmt.Sprintf("The manifests with ordinals %v have not been available for longer than the stuck threshold %s",
+			stuck, threshold.Duration)
+	}
+	meta.SetStatusCondition(&work.Status.Conditions, workCond)
+}
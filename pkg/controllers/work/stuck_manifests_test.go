@@ -0,0 +1,121 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func TestReportStuckManifests(t *testing.T) {
+	now := time.Now()
+	manifestCond := func(ordinal int, status metav1.ConditionStatus, since time.Duration) fleetv1beta1.ManifestCondition {
+		return fleetv1beta1.ManifestCondition{
+			Identifier: fleetv1beta1.WorkResourceIdentifier{Ordinal: ordinal},
+			Conditions: []metav1.Condition{{
+				Type:               fleetv1beta1.WorkConditionTypeAvailable,
+				Status:             status,
+				Reason:             string(manifestNotAvailableYetAction),
+				LastTransitionTime: metav1.NewTime(now.Add(-since)),
+			}},
+		}
+	}
+	threshold := &metav1.Duration{Duration: 10 * time.Minute}
+
+	tests := map[string]struct {
+		threshold         *metav1.Duration
+		manifestConds     []fleetv1beta1.ManifestCondition
+		wantManifestStuck []metav1.ConditionStatus
+		wantWorkStuck     metav1.ConditionStatus
+	}{
+		"manifest unavailable beyond the threshold is stuck": {
+			threshold:         threshold,
+			manifestConds:     []fleetv1beta1.ManifestCondition{manifestCond(0, metav1.ConditionTrue, time.Hour), manifestCond(1, metav1.ConditionFalse, time.Hour)},
+			wantManifestStuck: []metav1.ConditionStatus{metav1.ConditionFalse, metav1.ConditionTrue},
+			wantWorkStuck:     metav1.ConditionTrue,
+		},
+		"manifest unavailable within the threshold is not stuck": {
+			threshold:         threshold,
+			manifestConds:     []fleetv1beta1.ManifestCondition{manifestCond(0, metav1.ConditionFalse, time.Minute)},
+			wantManifestStuck: []metav1.ConditionStatus{metav1.ConditionFalse},
+			wantWorkStuck:     metav1.ConditionFalse,
+		},
+		"manifest whose availability is unknown is not stuck": {
+			threshold:         threshold,
+			manifestConds:     []fleetv1beta1.ManifestCondition{manifestCond(0, metav1.ConditionUnknown, time.Hour)},
+			wantManifestStuck: []metav1.ConditionStatus{metav1.ConditionFalse},
+			wantWorkStuck:     metav1.ConditionFalse,
+		},
+		"no stuck condition without a threshold": {
+			manifestConds:     []fleetv1beta1.ManifestCondition{manifestCond(0, metav1.ConditionFalse, time.Hour)},
+			wantManifestStuck: []metav1.ConditionStatus{""},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			work := &fleetv1beta1.Work{
+				Spec:   fleetv1beta1.WorkSpec{ApplyStrategy: &fleetv1beta1.ApplyStrategy{StuckThreshold: tt.threshold}},
+				Status: fleetv1beta1.WorkStatus{ManifestConditions: tt.manifestConds},
+			}
+			reportStuckManifests(work, now)
+			for i, want := range tt.wantManifestStuck {
+				var got metav1.ConditionStatus
+				if cond := meta.FindStatusCondition(work.Status.ManifestConditions[i].Conditions, fleetv1beta1.WorkConditionTypeStuck); cond != nil {
+					got = cond.Status
+				}
+				if got != want {
+					t.Errorf("Stuck condition of manifest %d = %q, want %q", i, got, want)
+				}
+			}
+			var got metav1.ConditionStatus
+			if cond := meta.FindStatusCondition(work.Status.Conditions, fleetv1beta1.WorkConditionTypeStuck); cond != nil {
+				got = cond.Status
+			}
+			if got != tt.wantWorkStuck {
+				t.Errorf("Stuck condition of the work = %q, want %q", got, tt.wantWorkStuck)
+			}
+		})
+	}
+}
+
+func TestReportStuckManifestsRecovery(t *testing.T) {
+	now := time.Now()
+	work := &fleetv1beta1.Work{
+		Spec: fleetv1beta1.WorkSpec{ApplyStrategy: &fleetv1beta1.ApplyStrategy{StuckThreshold: &metav1.Duration{Duration: time.Minute}}},
+		Status: fleetv1beta1.WorkStatus{ManifestConditions: []fleetv1beta1.ManifestCondition{{
+			Identifier: fleetv1beta1.WorkResourceIdentifier{Ordinal: 0},
+			Conditions: []metav1.Condition{{
+				Type:               fleetv1beta1.WorkConditionTypeAvailable,
+				Status:             metav1.ConditionFalse,
+				Reason:             string(manifestNotAvailableYetAction),
+				LastTransitionTime: metav1.NewTime(now.Add(-time.Hour)),
+			}},
+		}}},
+	}
+	reportStuckManifests(work, now)
+	if !meta.IsStatusConditionTrue(work.Status.Conditions, fleetv1beta1.WorkConditionTypeStuck) {
+		t.Fatalf("Stuck condition of the work = %v, want true", work.Status.Conditions)
+	}
+
+	// the resource becomes available.
+	meta.SetStatusCondition(&work.Status.ManifestConditions[0].Conditions, metav1.Condition{
+		Type:   fleetv1beta1.WorkConditionTypeAvailable,
+		Status: metav1.ConditionTrue,
+		Reason: string(manifestAvailableAction),
+	})
+	reportStuckManifests(work, now.Add(time.Minute))
+	if !meta.IsStatusConditionFalse(work.Status.ManifestConditions[0].Conditions, fleetv1beta1.WorkConditionTypeStuck) {
+		t.Errorf("Stuck condition of the manifest = %v, want false once available", work.Status.ManifestConditions[0].Conditions)
+	}
+	if !meta.IsStatusConditionFalse(work.Status.Conditions, fleetv1beta1.WorkConditionTypeStuck) {
+		t.Errorf("Stuck condition of the work = %v, want false once the manifests are available", work.Status.Conditions)
+	}
+}
@@ -31,6 +31,9 @@ import (
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/dynamic"
@@ -57,6 +60,7 @@ var (
 	testEnv        *envtest.Environment
 	workController *ApplyWorkReconciler
 	setupLog       = ctrl.Log.WithName("test")
+	spanExporter   = tracetest.NewInMemoryExporter()
 	ctx            context.Context
 	cancel         context.CancelFunc
 )
@@ -64,6 +68,12 @@ var (
 const (
 	// number of concurrent reconcile loop for work
 	maxWorkConcurrency = 5
+	// number of drifts kept in the drift history of a manifest
+	testMaxDriftHistoryDepth = 2
+	// maximum size of the raw JSON of a manifest
+	testMaxManifestSizeBytes = 256 * 1024
+	// number of the manifests of a work applied concurrently
+	testManifestWorkerPoolSize = 4
 )
 
 func TestAPIs(t *testing.T) {
@@ -121,8 +131,13 @@ func createControllers(ctx context.Context, hubCfg, spokeCfg *rest.Config, setup
 		spokeClient,
 		restMapper,
 		hubMgr.GetEventRecorderFor("work_controller"),
-		maxWorkConcurrency,
-		targetNS,
+		ApplyWorkReconcilerOptions{
+			Concurrency:          maxWorkConcurrency,
+			WorkNamespace:        targetNS,
+			MaxDriftHistoryDepth: testMaxDriftHistoryDepth,
+			MaxManifestSizeBytes: testMaxManifestSizeBytes,
+			WorkerPoolSize:       testManifestWorkerPoolSize,
+		},
 	)
 
 	if err = workController.SetupWithManager(hubMgr); err != nil {
@@ -183,6 +198,9 @@ var _ = BeforeSuite(func() {
 	err = k8sClient.Create(context.Background(), &workNamespace)
 	Expect(err).ToNot(HaveOccurred())
 
+	By("record the spans in memory")
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(spanExporter)))
+
 	By("start controllers")
 	var hubMgr manager.Manager
 	if hubMgr, workController, err = createControllers(ctx, cfg, cfg, setupLog, opts); err != nil {
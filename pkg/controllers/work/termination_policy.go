@@ -0,0 +1,107 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+// isOrphanOnDelete returns true if the resources applied by the work are left in the member cluster when the work is
+// deleted.
+func isOrphanOnDelete(work *fleetv1beta1.Work) bool {
+	return work.Spec.TerminationPolicy == fleetv1beta1.TerminationPolicyTypeOrphan
+}
+
+// orphanAppliedResources releases the resources applied by the deleting work instead of deleting them. The owner
+// reference to the appliedWork is removed from every applied resource, and the appliedWork is deleted with its
+// applied resources cleared, orphaning any dependent the work applier does not know about as well.
+func (r *ApplyWorkReconciler) orphanAppliedResources(ctx context.Context, work *fleetv1beta1.Work) error {
+	appliedWork := &fleetv1beta1.AppliedWork{}
+	err := r.spokeClient.Get(ctx, types.NamespacedName{Name: work.Name}, appliedWork)
+	switch {
+	case apierrors.IsNotFound(err):
+		klog.V(2).InfoS("The appliedWork is already deleted, nothing to orphan", "appliedWork", work.Name)
+		return nil
+	case err != nil:
+		klog.ErrorS(err, "Failed to retrieve the appliedWork", "appliedWork", work.Name)
+		return controller.NewAPIServerError(false, err)
+	}
+	owner := metav1.OwnerReference{
+		APIVersion: fleetv1beta1.GroupVersion.String(),
+		Kind:       fleetv1beta1.AppliedWorkKind,
+		Name:       appliedWork.GetName(),
+		UID:        appliedWork.GetUID(),
+	}
+
+	orphaned := len(appliedWork.Status.AppliedResources)
+	var errs []error
+	for _, res := range appliedWork.Status.AppliedResources {
+		if err := r.removeOwnerReference(ctx, res, owner); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return utilerrors.NewAggregate(errs)
+	}
+
+	if orphaned > 0 {
+		appliedWork.Status.AppliedResources = nil
+		if err := r.spokeClient.Status().Update(ctx, appliedWork); err != nil {
+			klog.ErrorS(err, "Failed to clear the applied resources of the appliedWork", "appliedWork", work.Name)
+			return controller.NewAPIServerError(false, err)
+		}
+	}
+	orphanPolicy := metav1.DeletePropagationOrphan
+	if err := r.spokeClient.Delete(ctx, appliedWork, &client.DeleteOptions{PropagationPolicy: &orphanPolicy}); err != nil && !apierrors.IsNotFound(err) {
+		klog.ErrorS(err, "Failed to delete the appliedWork", "appliedWork", work.Name)
+		return controller.NewAPIServerError(false, err)
+	}
+	klog.InfoS("Orphaned the resources applied by the work", "work", klog.KObj(work), "resources", orphaned)
+	return nil
+}
+
+// removeOwnerReference removes the owner reference to the appliedWork from the applied resource, if it still exists.
+func (r *ApplyWorkReconciler) removeOwnerReference(ctx context.Context, res fleetv1beta1.AppliedResourceMeta, owner metav1.OwnerReference) error {
+	gvr := schema.GroupVersionResource{Group: res.Group, Version: res.Version, Resource: res.Resource}
+	resourceClient := r.spokeDynamicClient.Resource(gvr).Namespace(appliedNamespace(res))
+	uObj, err := resourceClient.Get(ctx, res.Name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		klog.V(2).InfoS("The applied resource is already deleted", "resource", res.WorkResourceIdentifier, "owner", owner)
+		return nil
+	case err != nil:
+		klog.ErrorS(err, "Failed to get the applied resource", "resource", res.WorkResourceIdentifier, "owner", owner)
+		return controller.NewAPIServerError(false, err)
+	}
+	existingOwners := uObj.GetOwnerReferences()
+	newOwners := make([]metav1.OwnerReference, 0, len(existingOwners))
+	for _, existing := range existingOwners {
+		if !isReferSameObject(existing, owner) {
+			newOwners = append(newOwners, existing)
+		}
+	}
+	if len(newOwners) == len(existingOwners) {
+		return nil
+	}
+	uObj.SetOwnerReferences(newOwners)
+	if _, err := resourceClient.Update(ctx, uObj, metav1.UpdateOptions{FieldManager: r.fieldManager}); err != nil {
+		klog.ErrorS(err, "Failed to remove the owner reference from the applied resource", "resource", res.WorkResourceIdentifier, "owner", owner)
+		return controller.NewAPIServerError(false, err)
+	}
+	klog.V(2).InfoS("Removed the owner reference from the applied resource", "resource", res.WorkResourceIdentifier, "owner", owner)
+	return nil
+}
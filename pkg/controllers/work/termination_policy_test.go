@@ -0,0 +1,95 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+)
+
+func TestGarbageCollectAppliedWorkOrphan(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	now := metav1.Now()
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test-work",
+			Namespace:         "fleet-member-test",
+			Finalizers:        []string{fleetv1beta1.WorkFinalizer},
+			DeletionTimestamp: &now,
+		},
+		Spec: fleetv1beta1.WorkSpec{TerminationPolicy: fleetv1beta1.TerminationPolicyTypeOrphan},
+	}
+	appliedWork := &fleetv1beta1.AppliedWork{
+		ObjectMeta: metav1.ObjectMeta{Name: work.Name, UID: "applied-work-uid"},
+		Status: fleetv1beta1.AppliedWorkStatus{
+			AppliedResources: []fleetv1beta1.AppliedResourceMeta{
+				appliedDeploymentMeta("owned", "owned-uid"),
+				appliedDeploymentMeta("co-owned", "co-owned-uid"),
+				// a resource which is already deleted is skipped.
+				appliedDeploymentMeta("missing", "missing-uid"),
+			},
+		},
+	}
+	appliedWorkOwner := metav1.OwnerReference{
+		APIVersion: fleetv1beta1.GroupVersion.String(),
+		Kind:       fleetv1beta1.AppliedWorkKind,
+		Name:       appliedWork.Name,
+		UID:        appliedWork.UID,
+	}
+	otherOwner := metav1.OwnerReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "other", UID: "other-uid"}
+	owned := liveDeployment("owned", "owned-uid")
+	owned.SetOwnerReferences([]metav1.OwnerReference{appliedWorkOwner})
+	coOwned := liveDeployment("co-owned", "co-owned-uid")
+	coOwned.SetOwnerReferences([]metav1.OwnerReference{otherOwner, appliedWorkOwner})
+
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(work).Build()
+	spokeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(appliedWork).WithStatusSubresource(appliedWork).Build()
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), owned, coOwned)
+	r := &ApplyWorkReconciler{client: hubClient, spokeClient: spokeClient, spokeDynamicClient: dynamicClient}
+
+	key := types.NamespacedName{Name: work.Name, Namespace: work.Namespace}
+	if err := hubClient.Get(context.Background(), key, work); err != nil {
+		t.Fatalf("failed to get the work: %v", err)
+	}
+	if _, err := r.garbageCollectAppliedWork(context.Background(), work); err != nil {
+		t.Fatalf("garbageCollectAppliedWork() = %v, want no error", err)
+	}
+	if err := hubClient.Get(context.Background(), key, &fleetv1beta1.Work{}); !apierrors.IsNotFound(err) {
+		t.Errorf("work after the garbage collection: %v, want it deleted", err)
+	}
+	if err := spokeClient.Get(context.Background(), types.NamespacedName{Name: work.Name}, &fleetv1beta1.AppliedWork{}); !apierrors.IsNotFound(err) {
+		t.Errorf("appliedWork after the garbage collection: %v, want it deleted", err)
+	}
+
+	wantOwners := map[string][]metav1.OwnerReference{
+		"owned":    nil,
+		"co-owned": {otherOwner},
+	}
+	for name, want := range wantOwners {
+		got, err := dynamicClient.Resource(utils.DeploymentGVR).Namespace("default").Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get the orphaned deployment %s: %v", name, err)
+		}
+		if diff := cmp.Diff(want, got.GetOwnerReferences(), cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("owner references of the orphaned deployment %s mismatch (-want, +got):\n%s", name, diff)
+		}
+	}
+}
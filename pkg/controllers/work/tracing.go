@@ -0,0 +1,60 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	tracerName = "go.goms.io/fleet/pkg/controllers/work"
+
+	workNameAttributeKey        = "work.name"
+	workNamespaceAttributeKey   = "work.namespace"
+	manifestOrdinalAttributeKey = "manifest.ordinal"
+	manifestGVKAttributeKey     = "manifest.gvk"
+	applyResultAttributeKey     = "apply.result"
+
+	// applyResultFailed is the apply.result of the manifests that fail to apply.
+	applyResultFailed = "Failed"
+)
+
+// startWorkSpan starts the span of a work reconcile loop.
+func startWorkSpan(ctx context.Context, work types.NamespacedName) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, "ApplyWork", trace.WithAttributes(
+		attribute.String(workNameAttributeKey, work.Name),
+		attribute.String(workNamespaceAttributeKey, work.Namespace),
+	))
+}
+
+// startManifestSpan starts the child span of applying the manifest with the given ordinal.
+func startManifestSpan(ctx context.Context, ordinal int) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, "ApplyManifest", trace.WithAttributes(
+		attribute.Int(manifestOrdinalAttributeKey, ordinal),
+	))
+}
+
+// endManifestSpan records the GVK and the apply result of the manifest on its span and ends the span.
+func endManifestSpan(span trace.Span, obj *unstructured.Unstructured, result applyResult) {
+	if obj != nil {
+		span.SetAttributes(attribute.String(manifestGVKAttributeKey, obj.GroupVersionKind().String()))
+	}
+	if result.applyErr != nil {
+		span.SetAttributes(attribute.String(applyResultAttributeKey, applyResultFailed))
+		span.RecordError(result.applyErr)
+		span.SetStatus(codes.Error, result.applyErr.Error())
+	} else {
+		span.SetAttributes(attribute.String(applyResultAttributeKey, string(result.action)))
+	}
+	span.End()
+}
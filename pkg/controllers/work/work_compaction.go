@@ -0,0 +1,68 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+	"go.goms.io/fleet/pkg/utils/workcompaction"
+)
+
+const (
+	// compactionHandOverRequeueDelay is how long to wait before checking again whether the consolidated work which a
+	// work is compacted into has applied its manifests.
+	compactionHandOverRequeueDelay = 15 * time.Second
+)
+
+// compactedInto returns the name of the consolidated work which the work is compacted into, if any.
+func compactedInto(work *fleetv1beta1.Work) (string, bool) {
+	name, found := work.GetAnnotations()[fleetv1beta1.CompactedIntoAnnotation]
+	return name, found && name != ""
+}
+
+// handOverCompactedWork hands the resources applied by the work over to the consolidated work it is compacted into.
+// The manifests of the work are no longer applied. Once the consolidated work has applied them, and so co-owns their
+// resources, the work releases the resources the same way as the works with the Orphan termination policy do, so
+// that no resource is deleted and re-created on the way. The work is left without its appliedWork and finalizer
+// afterwards, and its status is reflected from the consolidated work by the work compactor on the hub cluster.
+func (r *ApplyWorkReconciler) handOverCompactedWork(ctx context.Context, work *fleetv1beta1.Work, consolidatedName string) (ctrl.Result, error) {
+	logObjRef := klog.KObj(work)
+	if !controllerutil.ContainsFinalizer(work, fleetv1beta1.WorkFinalizer) {
+		klog.V(2).InfoS("The resources of the compacted work are handed over already", "work", logObjRef, "consolidatedWork", consolidatedName)
+		return ctrl.Result{}, nil
+	}
+	var consolidated fleetv1beta1.Work
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: work.Namespace, Name: consolidatedName}, &consolidated)
+	switch {
+	case apierrors.IsNotFound(err):
+		klog.V(2).InfoS("The consolidated work is not found yet", "work", logObjRef, "consolidatedWork", consolidatedName)
+		return ctrl.Result{RequeueAfter: compactionHandOverRequeueDelay}, nil
+	case err != nil:
+		klog.ErrorS(err, "Failed to get the consolidated work", "work", logObjRef, "consolidatedWork", consolidatedName)
+		return ctrl.Result{}, controller.NewAPIServerError(true, err)
+	}
+	if !workcompaction.AppliesCompactedWork(&consolidated, work) {
+		klog.V(2).InfoS("Wait for the consolidated work to apply the manifests of the compacted work", "work", logObjRef,
+			"consolidatedWork", consolidatedName, "generation", work.Generation)
+		return ctrl.Result{RequeueAfter: compactionHandOverRequeueDelay}, nil
+	}
+	if err := r.orphanAppliedResources(ctx, work); err != nil {
+		return ctrl.Result{}, err
+	}
+	klog.InfoS("Handed the resources of the compacted work over to the consolidated work", "work", logObjRef, "consolidatedWork", consolidatedName)
+	controllerutil.RemoveFinalizer(work, fleetv1beta1.WorkFinalizer)
+	return ctrl.Result{}, r.client.Update(ctx, work, &client.UpdateOptions{})
+}
@@ -0,0 +1,156 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/workcompaction"
+)
+
+// TestHandOverCompactedWork migrates the resources of a work compacted into a consolidated work, and makes sure that
+// they are never left without an owner which keeps them, i.e. are never deleted, on the way.
+func TestHandOverCompactedWork(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	sourceOwner := metav1.OwnerReference{
+		APIVersion: fleetv1beta1.GroupVersion.String(),
+		Kind:       fleetv1beta1.AppliedWorkKind,
+		Name:       "small-work",
+		UID:        "small-applied-work-uid",
+	}
+	consolidatedOwner := metav1.OwnerReference{
+		APIVersion: fleetv1beta1.GroupVersion.String(),
+		Kind:       fleetv1beta1.AppliedWorkKind,
+		Name:       "compacted-0",
+		UID:        "consolidated-applied-work-uid",
+	}
+
+	tests := map[string]struct {
+		// consolidatedApplied is true if the consolidated work has applied the manifests of the compacted work.
+		consolidatedApplied bool
+		// consolidatedGeneration is the generation of the compacted work which the consolidated work has.
+		consolidatedGeneration int64
+		wantOwners             []metav1.OwnerReference
+		wantHandedOver         bool
+	}{
+		"consolidated work is not applied yet": {
+			consolidatedGeneration: 2,
+			wantOwners:             []metav1.OwnerReference{sourceOwner},
+		},
+		"consolidated work applied an older generation of the compacted work": {
+			consolidatedApplied:    true,
+			consolidatedGeneration: 1,
+			wantOwners:             []metav1.OwnerReference{sourceOwner, consolidatedOwner},
+		},
+		"consolidated work applied the compacted work": {
+			consolidatedApplied:    true,
+			consolidatedGeneration: 2,
+			wantOwners:             []metav1.OwnerReference{consolidatedOwner},
+			wantHandedOver:         true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			work := &fleetv1beta1.Work{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "small-work",
+					Namespace:   "fleet-member-test",
+					Generation:  2,
+					Finalizers:  []string{fleetv1beta1.WorkFinalizer},
+					Annotations: map[string]string{fleetv1beta1.CompactedIntoAnnotation: "compacted-0"},
+				},
+			}
+			consolidated := &fleetv1beta1.Work{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "compacted-0",
+					Namespace:  work.Namespace,
+					Generation: 3,
+					Labels:     map[string]string{fleetv1beta1.ConsolidatedWorkLabel: "true"},
+				},
+			}
+			if err := workcompaction.SetCompactedWorks(consolidated, []workcompaction.CompactedWork{
+				{Name: "other-work", Generation: 1, FirstOrdinal: 0, ManifestCount: 1},
+				{Name: work.Name, Generation: tt.consolidatedGeneration, FirstOrdinal: 1, ManifestCount: 1},
+			}); err != nil {
+				t.Fatalf("SetCompactedWorks() = %v, want no error", err)
+			}
+			deployment := liveDeployment("web", "web-uid")
+			if tt.consolidatedApplied {
+				consolidated.Status.Conditions = []metav1.Condition{{
+					Type:               fleetv1beta1.WorkConditionTypeApplied,
+					Status:             metav1.ConditionTrue,
+					Reason:             workAppliedCompletedReason,
+					ObservedGeneration: consolidated.Generation,
+					LastTransitionTime: metav1.Now(),
+				}}
+				// the consolidated work co-owns the resource once it applies it.
+				deployment.SetOwnerReferences([]metav1.OwnerReference{sourceOwner, consolidatedOwner})
+			} else {
+				deployment.SetOwnerReferences([]metav1.OwnerReference{sourceOwner})
+			}
+			appliedWork := &fleetv1beta1.AppliedWork{
+				ObjectMeta: metav1.ObjectMeta{Name: work.Name, UID: sourceOwner.UID},
+				Status: fleetv1beta1.AppliedWorkStatus{
+					AppliedResources: []fleetv1beta1.AppliedResourceMeta{appliedDeploymentMeta("web", "web-uid")},
+				},
+			}
+
+			hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(work, consolidated).Build()
+			spokeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(appliedWork).WithStatusSubresource(appliedWork).Build()
+			dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), deployment)
+			r := &ApplyWorkReconciler{client: hubClient, spokeClient: spokeClient, spokeDynamicClient: dynamicClient}
+
+			key := types.NamespacedName{Name: work.Name, Namespace: work.Namespace}
+			if err := hubClient.Get(context.Background(), key, work); err != nil {
+				t.Fatalf("failed to get the work: %v", err)
+			}
+			result, err := r.handOverCompactedWork(context.Background(), work, consolidated.Name)
+			if err != nil {
+				t.Fatalf("handOverCompactedWork() = %v, want no error", err)
+			}
+			if wantRequeue := !tt.wantHandedOver; (result.RequeueAfter > 0) != wantRequeue {
+				t.Errorf("handOverCompactedWork() requeueAfter = %v, want requeue %t", result.RequeueAfter, wantRequeue)
+			}
+
+			// the resource is never deleted, whether it is handed over or not.
+			got, err := dynamicClient.Resource(utils.DeploymentGVR).Namespace("default").Get(context.Background(), "web", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get the deployment after the hand over: %v", err)
+			}
+			if diff := cmp.Diff(tt.wantOwners, got.GetOwnerReferences(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("owner references of the deployment mismatch (-want, +got):\n%s", diff)
+			}
+
+			var gotWork fleetv1beta1.Work
+			if err := hubClient.Get(context.Background(), key, &gotWork); err != nil {
+				t.Fatalf("failed to get the work: %v", err)
+			}
+			if handedOver := !controllerutil.ContainsFinalizer(&gotWork, fleetv1beta1.WorkFinalizer); handedOver != tt.wantHandedOver {
+				t.Errorf("handOverCompactedWork() removed the finalizer = %t, want %t", handedOver, tt.wantHandedOver)
+			}
+			err = spokeClient.Get(context.Background(), types.NamespacedName{Name: work.Name}, &fleetv1beta1.AppliedWork{})
+			if deleted := apierrors.IsNotFound(err); deleted != tt.wantHandedOver {
+				t.Errorf("appliedWork deleted = %t (%v), want %t", deleted, err, tt.wantHandedOver)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/condition"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// workGroupAllPartsAppliedReason is the reason of the applied condition of a group whose parts are all applied.
+	workGroupAllPartsAppliedReason = "AllPartsApplied"
+	// workGroupPartNotAppliedReason is the reason of the applied condition of a group with a part not applied.
+	workGroupPartNotAppliedReason = "PartNotApplied"
+	// workGroupAllPartsAvailableReason is the reason of the available condition of a group whose parts are all available.
+	workGroupAllPartsAvailableReason = "AllPartsAvailable"
+	// workGroupPartNotAvailableReason is the reason of the available condition of a group with a part not available.
+	workGroupPartNotAvailableReason = "PartNotAvailable"
+)
+
+// syncWorkGroupStatus aggregates the statuses of the parts of the split work the work is a part of into their
+// WorkGroup. It does nothing if the work is not a part of a split work.
+func (r *ApplyWorkReconciler) syncWorkGroupStatus(ctx context.Context, work *fleetv1beta1.Work) error {
+	groupName, ok := work.Labels[fleetv1beta1.WorkGroupLabel]
+	if !ok {
+		return nil
+	}
+	var group fleetv1beta1.WorkGroup
+	key := types.NamespacedName{Name: groupName, Namespace: work.Namespace}
+	if err := r.client.Get(ctx, key, &group); err != nil {
+		if apierrors.IsNotFound(err) {
+			// the group is created along with the parts, its status is synced when a part is applied again.
+			klog.V(2).InfoS("The work group of the work part is not found", "work", klog.KObj(work), "workGroup", key)
+			return nil
+		}
+		klog.ErrorS(err, "Failed to get the work group", "workGroup", key)
+		return controller.NewAPIServerError(true, err)
+	}
+	var partList fleetv1beta1.WorkList
+	if err := r.client.List(ctx, &partList, client.InNamespace(work.Namespace),
+		client.MatchingLabels{fleetv1beta1.WorkGroupLabel: groupName}); err != nil {
+		klog.ErrorS(err, "Failed to list the parts of the work group", "workGroup", key)
+		return controller.NewAPIServerError(true, err)
+	}
+	parts := make(map[string]*fleetv1beta1.Work, len(partList.Items))
+	for i := range partList.Items {
+		parts[partList.Items[i].Name] = &partList.Items[i]
+	}
+	// the status of the work just applied may not be in the cache yet.
+	parts[work.Name] = work
+
+	status := aggregateWorkGroupStatus(&group, parts)
+	if equality.Semantic.DeepEqual(status, group.Status) {
+		return nil
+	}
+	group.Status = status
+	if err := r.client.Status().Update(ctx, &group, &client.SubResourceUpdateOptions{}); err != nil {
+		klog.ErrorS(err, "Failed to update the work group status", "workGroup", key)
+		return controller.NewUpdateIgnoreConflictError(err)
+	}
+	klog.V(2).InfoS("Updated the work group status", "workGroup", key,
+		"appliedParts", status.AppliedParts, "availableParts", status.AvailableParts)
+	return nil
+}
+
+// aggregateWorkGroupStatus returns the status of the group aggregated from its parts, keyed by their names.
+// The parts are an atomic unit: the group is applied, or available, only if all its parts are; a part which does
+// not exist yet is neither.
+func aggregateWorkGroupStatus(group *fleetv1beta1.WorkGroup, parts map[string]*fleetv1beta1.Work) fleetv1beta1.WorkGroupStatus {
+	status := fleetv1beta1.WorkGroupStatus{Conditions: append([]metav1.Condition(nil), group.Status.Conditions...)}
+	var notAppliedPart, notAvailablePart string
+	for _, name := range group.Spec.Parts {
+		part, ok := parts[name]
+		if ok && condition.IsConditionStatusTrue(meta.FindStatusCondition(part.Status.Conditions, fleetv1beta1.WorkConditionTypeApplied), part.Generation) {
+			status.AppliedParts++
+		} else if notAppliedPart == "" {
+			notAppliedPart = name
+		}
+		if ok && condition.IsConditionStatusTrue(meta.FindStatusCondition(part.Status.Conditions, fleetv1beta1.WorkConditionTypeAvailable), part.Generation) {
+			status.AvailableParts++
+		} else if notAvailablePart == "" {
+			notAvailablePart = name
+		}
+	}
+
+	applied := metav1.Condition{
+		Type:               fleetv1beta1.WorkGroupConditionTypeApplied,
+		Status:             metav1.ConditionTrue,
+		Reason:             workGroupAllPartsAppliedReason,
+		Message:            "All the parts of the work are applied",
+		ObservedGeneration: group.Generation,
+	}
+	if notAppliedPart != "" {
+		applied.Status = metav1.ConditionFalse
+		applied.Reason = workGroupPartNotAppliedReason
+		applied.Message = fmt.Sprintf("%d of %d parts are applied, the part %s is not applied", status.AppliedParts, len(group.Spec.Parts), notAppliedPart)
+	}
+	meta.SetStatusCondition(&status.Conditions, applied)
+
+	available := metav1.Condition{
+		Type:               fleetv1beta1.WorkGroupConditionTypeAvailable,
+		Status:             metav1.ConditionTrue,
+		Reason:             workGroupAllPartsAvailableReason,
+		Message:            "All the parts of the work are available",
+		ObservedGeneration: group.Generation,
+	}
+	if notAvailablePart != "" {
+		available.Status = metav1.ConditionFalse
+		available.Reason = workGroupPartNotAvailableReason
+		available.Message = fmt.Sprintf("%d of %d parts are available, the part %s is not available", status.AvailableParts, len(group.Spec.Parts), notAvailablePart)
+	}
+	meta.SetStatusCondition(&status.Conditions, available)
+	return status
+}
@@ -0,0 +1,162 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// workPart returns a part of the split work crp-work with the given applied and available condition statuses.
+func workPart(name string, applied, available metav1.ConditionStatus) *fleetv1beta1.Work {
+	return &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Namespace:  "fleet-member-test",
+			Generation: 1,
+			Labels:     map[string]string{fleetv1beta1.WorkGroupLabel: "crp-work"},
+		},
+		Status: fleetv1beta1.WorkStatus{
+			Conditions: []metav1.Condition{
+				{Type: fleetv1beta1.WorkConditionTypeApplied, Status: applied, ObservedGeneration: 1},
+				{Type: fleetv1beta1.WorkConditionTypeAvailable, Status: available, ObservedGeneration: 1},
+			},
+		},
+	}
+}
+
+func TestAggregateWorkGroupStatus(t *testing.T) {
+	group := &fleetv1beta1.WorkGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "crp-work", Namespace: "fleet-member-test", Generation: 2},
+		Spec:       fleetv1beta1.WorkGroupSpec{Parts: []string{"crp-work-part-0", "crp-work-part-1"}},
+	}
+	tests := map[string]struct {
+		parts         []*fleetv1beta1.Work
+		wantApplied   int
+		wantAvailable int
+		wantConds     []metav1.Condition
+	}{
+		"all parts are applied and available": {
+			parts: []*fleetv1beta1.Work{
+				workPart("crp-work-part-0", metav1.ConditionTrue, metav1.ConditionTrue),
+				workPart("crp-work-part-1", metav1.ConditionTrue, metav1.ConditionTrue),
+			},
+			wantApplied:   2,
+			wantAvailable: 2,
+			wantConds: []metav1.Condition{
+				{Type: fleetv1beta1.WorkGroupConditionTypeApplied, Status: metav1.ConditionTrue, Reason: workGroupAllPartsAppliedReason, ObservedGeneration: 2},
+				{Type: fleetv1beta1.WorkGroupConditionTypeAvailable, Status: metav1.ConditionTrue, Reason: workGroupAllPartsAvailableReason, ObservedGeneration: 2},
+			},
+		},
+		"a part which is not applied fails the group": {
+			parts: []*fleetv1beta1.Work{
+				workPart("crp-work-part-0", metav1.ConditionTrue, metav1.ConditionTrue),
+				workPart("crp-work-part-1", metav1.ConditionFalse, metav1.ConditionFalse),
+			},
+			wantApplied:   1,
+			wantAvailable: 1,
+			wantConds: []metav1.Condition{
+				{Type: fleetv1beta1.WorkGroupConditionTypeApplied, Status: metav1.ConditionFalse, Reason: workGroupPartNotAppliedReason, ObservedGeneration: 2},
+				{Type: fleetv1beta1.WorkGroupConditionTypeAvailable, Status: metav1.ConditionFalse, Reason: workGroupPartNotAvailableReason, ObservedGeneration: 2},
+			},
+		},
+		"a missing part fails the group": {
+			parts: []*fleetv1beta1.Work{
+				workPart("crp-work-part-1", metav1.ConditionTrue, metav1.ConditionTrue),
+			},
+			wantApplied:   1,
+			wantAvailable: 1,
+			wantConds: []metav1.Condition{
+				{Type: fleetv1beta1.WorkGroupConditionTypeApplied, Status: metav1.ConditionFalse, Reason: workGroupPartNotAppliedReason, ObservedGeneration: 2},
+				{Type: fleetv1beta1.WorkGroupConditionTypeAvailable, Status: metav1.ConditionFalse, Reason: workGroupPartNotAvailableReason, ObservedGeneration: 2},
+			},
+		},
+		"an applied part which is not available only fails the availability": {
+			parts: []*fleetv1beta1.Work{
+				workPart("crp-work-part-0", metav1.ConditionTrue, metav1.ConditionTrue),
+				workPart("crp-work-part-1", metav1.ConditionTrue, metav1.ConditionFalse),
+			},
+			wantApplied:   2,
+			wantAvailable: 1,
+			wantConds: []metav1.Condition{
+				{Type: fleetv1beta1.WorkGroupConditionTypeApplied, Status: metav1.ConditionTrue, Reason: workGroupAllPartsAppliedReason, ObservedGeneration: 2},
+				{Type: fleetv1beta1.WorkGroupConditionTypeAvailable, Status: metav1.ConditionFalse, Reason: workGroupPartNotAvailableReason, ObservedGeneration: 2},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			parts := make(map[string]*fleetv1beta1.Work, len(tt.parts))
+			for _, part := range tt.parts {
+				parts[part.Name] = part
+			}
+			got := aggregateWorkGroupStatus(group, parts)
+			if got.AppliedParts != tt.wantApplied || got.AvailableParts != tt.wantAvailable {
+				t.Errorf("aggregateWorkGroupStatus() applied, available parts = %d, %d, want %d, %d",
+					got.AppliedParts, got.AvailableParts, tt.wantApplied, tt.wantAvailable)
+			}
+			if diff := cmp.Diff(tt.wantConds, got.Conditions, cmpopts.IgnoreFields(metav1.Condition{}, "Message", "LastTransitionTime")); diff != "" {
+				t.Errorf("aggregateWorkGroupStatus() conditions mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSyncWorkGroupStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	group := &fleetv1beta1.WorkGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "crp-work", Namespace: "fleet-member-test"},
+		Spec:       fleetv1beta1.WorkGroupSpec{Parts: []string{"crp-work-part-0", "crp-work-part-1"}},
+	}
+	part0 := workPart("crp-work-part-0", metav1.ConditionTrue, metav1.ConditionTrue)
+	// the stored status of the second part is not applied yet.
+	part1 := workPart("crp-work-part-1", metav1.ConditionFalse, metav1.ConditionFalse)
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(group, part0, part1).WithStatusSubresource(group, part0, part1).Build()
+	r := &ApplyWorkReconciler{client: hubClient}
+
+	getAppliedCond := func() *metav1.Condition {
+		t.Helper()
+		var got fleetv1beta1.WorkGroup
+		if err := hubClient.Get(context.Background(), types.NamespacedName{Name: group.Name, Namespace: group.Namespace}, &got); err != nil {
+			t.Fatalf("failed to get the work group: %v", err)
+		}
+		return meta.FindStatusCondition(got.Status.Conditions, fleetv1beta1.WorkGroupConditionTypeApplied)
+	}
+
+	if err := r.syncWorkGroupStatus(context.Background(), part0); err != nil {
+		t.Fatalf("syncWorkGroupStatus() = %v, want no error", err)
+	}
+	if cond := getAppliedCond(); cond == nil || cond.Status != metav1.ConditionFalse {
+		t.Errorf("applied condition with a part not applied = %+v, want false", cond)
+	}
+
+	// the status of the part just applied counts even if it is not stored yet.
+	applied := workPart("crp-work-part-1", metav1.ConditionTrue, metav1.ConditionTrue)
+	if err := r.syncWorkGroupStatus(context.Background(), applied); err != nil {
+		t.Fatalf("syncWorkGroupStatus() = %v, want no error", err)
+	}
+	if cond := getAppliedCond(); cond == nil || cond.Status != metav1.ConditionTrue {
+		t.Errorf("applied condition with all the parts applied = %+v, want true", cond)
+	}
+
+	// a work which is not a part of a split work is left alone.
+	if err := r.syncWorkGroupStatus(context.Background(), &fleetv1beta1.Work{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "fleet-member-test"}}); err != nil {
+		t.Errorf("syncWorkGroupStatus() = %v, want no error", err)
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"encoding/json"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/metrics"
+)
+
+const (
+	// defaultEtcdObjectSizeLimitBytes is the default maximum size of an object stored in etcd.
+	defaultEtcdObjectSizeLimitBytes = 1536 * 1024
+
+	// specSizeWarningPercentage is the percentage of the etcd object size limit above which the work spec is
+	// reported as near the limit.
+	specSizeWarningPercentage = 80
+
+	// WorkSpecNearSizeLimitReason is the reason of the event emitted when the work spec is near the etcd object size limit.
+	WorkSpecNearSizeLimitReason = "WorkSpecNearSizeLimit"
+)
+
+// reportWorkSize records the serialized sizes of the work spec and status in the work status and the metrics, and
+// emits a warning event if the work spec is close to the etcd object size limit.
+// It is called after the rest of the status is built so that the status size is up-to-date.
+func (r *ApplyWorkReconciler) reportWorkSize(work *fleetv1beta1.Work) {
+	spec := work.Spec
+	if spec.Compressed {
+		// only the compressed manifests are stored while the manifests are decompressed in memory.
+		spec.Workload.Manifests = nil
+	}
+	specSize, err := serializedSize(spec)
+	if err != nil {
+		klog.ErrorS(err, "Failed to compute the work spec size", "work", klog.KObj(work))
+		return
+	}
+	work.Status.SpecSizeBytes = specSize
+	statusSize, err := serializedSize(work.Status)
+	if err != nil {
+		klog.ErrorS(err, "Failed to compute the work status size", "work", klog.KObj(work))
+		return
+	}
+	work.Status.StatusSizeBytes = statusSize
+
+	metrics.WorkSpecSizeBytes.WithLabelValues(work.Namespace, work.Name).Set(float64(specSize))
+	metrics.WorkStatusSizeBytes.WithLabelValues(work.Namespace, work.Name).Set(float64(statusSize))
+
+	if specSize*100 > defaultEtcdObjectSizeLimitBytes*specSizeWarningPercentage {
+		klog.V(2).InfoS("The work spec is near the etcd object size limit", "work", klog.KObj(work),
+			"specSizeBytes", specSize, "limitBytes", defaultEtcdObjectSizeLimitBytes)
+		r.recorder.Eventf(work, v1.EventTypeWarning, WorkSpecNearSizeLimitReason,
+			"work spec size %d bytes exceeds %d%% of the etcd object size limit %d bytes", specSize, specSizeWarningPercentage, defaultEtcdObjectSizeLimitBytes)
+	}
+}
+
+// serializedSize returns the size of the JSON serialization of the object in bytes.
+func serializedSize(obj interface{}) (int64, error) {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(raw)), nil
+}
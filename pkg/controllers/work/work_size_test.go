@@ -0,0 +1,104 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/metrics"
+	"go.goms.io/fleet/pkg/utils"
+)
+
+func TestReportWorkSize(t *testing.T) {
+	largeManifest := fleetv1beta1.Manifest{
+		RawExtension: runtime.RawExtension{
+			Raw: []byte(fmt.Sprintf(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"large","namespace":"default"},"data":{"key":%q}}`,
+				strings.Repeat("a", defaultEtcdObjectSizeLimitBytes*specSizeWarningPercentage/100))),
+		},
+	}
+	tests := map[string]struct {
+		manifests []fleetv1beta1.Manifest
+		wantEvent bool
+	}{
+		"small work": {
+			manifests: []fleetv1beta1.Manifest{configMapManifest("cm-1"), configMapManifest("cm-2")},
+		},
+		"work spec near the size limit": {
+			manifests: []fleetv1beta1.Manifest{largeManifest},
+			wantEvent: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			work := &fleetv1beta1.Work{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: fleetv1beta1.GroupVersion.String(),
+					Kind:       fleetv1beta1.WorkKind,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-work",
+					Namespace: "fleet-member-test",
+				},
+				Spec: fleetv1beta1.WorkSpec{
+					Workload: fleetv1beta1.WorkloadTemplate{Manifests: tt.manifests},
+				},
+			}
+			constructWorkCondition([]applyResult{{action: manifestAvailableAction}}, work)
+			recorder := utils.NewFakeRecorder(1)
+			r := &ApplyWorkReconciler{recorder: recorder}
+			r.reportWorkSize(work)
+
+			rawSpec, err := json.Marshal(work.Spec)
+			if err != nil {
+				t.Fatalf("failed to marshal the work spec: %v", err)
+			}
+			wantSpecSize := int64(len(rawSpec))
+			if work.Status.SpecSizeBytes != wantSpecSize {
+				t.Errorf("reportWorkSize() spec size = %d, want %d", work.Status.SpecSizeBytes, wantSpecSize)
+			}
+			// the status size is computed before the status size itself is set.
+			status := work.Status.DeepCopy()
+			status.StatusSizeBytes = 0
+			rawStatus, err := json.Marshal(status)
+			if err != nil {
+				t.Fatalf("failed to marshal the work status: %v", err)
+			}
+			wantStatusSize := int64(len(rawStatus))
+			if work.Status.StatusSizeBytes != wantStatusSize {
+				t.Errorf("reportWorkSize() status size = %d, want %d", work.Status.StatusSizeBytes, wantStatusSize)
+			}
+
+			if got := testutil.ToFloat64(metrics.WorkSpecSizeBytes.WithLabelValues(work.Namespace, work.Name)); got != float64(wantSpecSize) {
+				t.Errorf("fleet_work_spec_size_bytes = %v, want %d", got, wantSpecSize)
+			}
+			if got := testutil.ToFloat64(metrics.WorkStatusSizeBytes.WithLabelValues(work.Namespace, work.Name)); got != float64(wantStatusSize) {
+				t.Errorf("fleet_work_status_size_bytes = %v, want %d", got, wantStatusSize)
+			}
+
+			select {
+			case event := <-recorder.Events:
+				if !tt.wantEvent {
+					t.Errorf("reportWorkSize() emitted an unexpected event %q", event)
+				} else if !strings.HasPrefix(event, v1.EventTypeWarning+" "+WorkSpecNearSizeLimitReason) {
+					t.Errorf("reportWorkSize() event = %q, want a %s event", event, WorkSpecNearSizeLimitReason)
+				}
+			default:
+				if tt.wantEvent {
+					t.Errorf("reportWorkSize() emitted no event, want a %s event", WorkSpecNearSizeLimitReason)
+				}
+			}
+		})
+	}
+}
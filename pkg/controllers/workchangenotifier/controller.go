@@ -0,0 +1,248 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package workchangenotifier features a controller to notify an external system of the Work spec updates and status
+// transitions through an HTTP webhook.
+package workchangenotifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/compression"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// SignatureHeader is the HTTP header which carries the HMAC-SHA256 signature of the payload, in the form of
+	// `sha256=<hex digest>`, when a secret is configured.
+	SignatureHeader = "X-Fleet-Signature"
+
+	// notifyTimeout is the timeout of a single notification request.
+	notifyTimeout = 10 * time.Second
+)
+
+// ChangeType is the type of a Work change.
+type ChangeType string
+
+const (
+	// ChangeTypeSpecUpdated means the spec of the work is updated.
+	ChangeTypeSpecUpdated ChangeType = "SpecUpdated"
+	// ChangeTypeStatusTransition means the work or any of its manifests transits to a new condition while the spec
+	// stays the same, e.g. an applied resource drifts from the manifest in the member cluster.
+	ChangeTypeStatusTransition ChangeType = "StatusTransition"
+)
+
+// WorkChangeEvent is the payload posted to the webhook endpoint on a Work change.
+type WorkChangeEvent struct {
+	WorkName   string     `json:"workName"`
+	Namespace  string     `json:"namespace"`
+	ChangeType ChangeType `json:"changeType"`
+	// FromConditions are the work conditions before the change.
+	FromConditions []metav1.Condition `json:"fromConditions"`
+	// ToConditions are the work conditions after the change.
+	ToConditions []metav1.Condition `json:"toConditions"`
+	// AffectedManifestOrdinals are the ordinals of the manifests which are changed in the spec for a spec update, or
+	// whose conditions are changed for a status transition.
+	AffectedManifestOrdinals []int `json:"affectedManifestOrdinals"`
+}
+
+// workState is the last notified state of a work.
+type workState struct {
+	generation         int64
+	conditions         []metav1.Condition
+	manifests          []fleetv1beta1.Manifest
+	manifestConditions map[int][]metav1.Condition
+}
+
+// WorkChangeNotifier notifies the webhook endpoint of the work changes.
+// The first state of a work it observes, e.g. after the agent restarts, is recorded without a notification.
+type WorkChangeNotifier struct {
+	client     client.Client
+	url        string
+	secret     []byte
+	httpClient *http.Client
+
+	mu         sync.Mutex
+	lastStates map[types.NamespacedName]*workState
+}
+
+// NewWorkChangeNotifier creates a WorkChangeNotifier which posts the change events to the url, signed with the secret
+// if it is not empty.
+func NewWorkChangeNotifier(hubClient client.Client, url, secret string) *WorkChangeNotifier {
+	return &WorkChangeNotifier{
+		client:     hubClient,
+		url:        url,
+		secret:     []byte(secret),
+		httpClient: &http.Client{Timeout: notifyTimeout},
+		lastStates: make(map[types.NamespacedName]*workState),
+	}
+}
+
+// Reconcile compares the work with its last notified state and posts a change event if it has changed.
+func (n *WorkChangeNotifier) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var work fleetv1beta1.Work
+	if err := n.client.Get(ctx, req.NamespacedName, &work); err != nil {
+		if apierrors.IsNotFound(err) {
+			n.mu.Lock()
+			delete(n.lastStates, req.NamespacedName)
+			n.mu.Unlock()
+			return ctrl.Result{}, nil
+		}
+		klog.ErrorS(err, "Failed to get the work", "work", req.NamespacedName)
+		return ctrl.Result{}, controller.NewAPIServerError(true, err)
+	}
+
+	if err := compression.DecompressWork(&work); err != nil {
+		klog.ErrorS(err, "Failed to decompress the manifests of the work", "work", klog.KObj(&work))
+		return ctrl.Result{}, controller.NewUserError(err)
+	}
+	current := newWorkState(&work)
+	n.mu.Lock()
+	last, found := n.lastStates[req.NamespacedName]
+	n.mu.Unlock()
+	if !found {
+		n.recordState(req.NamespacedName, current)
+		return ctrl.Result{}, nil
+	}
+
+	event := buildChangeEvent(&work, last, current)
+	if event == nil {
+		return ctrl.Result{}, nil
+	}
+	if err := n.notify(ctx, event); err != nil {
+		klog.ErrorS(err, "Failed to notify the work change", "work", klog.KObj(&work), "changeType", event.ChangeType)
+		// keep the last notified state so that the change is notified again on retry.
+		return ctrl.Result{}, err
+	}
+	klog.V(2).InfoS("Notified the work change", "work", klog.KObj(&work), "changeType", event.ChangeType,
+		"affectedManifestOrdinals", event.AffectedManifestOrdinals)
+	n.recordState(req.NamespacedName, current)
+	return ctrl.Result{}, nil
+}
+
+func (n *WorkChangeNotifier) recordState(key types.NamespacedName, state *workState) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.lastStates[key] = state
+}
+
+func newWorkState(work *fleetv1beta1.Work) *workState {
+	state := &workState{
+		generation:         work.Generation,
+		conditions:         work.Status.Conditions,
+		manifests:          work.Spec.Workload.Manifests,
+		manifestConditions: make(map[int][]metav1.Condition, len(work.Status.ManifestConditions)),
+	}
+	for _, manifestCond := range work.Status.ManifestConditions {
+		state.manifestConditions[manifestCond.Identifier.Ordinal] = manifestCond.Conditions
+	}
+	return state
+}
+
+// buildChangeEvent returns the change event between the last and the current states of the work, or nil if nothing
+// worth notifying has changed.
+func buildChangeEvent(work *fleetv1beta1.Work, last, current *workState) *WorkChangeEvent {
+	event := &WorkChangeEvent{
+		WorkName:       work.Name,
+		Namespace:      work.Namespace,
+		FromConditions: last.conditions,
+		ToConditions:   current.conditions,
+	}
+	if current.generation != last.generation {
+		event.ChangeType = ChangeTypeSpecUpdated
+		for i := 0; i < len(current.manifests) || i < len(last.manifests); i++ {
+			if i >= len(current.manifests) || i >= len(last.manifests) ||
+				!bytes.Equal(current.manifests[i].Raw, last.manifests[i].Raw) {
+				event.AffectedManifestOrdinals = append(event.AffectedManifestOrdinals, i)
+			}
+		}
+		return event
+	}
+
+	for ordinal := 0; ordinal < len(current.manifests); ordinal++ {
+		if !conditionStatusesEqual(last.manifestConditions[ordinal], current.manifestConditions[ordinal]) {
+			event.AffectedManifestOrdinals = append(event.AffectedManifestOrdinals, ordinal)
+		}
+	}
+	if len(event.AffectedManifestOrdinals) == 0 && conditionStatusesEqual(last.conditions, current.conditions) {
+		return nil
+	}
+	event.ChangeType = ChangeTypeStatusTransition
+	return event
+}
+
+// conditionStatusesEqual returns true if both lists have the same condition types with the same statuses and reasons.
+// The timestamps and the messages are ignored so that a mere refresh is not reported as a transition.
+func conditionStatusesEqual(a, b []metav1.Condition) bool {
+	type conditionKey struct {
+		status metav1.ConditionStatus
+		reason string
+	}
+	toMap := func(conds []metav1.Condition) map[string]conditionKey {
+		m := make(map[string]conditionKey, len(conds))
+		for _, cond := range conds {
+			m[cond.Type] = conditionKey{status: cond.Status, reason: cond.Reason}
+		}
+		return m
+	}
+	return maps.Equal(toMap(a), toMap(b))
+}
+
+// notify posts the event to the webhook endpoint.
+func (n *WorkChangeNotifier) notify(ctx context.Context, event *WorkChangeEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the work change event: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to build the notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(n.secret) > 0 {
+		req.Header.Set(SignatureHeader, Sign(n.secret, payload))
+	}
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post the work change event: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("the webhook endpoint responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the value of the signature header of the payload.
+func Sign(secret, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (n *WorkChangeNotifier) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).Named("work-change-notifier").
+		For(&fleetv1beta1.Work{}).
+		Complete(n)
+}
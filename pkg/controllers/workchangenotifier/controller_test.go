@@ -0,0 +1,196 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package workchangenotifier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	testSecret = "test-secret"
+)
+
+func manifest(raw string) fleetv1beta1.Manifest {
+	return fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: []byte(raw)}}
+}
+
+func condition(condType string, status metav1.ConditionStatus, reason string) metav1.Condition {
+	return metav1.Condition{Type: condType, Status: status, Reason: reason}
+}
+
+func TestReconcile(t *testing.T) {
+	// postedEvent is the last event posted to the mocked webhook endpoint.
+	var postedEvent *WorkChangeEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read the request body: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if got, want := r.Header.Get(SignatureHeader), Sign([]byte(testSecret), body); got != want {
+			t.Errorf("signature header = %q, want %q", got, want)
+		}
+		postedEvent = &WorkChangeEvent{}
+		if err := json.Unmarshal(body, postedEvent); err != nil {
+			t.Errorf("failed to unmarshal the payload: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	appliedConds := []metav1.Condition{
+		condition(fleetv1beta1.WorkConditionTypeApplied, metav1.ConditionTrue, "WorkAppliedCompleted"),
+		condition(fleetv1beta1.WorkConditionTypeAvailable, metav1.ConditionTrue, "WorkAvailable"),
+	}
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-work",
+			Namespace:  "fleet-member-test",
+			Generation: 1,
+		},
+		Spec: fleetv1beta1.WorkSpec{
+			Workload: fleetv1beta1.WorkloadTemplate{
+				Manifests: []fleetv1beta1.Manifest{
+					manifest(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-1","namespace":"default"}}`),
+					manifest(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-2","namespace":"default"}}`),
+				},
+			},
+		},
+		Status: fleetv1beta1.WorkStatus{
+			Conditions: appliedConds,
+			ManifestConditions: []fleetv1beta1.ManifestCondition{
+				{Identifier: fleetv1beta1.WorkResourceIdentifier{Ordinal: 0}, Conditions: appliedConds},
+				{Identifier: fleetv1beta1.WorkResourceIdentifier{Ordinal: 1}, Conditions: appliedConds},
+			},
+		},
+	}
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(work).Build()
+	n := NewWorkChangeNotifier(hubClient, server.URL, testSecret)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: work.Name, Namespace: work.Namespace}}
+	ignoreTime := cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")
+
+	reconcile := func() {
+		t.Helper()
+		postedEvent = nil
+		if _, err := n.Reconcile(context.Background(), req); err != nil {
+			t.Fatalf("Reconcile() = %v, want no error", err)
+		}
+	}
+	update := func(mutate func(work *fleetv1beta1.Work)) {
+		t.Helper()
+		var current fleetv1beta1.Work
+		if err := hubClient.Get(context.Background(), req.NamespacedName, &current); err != nil {
+			t.Fatalf("failed to get the work: %v", err)
+		}
+		mutate(&current)
+		if err := hubClient.Update(context.Background(), &current); err != nil {
+			t.Fatalf("failed to update the work: %v", err)
+		}
+	}
+
+	// the first observed state is only recorded.
+	reconcile()
+	if postedEvent != nil {
+		t.Fatalf("Reconcile() posted %+v for the first observed state, want no event", postedEvent)
+	}
+
+	// a reconcile without any change posts nothing.
+	reconcile()
+	if postedEvent != nil {
+		t.Fatalf("Reconcile() posted %+v without a change, want no event", postedEvent)
+	}
+
+	// the second manifest is updated.
+	update(func(work *fleetv1beta1.Work) {
+		work.Generation = 2
+		work.Spec.Workload.Manifests[1] = manifest(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-2","namespace":"default"},"data":{"k":"v"}}`)
+	})
+	reconcile()
+	wantEvent := &WorkChangeEvent{
+		WorkName:                 work.Name,
+		Namespace:                work.Namespace,
+		ChangeType:               ChangeTypeSpecUpdated,
+		FromConditions:           appliedConds,
+		ToConditions:             appliedConds,
+		AffectedManifestOrdinals: []int{1},
+	}
+	if diff := cmp.Diff(wantEvent, postedEvent, ignoreTime); diff != "" {
+		t.Errorf("Reconcile() spec update event mismatch (-want +got):\n%s", diff)
+	}
+
+	// the first applied resource drifts and becomes unavailable without a spec change.
+	driftedConds := []metav1.Condition{
+		condition(fleetv1beta1.WorkConditionTypeApplied, metav1.ConditionTrue, "WorkAppliedCompleted"),
+		condition(fleetv1beta1.WorkConditionTypeAvailable, metav1.ConditionFalse, "WorkNotAvailableYet"),
+	}
+	update(func(work *fleetv1beta1.Work) {
+		work.Status.Conditions = driftedConds
+		work.Status.ManifestConditions[0].Conditions = driftedConds
+	})
+	reconcile()
+	wantEvent = &WorkChangeEvent{
+		WorkName:                 work.Name,
+		Namespace:                work.Namespace,
+		ChangeType:               ChangeTypeStatusTransition,
+		FromConditions:           appliedConds,
+		ToConditions:             driftedConds,
+		AffectedManifestOrdinals: []int{0},
+	}
+	if diff := cmp.Diff(wantEvent, postedEvent, ignoreTime); diff != "" {
+		t.Errorf("Reconcile() drift event mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReconcileNotifyFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: "fleet-member-test", Generation: 1},
+	}
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(work).Build()
+	n := NewWorkChangeNotifier(hubClient, server.URL, "")
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: work.Name, Namespace: work.Namespace}}
+	if _, err := n.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() = %v, want no error", err)
+	}
+
+	work.Generation = 2
+	if err := hubClient.Update(context.Background(), work); err != nil {
+		t.Fatalf("failed to update the work: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		// the change is notified again until the endpoint accepts it.
+		if _, err := n.Reconcile(context.Background(), req); err == nil {
+			t.Fatalf("Reconcile() = nil, want an error when the endpoint rejects the event")
+		}
+	}
+}
@@ -0,0 +1,483 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package workcompactor features a controller to merge the small works in a namespace into larger consolidated works,
+// so that the member agent runs fewer reconcile loops and keeps fewer finalizers and AppliedWorks for them.
+//
+// The compaction is transparent to the owners of the small works: the works are kept, and their manifests are applied
+// by the consolidated work they are compacted into instead of themselves, with the status of the consolidated work
+// reflected in their statuses. The resources are handed over without being deleted: the member agent keeps a
+// compacted work applied until the consolidated work has applied its manifests too, and only then releases them.
+package workcompactor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	workapplier "go.goms.io/fleet/pkg/controllers/work"
+	"go.goms.io/fleet/pkg/utils/condition"
+	"go.goms.io/fleet/pkg/utils/controller"
+	"go.goms.io/fleet/pkg/utils/resource"
+	"go.goms.io/fleet/pkg/utils/workcompaction"
+	"go.goms.io/fleet/pkg/utils/workintegrity"
+)
+
+const (
+	// SmallWorkMaxManifests is the max number of manifests of the works which are compacted.
+	SmallWorkMaxManifests = 3
+
+	// WorkCompactedReason is the reason of the condition when the manifests of a work are applied by the
+	// consolidated work it is compacted into.
+	WorkCompactedReason = "WorkCompacted"
+)
+
+// Reconciler compacts the small works of a namespace which share the same spec except for their manifests, i.e. the
+// same apply strategy and the other settings of the whole work, into consolidated works.
+//
+// A work stays compacted into the same consolidated work until it is deleted, as moving the manifests between the
+// consolidated works would hand the resources over once more. It is released from the consolidated work if it can no
+// longer be compacted, e.g. when its apply strategy changes; its resources are then applied by itself again and are
+// deleted by the consolidated work unless the work applies them first.
+type Reconciler struct {
+	Client client.Client
+	// MaxManifestsPerWork is the max number of manifests in a consolidated work.
+	MaxManifestsPerWork int
+}
+
+// consolidation is the plan of a consolidated work.
+type consolidation struct {
+	name string
+	key  string
+	// existing is the current consolidated work; it is nil if the consolidated work is to be created.
+	existing *fleetv1beta1.Work
+	// members are the works compacted into the consolidated work, in the order of their manifests.
+	members []*fleetv1beta1.Work
+}
+
+// manifestCount returns the number of manifests of the members of the consolidated work.
+func (c *consolidation) manifestCount() int {
+	count := 0
+	for _, member := range c.members {
+		count += len(member.Spec.Workload.Manifests)
+	}
+	return count
+}
+
+// Reconcile compacts the small works in the namespace of the request; the name of the request is ignored.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	namespace := req.Namespace
+	var works fleetv1beta1.WorkList
+	if err := r.Client.List(ctx, &works, client.InNamespace(namespace)); err != nil {
+		klog.ErrorS(err, "Failed to list the works", "namespace", namespace)
+		return ctrl.Result{}, controller.NewAPIServerError(true, err)
+	}
+	consolidations, released, err := r.plan(works.Items)
+	if err != nil {
+		klog.ErrorS(err, "Failed to plan the compaction of the works", "namespace", namespace)
+		return ctrl.Result{}, controller.NewUnexpectedBehaviorError(err)
+	}
+	// release the works first so that the member agent applies them again before the consolidated works drop them.
+	for _, work := range released {
+		if err := r.release(ctx, work); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	for _, c := range consolidations {
+		if err := r.consolidate(ctx, namespace, c); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// plan returns the consolidated works of the namespace with the works compacted into them, and the works to release
+// from their consolidated works.
+func (r *Reconciler) plan(works []fleetv1beta1.Work) ([]*consolidation, []*fleetv1beta1.Work, error) {
+	sources := make(map[string]*fleetv1beta1.Work, len(works))
+	var consolidations []*consolidation
+	usedNames := make(map[string]bool)
+	for i := range works {
+		work := &works[i]
+		if work.GetLabels()[fleetv1beta1.ConsolidatedWorkLabel] != "true" {
+			sources[work.Name] = work
+			continue
+		}
+		key, err := sharedSpecKey(work)
+		if err != nil {
+			return nil, nil, err
+		}
+		usedNames[work.Name] = true
+		consolidations = append(consolidations, &consolidation{name: work.Name, key: key, existing: work})
+	}
+	sort.Slice(consolidations, func(i, j int) bool { return consolidations[i].name < consolidations[j].name })
+
+	// keep the members of the existing consolidated works which can still be compacted into them.
+	assigned := make(map[string]bool)
+	var released []*fleetv1beta1.Work
+	for _, c := range consolidations {
+		records, err := workcompaction.CompactedWorks(c.existing)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, record := range records {
+			source, found := sources[record.Name]
+			if !found || !source.DeletionTimestamp.IsZero() {
+				// the manifests of a deleted work are deleted along with it.
+				continue
+			}
+			key, err := sharedSpecKey(source)
+			if err != nil {
+				return nil, nil, err
+			}
+			if key != c.key || !compactable(source, r.MaxManifestsPerWork) {
+				// the released work is compacted again, if it can be, once the member agent has applied it itself.
+				released = append(released, source)
+				continue
+			}
+			c.members = append(c.members, source)
+			assigned[source.Name] = true
+		}
+	}
+
+	// compact the other small works with the works sharing the same spec, once the member agent has applied them so
+	// that their resources exist when they are handed over.
+	candidates := make(map[string][]*fleetv1beta1.Work)
+	for _, source := range sources {
+		if assigned[source.Name] || containsWork(released, source) || !compactable(source, SmallWorkMaxManifests) ||
+			!condition.IsConditionStatusTrue(meta.FindStatusCondition(source.Status.Conditions, fleetv1beta1.WorkConditionTypeApplied), source.Generation) {
+			continue
+		}
+		key, err := sharedSpecKey(source)
+		if err != nil {
+			return nil, nil, err
+		}
+		candidates[key] = append(candidates[key], source)
+	}
+	keys := make([]string, 0, len(candidates))
+	for key := range candidates {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		pending := candidates[key]
+		sort.Slice(pending, func(i, j int) bool { return pending[i].Name < pending[j].Name })
+		var remaining []*fleetv1beta1.Work
+		for _, source := range pending {
+			if c := r.withRoom(consolidations, key, len(source.Spec.Workload.Manifests)); c != nil {
+				c.members = append(c.members, source)
+				assigned[source.Name] = true
+				continue
+			}
+			remaining = append(remaining, source)
+		}
+		// a new consolidated work is only worth it for two works or more.
+		for len(remaining) >= 2 {
+			c := &consolidation{name: nextName(usedNames, key), key: key}
+			usedNames[c.name] = true
+			for len(remaining) > 0 && c.manifestCount()+len(remaining[0].Spec.Workload.Manifests) <= r.MaxManifestsPerWork {
+				c.members = append(c.members, remaining[0])
+				assigned[remaining[0].Name] = true
+				remaining = remaining[1:]
+			}
+			if len(c.members) < 2 {
+				for _, member := range c.members {
+					delete(assigned, member.Name)
+				}
+				break
+			}
+			consolidations = append(consolidations, c)
+		}
+	}
+
+	// release the works which are marked as compacted into a consolidated work which does not have them.
+	for _, source := range sources {
+		if _, compacted := source.GetAnnotations()[fleetv1beta1.CompactedIntoAnnotation]; compacted && !assigned[source.Name] &&
+			source.DeletionTimestamp.IsZero() && !containsWork(released, source) {
+			released = append(released, source)
+		}
+	}
+	sort.Slice(released, func(i, j int) bool { return released[i].Name < released[j].Name })
+	return consolidations, released, nil
+}
+
+// withRoom returns the consolidated work of the shared spec key with room for the given number of manifests.
+func (r *Reconciler) withRoom(consolidations []*consolidation, key string, manifests int) *consolidation {
+	for _, c := range consolidations {
+		if c.key == key && c.manifestCount()+manifests <= r.MaxManifestsPerWork {
+			return c
+		}
+	}
+	return nil
+}
+
+// consolidate creates or updates the consolidated work with the manifests of its members, marks the members as
+// compacted into it and reflects its status in theirs. The consolidated work is deleted once it has no member left.
+func (r *Reconciler) consolidate(ctx context.Context, namespace string, c *consolidation) error {
+	if len(c.members) == 0 {
+		if c.existing == nil {
+			return nil
+		}
+		klog.V(2).InfoS("Delete the consolidated work without any compacted work", "work", klog.KObj(c.existing))
+		if err := r.Client.Delete(ctx, c.existing); err != nil && !apierrors.IsNotFound(err) {
+			klog.ErrorS(err, "Failed to delete the consolidated work", "work", klog.KObj(c.existing))
+			return controller.NewAPIServerError(false, err)
+		}
+		return nil
+	}
+
+	desired, records, err := buildConsolidatedWork(namespace, c)
+	if err != nil {
+		return controller.NewUnexpectedBehaviorError(err)
+	}
+	consolidated := c.existing
+	switch {
+	case consolidated == nil:
+		if err := r.Client.Create(ctx, desired); err != nil {
+			klog.ErrorS(err, "Failed to create the consolidated work", "work", klog.KObj(desired))
+			return controller.NewCreateIgnoreAlreadyExistError(err)
+		}
+		klog.V(2).InfoS("Created the consolidated work", "work", klog.KObj(desired), "compactedWorks", len(records))
+		consolidated = desired
+	case !equality.Semantic.DeepEqual(consolidated.Spec, desired.Spec) ||
+		consolidated.GetAnnotations()[fleetv1beta1.CompactedWorksAnnotation] != desired.GetAnnotations()[fleetv1beta1.CompactedWorksAnnotation]:
+		consolidated = consolidated.DeepCopy()
+		consolidated.Spec = desired.Spec
+		consolidated.SetAnnotations(desired.GetAnnotations())
+		if err := r.Client.Update(ctx, consolidated); err != nil {
+			klog.ErrorS(err, "Failed to update the consolidated work", "work", klog.KObj(consolidated))
+			return controller.NewUpdateIgnoreConflictError(err)
+		}
+		klog.V(2).InfoS("Updated the consolidated work", "work", klog.KObj(consolidated), "compactedWorks", len(records))
+	}
+
+	// the members are marked only after the consolidated work has their manifests.
+	for i, member := range c.members {
+		if err := r.markCompacted(ctx, member, consolidated, records[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// markCompacted marks the work as compacted into the consolidated work and reflects the status of the consolidated
+// work in its status.
+func (r *Reconciler) markCompacted(ctx context.Context, work, consolidated *fleetv1beta1.Work, record workcompaction.CompactedWork) error {
+	if work.GetAnnotations()[fleetv1beta1.CompactedIntoAnnotation] != consolidated.Name {
+		work = work.DeepCopy()
+		annotations := work.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+		annotations[fleetv1beta1.CompactedIntoAnnotation] = consolidated.Name
+		work.SetAnnotations(annotations)
+		if err := r.Client.Update(ctx, work); err != nil {
+			klog.ErrorS(err, "Failed to mark the work as compacted", "work", klog.KObj(work), "consolidatedWork", consolidated.Name)
+			return controller.NewUpdateIgnoreConflictError(err)
+		}
+		klog.V(2).InfoS("Compacted the work into the consolidated work", "work", klog.KObj(work), "consolidatedWork", consolidated.Name)
+	}
+
+	status := work.Status.DeepCopy()
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               fleetv1beta1.WorkConditionTypeCompacted,
+		Status:             metav1.ConditionTrue,
+		Reason:             WorkCompactedReason,
+		Message:            fmt.Sprintf("The manifests of the work are applied by the consolidated work %s", consolidated.Name),
+		ObservedGeneration: work.Generation,
+	})
+	reflectStatus(status, work, consolidated, record)
+	if equality.Semantic.DeepEqual(*status, work.Status) {
+		return nil
+	}
+	work = work.DeepCopy()
+	work.Status = *status
+	if err := r.Client.Status().Update(ctx, work); err != nil {
+		klog.ErrorS(err, "Failed to update the status of the compacted work", "work", klog.KObj(work))
+		return controller.NewUpdateIgnoreConflictError(err)
+	}
+	return nil
+}
+
+// reflectStatus sets the applied and available conditions and the manifest conditions of the compacted work from the
+// consolidated work, once the consolidated work has processed the manifests of the current generation of the work.
+func reflectStatus(status *fleetv1beta1.WorkStatus, work, consolidated *fleetv1beta1.Work, record workcompaction.CompactedWork) {
+	applied := meta.FindStatusCondition(consolidated.Status.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+	if record.Generation != work.Generation || applied == nil || applied.ObservedGeneration != consolidated.Generation {
+		return
+	}
+	for _, conditionType := range []string{fleetv1beta1.WorkConditionTypeApplied, fleetv1beta1.WorkConditionTypeAvailable} {
+		cond := meta.FindStatusCondition(consolidated.Status.Conditions, conditionType)
+		if cond == nil || cond.ObservedGeneration != consolidated.Generation {
+			continue
+		}
+		reflected := *cond
+		reflected.ObservedGeneration = work.Generation
+		meta.SetStatusCondition(&status.Conditions, reflected)
+	}
+	var manifestConditions []fleetv1beta1.ManifestCondition
+	for _, manifestCond := range consolidated.Status.ManifestConditions {
+		ordinal := manifestCond.Identifier.Ordinal
+		if ordinal < record.FirstOrdinal || ordinal >= record.FirstOrdinal+record.ManifestCount {
+			continue
+		}
+		reflected := *manifestCond.DeepCopy()
+		reflected.Identifier.Ordinal = ordinal - record.FirstOrdinal
+		manifestConditions = append(manifestConditions, reflected)
+	}
+	status.ManifestConditions = manifestConditions
+}
+
+// release undoes the compaction of the work so that the member agent applies its manifests again. The conditions
+// reflected from the consolidated work are removed along with the compacted condition, so that the work is not
+// compacted again before the member agent has applied it.
+func (r *Reconciler) release(ctx context.Context, work *fleetv1beta1.Work) error {
+	work = work.DeepCopy()
+	removed := false
+	for _, conditionType := range []string{fleetv1beta1.WorkConditionTypeCompacted, fleetv1beta1.WorkConditionTypeApplied, fleetv1beta1.WorkConditionTypeAvailable} {
+		removed = meta.RemoveStatusCondition(&work.Status.Conditions, conditionType) || removed
+	}
+	if removed {
+		if err := r.Client.Status().Update(ctx, work); err != nil {
+			klog.ErrorS(err, "Failed to update the status of the released work", "work", klog.KObj(work))
+			return controller.NewUpdateIgnoreConflictError(err)
+		}
+	}
+	delete(work.Annotations, fleetv1beta1.CompactedIntoAnnotation)
+	if err := r.Client.Update(ctx, work); err != nil {
+		klog.ErrorS(err, "Failed to release the work from its consolidated work", "work", klog.KObj(work))
+		return controller.NewUpdateIgnoreConflictError(err)
+	}
+	klog.V(2).InfoS("Released the work from its consolidated work", "work", klog.KObj(work))
+	return nil
+}
+
+// buildConsolidatedWork returns the consolidated work with the manifests of its members, and the records of the
+// members in the same order.
+func buildConsolidatedWork(namespace string, c *consolidation) (*fleetv1beta1.Work, []workcompaction.CompactedWork, error) {
+	first := c.members[0]
+	consolidated := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      c.name,
+			Namespace: namespace,
+			Labels:    map[string]string{fleetv1beta1.ConsolidatedWorkLabel: "true"},
+		},
+		Spec: *first.Spec.DeepCopy(),
+	}
+	if c.existing != nil {
+		consolidated.Labels = c.existing.Labels
+	}
+	consolidated.Spec.Workload = fleetv1beta1.WorkloadTemplate{}
+	// the members share the values of the annotations propagated to their resources.
+	annotations := make(map[string]string, len(first.Spec.PropagateAnnotations)+1)
+	for _, key := range first.Spec.PropagateAnnotations {
+		if value, found := first.GetAnnotations()[key]; found {
+			annotations[key] = value
+		}
+	}
+	records := make([]workcompaction.CompactedWork, 0, len(c.members))
+	for _, member := range c.members {
+		records = append(records, workcompaction.CompactedWork{
+			Name:          member.Name,
+			Generation:    member.Generation,
+			FirstOrdinal:  len(consolidated.Spec.Workload.Manifests),
+			ManifestCount: len(member.Spec.Workload.Manifests),
+		})
+		consolidated.Spec.Workload.Manifests = append(consolidated.Spec.Workload.Manifests, member.Spec.Workload.Manifests...)
+	}
+	checksums, err := workintegrity.ManifestChecksums(consolidated.Spec.Workload.Manifests)
+	if err != nil {
+		return nil, nil, err
+	}
+	consolidated.Spec.Workload.ManifestChecksums = checksums
+	consolidated.SetAnnotations(annotations)
+	if err := workcompaction.SetCompactedWorks(consolidated, records); err != nil {
+		return nil, nil, err
+	}
+	return consolidated, records, nil
+}
+
+// compactable returns true if the work can be compacted into a consolidated work with up to the given number of
+// manifests. The works whose manifests have settings of their own, or which are applied differently on request, are
+// never compacted.
+func compactable(work *fleetv1beta1.Work, maxManifests int) bool {
+	workload := work.Spec.Workload
+	annotations := work.GetAnnotations()
+	switch {
+	case !work.DeletionTimestamp.IsZero() || work.Spec.Compressed:
+		return false
+	case len(workload.Manifests) == 0 || len(workload.Manifests) > maxManifests:
+		return false
+	case len(workload.ManifestRetryPolicies) > 0 || len(workload.ManifestTargetNamespaces) > 0 ||
+		len(workload.ManifestBinaryData) > 0 || len(workload.ManifestHooks) > 0 || len(workload.ManifestBlobRefs) > 0:
+		return false
+	case work.GetLabels()[fleetv1beta1.WorkGroupLabel] != "":
+		// the parts of a split work are large on purpose.
+		return false
+	case annotations[workapplier.WorkSkipManifestOrdinalsAnnotation] != "" || annotations[workapplier.WorkPreApplyDryRunAnnotation] != "":
+		return false
+	}
+	return true
+}
+
+// sharedSpecKey returns the hash of what the works compacted together must share: the spec except for the manifests,
+// and the values of the annotations propagated to their resources.
+func sharedSpecKey(work *fleetv1beta1.Work) (string, error) {
+	spec := work.Spec.DeepCopy()
+	spec.Workload = fleetv1beta1.WorkloadTemplate{}
+	propagated := make(map[string]string, len(spec.PropagateAnnotations))
+	for _, key := range spec.PropagateAnnotations {
+		propagated[key] = work.GetAnnotations()[key]
+	}
+	key, err := resource.HashOf(struct {
+		Spec        *fleetv1beta1.WorkSpec `json:"spec"`
+		Annotations map[string]string      `json:"annotations"`
+	}{Spec: spec, Annotations: propagated})
+	if err != nil {
+		return "", fmt.Errorf("failed to compute the shared spec key of the work %s: %w", work.Name, err)
+	}
+	return key, nil
+}
+
+// nextName returns the name of a new consolidated work for the shared spec key which is not used yet.
+func nextName(used map[string]bool, key string) string {
+	for index := 0; ; index++ {
+		if name := fmt.Sprintf(fleetv1beta1.ConsolidatedWorkNameFmt, key[:8], index); !used[name] {
+			return name
+		}
+	}
+}
+
+// containsWork returns true if the works contain the work.
+func containsWork(works []*fleetv1beta1.Work, work *fleetv1beta1.Work) bool {
+	for _, w := range works {
+		if w.Name == work.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// SetupWithManager sets up the controller with the Manager. Every work event enqueues the namespace of the work.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).Named("work-compactor").
+		Watches(&fleetv1beta1.Work{}, handler.EnqueueRequestsFromMapFunc(
+			func(_ context.Context, o client.Object) []reconcile.Request {
+				return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: o.GetNamespace()}}}
+			})).
+		Complete(r)
+}
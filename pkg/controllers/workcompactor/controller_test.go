@@ -0,0 +1,232 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package workcompactor
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/workcompaction"
+)
+
+const namespace = "fleet-member-test"
+
+// appliedCondition returns the applied condition of the given generation.
+func appliedCondition(generation int64) metav1.Condition {
+	return metav1.Condition{
+		Type:               fleetv1beta1.WorkConditionTypeApplied,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Applied",
+		ObservedGeneration: generation,
+		LastTransitionTime: metav1.Now(),
+	}
+}
+
+// configMapWork returns a work of the ConfigMaps with the given names, applied by the member agent.
+func configMapWork(name string, strategy fleetv1beta1.ApplyStrategyType, configMaps ...string) *fleetv1beta1.Work {
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Generation: 1},
+		Spec:       fleetv1beta1.WorkSpec{ApplyStrategy: &fleetv1beta1.ApplyStrategy{Type: strategy}},
+		Status:     fleetv1beta1.WorkStatus{Conditions: []metav1.Condition{appliedCondition(1)}},
+	}
+	for _, cm := range configMaps {
+		raw := fmt.Sprintf(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":%q,"namespace":"default"}}`, cm)
+		work.Spec.Workload.Manifests = append(work.Spec.Workload.Manifests, fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: []byte(raw)}})
+	}
+	return work
+}
+
+// consolidatedWorks returns the consolidated works in the namespace by name.
+func consolidatedWorks(t *testing.T, hubClient client.Client) map[string]fleetv1beta1.Work {
+	t.Helper()
+	var works fleetv1beta1.WorkList
+	if err := hubClient.List(context.Background(), &works, client.InNamespace(namespace),
+		client.MatchingLabels{fleetv1beta1.ConsolidatedWorkLabel: "true"}); err != nil {
+		t.Fatalf("failed to list the consolidated works: %v", err)
+	}
+	consolidated := make(map[string]fleetv1beta1.Work, len(works.Items))
+	for _, work := range works.Items {
+		consolidated[work.Name] = work
+	}
+	return consolidated
+}
+
+func getWork(t *testing.T, hubClient client.Client, name string) *fleetv1beta1.Work {
+	t.Helper()
+	var work fleetv1beta1.Work
+	if err := hubClient.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: name}, &work); err != nil {
+		t.Fatalf("failed to get the work %s: %v", name, err)
+	}
+	return &work
+}
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	works := []client.Object{
+		configMapWork("work-a", fleetv1beta1.ApplyStrategyTypeClientSideApply, "a"),
+		configMapWork("work-b", fleetv1beta1.ApplyStrategyTypeClientSideApply, "b1", "b2"),
+		configMapWork("work-c", fleetv1beta1.ApplyStrategyTypeClientSideApply, "c"),
+		// a work with another apply strategy is not compacted with the others.
+		configMapWork("work-ssa", fleetv1beta1.ApplyStrategyTypeServerSideApply, "ssa"),
+		// a work which is not small is not compacted.
+		configMapWork("work-large", fleetv1beta1.ApplyStrategyTypeClientSideApply, "l1", "l2", "l3", "l4"),
+	}
+	// a work which the member agent has not applied yet is not compacted.
+	pending := configMapWork("work-pending", fleetv1beta1.ApplyStrategyTypeClientSideApply, "p")
+	pending.Status.Conditions = nil
+	works = append(works, pending)
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(works...).WithStatusSubresource(works...).Build()
+	r := &Reconciler{Client: hubClient, MaxManifestsPerWork: 10}
+	reconcile := func() {
+		t.Helper()
+		if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace}}); err != nil {
+			t.Fatalf("Reconcile() = %v, want no error", err)
+		}
+	}
+
+	// the small works sharing the same apply strategy are compacted into one consolidated work.
+	reconcile()
+	all := consolidatedWorks(t, hubClient)
+	if len(all) != 1 {
+		t.Fatalf("consolidated works = %d, want 1", len(all))
+	}
+	var consolidated fleetv1beta1.Work
+	for _, work := range all {
+		consolidated = work
+	}
+	if got, want := len(consolidated.Spec.Workload.Manifests), 4; got != want {
+		t.Errorf("consolidated work manifests = %d, want %d", got, want)
+	}
+	if diff := cmp.Diff(fleetv1beta1.ApplyStrategyTypeClientSideApply, consolidated.Spec.ApplyStrategy.Type); diff != "" {
+		t.Errorf("consolidated work apply strategy mismatch (-want, +got):\n%s", diff)
+	}
+	records, err := workcompaction.CompactedWorks(&consolidated)
+	if err != nil {
+		t.Fatalf("CompactedWorks() = %v, want no error", err)
+	}
+	wantRecords := []workcompaction.CompactedWork{
+		{Name: "work-a", Generation: 1, FirstOrdinal: 0, ManifestCount: 1},
+		{Name: "work-b", Generation: 1, FirstOrdinal: 1, ManifestCount: 2},
+		{Name: "work-c", Generation: 1, FirstOrdinal: 3, ManifestCount: 1},
+	}
+	if diff := cmp.Diff(wantRecords, records); diff != "" {
+		t.Errorf("compacted works mismatch (-want, +got):\n%s", diff)
+	}
+	for name, wantCompacted := range map[string]bool{"work-a": true, "work-b": true, "work-c": true, "work-ssa": false, "work-large": false, "work-pending": false} {
+		work := getWork(t, hubClient, name)
+		if got := work.Annotations[fleetv1beta1.CompactedIntoAnnotation]; (got == consolidated.Name) != wantCompacted {
+			t.Errorf("work %s compacted into %q, want compacted %t", name, got, wantCompacted)
+		}
+		if got := meta.IsStatusConditionTrue(work.Status.Conditions, fleetv1beta1.WorkConditionTypeCompacted); got != wantCompacted {
+			t.Errorf("work %s compacted condition = %t, want %t", name, got, wantCompacted)
+		}
+	}
+
+	// the status of the consolidated work is reflected in the statuses of the compacted works.
+	consolidated.Status.Conditions = []metav1.Condition{
+		{Type: fleetv1beta1.WorkConditionTypeApplied, Status: metav1.ConditionTrue, Reason: "Applied", ObservedGeneration: consolidated.Generation, LastTransitionTime: metav1.Now()},
+		{Type: fleetv1beta1.WorkConditionTypeAvailable, Status: metav1.ConditionTrue, Reason: "Available", ObservedGeneration: consolidated.Generation, LastTransitionTime: metav1.Now()},
+	}
+	for i, name := range []string{"a", "b1", "b2", "c"} {
+		consolidated.Status.ManifestConditions = append(consolidated.Status.ManifestConditions, fleetv1beta1.ManifestCondition{
+			Identifier: fleetv1beta1.WorkResourceIdentifier{Ordinal: i, Version: "v1", Kind: "ConfigMap", Namespace: "default", Name: name},
+		})
+	}
+	if err := hubClient.Status().Update(context.Background(), &consolidated); err != nil {
+		t.Fatalf("failed to update the consolidated work status: %v", err)
+	}
+	reconcile()
+	workB := getWork(t, hubClient, "work-b")
+	for _, conditionType := range []string{fleetv1beta1.WorkConditionTypeApplied, fleetv1beta1.WorkConditionTypeAvailable} {
+		cond := meta.FindStatusCondition(workB.Status.Conditions, conditionType)
+		if cond == nil || cond.Status != metav1.ConditionTrue || cond.ObservedGeneration != workB.Generation {
+			t.Errorf("work-b %s condition = %+v, want true for generation %d", conditionType, cond, workB.Generation)
+		}
+	}
+	wantManifestConditions := []fleetv1beta1.ManifestCondition{
+		{Identifier: fleetv1beta1.WorkResourceIdentifier{Ordinal: 0, Version: "v1", Kind: "ConfigMap", Namespace: "default", Name: "b1"}},
+		{Identifier: fleetv1beta1.WorkResourceIdentifier{Ordinal: 1, Version: "v1", Kind: "ConfigMap", Namespace: "default", Name: "b2"}},
+	}
+	if diff := cmp.Diff(wantManifestConditions, workB.Status.ManifestConditions); diff != "" {
+		t.Errorf("work-b manifest conditions mismatch (-want, +got):\n%s", diff)
+	}
+
+	// the manifests of a deleted work are dropped, and a work whose apply strategy changes is released.
+	if err := hubClient.Delete(context.Background(), getWork(t, hubClient, "work-a")); err != nil {
+		t.Fatalf("failed to delete work-a: %v", err)
+	}
+	workC := getWork(t, hubClient, "work-c")
+	workC.Spec.ApplyStrategy.Type = fleetv1beta1.ApplyStrategyTypeServerSideApply
+	if err := hubClient.Update(context.Background(), workC); err != nil {
+		t.Fatalf("failed to update work-c: %v", err)
+	}
+	reconcile()
+	consolidated = consolidatedWorks(t, hubClient)[consolidated.Name]
+	records, err = workcompaction.CompactedWorks(&consolidated)
+	if err != nil {
+		t.Fatalf("CompactedWorks() = %v, want no error", err)
+	}
+	wantRecords = []workcompaction.CompactedWork{{Name: "work-b", Generation: 1, FirstOrdinal: 0, ManifestCount: 2}}
+	if diff := cmp.Diff(wantRecords, records); diff != "" {
+		t.Errorf("compacted works after the changes mismatch (-want, +got):\n%s", diff)
+	}
+	if got := len(consolidated.Spec.Workload.Manifests); got != 2 {
+		t.Errorf("consolidated work manifests after the changes = %d, want 2", got)
+	}
+	workC = getWork(t, hubClient, "work-c")
+	if _, compacted := workC.Annotations[fleetv1beta1.CompactedIntoAnnotation]; compacted {
+		t.Errorf("work-c is still compacted after its apply strategy changed")
+	}
+	if meta.FindStatusCondition(workC.Status.Conditions, fleetv1beta1.WorkConditionTypeCompacted) != nil {
+		t.Errorf("work-c still has the compacted condition after it is released")
+	}
+
+	// the released work is compacted with the small work of the same new apply strategy once the member agent has
+	// applied it itself.
+	reconcile()
+	if got := len(consolidatedWorks(t, hubClient)); got != 1 {
+		t.Fatalf("consolidated works before work-c is applied = %d, want 1", got)
+	}
+	meta.SetStatusCondition(&workC.Status.Conditions, appliedCondition(workC.Generation))
+	if err := hubClient.Status().Update(context.Background(), workC); err != nil {
+		t.Fatalf("failed to update the work-c status: %v", err)
+	}
+	reconcile()
+	all = consolidatedWorks(t, hubClient)
+	if len(all) != 2 {
+		t.Fatalf("consolidated works after the changes = %d, want 2", len(all))
+	}
+	for name, work := range all {
+		if name == consolidated.Name {
+			continue
+		}
+		records, err := workcompaction.CompactedWorks(&work)
+		if err != nil {
+			t.Fatalf("CompactedWorks() = %v, want no error", err)
+		}
+		wantRecords := []workcompaction.CompactedWork{
+			{Name: "work-c", Generation: 1, FirstOrdinal: 0, ManifestCount: 1},
+			{Name: "work-ssa", Generation: 1, FirstOrdinal: 1, ManifestCount: 1},
+		}
+		if diff := cmp.Diff(wantRecords, records); diff != "" {
+			t.Errorf("compacted works of the new consolidated work mismatch (-want, +got):\n%s", diff)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package workdrreplication features a controller to copy the approved Work objects of a hub cluster to a disaster
+// recovery hub cluster.
+package workdrreplication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/controllers/workreplicator"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// workDRReplicatedReason is the reason of the WorkDRReplicated condition when all the works are copied.
+	workDRReplicatedReason = "AllWorkDRReplicated"
+	// workDRReplicateFailedReason is the reason of the WorkDRReplicated condition when some works fail to copy.
+	workDRReplicateFailedReason = "ReplicateWorkFailed"
+	// invalidWorkDRReplicationReason is the reason of the WorkDRReplicated condition when the replication is invalid.
+	invalidWorkDRReplicationReason = "InvalidWorkDRReplication"
+
+	// defaultSyncInterval is how often the works are copied if the replication does not set its sync interval.
+	defaultSyncInterval = time.Minute
+)
+
+// KubeConfigClientGetter returns the clients of the hub clusters from the kubeconfig stored in the Secrets in the
+// fleet system namespace.
+type KubeConfigClientGetter interface {
+	KubeConfigClient(ctx context.Context, secretName, key string) (client.Client, error)
+}
+
+var _ KubeConfigClientGetter = &workreplicator.SecretHubClientGetter{}
+
+// Reconciler reconciles a WorkDRReplication object.
+type Reconciler struct {
+	// Client is the client the controller uses to access the hub cluster the replications are in.
+	client.Client
+	// HubClientGetter returns the clients of the source and the disaster recovery hubs.
+	HubClientGetter KubeConfigClientGetter
+}
+
+// Reconcile copies the approved works of the source hub to the disaster recovery hub and deletes the replicas of the
+// works which are deleted, once every sync interval.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	wdrRef := klog.KRef("", req.Name)
+	startTime := time.Now()
+	klog.V(2).InfoS("WorkDRReplication reconciliation starts", "workDRReplication", wdrRef)
+	defer func() {
+		latency := time.Since(startTime).Milliseconds()
+		klog.V(2).InfoS("WorkDRReplication reconciliation ends", "workDRReplication", wdrRef, "latency", latency)
+	}()
+
+	var wdr fleetv1beta1.WorkDRReplication
+	if err := r.Client.Get(ctx, req.NamespacedName, &wdr); err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.V(2).InfoS("Ignoring NotFound workDRReplication", "workDRReplication", wdrRef)
+			return ctrl.Result{}, nil
+		}
+		klog.ErrorS(err, "Failed to get workDRReplication", "workDRReplication", wdrRef)
+		return ctrl.Result{}, controller.NewAPIServerError(true, err)
+	}
+	if wdr.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+	syncInterval := defaultSyncInterval
+	if wdr.Spec.SyncInterval != nil && wdr.Spec.SyncInterval.Duration > 0 {
+		syncInterval = wdr.Spec.SyncInterval.Duration
+	}
+
+	replicatedCond := metav1.Condition{
+		Type:               string(fleetv1beta1.WorkDRReplicationConditionTypeReplicated),
+		Status:             metav1.ConditionTrue,
+		Reason:             workDRReplicatedReason,
+		ObservedGeneration: wdr.Generation,
+	}
+	replicated, err := r.replicate(ctx, &wdr)
+	switch {
+	case errors.Is(err, controller.ErrUserError):
+		replicatedCond.Status = metav1.ConditionFalse
+		replicatedCond.Reason = invalidWorkDRReplicationReason
+		replicatedCond.Message = err.Error()
+	case err != nil:
+		replicatedCond.Status = metav1.ConditionFalse
+		replicatedCond.Reason = workDRReplicateFailedReason
+		replicatedCond.Message = err.Error()
+	default:
+		now := metav1.Now()
+		wdr.Status.ReplicatedWorks = replicated
+		wdr.Status.LastSyncTime = &now
+		replicatedCond.Message = fmt.Sprintf("%d works are copied to the disaster recovery hub", replicated)
+	}
+	wdr.SetConditions(replicatedCond)
+	if err := r.Client.Status().Update(ctx, &wdr); err != nil {
+		klog.ErrorS(err, "Failed to update the workDRReplication status", "workDRReplication", wdrRef)
+		return ctrl.Result{}, controller.NewUpdateIgnoreConflictError(err)
+	}
+	if err != nil && !errors.Is(err, controller.ErrUserError) {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: syncInterval}, nil
+}
+
+// replicate copies the approved works of the source hub to the disaster recovery hub and returns the number of the
+// works copied.
+func (r *Reconciler) replicate(ctx context.Context, wdr *fleetv1beta1.WorkDRReplication) (int, error) {
+	if wdr.Spec.DRHubClient == nil {
+		return 0, controller.NewUserError(errors.New("the disaster recovery hub is not set"))
+	}
+	if source := wdr.Spec.SourceHubClient; source != nil && *source == *wdr.Spec.DRHubClient {
+		return 0, controller.NewUserError(fmt.Errorf("the source hub and the disaster recovery hub are the same secret %q", source.SecretName))
+	}
+	sourceClient := r.Client
+	if wdr.Spec.SourceHubClient != nil {
+		var err error
+		if sourceClient, err = r.hubClient(ctx, wdr.Spec.SourceHubClient); err != nil {
+			return 0, err
+		}
+	}
+	drClient, err := r.hubClient(ctx, wdr.Spec.DRHubClient)
+	if err != nil {
+		return 0, err
+	}
+
+	var sourceList fleetv1beta1.WorkList
+	if err := sourceClient.List(ctx, &sourceList); err != nil {
+		klog.ErrorS(err, "Failed to list the works on the source hub", "workDRReplication", klog.KObj(wdr))
+		return 0, controller.NewAPIServerError(false, err)
+	}
+	var replicaList fleetv1beta1.WorkList
+	if err := drClient.List(ctx, &replicaList, client.MatchingLabels{fleetv1beta1.WorkDRReplicationTrackingLabel: wdr.Name}); err != nil {
+		klog.ErrorS(err, "Failed to list the work replicas on the disaster recovery hub", "workDRReplication", klog.KObj(wdr))
+		return 0, controller.NewAPIServerError(false, err)
+	}
+	replicas := make(map[types.NamespacedName]*fleetv1beta1.Work, len(replicaList.Items))
+	for i := range replicaList.Items {
+		replica := &replicaList.Items[i]
+		replicas[types.NamespacedName{Name: replica.Name, Namespace: replica.Namespace}] = replica
+	}
+
+	var replicateErr error
+	replicated := 0
+	for i := range sourceList.Items {
+		source := &sourceList.Items[i]
+		key := types.NamespacedName{Name: source.Name, Namespace: source.Namespace}
+		if source.DeletionTimestamp != nil || source.Annotations[fleetv1beta1.DRReplicaAnnotation] == "true" {
+			continue
+		}
+		existing := replicas[key]
+		delete(replicas, key)
+		// the replica of a work whose changes wait for approval keeps the spec approved last.
+		if len(source.Status.PendingApprovalDiff) > 0 {
+			klog.V(2).InfoS("Skipping the work pending approval", "workDRReplication", klog.KObj(wdr), "work", key)
+			continue
+		}
+		if err := upsertReplica(ctx, drClient, existing, buildReplica(wdr, source)); err != nil {
+			klog.ErrorS(err, "Failed to copy the work to the disaster recovery hub", "workDRReplication", klog.KObj(wdr), "work", key)
+			replicateErr = controller.NewAPIServerError(false, err)
+			continue
+		}
+		replicated++
+	}
+	for key, replica := range replicas {
+		klog.V(2).InfoS("Deleting the work replica", "workDRReplication", klog.KObj(wdr), "work", key)
+		if err := drClient.Delete(ctx, replica); err != nil && !apierrors.IsNotFound(err) {
+			klog.ErrorS(err, "Failed to delete the work replica", "workDRReplication", klog.KObj(wdr), "work", key)
+			replicateErr = controller.NewAPIServerError(false, err)
+		}
+	}
+	return replicated, replicateErr
+}
+
+// hubClient returns the client of the hub whose kubeconfig the reference refers to.
+func (r *Reconciler) hubClient(ctx context.Context, ref *fleetv1beta1.KubeconfigRef) (client.Client, error) {
+	key := ref.Key
+	if key == "" {
+		key = workreplicator.HubKubeConfigSecretKey
+	}
+	return r.HubClientGetter.KubeConfigClient(ctx, ref.SecretName, key)
+}
+
+// upsertReplica creates the desired replica if it does not exist yet, or updates the existing one if it differs.
+func upsertReplica(ctx context.Context, drClient client.Client, existing, desired *fleetv1beta1.Work) error {
+	if existing == nil {
+		return drClient.Create(ctx, desired)
+	}
+	if equality.Semantic.DeepEqual(existing.Spec, desired.Spec) &&
+		equality.Semantic.DeepEqual(existing.Labels, desired.Labels) &&
+		equality.Semantic.DeepEqual(existing.Annotations, desired.Annotations) {
+		return nil
+	}
+	existing.Labels = desired.Labels
+	existing.Annotations = desired.Annotations
+	existing.Spec = desired.Spec
+	return drClient.Update(ctx, existing)
+}
+
+// buildReplica builds the replica of the source work; only the spec is copied, not the status.
+func buildReplica(wdr *fleetv1beta1.WorkDRReplication, source *fleetv1beta1.Work) *fleetv1beta1.Work {
+	replicaLabels := make(map[string]string, len(source.Labels)+1)
+	for k, v := range source.Labels {
+		replicaLabels[k] = v
+	}
+	replicaLabels[fleetv1beta1.WorkDRReplicationTrackingLabel] = wdr.Name
+	replicaAnnotations := make(map[string]string, len(source.Annotations)+1)
+	for k, v := range source.Annotations {
+		replicaAnnotations[k] = v
+	}
+	replicaAnnotations[fleetv1beta1.DRReplicaAnnotation] = "true"
+	return &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        source.Name,
+			Namespace:   source.Namespace,
+			Labels:      replicaLabels,
+			Annotations: replicaAnnotations,
+		},
+		Spec: *source.Spec.DeepCopy(),
+	}
+}
+
+// SetupWithManager sets up the controller with the manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).Named("work-dr-replication-controller").
+		For(&fleetv1beta1.WorkDRReplication{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
@@ -0,0 +1,202 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package workdrreplication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	sourceHubSecret = "source-hub"
+	drHubSecret     = "dr-hub"
+
+	workNamespace = "fleet-member-test"
+	syncInterval  = 10 * time.Second
+)
+
+// fakeHubClientGetter returns the clients of the mocked hubs keyed by the names of their secrets; the hubs which are
+// not mocked are unreachable.
+type fakeHubClientGetter map[string]client.Client
+
+func (g fakeHubClientGetter) KubeConfigClient(_ context.Context, secretName, _ string) (client.Client, error) {
+	hubClient, found := g[secretName]
+	if !found {
+		return nil, controller.NewUserError(fmt.Errorf("the kubeconfig secret %q is not found", secretName))
+	}
+	return hubClient, nil
+}
+
+func testScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	return scheme
+}
+
+func testWork(name, manifest string) *fleetv1beta1.Work {
+	return &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: workNamespace, Labels: map[string]string{"app": name}},
+		Spec: fleetv1beta1.WorkSpec{
+			Workload: fleetv1beta1.WorkloadTemplate{
+				Manifests: []fleetv1beta1.Manifest{{RawExtension: runtime.RawExtension{Raw: []byte(manifest)}}},
+			},
+		},
+	}
+}
+
+func testReplication(name string) *fleetv1beta1.WorkDRReplication {
+	return &fleetv1beta1.WorkDRReplication{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: fleetv1beta1.WorkDRReplicationSpec{
+			SourceHubClient: &fleetv1beta1.KubeconfigRef{SecretName: sourceHubSecret},
+			DRHubClient:     &fleetv1beta1.KubeconfigRef{SecretName: drHubSecret},
+			SyncInterval:    &metav1.Duration{Duration: syncInterval},
+		},
+	}
+}
+
+// replicas returns the work replicas on the hub keyed by their names.
+func replicas(t *testing.T, hubClient client.Client) map[string]fleetv1beta1.Work {
+	t.Helper()
+	var workList fleetv1beta1.WorkList
+	if err := hubClient.List(context.Background(), &workList); err != nil {
+		t.Fatalf("failed to list the works: %v", err)
+	}
+	works := make(map[string]fleetv1beta1.Work, len(workList.Items))
+	for _, work := range workList.Items {
+		works[work.Name] = work
+	}
+	return works
+}
+
+func TestReconcile(t *testing.T) {
+	const (
+		manifest        = `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm","namespace":"default"}}`
+		updatedManifest = `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm","namespace":"kube-system"}}`
+	)
+	ctx := context.Background()
+	scheme := testScheme(t)
+	wdr := testReplication("to-dr")
+	applied := testWork("applied", manifest)
+	applied.Status.ManifestConditions = []fleetv1beta1.ManifestCondition{{Identifier: fleetv1beta1.WorkResourceIdentifier{Name: "cm"}}}
+	pending := testWork("pending", manifest)
+	pending.Status.PendingApprovalDiff = []fleetv1beta1.PendingManifestChange{{Identifier: fleetv1beta1.WorkResourceIdentifier{Name: "cm"}}}
+	replicationHub := fake.NewClientBuilder().WithScheme(scheme).WithObjects(wdr).WithStatusSubresource(wdr).Build()
+	sourceHub := fake.NewClientBuilder().WithScheme(scheme).WithObjects(applied, pending).WithStatusSubresource(applied, pending).Build()
+	drHub := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r := &Reconciler{
+		Client:          replicationHub,
+		HubClientGetter: fakeHubClientGetter{sourceHubSecret: sourceHub, drHubSecret: drHub},
+	}
+	reconcile := func() {
+		t.Helper()
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: wdr.Name}})
+		if err != nil {
+			t.Fatalf("Reconcile() = %v, want no error", err)
+		}
+		if result.RequeueAfter != syncInterval {
+			t.Fatalf("Reconcile() requeue after = %v, want the sync interval %v", result.RequeueAfter, syncInterval)
+		}
+	}
+
+	// the approved work is copied without its status, and the work pending approval is left out.
+	reconcile()
+	got := replicas(t, drHub)
+	if len(got) != 1 {
+		t.Fatalf("replicas on the DR hub = %v, want only the applied work", got)
+	}
+	replica := got["applied"]
+	if diff := cmp.Diff(applied.Spec, replica.Spec); diff != "" {
+		t.Errorf("replica spec mismatch (-want, +got):\n%s", diff)
+	}
+	if replica.Annotations[fleetv1beta1.DRReplicaAnnotation] != "true" || replica.Labels[fleetv1beta1.WorkDRReplicationTrackingLabel] != wdr.Name {
+		t.Errorf("replica metadata = %+v, want the DR replica annotation and the tracking label", replica.ObjectMeta)
+	}
+	if len(replica.Status.ManifestConditions) != 0 {
+		t.Errorf("replica status = %+v, want an empty status", replica.Status)
+	}
+
+	// the spec updates of the source works are propagated in the next sync.
+	var source fleetv1beta1.Work
+	if err := sourceHub.Get(ctx, types.NamespacedName{Name: "applied", Namespace: workNamespace}, &source); err != nil {
+		t.Fatalf("failed to get the source work: %v", err)
+	}
+	source.Spec = testWork("", updatedManifest).Spec
+	if err := sourceHub.Update(ctx, &source); err != nil {
+		t.Fatalf("failed to update the source work: %v", err)
+	}
+	reconcile()
+	if diff := cmp.Diff(source.Spec, replicas(t, drHub)["applied"].Spec); diff != "" {
+		t.Errorf("replica spec mismatch after the update (-want, +got):\n%s", diff)
+	}
+
+	// the replica is deleted once its source work is deleted.
+	if err := sourceHub.Delete(ctx, &source); err != nil {
+		t.Fatalf("failed to delete the source work: %v", err)
+	}
+	reconcile()
+	if got := replicas(t, drHub); len(got) != 0 {
+		t.Errorf("replicas on the DR hub = %v, want none", got)
+	}
+
+	var updated fleetv1beta1.WorkDRReplication
+	if err := replicationHub.Get(ctx, types.NamespacedName{Name: wdr.Name}, &updated); err != nil {
+		t.Fatalf("failed to get the workDRReplication: %v", err)
+	}
+	cond := updated.GetCondition(string(fleetv1beta1.WorkDRReplicationConditionTypeReplicated))
+	if cond == nil || cond.Status != metav1.ConditionTrue || updated.Status.LastSyncTime == nil {
+		t.Errorf("workDRReplication status = %+v, want replicated with the last sync time", updated.Status)
+	}
+}
+
+func TestReconcileUnreachableDRHub(t *testing.T) {
+	ctx := context.Background()
+	scheme := testScheme(t)
+	wdr := testReplication("to-dr")
+	replicationHub := fake.NewClientBuilder().WithScheme(scheme).WithObjects(wdr).WithStatusSubresource(wdr).Build()
+	r := &Reconciler{
+		Client:          replicationHub,
+		HubClientGetter: fakeHubClientGetter{sourceHubSecret: fake.NewClientBuilder().WithScheme(scheme).Build()},
+	}
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: wdr.Name}})
+	if err != nil || result.RequeueAfter != syncInterval {
+		t.Fatalf("Reconcile() = %+v, %v, want a requeue after the sync interval", result, err)
+	}
+	var updated fleetv1beta1.WorkDRReplication
+	if err := replicationHub.Get(ctx, types.NamespacedName{Name: wdr.Name}, &updated); err != nil {
+		t.Fatalf("failed to get the workDRReplication: %v", err)
+	}
+	cond := updated.GetCondition(string(fleetv1beta1.WorkDRReplicationConditionTypeReplicated))
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != invalidWorkDRReplicationReason {
+		t.Errorf("WorkDRReplicated condition = %+v, want False with reason %s", cond, invalidWorkDRReplicationReason)
+	}
+}
+
+func TestReplicateSameHub(t *testing.T) {
+	wdr := testReplication("to-self")
+	wdr.Spec.DRHubClient = wdr.Spec.SourceHubClient
+	r := &Reconciler{HubClientGetter: fakeHubClientGetter{}}
+	if _, err := r.replicate(context.Background(), wdr); !errors.Is(err, controller.ErrUserError) {
+		t.Errorf("replicate() = %v, want user error", err)
+	}
+}
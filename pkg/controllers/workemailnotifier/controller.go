@@ -0,0 +1,243 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package workemailnotifier features a controller to email a diff summary of a Work when it drifts or fails to apply
+// after it has been applied, through a configurable email gateway.
+package workemailnotifier
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/condition"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// NotifyEmailAnnotation is the annotation of a Work which carries the email address notified of its transitions.
+	NotifyEmailAnnotation = "fleet.azure.com/notify-email"
+
+	// DefaultCooldown is the default minimum interval between two emails of the same Work.
+	DefaultCooldown = time.Hour
+)
+
+// Transition is the type of the Work transition an email notifies of.
+type Transition string
+
+const (
+	// TransitionDrifted means some resources of an applied Work drift from their manifests in the member cluster.
+	TransitionDrifted Transition = "Drifted"
+	// TransitionApplyFailed means some manifests of an applied Work fail to apply.
+	TransitionApplyFailed Transition = "ApplyFailed"
+)
+
+// ManifestDiff is the change of a manifest reported in an email.
+type ManifestDiff struct {
+	Identifier fleetv1beta1.WorkResourceIdentifier `json:"identifier"`
+	// Reason is the reason of the Applied condition of the manifest, or the type of the event for a drift.
+	Reason  string `json:"reason"`
+	Message string `json:"message,omitempty"`
+}
+
+// EmailNotification is the email sent to the email gateway on a Work transition.
+type EmailNotification struct {
+	To               string     `json:"to"`
+	Subject          string     `json:"subject"`
+	WorkName         string     `json:"workName"`
+	ClusterNamespace string     `json:"clusterNamespace"`
+	Transition       Transition `json:"transition"`
+	// Manifests are the manifests which drifted or failed to apply.
+	Manifests []ManifestDiff `json:"manifests"`
+}
+
+// workState is the last observed state of a work.
+type workState struct {
+	applied bool
+	// lastDriftAt is the time of the latest drift event of the work.
+	lastDriftAt metav1.Time
+}
+
+// WorkEmailNotifier emails a diff summary of the annotated works when they transit from Applied=True to drifted or
+// Applied=False, at most once per work within the cooldown. The first state of a work it observes, e.g. after the
+// agent restarts, is recorded without a notification.
+type WorkEmailNotifier struct {
+	client   client.Client
+	gateway  Gateway
+	cooldown time.Duration
+	// now returns the current time; it is replaced in the tests.
+	now func() time.Time
+
+	mu         sync.Mutex
+	lastStates map[types.NamespacedName]*workState
+	lastSentAt map[types.NamespacedName]time.Time
+}
+
+// NewWorkEmailNotifier creates a WorkEmailNotifier which sends the emails through the gateway.
+func NewWorkEmailNotifier(hubClient client.Client, gateway Gateway, cooldown time.Duration) *WorkEmailNotifier {
+	return &WorkEmailNotifier{
+		client:     hubClient,
+		gateway:    gateway,
+		cooldown:   cooldown,
+		now:        time.Now,
+		lastStates: make(map[types.NamespacedName]*workState),
+		lastSentAt: make(map[types.NamespacedName]time.Time),
+	}
+}
+
+// Reconcile compares the work with its last observed state and emails the transition if there is one.
+func (n *WorkEmailNotifier) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var work fleetv1beta1.Work
+	if err := n.client.Get(ctx, req.NamespacedName, &work); err != nil {
+		if apierrors.IsNotFound(err) {
+			n.forget(req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		klog.ErrorS(err, "Failed to get the work", "work", req.NamespacedName)
+		return ctrl.Result{}, controller.NewAPIServerError(true, err)
+	}
+	to := work.GetAnnotations()[NotifyEmailAnnotation]
+	if to == "" {
+		n.forget(req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+
+	current := newWorkState(&work)
+	n.mu.Lock()
+	last, found := n.lastStates[req.NamespacedName]
+	n.mu.Unlock()
+	if !found {
+		n.recordState(req.NamespacedName, current)
+		return ctrl.Result{}, nil
+	}
+
+	notification := buildNotification(&work, last, current)
+	if notification == nil {
+		n.recordState(req.NamespacedName, current)
+		return ctrl.Result{}, nil
+	}
+	notification.To = to
+	n.mu.Lock()
+	sentAt, sent := n.lastSentAt[req.NamespacedName]
+	n.mu.Unlock()
+	if sent && n.now().Sub(sentAt) < n.cooldown {
+		klog.V(2).InfoS("Skip emailing the work transition within the cooldown", "work", klog.KObj(&work),
+			"transition", notification.Transition, "lastSentAt", sentAt)
+		n.recordState(req.NamespacedName, current)
+		return ctrl.Result{}, nil
+	}
+	if err := n.gateway.Send(ctx, notification); err != nil {
+		klog.ErrorS(err, "Failed to email the work transition", "work", klog.KObj(&work), "transition", notification.Transition)
+		// keep the last observed state so that the transition is emailed again on retry.
+		return ctrl.Result{}, err
+	}
+	klog.V(2).InfoS("Emailed the work transition", "work", klog.KObj(&work), "transition", notification.Transition,
+		"manifests", len(notification.Manifests))
+	n.mu.Lock()
+	n.lastSentAt[req.NamespacedName] = n.now()
+	n.mu.Unlock()
+	n.recordState(req.NamespacedName, current)
+	return ctrl.Result{}, nil
+}
+
+func (n *WorkEmailNotifier) recordState(key types.NamespacedName, state *workState) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.lastStates[key] = state
+}
+
+func (n *WorkEmailNotifier) forget(key types.NamespacedName) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.lastStates, key)
+	delete(n.lastSentAt, key)
+}
+
+func newWorkState(work *fleetv1beta1.Work) *workState {
+	state := &workState{
+		applied: condition.IsConditionStatusTrue(meta.FindStatusCondition(work.Status.Conditions, fleetv1beta1.WorkConditionTypeApplied), work.Generation),
+	}
+	for _, event := range work.Status.RecentEvents {
+		if event.Type == fleetv1beta1.WorkEventTypeDriftFound && state.lastDriftAt.Before(&event.Timestamp) {
+			state.lastDriftAt = event.Timestamp
+		}
+	}
+	return state
+}
+
+// buildNotification returns the email of the transition of the work from the last to the current state, or nil if
+// the work was not applied or has not drifted or failed since.
+func buildNotification(work *fleetv1beta1.Work, last, current *workState) *EmailNotification {
+	if !last.applied {
+		return nil
+	}
+	notification := &EmailNotification{
+		WorkName:         work.Name,
+		ClusterNamespace: work.Namespace,
+	}
+	appliedCond := meta.FindStatusCondition(work.Status.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+	if appliedCond != nil && appliedCond.Status == metav1.ConditionFalse {
+		// the work is not applied while a rollout is in progress or some manifests are skipped, which is not a failure.
+		for _, manifestCond := range work.Status.ManifestConditions {
+			applyCond := meta.FindStatusCondition(manifestCond.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+			if applyCond != nil && applyCond.Status == metav1.ConditionFalse {
+				notification.Manifests = append(notification.Manifests, ManifestDiff{
+					Identifier: manifestCond.Identifier,
+					Reason:     applyCond.Reason,
+					Message:    applyCond.Message,
+				})
+			}
+		}
+		if len(notification.Manifests) > 0 {
+			notification.Transition = TransitionApplyFailed
+			notification.Subject = fmt.Sprintf("Work %s/%s failed to apply %d manifests", work.Namespace, work.Name, len(notification.Manifests))
+			return notification
+		}
+	}
+	if !current.applied {
+		return nil
+	}
+	identifiers := make(map[int]fleetv1beta1.WorkResourceIdentifier, len(work.Status.ManifestConditions))
+	for _, manifestCond := range work.Status.ManifestConditions {
+		identifiers[manifestCond.Identifier.Ordinal] = manifestCond.Identifier
+	}
+	for _, event := range work.Status.RecentEvents {
+		if event.Type != fleetv1beta1.WorkEventTypeDriftFound || event.ManifestOrdinal == nil || !last.lastDriftAt.Before(&event.Timestamp) {
+			continue
+		}
+		identifier, ok := identifiers[*event.ManifestOrdinal]
+		if !ok {
+			identifier = fleetv1beta1.WorkResourceIdentifier{Ordinal: *event.ManifestOrdinal}
+		}
+		notification.Manifests = append(notification.Manifests, ManifestDiff{
+			Identifier: identifier,
+			Reason:     event.Type,
+			Message:    event.Message,
+		})
+	}
+	if len(notification.Manifests) == 0 {
+		return nil
+	}
+	notification.Transition = TransitionDrifted
+	notification.Subject = fmt.Sprintf("Work %s/%s drifted in %d manifests", work.Namespace, work.Name, len(notification.Manifests))
+	return notification
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (n *WorkEmailNotifier) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).Named("work-email-notifier").
+		For(&fleetv1beta1.Work{}).
+		Complete(n)
+}
@@ -0,0 +1,232 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package workemailnotifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	testEmail = "admin@example.com"
+)
+
+// fakeGateway records the emails it is asked to send.
+type fakeGateway struct {
+	sent []*EmailNotification
+	err  error
+}
+
+func (g *fakeGateway) Send(_ context.Context, notification *EmailNotification) error {
+	if g.err != nil {
+		return g.err
+	}
+	g.sent = append(g.sent, notification)
+	return nil
+}
+
+func workCondition(condType string, status metav1.ConditionStatus, reason, message string) metav1.Condition {
+	return metav1.Condition{Type: condType, Status: status, Reason: reason, Message: message, ObservedGeneration: 1}
+}
+
+func driftEvent(ordinal int, at time.Time, message string) fleetv1beta1.WorkEvent {
+	return fleetv1beta1.WorkEvent{
+		Timestamp:       metav1.NewTime(at),
+		Type:            fleetv1beta1.WorkEventTypeDriftFound,
+		ManifestOrdinal: &ordinal,
+		Message:         message,
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	cm := fleetv1beta1.WorkResourceIdentifier{Ordinal: 0, Version: "v1", Kind: "ConfigMap", Resource: "configmaps", Namespace: "app", Name: "config"}
+	deploy := fleetv1beta1.WorkResourceIdentifier{Ordinal: 1, Group: "apps", Version: "v1", Kind: "Deployment", Resource: "deployments", Namespace: "app", Name: "web"}
+	appliedStatus := fleetv1beta1.WorkStatus{
+		Conditions: []metav1.Condition{workCondition(fleetv1beta1.WorkConditionTypeApplied, metav1.ConditionTrue, "WorkAppliedCompleted", "")},
+		ManifestConditions: []fleetv1beta1.ManifestCondition{
+			{Identifier: cm, Conditions: []metav1.Condition{workCondition(fleetv1beta1.WorkConditionTypeApplied, metav1.ConditionTrue, "ManifestUpdated", "")}},
+			{Identifier: deploy, Conditions: []metav1.Condition{workCondition(fleetv1beta1.WorkConditionTypeApplied, metav1.ConditionTrue, "ManifestUpdated", "")}},
+		},
+	}
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-work",
+			Namespace:   "fleet-member-test",
+			Generation:  1,
+			Annotations: map[string]string{NotifyEmailAnnotation: testEmail},
+		},
+		Status: appliedStatus,
+	}
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(work).WithStatusSubresource(work).Build()
+	gateway := &fakeGateway{}
+	n := NewWorkEmailNotifier(hubClient, gateway, DefaultCooldown)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	n.now = func() time.Time { return now }
+	key := types.NamespacedName{Name: work.Name, Namespace: work.Namespace}
+
+	updateStatus := func(status fleetv1beta1.WorkStatus) {
+		t.Helper()
+		if err := hubClient.Get(context.Background(), key, work); err != nil {
+			t.Fatalf("failed to get the work: %v", err)
+		}
+		work.Status = status
+		if err := hubClient.Status().Update(context.Background(), work); err != nil {
+			t.Fatalf("failed to update the work status: %v", err)
+		}
+	}
+	reconcile := func() {
+		t.Helper()
+		if _, err := n.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("Reconcile() = %v, want no error", err)
+		}
+	}
+
+	// the first state is recorded without an email.
+	reconcile()
+	if len(gateway.sent) != 0 {
+		t.Fatalf("emails after the first reconcile = %+v, want none", gateway.sent)
+	}
+
+	// a drift of the applied work is emailed with the drifted manifest.
+	drifted := *appliedStatus.DeepCopy()
+	drifted.RecentEvents = []fleetv1beta1.WorkEvent{driftEvent(1, now, "The resource changed from generation 1 to 2 since the last apply")}
+	updateStatus(drifted)
+	reconcile()
+	want := []*EmailNotification{{
+		To:               testEmail,
+		Subject:          "Work fleet-member-test/test-work drifted in 1 manifests",
+		WorkName:         "test-work",
+		ClusterNamespace: "fleet-member-test",
+		Transition:       TransitionDrifted,
+		Manifests: []ManifestDiff{{
+			Identifier: deploy,
+			Reason:     fleetv1beta1.WorkEventTypeDriftFound,
+			Message:    "The resource changed from generation 1 to 2 since the last apply",
+		}},
+	}}
+	if diff := cmp.Diff(want, gateway.sent); diff != "" {
+		t.Fatalf("emails after the drift mismatch (-want +got):\n%s", diff)
+	}
+
+	// the failure within the cooldown is not emailed.
+	now = now.Add(time.Minute)
+	failed := *appliedStatus.DeepCopy()
+	failed.Conditions[0] = workCondition(fleetv1beta1.WorkConditionTypeApplied, metav1.ConditionFalse, "WorkAppliedFailed", "")
+	failed.ManifestConditions[0].Conditions[0] = workCondition(fleetv1beta1.WorkConditionTypeApplied, metav1.ConditionFalse, "ManifestApplyFailed", "Failed to apply manifest: forbidden")
+	updateStatus(failed)
+	reconcile()
+	if len(gateway.sent) != 1 {
+		t.Fatalf("emails within the cooldown = %d, want 1", len(gateway.sent))
+	}
+
+	// once the cooldown is over, the failure of the applied work is emailed with the failed manifest.
+	updateStatus(appliedStatus)
+	reconcile()
+	now = now.Add(DefaultCooldown)
+	updateStatus(failed)
+	reconcile()
+	want = append(want, &EmailNotification{
+		To:               testEmail,
+		Subject:          "Work fleet-member-test/test-work failed to apply 1 manifests",
+		WorkName:         "test-work",
+		ClusterNamespace: "fleet-member-test",
+		Transition:       TransitionApplyFailed,
+		Manifests: []ManifestDiff{{
+			Identifier: cm,
+			Reason:     "ManifestApplyFailed",
+			Message:    "Failed to apply manifest: forbidden",
+		}},
+	})
+	if diff := cmp.Diff(want, gateway.sent); diff != "" {
+		t.Fatalf("emails after the failure mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReconcileGatewayFailure(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-work",
+			Namespace:   "fleet-member-test",
+			Generation:  1,
+			Annotations: map[string]string{NotifyEmailAnnotation: testEmail},
+		},
+		Status: fleetv1beta1.WorkStatus{
+			Conditions: []metav1.Condition{workCondition(fleetv1beta1.WorkConditionTypeApplied, metav1.ConditionTrue, "WorkAppliedCompleted", "")},
+		},
+	}
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(work).WithStatusSubresource(work).Build()
+	gateway := &fakeGateway{}
+	n := NewWorkEmailNotifier(hubClient, gateway, DefaultCooldown)
+	key := types.NamespacedName{Name: work.Name, Namespace: work.Namespace}
+	if _, err := n.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() = %v, want no error", err)
+	}
+
+	work.Status.RecentEvents = []fleetv1beta1.WorkEvent{driftEvent(0, time.Now(), "drifted")}
+	if err := hubClient.Status().Update(context.Background(), work); err != nil {
+		t.Fatalf("failed to update the work status: %v", err)
+	}
+	gateway.err = errors.New("gateway unavailable")
+	if _, err := n.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err == nil {
+		t.Fatalf("Reconcile() = nil, want the gateway error")
+	}
+	// the drift is emailed on retry.
+	gateway.err = nil
+	if _, err := n.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() = %v, want no error", err)
+	}
+	if len(gateway.sent) != 1 || gateway.sent[0].Transition != TransitionDrifted {
+		t.Errorf("emails after the retry = %+v, want the drift", gateway.sent)
+	}
+}
+
+func TestReconcileWithoutAnnotation(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: "fleet-member-test", Generation: 1},
+		Status: fleetv1beta1.WorkStatus{
+			Conditions: []metav1.Condition{workCondition(fleetv1beta1.WorkConditionTypeApplied, metav1.ConditionTrue, "WorkAppliedCompleted", "")},
+		},
+	}
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(work).WithStatusSubresource(work).Build()
+	gateway := &fakeGateway{}
+	n := NewWorkEmailNotifier(hubClient, gateway, DefaultCooldown)
+	key := types.NamespacedName{Name: work.Name, Namespace: work.Namespace}
+	for i := 0; i < 2; i++ {
+		work.Status.RecentEvents = append(work.Status.RecentEvents, driftEvent(0, time.Now().Add(time.Duration(i)*time.Minute), "drifted"))
+		if err := hubClient.Status().Update(context.Background(), work); err != nil {
+			t.Fatalf("failed to update the work status: %v", err)
+		}
+		if _, err := n.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("Reconcile() = %v, want no error", err)
+		}
+	}
+	if len(gateway.sent) != 0 || len(n.lastStates) != 0 {
+		t.Errorf("emails = %+v and tracked works = %d, want none for a work without the annotation", gateway.sent, len(n.lastStates))
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package workemailnotifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+const (
+	// sendTimeout is the timeout of a single request to the webhook gateway.
+	sendTimeout = 10 * time.Second
+)
+
+// Gateway sends the emails.
+type Gateway interface {
+	Send(ctx context.Context, notification *EmailNotification) error
+}
+
+// WebhookGateway posts the emails as JSON to an HTTP endpoint which delivers them.
+type WebhookGateway struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewWebhookGateway creates a WebhookGateway which posts the emails to the url.
+func NewWebhookGateway(url string) *WebhookGateway {
+	return &WebhookGateway{url: url, httpClient: &http.Client{Timeout: sendTimeout}}
+}
+
+// Send posts the email to the webhook endpoint.
+func (g *WebhookGateway) Send(ctx context.Context, notification *EmailNotification) error {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the email: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to build the email request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post the email: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("the email gateway responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SMTPGateway sends the emails through an SMTP server, with the diff summary as a JSON body.
+type SMTPGateway struct {
+	addr string
+	from string
+	auth smtp.Auth
+	// sendMail sends the message; it is replaced in the tests.
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewSMTPGateway creates an SMTPGateway which sends the emails from the address through the SMTP server at addr,
+// in the form of host:port. The PLAIN authentication is used if the username is not empty.
+func NewSMTPGateway(addr, from, username, password string) *SMTPGateway {
+	g := &SMTPGateway{addr: addr, from: from, sendMail: smtp.SendMail}
+	if username != "" {
+		host, _, _ := strings.Cut(addr, ":")
+		g.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return g
+}
+
+// Send sends the email through the SMTP server.
+func (g *SMTPGateway) Send(_ context.Context, notification *EmailNotification) error {
+	body, err := json.MarshalIndent(notification, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal the email: %w", err)
+	}
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\nTo: %s\r\nSubject: %s\r\nContent-Type: application/json; charset=utf-8\r\n\r\n",
+		g.from, notification.To, notification.Subject)
+	msg.Write(body)
+	if err := g.sendMail(g.addr, g.auth, g.from, []string{notification.To}, msg.Bytes()); err != nil {
+		return fmt.Errorf("failed to send the email: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package workemailnotifier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func testNotification() *EmailNotification {
+	return &EmailNotification{
+		To:               testEmail,
+		Subject:          "Work fleet-member-test/test-work drifted in 1 manifests",
+		WorkName:         "test-work",
+		ClusterNamespace: "fleet-member-test",
+		Transition:       TransitionDrifted,
+		Manifests: []ManifestDiff{{
+			Identifier: fleetv1beta1.WorkResourceIdentifier{Ordinal: 1, Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "app", Name: "web"},
+			Reason:     fleetv1beta1.WorkEventTypeDriftFound,
+			Message:    "The resource changed from generation 1 to 2 since the last apply",
+		}},
+	}
+}
+
+func TestWebhookGatewaySend(t *testing.T) {
+	var posted *EmailNotification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read the request body: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		posted = &EmailNotification{}
+		if err := json.Unmarshal(body, posted); err != nil {
+			t.Errorf("failed to unmarshal the payload: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	if err := NewWebhookGateway(server.URL).Send(context.Background(), testNotification()); err != nil {
+		t.Fatalf("Send() = %v, want no error", err)
+	}
+	if diff := cmp.Diff(testNotification(), posted); diff != "" {
+		t.Errorf("posted email mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWebhookGatewaySendRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	if err := NewWebhookGateway(server.URL).Send(context.Background(), testNotification()); err == nil {
+		t.Errorf("Send() = nil, want the error of the rejected email")
+	}
+}
+
+func TestSMTPGatewaySend(t *testing.T) {
+	g := NewSMTPGateway("smtp.example.com:587", "fleet@example.com", "fleet", "password")
+	var gotAddr, gotFrom string
+	var gotTo []string
+	var gotMsg string
+	g.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		if a == nil {
+			t.Errorf("sendMail() auth = nil, want the PLAIN authentication")
+		}
+		gotAddr, gotFrom, gotTo, gotMsg = addr, from, to, string(msg)
+		return nil
+	}
+	if err := g.Send(context.Background(), testNotification()); err != nil {
+		t.Fatalf("Send() = %v, want no error", err)
+	}
+	if gotAddr != "smtp.example.com:587" || gotFrom != "fleet@example.com" || !cmp.Equal(gotTo, []string{testEmail}) {
+		t.Errorf("sendMail() addr, from, to = %q, %q, %v, want the configured server and the annotated recipient", gotAddr, gotFrom, gotTo)
+	}
+	header, body, found := strings.Cut(gotMsg, "\r\n\r\n")
+	if !found {
+		t.Fatalf("email message %q has no body", gotMsg)
+	}
+	if want := "Subject: " + testNotification().Subject; !strings.Contains(header, want) {
+		t.Errorf("email header %q, want it to contain %q", header, want)
+	}
+	var got EmailNotification
+	if err := json.Unmarshal([]byte(body), &got); err != nil {
+		t.Fatalf("failed to unmarshal the email body: %v", err)
+	}
+	if diff := cmp.Diff(testNotification(), &got); diff != "" {
+		t.Errorf("email body mismatch (-want +got):\n%s", diff)
+	}
+}
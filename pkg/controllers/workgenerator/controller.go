@@ -49,6 +49,9 @@ import (
 	"go.goms.io/fleet/pkg/utils/controller"
 	"go.goms.io/fleet/pkg/utils/informer"
 	"go.goms.io/fleet/pkg/utils/labels"
+	"go.goms.io/fleet/pkg/utils/manifeststore"
+	"go.goms.io/fleet/pkg/utils/workintegrity"
+	"go.goms.io/fleet/pkg/utils/workstatuspage"
 )
 
 var (
@@ -69,6 +72,12 @@ type Reconciler struct {
 	// the informer contains the cache for all the resources we need.
 	// to check the resource scope
 	InformerManager informer.Manager
+	// WorkSplitter splits the works with too many manifests into parts.
+	WorkSplitter WorkSplitter
+	// ManifestBlobMinSize is the min size in bytes of the manifests stored as ManifestStore blobs instead of inline,
+	// so that the identical manifests of many works are stored only once. No manifest is stored as a blob if it is
+	// not positive.
+	ManifestBlobMinSize int
 }
 
 // Reconcile triggers a single binding reconcile round.
@@ -122,6 +131,25 @@ func (r *Reconciler) Reconcile(ctx context.Context, req controllerruntime.Reques
 	overrideSucceeded := false
 	// list all the corresponding works
 	works, syncErr := r.listAllWorksAssociated(ctx, &resourceBinding)
+	if len(resourceBinding.Spec.CrossClusterDependencies) > 0 && syncErr == nil {
+		// the dependencies only hold back the creation of the works; the works already created are kept in sync.
+		if len(works) == 0 {
+			unmet, err := r.findUnmetDependency(ctx, &resourceBinding)
+			if err != nil {
+				return controllerruntime.Result{}, err
+			}
+			if unmet != "" {
+				return r.waitForDependency(ctx, &resourceBinding, unmet)
+			}
+		}
+		resourceBinding.SetConditions(metav1.Condition{
+			Status:             metav1.ConditionFalse,
+			Type:               string(fleetv1beta1.ResourceBindingWaitingForDependency),
+			Reason:             condition.AllDependenciesMetReason,
+			Message:            "All the cross cluster dependencies are met",
+			ObservedGeneration: resourceBinding.Generation,
+		})
+	}
 	if syncErr == nil {
 		// generate and apply the workUpdated works if we have all the works
 		overrideSucceeded, workUpdated, syncErr = r.syncAllWork(ctx, &resourceBinding, works, cluster)
@@ -190,6 +218,13 @@ func (r *Reconciler) Reconcile(ctx context.Context, req controllerruntime.Reques
 				ObservedGeneration: resourceBinding.Generation,
 			})
 		} else {
+			// the manifest conditions overflowing into the status pages are needed to report all the failed placements.
+			for _, work := range works {
+				if err := workstatuspage.Merge(ctx, r.Client, work); err != nil {
+					klog.ErrorS(err, "Failed to merge the status pages of the work", "work", klog.KObj(work))
+					return controllerruntime.Result{}, controller.NewAPIServerError(true, err)
+				}
+			}
 			setBindingStatus(works, &resourceBinding)
 		}
 	}
@@ -380,8 +415,16 @@ func (r *Reconciler) syncAllWork(ctx context.Context, resourceBinding *fleetv1be
 		// to allow CRP to collect the status of the placement
 		// TODO (RZ): revisit to see if we need this hack
 		work := generateSnapshotWorkObj(workNamePrefix, resourceBinding, snapshot, simpleManifests)
-		activeWork[work.Name] = work
-		newWork = append(newWork, work)
+		parts, group := r.WorkSplitter.Split(work)
+		for _, part := range parts {
+			activeWork[part.Name] = part
+			newWork = append(newWork, part)
+		}
+		if r.WorkSplitter.MaxManifestsPerWork > 0 {
+			errs.Go(func() error {
+				return r.syncWorkGroup(cctx, work, group)
+			})
+		}
 
 		// issue all the create/update requests for the corresponding works for each snapshot in parallel
 		for ni := range newWork {
@@ -499,7 +542,8 @@ func (r *Reconciler) getConfigMapEnvelopWorkObj(ctx context.Context, workNamePre
 				Workload: fleetv1beta1.WorkloadTemplate{
 					Manifests: manifest,
 				},
-				ApplyStrategy: resourceBinding.Spec.ApplyStrategy,
+				ApplyStrategy:            resourceBinding.Spec.ApplyStrategy,
+				CrossClusterDependencies: resourceBinding.Spec.CrossClusterDependencies,
 			},
 		}, nil
 	}
@@ -513,6 +557,7 @@ func (r *Reconciler) getConfigMapEnvelopWorkObj(ctx context.Context, workNamePre
 	work.Labels[fleetv1beta1.ParentResourceSnapshotIndexLabel] = resourceSnapshot.Labels[fleetv1beta1.ResourceIndexLabel]
 	work.Spec.Workload.Manifests = manifest
 	work.Spec.ApplyStrategy = resourceBinding.Spec.ApplyStrategy
+	work.Spec.CrossClusterDependencies = resourceBinding.Spec.CrossClusterDependencies
 	return &work, nil
 }
 
@@ -541,7 +586,8 @@ func generateSnapshotWorkObj(workName string, resourceBinding *fleetv1beta1.Clus
 			Workload: fleetv1beta1.WorkloadTemplate{
 				Manifests: manifest,
 			},
-			ApplyStrategy: resourceBinding.Spec.ApplyStrategy,
+			ApplyStrategy:            resourceBinding.Spec.ApplyStrategy,
+			CrossClusterDependencies: resourceBinding.Spec.CrossClusterDependencies,
 		},
 	}
 }
@@ -552,6 +598,12 @@ func (r *Reconciler) upsertWork(ctx context.Context, newWork, existingWork *flee
 	workObj := klog.KObj(newWork)
 	resourceSnapshotObj := klog.KObj(resourceSnapshot)
 	if existingWork == nil {
+		if err := r.prepareManifests(ctx, newWork); err != nil {
+			return false, err
+		}
+		if err := r.attachDefaultHealthPolicy(ctx, newWork); err != nil {
+			return false, err
+		}
 		if err := r.Client.Create(ctx, newWork); err != nil {
 			klog.ErrorS(err, "Failed to create the work associated with the resourceSnapshot", "resourceSnapshot", resourceSnapshotObj, "work", workObj)
 			return false, controller.NewCreateIgnoreAlreadyExistError(err)
@@ -573,9 +625,14 @@ func (r *Reconciler) upsertWork(ctx context.Context, newWork, existingWork *flee
 		klog.V(2).InfoS("Work is already associated with the desired resourceSnapshot", "resourceIndex", resourceIndex, "work", workObj, "resourceSnapshot", resourceSnapshotObj)
 		return false, nil
 	}
+	if err := r.prepareManifests(ctx, newWork); err != nil {
+		return false, err
+	}
 	// need to update the existing work, only two possible changes:
 	existingWork.Labels[fleetv1beta1.ParentResourceSnapshotIndexLabel] = resourceSnapshot.Labels[fleetv1beta1.ResourceIndexLabel]
 	existingWork.Spec.Workload.Manifests = newWork.Spec.Workload.Manifests
+	existingWork.Spec.Workload.ManifestBlobRefs = newWork.Spec.Workload.ManifestBlobRefs
+	existingWork.Spec.Workload.ManifestChecksums = newWork.Spec.Workload.ManifestChecksums
 	if err := r.Client.Update(ctx, existingWork); err != nil {
 		klog.ErrorS(err, "Failed to update the work associated with the resourceSnapshot", "resourceSnapshot", resourceSnapshotObj, "work", workObj)
 		return true, controller.NewUpdateIgnoreConflictError(err)
@@ -584,6 +641,24 @@ func (r *Reconciler) upsertWork(ctx context.Context, newWork, existingWork *flee
 	return true, nil
 }
 
+// prepareManifests stores the large manifests of the new work as ManifestStore blobs and records the checksums of
+// its manifests so that the manifests corrupted in storage can be detected.
+func (r *Reconciler) prepareManifests(ctx context.Context, newWork *fleetv1beta1.Work) error {
+	if r.ManifestBlobMinSize > 0 {
+		if err := manifeststore.Store(ctx, r.Client, newWork, r.ManifestBlobMinSize); err != nil {
+			klog.ErrorS(err, "Failed to store the work manifests as manifest store blobs", "work", klog.KObj(newWork))
+			return controller.NewAPIServerError(false, err)
+		}
+	}
+	checksums, err := workintegrity.ManifestChecksums(newWork.Spec.Workload.Manifests)
+	if err != nil {
+		klog.ErrorS(err, "Failed to compute the checksums of the work manifests", "work", klog.KObj(newWork))
+		return controller.NewUnexpectedBehaviorError(err)
+	}
+	newWork.Spec.Workload.ManifestChecksums = checksums
+	return nil
+}
+
 // getWorkNamePrefixFromSnapshotName extract the CRP and sub-index name from the corresponding resource snapshot.
 // The corresponding work name prefix is the CRP name + sub-index if there is a sub-index. Otherwise, it is the CRP name +"-work".
 // For example, if the resource snapshot name is "crp-1-0", the corresponding work name is "crp-0".
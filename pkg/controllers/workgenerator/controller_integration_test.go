@@ -29,6 +29,7 @@ import (
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	"go.goms.io/fleet/pkg/utils"
 	"go.goms.io/fleet/pkg/utils/condition"
+	"go.goms.io/fleet/pkg/utils/workintegrity"
 )
 
 var (
@@ -48,6 +49,8 @@ var (
 	invalidClusterResourceOverrideSnapshot placementv1alpha1.ClusterResourceOverrideSnapshot
 
 	ignoreConditionOption = cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime", "Message")
+	// the manifest checksums are derived from the manifests and are verified separately.
+	ignoreManifestChecksumsOption = cmpopts.IgnoreFields(placementv1beta1.WorkloadTemplate{}, "ManifestChecksums")
 
 	fakeReason  = "fakeApplyFailureReason"
 	fakeMessage = "fake apply failure message"
@@ -314,8 +317,12 @@ var _ = Describe("Test Work Generator Controller", func() {
 						},
 					},
 				}
-				diff := cmp.Diff(wantWork, work, ignoreWorkOption, ignoreTypeMeta)
+				diff := cmp.Diff(wantWork, work, ignoreWorkOption, ignoreTypeMeta, ignoreManifestChecksumsOption)
 				Expect(diff).Should(BeEmpty(), fmt.Sprintf("work(%s) mismatch (-want +got):\n%s", work.Name, diff))
+				Expect(work.Spec.Workload.ManifestChecksums).Should(HaveLen(3), "the work should record the checksums of its manifests")
+				corrupted, err := workintegrity.CorruptedManifests(&work)
+				Expect(err).Should(Succeed())
+				Expect(corrupted).Should(BeEmpty(), "the checksums of the work should match its manifests")
 				// check the binding status that it should be marked as work not applied eventually
 				verifyBindingStatusSyncedNotApplied(binding, false, true)
 				// mark the work applied
@@ -401,7 +408,7 @@ var _ = Describe("Test Work Generator Controller", func() {
 						},
 					},
 				}
-				diff := cmp.Diff(wantWork, work, ignoreWorkOption, ignoreTypeMeta)
+				diff := cmp.Diff(wantWork, work, ignoreWorkOption, ignoreTypeMeta, ignoreManifestChecksumsOption)
 				Expect(diff).Should(BeEmpty(), fmt.Sprintf("work(%s) mismatch (-want +got):\n%s", work.Name, diff))
 				// check the binding status that it should be marked as work not applied eventually
 				verifyBindingStatusSyncedNotApplied(binding, false, true)
@@ -465,7 +472,7 @@ var _ = Describe("Test Work Generator Controller", func() {
 						},
 					},
 				}
-				diff := cmp.Diff(wantWork, work, ignoreWorkOption, ignoreTypeMeta)
+				diff := cmp.Diff(wantWork, work, ignoreWorkOption, ignoreTypeMeta, ignoreManifestChecksumsOption)
 				Expect(diff).Should(BeEmpty(), fmt.Sprintf("work(%s) mismatch (-want +got):\n%s", work.Name, diff))
 				// check the binding status that it should be marked as work not applied eventually
 				verifyBindingStatusSyncedNotApplied(binding, false, true)
@@ -541,7 +548,7 @@ var _ = Describe("Test Work Generator Controller", func() {
 						},
 					},
 				}
-				diff := cmp.Diff(wantWork, work, ignoreWorkOption, ignoreTypeMeta)
+				diff := cmp.Diff(wantWork, work, ignoreWorkOption, ignoreTypeMeta, ignoreManifestChecksumsOption)
 				Expect(diff).Should(BeEmpty(), fmt.Sprintf("work(%s) mismatch (-want +got):\n%s", work.Name, diff))
 				//inspect the envelope work
 				var workList placementv1beta1.WorkList
@@ -579,7 +586,7 @@ var _ = Describe("Test Work Generator Controller", func() {
 						},
 					},
 				}
-				diff = cmp.Diff(wantWork, envWork, ignoreWorkOption, ignoreTypeMeta)
+				diff = cmp.Diff(wantWork, envWork, ignoreWorkOption, ignoreTypeMeta, ignoreManifestChecksumsOption)
 				Expect(diff).Should(BeEmpty(), fmt.Sprintf("enveloped work(%s) mismatch (-want +got):\n%s", envWork.Name, diff))
 				// mark the enveloped work applied
 				markWorkApplied(&work)
@@ -656,7 +663,7 @@ var _ = Describe("Test Work Generator Controller", func() {
 						},
 					},
 				}
-				diff := cmp.Diff(wantWork, work, ignoreWorkOption, ignoreTypeMeta)
+				diff := cmp.Diff(wantWork, work, ignoreWorkOption, ignoreTypeMeta, ignoreManifestChecksumsOption)
 				Expect(diff).Should(BeEmpty(), fmt.Sprintf("work(%s) mismatch (-want +got):\n%s", work.Name, diff))
 				// check the enveloped work is updated
 				fetchEnvelopedWork(&workList, binding)
@@ -693,7 +700,7 @@ var _ = Describe("Test Work Generator Controller", func() {
 						},
 					},
 				}
-				diff = cmp.Diff(wantWork, work, ignoreWorkOption, ignoreTypeMeta)
+				diff = cmp.Diff(wantWork, work, ignoreWorkOption, ignoreTypeMeta, ignoreManifestChecksumsOption)
 				Expect(diff).Should(BeEmpty(), fmt.Sprintf("envelop work(%s) mismatch (-want +got):\n%s", work.Name, diff))
 			})
 
@@ -835,7 +842,7 @@ var _ = Describe("Test Work Generator Controller", func() {
 						},
 					},
 				}
-				diff = cmp.Diff(wantWork, secondWork, ignoreWorkOption, ignoreTypeMeta)
+				diff = cmp.Diff(wantWork, secondWork, ignoreWorkOption, ignoreTypeMeta, ignoreManifestChecksumsOption)
 				Expect(diff).Should(BeEmpty(), fmt.Sprintf("work(%s) mismatch (-want +got):\n%s", work.Name, diff))
 				// check the binding status that it should be marked as applied false
 				verifyBindingStatusSyncedNotApplied(binding, false, true)
@@ -905,7 +912,7 @@ var _ = Describe("Test Work Generator Controller", func() {
 						},
 					},
 				}
-				diff = cmp.Diff(wantWork, secondWork, ignoreWorkOption, ignoreTypeMeta)
+				diff = cmp.Diff(wantWork, secondWork, ignoreWorkOption, ignoreTypeMeta, ignoreManifestChecksumsOption)
 				Expect(diff).Should(BeEmpty(), fmt.Sprintf("work(%s) mismatch (-want +got):\n%s", work.Name, diff))
 				// check the binding status that it should be marked as applied false
 				verifyBindingStatusSyncedNotApplied(binding, false, true)
@@ -1195,7 +1202,7 @@ var _ = Describe("Test Work Generator Controller", func() {
 						},
 					},
 				}
-				diff := cmp.Diff(wantWork, work, ignoreWorkOption, ignoreTypeMeta)
+				diff := cmp.Diff(wantWork, work, ignoreWorkOption, ignoreTypeMeta, ignoreManifestChecksumsOption)
 				Expect(diff).Should(BeEmpty(), fmt.Sprintf("work(%s) mismatch (-want +got):\n%s", work.Name, diff))
 				// check the binding status that it should be marked as work not applied eventually
 				verifyBindingStatusSyncedNotApplied(binding, true, true)
@@ -0,0 +1,78 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package workgenerator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/condition"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+// dependencyRecheckInterval is how long the work generator waits before it checks the unmet cross cluster
+// dependencies of a binding again, as the works of the other clusters are not watched for the binding.
+const dependencyRecheckInterval = 15 * time.Second
+
+// findUnmetDependency returns the description of the first cross cluster dependency of the binding which is not met;
+// it returns an empty string if all the dependencies are met.
+func (r *Reconciler) findUnmetDependency(ctx context.Context, resourceBinding *fleetv1beta1.ClusterResourceBinding) (string, error) {
+	for _, dependency := range resourceBinding.Spec.CrossClusterDependencies {
+		workKey := types.NamespacedName{Namespace: dependency.ClusterNamespace, Name: dependency.WorkName}
+		requiredCondition := dependency.RequiredCondition
+		if requiredCondition == "" {
+			requiredCondition = fleetv1beta1.WorkConditionTypeApplied
+		}
+		var work fleetv1beta1.Work
+		if err := r.Client.Get(ctx, workKey, &work); err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Sprintf("work %s is not found", workKey), nil
+			}
+			klog.ErrorS(err, "Failed to get the work the binding depends on", "resourceBinding", klog.KObj(resourceBinding), "work", workKey)
+			return "", controller.NewAPIServerError(true, err)
+		}
+		cond := meta.FindStatusCondition(work.Status.Conditions, requiredCondition)
+		if cond == nil || cond.Status != metav1.ConditionTrue || cond.ObservedGeneration != work.Generation {
+			return fmt.Sprintf("work %s is not %s yet", workKey, requiredCondition), nil
+		}
+	}
+	return "", nil
+}
+
+// waitForDependency reports that the works of the binding are not created until the unmet dependency is met and
+// checks the dependencies again later.
+func (r *Reconciler) waitForDependency(ctx context.Context, resourceBinding *fleetv1beta1.ClusterResourceBinding, unmet string) (controllerruntime.Result, error) {
+	bindingRef := klog.KObj(resourceBinding)
+	klog.V(2).InfoS("Waiting for the cross cluster dependency of the resource binding", "resourceBinding", bindingRef, "unmetDependency", unmet)
+	message := fmt.Sprintf("The works are not created until the cross cluster dependencies are met: %s", unmet)
+	resourceBinding.SetConditions(metav1.Condition{
+		Status:             metav1.ConditionTrue,
+		Type:               string(fleetv1beta1.ResourceBindingWaitingForDependency),
+		Reason:             condition.DependencyNotMetReason,
+		Message:            message,
+		ObservedGeneration: resourceBinding.Generation,
+	}, metav1.Condition{
+		Status:             metav1.ConditionFalse,
+		Type:               string(fleetv1beta1.ResourceBindingWorkSynchronized),
+		Reason:             condition.DependencyNotMetReason,
+		Message:            message,
+		ObservedGeneration: resourceBinding.Generation,
+	})
+	if err := r.Client.Status().Update(ctx, resourceBinding); err != nil {
+		klog.ErrorS(err, "Failed to update the resourceBinding status", "resourceBinding", bindingRef)
+		return controllerruntime.Result{}, controller.NewUpdateIgnoreConflictError(err)
+	}
+	return controllerruntime.Result{RequeueAfter: dependencyRecheckInterval}, nil
+}
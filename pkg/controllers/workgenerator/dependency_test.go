@@ -0,0 +1,156 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package workgenerator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+)
+
+// dependentBinding returns a bound binding of the test placement to the cluster with the given dependencies.
+func dependentBinding(cluster string, dependencies ...fleetv1beta1.CrossClusterDependency) *fleetv1beta1.ClusterResourceBinding {
+	return &fleetv1beta1.ClusterResourceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "app-" + cluster,
+			Labels: map[string]string{fleetv1beta1.CRPTrackingLabel: "app"},
+		},
+		Spec: fleetv1beta1.ResourceBindingSpec{
+			State:                    fleetv1beta1.BindingStateBound,
+			ResourceSnapshotName:     "app-0-snapshot",
+			TargetCluster:            cluster,
+			CrossClusterDependencies: dependencies,
+		},
+	}
+}
+
+func TestReconcileCrossClusterDependencies(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	if err := clusterv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the cluster scheme: %v", err)
+	}
+	snapshot := &fleetv1beta1.ClusterResourceSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "app-0-snapshot",
+			Labels: map[string]string{
+				fleetv1beta1.ResourceIndexLabel: "0",
+				fleetv1beta1.CRPTrackingLabel:   "app",
+			},
+			Annotations: map[string]string{fleetv1beta1.NumberOfResourceSnapshotsAnnotation: "1"},
+		},
+		Spec: fleetv1beta1.ResourceSnapshotSpec{
+			SelectedResources: []fleetv1beta1.ResourceContent{
+				{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"app"}}`)}},
+			},
+		},
+	}
+	clusterAWorkKey := types.NamespacedName{Namespace: fmt.Sprintf(utils.NamespaceNameFormat, "cluster-a"), Name: "app-work"}
+	bindingA := dependentBinding("cluster-a")
+	// the placement on cluster-b waits for the work on cluster-a to be applied.
+	bindingB := dependentBinding("cluster-b", fleetv1beta1.CrossClusterDependency{
+		ClusterNamespace: clusterAWorkKey.Namespace,
+		WorkName:         clusterAWorkKey.Name,
+	})
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(snapshot, bindingA, bindingB,
+			&clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster-a"}},
+			&clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster-b"}}).
+		WithStatusSubresource(&fleetv1beta1.ClusterResourceBinding{}, &fleetv1beta1.Work{}).
+		Build()
+	r := &Reconciler{Client: hubClient}
+
+	reconcile := func(binding *fleetv1beta1.ClusterResourceBinding) controllerruntime.Result {
+		t.Helper()
+		result, err := r.Reconcile(context.Background(), controllerruntime.Request{NamespacedName: client.ObjectKeyFromObject(binding)})
+		if err != nil {
+			t.Fatalf("Reconcile(%s) = %v, want no error", binding.Name, err)
+		}
+		return result
+	}
+	workCount := func(cluster string) int {
+		t.Helper()
+		var works fleetv1beta1.WorkList
+		if err := hubClient.List(context.Background(), &works, client.InNamespace(fmt.Sprintf(utils.NamespaceNameFormat, cluster))); err != nil {
+			t.Fatalf("failed to list the works: %v", err)
+		}
+		return len(works.Items)
+	}
+	waitingForDependency := func(binding *fleetv1beta1.ClusterResourceBinding) metav1.ConditionStatus {
+		t.Helper()
+		var got fleetv1beta1.ClusterResourceBinding
+		if err := hubClient.Get(context.Background(), client.ObjectKeyFromObject(binding), &got); err != nil {
+			t.Fatalf("failed to get the binding: %v", err)
+		}
+		cond := got.GetCondition(string(fleetv1beta1.ResourceBindingWaitingForDependency))
+		if cond == nil {
+			return metav1.ConditionUnknown
+		}
+		return cond.Status
+	}
+
+	// the work on cluster-b is not created before the work on cluster-a exists.
+	if result := reconcile(bindingB); result.RequeueAfter != dependencyRecheckInterval {
+		t.Errorf("Reconcile() of the waiting binding = %+v, want it requeued after %v", result, dependencyRecheckInterval)
+	}
+	if got := workCount("cluster-b"); got != 0 {
+		t.Errorf("works on cluster-b before the dependency exists = %d, want 0", got)
+	}
+	if got := waitingForDependency(bindingB); got != metav1.ConditionTrue {
+		t.Errorf("binding WaitingForDependency before the dependency exists = %s, want True", got)
+	}
+
+	// the work on cluster-a is created but not applied yet.
+	reconcile(bindingA)
+	if got := workCount("cluster-a"); got != 1 {
+		t.Fatalf("works on cluster-a = %d, want 1", got)
+	}
+	if got := waitingForDependency(bindingA); got != metav1.ConditionUnknown {
+		t.Errorf("binding WaitingForDependency of the binding without dependencies = %s, want no condition", got)
+	}
+	reconcile(bindingB)
+	if got := workCount("cluster-b"); got != 0 {
+		t.Errorf("works on cluster-b before the dependency is applied = %d, want 0", got)
+	}
+
+	// the work on cluster-b is created once the work on cluster-a is applied.
+	var workA fleetv1beta1.Work
+	if err := hubClient.Get(context.Background(), clusterAWorkKey, &workA); err != nil {
+		t.Fatalf("failed to get the work on cluster-a: %v", err)
+	}
+	meta.SetStatusCondition(&workA.Status.Conditions, metav1.Condition{
+		Type:               fleetv1beta1.WorkConditionTypeApplied,
+		Status:             metav1.ConditionTrue,
+		Reason:             "WorkAppliedCompleted",
+		ObservedGeneration: workA.Generation,
+	})
+	if err := hubClient.Status().Update(context.Background(), &workA); err != nil {
+		t.Fatalf("failed to update the work status on cluster-a: %v", err)
+	}
+	if result := reconcile(bindingB); result.RequeueAfter != 0 {
+		t.Errorf("Reconcile() of the binding whose dependencies are met = %+v, want no requeue", result)
+	}
+	if got := workCount("cluster-b"); got != 1 {
+		t.Errorf("works on cluster-b after the dependency is applied = %d, want 1", got)
+	}
+	if got := waitingForDependency(bindingB); got != metav1.ConditionFalse {
+		t.Errorf("binding WaitingForDependency after the dependency is applied = %s, want False", got)
+	}
+}
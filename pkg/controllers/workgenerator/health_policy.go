@@ -0,0 +1,69 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package workgenerator
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+// attachDefaultHealthPolicy makes the new work inherit the fleet-wide default WorkHealthPolicy, which is the policy of
+// the default name in the fleet system namespace. The policy is copied to the namespace of the work first since the
+// work can only refer to a policy in its own namespace. The work is left as it is if it refers to a policy already or
+// if there is no fleet-wide default.
+func (r *Reconciler) attachDefaultHealthPolicy(ctx context.Context, work *fleetv1beta1.Work) error {
+	if work.Spec.HealthPolicyRef != nil {
+		return nil
+	}
+	var defaultPolicy fleetv1beta1.WorkHealthPolicy
+	defaultKey := types.NamespacedName{Name: fleetv1beta1.DefaultWorkHealthPolicyName, Namespace: utils.FleetSystemNamespace}
+	if err := r.Client.Get(ctx, defaultKey, &defaultPolicy); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		klog.ErrorS(err, "Failed to get the default health policy", "healthPolicy", defaultKey)
+		return controller.NewAPIServerError(true, err)
+	}
+
+	var policy fleetv1beta1.WorkHealthPolicy
+	policyKey := types.NamespacedName{Name: fleetv1beta1.DefaultWorkHealthPolicyName, Namespace: work.Namespace}
+	err := r.Client.Get(ctx, policyKey, &policy)
+	switch {
+	case apierrors.IsNotFound(err):
+		policy = fleetv1beta1.WorkHealthPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: policyKey.Name, Namespace: policyKey.Namespace},
+			Spec:       defaultPolicy.Spec,
+		}
+		if err := r.Client.Create(ctx, &policy); err != nil {
+			klog.ErrorS(err, "Failed to copy the default health policy", "healthPolicy", policyKey)
+			return controller.NewCreateIgnoreAlreadyExistError(err)
+		}
+		klog.V(2).InfoS("Copied the default health policy", "healthPolicy", policyKey)
+	case err != nil:
+		klog.ErrorS(err, "Failed to get the health policy", "healthPolicy", policyKey)
+		return controller.NewAPIServerError(true, err)
+	case !equality.Semantic.DeepEqual(policy.Spec, defaultPolicy.Spec):
+		// keep the copy in sync with the changes made to the default since it was copied.
+		policy.Spec = defaultPolicy.Spec
+		if err := r.Client.Update(ctx, &policy); err != nil {
+			klog.ErrorS(err, "Failed to update the copy of the default health policy", "healthPolicy", policyKey)
+			return controller.NewUpdateIgnoreConflictError(err)
+		}
+		klog.V(2).InfoS("Updated the copy of the default health policy", "healthPolicy", policyKey)
+	}
+	work.Spec.HealthPolicyRef = &corev1.LocalObjectReference{Name: fleetv1beta1.DefaultWorkHealthPolicyName}
+	return nil
+}
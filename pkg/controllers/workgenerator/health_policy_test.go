@@ -0,0 +1,90 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package workgenerator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+)
+
+func TestAttachDefaultHealthPolicy(t *testing.T) {
+	const memberNamespace = "fleet-member-test"
+	defaultPolicy := &fleetv1beta1.WorkHealthPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: fleetv1beta1.DefaultWorkHealthPolicyName, Namespace: utils.FleetSystemNamespace},
+		Spec:       fleetv1beta1.WorkHealthCriteria{DriftToleranceSeconds: ptr.To[int32](60)},
+	}
+	staleCopy := &fleetv1beta1.WorkHealthPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: fleetv1beta1.DefaultWorkHealthPolicyName, Namespace: memberNamespace},
+		Spec:       fleetv1beta1.WorkHealthCriteria{DriftToleranceSeconds: ptr.To[int32](600)},
+	}
+	tests := map[string]struct {
+		objects         []client.Object
+		healthPolicyRef *corev1.LocalObjectReference
+		wantRef         *corev1.LocalObjectReference
+		wantCopy        *fleetv1beta1.WorkHealthCriteria
+	}{
+		"no fleet-wide default": {
+			wantRef: nil,
+		},
+		"default is copied and attached": {
+			objects:  []client.Object{defaultPolicy},
+			wantRef:  &corev1.LocalObjectReference{Name: fleetv1beta1.DefaultWorkHealthPolicyName},
+			wantCopy: &defaultPolicy.Spec,
+		},
+		"stale copy is updated": {
+			objects:  []client.Object{defaultPolicy, staleCopy},
+			wantRef:  &corev1.LocalObjectReference{Name: fleetv1beta1.DefaultWorkHealthPolicyName},
+			wantCopy: &defaultPolicy.Spec,
+		},
+		"work which refers to a policy keeps it": {
+			objects:         []client.Object{defaultPolicy},
+			healthPolicyRef: &corev1.LocalObjectReference{Name: "custom"},
+			wantRef:         &corev1.LocalObjectReference{Name: "custom"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+				t.Fatalf("failed to add the placement scheme: %v", err)
+			}
+			hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build()
+			r := &Reconciler{Client: hubClient}
+			work := &fleetv1beta1.Work{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: memberNamespace},
+				Spec:       fleetv1beta1.WorkSpec{HealthPolicyRef: tt.healthPolicyRef},
+			}
+			if err := r.attachDefaultHealthPolicy(context.Background(), work); err != nil {
+				t.Fatalf("attachDefaultHealthPolicy() = %v, want no error", err)
+			}
+			if diff := cmp.Diff(tt.wantRef, work.Spec.HealthPolicyRef); diff != "" {
+				t.Errorf("attachDefaultHealthPolicy() health policy ref mismatch (-want +got):\n%s", diff)
+			}
+			if tt.wantCopy == nil {
+				return
+			}
+			var policy fleetv1beta1.WorkHealthPolicy
+			if err := hubClient.Get(context.Background(), types.NamespacedName{Name: fleetv1beta1.DefaultWorkHealthPolicyName, Namespace: memberNamespace}, &policy); err != nil {
+				t.Fatalf("failed to get the copy of the default health policy: %v", err)
+			}
+			if diff := cmp.Diff(*tt.wantCopy, policy.Spec); diff != "" {
+				t.Errorf("attachDefaultHealthPolicy() copied health policy mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
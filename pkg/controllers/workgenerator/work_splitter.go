@@ -0,0 +1,147 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package workgenerator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/controllers/work"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+// WorkSplitter splits the works with too many manifests into parts with non-overlapping slices of the manifests,
+// which are tracked by a WorkGroup.
+type WorkSplitter struct {
+	// MaxManifestsPerWork is the max number of manifests in a work. The works are never split if it is not positive.
+	MaxManifestsPerWork int
+}
+
+// Split returns the parts of the work named `{workName}-part-{index}` and the WorkGroup tracking them.
+// The work itself and a nil WorkGroup are returned if the work does not have more manifests than the max.
+func (s WorkSplitter) Split(work *fleetv1beta1.Work) ([]*fleetv1beta1.Work, *fleetv1beta1.WorkGroup) {
+	manifests := work.Spec.Workload.Manifests
+	if s.MaxManifestsPerWork <= 0 || len(manifests) <= s.MaxManifestsPerWork {
+		return []*fleetv1beta1.Work{work}, nil
+	}
+	group := &fleetv1beta1.WorkGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            work.Name,
+			Namespace:       work.Namespace,
+			Labels:          work.Labels,
+			OwnerReferences: work.OwnerReferences,
+		},
+	}
+	var parts []*fleetv1beta1.Work
+	for start := 0; start < len(manifests); start += s.MaxManifestsPerWork {
+		end := min(start+s.MaxManifestsPerWork, len(manifests))
+		part := work.DeepCopy()
+		part.Name = fmt.Sprintf(fleetv1beta1.WorkPartNameFmt, work.Name, len(parts))
+		part.Labels[fleetv1beta1.WorkGroupLabel] = work.Name
+		splitWorkload(&part.Spec.Workload, start, end)
+		splitSkippedOrdinals(part, start, end)
+		parts = append(parts, part)
+		group.Spec.Parts = append(group.Spec.Parts, part.Name)
+	}
+	return parts, group
+}
+
+// splitWorkload keeps the manifests of the workload with the ordinals in [start, end) and their per-manifest settings,
+// whose ordinals are re-based to the part.
+func splitWorkload(workload *fleetv1beta1.WorkloadTemplate, start, end int) {
+	workload.Manifests = workload.Manifests[start:end]
+	if len(workload.ManifestChecksums) >= end {
+		workload.ManifestChecksums = workload.ManifestChecksums[start:end]
+	}
+	workload.ManifestRetryPolicies = splitSettings(workload.ManifestRetryPolicies, func(s *fleetv1beta1.ManifestRetryPolicy) *int { return &s.Ordinal }, start, end)
+	workload.ManifestTargetNamespaces = splitSettings(workload.ManifestTargetNamespaces, func(s *fleetv1beta1.ManifestTargetNamespace) *int { return &s.Ordinal }, start, end)
+	workload.ManifestBinaryData = splitSettings(workload.ManifestBinaryData, func(s *fleetv1beta1.ManifestBinaryData) *int { return &s.Ordinal }, start, end)
+	workload.ManifestHooks = splitSettings(workload.ManifestHooks, func(s *fleetv1beta1.ManifestHooks) *int { return &s.Ordinal }, start, end)
+	workload.ManifestBlobRefs = splitSettings(workload.ManifestBlobRefs, func(s *fleetv1beta1.ManifestBlobRef) *int { return &s.Ordinal }, start, end)
+}
+
+// splitSettings returns the per-manifest settings of the manifests with the ordinals in [start, end), with their
+// ordinals re-based to the part.
+func splitSettings[T any](settings []T, ordinal func(*T) *int, start, end int) []T {
+	var kept []T
+	for i := range settings {
+		setting := settings[i]
+		if o := ordinal(&setting); *o >= start && *o < end {
+			*o -= start
+			kept = append(kept, setting)
+		}
+	}
+	return kept
+}
+
+// splitSkippedOrdinals keeps the ordinals in [start, end) of the skip-manifest-ordinals annotation of the part, re-based
+// to the part. The annotation is removed if none of the manifests of the part is skipped.
+func splitSkippedOrdinals(part *fleetv1beta1.Work, start, end int) {
+	value, ok := part.Annotations[work.WorkSkipManifestOrdinalsAnnotation]
+	if !ok {
+		return
+	}
+	var kept []string
+	for _, field := range strings.Split(value, ",") {
+		ordinal, err := strconv.Atoi(strings.TrimSpace(field))
+		if err == nil && ordinal >= start && ordinal < end {
+			kept = append(kept, strconv.Itoa(ordinal-start))
+		}
+	}
+	if len(kept) == 0 {
+		delete(part.Annotations, work.WorkSkipManifestOrdinalsAnnotation)
+		return
+	}
+	part.Annotations[work.WorkSkipManifestOrdinalsAnnotation] = strings.Join(kept, ",")
+}
+
+// syncWorkGroup creates or updates the WorkGroup of the split work, or deletes the WorkGroup left behind by the work
+// if it is no longer split.
+func (r *Reconciler) syncWorkGroup(ctx context.Context, work *fleetv1beta1.Work, group *fleetv1beta1.WorkGroup) error {
+	var existing fleetv1beta1.WorkGroup
+	key := types.NamespacedName{Name: work.Name, Namespace: work.Namespace}
+	err := r.Client.Get(ctx, key, &existing)
+	switch {
+	case apierrors.IsNotFound(err):
+		if group == nil {
+			return nil
+		}
+		if err := r.Client.Create(ctx, group); err != nil {
+			klog.ErrorS(err, "Failed to create the work group", "workGroup", key)
+			return controller.NewCreateIgnoreAlreadyExistError(err)
+		}
+		klog.V(2).InfoS("Created the work group of the split work", "workGroup", key, "parts", len(group.Spec.Parts))
+		return nil
+	case err != nil:
+		klog.ErrorS(err, "Failed to get the work group", "workGroup", key)
+		return controller.NewAPIServerError(true, err)
+	case group == nil:
+		if err := r.Client.Delete(ctx, &existing); err != nil && !apierrors.IsNotFound(err) {
+			klog.ErrorS(err, "Failed to delete the work group of the work which is no longer split", "workGroup", key)
+			return controller.NewAPIServerError(false, err)
+		}
+		klog.V(2).InfoS("Deleted the work group of the work which is no longer split", "workGroup", key)
+		return nil
+	case equality.Semantic.DeepEqual(existing.Spec, group.Spec):
+		return nil
+	}
+	existing.Spec = group.Spec
+	if err := r.Client.Update(ctx, &existing); err != nil {
+		klog.ErrorS(err, "Failed to update the work group", "workGroup", key)
+		return controller.NewUpdateIgnoreConflictError(err)
+	}
+	klog.V(2).InfoS("Updated the work group of the split work", "workGroup", key, "parts", len(group.Spec.Parts))
+	return nil
+}
@@ -0,0 +1,215 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package workgenerator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	workapplier "go.goms.io/fleet/pkg/controllers/work"
+)
+
+func testManifests(n int) []fleetv1beta1.Manifest {
+	manifests := make([]fleetv1beta1.Manifest, n)
+	for i := range manifests {
+		manifests[i].Raw = []byte(fmt.Sprintf(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-%d","namespace":"app"}}`, i))
+	}
+	return manifests
+}
+
+func testSplitWork(manifests int) *fleetv1beta1.Work {
+	return &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "crp-work",
+			Namespace: "fleet-member-test",
+			Labels:    map[string]string{fleetv1beta1.ParentBindingLabel: "binding"},
+		},
+		Spec: fleetv1beta1.WorkSpec{Workload: fleetv1beta1.WorkloadTemplate{Manifests: testManifests(manifests)}},
+	}
+}
+
+func TestWorkSplitterSplit(t *testing.T) {
+	tests := map[string]struct {
+		maxManifests  int
+		manifests     int
+		wantParts     map[string][]fleetv1beta1.Manifest
+		wantGroupSpec *fleetv1beta1.WorkGroupSpec
+	}{
+		"splitting is disabled": {
+			maxManifests: 0,
+			manifests:    5,
+			wantParts:    map[string][]fleetv1beta1.Manifest{"crp-work": testManifests(5)},
+		},
+		"work within the max is not split": {
+			maxManifests: 5,
+			manifests:    5,
+			wantParts:    map[string][]fleetv1beta1.Manifest{"crp-work": testManifests(5)},
+		},
+		"work over the max is split into non-overlapping parts": {
+			maxManifests: 2,
+			manifests:    5,
+			wantParts: map[string][]fleetv1beta1.Manifest{
+				"crp-work-part-0": testManifests(5)[0:2],
+				"crp-work-part-1": testManifests(5)[2:4],
+				"crp-work-part-2": testManifests(5)[4:5],
+			},
+			wantGroupSpec: &fleetv1beta1.WorkGroupSpec{Parts: []string{"crp-work-part-0", "crp-work-part-1", "crp-work-part-2"}},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			work := testSplitWork(tt.manifests)
+			parts, group := WorkSplitter{MaxManifestsPerWork: tt.maxManifests}.Split(work)
+			gotParts := make(map[string][]fleetv1beta1.Manifest, len(parts))
+			for _, part := range parts {
+				gotParts[part.Name] = part.Spec.Workload.Manifests
+				if tt.wantGroupSpec != nil && part.Labels[fleetv1beta1.WorkGroupLabel] != work.Name {
+					t.Errorf("part %s has the work group label %q, want %q", part.Name, part.Labels[fleetv1beta1.WorkGroupLabel], work.Name)
+				}
+				if part.Labels[fleetv1beta1.ParentBindingLabel] != "binding" {
+					t.Errorf("part %s lost the parent binding label", part.Name)
+				}
+			}
+			if diff := cmp.Diff(tt.wantParts, gotParts); diff != "" {
+				t.Errorf("Split() parts mismatch (-want +got):\n%s", diff)
+			}
+			if _, labeled := work.Labels[fleetv1beta1.WorkGroupLabel]; labeled {
+				t.Errorf("Split() labeled the split work itself")
+			}
+			var gotGroupSpec *fleetv1beta1.WorkGroupSpec
+			if group != nil {
+				gotGroupSpec = &group.Spec
+				if group.Name != work.Name || group.Namespace != work.Namespace {
+					t.Errorf("Split() group = %s/%s, want %s/%s", group.Namespace, group.Name, work.Namespace, work.Name)
+				}
+			}
+			if diff := cmp.Diff(tt.wantGroupSpec, gotGroupSpec); diff != "" {
+				t.Errorf("Split() group spec mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWorkSplitterSplitPerManifestSettings(t *testing.T) {
+	blobRef := strings.Repeat("a", 64)
+	work := testSplitWork(5)
+	work.Annotations = map[string]string{workapplier.WorkSkipManifestOrdinalsAnnotation: "0,3"}
+	work.Spec.Workload.ManifestRetryPolicies = []fleetv1beta1.ManifestRetryPolicy{
+		{Ordinal: 1, RetryPolicy: fleetv1beta1.RetryPolicy{MaxRetries: 1}},
+		{Ordinal: 4, RetryPolicy: fleetv1beta1.RetryPolicy{MaxRetries: 4}},
+	}
+	work.Spec.Workload.ManifestTargetNamespaces = []fleetv1beta1.ManifestTargetNamespace{{Ordinal: 2, TargetNamespace: "ns-2"}}
+	work.Spec.Workload.ManifestBinaryData = []fleetv1beta1.ManifestBinaryData{{Ordinal: 3, BinaryData: map[string][]byte{"key": {0xff}}}}
+	work.Spec.Workload.ManifestHooks = []fleetv1beta1.ManifestHooks{{Ordinal: 0}}
+	work.Spec.Workload.ManifestBlobRefs = []fleetv1beta1.ManifestBlobRef{{Ordinal: 4, BlobRef: blobRef}}
+
+	parts, _ := WorkSplitter{MaxManifestsPerWork: 2}.Split(work)
+	if len(parts) != 3 {
+		t.Fatalf("Split() returned %d parts, want 3", len(parts))
+	}
+	wantWorkloads := []fleetv1beta1.WorkloadTemplate{
+		{
+			Manifests: testManifests(5)[0:2],
+			ManifestRetryPolicies: []fleetv1beta1.ManifestRetryPolicy{
+				{Ordinal: 1, RetryPolicy: fleetv1beta1.RetryPolicy{MaxRetries: 1}},
+			},
+			ManifestHooks: []fleetv1beta1.ManifestHooks{{Ordinal: 0}},
+		},
+		{
+			Manifests:                testManifests(5)[2:4],
+			ManifestTargetNamespaces: []fleetv1beta1.ManifestTargetNamespace{{Ordinal: 0, TargetNamespace: "ns-2"}},
+			ManifestBinaryData:       []fleetv1beta1.ManifestBinaryData{{Ordinal: 1, BinaryData: map[string][]byte{"key": {0xff}}}},
+		},
+		{
+			Manifests: testManifests(5)[4:5],
+			ManifestRetryPolicies: []fleetv1beta1.ManifestRetryPolicy{
+				{Ordinal: 0, RetryPolicy: fleetv1beta1.RetryPolicy{MaxRetries: 4}},
+			},
+			ManifestBlobRefs: []fleetv1beta1.ManifestBlobRef{{Ordinal: 0, BlobRef: blobRef}},
+		},
+	}
+	wantSkipped := []*string{ptr.To("0"), ptr.To("1"), nil}
+	for i, part := range parts {
+		if diff := cmp.Diff(wantWorkloads[i], part.Spec.Workload); diff != "" {
+			t.Errorf("Split() part %d workload mismatch (-want +got):\n%s", i, diff)
+		}
+		var gotSkipped *string
+		if value, ok := part.Annotations[workapplier.WorkSkipManifestOrdinalsAnnotation]; ok {
+			gotSkipped = &value
+		}
+		if diff := cmp.Diff(wantSkipped[i], gotSkipped); diff != "" {
+			t.Errorf("Split() part %d skipped ordinals mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+	// the split work itself keeps its settings.
+	if len(work.Spec.Workload.ManifestRetryPolicies) != 2 || work.Spec.Workload.ManifestRetryPolicies[1].Ordinal != 4 {
+		t.Errorf("Split() changed the retry policies of the split work to %v", work.Spec.Workload.ManifestRetryPolicies)
+	}
+}
+
+func TestSyncWorkGroup(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	r := &Reconciler{Client: fake.NewClientBuilder().WithScheme(scheme).Build()}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "crp-work", Namespace: "fleet-member-test"}
+	getGroup := func() (*fleetv1beta1.WorkGroup, error) {
+		var group fleetv1beta1.WorkGroup
+		err := r.Client.Get(ctx, key, &group)
+		return &group, err
+	}
+
+	// the group of the split work is created.
+	_, group := WorkSplitter{MaxManifestsPerWork: 2}.Split(testSplitWork(5))
+	if err := r.syncWorkGroup(ctx, testSplitWork(5), group); err != nil {
+		t.Fatalf("syncWorkGroup() = %v, want no error", err)
+	}
+	got, err := getGroup()
+	if err != nil {
+		t.Fatalf("failed to get the work group: %v", err)
+	}
+	if diff := cmp.Diff(group.Spec, got.Spec); diff != "" {
+		t.Errorf("created work group spec mismatch (-want +got):\n%s", diff)
+	}
+
+	// the parts are updated when the work shrinks.
+	_, group = WorkSplitter{MaxManifestsPerWork: 2}.Split(testSplitWork(3))
+	if err := r.syncWorkGroup(ctx, testSplitWork(3), group); err != nil {
+		t.Fatalf("syncWorkGroup() = %v, want no error", err)
+	}
+	if got, err = getGroup(); err != nil {
+		t.Fatalf("failed to get the work group: %v", err)
+	}
+	if want := []string{"crp-work-part-0", "crp-work-part-1"}; !cmp.Equal(want, got.Spec.Parts) {
+		t.Errorf("updated work group parts = %v, want %v", got.Spec.Parts, want)
+	}
+
+	// the group is deleted once the work is no longer split.
+	if err := r.syncWorkGroup(ctx, testSplitWork(2), nil); err != nil {
+		t.Fatalf("syncWorkGroup() = %v, want no error", err)
+	}
+	if _, err := getGroup(); !apierrors.IsNotFound(err) {
+		t.Errorf("get the work group of the unsplit work = %v, want not found", err)
+	}
+	var groups fleetv1beta1.WorkGroupList
+	if err := r.Client.List(ctx, &groups, client.InNamespace(key.Namespace)); err != nil || len(groups.Items) != 0 {
+		t.Errorf("work groups = %d with error %v, want none", len(groups.Items), err)
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package worklatency features a controller to measure how long the member agents take to apply the works created
+// in the hub cluster.
+package worklatency
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/metrics"
+	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+// WorkLatencyObserver observes the time from the creation of a work to the first time it is applied.
+// A work is observed at most once; the works applied before the observer starts are not observed since their first
+// transition may have been observed by the previous run already.
+type WorkLatencyObserver struct {
+	client    client.Client
+	startTime time.Time
+
+	mu sync.Mutex
+	// observed are the UIDs of the works whose latency is observed, by the work names.
+	observed map[types.NamespacedName]types.UID
+}
+
+// NewWorkLatencyObserver creates a WorkLatencyObserver.
+func NewWorkLatencyObserver(hubClient client.Client) *WorkLatencyObserver {
+	return &WorkLatencyObserver{
+		client:    hubClient,
+		startTime: time.Now(),
+		observed:  make(map[types.NamespacedName]types.UID),
+	}
+}
+
+// Reconcile observes the application latency of the work if it is applied for the first time.
+func (o *WorkLatencyObserver) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var work fleetv1beta1.Work
+	if err := o.client.Get(ctx, req.NamespacedName, &work); err != nil {
+		if apierrors.IsNotFound(err) {
+			o.mu.Lock()
+			delete(o.observed, req.NamespacedName)
+			o.mu.Unlock()
+			return ctrl.Result{}, nil
+		}
+		klog.ErrorS(err, "Failed to get the work", "work", req.NamespacedName)
+		return ctrl.Result{}, controller.NewAPIServerError(true, err)
+	}
+
+	appliedCond := meta.FindStatusCondition(work.Status.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+	if appliedCond == nil || appliedCond.Status != metav1.ConditionTrue {
+		return ctrl.Result{}, nil
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	// a work recreated with the same name is a new work to observe.
+	if uid, found := o.observed[req.NamespacedName]; found && uid == work.UID {
+		return ctrl.Result{}, nil
+	}
+	o.observed[req.NamespacedName] = work.UID
+	if appliedCond.LastTransitionTime.Time.Before(o.startTime) {
+		return ctrl.Result{}, nil
+	}
+	latency := appliedCond.LastTransitionTime.Sub(work.CreationTimestamp.Time)
+	cluster := clusterName(work.Namespace)
+	metrics.WorkApplicationLatencySeconds.WithLabelValues(cluster).Observe(latency.Seconds())
+	klog.V(2).InfoS("Observed the application latency of the work", "work", klog.KObj(&work), "cluster", cluster, "latency", latency)
+	return ctrl.Result{}, nil
+}
+
+// clusterName returns the name of the member cluster whose reserved namespace is the given one.
+func clusterName(namespace string) string {
+	return strings.TrimPrefix(namespace, fmt.Sprintf(utils.NamespaceNameFormat, ""))
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (o *WorkLatencyObserver) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).Named("work-latency-observer").
+		For(&fleetv1beta1.Work{}).
+		Complete(o)
+}
@@ -0,0 +1,115 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package worklatency
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/metrics"
+)
+
+// observedLatency returns the number and the sum of the latencies observed for the cluster.
+func observedLatency(t *testing.T, cluster string) (uint64, float64) {
+	t.Helper()
+	var m dto.Metric
+	if err := metrics.WorkApplicationLatencySeconds.WithLabelValues(cluster).(prometheus.Histogram).Write(&m); err != nil {
+		t.Fatalf("failed to read the histogram: %v", err)
+	}
+	return m.GetHistogram().GetSampleCount(), m.GetHistogram().GetSampleSum()
+}
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	tests := map[string]struct {
+		cluster string
+		// appliedAgo is how long ago the work is applied; the work is not applied if it is nil.
+		appliedAgo  *time.Duration
+		wantLatency *time.Duration
+	}{
+		"work which is not applied yet": {
+			cluster: "not-applied",
+		},
+		"work which is applied": {
+			cluster:     "applied",
+			appliedAgo:  ptr.To(time.Duration(0)),
+			wantLatency: ptr.To(10 * time.Second),
+		},
+		"work which is applied before the observer starts": {
+			cluster:    "applied-before-start",
+			appliedAgo: ptr.To(time.Hour),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			now := time.Now()
+			work := &fleetv1beta1.Work{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "test-work",
+					Namespace:         "fleet-member-" + tt.cluster,
+					UID:               "test-uid",
+					CreationTimestamp: metav1.NewTime(now.Add(-10 * time.Second)),
+				},
+			}
+			hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(work).WithStatusSubresource(work).Build()
+			o := NewWorkLatencyObserver(hubClient)
+			o.startTime = now.Add(-time.Minute)
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: work.Name, Namespace: work.Namespace}}
+
+			// the first reconcile sees the work before it is applied.
+			if _, err := o.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile() = %v, want no error", err)
+			}
+			if tt.appliedAgo != nil {
+				// the member agent reports the work is applied.
+				work.Status.Conditions = []metav1.Condition{{
+					Type:               fleetv1beta1.WorkConditionTypeApplied,
+					Status:             metav1.ConditionTrue,
+					Reason:             "WorkAppliedCompleted",
+					LastTransitionTime: metav1.NewTime(now.Add(-*tt.appliedAgo)),
+				}}
+				if err := hubClient.Status().Update(context.Background(), work); err != nil {
+					t.Fatalf("failed to update the work status: %v", err)
+				}
+			}
+			// reconcile twice to make sure the work is observed only once.
+			for i := 0; i < 2; i++ {
+				if _, err := o.Reconcile(context.Background(), req); err != nil {
+					t.Fatalf("Reconcile() = %v, want no error", err)
+				}
+			}
+
+			count, sum := observedLatency(t, tt.cluster)
+			if tt.wantLatency == nil {
+				if count != 0 {
+					t.Errorf("Reconcile() observed %d latencies, want none", count)
+				}
+				return
+			}
+			if count != 1 {
+				t.Fatalf("Reconcile() observed %d latencies, want 1", count)
+			}
+			if diff := math.Abs(sum - tt.wantLatency.Seconds()); diff > 1 {
+				t.Errorf("Reconcile() observed latency %vs, want it within 1s of %v", sum, *tt.wantLatency)
+			}
+		})
+	}
+}
@@ -0,0 +1,312 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package workreplicator features a controller to mirror the Work objects of a hub cluster to the other hub clusters
+// of an active-active fleet.
+package workreplicator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// workReplicatedReason is the reason of the WorkReplicated condition when all the works are replicated.
+	workReplicatedReason = "AllWorkReplicated"
+	// workNotReplicatedReason is the reason of the WorkReplicated condition when some works fail to replicate.
+	workNotReplicatedReason = "ReplicateWorkFailed"
+	// invalidWorkReplicatorReason is the reason of the WorkReplicated condition when the replicator is invalid.
+	invalidWorkReplicatorReason = "InvalidWorkReplicator"
+
+	// replicationInterval is how often the works are replicated again. The works on the other hubs cannot be watched
+	// by the controller so their changes are picked up by the periodic replication.
+	replicationInterval = 30 * time.Second
+)
+
+// Reconciler reconciles a WorkReplicator object.
+type Reconciler struct {
+	// Client is the client the controller uses to access the hub cluster the replicators are in.
+	client.Client
+	// HubClientGetter returns the clients of the source and the target hubs.
+	HubClientGetter HubClientGetter
+}
+
+// Reconcile replicates the works selected on the source hub to the target hubs and deletes the replicas of the works
+// which are deleted or no longer selected.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	wrRef := klog.KRef("", req.Name)
+	startTime := time.Now()
+	klog.V(2).InfoS("WorkReplicator reconciliation starts", "workReplicator", wrRef)
+	defer func() {
+		latency := time.Since(startTime).Milliseconds()
+		klog.V(2).InfoS("WorkReplicator reconciliation ends", "workReplicator", wrRef, "latency", latency)
+	}()
+
+	var wr fleetv1beta1.WorkReplicator
+	if err := r.Client.Get(ctx, req.NamespacedName, &wr); err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.V(2).InfoS("Ignoring NotFound workReplicator", "workReplicator", wrRef)
+			return ctrl.Result{}, nil
+		}
+		klog.ErrorS(err, "Failed to get workReplicator", "workReplicator", wrRef)
+		return ctrl.Result{}, controller.NewAPIServerError(true, err)
+	}
+	if wr.DeletionTimestamp != nil {
+		return ctrl.Result{}, r.handleDelete(ctx, &wr)
+	}
+	if !controllerutil.ContainsFinalizer(&wr, fleetv1beta1.WorkReplicatorFinalizer) {
+		controllerutil.AddFinalizer(&wr, fleetv1beta1.WorkReplicatorFinalizer)
+		if err := r.Client.Update(ctx, &wr); err != nil {
+			klog.ErrorS(err, "Failed to add the finalizer to the workReplicator", "workReplicator", wrRef)
+			return ctrl.Result{}, controller.NewUpdateIgnoreConflictError(err)
+		}
+	}
+
+	sourceWorks, err := r.listSourceWorks(ctx, &wr)
+	if err != nil {
+		if errors.Is(err, controller.ErrUserError) {
+			wr.SetConditions(metav1.Condition{
+				Type:               string(fleetv1beta1.WorkReplicatorConditionTypeWorkReplicated),
+				Status:             metav1.ConditionFalse,
+				Reason:             invalidWorkReplicatorReason,
+				Message:            err.Error(),
+				ObservedGeneration: wr.Generation,
+			})
+			return ctrl.Result{RequeueAfter: replicationInterval}, r.updateStatus(ctx, &wr)
+		}
+		return ctrl.Result{}, err
+	}
+
+	var replicateErr error
+	for _, hub := range wr.Spec.TargetHubs {
+		if err := r.replicateToHub(ctx, &wr, hub, sourceWorks); err != nil {
+			replicateErr = err
+		}
+	}
+
+	wr.Status.ReplicatedWorks = len(sourceWorks)
+	replicatedCond := metav1.Condition{
+		Type:               string(fleetv1beta1.WorkReplicatorConditionTypeWorkReplicated),
+		Status:             metav1.ConditionTrue,
+		Reason:             workReplicatedReason,
+		Message:            fmt.Sprintf("%d works are replicated to %d target hubs", len(sourceWorks), len(wr.Spec.TargetHubs)),
+		ObservedGeneration: wr.Generation,
+	}
+	if replicateErr != nil {
+		replicatedCond.Status = metav1.ConditionFalse
+		replicatedCond.Reason = workNotReplicatedReason
+		replicatedCond.Message = replicateErr.Error()
+	}
+	wr.SetConditions(replicatedCond)
+	if err := r.updateStatus(ctx, &wr); err != nil {
+		return ctrl.Result{}, err
+	}
+	if replicateErr != nil {
+		return ctrl.Result{}, replicateErr
+	}
+	return ctrl.Result{RequeueAfter: replicationInterval}, nil
+}
+
+// listSourceWorks returns the works selected by the replicator on the source hub. The work replicas and the works
+// which are being deleted are skipped.
+func (r *Reconciler) listSourceWorks(ctx context.Context, wr *fleetv1beta1.WorkReplicator) ([]fleetv1beta1.Work, error) {
+	for _, hub := range wr.Spec.TargetHubs {
+		if hub == wr.Spec.SourceHub {
+			return nil, controller.NewUserError(fmt.Errorf("the source hub %q cannot be a target hub", hub))
+		}
+	}
+	selector := labels.Everything()
+	if wr.Spec.WorkSelector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(wr.Spec.WorkSelector); err != nil {
+			return nil, controller.NewUserError(fmt.Errorf("invalid work selector %v: %w", wr.Spec.WorkSelector, err))
+		}
+	}
+	// the replicas are never replicated again, which would replicate them back to their source hubs.
+	notReplica, err := labels.NewRequirement(fleetv1beta1.ReplicaSourceHubLabel, selection.DoesNotExist, nil)
+	if err != nil {
+		return nil, controller.NewUnexpectedBehaviorError(err)
+	}
+	selector = selector.Add(*notReplica)
+
+	sourceClient, err := r.HubClientGetter.HubClient(ctx, wr.Spec.SourceHub)
+	if err != nil {
+		return nil, err
+	}
+	var workList fleetv1beta1.WorkList
+	if err := sourceClient.List(ctx, &workList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		klog.ErrorS(err, "Failed to list the works on the source hub", "workReplicator", klog.KObj(wr), "hub", wr.Spec.SourceHub)
+		return nil, controller.NewAPIServerError(false, err)
+	}
+	works := make([]fleetv1beta1.Work, 0, len(workList.Items))
+	for i := range workList.Items {
+		// the replicas of a deleted work are deleted while the work itself waits for its resources to be cleaned up.
+		if workList.Items[i].DeletionTimestamp == nil {
+			works = append(works, workList.Items[i])
+		}
+	}
+	return works, nil
+}
+
+// listReplicas returns the existing work replicas of the replicator on the given hub keyed by their names.
+func (r *Reconciler) listReplicas(ctx context.Context, wr *fleetv1beta1.WorkReplicator, hub string, hubClient client.Client) (map[types.NamespacedName]*fleetv1beta1.Work, error) {
+	var workList fleetv1beta1.WorkList
+	if err := hubClient.List(ctx, &workList, client.MatchingLabels{
+		fleetv1beta1.WorkReplicatorTrackingLabel: wr.Name,
+		fleetv1beta1.ReplicaSourceHubLabel:       wr.Spec.SourceHub,
+	}); err != nil {
+		klog.ErrorS(err, "Failed to list the work replicas on the target hub", "workReplicator", klog.KObj(wr), "hub", hub)
+		return nil, controller.NewAPIServerError(false, err)
+	}
+	replicas := make(map[types.NamespacedName]*fleetv1beta1.Work, len(workList.Items))
+	for i := range workList.Items {
+		replica := &workList.Items[i]
+		replicas[types.NamespacedName{Name: replica.Name, Namespace: replica.Namespace}] = replica
+	}
+	return replicas, nil
+}
+
+// replicateToHub creates or updates the replicas of the source works on the target hub and deletes the replicas
+// whose source works are gone.
+func (r *Reconciler) replicateToHub(ctx context.Context, wr *fleetv1beta1.WorkReplicator, hub string, sourceWorks []fleetv1beta1.Work) error {
+	hubClient, err := r.HubClientGetter.HubClient(ctx, hub)
+	if err != nil {
+		return err
+	}
+	replicas, err := r.listReplicas(ctx, wr, hub, hubClient)
+	if err != nil {
+		return err
+	}
+
+	var replicateErr error
+	for i := range sourceWorks {
+		desired := buildReplica(wr, &sourceWorks[i])
+		key := types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}
+		existing := replicas[key]
+		delete(replicas, key)
+		if existing == nil {
+			klog.V(2).InfoS("Creating the work replica", "workReplicator", klog.KObj(wr), "hub", hub, "work", key)
+			if err := hubClient.Create(ctx, desired); err != nil {
+				klog.ErrorS(err, "Failed to create the work replica", "workReplicator", klog.KObj(wr), "hub", hub, "work", key)
+				replicateErr = controller.NewAPIServerError(false, err)
+			}
+			continue
+		}
+		if equality.Semantic.DeepEqual(existing.Spec, desired.Spec) &&
+			equality.Semantic.DeepEqual(existing.Labels, desired.Labels) &&
+			equality.Semantic.DeepEqual(existing.Annotations, desired.Annotations) {
+			continue
+		}
+		existing.Labels = desired.Labels
+		existing.Annotations = desired.Annotations
+		existing.Spec = desired.Spec
+		klog.V(2).InfoS("Updating the work replica", "workReplicator", klog.KObj(wr), "hub", hub, "work", key)
+		if err := hubClient.Update(ctx, existing); err != nil {
+			klog.ErrorS(err, "Failed to update the work replica", "workReplicator", klog.KObj(wr), "hub", hub, "work", key)
+			replicateErr = controller.NewAPIServerError(false, err)
+		}
+	}
+	if err := deleteReplicas(ctx, wr, hub, hubClient, replicas); err != nil {
+		replicateErr = err
+	}
+	return replicateErr
+}
+
+// deleteReplicas deletes the given work replicas on the hub.
+func deleteReplicas(ctx context.Context, wr *fleetv1beta1.WorkReplicator, hub string, hubClient client.Client, replicas map[types.NamespacedName]*fleetv1beta1.Work) error {
+	var deleteErr error
+	for key, replica := range replicas {
+		klog.V(2).InfoS("Deleting the work replica", "workReplicator", klog.KObj(wr), "hub", hub, "work", key)
+		if err := hubClient.Delete(ctx, replica); err != nil && !apierrors.IsNotFound(err) {
+			klog.ErrorS(err, "Failed to delete the work replica", "workReplicator", klog.KObj(wr), "hub", hub, "work", key)
+			deleteErr = controller.NewAPIServerError(false, err)
+		}
+	}
+	return deleteErr
+}
+
+// buildReplica builds the replica of the source work.
+func buildReplica(wr *fleetv1beta1.WorkReplicator, source *fleetv1beta1.Work) *fleetv1beta1.Work {
+	replicaLabels := make(map[string]string, len(source.Labels)+2)
+	for k, v := range source.Labels {
+		replicaLabels[k] = v
+	}
+	replicaLabels[fleetv1beta1.WorkReplicatorTrackingLabel] = wr.Name
+	replicaLabels[fleetv1beta1.ReplicaSourceHubLabel] = wr.Spec.SourceHub
+	return &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        source.Name,
+			Namespace:   source.Namespace,
+			Labels:      replicaLabels,
+			Annotations: source.Annotations,
+		},
+		Spec: *source.Spec.DeepCopy(),
+	}
+}
+
+// handleDelete deletes the work replicas on all the target hubs before removing the finalizer of the replicator.
+func (r *Reconciler) handleDelete(ctx context.Context, wr *fleetv1beta1.WorkReplicator) error {
+	if !controllerutil.ContainsFinalizer(wr, fleetv1beta1.WorkReplicatorFinalizer) {
+		return nil
+	}
+	for _, hub := range wr.Spec.TargetHubs {
+		hubClient, err := r.HubClientGetter.HubClient(ctx, hub)
+		if err != nil {
+			if errors.Is(err, controller.ErrUserError) {
+				// the replicas on a hub which cannot be reached are left behind.
+				klog.ErrorS(err, "Skipping the cleanup of the work replicas on the unreachable hub", "workReplicator", klog.KObj(wr), "hub", hub)
+				continue
+			}
+			return err
+		}
+		replicas, err := r.listReplicas(ctx, wr, hub, hubClient)
+		if err != nil {
+			return err
+		}
+		if err := deleteReplicas(ctx, wr, hub, hubClient, replicas); err != nil {
+			return err
+		}
+	}
+	controllerutil.RemoveFinalizer(wr, fleetv1beta1.WorkReplicatorFinalizer)
+	if err := r.Client.Update(ctx, wr); err != nil {
+		klog.ErrorS(err, "Failed to remove the finalizer from the workReplicator", "workReplicator", klog.KObj(wr))
+		return controller.NewUpdateIgnoreConflictError(err)
+	}
+	klog.V(2).InfoS("Deleted the work replicas of the workReplicator", "workReplicator", klog.KObj(wr))
+	return nil
+}
+
+func (r *Reconciler) updateStatus(ctx context.Context, wr *fleetv1beta1.WorkReplicator) error {
+	if err := r.Client.Status().Update(ctx, wr); err != nil {
+		klog.ErrorS(err, "Failed to update the workReplicator status", "workReplicator", klog.KObj(wr))
+		return controller.NewUpdateIgnoreConflictError(err)
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).Named("work-replicator-controller").
+		For(&fleetv1beta1.WorkReplicator{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
@@ -0,0 +1,231 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package workreplicator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	hubA = "hub-a"
+	hubB = "hub-b"
+
+	workNamespace = "fleet-member-test"
+)
+
+// fakeHubClientGetter returns the clients of the mocked hubs; the hubs which are not mocked are unreachable.
+type fakeHubClientGetter map[string]client.Client
+
+func (g fakeHubClientGetter) HubClient(_ context.Context, hub string) (client.Client, error) {
+	hubClient, found := g[hub]
+	if !found {
+		return nil, controller.NewUserError(fmt.Errorf("hub %q is unreachable", hub))
+	}
+	return hubClient, nil
+}
+
+func testScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	return scheme
+}
+
+func testWork(name string, workLabels map[string]string, manifest string) *fleetv1beta1.Work {
+	return &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: workNamespace, Labels: workLabels},
+		Spec: fleetv1beta1.WorkSpec{
+			Workload: fleetv1beta1.WorkloadTemplate{
+				Manifests: []fleetv1beta1.Manifest{{RawExtension: runtime.RawExtension{Raw: []byte(manifest)}}},
+			},
+		},
+	}
+}
+
+func testReplicator(name, source, target string) *fleetv1beta1.WorkReplicator {
+	return &fleetv1beta1.WorkReplicator{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: fleetv1beta1.WorkReplicatorSpec{
+			SourceHub:    source,
+			TargetHubs:   []string{target},
+			WorkSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"replicate": "true"}},
+		},
+	}
+}
+
+// workSpecs returns the spec of the works on the hub keyed by their names.
+func workSpecs(t *testing.T, hubClient client.Client) map[string]fleetv1beta1.WorkSpec {
+	t.Helper()
+	var workList fleetv1beta1.WorkList
+	if err := hubClient.List(context.Background(), &workList); err != nil {
+		t.Fatalf("failed to list the works: %v", err)
+	}
+	specs := make(map[string]fleetv1beta1.WorkSpec, len(workList.Items))
+	for _, work := range workList.Items {
+		specs[work.Name] = work.Spec
+	}
+	return specs
+}
+
+func TestReconcileBidirectional(t *testing.T) {
+	const (
+		manifest        = `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm","namespace":"default"}}`
+		updatedManifest = `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm","namespace":"kube-system"}}`
+	)
+	ctx := context.Background()
+	scheme := testScheme(t)
+	selected := map[string]string{"replicate": "true"}
+	aToB := testReplicator("a-to-b", hubA, hubB)
+	bToA := testReplicator("b-to-a", hubB, hubA)
+	// the replicators are in hub A, which replicates to and from hub B.
+	hubAClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(aToB, bToA, testWork("work-a", selected, manifest), testWork("unselected", nil, manifest)).
+		WithStatusSubresource(aToB, bToA).Build()
+	hubBClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(testWork("work-b", selected, manifest)).Build()
+	r := &Reconciler{
+		Client:          hubAClient,
+		HubClientGetter: fakeHubClientGetter{hubA: hubAClient, hubB: hubBClient},
+	}
+	reconcileAll := func() {
+		t.Helper()
+		for _, name := range []string{aToB.Name, bToA.Name} {
+			if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: name}}); err != nil {
+				t.Fatalf("Reconcile(%s) = %v, want no error", name, err)
+			}
+		}
+	}
+	wantSpec := testWork("", nil, manifest).Spec
+
+	// the works are replicated in both directions, and the replicas are not replicated back.
+	reconcileAll()
+	reconcileAll()
+	if diff := cmp.Diff(map[string]fleetv1beta1.WorkSpec{"work-a": wantSpec, "work-b": wantSpec, "unselected": wantSpec}, workSpecs(t, hubAClient)); diff != "" {
+		t.Errorf("works on hub A mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]fleetv1beta1.WorkSpec{"work-a": wantSpec, "work-b": wantSpec}, workSpecs(t, hubBClient)); diff != "" {
+		t.Errorf("works on hub B mismatch (-want +got):\n%s", diff)
+	}
+	var replica fleetv1beta1.Work
+	if err := hubBClient.Get(ctx, types.NamespacedName{Name: "work-a", Namespace: workNamespace}, &replica); err != nil {
+		t.Fatalf("failed to get the replica on hub B: %v", err)
+	}
+	wantLabels := map[string]string{
+		"replicate":                              "true",
+		fleetv1beta1.WorkReplicatorTrackingLabel: aToB.Name,
+		fleetv1beta1.ReplicaSourceHubLabel:       hubA,
+	}
+	if diff := cmp.Diff(wantLabels, replica.Labels); diff != "" {
+		t.Errorf("replica labels mismatch (-want +got):\n%s", diff)
+	}
+
+	// the update on hub B is propagated to hub A.
+	var workB fleetv1beta1.Work
+	if err := hubBClient.Get(ctx, types.NamespacedName{Name: "work-b", Namespace: workNamespace}, &workB); err != nil {
+		t.Fatalf("failed to get the work on hub B: %v", err)
+	}
+	workB.Spec = testWork("", nil, updatedManifest).Spec
+	if err := hubBClient.Update(ctx, &workB); err != nil {
+		t.Fatalf("failed to update the work on hub B: %v", err)
+	}
+	// the deletion on hub A is propagated to hub B.
+	if err := hubAClient.Delete(ctx, testWork("work-a", nil, manifest)); err != nil {
+		t.Fatalf("failed to delete the work on hub A: %v", err)
+	}
+	reconcileAll()
+	wantUpdatedSpec := testWork("", nil, updatedManifest).Spec
+	if diff := cmp.Diff(map[string]fleetv1beta1.WorkSpec{"work-b": wantUpdatedSpec, "unselected": wantSpec}, workSpecs(t, hubAClient)); diff != "" {
+		t.Errorf("works on hub A mismatch after the changes (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]fleetv1beta1.WorkSpec{"work-b": wantUpdatedSpec}, workSpecs(t, hubBClient)); diff != "" {
+		t.Errorf("works on hub B mismatch after the changes (-want +got):\n%s", diff)
+	}
+
+	var got fleetv1beta1.WorkReplicator
+	if err := hubAClient.Get(ctx, types.NamespacedName{Name: aToB.Name}, &got); err != nil {
+		t.Fatalf("failed to get the workReplicator: %v", err)
+	}
+	wantCond := metav1.Condition{
+		Type:    string(fleetv1beta1.WorkReplicatorConditionTypeWorkReplicated),
+		Status:  metav1.ConditionTrue,
+		Reason:  workReplicatedReason,
+		Message: "0 works are replicated to 1 target hubs",
+	}
+	if diff := cmp.Diff(wantCond, *got.GetCondition(string(fleetv1beta1.WorkReplicatorConditionTypeWorkReplicated)),
+		cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime", "ObservedGeneration")); diff != "" {
+		t.Errorf("workReplicator condition mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReconcileUnreachableHub(t *testing.T) {
+	ctx := context.Background()
+	scheme := testScheme(t)
+	wr := testReplicator("a-to-c", hubA, "hub-c")
+	hubAClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(wr).WithStatusSubresource(wr).Build()
+	r := &Reconciler{
+		Client:          hubAClient,
+		HubClientGetter: fakeHubClientGetter{hubA: hubAClient},
+	}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: wr.Name}}); err == nil {
+		t.Fatalf("Reconcile() = nil, want error")
+	}
+	var got fleetv1beta1.WorkReplicator
+	if err := hubAClient.Get(ctx, types.NamespacedName{Name: wr.Name}, &got); err != nil {
+		t.Fatalf("failed to get the workReplicator: %v", err)
+	}
+	cond := got.GetCondition(string(fleetv1beta1.WorkReplicatorConditionTypeWorkReplicated))
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != workNotReplicatedReason {
+		t.Errorf("workReplicator condition = %+v, want %s with reason %s", cond, metav1.ConditionFalse, workNotReplicatedReason)
+	}
+}
+
+func TestHandleDelete(t *testing.T) {
+	ctx := context.Background()
+	scheme := testScheme(t)
+	wr := testReplicator("a-to-b", hubA, hubB)
+	wr.Finalizers = []string{fleetv1beta1.WorkReplicatorFinalizer}
+	replica := testWork("work-a", map[string]string{
+		fleetv1beta1.WorkReplicatorTrackingLabel: wr.Name,
+		fleetv1beta1.ReplicaSourceHubLabel:       hubA,
+	}, `{}`)
+	hubAClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(wr).Build()
+	hubBClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(replica, testWork("own-work", nil, `{}`)).Build()
+	r := &Reconciler{
+		Client:          hubAClient,
+		HubClientGetter: fakeHubClientGetter{hubA: hubAClient, hubB: hubBClient},
+	}
+	if err := hubAClient.Delete(ctx, wr); err != nil {
+		t.Fatalf("failed to delete the workReplicator: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: wr.Name}}); err != nil {
+		t.Fatalf("Reconcile() = %v, want no error", err)
+	}
+	if diff := cmp.Diff(map[string]fleetv1beta1.WorkSpec{"own-work": testWork("", nil, `{}`).Spec}, workSpecs(t, hubBClient)); diff != "" {
+		t.Errorf("works on hub B mismatch (-want +got):\n%s", diff)
+	}
+	var workReplicatorList fleetv1beta1.WorkReplicatorList
+	if err := hubAClient.List(ctx, &workReplicatorList); err != nil {
+		t.Fatalf("failed to list the workReplicators: %v", err)
+	}
+	if len(workReplicatorList.Items) != 0 {
+		t.Errorf("workReplicators = %v, want none after the finalizer is removed", workReplicatorList.Items)
+	}
+}
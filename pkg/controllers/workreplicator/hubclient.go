@@ -0,0 +1,91 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package workreplicator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// HubKubeConfigSecretKey is the key of the kubeconfig in the Secret of a hub cluster.
+	HubKubeConfigSecretKey = "kubeconfig"
+)
+
+// HubClientGetter returns the clients to access the hub clusters by their names.
+type HubClientGetter interface {
+	HubClient(ctx context.Context, hub string) (client.Client, error)
+}
+
+// cachedHubClient is a hub client built from the given version of the hub Secret.
+type cachedHubClient struct {
+	resourceVersion string
+	client          client.Client
+}
+
+// SecretHubClientGetter builds the hub clients from the kubeconfig stored in the Secret named after the hub in the
+// fleet system namespace. The clients are rebuilt when the Secrets change.
+type SecretHubClientGetter struct {
+	// Reader reads the Secrets of the hubs; it is not cached so that not all the Secrets are cached.
+	Reader client.Reader
+	// Scheme is the scheme of the hub clients.
+	Scheme *runtime.Scheme
+
+	mu sync.Mutex
+	// clients are the hub clients keyed by the names of their Secrets and the keys of the kubeconfig in them.
+	clients map[string]cachedHubClient
+}
+
+// HubClient returns the client of the given hub.
+func (g *SecretHubClientGetter) HubClient(ctx context.Context, hub string) (client.Client, error) {
+	return g.KubeConfigClient(ctx, hub, HubKubeConfigSecretKey)
+}
+
+// KubeConfigClient returns the client of the hub whose kubeconfig is stored under the key of the Secret with the
+// given name in the fleet system namespace.
+func (g *SecretHubClientGetter) KubeConfigClient(ctx context.Context, secretName, key string) (client.Client, error) {
+	var secret corev1.Secret
+	secretKey := types.NamespacedName{Name: secretName, Namespace: utils.FleetSystemNamespace}
+	if err := g.Reader.Get(ctx, secretKey, &secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, controller.NewUserError(fmt.Errorf("the kubeconfig secret %s is not found", secretKey))
+		}
+		klog.ErrorS(err, "Failed to get the kubeconfig secret of the hub", "secret", secretKey)
+		return nil, controller.NewAPIServerError(true, err)
+	}
+
+	cacheKey := secretName + "/" + key
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if cached, found := g.clients[cacheKey]; found && cached.resourceVersion == secret.ResourceVersion {
+		return cached.client, nil
+	}
+	config, err := clientcmd.RESTConfigFromKubeConfig(secret.Data[key])
+	if err != nil {
+		return nil, controller.NewUserError(fmt.Errorf("the kubeconfig under key %q of secret %s is invalid: %w", key, secretKey, err))
+	}
+	hubClient, err := client.New(config, client.Options{Scheme: g.Scheme})
+	if err != nil {
+		return nil, controller.NewUserError(fmt.Errorf("failed to create the client of the hub in secret %s: %w", secretKey, err))
+	}
+	if g.clients == nil {
+		g.clients = make(map[string]cachedHubClient)
+	}
+	g.clients[cacheKey] = cachedHubClient{resourceVersion: secret.ResourceVersion, client: hubClient}
+	return hubClient, nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package workreplicator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const testKubeConfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: hub
+  cluster:
+    server: https://127.0.0.1:6443
+contexts:
+- name: hub
+  context:
+    cluster: hub
+    user: hub
+current-context: hub
+users:
+- name: hub
+  user:
+    token: test-token
+`
+
+func TestSecretHubClientGetter(t *testing.T) {
+	hubSecret := func(name, kubeConfig string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: utils.FleetSystemNamespace},
+			Data:       map[string][]byte{HubKubeConfigSecretKey: []byte(kubeConfig)},
+		}
+	}
+	tests := map[string]struct {
+		objects       []client.Object
+		wantUserError bool
+	}{
+		"secret does not exist": {
+			wantUserError: true,
+		},
+		"invalid kubeconfig": {
+			objects:       []client.Object{hubSecret("hub", "not a kubeconfig")},
+			wantUserError: true,
+		},
+		"valid kubeconfig": {
+			objects: []client.Object{hubSecret("hub", testKubeConfig)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := &SecretHubClientGetter{
+				Reader: fake.NewClientBuilder().WithObjects(tt.objects...).Build(),
+				Scheme: runtime.NewScheme(),
+			}
+			hubClient, err := g.HubClient(context.Background(), "hub")
+			if tt.wantUserError {
+				if !errors.Is(err, controller.ErrUserError) {
+					t.Errorf("HubClient() = %v, want user error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("HubClient() = %v, want no error", err)
+			}
+			// the client is reused while the secret does not change.
+			cached, err := g.HubClient(context.Background(), "hub")
+			if err != nil {
+				t.Fatalf("HubClient() = %v, want no error", err)
+			}
+			if cached != hubClient {
+				t.Errorf("HubClient() returned a new client, want the cached one")
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package workspecgrowth features a controller to detect the works whose spec grows too much in a single update,
+// e.g. because a binary blob is embedded in a ConfigMap by accident, before they exhaust the etcd quota.
+package workspecgrowth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// DefaultMaxGrowthRatioPerUpdate is the default ratio by which the work spec may grow in a single update without
+	// being reported.
+	DefaultMaxGrowthRatioPerUpdate = 5
+
+	// WorkSpecGrowthAlertReason is the reason of the event and the condition when the work spec grows by more than
+	// the allowed ratio in a single update.
+	WorkSpecGrowthAlertReason = "WorkSpecGrowthAlert"
+
+	// WorkSpecShrunkReason is the reason of the condition when the work spec shrinks after a growth alert.
+	WorkSpecShrunkReason = "WorkSpecShrunk"
+)
+
+// observedSpec is the size of a work spec at the generation it is observed.
+type observedSpec struct {
+	uid        types.UID
+	generation int64
+	sizeBytes  int64
+}
+
+// WorkSpecGrowthDetector compares the size of each work spec with the size of its previous generation. The sizes are
+// kept in memory, so the first generation of a work observed after the detector starts is not compared.
+type WorkSpecGrowthDetector struct {
+	client   client.Client
+	recorder record.EventRecorder
+	// maxGrowthRatio is the ratio by which the work spec may grow in a single update without being reported.
+	maxGrowthRatio float64
+
+	mu       sync.Mutex
+	observed map[types.NamespacedName]observedSpec
+}
+
+// NewWorkSpecGrowthDetector creates a WorkSpecGrowthDetector.
+func NewWorkSpecGrowthDetector(hubClient client.Client, recorder record.EventRecorder, maxGrowthRatio float64) *WorkSpecGrowthDetector {
+	return &WorkSpecGrowthDetector{
+		client:         hubClient,
+		recorder:       recorder,
+		maxGrowthRatio: maxGrowthRatio,
+		observed:       make(map[types.NamespacedName]observedSpec),
+	}
+}
+
+// Reconcile compares the size of the work spec with the size of its previously observed generation, and sets the
+// spec growth warning condition if the spec grows by more than the allowed ratio. The condition is cleared once the
+// spec shrinks.
+func (d *WorkSpecGrowthDetector) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var work fleetv1beta1.Work
+	if err := d.client.Get(ctx, req.NamespacedName, &work); err != nil {
+		if apierrors.IsNotFound(err) {
+			d.mu.Lock()
+			delete(d.observed, req.NamespacedName)
+			d.mu.Unlock()
+			return ctrl.Result{}, nil
+		}
+		klog.ErrorS(err, "Failed to get the work", "work", req.NamespacedName)
+		return ctrl.Result{}, controller.NewAPIServerError(true, err)
+	}
+
+	d.mu.Lock()
+	previous, found := d.observed[req.NamespacedName]
+	d.mu.Unlock()
+	// a work recreated with the same name is a new work to observe.
+	if found && previous.uid != work.UID {
+		found = false
+	}
+	if found && previous.generation >= work.Generation {
+		return ctrl.Result{}, nil
+	}
+
+	raw, err := json.Marshal(work.Spec)
+	if err != nil {
+		klog.ErrorS(err, "Failed to compute the work spec size", "work", klog.KObj(&work))
+		return ctrl.Result{}, controller.NewUnexpectedBehaviorError(err)
+	}
+	current := observedSpec{uid: work.UID, generation: work.Generation, sizeBytes: int64(len(raw))}
+
+	if found {
+		var cond *metav1.Condition
+		switch {
+		case previous.sizeBytes > 0 && float64(current.sizeBytes) > float64(previous.sizeBytes)*d.maxGrowthRatio:
+			message := fmt.Sprintf("The work spec grew from %d to %d bytes in a single update, more than %g times",
+				previous.sizeBytes, current.sizeBytes, d.maxGrowthRatio)
+			klog.V(2).InfoS("The work spec grew too much in a single update", "work", klog.KObj(&work),
+				"previousSizeBytes", previous.sizeBytes, "sizeBytes", current.sizeBytes)
+			d.recorder.Event(&work, corev1.EventTypeWarning, WorkSpecGrowthAlertReason, message)
+			cond = &metav1.Condition{
+				Type:               fleetv1beta1.WorkConditionTypeSpecGrowthWarning,
+				Status:             metav1.ConditionTrue,
+				Reason:             WorkSpecGrowthAlertReason,
+				Message:            message,
+				ObservedGeneration: work.Generation,
+			}
+		case current.sizeBytes < previous.sizeBytes &&
+			meta.IsStatusConditionTrue(work.Status.Conditions, fleetv1beta1.WorkConditionTypeSpecGrowthWarning):
+			cond = &metav1.Condition{
+				Type:               fleetv1beta1.WorkConditionTypeSpecGrowthWarning,
+				Status:             metav1.ConditionFalse,
+				Reason:             WorkSpecShrunkReason,
+				Message:            fmt.Sprintf("The work spec shrank from %d to %d bytes", previous.sizeBytes, current.sizeBytes),
+				ObservedGeneration: work.Generation,
+			}
+		}
+		if cond != nil {
+			meta.SetStatusCondition(&work.Status.Conditions, *cond)
+			if err := d.client.Status().Update(ctx, &work); err != nil {
+				klog.ErrorS(err, "Failed to update the work status", "work", klog.KObj(&work))
+				return ctrl.Result{}, controller.NewUpdateIgnoreConflictError(err)
+			}
+		}
+	}
+
+	d.mu.Lock()
+	d.observed[req.NamespacedName] = current
+	d.mu.Unlock()
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (d *WorkSpecGrowthDetector) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).Named("work-spec-growth-detector").
+		For(&fleetv1beta1.Work{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(d)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package workspecgrowth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// configMapWorkload returns a workload of a ConfigMap whose data is about the given size in bytes.
+func configMapWorkload(dataSize int) fleetv1beta1.WorkloadTemplate {
+	raw := fmt.Sprintf(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm","namespace":"default"},"data":{"blob":%q}}`,
+		strings.Repeat("x", dataSize))
+	return fleetv1beta1.WorkloadTemplate{Manifests: []fleetv1beta1.Manifest{{RawExtension: runtime.RawExtension{Raw: []byte(raw)}}}}
+}
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	workKey := types.NamespacedName{Name: "test-work", Namespace: "fleet-member-test"}
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: workKey.Name, Namespace: workKey.Namespace, UID: "test-uid", Generation: 1},
+		Spec:       fleetv1beta1.WorkSpec{Workload: configMapWorkload(1000)},
+	}
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(work).WithStatusSubresource(work).Build()
+	recorder := record.NewFakeRecorder(10)
+	d := NewWorkSpecGrowthDetector(hubClient, recorder, DefaultMaxGrowthRatioPerUpdate)
+
+	// steps update the work spec with the given data sizes and reconcile it.
+	steps := []struct {
+		name       string
+		dataSize   int
+		wantStatus metav1.ConditionStatus
+		wantEvent  bool
+	}{
+		{name: "first observation", dataSize: 1000},
+		{name: "growth within the ratio", dataSize: 3000},
+		{name: "10x growth", dataSize: 30000, wantStatus: metav1.ConditionTrue, wantEvent: true},
+		{name: "small growth after the alert", dataSize: 31000, wantStatus: metav1.ConditionTrue},
+		{name: "spec shrinks", dataSize: 2000, wantStatus: metav1.ConditionFalse},
+	}
+	for i, step := range steps {
+		if i > 0 {
+			current := &fleetv1beta1.Work{}
+			if err := hubClient.Get(context.Background(), workKey, current); err != nil {
+				t.Fatalf("%s: failed to get the work: %v", step.name, err)
+			}
+			current.Spec.Workload = configMapWorkload(step.dataSize)
+			current.Generation++
+			if err := hubClient.Update(context.Background(), current); err != nil {
+				t.Fatalf("%s: failed to update the work: %v", step.name, err)
+			}
+		}
+		if _, err := d.Reconcile(context.Background(), ctrl.Request{NamespacedName: workKey}); err != nil {
+			t.Fatalf("%s: Reconcile() = %v, want no error", step.name, err)
+		}
+
+		got := &fleetv1beta1.Work{}
+		if err := hubClient.Get(context.Background(), workKey, got); err != nil {
+			t.Fatalf("%s: failed to get the work: %v", step.name, err)
+		}
+		cond := meta.FindStatusCondition(got.Status.Conditions, fleetv1beta1.WorkConditionTypeSpecGrowthWarning)
+		switch {
+		case step.wantStatus == "" && cond != nil:
+			t.Errorf("%s: spec growth warning condition = %+v, want none", step.name, cond)
+		case step.wantStatus != "" && (cond == nil || cond.Status != step.wantStatus):
+			t.Errorf("%s: spec growth warning condition = %+v, want status %s", step.name, cond, step.wantStatus)
+		}
+
+		select {
+		case event := <-recorder.Events:
+			if !step.wantEvent {
+				t.Errorf("%s: got event %q, want none", step.name, event)
+			} else if !strings.Contains(event, WorkSpecGrowthAlertReason) {
+				t.Errorf("%s: got event %q, want a %s event", step.name, event, WorkSpecGrowthAlertReason)
+			}
+		default:
+			if step.wantEvent {
+				t.Errorf("%s: got no event, want a %s event", step.name, WorkSpecGrowthAlertReason)
+			}
+		}
+	}
+
+	// reconciling the same generation again, e.g. on a resync, does not compare it with itself.
+	if _, err := d.Reconcile(context.Background(), ctrl.Request{NamespacedName: workKey}); err != nil {
+		t.Fatalf("Reconcile() = %v, want no error", err)
+	}
+	if len(recorder.Events) != 0 {
+		t.Errorf("Reconcile() of the observed generation emitted %d events, want none", len(recorder.Events))
+	}
+}
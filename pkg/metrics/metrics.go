@@ -24,6 +24,54 @@ var (
 		Buckets: []float64{0.01, 0.025, 0.05, 0.1, 0.15, 0.2, 0.25, 0.3, 0.4, 0.5, 0.7, 0.9, 1.0,
 			1.25, 1.5, 1.75, 2.0, 2.5, 3.0, 3.5, 4.0, 4.5, 5, 7, 9, 10, 15, 20, 30, 60, 120},
 	}, []string{"name"})
+	WorkEstimatedAPICalls = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "fleet_work_estimated_api_calls",
+		Help:    "Estimated number of member cluster API server calls made to apply a work",
+		Buckets: []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000},
+	})
+	WorkDesiredStatePercentage = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "fleet_work_desired_state_percentage",
+		Help: "Percentage of the manifests in a work that are both applied and available",
+	}, []string{"namespace", "name"})
+	WorkRolloutProgressPercentage = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "fleet_work_rollout_progress_percentage",
+		Help: "Percentage of the batches of a work applied in batches which are completed",
+	}, []string{"namespace", "name"})
+	WorkSpecSizeBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "fleet_work_spec_size_bytes",
+		Help: "Size of the serialized spec of a work in bytes",
+	}, []string{"namespace", "name"})
+	WorkStatusSizeBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "fleet_work_status_size_bytes",
+		Help: "Size of the serialized status of a work in bytes",
+	}, []string{"namespace", "name"})
+	ManifestApplyDurationMilliseconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "fleet_manifest_apply_duration_ms",
+		Help:    "Duration of the apply call of a manifest in a work in milliseconds",
+		Buckets: []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000},
+	}, []string{"group", "version", "kind"})
+	WorkIntegrityViolationsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "fleet_work_integrity_violations_total",
+		Help: "Number of the resources applied by the works which are found missing or replaced in the member cluster",
+	}, []string{"reason"})
+	WorkReconcileDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "fleet_work_reconcile_duration_seconds",
+		Help:    "Duration of a reconcile of a work by the member agent in seconds",
+		Buckets: []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+	}, []string{"namespace", "name"})
+	ManifestApplyOutcomesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "fleet_manifest_apply_outcomes_total",
+		Help: "Number of the manifests in the works processed by the member agent, by their outcome: applied, drifted, failed or skipped",
+	}, []string{"result"})
+	WorkGarbageCollectedResourcesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "fleet_work_garbage_collected_resources_total",
+		Help: "Number of the resources deleted by the member agent as the works which applied them no longer have them",
+	}, []string{"group", "version", "kind"})
+	WorkApplicationLatencySeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "fleet_work_application_latency_seconds",
+		Help:    "Length of time between when a work is created in the hub cluster to when it is first applied by the member agent",
+		Buckets: []float64{0.5, 1, 2, 5, 10, 20, 30, 60, 120, 300, 600, 1200, 1800, 3600},
+	}, []string{"cluster"})
 	PlacementApplyFailedCount = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Name: "placement_apply_failed_counter",
 		Help: "Number of failed to apply cluster resource placement",
@@ -0,0 +1,87 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package binarydata provides utils to merge the binary data of the manifests of a work into the binary fields of
+// the resources they describe.
+package binarydata
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// binaryFields are the fields which hold the binary data of the resources, by their kinds.
+var binaryFields = map[schema.GroupVersionKind]string{
+	{Version: "v1", Kind: "Secret"}:    "data",
+	{Version: "v1", Kind: "ConfigMap"}: "binaryData",
+}
+
+// Field returns the field of the resources of the kind which holds their binary data, and whether the kind has one.
+func Field(gvk schema.GroupVersionKind) (string, bool) {
+	field, ok := binaryFields[gvk]
+	return field, ok
+}
+
+// Validate returns an error if the resources of the kind have no binary field or a key of the binary data is not a
+// valid field name in it.
+func Validate(gvk schema.GroupVersionKind, data map[string][]byte) error {
+	field, ok := Field(gvk)
+	if !ok {
+		return fmt.Errorf("the resources of %s have no binary data field", gvk)
+	}
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if errs := validation.IsConfigMapKey(key); len(errs) > 0 {
+			return fmt.Errorf("invalid key %q of the binary data in the %s of %s: %s", key, field, gvk, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// Merge merges the binary data into the binary field of the object, overwriting the values of the same keys.
+func Merge(obj *unstructured.Unstructured, data map[string][]byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if err := Validate(obj.GroupVersionKind(), data); err != nil {
+		return err
+	}
+	field, _ := Field(obj.GroupVersionKind())
+	values, _, err := unstructured.NestedMap(obj.Object, field)
+	if err != nil {
+		return fmt.Errorf("failed to get the %s of the object: %w", field, err)
+	}
+	if values == nil {
+		values = make(map[string]interface{}, len(data))
+	}
+	for key, value := range data {
+		values[key] = base64.StdEncoding.EncodeToString(value)
+	}
+	return unstructured.SetNestedMap(obj.Object, values, field)
+}
+
+// ByOrdinal returns the binary data of the manifests of the work, keyed by their ordinals.
+func ByOrdinal(work *fleetv1beta1.Work) map[int]map[string][]byte {
+	if len(work.Spec.Workload.ManifestBinaryData) == 0 {
+		return nil
+	}
+	data := make(map[int]map[string][]byte, len(work.Spec.Workload.ManifestBinaryData))
+	for _, entry := range work.Spec.Workload.ManifestBinaryData {
+		data[entry.Ordinal] = entry.BinaryData
+	}
+	return data
+}
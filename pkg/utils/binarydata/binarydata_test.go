@@ -0,0 +1,68 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package binarydata
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestMerge(t *testing.T) {
+	tests := map[string]struct {
+		obj     map[string]interface{}
+		data    map[string][]byte
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		"binary data is merged into the data of a secret": {
+			obj:  map[string]interface{}{"apiVersion": "v1", "kind": "Secret", "data": map[string]interface{}{"a": "YQ=="}},
+			data: map[string][]byte{"b": {0xff, 0x00}},
+			want: map[string]interface{}{"apiVersion": "v1", "kind": "Secret", "data": map[string]interface{}{"a": "YQ==", "b": "/wA="}},
+		},
+		"binary data is merged into the binaryData of a config map": {
+			obj:  map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "data": map[string]interface{}{"a": "a"}},
+			data: map[string][]byte{"a": {0xff}},
+			want: map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "data": map[string]interface{}{"a": "a"},
+				"binaryData": map[string]interface{}{"a": "/w=="}},
+		},
+		"binary data overwrites the value of the same key": {
+			obj:  map[string]interface{}{"apiVersion": "v1", "kind": "Secret", "data": map[string]interface{}{"a": "YQ=="}},
+			data: map[string][]byte{"a": {0xff}},
+			want: map[string]interface{}{"apiVersion": "v1", "kind": "Secret", "data": map[string]interface{}{"a": "/w=="}},
+		},
+		"no binary data leaves the object as is": {
+			obj:  map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment"},
+			want: map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment"},
+		},
+		"kind without a binary field": {
+			obj:     map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment"},
+			data:    map[string][]byte{"a": {0xff}},
+			wantErr: true,
+		},
+		"invalid key": {
+			obj:     map[string]interface{}{"apiVersion": "v1", "kind": "Secret"},
+			data:    map[string][]byte{"a/b": {0xff}},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			obj := &unstructured.Unstructured{Object: tt.obj}
+			err := Merge(obj, tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Merge() = %v, want error %t", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tt.want, obj.Object); diff != "" {
+				t.Errorf("Merge() object mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -39,15 +39,18 @@ import (
 )
 
 const (
-	kubePrefix             = "kube-"
-	fleetPrefix            = "fleet-"
-	FleetSystemNamespace   = fleetPrefix + "system"
-	NamespaceNameFormat    = fleetPrefix + "member-%s"
-	RoleNameFormat         = fleetPrefix + "role-%s"
-	RoleBindingNameFormat  = fleetPrefix + "rolebinding-%s"
-	ValidationPathFmt      = "/validate-%s-%s-%s"
-	lessGroupsStringFormat = "groups: %v"
-	moreGroupsStringFormat = "groups: [%s, %s, %s,......]"
+	kubePrefix                   = "kube-"
+	fleetPrefix                  = "fleet-"
+	FleetSystemNamespace         = fleetPrefix + "system"
+	NamespaceNameFormat          = fleetPrefix + "member-%s"
+	RoleNameFormat               = fleetPrefix + "role-%s"
+	RoleBindingNameFormat        = fleetPrefix + "rolebinding-%s"
+	ClusterRoleNameFormat        = fleetPrefix + "clusterrole-%s"
+	ClusterRoleBindingNameFormat = fleetPrefix + "clusterrolebinding-%s"
+	ValidationPathFmt            = "/validate-%s-%s-%s"
+	MutationPathFmt              = "/mutate-%s-%s-%s"
+	lessGroupsStringFormat       = "groups: %v"
+	moreGroupsStringFormat       = "groups: [%s, %s, %s,......]"
 )
 
 const (
@@ -103,6 +106,11 @@ var (
 		APIGroups: []string{""},
 		Resources: []string{"events"},
 	}
+	ConfigMapReadRule = rbacv1.PolicyRule{
+		Verbs:     []string{"get", "list", "watch"},
+		APIGroups: []string{""},
+		Resources: []string{"configmaps"},
+	}
 	WorkRule = rbacv1.PolicyRule{
 		Verbs:     []string{"*"},
 		APIGroups: []string{workv1alpha1.GroupName},
@@ -321,6 +329,12 @@ var (
 		Resource: "jobs",
 	}
 
+	CronJobGVR = schema.GroupVersionResource{
+		Group:    batchv1.GroupName,
+		Version:  batchv1.SchemeGroupVersion.Version,
+		Resource: "cronjobs",
+	}
+
 	ConfigMapGVR = schema.GroupVersionResource{
 		Group:    corev1.GroupName,
 		Version:  corev1.SchemeGroupVersion.Version,
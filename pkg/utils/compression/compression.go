@@ -0,0 +1,72 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package compression provides utils to compress the manifests of a work.
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// CompressManifests returns the gzip-compressed JSON of the manifests.
+func CompressManifests(manifests []fleetv1beta1.Manifest) ([]byte, error) {
+	raw, err := json.Marshal(manifests)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the manifests: %w", err)
+	}
+	return Compress(raw)
+}
+
+// Compress returns the gzip-compressed data.
+func Compress(raw []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(raw); err != nil {
+		return nil, fmt.Errorf("failed to compress the manifests: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress the manifests: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// DecompressManifests returns the manifests from their gzip-compressed JSON.
+func DecompressManifests(data []byte) ([]fleetv1beta1.Manifest, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress the manifests: %w", err)
+	}
+	defer reader.Close()
+	raw, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress the manifests: %w", err)
+	}
+	var manifests []fleetv1beta1.Manifest
+	if err := json.Unmarshal(raw, &manifests); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the decompressed manifests: %w", err)
+	}
+	return manifests, nil
+}
+
+// DecompressWork fills the manifests of a work with its compressed manifests in memory, so that the work can be
+// processed the same way whether its spec is compressed or not. The compressed manifests are kept so that the spec
+// still reflects what is stored. It is a no-op if the work spec is not compressed.
+func DecompressWork(work *fleetv1beta1.Work) error {
+	if !work.Spec.Compressed {
+		return nil
+	}
+	manifests, err := DecompressManifests(work.Spec.Workload.CompressedManifests)
+	if err != nil {
+		return err
+	}
+	work.Spec.Workload.Manifests = manifests
+	return nil
+}
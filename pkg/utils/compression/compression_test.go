@@ -0,0 +1,93 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package compression
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func TestCompressionRoundTrip(t *testing.T) {
+	manifests := make([]fleetv1beta1.Manifest, 200)
+	for i := range manifests {
+		raw := fmt.Sprintf(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-%d","namespace":"default"},"data":{"index":"%d"}}`, i, i)
+		manifests[i] = fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: []byte(raw)}}
+	}
+	compressed, err := CompressManifests(manifests)
+	if err != nil {
+		t.Fatalf("CompressManifests() = %v, want no error", err)
+	}
+
+	// store the compressed work and retrieve it.
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: "fleet-member-test"},
+		Spec: fleetv1beta1.WorkSpec{
+			Compressed: true,
+			Workload:   fleetv1beta1.WorkloadTemplate{CompressedManifests: compressed},
+		},
+	}
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(work).Build()
+	var got fleetv1beta1.Work
+	if err := hubClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, &got); err != nil {
+		t.Fatalf("failed to get the work: %v", err)
+	}
+
+	if err := DecompressWork(&got); err != nil {
+		t.Fatalf("DecompressWork() = %v, want no error", err)
+	}
+	if diff := cmp.Diff(manifests, got.Spec.Workload.Manifests); diff != "" {
+		t.Errorf("DecompressWork() manifests mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDecompressWork(t *testing.T) {
+	manifests := []fleetv1beta1.Manifest{{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap"}`)}}}
+	tests := map[string]struct {
+		spec          fleetv1beta1.WorkSpec
+		wantManifests []fleetv1beta1.Manifest
+		wantErr       bool
+	}{
+		"uncompressed work is untouched": {
+			spec:          fleetv1beta1.WorkSpec{Workload: fleetv1beta1.WorkloadTemplate{Manifests: manifests}},
+			wantManifests: manifests,
+		},
+		"invalid compressed manifests": {
+			spec: fleetv1beta1.WorkSpec{
+				Compressed: true,
+				Workload:   fleetv1beta1.WorkloadTemplate{CompressedManifests: []byte("not gzip")},
+			},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			work := &fleetv1beta1.Work{Spec: tt.spec}
+			err := DecompressWork(work)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("DecompressWork() = %v, want error %t", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tt.wantManifests, work.Spec.Workload.Manifests); diff != "" {
+				t.Errorf("DecompressWork() manifests mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
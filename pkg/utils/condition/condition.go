@@ -95,6 +95,14 @@ const (
 
 	// AllWorkAvailableReason is the reason string of placement condition if all works are available.
 	AllWorkAvailableReason = "AllWorkAreAvailable"
+
+	// DependencyNotMetReason is the reason string of placement condition if some cross cluster dependencies of the
+	// works are not met yet.
+	DependencyNotMetReason = "DependencyNotMet"
+
+	// AllDependenciesMetReason is the reason string of placement condition if all cross cluster dependencies of the
+	// works are met.
+	AllDependenciesMetReason = "AllDependenciesMet"
 )
 
 // EqualCondition compares one condition with another; it ignores the LastTransitionTime and Message fields,
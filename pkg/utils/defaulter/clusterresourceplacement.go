@@ -62,7 +62,9 @@ func SetDefaultsClusterResourcePlacement(obj *fleetv1beta1.ClusterResourcePlacem
 	if strategy.Type == "" {
 		strategy.Type = fleetv1beta1.RollingUpdateRolloutStrategyType
 	}
-	if strategy.Type == fleetv1beta1.RollingUpdateRolloutStrategyType {
+	// The all at once strategy ignores the max unavailable and max surge limits but still relies on the
+	// unavailable period to determine the readiness of the untrackable resources.
+	if strategy.Type == fleetv1beta1.RollingUpdateRolloutStrategyType || strategy.Type == fleetv1beta1.AllAtOnceRolloutStrategyType {
 		if strategy.RollingUpdate == nil {
 			strategy.RollingUpdate = &fleetv1beta1.RollingUpdateConfig{}
 		}
@@ -141,6 +141,34 @@ func TestSetDefaultsClusterResourcePlacement(t *testing.T) {
 				},
 			},
 		},
+		"ClusterResourcePlacement with all at once rollout strategy": {
+			obj: &fleetv1beta1.ClusterResourcePlacement{
+				Spec: fleetv1beta1.ClusterResourcePlacementSpec{
+					Strategy: fleetv1beta1.RolloutStrategy{
+						Type: fleetv1beta1.AllAtOnceRolloutStrategyType,
+					},
+				},
+			},
+			wantObj: &fleetv1beta1.ClusterResourcePlacement{
+				Spec: fleetv1beta1.ClusterResourcePlacementSpec{
+					Policy: &fleetv1beta1.PlacementPolicy{
+						PlacementType: fleetv1beta1.PickAllPlacementType,
+					},
+					Strategy: fleetv1beta1.RolloutStrategy{
+						Type: fleetv1beta1.AllAtOnceRolloutStrategyType,
+						RollingUpdate: &fleetv1beta1.RollingUpdateConfig{
+							MaxUnavailable:           ptr.To(intstr.FromString(DefaultMaxUnavailableValue)),
+							MaxSurge:                 ptr.To(intstr.FromString(DefaultMaxSurgeValue)),
+							UnavailablePeriodSeconds: ptr.To(DefaultUnavailablePeriodSeconds),
+						},
+						ApplyStrategy: &fleetv1beta1.ApplyStrategy{
+							Type: fleetv1beta1.ApplyStrategyTypeClientSideApply,
+						},
+					},
+					RevisionHistoryLimit: ptr.To(int32(DefaultRevisionHistoryLimitValue)),
+				},
+			},
+		},
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
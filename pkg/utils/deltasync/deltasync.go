@@ -0,0 +1,194 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package deltasync features a client of the delta sync protocol of the works, which fetches only the manifests of a
+// work which changed since the version of the work fetched last, for the member clusters with a low bandwidth
+// connection to the hub cluster.
+//
+// The hub agent serves the delta of a work at GET {DiffPath}?since={resourceVersion} with the workdelta package. The
+// response is a WorkDelta which carries the spec of the work without its manifests, the number of the manifests, and
+// only the manifests which changed since the given version. The syncer falls back to fetching the full work when the hub does
+// not serve the delta, cannot compute it since the given version, or the merged spec does not match its hash.
+package deltasync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/resource"
+)
+
+const (
+	// diffPathFmt is the format of the path of the delta of a work served by the hub agent.
+	// The format is /apis/workdelta.kubernetes-fleet.io/v1beta1/namespaces/{namespace}/works/{name}/diff.
+	diffPathFmt = "/apis/workdelta.kubernetes-fleet.io/v1beta1/namespaces/%s/works/%s/diff"
+	// sinceParam is the query parameter of the version of the work the delta is computed since.
+	sinceParam = "since"
+)
+
+// DiffPath returns the path of the delta of the work served by the hub agent.
+func DiffPath(key types.NamespacedName) string {
+	return fmt.Sprintf(diffPathFmt, key.Namespace, key.Name)
+}
+
+// WorkDelta is the change of a work since a version of it.
+type WorkDelta struct {
+	// ResourceVersion is the current resource version of the work.
+	ResourceVersion string `json:"resourceVersion"`
+	// SpecHash is the hash of the current spec of the work, including all its manifests.
+	SpecHash string `json:"specHash"`
+	// Spec is the current spec of the work without its manifests.
+	Spec fleetv1beta1.WorkSpec `json:"spec"`
+	// ManifestCount is the current number of the manifests of the work.
+	ManifestCount int `json:"manifestCount"`
+	// ChangedManifests are the manifests which are added or changed since the version.
+	ChangedManifests []OrdinalManifest `json:"changedManifests,omitempty"`
+}
+
+// OrdinalManifest is a manifest of a work with its ordinal.
+type OrdinalManifest struct {
+	Ordinal  int                   `json:"ordinal"`
+	Manifest fleetv1beta1.Manifest `json:"manifest"`
+}
+
+// SyncResult is the result of syncing a work.
+type SyncResult struct {
+	// Spec is the current spec of the work.
+	Spec *fleetv1beta1.WorkSpec
+	// Delta is true if only the delta of the work is fetched.
+	Delta bool
+	// ManifestBytes is the number of the bytes of the manifests fetched.
+	ManifestBytes int
+}
+
+// syncedWork is the version of a work fetched last.
+type syncedWork struct {
+	resourceVersion string
+	specHash        string
+	spec            *fleetv1beta1.WorkSpec
+}
+
+// Syncer fetches the specs of the works from the hub cluster, only fetching the delta of a work since it was fetched
+// last when the hub supports it.
+type Syncer struct {
+	// restClient accesses the work deltas served by the hub agent.
+	restClient rest.Interface
+	// reader fetches the full works from the hub cluster.
+	reader client.Reader
+
+	mu     sync.Mutex
+	synced map[types.NamespacedName]*syncedWork
+}
+
+// NewSyncer returns a syncer which fetches the deltas with the REST client and the full works with the reader.
+func NewSyncer(restClient rest.Interface, reader client.Reader) *Syncer {
+	return &Syncer{
+		restClient: restClient,
+		reader:     reader,
+		synced:     make(map[types.NamespacedName]*syncedWork),
+	}
+}
+
+// Sync returns the current spec of the work. The full work is fetched the first time, and only its delta afterwards
+// unless the delta is unavailable.
+func (s *Syncer) Sync(ctx context.Context, key types.NamespacedName) (*SyncResult, error) {
+	s.mu.Lock()
+	last := s.synced[key]
+	s.mu.Unlock()
+	if last != nil {
+		result, err := s.syncDelta(ctx, key, last)
+		if err == nil {
+			return result, nil
+		}
+		klog.V(2).InfoS("Falling back to the full sync of the work", "work", key, "since", last.resourceVersion, "err", err)
+	}
+	return s.syncFull(ctx, key)
+}
+
+// Forget drops the version of the work fetched last, e.g. once the work is deleted.
+func (s *Syncer) Forget(key types.NamespacedName) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.synced, key)
+}
+
+// syncDelta fetches the delta of the work since the version fetched last and merges it into the spec fetched last.
+func (s *Syncer) syncDelta(ctx context.Context, key types.NamespacedName, last *syncedWork) (*SyncResult, error) {
+	var statusCode int
+	body, err := s.restClient.Get().AbsPath(DiffPath(key)).Param(sinceParam, last.resourceVersion).Do(ctx).StatusCode(&statusCode).Raw()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the delta of the work: %w", err)
+	}
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("the hub responded to the delta request with status %d", statusCode)
+	}
+	var delta WorkDelta
+	if err := json.Unmarshal(body, &delta); err != nil {
+		return nil, fmt.Errorf("failed to decode the delta of the work: %w", err)
+	}
+
+	// nothing to merge if the spec is the same as the one fetched last, e.g. only the metadata of the work changed.
+	if delta.SpecHash == last.specHash {
+		s.remember(key, &syncedWork{resourceVersion: delta.ResourceVersion, specHash: last.specHash, spec: last.spec})
+		return &SyncResult{Spec: last.spec.DeepCopy(), Delta: true}, nil
+	}
+	spec := delta.Spec.DeepCopy()
+	spec.Workload.Manifests = make([]fleetv1beta1.Manifest, delta.ManifestCount)
+	copy(spec.Workload.Manifests, last.spec.Workload.Manifests)
+	manifestBytes := 0
+	for _, changed := range delta.ChangedManifests {
+		if changed.Ordinal < 0 || changed.Ordinal >= delta.ManifestCount {
+			return nil, fmt.Errorf("the changed manifest ordinal %d is out of the %d manifests", changed.Ordinal, delta.ManifestCount)
+		}
+		spec.Workload.Manifests[changed.Ordinal] = changed.Manifest
+		manifestBytes += len(changed.Manifest.Raw)
+	}
+	hash, err := resource.HashOf(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash the merged spec of the work: %w", err)
+	}
+	if hash != delta.SpecHash {
+		return nil, fmt.Errorf("the merged spec hash %s does not match the spec hash %s of the work", hash, delta.SpecHash)
+	}
+	s.remember(key, &syncedWork{resourceVersion: delta.ResourceVersion, specHash: hash, spec: spec})
+	return &SyncResult{Spec: spec.DeepCopy(), Delta: true, ManifestBytes: manifestBytes}, nil
+}
+
+// syncFull fetches the full work.
+func (s *Syncer) syncFull(ctx context.Context, key types.NamespacedName) (*SyncResult, error) {
+	var work fleetv1beta1.Work
+	if err := s.reader.Get(ctx, key, &work); err != nil {
+		if apierrors.IsNotFound(err) {
+			s.Forget(key)
+		}
+		return nil, err
+	}
+	hash, err := resource.HashOf(work.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash the spec of the work: %w", err)
+	}
+	manifestBytes := 0
+	for _, manifest := range work.Spec.Workload.Manifests {
+		manifestBytes += len(manifest.Raw)
+	}
+	s.remember(key, &syncedWork{resourceVersion: work.ResourceVersion, specHash: hash, spec: work.Spec.DeepCopy()})
+	return &SyncResult{Spec: work.Spec.DeepCopy(), ManifestBytes: manifestBytes}, nil
+}
+
+func (s *Syncer) remember(key types.NamespacedName, synced *syncedWork) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.synced[key] = synced
+}
@@ -0,0 +1,204 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package deltasync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/resource"
+)
+
+const manifestCount = 50
+
+var workKey = types.NamespacedName{Name: "test-work", Namespace: "fleet-member-test"}
+
+func configMapManifest(i int, data string) fleetv1beta1.Manifest {
+	raw := fmt.Sprintf(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-%d","namespace":"default"},"data":{"key":%q}}`, i, data)
+	return fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: []byte(raw)}}
+}
+
+// fakeDeltaHub serves the delta of the work in the hub client since the version it kept last.
+type fakeDeltaHub struct {
+	t         *testing.T
+	hubClient client.Client
+	// versions are the specs of the work by their resource versions.
+	versions map[string]fleetv1beta1.WorkSpec
+	// corruptHash makes the hub serve a wrong spec hash.
+	corruptHash bool
+}
+
+func (h *fakeDeltaHub) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path != DiffPath(workKey) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	since, found := h.versions[req.URL.Query().Get(sinceParam)]
+	if !found {
+		w.WriteHeader(http.StatusGone)
+		return
+	}
+	var work fleetv1beta1.Work
+	if err := h.hubClient.Get(req.Context(), workKey, &work); err != nil {
+		h.t.Errorf("failed to get the work: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	hash, _ := resource.HashOf(work.Spec)
+	if h.corruptHash {
+		hash = "corrupt"
+	}
+	delta := WorkDelta{ResourceVersion: work.ResourceVersion, SpecHash: hash, ManifestCount: len(work.Spec.Workload.Manifests)}
+	delta.Spec = *work.Spec.DeepCopy()
+	delta.Spec.Workload.Manifests = nil
+	for i, manifest := range work.Spec.Workload.Manifests {
+		if i >= len(since.Workload.Manifests) || string(since.Workload.Manifests[i].Raw) != string(manifest.Raw) {
+			delta.ChangedManifests = append(delta.ChangedManifests, OrdinalManifest{Ordinal: i, Manifest: manifest})
+		}
+	}
+	body, _ := json.Marshal(delta)
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+// record keeps the current spec of the work as a version the hub serves the delta since.
+func (h *fakeDeltaHub) record() {
+	var work fleetv1beta1.Work
+	if err := h.hubClient.Get(context.Background(), workKey, &work); err != nil {
+		h.t.Fatalf("failed to get the work: %v", err)
+	}
+	h.versions[work.ResourceVersion] = *work.Spec.DeepCopy()
+}
+
+// setupSyncer returns a syncer of the hub with a work of 50 manifests.
+func setupSyncer(t *testing.T, served bool) (*Syncer, *fakeDeltaHub) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the fleet scheme: %v", err)
+	}
+	work := &fleetv1beta1.Work{ObjectMeta: metav1.ObjectMeta{Name: workKey.Name, Namespace: workKey.Namespace}}
+	for i := 0; i < manifestCount; i++ {
+		work.Spec.Workload.Manifests = append(work.Spec.Workload.Manifests, configMapManifest(i, "v1"))
+	}
+	hub := &fakeDeltaHub{
+		t:         t,
+		hubClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(work).Build(),
+		versions:  make(map[string]fleetv1beta1.WorkSpec),
+	}
+	var handler http.Handler = hub
+	if !served {
+		handler = http.NotFoundHandler()
+	}
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	restClient := discovery.NewDiscoveryClientForConfigOrDie(&rest.Config{Host: server.URL}).RESTClient()
+	return NewSyncer(restClient, hub.hubClient), hub
+}
+
+// updateManifest changes the data of the manifest with the ordinal on the hub.
+func updateManifest(t *testing.T, hub *fakeDeltaHub, ordinal int) fleetv1beta1.WorkSpec {
+	t.Helper()
+	var work fleetv1beta1.Work
+	if err := hub.hubClient.Get(context.Background(), workKey, &work); err != nil {
+		t.Fatalf("failed to get the work: %v", err)
+	}
+	work.Spec.Workload.Manifests[ordinal] = configMapManifest(ordinal, "v2")
+	if err := hub.hubClient.Update(context.Background(), &work); err != nil {
+		t.Fatalf("failed to update the work: %v", err)
+	}
+	return work.Spec
+}
+
+func TestSyncDelta(t *testing.T) {
+	ctx := context.Background()
+	syncer, hub := setupSyncer(t, true)
+	hub.record()
+
+	full, err := syncer.Sync(ctx, workKey)
+	if err != nil {
+		t.Fatalf("Sync() = %v, want no error", err)
+	}
+	if full.Delta {
+		t.Errorf("Sync() delta = true, want the full work fetched the first time")
+	}
+
+	// only the changed manifest is fetched once one of the fifty manifests changes.
+	wantSpec := updateManifest(t, hub, 7)
+	delta, err := syncer.Sync(ctx, workKey)
+	if err != nil {
+		t.Fatalf("Sync() = %v, want no error", err)
+	}
+	if !delta.Delta {
+		t.Errorf("Sync() delta = false, want only the delta fetched")
+	}
+	if diff := cmp.Diff(wantSpec, *delta.Spec); diff != "" {
+		t.Errorf("Sync() spec mismatch (-want, +got):\n%s", diff)
+	}
+	if want := len(configMapManifest(7, "v2").Raw); delta.ManifestBytes != want {
+		t.Errorf("Sync() fetched %d manifest bytes, want the %d bytes of the changed manifest", delta.ManifestBytes, want)
+	}
+	if delta.ManifestBytes*manifestCount > full.ManifestBytes*2 {
+		t.Errorf("Sync() fetched %d manifest bytes with the delta, want far fewer than the %d bytes of the full work", delta.ManifestBytes, full.ManifestBytes)
+	}
+}
+
+func TestSyncFallback(t *testing.T) {
+	tests := map[string]struct {
+		served      bool
+		corruptHash bool
+		// forgetVersion makes the hub unable to compute the delta since the version fetched last.
+		forgetVersion bool
+	}{
+		"delta not served": {},
+		"version unknown to the hub": {
+			served:        true,
+			forgetVersion: true,
+		},
+		"spec hash mismatch": {
+			served:      true,
+			corruptHash: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			syncer, hub := setupSyncer(t, tt.served)
+			hub.corruptHash = tt.corruptHash
+			if !tt.forgetVersion {
+				hub.record()
+			}
+			if _, err := syncer.Sync(ctx, workKey); err != nil {
+				t.Fatalf("Sync() = %v, want no error", err)
+			}
+			wantSpec := updateManifest(t, hub, 7)
+			result, err := syncer.Sync(ctx, workKey)
+			if err != nil {
+				t.Fatalf("Sync() = %v, want no error", err)
+			}
+			if result.Delta {
+				t.Errorf("Sync() delta = true, want the fallback to the full sync")
+			}
+			if diff := cmp.Diff(wantSpec, *result.Spec); diff != "" {
+				t.Errorf("Sync() spec mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
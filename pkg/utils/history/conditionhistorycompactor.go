@@ -0,0 +1,68 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package history provides utils to keep the bounded histories of the status of the fleet objects.
+package history
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// ConditionHistoryCompactor records the transitions of the conditions of an object, keeping at most a fixed number of
+// the most recent transitions for each condition type.
+type ConditionHistoryCompactor struct {
+	// limit is the maximum number of the transitions kept for each condition type.
+	limit int
+}
+
+// NewConditionHistoryCompactor returns a compactor which keeps at most limit transitions for each condition type.
+// A limit smaller than one keeps a single transition.
+func NewConditionHistoryCompactor(limit int) *ConditionHistoryCompactor {
+	if limit < 1 {
+		limit = 1
+	}
+	return &ConditionHistoryCompactor{limit: limit}
+}
+
+// Record appends a snapshot observed at the given time for each condition whose status or reason differs from the
+// last snapshot of its type, and returns the history. The history is allocated if it is nil and there is a
+// transition to record; the condition types which are no longer set keep their histories.
+func (c *ConditionHistoryCompactor) Record(history map[string][]fleetv1beta1.ConditionSnapshot, conditions []metav1.Condition, now metav1.Time) map[string][]fleetv1beta1.ConditionSnapshot {
+	for _, cond := range conditions {
+		snapshots := history[cond.Type]
+		if len(snapshots) > 0 {
+			last := snapshots[len(snapshots)-1]
+			if last.Status == cond.Status && last.Reason == cond.Reason {
+				continue
+			}
+		}
+		if history == nil {
+			history = make(map[string][]fleetv1beta1.ConditionSnapshot, len(conditions))
+		}
+		history[cond.Type] = c.Append(snapshots, fleetv1beta1.ConditionSnapshot{
+			Status:     cond.Status,
+			Reason:     cond.Reason,
+			ObservedAt: now,
+		})
+	}
+	return history
+}
+
+// Append appends the snapshot to the snapshots, oldest first. Once the snapshots are full, the new one evicts the
+// oldest in place, so that the backing array never grows beyond the limit. The snapshots are compacted to the limit
+// first if they were recorded with a larger one.
+func (c *ConditionHistoryCompactor) Append(snapshots []fleetv1beta1.ConditionSnapshot, snapshot fleetv1beta1.ConditionSnapshot) []fleetv1beta1.ConditionSnapshot {
+	if len(snapshots) < c.limit {
+		return append(snapshots, snapshot)
+	}
+	// shift the kept snapshots over the evicted ones in place and put the new one in the freed last slot.
+	kept := snapshots[len(snapshots)-c.limit+1:]
+	compacted := snapshots[:c.limit]
+	copy(compacted, kept)
+	compacted[c.limit-1] = snapshot
+	return compacted
+}
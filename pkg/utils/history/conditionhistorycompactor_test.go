@@ -0,0 +1,104 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package history
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+var baseTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func observedAt(i int) metav1.Time {
+	return metav1.NewTime(baseTime.Add(time.Duration(i) * time.Minute))
+}
+
+func TestRecordEvictsOldest(t *testing.T) {
+	compactor := NewConditionHistoryCompactor(fleetv1beta1.MaxConditionHistory)
+	var history map[string][]fleetv1beta1.ConditionSnapshot
+	var want []fleetv1beta1.ConditionSnapshot
+	for i := 0; i <= fleetv1beta1.MaxConditionHistory; i++ {
+		cond := metav1.Condition{Type: fleetv1beta1.WorkConditionTypeApplied, Status: metav1.ConditionFalse, Reason: fmt.Sprintf("Reason%d", i)}
+		history = compactor.Record(history, []metav1.Condition{cond}, observedAt(i))
+		want = append(want, fleetv1beta1.ConditionSnapshot{Status: cond.Status, Reason: cond.Reason, ObservedAt: observedAt(i)})
+	}
+	// the 11th transition evicts the 1st.
+	want = want[1:]
+	if diff := cmp.Diff(want, history[fleetv1beta1.WorkConditionTypeApplied]); diff != "" {
+		t.Errorf("Record() history mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestRecordOscillation(t *testing.T) {
+	compactor := NewConditionHistoryCompactor(fleetv1beta1.MaxConditionHistory)
+	var history map[string][]fleetv1beta1.ConditionSnapshot
+	statuses := []metav1.ConditionStatus{metav1.ConditionTrue, metav1.ConditionFalse}
+	for i := 0; i < 1000; i++ {
+		conditions := []metav1.Condition{
+			{Type: fleetv1beta1.WorkConditionTypeApplied, Status: statuses[i%2], Reason: "Applied"},
+			{Type: fleetv1beta1.WorkConditionTypeAvailable, Status: statuses[(i/3)%2], Reason: "Available"},
+		}
+		history = compactor.Record(history, conditions, observedAt(i))
+		for condType, snapshots := range history {
+			if len(snapshots) > fleetv1beta1.MaxConditionHistory {
+				t.Fatalf("Record() kept %d %s transitions after %d records, want at most %d", len(snapshots), condType, i+1, fleetv1beta1.MaxConditionHistory)
+			}
+			if cap(snapshots) > 2*fleetv1beta1.MaxConditionHistory {
+				t.Fatalf("Record() grew the %s history to capacity %d, want it bounded", condType, cap(snapshots))
+			}
+		}
+	}
+	applied := history[fleetv1beta1.WorkConditionTypeApplied]
+	if len(applied) != fleetv1beta1.MaxConditionHistory {
+		t.Fatalf("Record() kept %d Applied transitions, want %d", len(applied), fleetv1beta1.MaxConditionHistory)
+	}
+	// the last transitions are kept, oldest first.
+	for i, snapshot := range applied {
+		record := 1000 - fleetv1beta1.MaxConditionHistory + i
+		if want := observedAt(record); !snapshot.ObservedAt.Equal(&want) || snapshot.Status != statuses[record%2] {
+			t.Errorf("Record() Applied transition %d = %+v, want %s at %v", i, snapshot, statuses[record%2], want)
+		}
+	}
+}
+
+func TestRecordUnchanged(t *testing.T) {
+	compactor := NewConditionHistoryCompactor(fleetv1beta1.MaxConditionHistory)
+	cond := metav1.Condition{Type: fleetv1beta1.WorkConditionTypeApplied, Status: metav1.ConditionTrue, Reason: "Applied"}
+	history := compactor.Record(nil, []metav1.Condition{cond}, observedAt(0))
+	history = compactor.Record(history, []metav1.Condition{cond}, observedAt(1))
+	want := map[string][]fleetv1beta1.ConditionSnapshot{
+		fleetv1beta1.WorkConditionTypeApplied: {{Status: metav1.ConditionTrue, Reason: "Applied", ObservedAt: observedAt(0)}},
+	}
+	if diff := cmp.Diff(want, history); diff != "" {
+		t.Errorf("Record() history mismatch (-want, +got):\n%s", diff)
+	}
+	if got := compactor.Record(nil, nil, observedAt(2)); got != nil {
+		t.Errorf("Record() = %v, want nil without any condition", got)
+	}
+}
+
+func TestAppendCompactsLargerHistory(t *testing.T) {
+	compactor := NewConditionHistoryCompactor(3)
+	var snapshots []fleetv1beta1.ConditionSnapshot
+	for i := 0; i < 5; i++ {
+		snapshots = append(snapshots, fleetv1beta1.ConditionSnapshot{Status: metav1.ConditionTrue, ObservedAt: observedAt(i)})
+	}
+	got := compactor.Append(snapshots, fleetv1beta1.ConditionSnapshot{Status: metav1.ConditionFalse, ObservedAt: observedAt(5)})
+	want := []fleetv1beta1.ConditionSnapshot{
+		{Status: metav1.ConditionTrue, ObservedAt: observedAt(3)},
+		{Status: metav1.ConditionTrue, ObservedAt: observedAt(4)},
+		{Status: metav1.ConditionFalse, ObservedAt: observedAt(5)},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Append() mismatch (-want, +got):\n%s", diff)
+	}
+}
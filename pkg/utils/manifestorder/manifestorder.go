@@ -0,0 +1,184 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package manifestorder provides utils to order the manifests so that every resource is applied after the resources
+// it depends on, whatever the order the manifests are written in.
+package manifestorder
+
+import (
+	"container/heap"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	apiextensionsGroup = "apiextensions.k8s.io"
+	rbacGroup          = "rbac.authorization.k8s.io"
+)
+
+// workloadKinds are the kinds of the resources which run pods, keyed by their group.
+var workloadKinds = map[string]map[string]bool{
+	"":      {"Pod": true, "ReplicationController": true},
+	"apps":  {"Deployment": true, "StatefulSet": true, "DaemonSet": true, "ReplicaSet": true},
+	"batch": {"Job": true, "CronJob": true},
+}
+
+// TopoSortManifests returns the objects sorted so that every object comes after the objects it depends on:
+//   - a namespace comes before the resources in it;
+//   - a custom resource definition comes before the custom resources it defines;
+//   - a role or cluster role comes before the bindings which refer to it;
+//   - a service account comes before the bindings which refer to it as a subject;
+//   - the RBAC resources and the service accounts come before the workloads in the same namespace, or before all the
+//     workloads if they are cluster scoped.
+//
+// The objects without dependencies between them keep their relative order. The objects in a dependency cycle, which
+// the rules above cannot form, are left in their relative order after the sorted objects. The given slice is not
+// modified.
+func TopoSortManifests(objs []*unstructured.Unstructured) []*unstructured.Unstructured {
+	// dependents[i] are the indexes of the objects which depend on the object i.
+	dependents := make([][]int, len(objs))
+	inDegree := make([]int, len(objs))
+	for i := range objs {
+		for j := range objs {
+			if i != j && dependsOn(objs[j], objs[i]) {
+				dependents[i] = append(dependents[i], j)
+				inDegree[j]++
+			}
+		}
+	}
+
+	// Kahn's algorithm, which picks the ready object with the lowest index first to keep the order stable.
+	ready := &indexHeap{}
+	for i := range objs {
+		if inDegree[i] == 0 {
+			heap.Push(ready, i)
+		}
+	}
+	sorted := make([]*unstructured.Unstructured, 0, len(objs))
+	visited := make([]bool, len(objs))
+	for ready.Len() > 0 {
+		i := heap.Pop(ready).(int)
+		visited[i] = true
+		sorted = append(sorted, objs[i])
+		for _, j := range dependents[i] {
+			inDegree[j]--
+			if inDegree[j] == 0 {
+				heap.Push(ready, j)
+			}
+		}
+	}
+	for i := range objs {
+		if !visited[i] {
+			sorted = append(sorted, objs[i])
+		}
+	}
+	return sorted
+}
+
+// SplitIntoWaves splits the objects, sorted by TopoSortManifests, into consecutive waves so that no object depends on
+// another object of its wave. The objects of a wave can be applied concurrently once the waves before it are applied;
+// applying the waves one object at a time keeps the sorted order.
+func SplitIntoWaves(sorted []*unstructured.Unstructured) [][]*unstructured.Unstructured {
+	var waves [][]*unstructured.Unstructured
+	var wave []*unstructured.Unstructured
+	for _, obj := range sorted {
+		for _, other := range wave {
+			// the objects in a dependency cycle are kept apart as well.
+			if dependsOn(obj, other) || dependsOn(other, obj) {
+				waves = append(waves, wave)
+				wave = nil
+				break
+			}
+		}
+		wave = append(wave, obj)
+	}
+	if len(wave) > 0 {
+		waves = append(waves, wave)
+	}
+	return waves
+}
+
+// dependsOn returns whether the object has to be applied after the dependency.
+func dependsOn(obj, dependency *unstructured.Unstructured) bool {
+	objGVK, depGVK := obj.GroupVersionKind(), dependency.GroupVersionKind()
+	switch {
+	case depGVK.Group == "" && depGVK.Kind == "Namespace":
+		return obj.GetNamespace() == dependency.GetName()
+
+	case depGVK.Group == apiextensionsGroup && depGVK.Kind == "CustomResourceDefinition":
+		group, _, _ := unstructured.NestedString(dependency.Object, "spec", "group")
+		kind, _, _ := unstructured.NestedString(dependency.Object, "spec", "names", "kind")
+		return kind != "" && objGVK.Group == group && objGVK.Kind == kind
+	}
+
+	if isRBACOrServiceAccount(depGVK.Group, depGVK.Kind) {
+		if isWorkload(objGVK.Group, objGVK.Kind) {
+			return dependency.GetNamespace() == "" || dependency.GetNamespace() == obj.GetNamespace()
+		}
+		if objGVK.Group == rbacGroup && (objGVK.Kind == "RoleBinding" || objGVK.Kind == "ClusterRoleBinding") {
+			return isBoundBy(dependency, obj)
+		}
+	}
+	return false
+}
+
+// isBoundBy returns whether the binding refers to the role, cluster role or service account.
+func isBoundBy(dependency, binding *unstructured.Unstructured) bool {
+	depGVK := dependency.GroupVersionKind()
+	switch {
+	case depGVK.Group == rbacGroup && (depGVK.Kind == "Role" || depGVK.Kind == "ClusterRole"):
+		kind, _, _ := unstructured.NestedString(binding.Object, "roleRef", "kind")
+		name, _, _ := unstructured.NestedString(binding.Object, "roleRef", "name")
+		if kind != depGVK.Kind || name != dependency.GetName() {
+			return false
+		}
+		// a binding can only refer to a role in its own namespace.
+		return depGVK.Kind == "ClusterRole" || dependency.GetNamespace() == binding.GetNamespace()
+
+	case depGVK.Group == "" && depGVK.Kind == "ServiceAccount":
+		subjects, _, _ := unstructured.NestedSlice(binding.Object, "subjects")
+		for _, s := range subjects {
+			subject, ok := s.(map[string]interface{})
+			if !ok || subject["kind"] != "ServiceAccount" || subject["name"] != dependency.GetName() {
+				continue
+			}
+			namespace, _ := subject["namespace"].(string)
+			if namespace == "" {
+				namespace = binding.GetNamespace()
+			}
+			if namespace == dependency.GetNamespace() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isRBACOrServiceAccount(group, kind string) bool {
+	return group == rbacGroup || (group == "" && kind == "ServiceAccount")
+}
+
+func isWorkload(group, kind string) bool {
+	return workloadKinds[group][kind]
+}
+
+// indexHeap is a min-heap of the indexes of the objects.
+type indexHeap []int
+
+func (h indexHeap) Len() int           { return len(h) }
+func (h indexHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h indexHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *indexHeap) Push(x interface{}) {
+	*h = append(*h, x.(int))
+}
+
+func (h *indexHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
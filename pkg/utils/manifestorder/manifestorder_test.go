@@ -0,0 +1,172 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package manifestorder
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// object returns an object of the given kind with the given fields merged into it.
+func object(apiVersion, kind, namespace, name string, fields map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	for k, v := range fields {
+		obj.Object[k] = v
+	}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+// binding returns a binding of the role or cluster role to the service account.
+func binding(kind, namespace, name, roleKind, roleName, serviceAccountNamespace, serviceAccount string) *unstructured.Unstructured {
+	subject := map[string]interface{}{"kind": "ServiceAccount", "name": serviceAccount}
+	if serviceAccountNamespace != "" {
+		subject["namespace"] = serviceAccountNamespace
+	}
+	return object("rbac.authorization.k8s.io/v1", kind, namespace, name, map[string]interface{}{
+		"roleRef":  map[string]interface{}{"apiGroup": "rbac.authorization.k8s.io", "kind": roleKind, "name": roleName},
+		"subjects": []interface{}{subject},
+	})
+}
+
+// names returns the kinds and names of the objects in their order.
+func names(objs []*unstructured.Unstructured) []string {
+	res := make([]string, len(objs))
+	for i, obj := range objs {
+		res[i] = fmt.Sprintf("%s/%s", obj.GetKind(), obj.GetName())
+	}
+	return res
+}
+
+func TestTopoSortManifests(t *testing.T) {
+	namespace := object("v1", "Namespace", "", "app", nil)
+	deployment := object("apps/v1", "Deployment", "app", "web", nil)
+	crd := object("apiextensions.k8s.io/v1", "CustomResourceDefinition", "", "widgets.example.com", map[string]interface{}{
+		"spec": map[string]interface{}{"group": "example.com", "names": map[string]interface{}{"kind": "Widget"}},
+	})
+	widget := object("example.com/v1", "Widget", "", "widget", nil)
+	clusterRole := object("rbac.authorization.k8s.io/v1", "ClusterRole", "", "reader", nil)
+	clusterRoleBinding := binding("ClusterRoleBinding", "", "reader-binding", "ClusterRole", "reader", "app", "web-sa")
+	role := object("rbac.authorization.k8s.io/v1", "Role", "app", "editor", nil)
+	roleBinding := binding("RoleBinding", "app", "editor-binding", "Role", "editor", "", "web-sa")
+	serviceAccount := object("v1", "ServiceAccount", "app", "web-sa", nil)
+	job := object("batch/v1", "Job", "app", "migrate", nil)
+
+	tests := map[string]struct {
+		objs []*unstructured.Unstructured
+		want []string
+	}{
+		"namespace before deployment": {
+			objs: []*unstructured.Unstructured{deployment, namespace},
+			want: []string{"Namespace/app", "Deployment/web"},
+		},
+		"CRD before custom resource": {
+			objs: []*unstructured.Unstructured{widget, crd},
+			want: []string{"CustomResourceDefinition/widgets.example.com", "Widget/widget"},
+		},
+		"cluster role before cluster role binding": {
+			objs: []*unstructured.Unstructured{clusterRoleBinding, clusterRole},
+			want: []string{"ClusterRole/reader", "ClusterRoleBinding/reader-binding"},
+		},
+		"role before role binding": {
+			objs: []*unstructured.Unstructured{roleBinding, role},
+			want: []string{"Role/editor", "RoleBinding/editor-binding"},
+		},
+		"service account before role binding": {
+			objs: []*unstructured.Unstructured{roleBinding, serviceAccount},
+			want: []string{"ServiceAccount/web-sa", "RoleBinding/editor-binding"},
+		},
+		"RBAC before workload": {
+			objs: []*unstructured.Unstructured{job, roleBinding},
+			want: []string{"RoleBinding/editor-binding", "Job/migrate"},
+		},
+		"unrelated objects keep their order": {
+			objs: []*unstructured.Unstructured{widget, deployment, object("v1", "ConfigMap", "", "settings", nil)},
+			want: []string{"Widget/widget", "Deployment/web", "ConfigMap/settings"},
+		},
+		"role in another namespace is not a dependency": {
+			objs: []*unstructured.Unstructured{roleBinding, object("rbac.authorization.k8s.io/v1", "Role", "other", "editor", nil)},
+			want: []string{"RoleBinding/editor-binding", "Role/editor"},
+		},
+		"whole application": {
+			objs: []*unstructured.Unstructured{job, deployment, widget, roleBinding, clusterRoleBinding, role, serviceAccount, clusterRole, crd, namespace},
+			want: []string{
+				"ClusterRole/reader", "CustomResourceDefinition/widgets.example.com", "Widget/widget", "Namespace/app",
+				"Role/editor", "ServiceAccount/web-sa", "RoleBinding/editor-binding", "ClusterRoleBinding/reader-binding",
+				"Job/migrate", "Deployment/web",
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			original := names(tt.objs)
+			got := names(TopoSortManifests(tt.objs))
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("TopoSortManifests() mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(original, names(tt.objs)); diff != "" {
+				t.Errorf("TopoSortManifests() modified the given objects (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSplitIntoWaves(t *testing.T) {
+	namespace := object("v1", "Namespace", "", "app", nil)
+	deployment := object("apps/v1", "Deployment", "app", "web", nil)
+	configMap := object("v1", "ConfigMap", "app", "settings", nil)
+	role := object("rbac.authorization.k8s.io/v1", "Role", "app", "editor", nil)
+	roleBinding := binding("RoleBinding", "app", "editor-binding", "Role", "editor", "", "web-sa")
+	serviceAccount := object("v1", "ServiceAccount", "app", "web-sa", nil)
+
+	tests := map[string]struct {
+		objs []*unstructured.Unstructured
+		want [][]string
+	}{
+		"no objects": {},
+		"independent objects form a single wave": {
+			objs: []*unstructured.Unstructured{deployment, configMap, object("v1", "ConfigMap", "other", "settings", nil)},
+			want: [][]string{{"Deployment/web", "ConfigMap/settings", "ConfigMap/settings"}},
+		},
+		"namespace before the resources in it": {
+			objs: []*unstructured.Unstructured{configMap, namespace, deployment},
+			want: [][]string{{"Namespace/app"}, {"ConfigMap/settings", "Deployment/web"}},
+		},
+		"whole application": {
+			objs: []*unstructured.Unstructured{deployment, roleBinding, configMap, role, serviceAccount, namespace},
+			want: [][]string{
+				{"Namespace/app"},
+				{"ConfigMap/settings", "Role/editor", "ServiceAccount/web-sa"},
+				{"RoleBinding/editor-binding"},
+				{"Deployment/web"},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sorted := TopoSortManifests(tt.objs)
+			var got [][]string
+			var flattened []string
+			for _, wave := range SplitIntoWaves(sorted) {
+				got = append(got, names(wave))
+				flattened = append(flattened, names(wave)...)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("SplitIntoWaves() mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(names(sorted), flattened, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("SplitIntoWaves() changed the sorted order (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
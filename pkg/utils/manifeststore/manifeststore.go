@@ -0,0 +1,131 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package manifeststore provides utils to store the manifests shared by many works as content-addressed
+// ManifestStore blobs and to resolve the blob refs of a work to the manifests.
+package manifeststore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/workintegrity"
+)
+
+// WorkBlobRefsField is the field the works are indexed by to find the works referring to a blob.
+const WorkBlobRefsField = "spec.workload.manifestBlobRefs.blobRef"
+
+// BlobRef returns the ref of the blob holding the manifest, which is the checksum of its canonical JSON.
+func BlobRef(manifest fleetv1beta1.Manifest) (string, error) {
+	return workintegrity.ManifestChecksum(manifest)
+}
+
+// NewBlob returns the ManifestStore blob holding the manifest, named by its blob ref.
+func NewBlob(manifest fleetv1beta1.Manifest) (*fleetv1beta1.ManifestStore, error) {
+	ref, err := BlobRef(manifest)
+	if err != nil {
+		return nil, err
+	}
+	blob := &fleetv1beta1.ManifestStore{
+		Spec: fleetv1beta1.ManifestStoreSpec{Manifest: *manifest.DeepCopy()},
+	}
+	blob.Name = ref
+	return blob, nil
+}
+
+// Stub returns the stub of the manifest kept in the manifests of a work, which carries only the apiVersion, kind and
+// metadata of the resource.
+func Stub(manifest fleetv1beta1.Manifest) (fleetv1beta1.Manifest, error) {
+	var resource unstructured.Unstructured
+	if err := resource.UnmarshalJSON(manifest.Raw); err != nil {
+		return fleetv1beta1.Manifest{}, err
+	}
+	stub := map[string]interface{}{
+		"apiVersion": resource.GetAPIVersion(),
+		"kind":       resource.GetKind(),
+		"metadata":   resource.Object["metadata"],
+	}
+	raw, err := json.Marshal(stub)
+	if err != nil {
+		return fleetv1beta1.Manifest{}, err
+	}
+	return fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: raw}}, nil
+}
+
+// Store moves the manifests of the work which are at least minSize bytes long into ManifestStore blobs, in place.
+// Each of them is replaced with its stub and referred to its blob, which is created unless it already exists. The
+// manifests already referring to a blob are kept. The manifests are expected to be uncompressed.
+func Store(ctx context.Context, writer client.Writer, work *fleetv1beta1.Work, minSize int) error {
+	workload := &work.Spec.Workload
+	stored := make(map[int]bool, len(workload.ManifestBlobRefs))
+	for _, ref := range workload.ManifestBlobRefs {
+		stored[ref.Ordinal] = true
+	}
+	for i := range workload.Manifests {
+		if stored[i] || len(workload.Manifests[i].Raw) < minSize {
+			continue
+		}
+		blob, err := NewBlob(workload.Manifests[i])
+		if err != nil {
+			return fmt.Errorf("failed to build the blob of the manifest with ordinal %d: %w", i, err)
+		}
+		stub, err := Stub(workload.Manifests[i])
+		if err != nil {
+			return fmt.Errorf("failed to build the stub of the manifest with ordinal %d: %w", i, err)
+		}
+		if err := writer.Create(ctx, blob); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create the blob %s of the manifest with ordinal %d: %w", blob.Name, i, err)
+		}
+		workload.Manifests[i] = stub
+		workload.ManifestBlobRefs = append(workload.ManifestBlobRefs, fleetv1beta1.ManifestBlobRef{Ordinal: i, BlobRef: blob.Name})
+	}
+	return nil
+}
+
+// Resolve replaces the manifests of the work which refer to a blob with the content of the blob, in place. The
+// manifests are expected to be decompressed. It returns an error if a blob ref does not refer to a manifest of the
+// work, or a blob cannot be read or does not match its ref.
+func Resolve(ctx context.Context, reader client.Reader, work *fleetv1beta1.Work) error {
+	manifests := work.Spec.Workload.Manifests
+	for _, ref := range work.Spec.Workload.ManifestBlobRefs {
+		if ref.Ordinal < 0 || ref.Ordinal >= len(manifests) {
+			return fmt.Errorf("the blob ref %s refers to the manifest with ordinal %d of the %d manifests", ref.BlobRef, ref.Ordinal, len(manifests))
+		}
+		var blob fleetv1beta1.ManifestStore
+		if err := reader.Get(ctx, types.NamespacedName{Name: ref.BlobRef}, &blob); err != nil {
+			return fmt.Errorf("failed to get the blob %s of the manifest with ordinal %d: %w", ref.BlobRef, ref.Ordinal, err)
+		}
+		checksum, err := BlobRef(blob.Spec.Manifest)
+		if err != nil {
+			return fmt.Errorf("the blob %s is invalid: %w", ref.BlobRef, err)
+		}
+		if checksum != ref.BlobRef {
+			return fmt.Errorf("the content of the blob %s has the checksum %s", ref.BlobRef, checksum)
+		}
+		manifests[ref.Ordinal] = *blob.Spec.Manifest.DeepCopy()
+	}
+	return nil
+}
+
+// WorkBlobRefs returns the blobs the manifests of the work refer to. It is the indexer of the WorkBlobRefsField.
+func WorkBlobRefs(obj client.Object) []string {
+	work, ok := obj.(*fleetv1beta1.Work)
+	if !ok {
+		return nil
+	}
+	refs := make([]string, 0, len(work.Spec.Workload.ManifestBlobRefs))
+	for _, ref := range work.Spec.Workload.ManifestBlobRefs {
+		refs = append(refs, ref.BlobRef)
+	}
+	return refs
+}
@@ -0,0 +1,167 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package manifeststore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func manifest(raw string) fleetv1beta1.Manifest {
+	return fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: []byte(raw)}}
+}
+
+// clusterRoleManifest is the shared RBAC policy placed on every member cluster.
+var clusterRoleManifest = manifest(`{"apiVersion":"rbac.authorization.k8s.io/v1","kind":"ClusterRole","metadata":{"name":"reader"},"rules":[{"apiGroups":[""],"resources":["pods"],"verbs":["get","list"]}]}`)
+
+// clusterRoleStub is the stub of the shared RBAC policy in the manifests of the works.
+var clusterRoleStub = manifest(`{"apiVersion":"rbac.authorization.k8s.io/v1","kind":"ClusterRole","metadata":{"name":"reader"}}`)
+
+func blobWork(t *testing.T, namespace string, blobRef string) *fleetv1beta1.Work {
+	t.Helper()
+	return &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: "work", Namespace: namespace},
+		Spec: fleetv1beta1.WorkSpec{Workload: fleetv1beta1.WorkloadTemplate{
+			Manifests: []fleetv1beta1.Manifest{
+				manifest(fmt.Sprintf(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cluster","namespace":"default"},"data":{"name":%q}}`, namespace)),
+				clusterRoleStub,
+			},
+			ManifestBlobRefs: []fleetv1beta1.ManifestBlobRef{{Ordinal: 1, BlobRef: blobRef}},
+		}},
+	}
+}
+
+func TestResolveSharedBlob(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the fleet scheme: %v", err)
+	}
+	blob, err := NewBlob(clusterRoleManifest)
+	if err != nil {
+		t.Fatalf("NewBlob() = %v, want no error", err)
+	}
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(blob).Build()
+
+	first := blobWork(t, "fleet-member-1", blob.Name)
+	second := blobWork(t, "fleet-member-2", blob.Name)
+	for _, work := range []*fleetv1beta1.Work{first, second} {
+		if err := Resolve(context.Background(), hubClient, work); err != nil {
+			t.Fatalf("Resolve() = %v, want no error", err)
+		}
+	}
+	if diff := cmp.Diff(first.Spec.Workload.Manifests[1], second.Spec.Workload.Manifests[1]); diff != "" {
+		t.Errorf("resolved manifests of the works mismatch (-first, +second):\n%s", diff)
+	}
+	ref, err := BlobRef(first.Spec.Workload.Manifests[1])
+	if err != nil || ref != blob.Name {
+		t.Errorf("BlobRef() of the resolved manifest = %s, %v, want %s", ref, err, blob.Name)
+	}
+	// the inline manifests are kept.
+	if strings.Contains(string(first.Spec.Workload.Manifests[0].Raw), "fleet-member-2") {
+		t.Errorf("Resolve() changed the inline manifest to %s", first.Spec.Workload.Manifests[0].Raw)
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the fleet scheme: %v", err)
+	}
+	blob, err := NewBlob(clusterRoleManifest)
+	if err != nil {
+		t.Fatalf("NewBlob() = %v, want no error", err)
+	}
+	// a blob whose content does not match its name, e.g. tampered with in storage.
+	tampered := blob.DeepCopy()
+	tampered.Name = strings.Repeat("a", 64)
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(blob, tampered).Build()
+
+	tests := map[string]struct {
+		ref          fleetv1beta1.ManifestBlobRef
+		wantNotFound bool
+	}{
+		"missing blob": {
+			ref:          fleetv1beta1.ManifestBlobRef{Ordinal: 1, BlobRef: strings.Repeat("b", 64)},
+			wantNotFound: true,
+		},
+		"blob not matching its ref": {
+			ref: fleetv1beta1.ManifestBlobRef{Ordinal: 1, BlobRef: tampered.Name},
+		},
+		"ref of a missing manifest": {
+			ref: fleetv1beta1.ManifestBlobRef{Ordinal: 2, BlobRef: blob.Name},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			work := blobWork(t, "fleet-member-1", tt.ref.BlobRef)
+			work.Spec.Workload.ManifestBlobRefs = []fleetv1beta1.ManifestBlobRef{tt.ref}
+			err := Resolve(context.Background(), hubClient, work)
+			if err == nil {
+				t.Fatalf("Resolve() = nil, want an error")
+			}
+			if got := apierrors.IsNotFound(err); got != tt.wantNotFound {
+				t.Errorf("Resolve() = %v, want not found %t", err, tt.wantNotFound)
+			}
+		})
+	}
+}
+
+func TestStoreSharedManifest(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the fleet scheme: %v", err)
+	}
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	minSize := len(clusterRoleManifest.Raw)
+
+	var works []*fleetv1beta1.Work
+	for _, namespace := range []string{"fleet-member-1", "fleet-member-2"} {
+		work := blobWork(t, namespace, "")
+		work.Spec.Workload.Manifests[1] = *clusterRoleManifest.DeepCopy()
+		work.Spec.Workload.ManifestBlobRefs = nil
+		if err := Store(context.Background(), hubClient, work, minSize); err != nil {
+			t.Fatalf("Store() = %v, want no error", err)
+		}
+		works = append(works, work)
+	}
+
+	var blobs fleetv1beta1.ManifestStoreList
+	if err := hubClient.List(context.Background(), &blobs); err != nil {
+		t.Fatalf("failed to list the blobs: %v", err)
+	}
+	if len(blobs.Items) != 1 {
+		t.Fatalf("Store() created %d blobs, want 1", len(blobs.Items))
+	}
+	wantRefs := []fleetv1beta1.ManifestBlobRef{{Ordinal: 1, BlobRef: blobs.Items[0].Name}}
+	for _, work := range works {
+		if diff := cmp.Diff(wantRefs, work.Spec.Workload.ManifestBlobRefs); diff != "" {
+			t.Errorf("Store() blob refs mismatch (-want, +got):\n%s", diff)
+		}
+		if diff := cmp.Diff(clusterRoleStub, work.Spec.Workload.Manifests[1]); diff != "" {
+			t.Errorf("Store() stub mismatch (-want, +got):\n%s", diff)
+		}
+		if err := Resolve(context.Background(), hubClient, work); err != nil {
+			t.Fatalf("Resolve() = %v, want no error", err)
+		}
+		if diff := cmp.Diff(clusterRoleManifest, work.Spec.Workload.Manifests[1]); diff != "" {
+			t.Errorf("resolved manifest mismatch (-want, +got):\n%s", diff)
+		}
+	}
+	// the small manifests are kept inline.
+	if got := WorkBlobRefs(works[0]); len(got) != 1 {
+		t.Errorf("WorkBlobRefs() = %v, want only the ref of the large manifest", got)
+	}
+}
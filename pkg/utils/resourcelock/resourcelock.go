@@ -0,0 +1,77 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package resourcelock provides a registry of per-resource locks so that a resource managed by more than one work is
+// not applied by them concurrently.
+package resourcelock
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Key identifies a resource in the member cluster.
+type Key struct {
+	GVK       schema.GroupVersionKind
+	Namespace string
+	Name      string
+}
+
+// String returns the key in the namespace/name/gvk format.
+func (k Key) String() string {
+	return fmt.Sprintf("%s/%s/%s", k.Namespace, k.Name, k.GVK)
+}
+
+// lock is the lock of a resource along with the number of the callers holding or waiting for it.
+type lock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// Registry keeps the locks of the resources which are being applied. A lock is dropped once no caller holds or waits
+// for it, so the registry does not grow with the number of resources.
+type Registry struct {
+	mu    sync.Mutex
+	locks map[Key]*lock
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		locks: make(map[Key]*lock),
+	}
+}
+
+// Lock blocks until the lock of the resource is acquired and returns the function which releases it.
+func (r *Registry) Lock(key Key) (unlock func()) {
+	r.mu.Lock()
+	l, ok := r.locks[key]
+	if !ok {
+		l = &lock{}
+		r.locks[key] = l
+	}
+	l.refs++
+	r.mu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		l.refs--
+		if l.refs == 0 {
+			delete(r.locks, key)
+		}
+	}
+}
+
+// Len returns the number of the resources which are locked or waited for.
+func (r *Registry) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.locks)
+}
@@ -0,0 +1,79 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package resourcelock
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var deploymentGVK = schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+func TestKeyString(t *testing.T) {
+	key := Key{GVK: deploymentGVK, Namespace: "default", Name: "app"}
+	if got, want := key.String(), "default/app/apps/v1, Kind=Deployment"; got != want {
+		t.Errorf("String() = %s, want %s", got, want)
+	}
+}
+
+func TestLockSameResource(t *testing.T) {
+	registry := NewRegistry()
+	key := Key{GVK: deploymentGVK, Namespace: "default", Name: "app"}
+
+	var mu sync.Mutex
+	active, maxActive := 0, 0
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := registry.Lock(key)
+			defer unlock()
+			mu.Lock()
+			active++
+			if active > maxActive {
+				maxActive = active
+			}
+			mu.Unlock()
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			active--
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if maxActive != 1 {
+		t.Errorf("Lock() let %d callers hold the lock of the same resource at a time, want 1", maxActive)
+	}
+	if registry.Len() != 0 {
+		t.Errorf("Len() = %d after all the locks are released, want 0", registry.Len())
+	}
+}
+
+func TestLockDifferentResources(t *testing.T) {
+	registry := NewRegistry()
+	unlock := registry.Lock(Key{GVK: deploymentGVK, Namespace: "default", Name: "app"})
+	defer unlock()
+
+	acquired := make(chan struct{})
+	go func() {
+		defer close(acquired)
+		// the same name in another namespace is a different resource.
+		registry.Lock(Key{GVK: deploymentGVK, Namespace: "other", Name: "app"})()
+	}()
+	select {
+	case <-acquired:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Lock() of a different resource is blocked by the lock of another resource")
+	}
+	if registry.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", registry.Len())
+	}
+}
@@ -392,6 +392,12 @@ func TestValidateClusterResourcePlacement_RolloutStrategy(t *testing.T) {
 			wantErr:    true,
 			wantErrMsg: "unsupported rollout strategy type `random type`",
 		},
+		"valid rollout strategy - AllAtOnce": {
+			strategy: placementv1beta1.RolloutStrategy{
+				Type: placementv1beta1.AllAtOnceRolloutStrategyType,
+			},
+			wantErr: false,
+		},
 		"invalid rollout strategy - UnavailablePeriodSeconds": {
 			strategy: placementv1beta1.RolloutStrategy{
 				Type: placementv1beta1.RollingUpdateRolloutStrategyType,
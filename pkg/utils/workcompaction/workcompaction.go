@@ -0,0 +1,77 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package workcompaction provides utils to track the small works compacted into a consolidated work, which applies
+// their manifests on their behalf.
+package workcompaction
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/condition"
+)
+
+// CompactedWork is the record of a work compacted into a consolidated work.
+type CompactedWork struct {
+	// Name is the name of the compacted work, in the namespace of the consolidated work.
+	Name string `json:"name"`
+	// Generation is the generation of the compacted work whose manifests the consolidated work has.
+	Generation int64 `json:"generation"`
+	// FirstOrdinal is the ordinal of the first manifest of the compacted work in the consolidated work.
+	FirstOrdinal int `json:"firstOrdinal"`
+	// ManifestCount is the number of the manifests of the compacted work.
+	ManifestCount int `json:"manifestCount"`
+}
+
+// CompactedWorks returns the records of the works compacted into the consolidated work.
+func CompactedWorks(consolidated *fleetv1beta1.Work) ([]CompactedWork, error) {
+	value, found := consolidated.GetAnnotations()[fleetv1beta1.CompactedWorksAnnotation]
+	if !found {
+		return nil, nil
+	}
+	var records []CompactedWork
+	if err := json.Unmarshal([]byte(value), &records); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the compacted works of the consolidated work %s: %w", consolidated.Name, err)
+	}
+	return records, nil
+}
+
+// SetCompactedWorks records the works compacted into the consolidated work.
+func SetCompactedWorks(consolidated *fleetv1beta1.Work, records []CompactedWork) error {
+	value, err := json.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the compacted works of the consolidated work %s: %w", consolidated.Name, err)
+	}
+	annotations := consolidated.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+	annotations[fleetv1beta1.CompactedWorksAnnotation] = string(value)
+	consolidated.SetAnnotations(annotations)
+	return nil
+}
+
+// AppliesCompactedWork returns true if the consolidated work has applied the manifests of the current generation of
+// the compacted work, so that the resources of the compacted work can be handed over to the consolidated work.
+func AppliesCompactedWork(consolidated, work *fleetv1beta1.Work) bool {
+	applied := meta.FindStatusCondition(consolidated.Status.Conditions, fleetv1beta1.WorkConditionTypeApplied)
+	if !condition.IsConditionStatusTrue(applied, consolidated.Generation) {
+		return false
+	}
+	records, err := CompactedWorks(consolidated)
+	if err != nil {
+		return false
+	}
+	for _, record := range records {
+		if record.Name == work.Name {
+			return record.Generation == work.Generation
+		}
+	}
+	return false
+}
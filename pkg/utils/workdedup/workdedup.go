@@ -0,0 +1,74 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package workdedup provides utils to find the manifests of a work which describe the same resource as another
+// manifest of the work.
+package workdedup
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// resourceKey identifies the resource a manifest describes.
+type resourceKey struct {
+	gvk       schema.GroupVersionKind
+	namespace string
+	name      string
+}
+
+// DeduplicateManifests returns the manifests without the duplicates, in their order, and the ordinals of the removed
+// duplicates, in increasing order. Two manifests are duplicates if they have the same group, version, kind,
+// namespace and name; the manifest with the lowest ordinal is kept so that the result is the same on every call.
+// The manifests which cannot be decoded or have no name are never duplicates. The given slice is not modified.
+func DeduplicateManifests(manifests []fleetv1beta1.Manifest) ([]fleetv1beta1.Manifest, []int) {
+	duplicates := Duplicates(manifests, nil)
+	if len(duplicates) == 0 {
+		return manifests, nil
+	}
+	unique := make([]fleetv1beta1.Manifest, 0, len(manifests)-len(duplicates))
+	removed := make([]int, 0, len(duplicates))
+	for i := range manifests {
+		if _, ok := duplicates[i]; ok {
+			removed = append(removed, i)
+			continue
+		}
+		unique = append(unique, manifests[i])
+	}
+	sort.Ints(removed)
+	return unique, removed
+}
+
+// Duplicates returns the ordinal of the kept manifest for the ordinal of every duplicate, per the rules of
+// DeduplicateManifests. The manifests with an ordinal in targetNamespaces are compared by the namespace they are
+// applied to instead of their own, so the same manifest applied to several namespaces is not duplicated.
+// It returns nil if there is no duplicate.
+func Duplicates(manifests []fleetv1beta1.Manifest, targetNamespaces map[int]string) map[int]int {
+	var duplicates map[int]int
+	kept := make(map[resourceKey]int, len(manifests))
+	for i := range manifests {
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(manifests[i].Raw); err != nil || obj.GetName() == "" {
+			continue
+		}
+		key := resourceKey{gvk: obj.GroupVersionKind(), namespace: obj.GetNamespace(), name: obj.GetName()}
+		if namespace, ok := targetNamespaces[i]; ok {
+			key.namespace = namespace
+		}
+		if first, ok := kept[key]; ok {
+			if duplicates == nil {
+				duplicates = make(map[int]int)
+			}
+			duplicates[i] = first
+			continue
+		}
+		kept[key] = i
+	}
+	return duplicates
+}
@@ -0,0 +1,98 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package workdedup
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// manifest returns the manifest of a config map with the given name, namespace and data value.
+func manifest(namespace, name, value string) fleetv1beta1.Manifest {
+	return fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: []byte(fmt.Sprintf(
+		`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":%q,"namespace":%q},"data":{"key":%q}}`, name, namespace, value))}}
+}
+
+func TestDeduplicateManifests(t *testing.T) {
+	secret := fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: []byte(
+		`{"apiVersion":"v1","kind":"Secret","metadata":{"name":"app","namespace":"app"}}`)}}
+	generated := fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: []byte(
+		`{"apiVersion":"batch/v1","kind":"Job","metadata":{"generateName":"migrate-","namespace":"app"}}`)}}
+	invalid := fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: []byte(`not a manifest`)}}
+
+	tests := map[string]struct {
+		manifests   []fleetv1beta1.Manifest
+		wantUnique  []fleetv1beta1.Manifest
+		wantRemoved []int
+	}{
+		"no duplicates": {
+			manifests:  []fleetv1beta1.Manifest{manifest("app", "app", "a"), manifest("other", "app", "a"), secret},
+			wantUnique: []fleetv1beta1.Manifest{manifest("app", "app", "a"), manifest("other", "app", "a"), secret},
+		},
+		"the first of the duplicates is kept": {
+			manifests: []fleetv1beta1.Manifest{
+				manifest("app", "app", "first"), secret, manifest("app", "app", "second"), manifest("app", "app", "third"),
+			},
+			wantUnique:  []fleetv1beta1.Manifest{manifest("app", "app", "first"), secret},
+			wantRemoved: []int{2, 3},
+		},
+		"the manifests without names or which cannot be decoded are kept": {
+			manifests:  []fleetv1beta1.Manifest{generated, generated, invalid, invalid},
+			wantUnique: []fleetv1beta1.Manifest{generated, generated, invalid, invalid},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotUnique, gotRemoved := DeduplicateManifests(tt.manifests)
+			if diff := cmp.Diff(tt.wantUnique, gotUnique); diff != "" {
+				t.Errorf("DeduplicateManifests() unique manifests mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantRemoved, gotRemoved); diff != "" {
+				t.Errorf("DeduplicateManifests() removed ordinals mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDeduplicateManifestsIsStable(t *testing.T) {
+	var manifests []fleetv1beta1.Manifest
+	for i := 0; i < 50; i++ {
+		manifests = append(manifests, manifest("app", fmt.Sprintf("cm-%d", i%7), fmt.Sprintf("value-%d", i)))
+	}
+	wantUnique, wantRemoved := DeduplicateManifests(manifests)
+	for i := 0; i < 7; i++ {
+		if diff := cmp.Diff(manifest("app", fmt.Sprintf("cm-%d", i), fmt.Sprintf("value-%d", i)), wantUnique[i]); diff != "" {
+			t.Fatalf("DeduplicateManifests() kept manifest %d mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+	for run := 0; run < 10; run++ {
+		gotUnique, gotRemoved := DeduplicateManifests(manifests)
+		if diff := cmp.Diff(wantUnique, gotUnique); diff != "" {
+			t.Fatalf("DeduplicateManifests() run %d unique manifests mismatch (-want +got):\n%s", run, diff)
+		}
+		if diff := cmp.Diff(wantRemoved, gotRemoved); diff != "" {
+			t.Fatalf("DeduplicateManifests() run %d removed ordinals mismatch (-want +got):\n%s", run, diff)
+		}
+	}
+	// deduplicating the unique manifests again removes nothing.
+	if _, removed := DeduplicateManifests(wantUnique); len(removed) != 0 {
+		t.Errorf("DeduplicateManifests() of the unique manifests removed %v, want nothing", removed)
+	}
+}
+
+func TestDuplicatesWithTargetNamespaces(t *testing.T) {
+	manifests := []fleetv1beta1.Manifest{manifest("app", "app", "a"), manifest("app", "app", "a"), manifest("app", "app", "a")}
+	// the second manifest is applied to another namespace while the third one is applied to the namespace of the first.
+	got := Duplicates(manifests, map[int]string{1: "other", 2: "app"})
+	if diff := cmp.Diff(map[int]int{2: 0}, got); diff != "" {
+		t.Errorf("Duplicates() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package workintegrity provides utils to detect the manifests of a work which are corrupted in storage.
+package workintegrity
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/compression"
+)
+
+// ManifestChecksum returns the hex-encoded SHA-256 checksum of the canonical JSON of the manifest.
+// The JSON is re-encoded with sorted keys since the API server does not keep the raw bytes of a manifest as written.
+func ManifestChecksum(manifest fleetv1beta1.Manifest) (string, error) {
+	var obj interface{}
+	if err := json.Unmarshal(manifest.Raw, &obj); err != nil {
+		return "", fmt.Errorf("failed to unmarshal the manifest: %w", err)
+	}
+	canonical, err := json.Marshal(obj)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the manifest: %w", err)
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(canonical)), nil
+}
+
+// ManifestChecksums returns the checksums of the manifests, in the order of the manifests.
+func ManifestChecksums(manifests []fleetv1beta1.Manifest) ([]string, error) {
+	if len(manifests) == 0 {
+		return nil, nil
+	}
+	checksums := make([]string, len(manifests))
+	for i := range manifests {
+		checksum, err := ManifestChecksum(manifests[i])
+		if err != nil {
+			return nil, fmt.Errorf("manifest with ordinal %d: %w", i, err)
+		}
+		checksums[i] = checksum
+	}
+	return checksums, nil
+}
+
+// CorruptedManifests returns the ordinals of the manifests of the work which do not match their checksums.
+// A manifest without a checksum or a checksum without a manifest is reported as well. The compressed manifests are
+// checked after they are decompressed. It returns nil if the work has no checksums.
+func CorruptedManifests(work *fleetv1beta1.Work) ([]int, error) {
+	checksums := work.Spec.Workload.ManifestChecksums
+	if len(checksums) == 0 {
+		return nil, nil
+	}
+	manifests := work.Spec.Workload.Manifests
+	if work.Spec.Compressed {
+		decompressed, err := compression.DecompressManifests(work.Spec.Workload.CompressedManifests)
+		if err != nil {
+			return nil, err
+		}
+		manifests = decompressed
+	}
+
+	var corrupted []int
+	for i := 0; i < max(len(manifests), len(checksums)); i++ {
+		if i >= len(manifests) || i >= len(checksums) {
+			corrupted = append(corrupted, i)
+			continue
+		}
+		checksum, err := ManifestChecksum(manifests[i])
+		if err != nil || checksum != checksums[i] {
+			corrupted = append(corrupted, i)
+		}
+	}
+	return corrupted, nil
+}
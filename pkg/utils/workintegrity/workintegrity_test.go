@@ -0,0 +1,96 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package workintegrity
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/compression"
+)
+
+func manifest(raw string) fleetv1beta1.Manifest {
+	return fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: []byte(raw)}}
+}
+
+func TestManifestChecksumIsCanonical(t *testing.T) {
+	got, err := ManifestChecksum(manifest(`{"kind":"ConfigMap","apiVersion":"v1"}`))
+	if err != nil {
+		t.Fatalf("ManifestChecksum() = %v, want no error", err)
+	}
+	want, err := ManifestChecksum(manifest(`{ "apiVersion": "v1", "kind": "ConfigMap" }`))
+	if err != nil {
+		t.Fatalf("ManifestChecksum() = %v, want no error", err)
+	}
+	if got != want {
+		t.Errorf("ManifestChecksum() = %s, want %s for the re-encoded manifest", got, want)
+	}
+}
+
+func TestCorruptedManifests(t *testing.T) {
+	manifests := []fleetv1beta1.Manifest{
+		manifest(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-0"}}`),
+		manifest(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-1"}}`),
+	}
+	checksums, err := ManifestChecksums(manifests)
+	if err != nil {
+		t.Fatalf("ManifestChecksums() = %v, want no error", err)
+	}
+	compressed, err := compression.CompressManifests(manifests)
+	if err != nil {
+		t.Fatalf("CompressManifests() = %v, want no error", err)
+	}
+
+	tests := map[string]struct {
+		workload   fleetv1beta1.WorkloadTemplate
+		compressed bool
+		want       []int
+	}{
+		"intact manifests": {
+			workload: fleetv1beta1.WorkloadTemplate{Manifests: manifests, ManifestChecksums: checksums},
+		},
+		"intact compressed manifests": {
+			workload:   fleetv1beta1.WorkloadTemplate{CompressedManifests: compressed, ManifestChecksums: checksums},
+			compressed: true,
+		},
+		"checksum mismatch": {
+			workload: fleetv1beta1.WorkloadTemplate{
+				Manifests:         []fleetv1beta1.Manifest{manifests[0], manifest(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-x"}}`)},
+				ManifestChecksums: checksums,
+			},
+			want: []int{1},
+		},
+		"missing manifest": {
+			workload: fleetv1beta1.WorkloadTemplate{Manifests: manifests[:1], ManifestChecksums: checksums},
+			want:     []int{1},
+		},
+		"undecodable manifest": {
+			workload: fleetv1beta1.WorkloadTemplate{
+				Manifests:         []fleetv1beta1.Manifest{manifest(`{"apiVersion":`), manifests[1]},
+				ManifestChecksums: checksums,
+			},
+			want: []int{0},
+		},
+		"work without checksums": {
+			workload: fleetv1beta1.WorkloadTemplate{Manifests: manifests},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			work := &fleetv1beta1.Work{Spec: fleetv1beta1.WorkSpec{Workload: tt.workload, Compressed: tt.compressed}}
+			got, err := CorruptedManifests(work)
+			if err != nil {
+				t.Fatalf("CorruptedManifests() = %v, want no error", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("CorruptedManifests() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
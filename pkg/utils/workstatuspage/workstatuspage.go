@@ -0,0 +1,53 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package workstatuspage provides utils to paginate the manifest conditions of a work status into WorkStatusPages.
+package workstatuspage
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// PageName returns the name of the given page of a work; the page numbers start from 1.
+func PageName(workName string, page int) string {
+	return fmt.Sprintf(fleetv1beta1.WorkStatusPageNameFmt, workName, page)
+}
+
+// Split splits the manifest conditions into the ones kept in the work status and the pages overflowing them, each
+// holding at most pageSize conditions. The conditions are not split if the pageSize is not positive.
+func Split(conditions []fleetv1beta1.ManifestCondition, pageSize int) ([]fleetv1beta1.ManifestCondition, [][]fleetv1beta1.ManifestCondition) {
+	if pageSize <= 0 || len(conditions) <= pageSize {
+		return conditions, nil
+	}
+	var pages [][]fleetv1beta1.ManifestCondition
+	for start := pageSize; start < len(conditions); start += pageSize {
+		end := start + pageSize
+		if end > len(conditions) {
+			end = len(conditions)
+		}
+		pages = append(pages, conditions[start:end])
+	}
+	return conditions[:pageSize], pages
+}
+
+// Merge appends the manifest conditions of the status pages referenced by the work status to the manifest
+// conditions of the work in memory, so that the work has all its manifest conditions whether its status is paginated
+// or not. It is a no-op if the work status is not paginated.
+func Merge(ctx context.Context, reader client.Reader, work *fleetv1beta1.Work) error {
+	for _, pageName := range work.Status.WorkStatusPageRef {
+		var page fleetv1beta1.WorkStatusPage
+		if err := reader.Get(ctx, types.NamespacedName{Name: pageName, Namespace: work.Namespace}, &page); err != nil {
+			return fmt.Errorf("failed to get the status page %s of the work: %w", pageName, err)
+		}
+		work.Status.ManifestConditions = append(work.Status.ManifestConditions, page.ManifestConditions...)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package workstatuspage
+
+import (
+	"testing"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func TestSplit(t *testing.T) {
+	conditions := make([]fleetv1beta1.ManifestCondition, 200)
+	for i := range conditions {
+		conditions[i].Identifier.Ordinal = i
+	}
+	tests := map[string]struct {
+		pageSize      int
+		wantKept      int
+		wantPageSizes []int
+	}{
+		"pagination is disabled": {
+			pageSize: 0,
+			wantKept: 200,
+		},
+		"conditions fit in the work": {
+			pageSize: 200,
+			wantKept: 200,
+		},
+		"conditions are split evenly": {
+			pageSize:      50,
+			wantKept:      50,
+			wantPageSizes: []int{50, 50, 50},
+		},
+		"the last page is partial": {
+			pageSize:      80,
+			wantKept:      80,
+			wantPageSizes: []int{80, 40},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			kept, pages := Split(conditions, tt.pageSize)
+			if len(kept) != tt.wantKept {
+				t.Errorf("Split() kept %d conditions, want %d", len(kept), tt.wantKept)
+			}
+			if len(pages) != len(tt.wantPageSizes) {
+				t.Fatalf("Split() = %d pages, want %d", len(pages), len(tt.wantPageSizes))
+			}
+			ordinal := len(kept)
+			for i, page := range pages {
+				if len(page) != tt.wantPageSizes[i] {
+					t.Errorf("Split() page %d = %d conditions, want %d", i+1, len(page), tt.wantPageSizes[i])
+				}
+				for _, cond := range page {
+					if cond.Identifier.Ordinal != ordinal {
+						t.Errorf("Split() page %d has ordinal %d, want %d", i+1, cond.Identifier.Ordinal, ordinal)
+					}
+					ordinal++
+				}
+			}
+		})
+	}
+}
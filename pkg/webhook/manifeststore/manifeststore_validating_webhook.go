@@ -0,0 +1,123 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package manifeststore
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/manifeststore"
+)
+
+const (
+	// maxReportedWorks is the maximum number of the works referring to a blob listed when its deletion is denied.
+	maxReportedWorks = 5
+
+	nameMismatchDeniedFormat  = "ManifestStore %s is disallowed as its name does not match the checksum %s of its manifest"
+	contentChangeDeniedFormat = "ManifestStore %s is disallowed as the manifest of a manifest store is immutable"
+	deletionDeniedFormat      = "ManifestStore %s cannot be deleted as %d work(s) refer to it: %s"
+)
+
+var (
+	// ValidationPath is the webhook service path which admission requests are routed to for validating ManifestStore
+	// resources.
+	ValidationPath = fmt.Sprintf(utils.ValidationPathFmt, placementv1beta1.GroupVersion.Group, placementv1beta1.GroupVersion.Version, "manifeststore")
+)
+
+type manifestStoreValidator struct {
+	client  client.Client
+	decoder webhook.AdmissionDecoder
+}
+
+// Add registers the webhook for the ManifestStore resources.
+func Add(mgr manager.Manager) error {
+	// index the works by the blobs they refer to, so that a deletion does not list every work on the hub cluster.
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &placementv1beta1.Work{}, manifeststore.WorkBlobRefsField, manifeststore.WorkBlobRefs); err != nil {
+		return err
+	}
+	hookServer := mgr.GetWebhookServer()
+	hookServer.Register(ValidationPath, &webhook.Admission{Handler: &manifestStoreValidator{
+		client:  mgr.GetClient(),
+		decoder: admission.NewDecoder(mgr.GetScheme()),
+	}})
+	return nil
+}
+
+// Handle manifestStoreValidator denies a manifest store whose name is not the checksum of its manifest, a change of
+// its manifest, and its deletion while a work refers to it.
+func (v *manifestStoreValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	namespacedName := types.NamespacedName{Name: req.Name}
+	switch req.Operation {
+	case admissionv1.Create:
+		var blob placementv1beta1.ManifestStore
+		if err := v.decoder.Decode(req, &blob); err != nil {
+			klog.ErrorS(err, "Failed to decode the manifest store", "operation", req.Operation, "namespacedName", namespacedName)
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		ref, err := manifeststore.BlobRef(blob.Spec.Manifest)
+		if err != nil {
+			return admission.Denied(err.Error())
+		}
+		if ref != blob.Name {
+			return admission.Denied(fmt.Sprintf(nameMismatchDeniedFormat, blob.Name, ref))
+		}
+		return admission.Allowed("")
+
+	case admissionv1.Update:
+		var blob, oldBlob placementv1beta1.ManifestStore
+		if err := v.decoder.Decode(req, &blob); err != nil {
+			klog.ErrorS(err, "Failed to decode the manifest store", "operation", req.Operation, "namespacedName", namespacedName)
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if err := v.decoder.DecodeRaw(req.OldObject, &oldBlob); err != nil {
+			klog.ErrorS(err, "Failed to decode the old manifest store", "operation", req.Operation, "namespacedName", namespacedName)
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		ref, err := manifeststore.BlobRef(blob.Spec.Manifest)
+		if err != nil {
+			return admission.Denied(err.Error())
+		}
+		oldRef, err := manifeststore.BlobRef(oldBlob.Spec.Manifest)
+		if err != nil || ref != oldRef {
+			return admission.Denied(fmt.Sprintf(contentChangeDeniedFormat, blob.Name))
+		}
+		return admission.Allowed("")
+
+	case admissionv1.Delete:
+		var works placementv1beta1.WorkList
+		if err := v.client.List(ctx, &works, client.MatchingFields{manifeststore.WorkBlobRefsField: req.Name}); err != nil {
+			klog.ErrorS(err, "Failed to list the works referring to the manifest store", "operation", req.Operation, "namespacedName", namespacedName)
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		referring := make([]string, 0, len(works.Items))
+		for i := range works.Items {
+			referring = append(referring, klog.KObj(&works.Items[i]).String())
+		}
+		if len(referring) == 0 {
+			return admission.Allowed("")
+		}
+		klog.V(2).InfoS("Blocked the deletion of the manifest store referred to by works", "user", req.UserInfo.Username,
+			"namespacedName", namespacedName, "works", len(referring))
+		reported := referring
+		if len(reported) > maxReportedWorks {
+			reported = append(reported[:maxReportedWorks:maxReportedWorks], "...")
+		}
+		return admission.Denied(fmt.Sprintf(deletionDeniedFormat, req.Name, len(referring), strings.Join(reported, ", ")))
+	}
+	return admission.Allowed("")
+}
@@ -0,0 +1,131 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package manifeststore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/manifeststore"
+)
+
+func newBlob(t *testing.T, raw string) *placementv1beta1.ManifestStore {
+	t.Helper()
+	blob, err := manifeststore.NewBlob(placementv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: []byte(raw)}})
+	if err != nil {
+		t.Fatalf("NewBlob() = %v, want no error", err)
+	}
+	blob.TypeMeta = metav1.TypeMeta{APIVersion: placementv1beta1.GroupVersion.String(), Kind: placementv1beta1.ManifestStoreKind}
+	return blob
+}
+
+func marshal(t *testing.T, obj interface{}) runtime.RawExtension {
+	t.Helper()
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("failed to marshal the object: %v", err)
+	}
+	return runtime.RawExtension{Raw: raw}
+}
+
+func TestManifestStoreValidatorHandle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	blob := newBlob(t, `{"apiVersion":"rbac.authorization.k8s.io/v1","kind":"ClusterRole","metadata":{"name":"reader"}}`)
+	changed := newBlob(t, `{"apiVersion":"rbac.authorization.k8s.io/v1","kind":"ClusterRole","metadata":{"name":"writer"}}`)
+	changed.Name = blob.Name
+	misnamed := blob.DeepCopy()
+	misnamed.Name = strings.Repeat("a", 64)
+	referringWork := func(namespace, blobRef string) client.Object {
+		return &placementv1beta1.Work{
+			ObjectMeta: metav1.ObjectMeta{Name: "work", Namespace: namespace},
+			Spec: placementv1beta1.WorkSpec{Workload: placementv1beta1.WorkloadTemplate{
+				ManifestBlobRefs: []placementv1beta1.ManifestBlobRef{{Ordinal: 0, BlobRef: blobRef}},
+			}},
+		}
+	}
+
+	tests := map[string]struct {
+		operation   admissionv1.Operation
+		object      *placementv1beta1.ManifestStore
+		oldObject   *placementv1beta1.ManifestStore
+		works       []client.Object
+		wantAllowed bool
+	}{
+		"blob named by its checksum is allowed": {
+			operation:   admissionv1.Create,
+			object:      blob,
+			wantAllowed: true,
+		},
+		"blob not named by its checksum is denied": {
+			operation: admissionv1.Create,
+			object:    misnamed,
+		},
+		"update keeping the manifest is allowed": {
+			operation:   admissionv1.Update,
+			object:      blob,
+			oldObject:   blob,
+			wantAllowed: true,
+		},
+		"update changing the manifest is denied": {
+			operation: admissionv1.Update,
+			object:    changed,
+			oldObject: blob,
+		},
+		"deletion of a blob no work refers to is allowed": {
+			operation:   admissionv1.Delete,
+			oldObject:   blob,
+			works:       []client.Object{referringWork("fleet-member-1", misnamed.Name)},
+			wantAllowed: true,
+		},
+		"deletion of a blob referred to by works is denied": {
+			operation: admissionv1.Delete,
+			oldObject: blob,
+			works:     []client.Object{referringWork("fleet-member-1", blob.Name), referringWork("fleet-member-2", blob.Name)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			v := &manifestStoreValidator{
+				client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.works...).
+					WithIndex(&placementv1beta1.Work{}, manifeststore.WorkBlobRefsField, manifeststore.WorkBlobRefs).Build(),
+				decoder: admission.NewDecoder(scheme),
+			}
+			req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: tt.operation}}
+			if tt.object != nil {
+				req.Name = tt.object.Name
+				req.Object = marshal(t, tt.object)
+			}
+			if tt.oldObject != nil {
+				req.Name = tt.oldObject.Name
+				req.OldObject = marshal(t, tt.oldObject)
+			}
+			resp := v.Handle(context.Background(), req)
+			if resp.Allowed != tt.wantAllowed {
+				t.Fatalf("Handle() allowed = %t, want %t: %v", resp.Allowed, tt.wantAllowed, resp.Result)
+			}
+			if !resp.Allowed && tt.operation == admissionv1.Delete {
+				want := fmt.Sprintf("%d work(s) refer to it", len(tt.works))
+				if !strings.Contains(resp.Result.Message, want) {
+					t.Errorf("Handle() message = %q, want it to contain %q", resp.Result.Message, want)
+				}
+			}
+		})
+	}
+}
@@ -50,10 +50,12 @@ import (
 	"go.goms.io/fleet/pkg/webhook/clusterresourceoverride"
 	"go.goms.io/fleet/pkg/webhook/clusterresourceplacement"
 	"go.goms.io/fleet/pkg/webhook/fleetresourcehandler"
+	"go.goms.io/fleet/pkg/webhook/manifeststore"
 	"go.goms.io/fleet/pkg/webhook/membercluster"
 	"go.goms.io/fleet/pkg/webhook/pod"
 	"go.goms.io/fleet/pkg/webhook/replicaset"
 	"go.goms.io/fleet/pkg/webhook/resourceoverride"
+	"go.goms.io/fleet/pkg/webhook/work"
 )
 
 const (
@@ -61,6 +63,7 @@ const (
 	fleetWebhookKeyFileName       = "tls.key"
 	fleetValidatingWebhookCfgName = "fleet-validating-webhook-configuration"
 	fleetGuardRailWebhookCfgName  = "fleet-guard-rail-webhook-configuration"
+	fleetMutatingWebhookCfgName   = "fleet-mutating-webhook-configuration"
 
 	crdResourceName                      = "customresourcedefinitions"
 	bindingResourceName                  = "bindings"
@@ -101,6 +104,7 @@ const (
 	podResourceName                      = "pods"
 	clusterResourceOverrideName          = "clusterresourceoverrides"
 	resourceOverrideName                 = "resourceoverrides"
+	manifestStoreResourceName            = "manifeststores"
 )
 
 var (
@@ -117,14 +121,18 @@ var (
 
 var AddToManagerFuncs []func(manager.Manager) error
 var AddToManagerFleetResourceValidator func(manager.Manager, []string, bool) error
+var AddToManagerWorkWebhooks func(manager.Manager, string) error
 
 // AddToManager adds all Controllers to the Manager
-func AddToManager(m manager.Manager, whiteListedUsers []string, isFleetV1Beta1API bool) error {
+func AddToManager(m manager.Manager, whiteListedUsers []string, isFleetV1Beta1API bool, workNamespacePattern string) error {
 	for _, f := range AddToManagerFuncs {
 		if err := f(m); err != nil {
 			return err
 		}
 	}
+	if err := AddToManagerWorkWebhooks(m, workNamespacePattern); err != nil {
+		return err
+	}
 	return AddToManagerFleetResourceValidator(m, whiteListedUsers, isFleetV1Beta1API)
 }
 
@@ -177,11 +185,14 @@ func (w *Config) Start(ctx context.Context) error {
 	return nil
 }
 
-// createFleetWebhookConfiguration creates the ValidatingWebhookConfiguration object for the webhook.
+// createFleetWebhookConfiguration creates the ValidatingWebhookConfiguration and MutatingWebhookConfiguration objects for the webhook.
 func (w *Config) createFleetWebhookConfiguration(ctx context.Context) error {
 	if err := w.createValidatingWebhookConfiguration(ctx, w.buildFleetValidatingWebhooks(), fleetValidatingWebhookCfgName); err != nil {
 		return err
 	}
+	if err := w.createMutatingWebhookConfiguration(ctx, w.buildFleetMutatingWebhooks(), fleetMutatingWebhookCfgName); err != nil {
+		return err
+	}
 	if w.enableGuardRail {
 		if err := w.createValidatingWebhookConfiguration(ctx, w.buildFleetGuardRailValidatingWebhooks(), fleetGuardRailWebhookCfgName); err != nil {
 			return err
@@ -226,6 +237,42 @@ func (w *Config) createValidatingWebhookConfiguration(ctx context.Context, webho
 	return nil
 }
 
+func (w *Config) createMutatingWebhookConfiguration(ctx context.Context, webhooks []admv1.MutatingWebhook, configName string) error {
+	mutatingWebhookConfig := admv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: configName,
+			Labels: map[string]string{
+				"admissions.enforcer/disabled": "true",
+			},
+		},
+		Webhooks: webhooks,
+	}
+
+	// We need to ensure this webhook configuration is garbage collected if Fleet is uninstalled from the cluster.
+	// Since the fleet-system namespace is a prerequisite for core Fleet components, we bind to this namespace.
+	if err := bindWebhookConfigToFleetSystem(ctx, w.mgr.GetClient(), &mutatingWebhookConfig); err != nil {
+		return err
+	}
+
+	if err := w.mgr.GetClient().Create(ctx, &mutatingWebhookConfig); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+		klog.V(2).InfoS("mutating webhook configuration exists, need to overwrite", "name", configName)
+		// Here we simply use delete/create pattern to implement full overwrite
+		if err := w.mgr.GetClient().Delete(ctx, &mutatingWebhookConfig); err != nil {
+			return err
+		}
+		if err = w.mgr.GetClient().Create(ctx, &mutatingWebhookConfig); err != nil {
+			return err
+		}
+		klog.V(2).InfoS("successfully overwritten mutating webhook configuration", "name", configName)
+		return nil
+	}
+	klog.V(2).InfoS("successfully created mutating webhook configuration", "name", configName)
+	return nil
+}
+
 // buildValidatingWebHooks returns a slice of fleet validating webhook objects.
 func (w *Config) buildFleetValidatingWebhooks() []admv1.ValidatingWebhook {
 	webHooks := []admv1.ValidatingWebhook{
@@ -346,11 +393,135 @@ func (w *Config) buildFleetValidatingWebhooks() []admv1.ValidatingWebhook {
 			},
 			TimeoutSeconds: longWebhookTimeout,
 		},
+		{
+			Name:                    "fleet.work.validating",
+			ClientConfig:            w.createClientConfig(work.ValidationPath),
+			FailurePolicy:           &failFailurePolicy,
+			SideEffects:             &sideEffortsNone,
+			AdmissionReviewVersions: admissionReviewVersions,
+			Rules: []admv1.RuleWithOperations{
+				{
+					Operations: []admv1.OperationType{
+						admv1.Create,
+					},
+					Rule: createRule([]string{placementv1beta1.GroupVersion.Group}, []string{placementv1beta1.GroupVersion.Version}, []string{workResourceName}, &namespacedScope),
+				},
+			},
+			TimeoutSeconds: longWebhookTimeout,
+		},
+		{
+			Name:                    "fleet.workbinarydata.validating",
+			ClientConfig:            w.createClientConfig(work.BinaryDataValidationPath),
+			FailurePolicy:           &failFailurePolicy,
+			SideEffects:             &sideEffortsNone,
+			AdmissionReviewVersions: admissionReviewVersions,
+			Rules: []admv1.RuleWithOperations{
+				{
+					Operations: []admv1.OperationType{
+						admv1.Create,
+						admv1.Update,
+					},
+					Rule: createRule([]string{placementv1beta1.GroupVersion.Group}, []string{placementv1beta1.GroupVersion.Version}, []string{workResourceName}, &namespacedScope),
+				},
+			},
+			TimeoutSeconds: longWebhookTimeout,
+		},
+		{
+			Name:                    "fleet.workdeletionprotection.validating",
+			ClientConfig:            w.createClientConfig(work.DeletionProtectionPath),
+			FailurePolicy:           &failFailurePolicy,
+			SideEffects:             &sideEffortsNone,
+			AdmissionReviewVersions: admissionReviewVersions,
+			Rules: []admv1.RuleWithOperations{
+				{
+					Operations: []admv1.OperationType{
+						admv1.Delete,
+					},
+					Rule: createRule([]string{placementv1beta1.GroupVersion.Group}, []string{placementv1beta1.GroupVersion.Version}, []string{workResourceName}, &namespacedScope),
+				},
+			},
+			TimeoutSeconds: longWebhookTimeout,
+		},
+		{
+			Name:                    "fleet.workresourcecount.validating",
+			ClientConfig:            w.createClientConfig(work.ResourceCountValidationPath),
+			FailurePolicy:           &failFailurePolicy,
+			SideEffects:             &sideEffortsNone,
+			AdmissionReviewVersions: admissionReviewVersions,
+			Rules: []admv1.RuleWithOperations{
+				{
+					Operations: []admv1.OperationType{
+						admv1.Create,
+						admv1.Update,
+					},
+					Rule: createRule([]string{placementv1beta1.GroupVersion.Group}, []string{placementv1beta1.GroupVersion.Version}, []string{workResourceName}, &namespacedScope),
+				},
+			},
+			TimeoutSeconds: longWebhookTimeout,
+		},
+		{
+			Name:                    "fleet.manifeststore.validating",
+			ClientConfig:            w.createClientConfig(manifeststore.ValidationPath),
+			FailurePolicy:           &failFailurePolicy,
+			SideEffects:             &sideEffortsNone,
+			AdmissionReviewVersions: admissionReviewVersions,
+			Rules: []admv1.RuleWithOperations{
+				{
+					Operations: []admv1.OperationType{
+						admv1.Create,
+						admv1.Update,
+						admv1.Delete,
+					},
+					Rule: createRule([]string{placementv1beta1.GroupVersion.Group}, []string{placementv1beta1.GroupVersion.Version}, []string{manifestStoreResourceName}, &clusterScope),
+				},
+			},
+			TimeoutSeconds: longWebhookTimeout,
+		},
 	}
 
 	return webHooks
 }
 
+// buildFleetMutatingWebhooks returns a slice of fleet mutating webhook objects.
+func (w *Config) buildFleetMutatingWebhooks() []admv1.MutatingWebhook {
+	return []admv1.MutatingWebhook{
+		{
+			Name:                    "fleet.work.mutating",
+			ClientConfig:            w.createClientConfig(work.MutationPath),
+			FailurePolicy:           &ignoreFailurePolicy,
+			SideEffects:             &sideEffortsNone,
+			AdmissionReviewVersions: admissionReviewVersions,
+			Rules: []admv1.RuleWithOperations{
+				{
+					Operations: []admv1.OperationType{
+						admv1.Create,
+						admv1.Update,
+					},
+					Rule: createRule([]string{placementv1beta1.GroupVersion.Group}, []string{placementv1beta1.GroupVersion.Version}, []string{workResourceName}, &namespacedScope),
+				},
+			},
+			TimeoutSeconds: longWebhookTimeout,
+		},
+		{
+			Name:                    "fleet.workcompression.mutating",
+			ClientConfig:            w.createClientConfig(work.CompressionPath),
+			FailurePolicy:           &failFailurePolicy,
+			SideEffects:             &sideEffortsNone,
+			AdmissionReviewVersions: admissionReviewVersions,
+			Rules: []admv1.RuleWithOperations{
+				{
+					Operations: []admv1.OperationType{
+						admv1.Create,
+						admv1.Update,
+					},
+					Rule: createRule([]string{placementv1beta1.GroupVersion.Group}, []string{placementv1beta1.GroupVersion.Version}, []string{workResourceName}, &namespacedScope),
+				},
+			},
+			TimeoutSeconds: longWebhookTimeout,
+		},
+	}
+}
+
 // buildFleetGuardRailValidatingWebhooks returns a slice of fleet guard rail validating webhook objects.
 func (w *Config) buildFleetGuardRailValidatingWebhooks() []admv1.ValidatingWebhook {
 	// MatchLabels/MatchExpressions values are ANDed to select resources.
@@ -732,8 +903,8 @@ func genCertAndKeyFile(certData, keyData []byte, certDir string) error {
 	return nil
 }
 
-// bindWebhookConfigToFleetSystem sets the OwnerReference of the argued ValidatingWebhookConfiguration to the cluster scoped fleet-system namespace.
-func bindWebhookConfigToFleetSystem(ctx context.Context, k8Client client.Client, validatingWebhookConfig *admv1.ValidatingWebhookConfiguration) error {
+// bindWebhookConfigToFleetSystem sets the OwnerReference of the argued webhook configuration to the cluster scoped fleet-system namespace.
+func bindWebhookConfigToFleetSystem(ctx context.Context, k8Client client.Client, webhookConfig client.Object) error {
 	var fleetNs corev1.Namespace
 	if err := k8Client.Get(ctx, client.ObjectKey{Name: "fleet-system"}, &fleetNs); err != nil {
 		return err
@@ -747,7 +918,7 @@ func bindWebhookConfigToFleetSystem(ctx context.Context, k8Client client.Client,
 		BlockOwnerDeletion: ptr.To(false),
 	}
 
-	validatingWebhookConfig.OwnerReferences = []metav1.OwnerReference{ownerRef}
+	webhookConfig.SetOwnerReferences([]metav1.OwnerReference{ownerRef})
 	return nil
 }
 
@@ -22,7 +22,7 @@ func TestBuildFleetValidatingWebhooks(t *testing.T) {
 				serviceURL:           "test-url",
 				clientConnectionType: &url,
 			},
-			wantLength: 7,
+			wantLength: 12,
 		},
 	}
 
@@ -34,6 +34,31 @@ func TestBuildFleetValidatingWebhooks(t *testing.T) {
 	}
 }
 
+func TestBuildFleetMutatingWebhooks(t *testing.T) {
+	url := options.WebhookClientConnectionType("url")
+	testCases := map[string]struct {
+		config     Config
+		wantLength int
+	}{
+		"valid input": {
+			config: Config{
+				serviceNamespace:     "test-namespace",
+				servicePort:          8080,
+				serviceURL:           "test-url",
+				clientConnectionType: &url,
+			},
+			wantLength: 2,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			gotResult := testCase.config.buildFleetMutatingWebhooks()
+			assert.Equal(t, testCase.wantLength, len(gotResult), utils.TestCaseMsg, testName)
+		})
+	}
+}
+
 func TestBuildFleetGuardRailValidatingWebhooks(t *testing.T) {
 	url := options.WebhookClientConnectionType("url")
 	testCases := map[string]struct {
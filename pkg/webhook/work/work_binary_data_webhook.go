@@ -0,0 +1,87 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/binarydata"
+	"go.goms.io/fleet/pkg/utils/compression"
+)
+
+const (
+	binaryDataDeniedFormat = "Work %s/%s is disallowed as the binary data of the manifest with ordinal %d is invalid: %v"
+)
+
+var (
+	// BinaryDataValidationPath is the webhook service path which admission requests are routed to for validating the
+	// binary data of the manifests of the Work resources.
+	BinaryDataValidationPath = fmt.Sprintf(utils.ValidationPathFmt, placementv1beta1.GroupVersion.Group, placementv1beta1.GroupVersion.Version, "work-binarydata")
+)
+
+type binaryDataValidator struct {
+	decoder webhook.AdmissionDecoder
+}
+
+// Handle binaryDataValidator denies a work if the binary data of a manifest does not refer to a manifest of the work,
+// or its keys are not valid field names in the binary field of the kind of the manifest.
+func (v *binaryDataValidator) Handle(_ context.Context, req admission.Request) admission.Response {
+	namespacedName := types.NamespacedName{Name: req.Name, Namespace: req.Namespace}
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+	var work placementv1beta1.Work
+	if err := v.decoder.Decode(req, &work); err != nil {
+		klog.ErrorS(err, "Failed to decode the work", "operation", req.Operation, "namespacedName", namespacedName)
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if len(work.Spec.Workload.ManifestBinaryData) == 0 {
+		return admission.Allowed("")
+	}
+	if err := compression.DecompressWork(&work); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if ordinal, err := validateManifestBinaryData(&work); err != nil {
+		klog.V(2).InfoS("Work has invalid binary data", "operation", req.Operation, "namespacedName", namespacedName, "ordinal", ordinal, "err", err)
+		return admission.Denied(fmt.Sprintf(binaryDataDeniedFormat, req.Namespace, req.Name, ordinal, err))
+	}
+	return admission.Allowed("")
+}
+
+// validateManifestBinaryData returns the ordinal of the first invalid binary data of the manifests of the work and
+// why it is invalid.
+func validateManifestBinaryData(work *placementv1beta1.Work) (int, error) {
+	manifests := work.Spec.Workload.Manifests
+	seen := make(map[int]bool, len(work.Spec.Workload.ManifestBinaryData))
+	for _, entry := range work.Spec.Workload.ManifestBinaryData {
+		if entry.Ordinal < 0 || entry.Ordinal >= len(manifests) {
+			return entry.Ordinal, fmt.Errorf("the work has %d manifests", len(manifests))
+		}
+		if seen[entry.Ordinal] {
+			return entry.Ordinal, fmt.Errorf("the manifest has more than one binary data")
+		}
+		seen[entry.Ordinal] = true
+		var obj unstructured.Unstructured
+		if err := obj.UnmarshalJSON(manifests[entry.Ordinal].Raw); err != nil {
+			return entry.Ordinal, fmt.Errorf("failed to decode the manifest: %w", err)
+		}
+		if err := binarydata.Validate(obj.GroupVersionKind(), entry.BinaryData); err != nil {
+			return entry.Ordinal, err
+		}
+	}
+	return 0, nil
+}
@@ -0,0 +1,84 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func TestBinaryDataValidatorHandle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	manifests := []placementv1beta1.Manifest{
+		{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"Secret","metadata":{"name":"tls","namespace":"app"}}`)}},
+		{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"app"}}`)}},
+	}
+	cert := []byte{0x30, 0x82, 0xff}
+	tests := map[string]struct {
+		binaryData  []placementv1beta1.ManifestBinaryData
+		wantAllowed bool
+	}{
+		"work without binary data is allowed": {
+			wantAllowed: true,
+		},
+		"binary data of a secret is allowed": {
+			binaryData:  []placementv1beta1.ManifestBinaryData{{Ordinal: 0, BinaryData: map[string][]byte{"tls.crt": cert}}},
+			wantAllowed: true,
+		},
+		"binary data with an invalid key is denied": {
+			binaryData: []placementv1beta1.ManifestBinaryData{{Ordinal: 0, BinaryData: map[string][]byte{"tls/crt": cert}}},
+		},
+		"binary data of a kind without a binary field is denied": {
+			binaryData: []placementv1beta1.ManifestBinaryData{{Ordinal: 1, BinaryData: map[string][]byte{"tls.crt": cert}}},
+		},
+		"binary data of a missing manifest is denied": {
+			binaryData: []placementv1beta1.ManifestBinaryData{{Ordinal: 2, BinaryData: map[string][]byte{"tls.crt": cert}}},
+		},
+		"duplicate binary data of a manifest is denied": {
+			binaryData: []placementv1beta1.ManifestBinaryData{
+				{Ordinal: 0, BinaryData: map[string][]byte{"tls.crt": cert}},
+				{Ordinal: 0, BinaryData: map[string][]byte{"ca.crt": cert}},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			v := &binaryDataValidator{decoder: admission.NewDecoder(scheme)}
+			work := &placementv1beta1.Work{
+				TypeMeta:   metav1.TypeMeta{APIVersion: placementv1beta1.GroupVersion.String(), Kind: "Work"},
+				ObjectMeta: metav1.ObjectMeta{Name: "work", Namespace: "fleet-member-test"},
+				Spec: placementv1beta1.WorkSpec{Workload: placementv1beta1.WorkloadTemplate{
+					Manifests:          manifests,
+					ManifestBinaryData: tt.binaryData,
+				}},
+			}
+			raw, err := json.Marshal(work)
+			if err != nil {
+				t.Fatalf("failed to marshal the work: %v", err)
+			}
+			req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Name:      work.Name,
+				Namespace: work.Namespace,
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: raw},
+			}}
+			if resp := v.Handle(context.Background(), req); resp.Allowed != tt.wantAllowed {
+				t.Errorf("Handle() allowed = %t, want %t: %v", resp.Allowed, tt.wantAllowed, resp.Result)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/compression"
+)
+
+const (
+	// CompressionThresholdBytes is the size of the JSON of the manifests above which the manifests of a work are
+	// compressed.
+	CompressionThresholdBytes = 512 * 1024
+)
+
+var (
+	// CompressionPath is the webhook service path which admission requests are routed to for compressing the
+	// manifests of Work resources.
+	CompressionPath = fmt.Sprintf(utils.MutationPathFmt, placementv1beta1.GroupVersion.Group, placementv1beta1.GroupVersion.Version, "work-compression")
+)
+
+type workManifestCompressor struct {
+	decoder   webhook.AdmissionDecoder
+	threshold int
+}
+
+// Handle workManifestCompressor moves the manifests of a work into the gzip-compressed workload.compressedManifests
+// if their JSON is larger than the threshold.
+// A work carrying uncompressed manifests, e.g. the manifests of a compressed work are updated by a client that is not
+// aware of the compression, always takes the new manifests and drops the stale compressed ones.
+func (m *workManifestCompressor) Handle(_ context.Context, req admission.Request) admission.Response {
+	namespacedName := types.NamespacedName{Name: req.Name, Namespace: req.Namespace}
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+	var work unstructured.Unstructured
+	if err := m.decoder.Decode(req, &work); err != nil {
+		klog.ErrorS(err, "Failed to decode the work", "operation", req.Operation, "namespacedName", namespacedName)
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	manifests, found, err := unstructured.NestedSlice(work.Object, "spec", "workload", "manifests")
+	if err != nil {
+		klog.ErrorS(err, "Failed to read the manifests of the work", "operation", req.Operation, "namespacedName", namespacedName)
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if !found || len(manifests) == 0 {
+		return admission.Allowed("the work has no uncompressed manifests")
+	}
+	raw, err := json.Marshal(manifests)
+	if err != nil {
+		klog.ErrorS(err, "Failed to marshal the manifests of the work", "operation", req.Operation, "namespacedName", namespacedName)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	unstructured.RemoveNestedField(work.Object, "spec", "compressed")
+	unstructured.RemoveNestedField(work.Object, "spec", "workload", "compressedManifests")
+	if len(raw) > m.threshold {
+		compressed, err := compression.Compress(raw)
+		if err != nil {
+			klog.ErrorS(err, "Failed to compress the manifests of the work", "operation", req.Operation, "namespacedName", namespacedName)
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		unstructured.RemoveNestedField(work.Object, "spec", "workload", "manifests")
+		if err := unstructured.SetNestedField(work.Object, true, "spec", "compressed"); err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		// byte slices are serialized as base64 strings.
+		if err := unstructured.SetNestedField(work.Object, base64.StdEncoding.EncodeToString(compressed), "spec", "workload", "compressedManifests"); err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		klog.V(2).InfoS("Compressed the manifests of the work", "operation", req.Operation, "namespacedName", namespacedName,
+			"manifests", len(manifests), "size", len(raw), "compressedSize", len(compressed))
+	}
+
+	marshaled, err := json.Marshal(work.Object)
+	if err != nil {
+		klog.ErrorS(err, "Failed to marshal the work", "operation", req.Operation, "namespacedName", namespacedName)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
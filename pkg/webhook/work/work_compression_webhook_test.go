@@ -0,0 +1,156 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/compression"
+)
+
+// compressionTestManifests returns count config map manifests with a data entry of the given size.
+func compressionTestManifests(count, dataSize int) []placementv1beta1.Manifest {
+	manifests := make([]placementv1beta1.Manifest, count)
+	for i := range manifests {
+		raw := fmt.Sprintf(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-%d","namespace":"default"},"data":{"key":"%s"}}`,
+			i, strings.Repeat(fmt.Sprint(i%10), dataSize))
+		manifests[i] = placementv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: []byte(raw)}}
+	}
+	return manifests
+}
+
+// manifestObjects decodes the manifests into generic objects.
+func manifestObjects(t *testing.T, manifests []placementv1beta1.Manifest) []map[string]interface{} {
+	t.Helper()
+	objs := make([]map[string]interface{}, len(manifests))
+	for i, manifest := range manifests {
+		if err := json.Unmarshal(manifest.Raw, &objs[i]); err != nil {
+			t.Fatalf("failed to unmarshal the manifest with ordinal %d: %v", i, err)
+		}
+	}
+	return objs
+}
+
+func TestWorkManifestCompressorHandle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	m := &workManifestCompressor{decoder: admission.NewDecoder(scheme), threshold: CompressionThresholdBytes}
+
+	largeManifests := compressionTestManifests(200, 4096)
+	smallManifests := compressionTestManifests(1, 16)
+	staleCompressed, err := compression.CompressManifests(largeManifests)
+	if err != nil {
+		t.Fatalf("CompressManifests() = %v, want no error", err)
+	}
+	tests := map[string]struct {
+		spec           placementv1beta1.WorkSpec
+		wantCompressed bool
+		wantManifests  []placementv1beta1.Manifest
+	}{
+		"large manifests are compressed": {
+			spec:           placementv1beta1.WorkSpec{Workload: placementv1beta1.WorkloadTemplate{Manifests: largeManifests}},
+			wantCompressed: true,
+			wantManifests:  largeManifests,
+		},
+		"small manifests are kept as is": {
+			spec:          placementv1beta1.WorkSpec{Workload: placementv1beta1.WorkloadTemplate{Manifests: smallManifests}},
+			wantManifests: smallManifests,
+		},
+		"new small manifests replace the stale compressed manifests": {
+			spec: placementv1beta1.WorkSpec{
+				Compressed: true,
+				Workload: placementv1beta1.WorkloadTemplate{
+					Manifests:           smallManifests,
+					CompressedManifests: staleCompressed,
+				},
+			},
+			wantManifests: smallManifests,
+		},
+		"compressed manifests are kept as is": {
+			spec: placementv1beta1.WorkSpec{
+				Compressed: true,
+				Workload:   placementv1beta1.WorkloadTemplate{CompressedManifests: staleCompressed},
+			},
+			wantCompressed: true,
+			wantManifests:  largeManifests,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			work := &placementv1beta1.Work{
+				TypeMeta:   metav1.TypeMeta{APIVersion: placementv1beta1.GroupVersion.String(), Kind: "Work"},
+				ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: "fleet-member-test"},
+				Spec:       tt.spec,
+			}
+			raw, err := json.Marshal(work)
+			if err != nil {
+				t.Fatalf("failed to marshal the work: %v", err)
+			}
+			resp := m.Handle(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Name:      work.Name,
+				Namespace: work.Namespace,
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: raw},
+			}})
+			if !resp.Allowed {
+				t.Fatalf("Handle() = %v, want allowed", resp.Result)
+			}
+			patched := raw
+			if len(resp.Patches) > 0 {
+				patch, err := json.Marshal(resp.Patches)
+				if err != nil {
+					t.Fatalf("failed to marshal the patches: %v", err)
+				}
+				decodedPatch, err := jsonpatch.DecodePatch(patch)
+				if err != nil {
+					t.Fatalf("failed to decode the patches: %v", err)
+				}
+				if patched, err = decodedPatch.Apply(raw); err != nil {
+					t.Fatalf("failed to apply the patches: %v", err)
+				}
+			}
+			var got placementv1beta1.Work
+			if err := json.Unmarshal(patched, &got); err != nil {
+				t.Fatalf("failed to unmarshal the patched work: %v", err)
+			}
+
+			if got.Spec.Compressed != tt.wantCompressed {
+				t.Errorf("Handle() compressed = %t, want %t", got.Spec.Compressed, tt.wantCompressed)
+			}
+			if tt.wantCompressed {
+				if len(got.Spec.Workload.Manifests) != 0 {
+					t.Errorf("Handle() kept %d uncompressed manifests, want none", len(got.Spec.Workload.Manifests))
+				}
+				if len(patched) >= len(raw) && tt.spec.Workload.Manifests != nil {
+					t.Errorf("Handle() work size = %d bytes, want less than %d bytes", len(patched), len(raw))
+				}
+			} else if len(got.Spec.Workload.CompressedManifests) != 0 {
+				t.Errorf("Handle() kept the compressed manifests of an uncompressed work")
+			}
+			if err := compression.DecompressWork(&got); err != nil {
+				t.Fatalf("DecompressWork() = %v, want no error", err)
+			}
+			// the manifests are re-serialized by the webhook so they are compared as objects.
+			if diff := cmp.Diff(manifestObjects(t, tt.wantManifests), manifestObjects(t, got.Spec.Workload.Manifests)); diff != "" {
+				t.Errorf("Handle() manifests mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
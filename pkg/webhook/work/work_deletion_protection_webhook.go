@@ -0,0 +1,74 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+)
+
+const (
+	// DeletionProtectedLabel is the label which protects a work from being deleted when it is set to "true".
+	DeletionProtectedLabel = "fleet.azure.com/deletion-protected"
+	// DeletionConfirmedAnnotation is the annotation which confirms the deletion of a protected work when it is set
+	// to "true". It must be added to the work before the work is deleted.
+	DeletionConfirmedAnnotation = "fleet.azure.com/deletion-confirmed"
+
+	// deletionProtectionEventSource is the source of the events recorded for the blocked deletions.
+	deletionProtectionEventSource = "work-deletion-protection"
+	// workDeletionBlockedReason is the reason of the event recorded when the deletion of a protected work is blocked.
+	workDeletionBlockedReason = "DeletionBlocked"
+
+	deletionDeniedFormat = "Work %s/%s is protected by the label %s=true, set the annotation %s=true on the work to confirm its deletion"
+)
+
+var (
+	// DeletionProtectionPath is the webhook service path which admission requests are routed to for protecting the
+	// Work resources from deletion.
+	DeletionProtectionPath = fmt.Sprintf(utils.ValidationPathFmt, placementv1beta1.GroupVersion.Group, placementv1beta1.GroupVersion.Version, "work-deletionprotection")
+)
+
+type workDeletionProtector struct {
+	decoder  webhook.AdmissionDecoder
+	recorder record.EventRecorder
+}
+
+// Handle workDeletionProtector denies the deletion of a work labeled as deletion protected unless the work is
+// annotated to confirm the deletion. Every blocked deletion is recorded as an event on the work for auditing.
+func (p *workDeletionProtector) Handle(_ context.Context, req admission.Request) admission.Response {
+	namespacedName := types.NamespacedName{Name: req.Name, Namespace: req.Namespace}
+	if req.Operation != admissionv1.Delete {
+		return admission.Allowed("")
+	}
+	var work placementv1beta1.Work
+	if err := p.decoder.DecodeRaw(req.OldObject, &work); err != nil {
+		klog.ErrorS(err, "Failed to decode the work", "operation", req.Operation, "namespacedName", namespacedName)
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if work.Labels[DeletionProtectedLabel] != "true" {
+		return admission.Allowed("")
+	}
+	if work.Annotations[DeletionConfirmedAnnotation] == "true" {
+		klog.V(2).InfoS("Deletion of the protected work is confirmed", "user", req.UserInfo.Username, "namespacedName", namespacedName)
+		return admission.Allowed("")
+	}
+	klog.InfoS("Blocked the deletion of the protected work", "user", req.UserInfo.Username, "groups", req.UserInfo.Groups, "namespacedName", namespacedName)
+	p.recorder.Eventf(&work, corev1.EventTypeWarning, workDeletionBlockedReason,
+		"Deletion by user %q is blocked as the work is not annotated with %s=true", req.UserInfo.Username, DeletionConfirmedAnnotation)
+	return admission.Denied(fmt.Sprintf(deletionDeniedFormat, req.Namespace, req.Name, DeletionProtectedLabel, DeletionConfirmedAnnotation))
+}
@@ -0,0 +1,97 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func TestWorkDeletionProtectorHandle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	tests := map[string]struct {
+		operation   admissionv1.Operation
+		labels      map[string]string
+		annotations map[string]string
+		wantAllowed bool
+		wantEvent   bool
+	}{
+		"deleting an unprotected work is allowed": {
+			operation:   admissionv1.Delete,
+			wantAllowed: true,
+		},
+		"deleting a protected work without the confirmation is denied": {
+			operation: admissionv1.Delete,
+			labels:    map[string]string{DeletionProtectedLabel: "true"},
+			wantEvent: true,
+		},
+		"deleting a protected work with a false confirmation is denied": {
+			operation:   admissionv1.Delete,
+			labels:      map[string]string{DeletionProtectedLabel: "true"},
+			annotations: map[string]string{DeletionConfirmedAnnotation: "false"},
+			wantEvent:   true,
+		},
+		"deleting a protected work with the confirmation is allowed": {
+			operation:   admissionv1.Delete,
+			labels:      map[string]string{DeletionProtectedLabel: "true"},
+			annotations: map[string]string{DeletionConfirmedAnnotation: "true"},
+			wantAllowed: true,
+		},
+		"deleting a work whose protection is turned off is allowed": {
+			operation:   admissionv1.Delete,
+			labels:      map[string]string{DeletionProtectedLabel: "false"},
+			wantAllowed: true,
+		},
+		"updating a protected work is allowed": {
+			operation:   admissionv1.Update,
+			labels:      map[string]string{DeletionProtectedLabel: "true"},
+			wantAllowed: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			p := &workDeletionProtector{decoder: admission.NewDecoder(scheme), recorder: recorder}
+			work := &placementv1beta1.Work{
+				TypeMeta: metav1.TypeMeta{APIVersion: placementv1beta1.GroupVersion.String(), Kind: "Work"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "work",
+					Namespace:   "fleet-member-test",
+					Labels:      tt.labels,
+					Annotations: tt.annotations,
+				},
+			}
+			raw, err := json.Marshal(work)
+			if err != nil {
+				t.Fatalf("failed to marshal the work: %v", err)
+			}
+			req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Name:      work.Name,
+				Namespace: work.Namespace,
+				Operation: tt.operation,
+				OldObject: runtime.RawExtension{Raw: raw},
+			}}
+			if resp := p.Handle(context.Background(), req); resp.Allowed != tt.wantAllowed {
+				t.Errorf("Handle() allowed = %t, want %t: %v", resp.Allowed, tt.wantAllowed, resp.Result)
+			}
+			if gotEvent := len(recorder.Events) > 0; gotEvent != tt.wantEvent {
+				t.Errorf("Handle() recorded an event = %t, want %t", gotEvent, tt.wantEvent)
+			}
+		})
+	}
+}
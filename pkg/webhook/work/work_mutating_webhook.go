@@ -0,0 +1,125 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+)
+
+const (
+	// CreatedByLabel is the label which records the user who created the work.
+	CreatedByLabel = "fleet.azure.com/created-by"
+	// CreatedAtLabel is the label which records when the work was created.
+	CreatedAtLabel = "fleet.azure.com/created-at"
+	// LastUpdatedByLabel is the label which records the user who last updated the work.
+	LastUpdatedByLabel = "fleet.azure.com/last-updated-by"
+	// LastUpdatedAtLabel is the label which records when the work was last updated.
+	LastUpdatedAtLabel = "fleet.azure.com/last-updated-at"
+
+	// auditTimestampFormat is the format of the audit timestamps, which must be a valid label value.
+	auditTimestampFormat = "20060102T150405Z"
+	// fleetSystemServiceAccountPrefix is the prefix of the user names of the fleet agents, which are not audited.
+	fleetSystemServiceAccountPrefix = "system:serviceaccount:fleet-system:"
+)
+
+var (
+	// MutationPath is the webhook service path which admission requests are routed to for mutating Work resources.
+	MutationPath = fmt.Sprintf(utils.MutationPathFmt, placementv1beta1.GroupVersion.Group, placementv1beta1.GroupVersion.Version, "work")
+
+	// invalidLabelValueChars matches the characters that are not allowed in a label value.
+	invalidLabelValueChars = regexp.MustCompile("[^A-Za-z0-9._-]")
+)
+
+type workAuditLabeler struct {
+	decoder webhook.AdmissionDecoder
+	now     func() time.Time
+}
+
+// Handle workAuditLabeler labels a work with the user who created it on create and the user who last updated its spec
+// on update. The works changed by the fleet agents are not labeled. The audit labels are carried over from the old
+// object on update so that they cannot be changed or removed, and the last updated labels are only stamped anew when
+// the spec of the work changes.
+func (m *workAuditLabeler) Handle(_ context.Context, req admission.Request) admission.Response {
+	namespacedName := types.NamespacedName{Name: req.Name, Namespace: req.Namespace}
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+	if strings.HasPrefix(req.UserInfo.Username, fleetSystemServiceAccountPrefix) {
+		klog.V(3).InfoS("Skip labeling the work changed by a fleet agent", "operation", req.Operation, "namespacedName", namespacedName, "user", req.UserInfo.Username)
+		return admission.Allowed("")
+	}
+	var work unstructured.Unstructured
+	if err := m.decoder.Decode(req, &work); err != nil {
+		klog.ErrorS(err, "Failed to decode the work", "operation", req.Operation, "namespacedName", namespacedName)
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	labels := work.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	user := toLabelValue(req.UserInfo.Username)
+	timestamp := m.now().UTC().Format(auditTimestampFormat)
+	switch req.Operation {
+	case admissionv1.Create:
+		labels[CreatedByLabel] = user
+		labels[CreatedAtLabel] = timestamp
+	case admissionv1.Update:
+		var oldWork unstructured.Unstructured
+		if err := m.decoder.DecodeRaw(req.OldObject, &oldWork); err != nil {
+			klog.ErrorS(err, "Failed to decode the old work", "operation", req.Operation, "namespacedName", namespacedName)
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		for _, key := range []string{CreatedByLabel, CreatedAtLabel, LastUpdatedByLabel, LastUpdatedAtLabel} {
+			if value, ok := oldWork.GetLabels()[key]; ok {
+				labels[key] = value
+			} else {
+				delete(labels, key)
+			}
+		}
+		if !equality.Semantic.DeepEqual(work.Object["spec"], oldWork.Object["spec"]) {
+			labels[LastUpdatedByLabel] = user
+			labels[LastUpdatedAtLabel] = timestamp
+		}
+	}
+	work.SetLabels(labels)
+
+	marshaled, err := json.Marshal(work.Object)
+	if err != nil {
+		klog.ErrorS(err, "Failed to marshal the work", "operation", req.Operation, "namespacedName", namespacedName)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	klog.V(2).InfoS("Labeled the work with its audit information", "operation", req.Operation, "namespacedName", namespacedName, "user", req.UserInfo.Username)
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
+
+// toLabelValue converts a user name into a valid label value by replacing the disallowed characters, e.g. the colons
+// in the service account user names, and truncating it to the maximum label value length.
+func toLabelValue(name string) string {
+	value := invalidLabelValueChars.ReplaceAllString(name, "_")
+	if len(value) > validation.LabelValueMaxLength {
+		value = value[:validation.LabelValueMaxLength]
+	}
+	return strings.Trim(value, "._-")
+}
@@ -0,0 +1,174 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func TestWorkAuditLabelerHandle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	now := time.Date(2024, 6, 1, 12, 30, 0, 0, time.UTC)
+	m := &workAuditLabeler{decoder: admission.NewDecoder(scheme), now: func() time.Time { return now }}
+
+	// handle sends the work through the webhook as the user and returns the mutated work.
+	handle := func(operation admissionv1.Operation, user string, work, oldWork *placementv1beta1.Work) *placementv1beta1.Work {
+		t.Helper()
+		raw, err := json.Marshal(work)
+		if err != nil {
+			t.Fatalf("failed to marshal the work: %v", err)
+		}
+		req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Name:      work.Name,
+			Namespace: work.Namespace,
+			Operation: operation,
+			UserInfo:  authenticationv1.UserInfo{Username: user},
+			Object:    runtime.RawExtension{Raw: raw},
+		}}
+		if oldWork != nil {
+			oldRaw, err := json.Marshal(oldWork)
+			if err != nil {
+				t.Fatalf("failed to marshal the old work: %v", err)
+			}
+			req.OldObject = runtime.RawExtension{Raw: oldRaw}
+		}
+		resp := m.Handle(context.Background(), req)
+		if !resp.Allowed {
+			t.Fatalf("Handle() = %v, want allowed", resp.Result)
+		}
+		if len(resp.Patches) == 0 {
+			return work.DeepCopy()
+		}
+		patch, err := json.Marshal(resp.Patches)
+		if err != nil {
+			t.Fatalf("failed to marshal the patches: %v", err)
+		}
+		decodedPatch, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			t.Fatalf("failed to decode the patches: %v", err)
+		}
+		patched, err := decodedPatch.Apply(raw)
+		if err != nil {
+			t.Fatalf("failed to apply the patches: %v", err)
+		}
+		var got placementv1beta1.Work
+		if err := json.Unmarshal(patched, &got); err != nil {
+			t.Fatalf("failed to unmarshal the patched work: %v", err)
+		}
+		return &got
+	}
+
+	work := &placementv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-work",
+			Namespace: "fleet-member-test",
+			Labels:    map[string]string{"app": "test"},
+		},
+	}
+
+	created := handle(admissionv1.Create, "system:admin", work, nil)
+	wantLabels := map[string]string{
+		"app":          "test",
+		CreatedByLabel: "system_admin",
+		CreatedAtLabel: "20240601T123000Z",
+	}
+	if diff := cmp.Diff(wantLabels, created.Labels); diff != "" {
+		t.Errorf("Handle() create labels mismatch (-want +got):\n%s", diff)
+	}
+
+	now = now.Add(time.Hour)
+	update := created.DeepCopy()
+	update.Labels[CreatedByLabel] = "someone-else"
+	delete(update.Labels, CreatedAtLabel)
+	update.Spec.PropagateAnnotations = []string{"team"}
+	updated := handle(admissionv1.Update, "alice@example.com", update, created)
+	wantLabels = map[string]string{
+		"app":              "test",
+		CreatedByLabel:     "system_admin",
+		CreatedAtLabel:     "20240601T123000Z",
+		LastUpdatedByLabel: "alice_example.com",
+		LastUpdatedAtLabel: "20240601T133000Z",
+	}
+	if diff := cmp.Diff(wantLabels, updated.Labels); diff != "" {
+		t.Errorf("Handle() spec update labels mismatch (-want +got):\n%s", diff)
+	}
+
+	// the last updated labels are kept when the spec does not change.
+	now = now.Add(time.Hour)
+	metadataUpdate := updated.DeepCopy()
+	metadataUpdate.Labels["app"] = "renamed"
+	metadataUpdate.Labels[LastUpdatedByLabel] = "someone-else"
+	metadataUpdated := handle(admissionv1.Update, "bob", metadataUpdate, updated)
+	wantLabels["app"] = "renamed"
+	if diff := cmp.Diff(wantLabels, metadataUpdated.Labels); diff != "" {
+		t.Errorf("Handle() metadata update labels mismatch (-want +got):\n%s", diff)
+	}
+
+	// the changes made by the fleet agents are not labeled.
+	agentUpdate := metadataUpdated.DeepCopy()
+	agentUpdate.Spec.PropagateAnnotations = nil
+	agentUpdated := handle(admissionv1.Update, "system:serviceaccount:fleet-system:hub-agent-sa", agentUpdate, metadataUpdated)
+	if diff := cmp.Diff(wantLabels, agentUpdated.Labels); diff != "" {
+		t.Errorf("Handle() fleet agent update labels mismatch (-want +got):\n%s", diff)
+	}
+	agentCreated := handle(admissionv1.Create, "system:serviceaccount:fleet-system:hub-agent-sa", work, nil)
+	if diff := cmp.Diff(work.Labels, agentCreated.Labels); diff != "" {
+		t.Errorf("Handle() fleet agent create labels mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestToLabelValue(t *testing.T) {
+	testCases := map[string]struct {
+		name string
+		want string
+	}{
+		"valid user name": {
+			name: "admin",
+			want: "admin",
+		},
+		"service account user name": {
+			name: "system:serviceaccount:fleet-system:hub-agent-sa",
+			want: "system_serviceaccount_fleet-system_hub-agent-sa",
+		},
+		"user name starting with a disallowed character": {
+			name: ":admin:",
+			want: "admin",
+		},
+		"too long user name": {
+			name: strings.Repeat("a", 100),
+			want: strings.Repeat("a", validation.LabelValueMaxLength),
+		},
+	}
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			got := toLabelValue(tc.name)
+			if got != tc.want {
+				t.Errorf("toLabelValue(%q) = %q, want %q", tc.name, got, tc.want)
+			}
+			if errs := validation.IsValidLabelValue(got); len(errs) != 0 {
+				t.Errorf("toLabelValue(%q) = %q is not a valid label value: %v", tc.name, got, errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/compression"
+)
+
+const (
+	// MaxResourcesPerMemberClusterEnvName is the environment variable which sets the maximum number of resources the
+	// works of a member cluster can apply. The limit is not enforced if it is unset or 0.
+	MaxResourcesPerMemberClusterEnvName = "MAX_RESOURCES_PER_MEMBER_CLUSTER"
+
+	resourceCountDeniedFormat = "Work %s/%s is disallowed as the works in the namespace would apply %d resources to the member cluster, which exceeds the limit of %d resources"
+)
+
+var (
+	// ResourceCountValidationPath is the webhook service path which admission requests are routed to for validating
+	// the number of resources the Work resources apply to a member cluster.
+	ResourceCountValidationPath = fmt.Sprintf(utils.ValidationPathFmt, placementv1beta1.GroupVersion.Group, placementv1beta1.GroupVersion.Version, "work-resourcecount")
+)
+
+// maxResourcesPerMemberCluster returns the maximum number of resources the works of a member cluster can apply.
+func maxResourcesPerMemberCluster() (int, error) {
+	value := os.Getenv(MaxResourcesPerMemberClusterEnvName)
+	if value == "" {
+		return 0, nil
+	}
+	maxResources, err := strconv.Atoi(value)
+	if err != nil || maxResources < 0 {
+		return 0, fmt.Errorf("invalid maximum number of resources per member cluster %q in %s", value, MaxResourcesPerMemberClusterEnvName)
+	}
+	return maxResources, nil
+}
+
+type resourceCountLimitAdmission struct {
+	client       client.Reader
+	decoder      webhook.AdmissionDecoder
+	maxResources int
+}
+
+// Handle resourceCountLimitAdmission denies a work if the works in its namespace would apply more resources to the
+// member cluster than the limit. Every manifest of a work is a resource the AppliedWork of the work tracks on the
+// member cluster. An updated work replaces its resources instead of adding to them, and a deleted work is always
+// allowed as it only lowers the count.
+func (v *resourceCountLimitAdmission) Handle(ctx context.Context, req admission.Request) admission.Response {
+	namespacedName := types.NamespacedName{Name: req.Name, Namespace: req.Namespace}
+	if v.maxResources <= 0 || (req.Operation != admissionv1.Create && req.Operation != admissionv1.Update) {
+		return admission.Allowed("")
+	}
+	var work placementv1beta1.Work
+	if err := v.decoder.Decode(req, &work); err != nil {
+		klog.ErrorS(err, "Failed to decode the work", "operation", req.Operation, "namespacedName", namespacedName)
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	total, err := workResourceCount(&work)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	var works placementv1beta1.WorkList
+	if err := v.client.List(ctx, &works, client.InNamespace(req.Namespace)); err != nil {
+		klog.ErrorS(err, "Failed to list the works of the member cluster", "namespace", req.Namespace)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	for i := range works.Items {
+		// the resources of the work being updated are replaced by the new ones.
+		if works.Items[i].Name == req.Name {
+			continue
+		}
+		count, err := workResourceCount(&works.Items[i])
+		if err != nil {
+			klog.ErrorS(err, "Failed to count the resources of the work", "work", klog.KObj(&works.Items[i]))
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		total += count
+	}
+	if total > v.maxResources {
+		klog.V(2).InfoS("Work would exceed the resource count limit of the member cluster", "operation", req.Operation,
+			"namespacedName", namespacedName, "resourceCount", total, "maxResources", v.maxResources)
+		return admission.Denied(fmt.Sprintf(resourceCountDeniedFormat, req.Namespace, req.Name, total, v.maxResources))
+	}
+	return admission.Allowed("")
+}
+
+// workResourceCount returns the number of resources the work applies to the member cluster.
+func workResourceCount(work *placementv1beta1.Work) (int, error) {
+	if err := compression.DecompressWork(work); err != nil {
+		return 0, err
+	}
+	return len(work.Spec.Workload.Manifests), nil
+}
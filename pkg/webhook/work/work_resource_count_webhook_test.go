@@ -0,0 +1,104 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/compression"
+)
+
+// resourceCountTestWork returns a work in the member cluster namespace with the given number of manifests.
+func resourceCountTestWork(name string, manifestCount int) *placementv1beta1.Work {
+	return &placementv1beta1.Work{
+		TypeMeta:   metav1.TypeMeta{APIVersion: placementv1beta1.GroupVersion.String(), Kind: "Work"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "fleet-member-test"},
+		Spec: placementv1beta1.WorkSpec{
+			Workload: placementv1beta1.WorkloadTemplate{Manifests: compressionTestManifests(manifestCount, 16)},
+		},
+	}
+}
+
+func TestResourceCountLimitAdmissionHandle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	compressedWork := resourceCountTestWork("compressed-work", 0)
+	compressed, err := compression.CompressManifests(compressionTestManifests(2, 16))
+	if err != nil {
+		t.Fatalf("CompressManifests() = %v, want no error", err)
+	}
+	compressedWork.Spec.Compressed = true
+	compressedWork.Spec.Workload.CompressedManifests = compressed
+	existingWorks := []client.Object{resourceCountTestWork("work-1", 4), resourceCountTestWork("work-2", 3), compressedWork}
+
+	tests := map[string]struct {
+		operation   admissionv1.Operation
+		work        *placementv1beta1.Work
+		wantAllowed bool
+	}{
+		"new work reaching the exact limit is allowed": {
+			operation:   admissionv1.Create,
+			work:        resourceCountTestWork("new-work", 1),
+			wantAllowed: true,
+		},
+		"new work exceeding the limit is denied": {
+			operation: admissionv1.Create,
+			work:      resourceCountTestWork("new-work", 2),
+		},
+		"updated work replacing its resources within the limit is allowed": {
+			operation:   admissionv1.Update,
+			work:        resourceCountTestWork("work-1", 5),
+			wantAllowed: true,
+		},
+		"updated work exceeding the limit is denied": {
+			operation: admissionv1.Update,
+			work:      resourceCountTestWork("work-1", 6),
+		},
+		"deleted work is allowed": {
+			operation:   admissionv1.Delete,
+			work:        resourceCountTestWork("work-1", 4),
+			wantAllowed: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			v := &resourceCountLimitAdmission{
+				client:       fake.NewClientBuilder().WithScheme(scheme).WithObjects(existingWorks...).Build(),
+				decoder:      admission.NewDecoder(scheme),
+				maxResources: 10,
+			}
+			raw, err := json.Marshal(tt.work)
+			if err != nil {
+				t.Fatalf("failed to marshal the work: %v", err)
+			}
+			req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Name:      tt.work.Name,
+				Namespace: tt.work.Namespace,
+				Operation: tt.operation,
+			}}
+			if tt.operation == admissionv1.Delete {
+				req.OldObject = runtime.RawExtension{Raw: raw}
+			} else {
+				req.Object = runtime.RawExtension{Raw: raw}
+			}
+			if resp := v.Handle(context.Background(), req); resp.Allowed != tt.wantAllowed {
+				t.Errorf("Handle() allowed = %t, want %t: %v", resp.Allowed, tt.wantAllowed, resp.Result)
+			}
+		})
+	}
+}
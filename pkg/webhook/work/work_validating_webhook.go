@@ -0,0 +1,93 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+)
+
+const (
+	deniedWorkResource  = "Work creation is disallowed in a non-reserved namespace"
+	allowedWorkResource = "Work creation is allowed in the reserved namespace"
+	workDeniedFormat    = "Work %s/%s creation is disallowed as works can only be created in the member cluster reserved namespaces matching %q"
+)
+
+var (
+	// ValidationPath is the webhook service path which admission requests are routed to for validating Work resources.
+	ValidationPath = fmt.Sprintf(utils.ValidationPathFmt, placementv1beta1.GroupVersion.Group, placementv1beta1.GroupVersion.Version, "work")
+
+	// defaultNamespacePattern matches the member cluster reserved namespaces.
+	defaultNamespacePattern = fmt.Sprintf("^"+regexp.QuoteMeta(utils.NamespaceNameFormat)+"$", ".+")
+
+	// forbiddenNamespaces are the namespaces where works are always rejected regardless of the namespace pattern.
+	forbiddenNamespaces = map[string]bool{
+		"default":     true,
+		"kube-system": true,
+		"kube-public": true,
+	}
+)
+
+// Add registers the validating and the mutating webhooks for the Work objects. The works can only be created in the
+// namespaces matching the pattern, which defaults to the member cluster reserved namespaces if empty.
+func Add(mgr manager.Manager, pattern string) error {
+	if pattern == "" {
+		pattern = defaultNamespacePattern
+	}
+	namespacePattern, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid work namespace pattern %q: %w", pattern, err)
+	}
+	maxResources, err := maxResourcesPerMemberCluster()
+	if err != nil {
+		return err
+	}
+	hookServer := mgr.GetWebhookServer()
+	hookServer.Register(ValidationPath, &webhook.Admission{Handler: &workValidator{namespacePattern: namespacePattern}})
+	hookServer.Register(MutationPath, &webhook.Admission{Handler: &workAuditLabeler{decoder: admission.NewDecoder(mgr.GetScheme()), now: time.Now}})
+	hookServer.Register(ResourceCountValidationPath, &webhook.Admission{Handler: &resourceCountLimitAdmission{
+		client:       mgr.GetClient(),
+		decoder:      admission.NewDecoder(mgr.GetScheme()),
+		maxResources: maxResources,
+	}})
+	hookServer.Register(CompressionPath, &webhook.Admission{Handler: &workManifestCompressor{decoder: admission.NewDecoder(mgr.GetScheme()), threshold: CompressionThresholdBytes}})
+	hookServer.Register(BinaryDataValidationPath, &webhook.Admission{Handler: &binaryDataValidator{decoder: admission.NewDecoder(mgr.GetScheme())}})
+	hookServer.Register(DeletionProtectionPath, &webhook.Admission{Handler: &workDeletionProtector{
+		decoder:  admission.NewDecoder(mgr.GetScheme()),
+		recorder: mgr.GetEventRecorderFor(deletionProtectionEventSource),
+	}})
+	return nil
+}
+
+type workValidator struct {
+	namespacePattern *regexp.Regexp
+}
+
+// Handle workValidator denies a work if it is not created in a member cluster reserved namespace.
+func (v *workValidator) Handle(_ context.Context, req admission.Request) admission.Response {
+	namespacedName := types.NamespacedName{Name: req.Name, Namespace: req.Namespace}
+	if req.Operation == admissionv1.Create {
+		klog.V(2).InfoS("handling work resource", "operation", req.Operation, "subResource", req.SubResource, "namespacedName", namespacedName)
+		if forbiddenNamespaces[req.Namespace] || !v.namespacePattern.MatchString(req.Namespace) {
+			klog.V(2).InfoS(deniedWorkResource, "user", req.UserInfo.Username, "groups", req.UserInfo.Groups, "operation", req.Operation, "GVK", req.RequestKind, "subResource", req.SubResource, "namespacedName", namespacedName)
+			return admission.Denied(fmt.Sprintf(workDeniedFormat, req.Namespace, req.Name, v.namespacePattern.String()))
+		}
+	}
+	klog.V(3).InfoS(allowedWorkResource, "user", req.UserInfo.Username, "groups", req.UserInfo.Groups, "operation", req.Operation, "GVK", req.RequestKind, "subResource", req.SubResource, "namespacedName", namespacedName)
+	return admission.Allowed("")
+}
@@ -0,0 +1,124 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"go.goms.io/fleet/pkg/utils"
+)
+
+func TestHandle(t *testing.T) {
+	testCases := map[string]struct {
+		namespacePattern string
+		req              admission.Request
+		wantResponse     admission.Response
+	}{
+		"allow work creation in the reserved namespace": {
+			namespacePattern: defaultNamespacePattern,
+			req: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:      "test-work",
+					Namespace: "fleet-member-test",
+					Operation: admissionv1.Create,
+				},
+			},
+			wantResponse: admission.Allowed(""),
+		},
+		"deny work creation in a non-reserved namespace": {
+			namespacePattern: defaultNamespacePattern,
+			req: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:      "test-work",
+					Namespace: "test-ns",
+					Operation: admissionv1.Create,
+				},
+			},
+			wantResponse: admission.Denied(fmt.Sprintf(workDeniedFormat, "test-ns", "test-work", defaultNamespacePattern)),
+		},
+		"deny work creation in the reserved namespace prefix only": {
+			namespacePattern: defaultNamespacePattern,
+			req: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:      "test-work",
+					Namespace: "fleet-member-",
+					Operation: admissionv1.Create,
+				},
+			},
+			wantResponse: admission.Denied(fmt.Sprintf(workDeniedFormat, "fleet-member-", "test-work", defaultNamespacePattern)),
+		},
+		"allow work creation in a namespace matching the custom pattern": {
+			namespacePattern: "^team-.+$",
+			req: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:      "test-work",
+					Namespace: "team-a",
+					Operation: admissionv1.Create,
+				},
+			},
+			wantResponse: admission.Allowed(""),
+		},
+		"deny work creation in the default namespace even if the pattern matches": {
+			namespacePattern: ".*",
+			req: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:      "test-work",
+					Namespace: "default",
+					Operation: admissionv1.Create,
+				},
+			},
+			wantResponse: admission.Denied(fmt.Sprintf(workDeniedFormat, "default", "test-work", ".*")),
+		},
+		"deny work creation in the kube-system namespace even if the pattern matches": {
+			namespacePattern: ".*",
+			req: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:      "test-work",
+					Namespace: "kube-system",
+					Operation: admissionv1.Create,
+				},
+			},
+			wantResponse: admission.Denied(fmt.Sprintf(workDeniedFormat, "kube-system", "test-work", ".*")),
+		},
+		"deny work creation in the kube-public namespace even if the pattern matches": {
+			namespacePattern: ".*",
+			req: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:      "test-work",
+					Namespace: "kube-public",
+					Operation: admissionv1.Create,
+				},
+			},
+			wantResponse: admission.Denied(fmt.Sprintf(workDeniedFormat, "kube-public", "test-work", ".*")),
+		},
+		"allow work deletion in a non-reserved namespace": {
+			namespacePattern: defaultNamespacePattern,
+			req: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Name:      "test-work",
+					Namespace: "test-ns",
+					Operation: admissionv1.Delete,
+				},
+			},
+			wantResponse: admission.Allowed(""),
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			v := workValidator{namespacePattern: regexp.MustCompile(testCase.namespacePattern)}
+			gotResult := v.Handle(context.Background(), testCase.req)
+			assert.Equal(t, testCase.wantResponse, gotResult, utils.TestCaseMsg, testName)
+		})
+	}
+}
@@ -0,0 +1,232 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package workdelta serves the deltas of the Works for the delta sync protocol of the deltasync package, so that the
+// member clusters with a low bandwidth connection to the hub cluster fetch only the manifests of a Work which changed
+// since the version they fetched last.
+package workdelta
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/deltasync"
+	"go.goms.io/fleet/pkg/utils/resource"
+	"go.goms.io/fleet/pkg/utils/workintegrity"
+)
+
+const (
+	// MaxVersionsPerWork is the number of the latest versions of a work kept to compute its deltas since; the delta
+	// since an older version is unavailable and the member falls back to fetching the full work.
+	MaxVersionsPerWork = 10
+
+	shutdownTimeout = 5 * time.Second
+)
+
+var (
+	// DiffPathPattern is the pattern of the path the deltas are served at, which matches deltasync.DiffPath.
+	DiffPathPattern = "GET /apis/workdelta.kubernetes-fleet.io/v1beta1/namespaces/{namespace}/works/{name}/diff"
+)
+
+// workVersion is a version of a work, identified by the checksums of its manifests.
+type workVersion struct {
+	resourceVersion string
+	checksums       []string
+}
+
+// Server serves the deltas of the works.
+type Server struct {
+	// Addr is the TCP address the server listens on.
+	Addr string
+	// Cache provides the informer the versions of the works are observed with.
+	Cache cache.Cache
+	// Reader reads the current works.
+	Reader client.Reader
+
+	mu sync.Mutex
+	// versions are the latest versions of the works, oldest first.
+	versions map[types.NamespacedName][]workVersion
+}
+
+// NeedLeaderElection implements the LeaderElectionRunnable interface so that every replica serves the deltas.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves the deltas until the context is cancelled.
+func (s *Server) Start(ctx context.Context) error {
+	informer, err := s.Cache.GetInformer(ctx, &fleetv1beta1.Work{})
+	if err != nil {
+		return fmt.Errorf("failed to get the work informer: %w", err)
+	}
+	if _, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { s.observe(obj) },
+		UpdateFunc: func(_, obj interface{}) { s.observe(obj) },
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if work, ok := obj.(*fleetv1beta1.Work); ok {
+				s.forget(types.NamespacedName{Namespace: work.Namespace, Name: work.Name})
+			}
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to watch the works: %w", err)
+	}
+	server := &http.Server{Addr: s.Addr, Handler: s.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			klog.ErrorS(err, "Failed to shut down the work delta server")
+		}
+	}()
+	klog.InfoS("Starting the work delta server", "address", s.Addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve the work deltas: %w", err)
+	}
+	return nil
+}
+
+// Handler returns the handler of the deltas.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(DiffPathPattern, s.serveDelta)
+	return mux
+}
+
+// serveDelta responds with the delta of the work since the version in the since query parameter. It responds with
+// 410 Gone if the version is not one of the latest versions of the work, or the work is compressed, so that the
+// member falls back to fetching the full work.
+func (s *Server) serveDelta(w http.ResponseWriter, req *http.Request) {
+	key := types.NamespacedName{Namespace: req.PathValue("namespace"), Name: req.PathValue("name")}
+	since := req.URL.Query().Get("since")
+	if since == "" {
+		http.Error(w, "the since query parameter is required", http.StatusBadRequest)
+		return
+	}
+	var work fleetv1beta1.Work
+	if err := s.Reader.Get(req.Context(), key, &work); err != nil {
+		klog.ErrorS(err, "Failed to get the work to serve its delta", "work", key)
+		writeAPIError(w, err)
+		return
+	}
+	if work.Spec.Compressed {
+		http.Error(w, fmt.Sprintf("the delta of the compressed work %s is unavailable", key), http.StatusGone)
+		return
+	}
+	checksums, err := workintegrity.ManifestChecksums(work.Spec.Workload.Manifests)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.record(key, workVersion{resourceVersion: work.ResourceVersion, checksums: checksums})
+	sinceChecksums, found := s.checksumsOf(key, since)
+	if !found {
+		http.Error(w, fmt.Sprintf("the delta of the work %s since version %s is unavailable", key, since), http.StatusGone)
+		return
+	}
+	hash, err := resource.HashOf(work.Spec)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	delta := deltasync.WorkDelta{
+		ResourceVersion: work.ResourceVersion,
+		SpecHash:        hash,
+		Spec:            *work.Spec.DeepCopy(),
+		ManifestCount:   len(work.Spec.Workload.Manifests),
+	}
+	delta.Spec.Workload.Manifests = nil
+	for i, manifest := range work.Spec.Workload.Manifests {
+		if i >= len(sinceChecksums) || sinceChecksums[i] != checksums[i] {
+			delta.ChangedManifests = append(delta.ChangedManifests, deltasync.OrdinalManifest{Ordinal: i, Manifest: manifest})
+		}
+	}
+	klog.V(2).InfoS("Serving the delta of the work", "work", key, "since", since,
+		"resourceVersion", work.ResourceVersion, "changedManifests", len(delta.ChangedManifests))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(delta); err != nil {
+		klog.ErrorS(err, "Failed to write the delta of the work", "work", key)
+	}
+}
+
+// observe records the version of the work observed by the informer.
+func (s *Server) observe(obj interface{}) {
+	work, ok := obj.(*fleetv1beta1.Work)
+	if !ok || work.Spec.Compressed {
+		return
+	}
+	checksums, err := workintegrity.ManifestChecksums(work.Spec.Workload.Manifests)
+	if err != nil {
+		klog.V(2).InfoS("Failed to compute the checksums of the work manifests", "work", klog.KObj(work), "err", err)
+		return
+	}
+	s.record(types.NamespacedName{Namespace: work.Namespace, Name: work.Name},
+		workVersion{resourceVersion: work.ResourceVersion, checksums: checksums})
+}
+
+// record keeps the version of the work, dropping the oldest one beyond MaxVersionsPerWork.
+func (s *Server) record(key types.NamespacedName, version workVersion) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.versions == nil {
+		s.versions = make(map[types.NamespacedName][]workVersion)
+	}
+	versions := s.versions[key]
+	for _, v := range versions {
+		if v.resourceVersion == version.resourceVersion {
+			return
+		}
+	}
+	versions = append(versions, version)
+	if len(versions) > MaxVersionsPerWork {
+		versions = versions[len(versions)-MaxVersionsPerWork:]
+	}
+	s.versions[key] = versions
+}
+
+// checksumsOf returns the manifest checksums of the version of the work if it is kept.
+func (s *Server) checksumsOf(key types.NamespacedName, resourceVersion string) ([]string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, v := range s.versions[key] {
+		if v.resourceVersion == resourceVersion {
+			return v.checksums, true
+		}
+	}
+	return nil, false
+}
+
+// forget drops the versions of the work once it is deleted.
+func (s *Server) forget(key types.NamespacedName) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.versions, key)
+}
+
+// writeAPIError responds with the status code of the API server error.
+func writeAPIError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	var statusErr apierrors.APIStatus
+	if errors.As(err, &statusErr) {
+		code = int(statusErr.Status().Code)
+	}
+	http.Error(w, err.Error(), code)
+}
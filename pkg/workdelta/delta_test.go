@@ -0,0 +1,160 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package workdelta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/deltasync"
+)
+
+const manifestCount = 50
+
+var workKey = types.NamespacedName{Name: "test-work", Namespace: "fleet-member-test"}
+
+func configMapManifest(i int, data string) fleetv1beta1.Manifest {
+	raw := fmt.Sprintf(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-%d","namespace":"default"},"data":{"key":%q}}`, i, data)
+	return fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: []byte(raw)}}
+}
+
+// setupServer returns the delta server of the hub with a work of 50 manifests and a syncer of a member using it.
+func setupServer(t *testing.T) (*Server, client.Client, *deltasync.Syncer) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the fleet scheme: %v", err)
+	}
+	work := &fleetv1beta1.Work{ObjectMeta: metav1.ObjectMeta{Name: workKey.Name, Namespace: workKey.Namespace}}
+	for i := 0; i < manifestCount; i++ {
+		work.Spec.Workload.Manifests = append(work.Spec.Workload.Manifests, configMapManifest(i, "v1"))
+	}
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(work).Build()
+	s := &Server{Reader: hubClient}
+	server := httptest.NewServer(s.Handler())
+	t.Cleanup(server.Close)
+	restClient := discovery.NewDiscoveryClientForConfigOrDie(&rest.Config{Host: server.URL}).RESTClient()
+	return s, hubClient, deltasync.NewSyncer(restClient, hubClient)
+}
+
+// updateManifest changes the data of the manifest with the ordinal on the hub and lets the server observe it.
+func updateManifest(t *testing.T, s *Server, hubClient client.Client, ordinal int) fleetv1beta1.WorkSpec {
+	t.Helper()
+	var work fleetv1beta1.Work
+	if err := hubClient.Get(context.Background(), workKey, &work); err != nil {
+		t.Fatalf("failed to get the work: %v", err)
+	}
+	work.Spec.Workload.Manifests[ordinal] = configMapManifest(ordinal, "v2")
+	if err := hubClient.Update(context.Background(), &work); err != nil {
+		t.Fatalf("failed to update the work: %v", err)
+	}
+	s.observe(&work)
+	return work.Spec
+}
+
+func TestServeDelta(t *testing.T) {
+	ctx := context.Background()
+	s, hubClient, syncer := setupServer(t)
+	var work fleetv1beta1.Work
+	if err := hubClient.Get(ctx, workKey, &work); err != nil {
+		t.Fatalf("failed to get the work: %v", err)
+	}
+	s.observe(&work)
+
+	full, err := syncer.Sync(ctx, workKey)
+	if err != nil {
+		t.Fatalf("Sync() = %v, want no error", err)
+	}
+	if full.Delta {
+		t.Errorf("Sync() delta = true, want the full work fetched the first time")
+	}
+
+	// only the changed manifest is served once one of the fifty manifests changes.
+	wantSpec := updateManifest(t, s, hubClient, 7)
+	delta, err := syncer.Sync(ctx, workKey)
+	if err != nil {
+		t.Fatalf("Sync() = %v, want no error", err)
+	}
+	if !delta.Delta {
+		t.Errorf("Sync() delta = false, want only the delta fetched")
+	}
+	if diff := cmp.Diff(wantSpec, *delta.Spec); diff != "" {
+		t.Errorf("Sync() spec mismatch (-want, +got):\n%s", diff)
+	}
+	if want := len(configMapManifest(7, "v2").Raw); delta.ManifestBytes != want {
+		t.Errorf("Sync() fetched %d manifest bytes, want the %d bytes of the changed manifest", delta.ManifestBytes, want)
+	}
+
+	// nothing is served again for an unchanged work.
+	unchanged, err := syncer.Sync(ctx, workKey)
+	if err != nil {
+		t.Fatalf("Sync() = %v, want no error", err)
+	}
+	if !unchanged.Delta || unchanged.ManifestBytes != 0 {
+		t.Errorf("Sync() = delta %t with %d manifest bytes, want an empty delta", unchanged.Delta, unchanged.ManifestBytes)
+	}
+}
+
+func TestServeDeltaUnavailable(t *testing.T) {
+	ctx := context.Background()
+	s, hubClient, syncer := setupServer(t)
+	// the server never observed the version the member fetched, e.g. it restarted since.
+	if _, err := syncer.Sync(ctx, workKey); err != nil {
+		t.Fatalf("Sync() = %v, want no error", err)
+	}
+	s.forget(workKey)
+	wantSpec := updateManifest(t, s, hubClient, 7)
+	result, err := syncer.Sync(ctx, workKey)
+	if err != nil {
+		t.Fatalf("Sync() = %v, want no error", err)
+	}
+	if result.Delta {
+		t.Errorf("Sync() delta = true, want the fallback to the full sync")
+	}
+	if diff := cmp.Diff(wantSpec, *result.Spec); diff != "" {
+		t.Errorf("Sync() spec mismatch (-want, +got):\n%s", diff)
+	}
+
+	tests := map[string]struct {
+		path     string
+		wantCode int
+	}{
+		"missing since": {
+			path:     fmt.Sprintf("/apis/workdelta.kubernetes-fleet.io/v1beta1/namespaces/%s/works/%s/diff", workKey.Namespace, workKey.Name),
+			wantCode: http.StatusBadRequest,
+		},
+		"unknown version": {
+			path:     deltasync.DiffPath(workKey) + "?since=1",
+			wantCode: http.StatusGone,
+		},
+		"missing work": {
+			path:     deltasync.DiffPath(types.NamespacedName{Namespace: workKey.Namespace, Name: "missing"}) + "?since=1",
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			s.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if recorder.Code != tt.wantCode {
+				t.Errorf("serveDelta() code = %d, want %d: %s", recorder.Code, tt.wantCode, recorder.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package workdiff serves the diffs of the manifests of a Work against their resources in the member cluster as
+// unified diffs, so that the operators do not need to read them out of the raw status of the Work.
+package workdiff
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	// KindWorkDiffReport is the kind of the diff report of a work.
+	KindWorkDiffReport = "WorkDiffReport"
+
+	// colorParam is the query parameter which turns off the colors of the diffs when it is false.
+	colorParam = "color"
+
+	colorReset = "\x1b[0m"
+	colorBold  = "\x1b[1m"
+	colorRed   = "\x1b[31m"
+	colorGreen = "\x1b[32m"
+	colorCyan  = "\x1b[36m"
+
+	shutdownTimeout = 5 * time.Second
+)
+
+var (
+	// DiffPathPattern is the pattern of the path the diff reports are served at.
+	DiffPathPattern = fmt.Sprintf("GET /apis/%s/%s/namespaces/{namespace}/works/{name}/diff",
+		fleetv1beta1.GroupVersion.Group, fleetv1beta1.GroupVersion.Version)
+)
+
+// WorkDiffReport is the diffs of the manifests of a work against their resources in the member cluster. It is
+// computed on request and never stored.
+type WorkDiffReport struct {
+	metav1.TypeMeta `json:",inline"`
+	// Namespace is the namespace of the work.
+	Namespace string `json:"namespace"`
+	// Name is the name of the work.
+	Name string `json:"name"`
+	// Generation is the generation of the work.
+	Generation int64 `json:"generation"`
+	// Manifests are the diffs of the manifests which differ from their resources in the member cluster.
+	Manifests []ManifestDiff `json:"manifests,omitempty"`
+}
+
+// ManifestDiff is the diff of a manifest against its resource in the member cluster.
+type ManifestDiff struct {
+	// Identifier is the identity of the resource of the manifest.
+	Identifier fleetv1beta1.WorkResourceIdentifier `json:"identifier"`
+	// ResourceExistsInMember is true if the resource exists in the member cluster; the whole manifest is the diff
+	// otherwise.
+	ResourceExistsInMember bool `json:"resourceExistsInMember"`
+	// Diff is the unified diff from the resource in the member cluster to the manifest, similar to `git diff`.
+	Diff string `json:"diff"`
+}
+
+// Server serves the diff reports of the works.
+type Server struct {
+	// Addr is the TCP address the server listens on.
+	Addr string
+	// Reader reads the works.
+	Reader client.Reader
+}
+
+// NeedLeaderElection implements the LeaderElectionRunnable interface so that every replica serves the reports.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves the diff reports until the context is cancelled.
+func (s *Server) Start(ctx context.Context) error {
+	server := &http.Server{Addr: s.Addr, Handler: s.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			klog.ErrorS(err, "Failed to shut down the work diff server")
+		}
+	}()
+	klog.InfoS("Starting the work diff server", "address", s.Addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve the work diffs: %w", err)
+	}
+	return nil
+}
+
+// Handler returns the handler of the diff reports.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(DiffPathPattern, s.serveDiff)
+	return mux
+}
+
+// serveDiff responds with the diff report of the work. The diffs come from the latest dry-run of the work, which the
+// member agent performs against the live resources in the member cluster, as the hub agent cannot reach the member
+// clusters itself.
+func (s *Server) serveDiff(w http.ResponseWriter, req *http.Request) {
+	key := types.NamespacedName{Namespace: req.PathValue("namespace"), Name: req.PathValue("name")}
+	color := true
+	if value := req.URL.Query().Get(colorParam); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("the %s query parameter %q is not a boolean", colorParam, value), http.StatusBadRequest)
+			return
+		}
+		color = parsed
+	}
+	var work fleetv1beta1.Work
+	if err := s.Reader.Get(req.Context(), key, &work); err != nil {
+		klog.ErrorS(err, "Failed to get the work to report its diff", "work", key)
+		writeAPIError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(BuildReport(&work, color)); err != nil {
+		klog.ErrorS(err, "Failed to write the diff report of the work", "work", key)
+	}
+}
+
+// BuildReport returns the diff report of the work from the results of its latest dry-run, with the diffs colored
+// with the ANSI escape codes if color is true.
+func BuildReport(work *fleetv1beta1.Work, color bool) *WorkDiffReport {
+	report := &WorkDiffReport{
+		TypeMeta:   metav1.TypeMeta{APIVersion: fleetv1beta1.GroupVersion.String(), Kind: KindWorkDiffReport},
+		Namespace:  work.Namespace,
+		Name:       work.Name,
+		Generation: work.Generation,
+	}
+	identifiers := make(map[int]fleetv1beta1.WorkResourceIdentifier, len(work.Status.ManifestConditions))
+	for _, manifestCond := range work.Status.ManifestConditions {
+		identifiers[manifestCond.Identifier.Ordinal] = manifestCond.Identifier
+	}
+	for _, result := range work.Status.DryRunResults {
+		if len(result.Changes) == 0 {
+			continue
+		}
+		identifier, found := identifiers[result.Ordinal]
+		if !found {
+			identifier = fleetv1beta1.WorkResourceIdentifier{Ordinal: result.Ordinal}
+		}
+		report.Manifests = append(report.Manifests, ManifestDiff{
+			Identifier:             identifier,
+			ResourceExistsInMember: result.ResourceExistsInMember,
+			Diff:                   unifiedDiff(identifier, result.Changes, color),
+		})
+	}
+	return report
+}
+
+// unifiedDiff renders the changes of a resource as a unified diff from the member cluster to the hub, with a hunk
+// per changed field.
+func unifiedDiff(identifier fleetv1beta1.WorkResourceIdentifier, changes []fleetv1beta1.PatchDetail, color bool) string {
+	paint := func(code string) string {
+		if !color {
+			return ""
+		}
+		return code
+	}
+	resource := resourcePath(identifier)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s--- member/%s%s\n", paint(colorBold), resource, paint(colorReset))
+	fmt.Fprintf(&b, "%s+++ hub/%s%s\n", paint(colorBold), resource, paint(colorReset))
+	for _, change := range changes {
+		fmt.Fprintf(&b, "%s@@ %s @@%s\n", paint(colorCyan), change.Path, paint(colorReset))
+		for _, line := range valueLines(change.ValueInMember) {
+			fmt.Fprintf(&b, "%s-%s%s\n", paint(colorRed), line, paint(colorReset))
+		}
+		for _, line := range valueLines(change.ValueInHub) {
+			fmt.Fprintf(&b, "%s+%s%s\n", paint(colorGreen), line, paint(colorReset))
+		}
+	}
+	return b.String()
+}
+
+// resourcePath returns the path of the resource in the diff headers, e.g. `apps/v1/Deployment/app/web`.
+func resourcePath(identifier fleetv1beta1.WorkResourceIdentifier) string {
+	if identifier.Kind == "" {
+		return fmt.Sprintf("manifest-%d", identifier.Ordinal)
+	}
+	parts := []string{identifier.Group, identifier.Version, identifier.Kind, identifier.Namespace, identifier.Name}
+	var nonEmpty []string
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, "/")
+}
+
+// valueLines returns the lines of the indented JSON value, or no line if the value is absent.
+func valueLines(value string) []string {
+	if value == "" {
+		return nil
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, []byte(value), "", "  "); err != nil {
+		return []string{value}
+	}
+	return strings.Split(indented.String(), "\n")
+}
+
+// writeAPIError responds with the status code of the API server error.
+func writeAPIError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	var statusErr apierrors.APIStatus
+	if errors.As(err, &statusErr) {
+		code = int(statusErr.Status().Code)
+	}
+	http.Error(w, err.Error(), code)
+}
@@ -0,0 +1,158 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package workdiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// the server is added to the hub agent manager as a runnable served by every replica.
+var _ manager.LeaderElectionRunnable = &Server{}
+
+var deployment = fleetv1beta1.WorkResourceIdentifier{
+	Ordinal: 1, Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "app", Name: "web",
+}
+
+func testWork() *fleetv1beta1.Work {
+	return &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: "work", Namespace: "fleet-member-1", Generation: 3},
+		Status: fleetv1beta1.WorkStatus{
+			ManifestConditions: []fleetv1beta1.ManifestCondition{{Identifier: deployment}},
+			DryRunResults: []fleetv1beta1.ManifestDryRunResult{
+				{Ordinal: 0},
+				{
+					Ordinal:                1,
+					ResourceExistsInMember: true,
+					Changes: []fleetv1beta1.PatchDetail{
+						{Path: "spec.replicas", ValueInMember: "2", ValueInHub: "3"},
+						{Path: "metadata.labels", ValueInHub: `{"tier":"web"}`},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestBuildReport(t *testing.T) {
+	tests := map[string]struct {
+		color    bool
+		wantDiff string
+	}{
+		"plain": {
+			wantDiff: "--- member/apps/v1/Deployment/app/web\n" +
+				"+++ hub/apps/v1/Deployment/app/web\n" +
+				"@@ spec.replicas @@\n" +
+				"-2\n" +
+				"+3\n" +
+				"@@ metadata.labels @@\n" +
+				"+{\n" +
+				"+  \"tier\": \"web\"\n" +
+				"+}\n",
+		},
+		"colored": {
+			color: true,
+			wantDiff: "\x1b[1m--- member/apps/v1/Deployment/app/web\x1b[0m\n" +
+				"\x1b[1m+++ hub/apps/v1/Deployment/app/web\x1b[0m\n" +
+				"\x1b[36m@@ spec.replicas @@\x1b[0m\n" +
+				"\x1b[31m-2\x1b[0m\n" +
+				"\x1b[32m+3\x1b[0m\n" +
+				"\x1b[36m@@ metadata.labels @@\x1b[0m\n" +
+				"\x1b[32m+{\x1b[0m\n" +
+				"\x1b[32m+  \"tier\": \"web\"\x1b[0m\n" +
+				"\x1b[32m+}\x1b[0m\n",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			want := &WorkDiffReport{
+				TypeMeta:   metav1.TypeMeta{APIVersion: fleetv1beta1.GroupVersion.String(), Kind: KindWorkDiffReport},
+				Namespace:  "fleet-member-1",
+				Name:       "work",
+				Generation: 3,
+				Manifests:  []ManifestDiff{{Identifier: deployment, ResourceExistsInMember: true, Diff: tt.wantDiff}},
+			}
+			if diff := cmp.Diff(want, BuildReport(testWork(), tt.color)); diff != "" {
+				t.Errorf("BuildReport() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestServeDiff(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the fleet scheme: %v", err)
+	}
+	s := &Server{Reader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(testWork()).Build()}
+	pathOf := func(name string) string {
+		return fmt.Sprintf("/apis/%s/%s/namespaces/fleet-member-1/works/%s/diff", fleetv1beta1.GroupVersion.Group, fleetv1beta1.GroupVersion.Version, name)
+	}
+
+	tests := map[string]struct {
+		method    string
+		path      string
+		wantCode  int
+		wantColor bool
+	}{
+		"colored by default": {
+			method:    http.MethodGet,
+			path:      pathOf("work"),
+			wantCode:  http.StatusOK,
+			wantColor: true,
+		},
+		"plain on request": {
+			method:   http.MethodGet,
+			path:     pathOf("work") + "?color=false",
+			wantCode: http.StatusOK,
+		},
+		"invalid color": {
+			method:   http.MethodGet,
+			path:     pathOf("work") + "?color=maybe",
+			wantCode: http.StatusBadRequest,
+		},
+		"missing work": {
+			method:   http.MethodGet,
+			path:     pathOf("missing"),
+			wantCode: http.StatusNotFound,
+		},
+		"method not allowed": {
+			method:   http.MethodPost,
+			path:     pathOf("work"),
+			wantCode: http.StatusMethodNotAllowed,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			s.Handler().ServeHTTP(recorder, httptest.NewRequest(tt.method, tt.path, nil))
+			if recorder.Code != tt.wantCode {
+				t.Fatalf("serveDiff() code = %d, want %d: %s", recorder.Code, tt.wantCode, recorder.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var got WorkDiffReport
+			if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil {
+				t.Fatalf("failed to decode the report: %v", err)
+			}
+			if diff := cmp.Diff(BuildReport(testWork(), tt.wantColor), &got); diff != "" {
+				t.Errorf("serveDiff() report mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package workmerge serves the partial updates of the Work specs, so that the writers updating different fields of
+// the same Work do not need to send the full spec and race with each other.
+package workmerge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/workintegrity"
+)
+
+const (
+	// FieldManagerQueryParameter is the query parameter which names the writer owning the merged fields.
+	FieldManagerQueryParameter = "fieldManager"
+	// DefaultFieldManager is the field manager of the merged fields if the request does not name one.
+	DefaultFieldManager = "work-merge"
+
+	// maxPatchBytes is the maximum size of a merge patch.
+	maxPatchBytes   = 3 * 1024 * 1024
+	shutdownTimeout = 5 * time.Second
+)
+
+var (
+	// MergePathPattern is the pattern of the path the partial updates are served at.
+	MergePathPattern = fmt.Sprintf("PATCH /apis/%s/%s/namespaces/{namespace}/works/{name}/merge",
+		fleetv1beta1.GroupVersion.Group, fleetv1beta1.GroupVersion.Version)
+)
+
+// Server merges the partial specs into the works.
+type Server struct {
+	// Addr is the TCP address the server listens on.
+	Addr string
+	// Client patches the works.
+	Client client.Client
+}
+
+// NeedLeaderElection implements the LeaderElectionRunnable interface so that every replica serves the updates.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves the partial updates until the context is cancelled.
+func (s *Server) Start(ctx context.Context) error {
+	server := &http.Server{Addr: s.Addr, Handler: s.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			klog.ErrorS(err, "Failed to shut down the work merge server")
+		}
+	}()
+	klog.InfoS("Starting the work merge server", "address", s.Addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve the work merges: %w", err)
+	}
+	return nil
+}
+
+// Handler returns the handler of the partial updates.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(MergePathPattern, s.serveMerge)
+	return mux
+}
+
+// serveMerge merges the JSON merge patch of a work spec in the request body into the work and responds with the
+// merged work. The patch is applied by the API server in one step, so the concurrent patches of different fields
+// do not overwrite each other, and the merged fields are recorded as owned by the field manager of the request.
+func (s *Server) serveMerge(w http.ResponseWriter, req *http.Request) {
+	key := types.NamespacedName{Namespace: req.PathValue("namespace"), Name: req.PathValue("name")}
+	fieldManager := req.URL.Query().Get(FieldManagerQueryParameter)
+	if fieldManager == "" {
+		fieldManager = DefaultFieldManager
+	}
+
+	specPatch, err := io.ReadAll(io.LimitReader(req.Body, maxPatchBytes+1))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(specPatch) > maxPatchBytes {
+		http.Error(w, fmt.Sprintf("the merge patch is larger than %d bytes", maxPatchBytes), http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err := validateSpecPatch(specPatch); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	if specPatch, err = withManifestChecksums(specPatch); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	// the spec patch is nested under the spec field so that nothing else of the work can be changed.
+	patch, err := json.Marshal(map[string]json.RawMessage{"spec": specPatch})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	work := &fleetv1beta1.Work{ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name}}
+	if err := s.Client.Patch(req.Context(), work, client.RawPatch(types.MergePatchType, patch), client.FieldOwner(fieldManager)); err != nil {
+		klog.ErrorS(err, "Failed to merge the patch into the work", "work", key, "fieldManager", fieldManager)
+		code := http.StatusInternalServerError
+		var statusErr apierrors.APIStatus
+		if errors.As(err, &statusErr) {
+			code = int(statusErr.Status().Code)
+		}
+		http.Error(w, err.Error(), code)
+		return
+	}
+	klog.V(2).InfoS("Merged the patch into the work", "work", key, "fieldManager", fieldManager)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(work); err != nil {
+		klog.ErrorS(err, "Failed to write the merged work", "work", key)
+	}
+}
+
+// manifestKey identifies the resource a manifest is applied to.
+type manifestKey struct {
+	group     string
+	kind      string
+	namespace string
+	name      string
+}
+
+// validateSpecPatch checks that the merge patch is a work spec and, since a merge patch replaces a list as a whole,
+// that the manifests it sets do not apply to the same resource twice.
+func validateSpecPatch(specPatch []byte) error {
+	var spec struct {
+		Workload *struct {
+			Manifests []fleetv1beta1.Manifest `json:"manifests"`
+		} `json:"workload"`
+	}
+	if err := json.Unmarshal(specPatch, &spec); err != nil {
+		return fmt.Errorf("the merge patch is not a valid work spec: %w", err)
+	}
+	if spec.Workload == nil {
+		return nil
+	}
+
+	seen := make(map[manifestKey]int, len(spec.Workload.Manifests))
+	for index, manifest := range spec.Workload.Manifests {
+		var obj unstructured.Unstructured
+		if err := obj.UnmarshalJSON(manifest.Raw); err != nil {
+			return fmt.Errorf("the manifest with ordinal %d is not a valid object: %w", index, err)
+		}
+		key := manifestKey{group: obj.GroupVersionKind().Group, kind: obj.GetKind(), namespace: obj.GetNamespace(), name: obj.GetName()}
+		if previous, found := seen[key]; found {
+			return fmt.Errorf("the manifests with ordinal %d and %d are the same %s %s", previous, index, key.kind, klog.KRef(key.namespace, key.name))
+		}
+		seen[key] = index
+	}
+	return nil
+}
+
+// withManifestChecksums sets the checksums of the manifests in a merge patch which replaces the manifests, so that
+// the checksums of the work do not go stale.
+func withManifestChecksums(specPatch []byte) ([]byte, error) {
+	var spec map[string]json.RawMessage
+	if err := json.Unmarshal(specPatch, &spec); err != nil {
+		return nil, fmt.Errorf("the merge patch is not a valid work spec: %w", err)
+	}
+	var workload map[string]json.RawMessage
+	if err := json.Unmarshal(spec["workload"], &workload); err != nil || workload == nil {
+		// the patch does not replace the manifests.
+		return specPatch, nil
+	}
+	rawManifests, found := workload["manifests"]
+	if !found {
+		return specPatch, nil
+	}
+	var manifests []fleetv1beta1.Manifest
+	if err := json.Unmarshal(rawManifests, &manifests); err != nil {
+		return nil, fmt.Errorf("the manifests of the merge patch are not valid: %w", err)
+	}
+	checksums, err := workintegrity.ManifestChecksums(manifests)
+	if err != nil {
+		return nil, err
+	}
+	// the checksums are removed along with the manifests.
+	if workload["manifestChecksums"], err = json.Marshal(checksums); err != nil {
+		return nil, err
+	}
+	if spec["workload"], err = json.Marshal(workload); err != nil {
+		return nil, err
+	}
+	return json.Marshal(spec)
+}
@@ -0,0 +1,193 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package workmerge
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/workintegrity"
+)
+
+const (
+	configMapManifest       = `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm","namespace":"default"}}`
+	otherConfigMapManifest  = `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"other-cm","namespace":"default"}}`
+	configMapInAppsManifest = `{"apiVersion":"apps/v1","kind":"ConfigMap","metadata":{"name":"cm","namespace":"default"}}`
+)
+
+// newTestServer returns a merge server of a hub cluster with the given work.
+func newTestServer(t *testing.T, work *fleetv1beta1.Work) (*httptest.Server, client.Client) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(work).Build()
+	server := httptest.NewServer((&Server{Client: hubClient}).Handler())
+	t.Cleanup(server.Close)
+	return server, hubClient
+}
+
+// merge sends the merge patch of the work spec and returns the response status code and body.
+func merge(t *testing.T, serverURL, namespace, name, fieldManager, specPatch string) (int, string) {
+	t.Helper()
+	url := fmt.Sprintf("%s/apis/%s/%s/namespaces/%s/works/%s/merge?%s=%s", serverURL, fleetv1beta1.GroupVersion.Group,
+		fleetv1beta1.GroupVersion.Version, namespace, name, FieldManagerQueryParameter, fieldManager)
+	req, err := http.NewRequest(http.MethodPatch, url, strings.NewReader(specPatch))
+	if err != nil {
+		t.Errorf("failed to build the request: %v", err)
+		return 0, ""
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Errorf("failed to send the request: %v", err)
+		return 0, ""
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Errorf("failed to read the response: %v", err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestServeMerge(t *testing.T) {
+	tests := map[string]struct {
+		workName      string
+		specPatch     string
+		wantCode      int
+		wantManifests []string
+	}{
+		"manifests are replaced": {
+			workName:      "test-work",
+			specPatch:     `{"workload":{"manifests":[` + otherConfigMapManifest + `,` + configMapManifest + `]}}`,
+			wantCode:      http.StatusOK,
+			wantManifests: []string{otherConfigMapManifest, configMapManifest},
+		},
+		"manifests of the same name in different groups are allowed": {
+			workName:      "test-work",
+			specPatch:     `{"workload":{"manifests":[` + configMapManifest + `,` + configMapInAppsManifest + `]}}`,
+			wantCode:      http.StatusOK,
+			wantManifests: []string{configMapManifest, configMapInAppsManifest},
+		},
+		"duplicate manifests are rejected": {
+			workName:      "test-work",
+			specPatch:     `{"workload":{"manifests":[` + configMapManifest + `,` + otherConfigMapManifest + `,` + configMapManifest + `]}}`,
+			wantCode:      http.StatusUnprocessableEntity,
+			wantManifests: []string{configMapManifest},
+		},
+		"invalid patch is rejected": {
+			workName:      "test-work",
+			specPatch:     `{"workload":"manifests"}`,
+			wantCode:      http.StatusUnprocessableEntity,
+			wantManifests: []string{configMapManifest},
+		},
+		"unknown work is not found": {
+			workName:      "unknown-work",
+			specPatch:     `{"memberAPITimeoutSeconds":60}`,
+			wantCode:      http.StatusNotFound,
+			wantManifests: []string{configMapManifest},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			manifests := []fleetv1beta1.Manifest{{RawExtension: runtime.RawExtension{Raw: []byte(configMapManifest)}}}
+			checksums, err := workintegrity.ManifestChecksums(manifests)
+			if err != nil {
+				t.Fatalf("ManifestChecksums() = %v, want no error", err)
+			}
+			work := &fleetv1beta1.Work{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: "fleet-member-test"},
+				Spec: fleetv1beta1.WorkSpec{
+					Workload: fleetv1beta1.WorkloadTemplate{Manifests: manifests, ManifestChecksums: checksums},
+				},
+			}
+			server, hubClient := newTestServer(t, work)
+
+			code, body := merge(t, server.URL, work.Namespace, tt.workName, "test-writer", tt.specPatch)
+			if code != tt.wantCode {
+				t.Errorf("merge() status code = %d, want %d: %s", code, tt.wantCode, body)
+			}
+
+			var got fleetv1beta1.Work
+			if err := hubClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, &got); err != nil {
+				t.Fatalf("failed to get the work: %v", err)
+			}
+			var gotManifests []string
+			for _, manifest := range got.Spec.Workload.Manifests {
+				gotManifests = append(gotManifests, string(manifest.Raw))
+			}
+			if diff := cmp.Diff(tt.wantManifests, gotManifests); diff != "" {
+				t.Errorf("merge() manifests mismatch (-want +got):\n%s", diff)
+			}
+			// the checksums are replaced along with the manifests.
+			corrupted, err := workintegrity.CorruptedManifests(&got)
+			if err != nil {
+				t.Fatalf("CorruptedManifests() = %v, want no error", err)
+			}
+			if len(corrupted) != 0 {
+				t.Errorf("merge() left the checksums of the manifests %v stale", corrupted)
+			}
+		})
+	}
+}
+
+func TestServeMergeConcurrently(t *testing.T) {
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: "fleet-member-test"},
+		Spec: fleetv1beta1.WorkSpec{
+			Workload: fleetv1beta1.WorkloadTemplate{
+				Manifests: []fleetv1beta1.Manifest{{RawExtension: runtime.RawExtension{Raw: []byte(configMapManifest)}}},
+			},
+		},
+	}
+	server, hubClient := newTestServer(t, work)
+
+	// the two writers change different fields of the same work at the same time.
+	patches := map[string]string{
+		"timeout-writer":  `{"memberAPITimeoutSeconds":60}`,
+		"strategy-writer": `{"applyStrategy":{"type":"ServerSideApply","allowCoOwnership":true}}`,
+	}
+	var wg sync.WaitGroup
+	for fieldManager, specPatch := range patches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if code, body := merge(t, server.URL, work.Namespace, work.Name, fieldManager, specPatch); code != http.StatusOK {
+				t.Errorf("merge() of %s status code = %d, want %d: %s", fieldManager, code, http.StatusOK, body)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var got fleetv1beta1.Work
+	if err := hubClient.Get(context.Background(), types.NamespacedName{Name: work.Name, Namespace: work.Namespace}, &got); err != nil {
+		t.Fatalf("failed to get the work: %v", err)
+	}
+	want := fleetv1beta1.WorkSpec{
+		Workload:                work.Spec.Workload,
+		MemberAPITimeoutSeconds: ptr.To(int64(60)),
+		ApplyStrategy:           &fleetv1beta1.ApplyStrategy{Type: fleetv1beta1.ApplyStrategyTypeServerSideApply, AllowCoOwnership: true},
+	}
+	if diff := cmp.Diff(want, got.Spec); diff != "" {
+		t.Errorf("merged work spec mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package workresync serves the requests to resync the Works on demand, so that the operators do not need to wait
+// for the next periodic reconcile of the member agent to get a Work applied again.
+package workresync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	// MinResyncInterval is the minimum interval between two forced resyncs of the same work.
+	MinResyncInterval = 30 * time.Second
+
+	shutdownTimeout = 5 * time.Second
+)
+
+var (
+	// ResyncPathPattern is the pattern of the path the resync requests are served at.
+	ResyncPathPattern = fmt.Sprintf("POST /apis/%s/%s/namespaces/{namespace}/works/{name}/resync",
+		fleetv1beta1.GroupVersion.Group, fleetv1beta1.GroupVersion.Version)
+)
+
+// Server requests the forced resyncs of the works.
+type Server struct {
+	// Addr is the TCP address the server listens on.
+	Addr string
+	// Client reads and annotates the works.
+	Client client.Client
+
+	// now returns the current time; it is time.Now if nil.
+	now func() time.Time
+}
+
+// NeedLeaderElection implements the LeaderElectionRunnable interface so that every replica serves the requests.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves the resync requests until the context is cancelled.
+func (s *Server) Start(ctx context.Context) error {
+	server := &http.Server{Addr: s.Addr, Handler: s.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			klog.ErrorS(err, "Failed to shut down the work resync server")
+		}
+	}()
+	klog.InfoS("Starting the work resync server", "address", s.Addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve the work resyncs: %w", err)
+	}
+	return nil
+}
+
+// Handler returns the handler of the resync requests.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(ResyncPathPattern, s.serveResync)
+	return mux
+}
+
+// serveResync requests a forced resync of the work by setting its force resync annotation, which the member agent
+// watches, and responds with 202 Accepted as the work is applied asynchronously. The time of the last request is
+// kept in the annotation so that the rate limit holds across the replicas of the hub agent.
+func (s *Server) serveResync(w http.ResponseWriter, req *http.Request) {
+	key := types.NamespacedName{Namespace: req.PathValue("namespace"), Name: req.PathValue("name")}
+	now := time.Now
+	if s.now != nil {
+		now = s.now
+	}
+	requestedAt := now()
+
+	var work fleetv1beta1.Work
+	if err := s.Client.Get(req.Context(), key, &work); err != nil {
+		klog.ErrorS(err, "Failed to get the work to resync", "work", key)
+		writeAPIError(w, err)
+		return
+	}
+	if last, err := time.Parse(time.RFC3339Nano, work.GetAnnotations()[fleetv1beta1.ForceResyncAnnotation]); err == nil {
+		if wait := last.Add(MinResyncInterval).Sub(requestedAt); wait > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(wait.Round(time.Second).Seconds())))
+			http.Error(w, fmt.Sprintf("the work %s was resynced at %s, retry in %s", key, last.Format(time.RFC3339), wait.Round(time.Second)),
+				http.StatusTooManyRequests)
+			return
+		}
+	}
+
+	// the resource version makes the patch fail if another request annotates the work in the meantime.
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"resourceVersion": work.ResourceVersion,
+			"annotations": map[string]string{
+				fleetv1beta1.ForceResyncAnnotation: requestedAt.UTC().Format(time.RFC3339Nano),
+			},
+		},
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := s.Client.Patch(req.Context(), &work, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		if apierrors.IsConflict(err) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(MinResyncInterval.Seconds())))
+			http.Error(w, fmt.Sprintf("the work %s is being resynced by another request", key), http.StatusTooManyRequests)
+			return
+		}
+		klog.ErrorS(err, "Failed to request the resync of the work", "work", key)
+		writeAPIError(w, err)
+		return
+	}
+	klog.V(2).InfoS("Requested the resync of the work", "work", key, "requestedAt", requestedAt)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	status := metav1.Status{
+		TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   metav1.StatusSuccess,
+		Message:  fmt.Sprintf("the resync of the work %s is requested", key),
+		Code:     http.StatusAccepted,
+	}
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		klog.ErrorS(err, "Failed to write the resync response", "work", key)
+	}
+}
+
+// writeAPIError responds with the status code of the API server error.
+func writeAPIError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	var statusErr apierrors.APIStatus
+	if errors.As(err, &statusErr) {
+		code = int(statusErr.Status().Code)
+	}
+	http.Error(w, err.Error(), code)
+}
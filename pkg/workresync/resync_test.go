@@ -0,0 +1,127 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package workresync
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+var (
+	requestTime = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	workKey     = types.NamespacedName{Namespace: "fleet-member-test", Name: "test-work"}
+)
+
+// resync sends the resync request of the work and returns the response.
+func resync(t *testing.T, serverURL string, key types.NamespacedName) *http.Response {
+	t.Helper()
+	url := fmt.Sprintf("%s/apis/%s/%s/namespaces/%s/works/%s/resync", serverURL, fleetv1beta1.GroupVersion.Group,
+		fleetv1beta1.GroupVersion.Version, key.Namespace, key.Name)
+	resp, err := http.Post(url, "application/json", nil)
+	if err != nil {
+		t.Fatalf("failed to send the request: %v", err)
+	}
+	resp.Body.Close()
+	return resp
+}
+
+func TestServeResync(t *testing.T) {
+	tests := map[string]struct {
+		lastResync     *time.Time
+		key            types.NamespacedName
+		conflict       bool
+		wantCode       int
+		wantRetryAfter string
+		wantAnnotation string
+	}{
+		"first resync is accepted": {
+			key:            workKey,
+			wantCode:       http.StatusAccepted,
+			wantAnnotation: requestTime.Format(time.RFC3339Nano),
+		},
+		"resync within the interval is rejected": {
+			lastResync:     ptr.To(requestTime.Add(-10 * time.Second)),
+			key:            workKey,
+			wantCode:       http.StatusTooManyRequests,
+			wantRetryAfter: "20",
+			wantAnnotation: requestTime.Add(-10 * time.Second).Format(time.RFC3339Nano),
+		},
+		"resync after the interval is accepted": {
+			lastResync:     ptr.To(requestTime.Add(-MinResyncInterval)),
+			key:            workKey,
+			wantCode:       http.StatusAccepted,
+			wantAnnotation: requestTime.Format(time.RFC3339Nano),
+		},
+		"concurrent resync is rejected": {
+			key:            workKey,
+			conflict:       true,
+			wantCode:       http.StatusTooManyRequests,
+			wantRetryAfter: "30",
+		},
+		"missing work is not found": {
+			key:      types.NamespacedName{Namespace: workKey.Namespace, Name: "missing"},
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+				t.Fatalf("failed to add the placement scheme: %v", err)
+			}
+			work := &fleetv1beta1.Work{ObjectMeta: metav1.ObjectMeta{Namespace: workKey.Namespace, Name: workKey.Name}}
+			if tt.lastResync != nil {
+				work.SetAnnotations(map[string]string{fleetv1beta1.ForceResyncAnnotation: tt.lastResync.Format(time.RFC3339Nano)})
+			}
+			builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(work)
+			if tt.conflict {
+				builder = builder.WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(_ context.Context, _ client.WithWatch, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+						return apierrors.NewConflict(schema.GroupResource{Group: fleetv1beta1.GroupVersion.Group, Resource: "works"},
+							obj.GetName(), fmt.Errorf("the object has been modified"))
+					},
+				})
+			}
+			hubClient := builder.Build()
+			server := httptest.NewServer((&Server{Client: hubClient, now: func() time.Time { return requestTime }}).Handler())
+			defer server.Close()
+
+			resp := resync(t, server.URL, tt.key)
+			if resp.StatusCode != tt.wantCode {
+				t.Fatalf("resync status code = %d, want %d", resp.StatusCode, tt.wantCode)
+			}
+			if got := resp.Header.Get("Retry-After"); got != tt.wantRetryAfter {
+				t.Errorf("resync Retry-After = %q, want %q", got, tt.wantRetryAfter)
+			}
+			if tt.wantCode == http.StatusNotFound {
+				return
+			}
+			var got fleetv1beta1.Work
+			if err := hubClient.Get(context.Background(), workKey, &got); err != nil {
+				t.Fatalf("failed to get the work: %v", err)
+			}
+			if annotation := got.GetAnnotations()[fleetv1beta1.ForceResyncAnnotation]; annotation != tt.wantAnnotation {
+				t.Errorf("work force resync annotation = %q, want %q", annotation, tt.wantAnnotation)
+			}
+		})
+	}
+}
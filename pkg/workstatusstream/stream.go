@@ -0,0 +1,284 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package workstatusstream serves the status changes of a Work as a stream of Server-Sent Events, so that the
+// operators watching a long-running apply get the updates without polling the Work.
+package workstatusstream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+
+	"golang.org/x/time/rate"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/workstatuspage"
+)
+
+const (
+	// MaxEventsPerSecond is the maximum number of events sent per second on a stream; the status changes in between
+	// are coalesced into the next event.
+	MaxEventsPerSecond = 10
+	// MaxStreamDuration is the maximum time a stream is kept open.
+	MaxStreamDuration = 10 * time.Minute
+
+	// EventTypeStatusChanged is the type of the event sent when the manifest conditions of the work change.
+	EventTypeStatusChanged = "StatusChanged"
+	// EventTypeDeleted is the type of the event sent when the work is deleted; the stream closes after it.
+	EventTypeDeleted = "Deleted"
+
+	shutdownTimeout = 5 * time.Second
+)
+
+var (
+	// StreamPathPattern is the pattern of the path the status streams are served at.
+	StreamPathPattern = fmt.Sprintf("GET /apis/%s/%s/namespaces/{namespace}/works/{name}/watch-status",
+		fleetv1beta1.GroupVersion.Group, fleetv1beta1.GroupVersion.Version)
+)
+
+// WorkStatusEvent is the status change of a work sent as a Server-Sent Event.
+type WorkStatusEvent struct {
+	// Type is the type of the event.
+	Type string `json:"type"`
+	// Namespace is the namespace of the work.
+	Namespace string `json:"namespace"`
+	// Name is the name of the work.
+	Name string `json:"name"`
+	// Generation is the generation of the work.
+	Generation int64 `json:"generation,omitempty"`
+	// ManifestConditions are the manifest conditions which are added or changed since the previous event.
+	ManifestConditions []fleetv1beta1.ManifestCondition `json:"manifestConditions,omitempty"`
+	// RemovedOrdinals are the ordinals of the manifest conditions which are removed since the previous event.
+	RemovedOrdinals []int `json:"removedOrdinals,omitempty"`
+}
+
+// Server streams the status changes of the works as Server-Sent Events.
+type Server struct {
+	// Addr is the TCP address the server listens on.
+	Addr string
+	// Cache provides the informer the status changes of the works are observed with.
+	Cache cache.Cache
+	// Reader reads the works and their status pages.
+	Reader client.Reader
+
+	informer cache.Informer
+}
+
+// NeedLeaderElection implements the LeaderElectionRunnable interface so that every replica serves the streams.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves the streams until the context is cancelled.
+func (s *Server) Start(ctx context.Context) error {
+	informer, err := s.Cache.GetInformer(ctx, &fleetv1beta1.Work{})
+	if err != nil {
+		return fmt.Errorf("failed to get the work informer: %w", err)
+	}
+	s.informer = informer
+	server := &http.Server{Addr: s.Addr, Handler: s.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			klog.ErrorS(err, "Failed to shut down the work status stream server")
+		}
+	}()
+	klog.InfoS("Starting the work status stream server", "address", s.Addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve the work status streams: %w", err)
+	}
+	return nil
+}
+
+// Handler returns the handler of the status streams.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(StreamPathPattern, s.serveStream)
+	return mux
+}
+
+// serveStream streams the status changes of a work until the work is deleted, the client disconnects or the stream
+// reaches its maximum duration.
+func (s *Server) serveStream(w http.ResponseWriter, req *http.Request) {
+	key := types.NamespacedName{Namespace: req.PathValue("namespace"), Name: req.PathValue("name")}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), MaxStreamDuration)
+	defer cancel()
+
+	// register the handler before reading the work so that no change is missed in between.
+	changed := make(chan struct{}, 1)
+	deleted := make(chan struct{})
+	registration, err := s.informer.AddEventHandler(workEventHandler(key, changed, deleted))
+	if err != nil {
+		klog.ErrorS(err, "Failed to watch the work", "work", key)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer func() {
+		if err := s.informer.RemoveEventHandler(registration); err != nil {
+			klog.ErrorS(err, "Failed to stop watching the work", "work", key)
+		}
+	}()
+
+	var work fleetv1beta1.Work
+	if err := s.getWork(ctx, key, &work); err != nil {
+		code := http.StatusInternalServerError
+		if apierrors.IsNotFound(err) {
+			code = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), code)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	klog.V(2).InfoS("Streaming the work status", "work", key)
+	limiter := rate.NewLimiter(MaxEventsPerSecond, 1)
+	// the first event carries all the manifest conditions.
+	var last []fleetv1beta1.ManifestCondition
+	initial := true
+	for {
+		if event := statusChangedEvent(&work, last, initial); event != nil {
+			if err := limiter.Wait(ctx); err != nil {
+				return
+			}
+			if err := writeEvent(w, flusher, event); err != nil {
+				klog.V(2).InfoS("Failed to send the work status event", "work", key, "err", err)
+				return
+			}
+			last = work.Status.ManifestConditions
+			initial = false
+		}
+		select {
+		case <-ctx.Done():
+			klog.V(2).InfoS("Closing the work status stream", "work", key, "err", ctx.Err())
+			return
+		case <-deleted:
+			if err := writeEvent(w, flusher, &WorkStatusEvent{Type: EventTypeDeleted, Namespace: key.Namespace, Name: key.Name}); err != nil {
+				klog.V(2).InfoS("Failed to send the work deleted event", "work", key, "err", err)
+			}
+			return
+		case <-changed:
+			if err := s.getWork(ctx, key, &work); err != nil {
+				if !apierrors.IsNotFound(err) {
+					klog.ErrorS(err, "Failed to get the work", "work", key)
+					return
+				}
+				// the deletion is observed with the next event.
+			}
+		}
+	}
+}
+
+// getWork reads the work with the manifest conditions of its status pages.
+func (s *Server) getWork(ctx context.Context, key types.NamespacedName, work *fleetv1beta1.Work) error {
+	*work = fleetv1beta1.Work{}
+	if err := s.Reader.Get(ctx, key, work); err != nil {
+		return err
+	}
+	return workstatuspage.Merge(ctx, s.Reader, work)
+}
+
+// workEventHandler notifies the changes and the deletion of the work with the given key.
+func workEventHandler(key types.NamespacedName, changed chan<- struct{}, deleted chan<- struct{}) toolscache.ResourceEventHandler {
+	isWork := func(obj interface{}) bool {
+		if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		work, ok := obj.(*fleetv1beta1.Work)
+		return ok && work.Namespace == key.Namespace && work.Name == key.Name
+	}
+	notify := func() {
+		// the pending notification already covers this change.
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+	closed := false
+	return toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if isWork(obj) {
+				notify()
+			}
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			if isWork(newObj) {
+				notify()
+			}
+		},
+		// the events to a handler are delivered sequentially so closed is not accessed concurrently.
+		DeleteFunc: func(obj interface{}) {
+			if isWork(obj) && !closed {
+				closed = true
+				close(deleted)
+			}
+		},
+	}
+}
+
+// statusChangedEvent returns the event of the manifest conditions of the work which changed since the last sent
+// ones, or nil if there is no change. The initial event is always returned.
+func statusChangedEvent(work *fleetv1beta1.Work, last []fleetv1beta1.ManifestCondition, initial bool) *WorkStatusEvent {
+	lastByOrdinal := make(map[int]*fleetv1beta1.ManifestCondition, len(last))
+	for i := range last {
+		lastByOrdinal[last[i].Identifier.Ordinal] = &last[i]
+	}
+	event := &WorkStatusEvent{
+		Type:       EventTypeStatusChanged,
+		Namespace:  work.Namespace,
+		Name:       work.Name,
+		Generation: work.Generation,
+	}
+	current := make(map[int]bool, len(work.Status.ManifestConditions))
+	for _, manifestCond := range work.Status.ManifestConditions {
+		current[manifestCond.Identifier.Ordinal] = true
+		if previous, ok := lastByOrdinal[manifestCond.Identifier.Ordinal]; ok && reflect.DeepEqual(*previous, manifestCond) {
+			continue
+		}
+		event.ManifestConditions = append(event.ManifestConditions, manifestCond)
+	}
+	for _, manifestCond := range last {
+		if !current[manifestCond.Identifier.Ordinal] {
+			event.RemovedOrdinals = append(event.RemovedOrdinals, manifestCond.Identifier.Ordinal)
+		}
+	}
+	if !initial && len(event.ManifestConditions) == 0 && len(event.RemovedOrdinals) == 0 {
+		return nil
+	}
+	return event
+}
+
+// writeEvent writes the event in the Server-Sent Events format and flushes it to the client.
+func writeEvent(w http.ResponseWriter, flusher http.Flusher, event *WorkStatusEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the work status event: %w", err)
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+		return err
+	}
+	flusher.Flush()
+	return nil
+}
@@ -0,0 +1,201 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package workstatusstream
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// manifestCondition returns the manifest condition of the config map with the given ordinal and applied status.
+func manifestCondition(ordinal int, applied metav1.ConditionStatus) fleetv1beta1.ManifestCondition {
+	return fleetv1beta1.ManifestCondition{
+		Identifier: fleetv1beta1.WorkResourceIdentifier{Ordinal: ordinal, Version: "v1", Kind: "ConfigMap", Name: fmt.Sprintf("cm-%d", ordinal)},
+		Conditions: []metav1.Condition{{
+			Type:               fleetv1beta1.WorkConditionTypeApplied,
+			Status:             applied,
+			Reason:             "test",
+			LastTransitionTime: metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+		}},
+	}
+}
+
+// readEvent reads the next Server-Sent Event of the stream.
+func readEvent(t *testing.T, reader *bufio.Reader) *WorkStatusEvent {
+	t.Helper()
+	var event WorkStatusEvent
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("failed to read the stream: %v", err)
+		}
+		line = strings.TrimSpace(line)
+		if data, ok := strings.CutPrefix(line, "data: "); ok {
+			if err := json.Unmarshal([]byte(data), &event); err != nil {
+				t.Fatalf("failed to unmarshal the event: %v", err)
+			}
+		}
+		if line == "" && event.Type != "" {
+			return &event
+		}
+	}
+}
+
+func TestServeStream(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: "fleet-member-test", Generation: 1},
+		Status: fleetv1beta1.WorkStatus{
+			ManifestConditions: []fleetv1beta1.ManifestCondition{
+				manifestCondition(0, metav1.ConditionFalse),
+				manifestCondition(1, metav1.ConditionFalse),
+			},
+		},
+	}
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(work).WithStatusSubresource(work).Build()
+	informer := &controllertest.FakeInformer{}
+	s := &Server{Reader: hubClient, informer: informer}
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		server.URL+"/apis/placement.kubernetes-fleet.io/v1beta1/namespaces/fleet-member-test/works/test-work/watch-status", nil)
+	if err != nil {
+		t.Fatalf("failed to build the request: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to open the stream: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("stream status code = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	reader := bufio.NewReader(resp.Body)
+
+	// the first event carries all the manifest conditions.
+	event := readEvent(t, reader)
+	if diff := cmp.Diff(work.Status.ManifestConditions, event.ManifestConditions); diff != "" {
+		t.Errorf("initial event manifest conditions mismatch (-want +got):\n%s", diff)
+	}
+
+	// a reconcile applies the manifest with ordinal 1.
+	var current fleetv1beta1.Work
+	if err := hubClient.Get(ctx, client.ObjectKeyFromObject(work), &current); err != nil {
+		t.Fatalf("failed to get the work: %v", err)
+	}
+	old := current.DeepCopy()
+	current.Status.ManifestConditions[1] = manifestCondition(1, metav1.ConditionTrue)
+	if err := hubClient.Status().Update(ctx, &current); err != nil {
+		t.Fatalf("failed to update the work status: %v", err)
+	}
+	informer.Update(old, &current)
+	event = readEvent(t, reader)
+	if event.Type != EventTypeStatusChanged {
+		t.Errorf("event type = %s, want %s", event.Type, EventTypeStatusChanged)
+	}
+	want := []fleetv1beta1.ManifestCondition{manifestCondition(1, metav1.ConditionTrue)}
+	if diff := cmp.Diff(want, event.ManifestConditions); diff != "" {
+		t.Errorf("status changed event manifest conditions mismatch (-want +got):\n%s", diff)
+	}
+
+	// the stream closes after the work is deleted.
+	if err := hubClient.Delete(ctx, &current); err != nil {
+		t.Fatalf("failed to delete the work: %v", err)
+	}
+	informer.Delete(&current)
+	if event = readEvent(t, reader); event.Type != EventTypeDeleted {
+		t.Errorf("event type = %s, want %s", event.Type, EventTypeDeleted)
+	}
+	if _, err := reader.ReadString('\n'); err == nil {
+		t.Errorf("stream is still open after the work is deleted")
+	}
+}
+
+func TestServeStreamWorkNotFound(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	s := &Server{Reader: fake.NewClientBuilder().WithScheme(scheme).Build(), informer: &controllertest.FakeInformer{}}
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/apis/placement.kubernetes-fleet.io/v1beta1/namespaces/fleet-member-test/works/test-work/watch-status", nil)
+	s.Handler().ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("stream status code = %d, want %d", recorder.Code, http.StatusNotFound)
+	}
+}
+
+func TestStatusChangedEvent(t *testing.T) {
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-work", Namespace: "fleet-member-test"},
+		Status: fleetv1beta1.WorkStatus{
+			ManifestConditions: []fleetv1beta1.ManifestCondition{manifestCondition(0, metav1.ConditionTrue)},
+		},
+	}
+	tests := map[string]struct {
+		last        []fleetv1beta1.ManifestCondition
+		initial     bool
+		wantChanged []fleetv1beta1.ManifestCondition
+		wantRemoved []int
+		wantNil     bool
+	}{
+		"initial event": {
+			initial:     true,
+			wantChanged: work.Status.ManifestConditions,
+		},
+		"unchanged status": {
+			last:    work.Status.ManifestConditions,
+			wantNil: true,
+		},
+		"changed and removed conditions": {
+			last:        []fleetv1beta1.ManifestCondition{manifestCondition(0, metav1.ConditionFalse), manifestCondition(1, metav1.ConditionTrue)},
+			wantChanged: work.Status.ManifestConditions,
+			wantRemoved: []int{1},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			event := statusChangedEvent(work, tt.last, tt.initial)
+			if tt.wantNil {
+				if event != nil {
+					t.Errorf("statusChangedEvent() = %+v, want nil", event)
+				}
+				return
+			}
+			if event == nil {
+				t.Fatalf("statusChangedEvent() = nil, want an event")
+			}
+			if diff := cmp.Diff(tt.wantChanged, event.ManifestConditions); diff != "" {
+				t.Errorf("statusChangedEvent() manifest conditions mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantRemoved, event.RemovedOrdinals); diff != "" {
+				t.Errorf("statusChangedEvent() removed ordinals mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
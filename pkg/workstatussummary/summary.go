@@ -0,0 +1,236 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package workstatussummary serves the counts of the applied, available and drifted Works per member namespace, so
+// that the operators of large fleets do not need to list thousands of Works to get an overview of their status.
+package workstatussummary
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/condition"
+)
+
+const (
+	// KindWorkStatusSummary is the kind of the summary of the works in a namespace.
+	KindWorkStatusSummary = "WorkStatusSummary"
+	// KindWorkStatusSummaryList is the kind of the list of the summaries.
+	KindWorkStatusSummaryList = "WorkStatusSummaryList"
+
+	shutdownTimeout = 5 * time.Second
+)
+
+var (
+	// ListPathPattern is the pattern of the path the summaries of all the selected namespaces are served at.
+	ListPathPattern = fmt.Sprintf("GET /apis/%s/%s/workstatussummaries",
+		fleetv1beta1.GroupVersion.Group, fleetv1beta1.GroupVersion.Version)
+	// GetPathPattern is the pattern of the path the summary of a single namespace is served at.
+	GetPathPattern = fmt.Sprintf("GET /apis/%s/%s/namespaces/{namespace}/workstatussummary",
+		fleetv1beta1.GroupVersion.Group, fleetv1beta1.GroupVersion.Version)
+)
+
+// WorkStatusSummary is the counts of the works in a namespace by their status. It is computed on request and never
+// stored.
+type WorkStatusSummary struct {
+	metav1.TypeMeta `json:",inline"`
+	// Namespace is the namespace of the works.
+	Namespace string `json:"namespace"`
+	// Total is the number of the works in the namespace.
+	Total int `json:"total"`
+	// Applied is the number of the works whose current generation is applied.
+	Applied int `json:"applied"`
+	// Available is the number of the works whose current generation is available.
+	Available int `json:"available"`
+	// Drifted is the number of the works whose latest event is a drift found in the member cluster.
+	Drifted int `json:"drifted"`
+}
+
+// WorkStatusSummaryList is the summaries of the works of the selected namespaces, sorted by namespace.
+type WorkStatusSummaryList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Items are the summaries of the namespaces which have works.
+	Items []WorkStatusSummary `json:"items"`
+}
+
+// Server serves the summaries of the work statuses.
+type Server struct {
+	// Addr is the TCP address the server listens on.
+	Addr string
+	// Reader reads the works and the namespaces; it is expected to be backed by the informer cache so that the
+	// summaries are computed in memory.
+	Reader client.Reader
+	// NamespaceSelector selects the namespaces which are summarized; all the namespaces are summarized if it is nil.
+	NamespaceSelector labels.Selector
+}
+
+// NeedLeaderElection implements the LeaderElectionRunnable interface so that every replica serves the summaries.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves the summaries until the context is cancelled.
+func (s *Server) Start(ctx context.Context) error {
+	server := &http.Server{Addr: s.Addr, Handler: s.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			klog.ErrorS(err, "Failed to shut down the work status summary server")
+		}
+	}()
+	klog.InfoS("Starting the work status summary server", "address", s.Addr, "namespaceSelector", s.NamespaceSelector)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve the work status summaries: %w", err)
+	}
+	return nil
+}
+
+// Handler returns the handler of the summary requests.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(ListPathPattern, s.serveList)
+	mux.HandleFunc(GetPathPattern, s.serveGet)
+	return mux
+}
+
+// serveList responds with the summaries of all the selected namespaces which have works.
+func (s *Server) serveList(w http.ResponseWriter, req *http.Request) {
+	summaries, err := s.summarize(req.Context())
+	if err != nil {
+		klog.ErrorS(err, "Failed to summarize the work statuses")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	list := WorkStatusSummaryList{
+		TypeMeta: metav1.TypeMeta{APIVersion: fleetv1beta1.GroupVersion.String(), Kind: KindWorkStatusSummaryList},
+		Items:    summaries,
+	}
+	writeJSON(w, &list)
+}
+
+// serveGet responds with the summary of a single namespace; a namespace without works has an empty summary.
+func (s *Server) serveGet(w http.ResponseWriter, req *http.Request) {
+	namespace := req.PathValue("namespace")
+	selected, err := s.isSelected(req.Context(), namespace)
+	if err != nil {
+		klog.ErrorS(err, "Failed to get the namespace to summarize", "namespace", namespace)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !selected {
+		http.Error(w, fmt.Sprintf("the namespace %s is not summarized", namespace), http.StatusNotFound)
+		return
+	}
+	var works fleetv1beta1.WorkList
+	if err := s.Reader.List(req.Context(), &works, client.InNamespace(namespace)); err != nil {
+		klog.ErrorS(err, "Failed to list the works to summarize", "namespace", namespace)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	summary := newSummary(namespace)
+	for i := range works.Items {
+		summary.add(&works.Items[i])
+	}
+	writeJSON(w, summary)
+}
+
+// summarize returns the summaries of the selected namespaces which have works, sorted by namespace.
+func (s *Server) summarize(ctx context.Context) ([]WorkStatusSummary, error) {
+	var works []fleetv1beta1.Work
+	if s.NamespaceSelector == nil || s.NamespaceSelector.Empty() {
+		var workList fleetv1beta1.WorkList
+		if err := s.Reader.List(ctx, &workList); err != nil {
+			return nil, err
+		}
+		works = workList.Items
+	} else {
+		// the works are listed per namespace as the cache indexes them by namespace.
+		var namespaces corev1.NamespaceList
+		if err := s.Reader.List(ctx, &namespaces, client.MatchingLabelsSelector{Selector: s.NamespaceSelector}); err != nil {
+			return nil, err
+		}
+		for _, ns := range namespaces.Items {
+			var workList fleetv1beta1.WorkList
+			if err := s.Reader.List(ctx, &workList, client.InNamespace(ns.Name)); err != nil {
+				return nil, err
+			}
+			works = append(works, workList.Items...)
+		}
+	}
+
+	byNamespace := make(map[string]*WorkStatusSummary)
+	for i := range works {
+		summary, ok := byNamespace[works[i].Namespace]
+		if !ok {
+			summary = newSummary(works[i].Namespace)
+			byNamespace[works[i].Namespace] = summary
+		}
+		summary.add(&works[i])
+	}
+	summaries := make([]WorkStatusSummary, 0, len(byNamespace))
+	for _, summary := range byNamespace {
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Namespace < summaries[j].Namespace
+	})
+	return summaries, nil
+}
+
+// isSelected returns whether the namespace is selected by the namespace selector.
+func (s *Server) isSelected(ctx context.Context, namespace string) (bool, error) {
+	if s.NamespaceSelector == nil || s.NamespaceSelector.Empty() {
+		return true, nil
+	}
+	var ns corev1.Namespace
+	if err := s.Reader.Get(ctx, client.ObjectKey{Name: namespace}, &ns); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return s.NamespaceSelector.Matches(labels.Set(ns.Labels)), nil
+}
+
+func newSummary(namespace string) *WorkStatusSummary {
+	return &WorkStatusSummary{
+		TypeMeta:  metav1.TypeMeta{APIVersion: fleetv1beta1.GroupVersion.String(), Kind: KindWorkStatusSummary},
+		Namespace: namespace,
+	}
+}
+
+// add counts the work in the summary. The conditions observed on a previous generation of the work are not counted
+// as the member agent has not reported on the current spec yet.
+func (summary *WorkStatusSummary) add(work *fleetv1beta1.Work) {
+	summary.Total++
+	if condition.IsConditionStatusTrue(meta.FindStatusCondition(work.Status.Conditions, fleetv1beta1.WorkConditionTypeApplied), work.Generation) {
+		summary.Applied++
+	}
+	if condition.IsConditionStatusTrue(meta.FindStatusCondition(work.Status.Conditions, fleetv1beta1.WorkConditionTypeAvailable), work.Generation) {
+		summary.Available++
+	}
+	if events := work.Status.RecentEvents; len(events) > 0 && events[len(events)-1].Type == fleetv1beta1.WorkEventTypeDriftFound {
+		summary.Drifted++
+	}
+}
+
+func writeJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		klog.ErrorS(err, "Failed to write the work status summary")
+	}
+}
@@ -0,0 +1,208 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package workstatussummary
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// summarizedWork returns a work at generation 2 with the given conditions observed on the given generation and the
+// given latest event.
+func summarizedWork(namespace, name string, observedGeneration int64, applied, available metav1.ConditionStatus, latestEvent string) *fleetv1beta1.Work {
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Generation: 2},
+	}
+	for conditionType, status := range map[string]metav1.ConditionStatus{
+		fleetv1beta1.WorkConditionTypeApplied:   applied,
+		fleetv1beta1.WorkConditionTypeAvailable: available,
+	} {
+		if status != "" {
+			work.Status.Conditions = append(work.Status.Conditions, metav1.Condition{
+				Type:               conditionType,
+				Status:             status,
+				Reason:             "test",
+				ObservedGeneration: observedGeneration,
+			})
+		}
+	}
+	if latestEvent != "" {
+		work.Status.RecentEvents = []fleetv1beta1.WorkEvent{
+			{Type: fleetv1beta1.WorkEventTypeDriftFound},
+			{Type: latestEvent},
+		}
+	}
+	return work
+}
+
+func TestServeSummaries(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the core scheme: %v", err)
+	}
+	works := []client.Object{
+		summarizedWork("fleet-member-a", "applied", 2, metav1.ConditionTrue, metav1.ConditionFalse, fleetv1beta1.WorkEventTypeManifestApplied),
+		summarizedWork("fleet-member-a", "available", 2, metav1.ConditionTrue, metav1.ConditionTrue, ""),
+		summarizedWork("fleet-member-a", "drifted", 2, metav1.ConditionTrue, metav1.ConditionTrue, fleetv1beta1.WorkEventTypeDriftFound),
+		summarizedWork("fleet-member-a", "outdated", 1, metav1.ConditionTrue, metav1.ConditionTrue, ""),
+		summarizedWork("fleet-member-b", "failed", 2, metav1.ConditionFalse, "", fleetv1beta1.WorkEventTypeError),
+		summarizedWork("fleet-member-b", "pending", 0, "", "", ""),
+		summarizedWork("fleet-member-c", "available", 2, metav1.ConditionTrue, metav1.ConditionTrue, ""),
+	}
+	namespaces := []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "fleet-member-a", Labels: map[string]string{"env": "prod"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "fleet-member-b", Labels: map[string]string{"env": "prod"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "fleet-member-c", Labels: map[string]string{"env": "test"}}},
+	}
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(works, namespaces...)...).Build()
+
+	summaryA := WorkStatusSummary{Namespace: "fleet-member-a", Total: 4, Applied: 3, Available: 2, Drifted: 1}
+	summaryB := WorkStatusSummary{Namespace: "fleet-member-b", Total: 2}
+	summaryC := WorkStatusSummary{Namespace: "fleet-member-c", Total: 1, Applied: 1, Available: 1}
+	for _, summary := range []*WorkStatusSummary{&summaryA, &summaryB, &summaryC} {
+		summary.TypeMeta = metav1.TypeMeta{APIVersion: fleetv1beta1.GroupVersion.String(), Kind: KindWorkStatusSummary}
+	}
+	prodSelector := labels.SelectorFromSet(labels.Set{"env": "prod"})
+
+	tests := map[string]struct {
+		selector labels.Selector
+		path     string
+		wantCode int
+		wantBody interface{}
+	}{
+		"all the namespaces": {
+			path:     "/apis/placement.kubernetes-fleet.io/v1beta1/workstatussummaries",
+			wantCode: http.StatusOK,
+			wantBody: &WorkStatusSummaryList{
+				TypeMeta: metav1.TypeMeta{APIVersion: fleetv1beta1.GroupVersion.String(), Kind: KindWorkStatusSummaryList},
+				Items:    []WorkStatusSummary{summaryA, summaryB, summaryC},
+			},
+		},
+		"the selected namespaces": {
+			selector: prodSelector,
+			path:     "/apis/placement.kubernetes-fleet.io/v1beta1/workstatussummaries",
+			wantCode: http.StatusOK,
+			wantBody: &WorkStatusSummaryList{
+				TypeMeta: metav1.TypeMeta{APIVersion: fleetv1beta1.GroupVersion.String(), Kind: KindWorkStatusSummaryList},
+				Items:    []WorkStatusSummary{summaryA, summaryB},
+			},
+		},
+		"a single namespace": {
+			selector: prodSelector,
+			path:     "/apis/placement.kubernetes-fleet.io/v1beta1/namespaces/fleet-member-a/workstatussummary",
+			wantCode: http.StatusOK,
+			wantBody: &summaryA,
+		},
+		"a namespace without works": {
+			path:     "/apis/placement.kubernetes-fleet.io/v1beta1/namespaces/fleet-member-d/workstatussummary",
+			wantCode: http.StatusOK,
+			wantBody: &WorkStatusSummary{
+				TypeMeta:  metav1.TypeMeta{APIVersion: fleetv1beta1.GroupVersion.String(), Kind: KindWorkStatusSummary},
+				Namespace: "fleet-member-d",
+			},
+		},
+		"a namespace which is not selected": {
+			selector: prodSelector,
+			path:     "/apis/placement.kubernetes-fleet.io/v1beta1/namespaces/fleet-member-c/workstatussummary",
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := &Server{Reader: hubClient, NamespaceSelector: tt.selector}
+			recorder := httptest.NewRecorder()
+			s.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if recorder.Code != tt.wantCode {
+				t.Fatalf("GET %s = %d, want %d: %s", tt.path, recorder.Code, tt.wantCode, recorder.Body.String())
+			}
+			if tt.wantBody == nil {
+				return
+			}
+			var got interface{}
+			switch tt.wantBody.(type) {
+			case *WorkStatusSummaryList:
+				got = &WorkStatusSummaryList{}
+			default:
+				got = &WorkStatusSummary{}
+			}
+			if err := json.Unmarshal(recorder.Body.Bytes(), got); err != nil {
+				t.Fatalf("failed to unmarshal the response: %v", err)
+			}
+			if diff := cmp.Diff(tt.wantBody, got); diff != "" {
+				t.Errorf("GET %s mismatch (-want +got):\n%s", tt.path, diff)
+			}
+		})
+	}
+}
+
+// TestSummaryConsistentWithWorks checks that the summaries count every work the same way its own status reads.
+func TestSummaryConsistentWithWorks(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add the placement scheme: %v", err)
+	}
+	statuses := []metav1.ConditionStatus{"", metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionUnknown}
+	events := []string{"", fleetv1beta1.WorkEventTypeManifestApplied, fleetv1beta1.WorkEventTypeDriftFound}
+	var works []client.Object
+	want := map[string]*WorkStatusSummary{}
+	for i := 0; i < 200; i++ {
+		namespace := fmt.Sprintf("fleet-member-%d", i%7)
+		observedGeneration := int64(1 + i%2)
+		applied, available, event := statuses[i%4], statuses[(i/4)%4], events[(i/3)%3]
+		works = append(works, summarizedWork(namespace, fmt.Sprintf("work-%d", i), observedGeneration, applied, available, event))
+
+		summary, ok := want[namespace]
+		if !ok {
+			summary = newSummary(namespace)
+			want[namespace] = summary
+		}
+		summary.Total++
+		if applied == metav1.ConditionTrue && observedGeneration == 2 {
+			summary.Applied++
+		}
+		if available == metav1.ConditionTrue && observedGeneration == 2 {
+			summary.Available++
+		}
+		if event == fleetv1beta1.WorkEventTypeDriftFound {
+			summary.Drifted++
+		}
+	}
+	s := &Server{Reader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(works...).Build()}
+
+	recorder := httptest.NewRecorder()
+	s.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/apis/placement.kubernetes-fleet.io/v1beta1/workstatussummaries", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("GET summaries = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+	var got WorkStatusSummaryList
+	if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil {
+		t.Fatalf("failed to unmarshal the response: %v", err)
+	}
+	if len(got.Items) != len(want) {
+		t.Fatalf("summaries = %d, want %d", len(got.Items), len(want))
+	}
+	for _, summary := range got.Items {
+		if diff := cmp.Diff(want[summary.Namespace], &summary); diff != "" {
+			t.Errorf("summary of %s mismatch (-want +got):\n%s", summary.Namespace, diff)
+		}
+	}
+}